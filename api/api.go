@@ -32,6 +32,7 @@ import (
 	cfstore "github.com/micro/go-micro/v2/store/cloudflare"
 	"github.com/micro/go-micro/v2/sync/lock/memory"
 	"github.com/micro/go-micro/v2/util/log"
+	"github.com/micro/micro/v2/internal/deadline"
 	"github.com/micro/micro/v2/internal/handler"
 	"github.com/micro/micro/v2/internal/helper"
 	"github.com/micro/micro/v2/internal/stats"
@@ -185,6 +186,9 @@ func run(ctx *cli.Context, srvOpts ...micro.Option) {
 		srvOpts = append(srvOpts, micro.RegisterInterval(i*time.Second))
 	}
 
+	// pass the deadline budget on to services
+	srvOpts = append(srvOpts, micro.WrapClient(deadline.NewClientWrapper()))
+
 	// initialise service
 	service := micro.NewService(srvOpts...)
 
@@ -299,6 +303,9 @@ func run(ctx *cli.Context, srvOpts ...micro.Option) {
 		h = plugins[i-1].Handler()(h)
 	}
 
+	// reject requests whose deadline budget is exhausted
+	h = deadline.NewHandler(Name, h)
+
 	// create the server
 	api := httpapi.NewServer(Address)
 	api.Init(opts...)
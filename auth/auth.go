@@ -0,0 +1,79 @@
+// Package auth exchanges the identity of services for scoped tokens
+package auth
+
+import (
+	"github.com/micro/cli/v2"
+	"github.com/micro/go-micro/v2"
+	"github.com/micro/go-micro/v2/util/log"
+	"github.com/micro/micro/v2/auth/handler"
+	pb "github.com/micro/micro/v2/auth/proto"
+	"github.com/micro/micro/v2/auth/rules"
+)
+
+var (
+	// Name of the auth service
+	Name = "go.micro.auth"
+	// Address of the auth service
+	Address = ":8010"
+)
+
+func run(ctx *cli.Context, srvOpts ...micro.Option) {
+	log.Name("auth")
+
+	if len(ctx.String("server_name")) > 0 {
+		Name = ctx.String("server_name")
+	}
+	if len(ctx.String("address")) > 0 {
+		Address = ctx.String("address")
+	}
+	if ttl := ctx.Duration("token_ttl"); ttl > 0 {
+		handler.TokenTTL = ttl
+	}
+
+	acl, err := rules.ParseAll(ctx.String("rules"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	srvOpts = append(srvOpts, micro.Name(Name), micro.Address(Address))
+
+	service := micro.NewService(srvOpts...)
+
+	pb.RegisterIdentityHandler(service.Server(), new(handler.Identity))
+	pb.RegisterRulesHandler(service.Server(), &handler.Rules{Rules: acl})
+
+	if err := service.Run(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// Commands is the cli interface for the auth service
+func Commands(options ...micro.Option) []*cli.Command {
+	command := &cli.Command{
+		Name:  "auth",
+		Usage: "Run the auth service to exchange service identities for scoped tokens",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "address",
+				Usage:   "Set the auth service address e.g 0.0.0.0:8010",
+				EnvVars: []string{"MICRO_SERVER_ADDRESS"},
+			},
+			&cli.DurationFlag{
+				Name:    "token_ttl",
+				Usage:   "Set how long exchanged tokens are valid for e.g 1h",
+				EnvVars: []string{"MICRO_AUTH_TOKEN_TTL"},
+			},
+			&cli.StringFlag{
+				Name:    "rules",
+				Usage:   "Semicolon separated access rules for topics, the first matching rule applies e.g \"allow * go.micro.runtime.* platform; deny publish go.micro.runtime.*\"",
+				EnvVars: []string{"MICRO_AUTH_RULES"},
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			run(ctx, options...)
+			return nil
+		},
+	}
+
+	return []*cli.Command{command}
+}
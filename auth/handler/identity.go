@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/util/log"
+	pb "github.com/micro/micro/v2/auth/proto"
+	"github.com/micro/micro/v2/internal/identity"
+	"github.com/micro/micro/v2/internal/namespace"
+	"github.com/micro/micro/v2/internal/token"
+)
+
+var (
+	// TokenTTL is how long exchanged tokens are valid for
+	TokenTTL = time.Hour
+)
+
+// Identity exchanges identity documents for scoped tokens
+type Identity struct{}
+
+func (i *Identity) Exchange(ctx context.Context, req *pb.ExchangeRequest, rsp *pb.ExchangeResponse) error {
+	if len(req.Identity) == 0 {
+		return errors.BadRequest("go.micro.auth.Exchange", "identity required")
+	}
+
+	if len(namespace.Key) == 0 {
+		return errors.InternalServerError("go.micro.auth.Exchange", "MICRO_NAMESPACE_KEY is not set")
+	}
+
+	doc, err := identity.Verify(req.Identity)
+	if err != nil {
+		return errors.Unauthorized("go.micro.auth.Exchange", err.Error())
+	}
+
+	// services outside a namespace are scoped to themselves
+	ns := doc.Namespace
+	if len(ns) == 0 {
+		ns = doc.Service
+	}
+	// services are never granted every namespace
+	if ns == namespace.All {
+		return errors.Forbidden("go.micro.auth.Exchange", "identity of %s can't be scoped to every namespace", doc.Service)
+	}
+
+	tk := token.New()
+	tk.Expires = uint64(time.Now().Add(TokenTTL).Unix())
+	tk.Claims["namespace"] = ns
+	tk.Claims["service"] = doc.Service
+	tk.Claims["version"] = doc.Version
+
+	str, err := tk.Encode(namespace.Key)
+	if err != nil {
+		return errors.InternalServerError("go.micro.auth.Exchange", err.Error())
+	}
+
+	log.Logf("Exchanged identity of %s %s revision %d for a token scoped to %s", doc.Service, doc.Version, doc.Revision, ns)
+
+	rsp.Token = str
+	rsp.Expires = int64(tk.Expires)
+	rsp.Namespace = ns
+
+	return nil
+}
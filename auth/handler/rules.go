@@ -0,0 +1,25 @@
+package handler
+
+import (
+	"context"
+
+	pb "github.com/micro/micro/v2/auth/proto"
+	"github.com/micro/micro/v2/auth/rules"
+)
+
+// Rules serves the access rules to the platform services which enforce them
+type Rules struct {
+	Rules []*rules.Rule
+}
+
+func (r *Rules) List(ctx context.Context, req *pb.ListRulesRequest, rsp *pb.ListRulesResponse) error {
+	for _, rule := range r.Rules {
+		rsp.Rules = append(rsp.Rules, &pb.Rule{
+			Access:  rule.Access,
+			Action:  rule.Action,
+			Topic:   rule.Topic,
+			Account: rule.Account,
+		})
+	}
+	return nil
+}
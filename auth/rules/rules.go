@@ -0,0 +1,114 @@
+// Package rules are the access rules for topics enforced by the platform
+// services e.g which namespaces may publish or subscribe to a topic
+package rules
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+const (
+	// Allow access
+	Allow = "allow"
+	// Deny access
+	Deny = "deny"
+
+	// Publish to a topic
+	Publish = "publish"
+	// Subscribe to a topic
+	Subscribe = "subscribe"
+	// Any action
+	Any = "*"
+)
+
+// Rule grants or denies an account access to topics
+type Rule struct {
+	// Access is allow or deny
+	Access string
+	// Action is publish, subscribe or *
+	Action string
+	// Topic pattern e.g go.micro.runtime.*
+	Topic string
+	// Account pattern of the namespaces the rule applies to e.g team-*
+	Account string
+}
+
+// String returns the rule as parsed e.g deny publish go.micro.runtime.* *
+func (r *Rule) String() string {
+	return strings.Join([]string{r.Access, r.Action, r.Topic, r.Account}, " ")
+}
+
+// Parse a rule e.g "deny publish go.micro.runtime.*" or "allow subscribe team-a.* team-a".
+// The account defaults to * so the rule applies to every namespace.
+func Parse(spec string) (*Rule, error) {
+	fields := strings.Fields(spec)
+	if len(fields) < 3 || len(fields) > 4 {
+		return nil, fmt.Errorf("invalid rule %q, expected <allow|deny> <publish|subscribe|*> <topic> [account]", spec)
+	}
+
+	r := &Rule{
+		Access:  fields[0],
+		Action:  fields[1],
+		Topic:   fields[2],
+		Account: "*",
+	}
+	if len(fields) == 4 {
+		r.Account = fields[3]
+	}
+
+	if r.Access != Allow && r.Access != Deny {
+		return nil, fmt.Errorf("invalid access %s in rule %q, expected allow or deny", r.Access, spec)
+	}
+
+	switch r.Action {
+	case Publish, Subscribe, Any:
+	default:
+		return nil, fmt.Errorf("invalid action %s in rule %q, expected publish, subscribe or *", r.Action, spec)
+	}
+
+	// check the patterns are valid
+	for _, p := range []string{r.Topic, r.Account} {
+		if _, err := path.Match(p, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %s in rule %q", p, spec)
+		}
+	}
+
+	return r, nil
+}
+
+// ParseAll parses the rules separated by semicolons
+func ParseAll(specs string) ([]*Rule, error) {
+	var rules []*Rule
+	for _, spec := range strings.Split(specs, ";") {
+		if len(strings.TrimSpace(spec)) == 0 {
+			continue
+		}
+		r, err := Parse(spec)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, r)
+	}
+	return rules, nil
+}
+
+func match(pattern, value string) bool {
+	ok, _ := path.Match(pattern, value)
+	return ok
+}
+
+// Allowed returns true if the account may perform the action on the topic.
+// The first rule which matches applies and access is allowed if none do.
+func Allowed(rules []*Rule, account, action, topic string) bool {
+	for _, r := range rules {
+		if r.Action != Any && r.Action != action {
+			continue
+		}
+		if !match(r.Topic, topic) || !match(r.Account, account) {
+			continue
+		}
+		return r.Access == Allow
+	}
+	return true
+}
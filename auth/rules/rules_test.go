@@ -0,0 +1,53 @@
+package rules
+
+import (
+	"testing"
+)
+
+func TestAllowed(t *testing.T) {
+	rules, err := ParseAll("allow * go.micro.runtime.* platform; deny publish go.micro.runtime.*; allow subscribe team-a.* team-a; deny * team-a.*")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testData := []struct {
+		account string
+		action  string
+		topic   string
+		allowed bool
+	}{
+		{"platform", Publish, "go.micro.runtime.events", true},
+		{"team-a", Publish, "go.micro.runtime.events", false},
+		{"team-a", Subscribe, "go.micro.runtime.events", true},
+		{"team-a", Subscribe, "team-a.orders", true},
+		{"team-b", Subscribe, "team-a.orders", false},
+		{"team-b", Publish, "team-b.orders", true},
+	}
+
+	for _, d := range testData {
+		if got := Allowed(rules, d.account, d.action, d.topic); got != d.allowed {
+			t.Errorf("%s %s %s: expected %v got %v", d.account, d.action, d.topic, d.allowed, got)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	for _, spec := range []string{
+		"deny publish",
+		"block publish foo",
+		"allow read foo",
+		"allow publish foo[ bar",
+	} {
+		if _, err := Parse(spec); err == nil {
+			t.Errorf("Expected an error parsing %q", spec)
+		}
+	}
+
+	r, err := Parse("deny publish go.micro.runtime.*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.String() != "deny publish go.micro.runtime.* *" {
+		t.Fatalf("Unexpected rule %s", r)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	pb "github.com/micro/go-micro/v2/broker/service/proto"
 	"github.com/micro/go-micro/v2/util/log"
 	"github.com/micro/micro/v2/broker/handler"
+	"github.com/micro/micro/v2/internal/namespace"
 )
 
 var (
@@ -21,6 +22,11 @@ var (
 func run(ctx *cli.Context, srvOpts ...micro.Option) {
 	log.Name("broker")
 
+	// callers can't be identified without the namespace key
+	if ctx.Bool("acl") && len(namespace.Key) == 0 {
+		log.Fatal("MICRO_NAMESPACE_KEY must be set to enforce the acl")
+	}
+
 	if len(ctx.String("server_name")) > 0 {
 		Name = ctx.String("server_name")
 	}
@@ -50,11 +56,22 @@ func run(ctx *cli.Context, srvOpts ...micro.Option) {
 	// new service
 	service := micro.NewService(srvOpts...)
 
-	// register the broker handler
-	pb.RegisterBrokerHandler(service.Server(), &handler.Broker{
+	brokerHandler := &handler.Broker{
 		// using the mdns broker
 		Broker: service.Options().Broker,
-	})
+	}
+
+	// enforce the topic rules of the auth service
+	if ctx.Bool("acl") {
+		done := make(chan bool)
+		defer close(done)
+
+		brokerHandler.ACL = handler.NewACL(service.Client())
+		brokerHandler.ACL.Start(done)
+	}
+
+	// register the broker handler
+	pb.RegisterBrokerHandler(service.Server(), brokerHandler)
 
 	// run the service
 	service.Run()
@@ -70,6 +87,11 @@ func Commands(options ...micro.Option) []*cli.Command {
 				Usage:   "Set the broker http address e.g 0.0.0.0:8001",
 				EnvVars: []string{"MICRO_SERVER_ADDRESS"},
 			},
+			&cli.BoolFlag{
+				Name:    "acl",
+				Usage:   "Enforce the topic rules of the auth service on publishers and subscribers, requires MICRO_NAMESPACE_KEY",
+				EnvVars: []string{"MICRO_BROKER_ACL"},
+			},
 		},
 		Action: func(ctx *cli.Context) error {
 			run(ctx, options...)
@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/micro/go-micro/v2/client"
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/util/log"
+	pb "github.com/micro/micro/v2/auth/proto"
+	"github.com/micro/micro/v2/auth/rules"
+	"github.com/micro/micro/v2/internal/namespace"
+)
+
+var (
+	// AuthName is the name of the auth service the rules are read from
+	AuthName = "go.micro.auth"
+	// RefreshInterval is how often the rules are refreshed
+	RefreshInterval = time.Second * 30
+)
+
+// ACL enforces the topic rules of the auth service
+type ACL struct {
+	client pb.RulesService
+
+	sync.RWMutex
+	rules  []*rules.Rule
+	loaded bool
+}
+
+// NewACL returns an ACL which reads the rules from the auth service
+func NewACL(c client.Client) *ACL {
+	return &ACL{
+		client: pb.NewRulesService(AuthName, c),
+	}
+}
+
+// refresh reads the rules from the auth service
+func (a *ACL) refresh() error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	rsp, err := a.client.List(ctx, &pb.ListRulesRequest{})
+	if err != nil {
+		return err
+	}
+
+	var acl []*rules.Rule
+	for _, r := range rsp.Rules {
+		acl = append(acl, &rules.Rule{
+			Access:  r.Access,
+			Action:  r.Action,
+			Topic:   r.Topic,
+			Account: r.Account,
+		})
+	}
+
+	a.Lock()
+	a.rules = acl
+	a.loaded = true
+	a.Unlock()
+
+	return nil
+}
+
+// Start refreshing the rules until the channel is closed
+func (a *ACL) Start(done <-chan bool) {
+	if err := a.refresh(); err != nil {
+		log.Logf("Failed to read the rules from %s: %v", AuthName, err)
+	}
+
+	go func() {
+		t := time.NewTicker(RefreshInterval)
+		defer t.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-t.C:
+				// keep the last rules if the auth service is unavailable
+				if err := a.refresh(); err != nil {
+					log.Logf("Failed to refresh the rules from %s: %v", AuthName, err)
+				}
+			}
+		}
+	}()
+}
+
+// Check returns an error if the caller may not perform the action on the topic.
+// Callers are identified by the namespace token in the Authorization metadata.
+func (a *ACL) Check(ctx context.Context, action, topic string) error {
+	ns, err := namespace.FromContext(ctx)
+	if err != nil {
+		return errors.Unauthorized("go.micro.broker", err.Error())
+	}
+
+	// unscoped callers have access to everything
+	if ns == namespace.All {
+		return nil
+	}
+
+	a.RLock()
+	defer a.RUnlock()
+
+	// fail closed until the rules are known
+	if !a.loaded {
+		return errors.New("go.micro.broker", "access rules not loaded", 503)
+	}
+
+	if !rules.Allowed(a.rules, ns, action, topic) {
+		return errors.Forbidden("go.micro.broker", "namespace %s may not %s to %s", ns, action, topic)
+	}
+
+	return nil
+}
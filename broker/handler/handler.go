@@ -7,13 +7,22 @@ import (
 	pb "github.com/micro/go-micro/v2/broker/service/proto"
 	"github.com/micro/go-micro/v2/errors"
 	"github.com/micro/go-micro/v2/util/log"
+	"github.com/micro/micro/v2/auth/rules"
 )
 
 type Broker struct {
 	Broker broker.Broker
+	// ACL enforces the topic rules if set
+	ACL *ACL
 }
 
 func (b *Broker) Publish(ctx context.Context, req *pb.PublishRequest, rsp *pb.Empty) error {
+	if b.ACL != nil {
+		if err := b.ACL.Check(ctx, rules.Publish, req.Topic); err != nil {
+			return err
+		}
+	}
+
 	log.Debugf("Publishing message to %s topic", req.Topic)
 	err := b.Broker.Publish(req.Topic, &broker.Message{
 		Header: req.Message.Header,
@@ -27,6 +36,12 @@ func (b *Broker) Publish(ctx context.Context, req *pb.PublishRequest, rsp *pb.Em
 }
 
 func (b *Broker) Subscribe(ctx context.Context, req *pb.SubscribeRequest, stream pb.Broker_SubscribeStream) error {
+	if b.ACL != nil {
+		if err := b.ACL.Check(ctx, rules.Subscribe, req.Topic); err != nil {
+			return err
+		}
+	}
+
 	errChan := make(chan error, 1)
 
 	// message handler to stream back messages from broker
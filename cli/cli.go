@@ -2,12 +2,19 @@
 package cli
 
 import (
+	"bufio"
 	"fmt"
 	"os"
+	"runtime/debug"
 	"strings"
+	"time"
 
 	"github.com/chzyer/readline"
 	"github.com/micro/cli/v2"
+	mdebug "github.com/micro/micro/v2/debug"
+	"github.com/micro/micro/v2/internal/bulk"
+	clic "github.com/micro/micro/v2/internal/command/cli"
+	"github.com/micro/micro/v2/internal/redact"
 )
 
 var (
@@ -20,7 +27,6 @@ var (
 		"list":       &command{"list", "List services, peers or routes", list},
 		"get":        &command{"get", "Get service info", getService},
 		"services":   &command{"services", "List services in the network", netServices},
-		"stream":     &command{"stream", "Stream a call to a service", streamService},
 		"publish":    &command{"publish", "Publish a message to a topic", publish},
 		"health":     &command{"health", "Get service health", queryHealth},
 		"stats":      &command{"stats", "Get service stats", queryStats},
@@ -35,6 +41,36 @@ type command struct {
 	exec  exec
 }
 
+// recovered formats the panic of a command as an error
+// including the stack when the cli is run with --debug
+func recovered(c *cli.Context, name string, r interface{}) error {
+	if c.Bool("debug") {
+		return fmt.Errorf("%s failed: %v\n%s", name, r, debug.Stack())
+	}
+	return fmt.Errorf("%s failed: %v", name, r)
+}
+
+// safeExec runs the command recovering from any panic so
+// a failing command doesn't end the interactive session
+func safeExec(c *cli.Context, cmd *command, args []string, w *bufio.Writer) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = recovered(c, cmd.name, r)
+		}
+	}()
+	return cmd.exec(c, args, w)
+}
+
+// safeStream runs the stream recovering from any panic
+func safeStream(c *cli.Context, name string, s *streamCommand, args []string) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = recovered(c, name, r)
+		}
+	}()
+	return runStream(c, s, args)
+}
+
 func runc(c *cli.Context) error {
 	commands["help"] = &command{"help", "CLI usage", help}
 	alias := map[string]string{
@@ -52,6 +88,10 @@ func runc(c *cli.Context) error {
 
 	for {
 		args, err := r.Readline()
+		// Ctrl-C clears the line rather than exiting
+		if err == readline.ErrInterrupt {
+			continue
+		}
 		if err != nil {
 			fmt.Fprint(os.Stdout, err)
 			return err
@@ -77,13 +117,19 @@ func runc(c *cli.Context) error {
 		}
 
 		if cmd, ok := commands[name]; ok {
-			rsp, err := cmd.exec(c, parts[1:])
+			rw := redact.NewWriter(os.Stdout)
+			w := clic.NewWriter(rw)
+			err := safeExec(c, cmd, parts[1:], w)
+			w.Flush()
+			rw.Flush()
 			if err != nil {
 				// TODO return err
 				println(err.Error())
-				continue
 			}
-			println(string(rsp))
+		} else if s, ok := streams[name]; ok {
+			if err := safeStream(c, name, s, parts[1:]); err != nil {
+				println(err.Error())
+			}
 		} else {
 			// TODO return err
 			println("unknown command")
@@ -158,8 +204,9 @@ func NetworkCommands() []*cli.Command {
 					EnvVars: []string{"MICRO_ADDRESS"},
 				},
 				&cli.StringFlag{
-					Name:    "output, o",
-					Usage:   "Set the output format; json (default), raw",
+					Name:    "output",
+					Aliases: []string{"o"},
+					Usage:   "Set the output format; json (default), raw or a format added by a plugin",
 					EnvVars: []string{"MICRO_OUTPUT"},
 				},
 				&cli.StringSliceFlag{
@@ -167,6 +214,15 @@ func NetworkCommands() []*cli.Command {
 					Usage:   "A list of key-value pairs to be forwarded as metadata",
 					EnvVars: []string{"MICRO_METADATA"},
 				},
+				&cli.BoolFlag{
+					Name:  "all-nodes",
+					Usage: "Call every node of the service concurrently and return the responses keyed by node id",
+				},
+				&cli.IntFlag{
+					Name:  "concurrency",
+					Usage: "Set how many nodes are called at once with --all-nodes",
+					Value: bulk.Concurrency,
+				},
 			},
 		},
 	}
@@ -194,6 +250,12 @@ func NetworkDNSCommands() []*cli.Command {
 					Usage:   "Bearer token for the go.micro.network.dns service",
 					EnvVars: []string{"MICRO_NETWORK_DNS_ADVERTISE_TOKEN"},
 				},
+				&cli.IntFlag{
+					Name:    "ttl",
+					Usage:   "TTL of the record in seconds, 1 is automatic",
+					EnvVars: []string{"MICRO_NETWORK_DNS_ADVERTISE_TTL"},
+					Value:   1,
+				},
 			},
 			Action: Print(netDNSAdvertise),
 		},
@@ -244,6 +306,24 @@ func NetworkDNSCommands() []*cli.Command {
 			},
 			Action: Print(netDNSResolve),
 		},
+		{
+			Name:  "list",
+			Usage: "List all records for a domain with their TTLs",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    "domain",
+					Usage:   "Domain name to list records for",
+					EnvVars: []string{"MICRO_NETWORK_DNS_LIST_DOMAIN"},
+					Value:   "network.micro.mu",
+				},
+				&cli.StringFlag{
+					Name:    "token",
+					Usage:   "Bearer token for the go.micro.network.dns service",
+					EnvVars: []string{"MICRO_NETWORK_DNS_LIST_TOKEN"},
+				},
+			},
+			Action: Print(netDNSList),
+		},
 	}
 }
 
@@ -276,8 +356,27 @@ func RegistryCommands() []*cli.Command {
 			Subcommands: []*cli.Command{
 				{
 					Name:   "service",
-					Usage:  "Register a service with JSON definition",
+					Usage:  "Register a service with JSON definition or --from-proto ./api.proto",
 					Action: Print(registerService),
+					Flags: []cli.Flag{
+						&cli.StringFlag{
+							Name:  "from-proto",
+							Usage: "Derive the service definition from a .proto file or FileDescriptorSet",
+						},
+						&cli.StringFlag{
+							Name:  "name",
+							Usage: "Set the name of the service, defaults to the proto package",
+						},
+						&cli.StringFlag{
+							Name:  "version",
+							Usage: "Set the version of the service",
+							Value: "latest",
+						},
+						&cli.StringFlag{
+							Name:  "address",
+							Usage: "Set the address of the node registered for the service",
+						},
+					},
 				},
 			},
 		},
@@ -292,6 +391,23 @@ func RegistryCommands() []*cli.Command {
 				},
 			},
 		},
+		{
+			Name:   "prune",
+			Usage:  "Deregister the nodes which fail their health check",
+			Action: Print(pruneServices),
+			Flags: []cli.Flag{
+				&cli.IntFlag{
+					Name:  "concurrency",
+					Usage: "Set how many nodes are checked at once",
+					Value: bulk.Concurrency,
+				},
+				&cli.DurationFlag{
+					Name:  "timeout",
+					Usage: "Set how long each node has to respond to its health check",
+					Value: 5 * time.Second,
+				},
+			},
+		},
 		{
 			Name:  "get",
 			Usage: "Get item from registry",
@@ -312,6 +428,12 @@ func Commands() []*cli.Command {
 			Name:   "cli",
 			Usage:  "Run the interactive CLI",
 			Action: runc,
+			Flags: []cli.Flag{
+				&cli.BoolFlag{
+					Name:  "debug",
+					Usage: "Print the stack of commands which fail unexpectedly",
+				},
+			},
 		},
 		{
 			Name:   "call",
@@ -324,8 +446,9 @@ func Commands() []*cli.Command {
 					EnvVars: []string{"MICRO_ADDRESS"},
 				},
 				&cli.StringFlag{
-					Name:    "output, o",
-					Usage:   "Set the output format; json (default), raw",
+					Name:    "output",
+					Aliases: []string{"o"},
+					Usage:   "Set the output format; json (default), raw or a format added by a plugin",
 					EnvVars: []string{"MICRO_OUTPUT"},
 				},
 				&cli.StringSliceFlag{
@@ -333,6 +456,15 @@ func Commands() []*cli.Command {
 					Usage:   "A list of key-value pairs to be forwarded as metadata",
 					EnvVars: []string{"MICRO_METADATA"},
 				},
+				&cli.BoolFlag{
+					Name:  "all-nodes",
+					Usage: "Call every node of the service concurrently and return the responses keyed by node id",
+				},
+				&cli.IntFlag{
+					Name:  "concurrency",
+					Usage: "Set how many nodes are called at once with --all-nodes",
+					Value: bulk.Concurrency,
+				},
 			},
 		},
 		{
@@ -343,11 +475,12 @@ func Commands() []*cli.Command {
 		{
 			Name:   "stream",
 			Usage:  "Create a service stream",
-			Action: Print(streamService),
+			Action: Print(background(streamService)),
 			Flags: []cli.Flag{
 				&cli.StringFlag{
-					Name:    "output, o",
-					Usage:   "Set the output format; json (default), raw",
+					Name:    "output",
+					Aliases: []string{"o"},
+					Usage:   "Set the output format; json (default), raw or a format added by a plugin",
 					EnvVars: []string{"MICRO_OUTPUT"},
 				},
 				&cli.StringSliceFlag{
@@ -370,9 +503,18 @@ func Commands() []*cli.Command {
 			},
 		},
 		{
-			Name:   "stats",
-			Usage:  "Query the stats of a service",
-			Action: Print(queryStats),
+			Name:  "stats",
+			Usage: "Query the stats of a service, or interactively display the stats of services with --top",
+			Flags: append(mdebug.TopFlags(), &cli.BoolFlag{
+				Name:  "top",
+				Usage: "Interactively display the stats of services sorted by memory, req/s or error rate",
+			}),
+			Action: func(c *cli.Context) error {
+				if c.Bool("top") {
+					return mdebug.Top(c)
+				}
+				return Print(queryStats)(c)
+			},
 		},
 	}
 
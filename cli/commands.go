@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"bufio"
 	"fmt"
 	"os"
 	"sort"
@@ -9,27 +10,33 @@ import (
 	"github.com/micro/cli/v2"
 )
 
-func quit(c *cli.Context, args []string) ([]byte, error) {
+func quit(c *cli.Context, args []string, w *bufio.Writer) error {
 	os.Exit(0)
-	return nil, nil
+	return nil
 }
 
-func help(c *cli.Context, args []string) ([]byte, error) {
-	w := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', 0)
+func help(c *cli.Context, args []string, bw *bufio.Writer) error {
+	w := tabwriter.NewWriter(bw, 0, 8, 1, '\t', 0)
 
-	fmt.Fprintln(os.Stdout, "Commands:")
+	fmt.Fprintln(bw, "Commands:")
+
+	usage := make(map[string]string)
+	for k, cmd := range commands {
+		usage[k] = cmd.usage
+	}
+	for k, cmd := range streams {
+		usage[k] = cmd.usage
+	}
 
 	var keys []string
-	for k, _ := range commands {
+	for k := range usage {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 
 	for _, k := range keys {
-		cmd := commands[k]
-		fmt.Fprintln(w, "\t", cmd.name, "\t\t", cmd.usage)
+		fmt.Fprintln(w, "\t", k, "\t\t", usage[k])
 	}
 
-	w.Flush()
-	return nil, nil
+	return w.Flush()
 }
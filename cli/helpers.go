@@ -1,168 +1,186 @@
 package cli
 
 import (
-	"context"
-	"encoding/json"
+	"bufio"
+	"bytes"
 	"errors"
 	"fmt"
 	"os"
-	"strings"
 
 	"github.com/micro/cli/v2"
-	"github.com/micro/go-micro/v2/client"
-	cbytes "github.com/micro/go-micro/v2/codec/bytes"
-	"github.com/micro/go-micro/v2/config/cmd"
 	clic "github.com/micro/micro/v2/internal/command/cli"
+	"github.com/micro/micro/v2/internal/redact"
+	"github.com/micro/micro/v2/plugin"
 )
 
-type exec func(*cli.Context, []string) ([]byte, error)
+type exec func(*cli.Context, []string, *bufio.Writer) error
 
+// Print streams the output of the command to stdout as it's written. The
+// output is buffered for a renderer as it's given the whole response.
 func Print(e exec) func(*cli.Context) error {
 	return func(c *cli.Context) error {
-		rsp, err := e(c, c.Args().Slice())
+		render, err := renderer(c.String("output"))
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
 		}
-		fmt.Printf("%s\n", string(rsp))
-		return nil
+
+		if render != nil {
+			b := bytes.NewBuffer(nil)
+			w := clic.NewWriter(b)
+			if err := e(c, c.Args().Slice(), w); err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+			w.Flush()
+
+			// write the rendered output in chunks
+			w = clic.NewWriter(os.Stdout)
+			if err := render(w, redact.Bytes(b.Bytes())); err != nil {
+				w.Flush()
+				fmt.Println(err)
+				os.Exit(1)
+			}
+			w.WriteByte('\n')
+			return w.Flush()
+		}
+
+		rw := redact.NewWriter(os.Stdout)
+		defer rw.Flush()
+
+		w := clic.NewWriter(rw)
+		if err := e(c, c.Args().Slice(), w); err != nil {
+			w.Flush()
+			rw.Flush()
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return w.Flush()
 	}
 }
 
-func list(c *cli.Context, args []string) ([]byte, error) {
+// renderer returns the renderer registered by a plugin for the output
+// format, nil is returned for the built in formats printed as is
+func renderer(format string) (plugin.Renderer, error) {
+	switch format {
+	case "", "json", "raw", "text":
+		return nil, nil
+	}
+
+	for _, p := range plugin.Plugins() {
+		rp, ok := p.(plugin.Renderers)
+		if !ok {
+			continue
+		}
+		if r, ok := rp.Renderers()[format]; ok {
+			return r, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown output format %s", format)
+}
+
+func list(c *cli.Context, args []string, w *bufio.Writer) error {
 	// no args
 	if len(args) == 0 {
-		return clic.ListServices(c)
+		return clic.ListServices(c, w)
 	}
 
 	// check first arg
 	switch args[0] {
 	case "services":
-		return clic.ListServices(c)
+		return clic.ListServices(c, w)
 	case "nodes":
-		return clic.NetworkNodes(c)
+		return clic.NetworkNodes(c, w)
 	case "routes":
-		return clic.NetworkRoutes(c)
+		return clic.NetworkRoutes(c, w)
 	}
 
-	return nil, errors.New("unknown command")
+	return errors.New("unknown command")
 }
 
-func networkConnect(c *cli.Context, args []string) ([]byte, error) {
-	return clic.NetworkConnect(c, args)
+func networkConnect(c *cli.Context, args []string, w *bufio.Writer) error {
+	return clic.NetworkConnect(c, args, w)
 }
 
-func networkConnections(c *cli.Context, args []string) ([]byte, error) {
-	return clic.NetworkConnections(c)
+func networkConnections(c *cli.Context, args []string, w *bufio.Writer) error {
+	return clic.NetworkConnections(c, w)
 }
 
-func networkGraph(c *cli.Context, args []string) ([]byte, error) {
-	return clic.NetworkGraph(c)
+func networkGraph(c *cli.Context, args []string, w *bufio.Writer) error {
+	return clic.NetworkGraph(c, w)
 }
 
-func netNodes(c *cli.Context, args []string) ([]byte, error) {
-	return clic.NetworkNodes(c)
+func netNodes(c *cli.Context, args []string, w *bufio.Writer) error {
+	return clic.NetworkNodes(c, w)
 }
 
-func netRoutes(c *cli.Context, args []string) ([]byte, error) {
-	return clic.NetworkRoutes(c)
+func netRoutes(c *cli.Context, args []string, w *bufio.Writer) error {
+	return clic.NetworkRoutes(c, w)
 }
 
-func netServices(c *cli.Context, args []string) ([]byte, error) {
-	return clic.NetworkServices(c)
+func netServices(c *cli.Context, args []string, w *bufio.Writer) error {
+	return clic.NetworkServices(c, w)
 }
 
-func netDNSAdvertise(c *cli.Context, args []string) ([]byte, error) {
-	return clic.NetworkDNSAdvertise(c)
+func netDNSAdvertise(c *cli.Context, args []string, w *bufio.Writer) error {
+	return clic.NetworkDNSAdvertise(c, w)
 }
 
-func netDNSRemove(c *cli.Context, args []string) ([]byte, error) {
-	return clic.NetworkDNSRemove(c)
+func netDNSRemove(c *cli.Context, args []string, w *bufio.Writer) error {
+	return clic.NetworkDNSRemove(c, w)
 }
 
-func netDNSResolve(c *cli.Context, args []string) ([]byte, error) {
-	return clic.NetworkDNSResolve(c)
+func netDNSResolve(c *cli.Context, args []string, w *bufio.Writer) error {
+	return clic.NetworkDNSResolve(c, w)
 }
 
-func listServices(c *cli.Context, args []string) ([]byte, error) {
-	return clic.ListServices(c)
+func netDNSList(c *cli.Context, args []string, w *bufio.Writer) error {
+	return clic.NetworkDNSList(c, w)
 }
 
-func registerService(c *cli.Context, args []string) ([]byte, error) {
-	return clic.RegisterService(c, args)
+func listServices(c *cli.Context, args []string, w *bufio.Writer) error {
+	return clic.ListServices(c, w)
 }
 
-func deregisterService(c *cli.Context, args []string) ([]byte, error) {
-	return clic.DeregisterService(c, args)
+func registerService(c *cli.Context, args []string, w *bufio.Writer) error {
+	return clic.RegisterService(c, args, w)
 }
 
-func getService(c *cli.Context, args []string) ([]byte, error) {
-	return clic.GetService(c, args)
+func deregisterService(c *cli.Context, args []string, w *bufio.Writer) error {
+	return clic.DeregisterService(c, args, w)
 }
 
-func callService(c *cli.Context, args []string) ([]byte, error) {
-	return clic.CallService(c, args)
+func pruneServices(c *cli.Context, args []string, w *bufio.Writer) error {
+	return clic.PruneServices(c, w)
 }
 
-// netCall calls services through the network
-func netCall(c *cli.Context, args []string) ([]byte, error) {
-	os.Setenv("MICRO_PROXY", "go.micro.network")
-	return clic.CallService(c, args)
+func getService(c *cli.Context, args []string, w *bufio.Writer) error {
+	return clic.GetService(c, args, w)
 }
 
-// TODO: stream via HTTP
-func streamService(c *cli.Context, args []string) ([]byte, error) {
-	if len(args) < 2 {
-		return nil, errors.New("require service and endpoint")
-	}
-	service := args[0]
-	endpoint := args[1]
-	var request map[string]interface{}
-
-	// ignore error
-	json.Unmarshal([]byte(strings.Join(args[2:], " ")), &request)
-
-	req := (*cmd.DefaultOptions().Client).NewRequest(service, endpoint, request, client.WithContentType("application/json"))
-	stream, err := (*cmd.DefaultOptions().Client).Stream(context.Background(), req)
-	if err != nil {
-		return nil, fmt.Errorf("error calling %s.%s: %v", service, endpoint, err)
-	}
-
-	if err := stream.Send(request); err != nil {
-		return nil, fmt.Errorf("error sending to %s.%s: %v", service, endpoint, err)
-	}
-
-	output := c.String("output")
+func callService(c *cli.Context, args []string, w *bufio.Writer) error {
+	return clic.CallService(c, args, w)
+}
 
-	for {
-		if output == "raw" {
-			rsp := cbytes.Frame{}
-			if err := stream.Recv(&rsp); err != nil {
-				return nil, fmt.Errorf("error receiving from %s.%s: %v", service, endpoint, err)
-			}
-			fmt.Print(string(rsp.Data))
-		} else {
-			var response map[string]interface{}
-			if err := stream.Recv(&response); err != nil {
-				return nil, fmt.Errorf("error receiving from %s.%s: %v", service, endpoint, err)
-			}
-			b, _ := json.MarshalIndent(response, "", "\t")
-			fmt.Print(string(b))
-		}
-	}
+// netCall calls services through the network
+func netCall(c *cli.Context, args []string, w *bufio.Writer) error {
+	os.Setenv("MICRO_PROXY", "go.micro.network")
+	return clic.CallService(c, args, w)
 }
 
-func publish(c *cli.Context, args []string) ([]byte, error) {
+func publish(c *cli.Context, args []string, w *bufio.Writer) error {
 	if err := clic.Publish(c, args); err != nil {
-		return nil, err
+		return err
 	}
-	return []byte(`ok`), nil
+	_, err := fmt.Fprintln(w, "ok")
+	return err
 }
 
-func queryHealth(c *cli.Context, args []string) ([]byte, error) {
-	return clic.QueryHealth(c, args)
+func queryHealth(c *cli.Context, args []string, w *bufio.Writer) error {
+	return clic.QueryHealth(c, args, w)
 }
 
-func queryStats(c *cli.Context, args []string) ([]byte, error) {
-	return clic.QueryStats(c, args)
+func queryStats(c *cli.Context, args []string, w *bufio.Writer) error {
+	return clic.QueryStats(c, args, w)
 }
@@ -0,0 +1,340 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/micro/cli/v2"
+	"github.com/micro/go-micro/v2/client"
+	cbytes "github.com/micro/go-micro/v2/codec/bytes"
+	"github.com/micro/go-micro/v2/config/cmd"
+	"github.com/micro/go-micro/v2/debug/service"
+	proto "github.com/micro/go-micro/v2/debug/service/proto"
+	clic "github.com/micro/micro/v2/internal/command/cli"
+	"github.com/micro/micro/v2/internal/redact"
+)
+
+var (
+	// Heartbeat is how often long running streams check the service is alive
+	Heartbeat = time.Second * 10
+	// HeartbeatMisses is the number of missed heartbeats before reconnecting
+	HeartbeatMisses = 3
+
+	streams = map[string]*streamCommand{
+		"stream": &streamCommand{"stream", "Stream a call to a service", streamService},
+		"logs":   &streamCommand{"logs", "Get logs for a service, use -f to follow", streamLogs},
+	}
+)
+
+// streamc is a long running command which runs until the context is done
+type streamc func(context.Context, *cli.Context, []string, *bufio.Writer) error
+
+type streamCommand struct {
+	name  string
+	usage string
+	exec  streamc
+}
+
+// background runs the stream until it completes
+func background(s streamc) exec {
+	return func(c *cli.Context, args []string, w *bufio.Writer) error {
+		return s(context.Background(), c, args, w)
+	}
+}
+
+// runStream runs a long running command until it completes or is
+// interrupted, returning to the prompt rather than exiting on Ctrl-C
+func runStream(c *cli.Context, s *streamCommand, args []string) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt)
+	defer signal.Stop(sig)
+
+	go func() {
+		select {
+		case <-sig:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	rw := redact.NewWriter(os.Stdout)
+	defer rw.Flush()
+
+	w := clic.NewWriter(rw)
+	defer w.Flush()
+
+	return s.exec(ctx, c, args, w)
+}
+
+// heartbeat checks the service is alive every interval, sending an
+// error once it has missed too many heartbeats
+func heartbeat(ctx context.Context, name string) <-chan error {
+	ch := make(chan error, 1)
+
+	go func() {
+		t := time.NewTicker(Heartbeat)
+		defer t.Stop()
+
+		c := *cmd.DefaultOptions().Client
+		req := c.NewRequest(name, "Debug.Health", &proto.HealthRequest{})
+
+		var misses int
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-t.C:
+			}
+
+			hctx, cancel := context.WithTimeout(ctx, Heartbeat)
+			err := c.Call(hctx, req, &proto.HealthResponse{})
+			cancel()
+
+			if err == nil {
+				misses = 0
+				continue
+			}
+
+			misses++
+
+			if misses >= HeartbeatMisses {
+				ch <- fmt.Errorf("missed %d heartbeats: %v", misses, err)
+				return
+			}
+		}
+	}()
+
+	return ch
+}
+
+// backoff waits before reconnecting, returning false if the context is done
+func backoff(ctx context.Context, attempt int) bool {
+	d := time.Second * time.Duration(attempt)
+	if d > time.Second*10 {
+		d = time.Second * 10
+	}
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(d):
+		return true
+	}
+}
+
+func streamService(ctx context.Context, c *cli.Context, args []string, w *bufio.Writer) error {
+	if len(args) < 2 {
+		return errors.New("require service and endpoint")
+	}
+	service := args[0]
+	endpoint := args[1]
+	var request map[string]interface{}
+
+	// ignore error
+	json.Unmarshal([]byte(strings.Join(args[2:], " ")), &request)
+
+	for attempt := 1; ; attempt++ {
+		connected, err := streamOnce(ctx, c, service, endpoint, request, w)
+
+		// interrupted or completed
+		if ctx.Err() != nil || err == io.EOF {
+			return nil
+		}
+
+		// failed to create the stream in the first place
+		if !connected && attempt == 1 {
+			return err
+		}
+
+		if connected {
+			attempt = 1
+		}
+
+		fmt.Fprintf(w, "stream to %s.%s lost: %v, reconnecting\n", service, endpoint, err)
+		w.Flush()
+
+		if !backoff(ctx, attempt) {
+			return nil
+		}
+	}
+}
+
+// streamOnce streams the responses until the stream fails or the context is done
+func streamOnce(ctx context.Context, c *cli.Context, service, endpoint string, request map[string]interface{}, w *bufio.Writer) (bool, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	cl := *cmd.DefaultOptions().Client
+	req := cl.NewRequest(service, endpoint, request, client.WithContentType("application/json"))
+	stream, err := cl.Stream(ctx, req)
+	if err != nil {
+		return false, fmt.Errorf("error calling %s.%s: %v", service, endpoint, err)
+	}
+	defer stream.Close()
+
+	if err := stream.Send(request); err != nil {
+		return false, fmt.Errorf("error sending to %s.%s: %v", service, endpoint, err)
+	}
+
+	raw := c.String("output") == "raw"
+	msgs := make(chan []byte)
+	errs := make(chan error, 1)
+
+	go func() {
+		for {
+			var b []byte
+			var err error
+
+			if raw {
+				rsp := cbytes.Frame{}
+				err = stream.Recv(&rsp)
+				b = rsp.Data
+			} else {
+				var rsp json.RawMessage
+				err = stream.Recv(&rsp)
+				b = rsp
+			}
+
+			if err != nil {
+				errs <- err
+				return
+			}
+
+			select {
+			case msgs <- b:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	hb := heartbeat(ctx, service)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return true, ctx.Err()
+		case err := <-hb:
+			return true, err
+		case err := <-errs:
+			if err == io.EOF {
+				return true, err
+			}
+			return true, fmt.Errorf("error receiving from %s.%s: %v", service, endpoint, err)
+		case b := <-msgs:
+			if raw {
+				w.Write(b)
+			} else if err := clic.WriteJSON(w, bytes.NewReader(b)); err != nil {
+				return true, err
+			}
+			w.WriteByte('\n')
+			// flush each message as it arrives
+			w.Flush()
+		}
+	}
+}
+
+func streamLogs(ctx context.Context, c *cli.Context, args []string, w *bufio.Writer) error {
+	var name string
+	var follow bool
+
+	for _, arg := range args {
+		switch arg {
+		case "-f", "--follow":
+			follow = true
+		default:
+			if len(name) == 0 {
+				name = arg
+			}
+		}
+	}
+
+	if len(name) == 0 {
+		return errors.New("require service name")
+	}
+
+	var since time.Time
+
+	for attempt := 1; ; attempt++ {
+		last, connected, err := logsOnce(ctx, name, since, follow, w)
+		since = last
+
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		if !follow {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+
+		if !connected && attempt == 1 {
+			return err
+		}
+
+		if connected {
+			attempt = 1
+		}
+
+		fmt.Fprintf(w, "log stream for %s lost: %v, reconnecting\n", name, err)
+		w.Flush()
+
+		if !backoff(ctx, attempt) {
+			return nil
+		}
+	}
+}
+
+// logsOnce writes logs from since, returning the timestamp of the last
+// record so a reconnect can resume where it left off
+func logsOnce(ctx context.Context, name string, since time.Time, follow bool, w *bufio.Writer) (time.Time, bool, error) {
+	stream, err := service.NewClient(name).Log(since, 0, follow)
+	if err != nil {
+		return since, false, err
+	}
+	defer stream.Stop()
+
+	var hb <-chan error
+	if follow {
+		hctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		hb = heartbeat(hctx, name)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return since, true, ctx.Err()
+		case err := <-hb:
+			return since, true, err
+		case record, ok := <-stream.Chan():
+			if !ok {
+				return since, true, io.EOF
+			}
+
+			// skip records already seen before reconnecting
+			if !since.IsZero() && !record.Timestamp.After(since) {
+				continue
+			}
+			since = record.Timestamp
+
+			fmt.Fprintf(w, "%v\n", record.Message)
+			w.Flush()
+		}
+	}
+}
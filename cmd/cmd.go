@@ -2,20 +2,24 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
 	ccli "github.com/micro/cli/v2"
 	"github.com/micro/go-micro/v2"
 	"github.com/micro/go-micro/v2/config/cmd"
 	"github.com/micro/micro/v2/api"
+	"github.com/micro/micro/v2/auth"
 	"github.com/micro/micro/v2/bot"
 	"github.com/micro/micro/v2/broker"
 	"github.com/micro/micro/v2/cli"
 	"github.com/micro/micro/v2/config"
 	"github.com/micro/micro/v2/debug"
+	"github.com/micro/micro/v2/edge"
 	"github.com/micro/micro/v2/health"
 	"github.com/micro/micro/v2/monitor"
 	"github.com/micro/micro/v2/network"
 	"github.com/micro/micro/v2/new"
+	"github.com/micro/micro/v2/notify"
 	"github.com/micro/micro/v2/plugin"
 	"github.com/micro/micro/v2/plugin/build"
 	"github.com/micro/micro/v2/proxy"
@@ -27,11 +31,13 @@ import (
 	"github.com/micro/micro/v2/store"
 	"github.com/micro/micro/v2/token"
 	"github.com/micro/micro/v2/tunnel"
+	"github.com/micro/micro/v2/verify"
 	"github.com/micro/micro/v2/web"
 
 	// include usage
 
 	"github.com/micro/micro/v2/internal/platform"
+	"github.com/micro/micro/v2/internal/redact"
 	_ "github.com/micro/micro/v2/internal/usage"
 )
 
@@ -175,6 +181,21 @@ func setup(app *ccli.App) {
 			EnvVars: []string{"MICRO_NAMESPACE"},
 			Value:   "go.micro",
 		},
+		&ccli.BoolFlag{
+			Name:    "show-secrets",
+			Usage:   "Show the values of secret keys in the output rather than masking them",
+			EnvVars: []string{"MICRO_SHOW_SECRETS"},
+		},
+		&ccli.StringFlag{
+			Name:    "output",
+			Usage:   "Set the output format of commands; json (default), raw or a format added by a plugin e.g csv",
+			EnvVars: []string{"MICRO_OUTPUT"},
+		},
+		&ccli.StringFlag{
+			Name:    "redact_patterns",
+			Usage:   "Comma separated list of key patterns masked in the output e.g password,token,secret,api_key",
+			EnvVars: []string{"MICRO_REDACT_PATTERNS"},
+		},
 	)
 
 	plugins := plugin.Plugins()
@@ -219,6 +240,12 @@ func setup(app *ccli.App) {
 		if len(ctx.String("web_namespace")) > 0 {
 			web.Namespace = ctx.String("web_namespace")
 		}
+		if ctx.Bool("show-secrets") {
+			redact.Show = true
+		}
+		if len(ctx.String("redact_patterns")) > 0 {
+			redact.SetPatterns(strings.Split(ctx.String("redact_patterns"), ","))
+		}
 
 		for _, p := range plugins {
 			if err := p.Init(ctx); err != nil {
@@ -264,6 +291,7 @@ func Init(options ...micro.Option) {
 func Setup(app *ccli.App, options ...micro.Option) {
 	// Add the various commands
 	app.Commands = append(app.Commands, api.Commands(options...)...)
+	app.Commands = append(app.Commands, auth.Commands(options...)...)
 	app.Commands = append(app.Commands, bot.Commands()...)
 	app.Commands = append(app.Commands, cli.Commands()...)
 	app.Commands = append(app.Commands, broker.Commands(options...)...)
@@ -273,9 +301,11 @@ func Setup(app *ccli.App, options ...micro.Option) {
 	app.Commands = append(app.Commands, router.Commands(options...)...)
 	app.Commands = append(app.Commands, tunnel.Commands(options...)...)
 	app.Commands = append(app.Commands, network.Commands(options...)...)
+	app.Commands = append(app.Commands, notify.Commands(options...)...)
 	app.Commands = append(app.Commands, registry.Commands(options...)...)
 	app.Commands = append(app.Commands, runtime.Commands(options...)...)
 	app.Commands = append(app.Commands, debug.Commands(options...)...)
+	app.Commands = append(app.Commands, edge.Commands(options...)...)
 	app.Commands = append(app.Commands, server.Commands(options...)...)
 	app.Commands = append(app.Commands, service.Commands(options...)...)
 	app.Commands = append(app.Commands, store.Commands(options...)...)
@@ -284,6 +314,7 @@ func Setup(app *ccli.App, options ...micro.Option) {
 	app.Commands = append(app.Commands, build.Commands()...)
 	app.Commands = append(app.Commands, web.Commands(options...)...)
 	app.Commands = append(app.Commands, config.Commands(options...)...)
+	app.Commands = append(app.Commands, verify.Commands(options...)...)
 
 	// add the init command for our internal operator
 	app.Commands = append(app.Commands, &ccli.Command{
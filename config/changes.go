@@ -0,0 +1,224 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/micro/cli/v2"
+	"github.com/micro/go-micro/v2/client"
+	"github.com/micro/go-micro/v2/metadata"
+	pb "github.com/micro/micro/v2/config/changes/proto"
+	"github.com/micro/micro/v2/config/handler"
+	"github.com/micro/micro/v2/internal/namespace"
+)
+
+// changeCommands are the commands for managing changes pending approval
+func changeCommands() []*cli.Command {
+	flags := []cli.Flag{
+		&cli.StringFlag{
+			Name:    "token",
+			EnvVars: []string{"MICRO_NAMESPACE_TOKEN"},
+			Usage:   "The token of the account used to approve or reject changes, see micro token namespace --account",
+		},
+	}
+
+	return []*cli.Command{
+		{
+			Name:   "pending",
+			Usage:  "List config changes pending approval e.g micro config pending [namespace]",
+			Flags:  flags,
+			Action: listChanges,
+		},
+		{
+			Name:   "approve",
+			Usage:  "Approve a pending config change e.g micro config approve <change-id>",
+			Flags:  flags,
+			Action: approveChange,
+		},
+		{
+			Name:   "reject",
+			Usage:  "Reject a pending config change e.g micro config reject <change-id>",
+			Flags:  flags,
+			Action: rejectChange,
+		},
+		{
+			Name:  "audit",
+			Usage: "List the changes made to the config e.g micro config audit --path app.db --since 24h",
+			Flags: append([]cli.Flag{
+				&cli.StringFlag{
+					Name:  "path",
+					Usage: "List the changes to the config at the path and within it",
+				},
+				&cli.StringFlag{
+					Name:  "namespace",
+					Usage: "Set the namespace of the config, all of the namespaces if it's not set",
+				},
+				&cli.StringFlag{
+					Name:  "since",
+					Usage: "List the changes since a duration ago e.g 24h or a time e.g 2020-01-30T00:00:00Z",
+				},
+				&cli.Int64Flag{
+					Name:  "limit",
+					Usage: "Maximum number of changes listed, newest first",
+				},
+			}, flags...),
+			Action: auditChanges,
+		},
+		{
+			Name:   "stats",
+			Usage:  "Show the hit rate of the read cache of a config service instance",
+			Flags:  flags,
+			Action: cacheStats,
+		},
+	}
+}
+
+func changesContext(ctx *cli.Context) context.Context {
+	return namespace.NewContext(context.Background(), ctx.String("token"))
+}
+
+// rawContext requests the config with its references unresolved e.g to write it back
+func rawContext(ctx *cli.Context) context.Context {
+	md := metadata.Metadata{handler.RawHeader: "true"}
+	return namespace.NewContext(metadata.NewContext(context.Background(), md), ctx.String("token"))
+}
+
+func listChanges(ctx *cli.Context) error {
+	changes := pb.NewChangesService(Name, client.DefaultClient)
+
+	rsp, err := changes.List(changesContext(ctx), &pb.ListRequest{Key: ctx.Args().First()})
+	if err != nil {
+		return err
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', 0)
+	fmt.Fprintln(writer, "ID\tACTION\tNAMESPACE\tPATH\tACCOUNT\tCREATED")
+	for _, ch := range rsp.Changes {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			ch.Id,
+			ch.Action,
+			ch.Key,
+			ch.Path,
+			ch.Account,
+			time.Unix(ch.Created, 0).Format(time.RFC3339),
+		)
+	}
+	return writer.Flush()
+}
+
+// since returns the unix time of the duration ago or the RFC3339 time, 0 if it's empty
+func since(s string) (int64, error) {
+	if len(s) == 0 {
+		return 0, nil
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return time.Now().Add(-d).Unix(), nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid since %s, expected a duration e.g 24h or a time e.g 2020-01-30T00:00:00Z", s)
+	}
+	return t.Unix(), nil
+}
+
+// auditChanges prints the changes made to the config at the path
+func auditChanges(ctx *cli.Context) error {
+	from, err := since(ctx.String("since"))
+	if err != nil {
+		return err
+	}
+
+	changes := pb.NewChangesService(Name, client.DefaultClient)
+
+	rsp, err := changes.Audit(changesContext(ctx), &pb.AuditRequest{
+		Key:   ctx.String("namespace"),
+		Path:  configPath(ctx.String("path")),
+		Since: from,
+		Limit: ctx.Int64("limit"),
+	})
+	if err != nil {
+		return err
+	}
+
+	value := func(b []byte) string {
+		if len(b) == 0 {
+			return "-"
+		}
+		return summary(b)
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', 0)
+	fmt.Fprintln(writer, "TIME\tACCOUNT\tTOKEN\tACTION\tNAMESPACE\tPATH\tOLD\tNEW")
+	for _, e := range rsp.Entries {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			time.Unix(e.Timestamp, 0).Format(time.RFC3339),
+			e.Account,
+			e.Token,
+			e.Action,
+			e.Key,
+			e.Path,
+			value(e.Old),
+			value(e.New),
+		)
+	}
+	return writer.Flush()
+}
+
+func cacheStats(ctx *cli.Context) error {
+	changes := pb.NewChangesService(Name, client.DefaultClient)
+
+	rsp, err := changes.Stats(changesContext(ctx), &pb.StatsRequest{})
+	if err != nil {
+		return err
+	}
+
+	if rsp.Ttl == 0 {
+		fmt.Println("The read cache is disabled, set --cache_ttl to enable it")
+		return nil
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', 0)
+	fmt.Fprintln(writer, "TTL\tENTRIES\tHITS\tMISSES\tSTALE\tHIT RATE")
+	fmt.Fprintf(writer, "%s\t%d\t%d\t%d\t%d\t%.1f%%\n",
+		time.Duration(rsp.Ttl)*time.Second,
+		rsp.Entries,
+		rsp.Hits,
+		rsp.Misses,
+		rsp.Stale,
+		rsp.HitRate*100,
+	)
+	return writer.Flush()
+}
+
+func approveChange(ctx *cli.Context) error {
+	id := ctx.Args().First()
+	if len(id) == 0 {
+		return fmt.Errorf("require change id")
+	}
+
+	changes := pb.NewChangesService(Name, client.DefaultClient)
+	if _, err := changes.Approve(changesContext(ctx), &pb.ApproveRequest{Id: id}); err != nil {
+		return err
+	}
+
+	fmt.Printf("Change %s approved\n", id)
+	return nil
+}
+
+func rejectChange(ctx *cli.Context) error {
+	id := ctx.Args().First()
+	if len(id) == 0 {
+		return fmt.Errorf("require change id")
+	}
+
+	changes := pb.NewChangesService(Name, client.DefaultClient)
+	if _, err := changes.Reject(changesContext(ctx), &pb.RejectRequest{Id: id}); err != nil {
+		return err
+	}
+
+	fmt.Printf("Change %s rejected\n", id)
+	return nil
+}
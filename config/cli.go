@@ -0,0 +1,558 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/micro/cli/v2"
+	"github.com/micro/go-micro/v2/client"
+	"github.com/micro/go-micro/v2/config/encoder/toml"
+	"github.com/micro/go-micro/v2/config/encoder/yaml"
+	mp "github.com/micro/go-micro/v2/config/source/service/proto"
+	"github.com/micro/go-micro/v2/errors"
+	pb "github.com/micro/micro/v2/config/changes/proto"
+	"github.com/micro/micro/v2/config/handler"
+	"github.com/micro/micro/v2/config/secret"
+)
+
+var (
+	// Namespace is the default namespace of the config read and written
+	Namespace = "global"
+)
+
+// valueCommands are the commands reading and writing the values of the config
+func valueCommands() []*cli.Command {
+	flags := []cli.Flag{
+		&cli.StringFlag{
+			Name:  "namespace",
+			Usage: "Set the namespace of the config",
+			Value: Namespace,
+		},
+		&cli.StringFlag{
+			Name:  "format",
+			Usage: "Set the format of the values; json, yaml or toml",
+			Value: "json",
+		},
+		&cli.StringFlag{
+			Name:    "token",
+			EnvVars: []string{"MICRO_NAMESPACE_TOKEN"},
+			Usage:   "The token of the account making the changes",
+		},
+	}
+
+	return []*cli.Command{
+		{
+			Name:      "get",
+			Usage:     "Get the config at a path e.g micro config get app.db.host",
+			ArgsUsage: "[path]",
+			Flags: append([]cli.Flag{
+				&cli.BoolFlag{
+					Name:  "raw",
+					Usage: "Get the config with its references e.g ${global:db/host} unresolved",
+				},
+				&cli.StringFlag{
+					Name:    "env",
+					EnvVars: []string{"MICRO_CONFIG_ENV"},
+					Usage:   "Get the config of the namespace with the overlay of the environment e.g prod merged over it",
+				},
+			}, flags...),
+			Action: getConfig,
+		},
+		{
+			Name:      "set",
+			Usage:     "Set the config at a path e.g micro config set app.db '{\"host\": \"prod-db\"}'",
+			ArgsUsage: "[path] [value]",
+			Flags: append([]cli.Flag{
+				&cli.BoolFlag{
+					Name:  "secret",
+					Usage: "Set the value as a secret, it's encrypted before it's stored and masked for the accounts which can't read secrets",
+				},
+				&cli.DurationFlag{
+					Name:  "ttl",
+					Usage: "Set the value until the ttl expires e.g 1h, it then reverts to the value before it was set unless --default is set",
+				},
+				&cli.StringFlag{
+					Name:  "default",
+					Usage: "Set the value the path reverts to when the ttl expires, the path is deleted if it's empty",
+				},
+				&cli.StringFlag{
+					Name:    "env",
+					EnvVars: []string{"MICRO_CONFIG_ENV"},
+					Usage:   "Set the value in the overlay of the environment e.g prod rather than the namespace",
+				},
+			}, flags...),
+			Action: setConfig,
+		},
+		{
+			Name:      "del",
+			Usage:     "Delete the config at a path e.g micro config del app.db.host",
+			ArgsUsage: "[path]",
+			Flags: append([]cli.Flag{
+				&cli.StringFlag{
+					Name:    "env",
+					EnvVars: []string{"MICRO_CONFIG_ENV"},
+					Usage:   "Delete the value from the overlay of the environment e.g prod rather than the namespace",
+				},
+			}, flags...),
+			Action: delConfig,
+		},
+		{
+			Name:      "watch",
+			Usage:     "Watch the changes to the config at a path e.g micro config watch app.db",
+			ArgsUsage: "[path]",
+			Flags:     flags,
+			Action:    watchConfig,
+		},
+		{
+			Name:      "history",
+			Usage:     "List the revisions changing the config at a path e.g micro config history app.db",
+			ArgsUsage: "[path]",
+			Flags: append([]cli.Flag{
+				&cli.Int64Flag{
+					Name:  "limit",
+					Usage: "Maximum number of revisions listed, newest first",
+				},
+			}, flags...),
+			Action: configHistory,
+		},
+		{
+			Name:      "rollback",
+			Usage:     "Roll back the config at a path to a revision e.g micro config rollback app.db --rev 3",
+			ArgsUsage: "[path]",
+			Flags: append([]cli.Flag{
+				&cli.Int64Flag{
+					Name:     "rev",
+					Usage:    "Set the revision rolled back to",
+					Required: true,
+				},
+			}, flags...),
+			Action: rollbackConfig,
+		},
+		{
+			Name:      "commit",
+			Usage:     "Set and delete the config at several paths all together or not at all e.g micro config commit app.flags.checkout=true app.flags.threshold=0.3",
+			ArgsUsage: "[path=value...]",
+			Flags: append([]cli.Flag{
+				&cli.StringSliceFlag{
+					Name:  "del",
+					Usage: "Delete the config at the path as part of the commit, may be repeated",
+				},
+			}, flags...),
+			Action: commitConfig,
+		},
+	}
+}
+
+// configPath returns the path of the config for the dot or slash separated path
+func configPath(path string) string {
+	return strings.Trim(strings.Replace(path, ".", "/", -1), "/")
+}
+
+// encodeValue encodes the value in the format
+func encodeValue(v interface{}, format string) ([]byte, error) {
+	switch format {
+	case "json":
+		return json.MarshalIndent(v, "", "  ")
+	case "yaml":
+		return yaml.NewEncoder().Encode(v)
+	case "toml":
+		return toml.NewEncoder().Encode(v)
+	}
+	return nil, fmt.Errorf("unknown format %s, expected json, yaml or toml", format)
+}
+
+// decodeValue decodes the value in the format, values which aren't valid json are strings
+func decodeValue(b []byte, format string) (interface{}, error) {
+	var v interface{}
+	switch format {
+	case "json":
+		v, err := decode(b)
+		if err != nil {
+			return string(b), nil
+		}
+		return v, nil
+	case "yaml":
+		if err := yaml.NewEncoder().Decode(b, &v); err != nil {
+			return nil, err
+		}
+	case "toml":
+		if err := toml.NewEncoder().Decode(b, &v); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown format %s, expected json, yaml or toml", format)
+	}
+	return v, nil
+}
+
+// changeID returns the checksum of the config of the namespace, it's the
+// checksum of the change set published on the watch topic when it changes
+func changeID(c *cli.Context, cfg mp.ConfigService, namespace string) string {
+	rsp, err := cfg.Read(rawContext(c), &mp.ReadRequest{Key: namespace})
+	if err != nil {
+		return ""
+	}
+	return rsp.Change.GetChangeSet().GetChecksum()
+}
+
+func getConfig(c *cli.Context) error {
+	namespace := c.String("namespace")
+	path := configPath(c.Args().First())
+
+	cfg := mp.NewConfigService(Name, client.DefaultClient)
+
+	ctx := changesContext(c)
+	if c.Bool("raw") {
+		ctx = rawContext(c)
+	}
+
+	var v interface{}
+	var ok bool
+	var err error
+	if env := c.String("env"); len(env) > 0 {
+		v, ok, err = resolveConfig(ctx, namespace, env, path)
+	} else {
+		v, ok, err = readConfig(ctx, cfg, namespace, path)
+	}
+	if err != nil {
+		return err
+	}
+	if !ok || v == nil {
+		return fmt.Errorf("no config at %s in %s", c.Args().First(), handler.Overlay(namespace, c.String("env")))
+	}
+
+	b, err := encodeValue(v, c.String("format"))
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(strings.TrimSpace(string(b)))
+	return nil
+}
+
+// resolveConfig reads the config at the path of the namespace with the overlay of the environment merged over it
+func resolveConfig(ctx context.Context, namespace, env, path string) (interface{}, bool, error) {
+	changes := pb.NewChangesService(Name, client.DefaultClient)
+
+	rsp, err := changes.Resolve(ctx, &pb.ResolveRequest{Key: namespace, Env: env, Path: path})
+	if err != nil {
+		if errors.Parse(err.Error()).Code == 404 {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	v, err := decode(rsp.ChangeSet.GetData())
+	if err != nil {
+		return nil, true, err
+	}
+	return v, true, nil
+}
+
+// watchConfig prints the values added, changed and removed at the path by each change
+func watchConfig(c *cli.Context) error {
+	namespace := c.String("namespace")
+	path := configPath(c.Args().First())
+
+	ctx := changesContext(c)
+	cfg := mp.NewConfigService(Name, client.DefaultClient)
+
+	stream, err := cfg.Watch(ctx, &mp.WatchRequest{Key: namespace})
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	// the value before the first change
+	old, _, err := readConfig(ctx, cfg, namespace, path)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Watching %s in %s\n", c.Args().First(), namespace)
+
+	// the path of a value changed within the config
+	full := func(p string) string {
+		if len(path) == 0 {
+			return p
+		}
+		if len(p) == 0 {
+			return path
+		}
+		return path + "/" + p
+	}
+
+	for {
+		rsp, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		v, err := decode(rsp.GetChangeSet().GetData())
+		if err != nil {
+			return err
+		}
+		v = lookup(v, path)
+
+		added := compare(v, old)
+		removed := compare(old, v).Added
+		old = v
+
+		// changes to the rest of the config
+		if len(added.Added)+len(added.Changed)+len(removed) == 0 {
+			continue
+		}
+
+		ts := time.Unix(rsp.GetChangeSet().GetTimestamp(), 0).Format(time.RFC3339)
+		fmt.Printf("%s change %s\n", ts, rsp.GetChangeSet().GetChecksum())
+		for _, l := range added.Added {
+			fmt.Printf("+ %s = %s\n", full(l.Path), l.Value)
+		}
+		for _, l := range added.Changed {
+			fmt.Printf("~ %s = %s (was %s)\n", full(l.Path), l.Value, l.Old)
+		}
+		for _, l := range removed {
+			fmt.Printf("- %s (was %s)\n", full(l.Path), l.Value)
+		}
+	}
+}
+
+// configHistory prints the revisions changing the config at the path
+func configHistory(c *cli.Context) error {
+	changes := pb.NewChangesService(Name, client.DefaultClient)
+
+	rsp, err := changes.History(changesContext(c), &pb.HistoryRequest{
+		Key:   c.String("namespace"),
+		Path:  configPath(c.Args().First()),
+		Limit: c.Int64("limit"),
+	})
+	if err != nil {
+		return err
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', 0)
+	fmt.Fprintln(writer, "REV\tACTION\tPATH\tACCOUNT\tTIMESTAMP\tCHECKSUM\tVALUE")
+	for _, r := range rsp.Revisions {
+		action := r.Action
+		if r.Rollback > 0 {
+			action = fmt.Sprintf("%s to %d", action, r.Rollback)
+		}
+		value := "-"
+		if len(r.Data) > 0 {
+			value = summary(r.Data)
+		}
+		fmt.Fprintf(writer, "%d\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			r.Rev,
+			action,
+			r.Path,
+			r.Account,
+			time.Unix(r.Timestamp, 0).Format(time.RFC3339),
+			r.Checksum,
+			value,
+		)
+	}
+	return writer.Flush()
+}
+
+// summary returns the compacted value truncated to fit a table
+func summary(b []byte) string {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, b); err == nil {
+		b = buf.Bytes()
+	}
+	if len(b) > 60 {
+		return string(b[:57]) + "..."
+	}
+	return string(b)
+}
+
+func rollbackConfig(c *cli.Context) error {
+	namespace := c.String("namespace")
+	rev := c.Int64("rev")
+
+	changes := pb.NewChangesService(Name, client.DefaultClient)
+
+	_, err := changes.Rollback(changesContext(c), &pb.RollbackRequest{
+		Key:  namespace,
+		Path: configPath(c.Args().First()),
+		Rev:  rev,
+	})
+	if err != nil {
+		return err
+	}
+
+	cfg := mp.NewConfigService(Name, client.DefaultClient)
+	fmt.Printf("Rolled back %s in %s to revision %d, change %s\n", c.Args().First(), namespace, rev, changeID(c, cfg, namespace))
+	return nil
+}
+
+// commitConfig sets the path=value arguments and deletes the paths of --del as a single change
+func commitConfig(c *cli.Context) error {
+	namespace := c.String("namespace")
+
+	var ops []*pb.Operation
+	for _, arg := range c.Args().Slice() {
+		parts := strings.SplitN(arg, "=", 2)
+		if len(parts) != 2 || len(configPath(parts[0])) == 0 {
+			return fmt.Errorf("invalid %s, expected path=value", arg)
+		}
+
+		v, err := decodeValue([]byte(parts[1]), c.String("format"))
+		if err != nil {
+			return err
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+
+		ops = append(ops, &pb.Operation{Key: namespace, Path: configPath(parts[0]), Data: b})
+	}
+	for _, path := range c.StringSlice("del") {
+		ops = append(ops, &pb.Operation{Key: namespace, Path: configPath(path), Delete: true})
+	}
+	if len(ops) == 0 {
+		return fmt.Errorf("require path=value or --del path")
+	}
+
+	changes := pb.NewChangesService(Name, client.DefaultClient)
+
+	rsp, err := changes.Commit(changesContext(c), &pb.CommitRequest{Operations: ops})
+	if err != nil {
+		return err
+	}
+
+	cfg := mp.NewConfigService(Name, client.DefaultClient)
+	fmt.Printf("Committed %d changes to %s as %s, change %s\n", len(ops), namespace, rsp.Id, changeID(c, cfg, namespace))
+	return nil
+}
+
+func setConfig(c *cli.Context) error {
+	if c.Args().Len() < 2 {
+		return fmt.Errorf("require path and value")
+	}
+
+	namespace := handler.Overlay(c.String("namespace"), c.String("env"))
+	path := configPath(c.Args().First())
+
+	v, err := decodeValue([]byte(c.Args().Get(1)), c.String("format"))
+	if err != nil {
+		return err
+	}
+	if c.Bool("secret") {
+		v = map[string]interface{}{secret.SecretField: v}
+	}
+
+	ctx := rawContext(c)
+	cfg := mp.NewConfigService(Name, client.DefaultClient)
+
+	_, exists, err := readConfig(ctx, cfg, namespace, "")
+	if err != nil {
+		return err
+	}
+
+	// values set at the root are merged into the config
+	if len(path) == 0 {
+		if _, ok := v.(map[string]interface{}); !ok {
+			return fmt.Errorf("the config of a namespace must be an object")
+		}
+	}
+
+	if ttl := c.Duration("ttl"); ttl > 0 {
+		return leaseConfig(ctx, c, cfg, namespace, path, v)
+	}
+
+	if err := writeConfig(ctx, cfg, namespace, path, exists, v); err != nil {
+		return err
+	}
+
+	fmt.Printf("Set %s in %s, change %s\n", c.Args().First(), namespace, changeID(c, cfg, namespace))
+	return nil
+}
+
+// leaseConfig sets the value at the path until the ttl expires, it reverts to the default
+// if it's set or the value it replaced, the path is deleted if neither is set
+func leaseConfig(ctx context.Context, c *cli.Context, cfg mp.ConfigService, namespace, path string, v interface{}) error {
+	if len(path) == 0 {
+		return fmt.Errorf("require path")
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	var def []byte
+	if c.IsSet("default") {
+		if len(c.String("default")) > 0 {
+			dv, err := decodeValue([]byte(c.String("default")), c.String("format"))
+			if err != nil {
+				return err
+			}
+			if def, err = json.Marshal(dv); err != nil {
+				return err
+			}
+		}
+	} else {
+		old, _, err := readConfig(ctx, cfg, namespace, path)
+		if err != nil {
+			return err
+		}
+		if old != nil {
+			if def, err = json.Marshal(old); err != nil {
+				return err
+			}
+		}
+		// the secrets read are masked so they can't be reverted to
+		if bytes.Contains(def, []byte(`"`+secret.Mask+`"`)) {
+			return fmt.Errorf("the value at %s has secrets, set the value it reverts to with --default", c.Args().First())
+		}
+	}
+
+	changes := pb.NewChangesService(Name, client.DefaultClient)
+
+	rsp, err := changes.Lease(ctx, &pb.LeaseRequest{
+		Key:     namespace,
+		Path:    path,
+		Data:    b,
+		Ttl:     int64(c.Duration("ttl").Seconds()),
+		Default: def,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Set %s in %s until %s, change %s\n", c.Args().First(), namespace,
+		time.Unix(rsp.Expires, 0).Format(time.RFC3339), changeID(c, cfg, namespace))
+	return nil
+}
+
+func delConfig(c *cli.Context) error {
+	namespace := handler.Overlay(c.String("namespace"), c.String("env"))
+	path := configPath(c.Args().First())
+
+	// deleting without a path deletes the namespace
+	if len(path) == 0 {
+		return fmt.Errorf("require path")
+	}
+
+	cfg := mp.NewConfigService(Name, client.DefaultClient)
+
+	_, err := cfg.Delete(changesContext(c), &mp.DeleteRequest{Change: &mp.Change{
+		Key:       namespace,
+		Path:      path,
+		ChangeSet: &mp.ChangeSet{Source: "cli"},
+	}})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Deleted %s in %s, change %s\n", c.Args().First(), namespace, changeID(c, cfg, namespace))
+	return nil
+}
@@ -1,15 +1,30 @@
 package config
 
 import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
 	"github.com/micro/cli/v2"
 	"github.com/micro/go-micro/v2"
+	"github.com/micro/go-micro/v2/config/encoder/yaml"
 	proto "github.com/micro/go-micro/v2/config/source/service/proto"
 	"github.com/micro/go-micro/v2/util/log"
+	pb "github.com/micro/micro/v2/config/changes/proto"
 	"github.com/micro/micro/v2/config/db"
 	_ "github.com/micro/micro/v2/config/db/cockroach"
 	_ "github.com/micro/micro/v2/config/db/etcd"
+	_ "github.com/micro/micro/v2/config/db/file"
 	_ "github.com/micro/micro/v2/config/db/memory"
+	_ "github.com/micro/micro/v2/config/db/mongodb"
+	_ "github.com/micro/micro/v2/config/db/mysql"
+	_ "github.com/micro/micro/v2/config/db/postgres"
 	"github.com/micro/micro/v2/config/handler"
+	"github.com/micro/micro/v2/config/mirror"
+	"github.com/micro/micro/v2/config/secret"
+	"github.com/micro/micro/v2/internal/standby"
 )
 
 var (
@@ -30,10 +45,85 @@ func Run(c *cli.Context, srvOpts ...micro.Option) {
 		Database = c.String("database")
 	}
 
+	// keys which require changes to be approved
+	for _, key := range strings.Split(c.String("approval"), ",") {
+		if key = strings.TrimSpace(key); len(key) > 0 {
+			handler.ApprovalKeys[key] = true
+		}
+	}
+
+	for _, acc := range strings.Split(c.String("approvers"), ",") {
+		if acc = strings.TrimSpace(acc); len(acc) > 0 {
+			handler.Approvers[acc] = true
+		}
+	}
+
+	// encrypt the secrets before they're written to the db
+	if key := c.String("secret_key"); len(key) > 0 {
+		p, err := secret.NewProvider(key)
+		if err != nil {
+			log.Fatalf("micro config secret key error: %s", err)
+		}
+		handler.Secrets = p
+	}
+
+	for _, acc := range strings.Split(c.String("secret_readers"), ",") {
+		if acc = strings.TrimSpace(acc); len(acc) > 0 {
+			handler.SecretReaders[acc] = true
+		}
+	}
+
+	// environment variables the config can reference
+	for _, name := range strings.Split(c.String("interpolate_env"), ",") {
+		if name = strings.TrimSpace(name); len(name) > 0 {
+			handler.EnvVars[name] = true
+		}
+	}
+
+	// scope the accounts and tokens to the namespaces and paths they can access
+	if path := c.String("acl"); len(path) > 0 {
+		rules, err := readRules(path)
+		if err != nil {
+			log.Fatalf("micro config acl error: %s", err)
+		}
+		handler.Rules = rules
+	}
+
+	// serve the reads from a cache, invalidated as the config changes
+	handler.CacheTTL = c.Duration("cache_ttl")
+
+	// namespaces mirrored from external sources, they can't be written through the api
+	mirrors, err := mirror.Parse(c.String("mirror"))
+	if err != nil {
+		log.Fatalf("micro config mirror error: %s", err)
+	}
+	sources := map[string]mirror.Source{}
+	for ns, u := range mirrors {
+		src, err := mirror.NewSource(u)
+		if err != nil {
+			log.Fatalf("micro config mirror %s error: %s", ns, err)
+		}
+		sources[ns] = src
+		handler.ReadOnly[ns] = src.String()
+	}
+
 	srvOpts = append(srvOpts, micro.Name(Name))
 
+	// take part in electing the active instance
+	if c.Bool("standby") {
+		// the writes replicated between the instances are signed with the key
+		if len(c.String("standby_key")) == 0 {
+			log.Fatal("--standby_key is required to run as an active/standby pair")
+		}
+		srvOpts = append(srvOpts, micro.Metadata(standby.Metadata()))
+		standby.Forwarded = append(standby.Forwarded, handler.ExpiredHeader)
+	}
+
 	service := micro.NewService(srvOpts...)
-	proto.RegisterConfigHandler(service.Server(), new(handler.Handler))
+	h := new(handler.Handler)
+	proto.RegisterConfigHandler(service.Server(), h)
+	pb.RegisterChangesHandler(service.Server(), &handler.Changes{Config: h})
+	pb.RegisterSchemasHandler(service.Server(), &handler.Schemas{Config: h})
 
 	_ = service.Server().Subscribe(service.Server().NewSubscriber(handler.WatchTopic, handler.Watcher))
 
@@ -44,11 +134,79 @@ func Run(c *cli.Context, srvOpts ...micro.Option) {
 		log.Fatalf("micro config init database error: %s", err)
 	}
 
+	// the changes streamed by the db are delivered to the watchers rather than published
+	if w, err := db.Watch(); err == nil {
+		handler.Publish = false
+		exit := make(chan bool)
+		defer close(exit)
+		go handler.Stream(w, exit)
+	} else if err != db.ErrWatchNotSupported {
+		log.Logf("Publishing the config changes, the %s db can't stream them: %v", Database, err)
+	}
+
+	if c.Bool("standby") {
+		opts := service.Server().Options()
+		sb := standby.New(Name, opts.Name+"-"+opts.Id, c.String("standby_key"), service.Options().Registry, service.Client())
+		sb.Start()
+		defer sb.Stop()
+
+		// catch up with the changes made before we started
+		if node := sb.ActiveNode(); node != nil {
+			if err := syncFrom(node.Address); err != nil {
+				log.Logf("Failed to sync from the active instance %s: %v", node.Id, err)
+			}
+		}
+
+		h.Standby = sb
+	}
+
+	// revert the config of the leases as they expire
+	expire := make(chan bool)
+	defer close(expire)
+	go h.Expire(expire)
+
+	for ns, src := range sources {
+		go h.Mirror(ns, src, expire)
+	}
+
 	if err := service.Run(); err != nil {
 		log.Fatalf("micro config Run the service error: ", err)
 	}
 }
 
+// readRules reads the access rules of the json or yaml file
+func readRules(path string) ([]*handler.Rule, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var rules []*handler.Rule
+	if ext := filepath.Ext(path); ext == ".yaml" || ext == ".yml" {
+		err = yaml.NewEncoder().Decode(b, &rules)
+	} else {
+		err = json.Unmarshal(b, &rules)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+
+	for i, r := range rules {
+		if len(r.Account) == 0 && len(r.Token) == 0 {
+			return nil, fmt.Errorf("rule %d has no account or token", i)
+		}
+		if r.Access != handler.ReadAccess && r.Access != handler.WriteAccess {
+			return nil, fmt.Errorf("rule %d has invalid access %q, expected read or write", i, r.Access)
+		}
+		if len(r.Namespace) == 0 {
+			return nil, fmt.Errorf("rule %d has no namespace", i)
+		}
+		r.Path = configPath(r.Path)
+	}
+
+	return rules, nil
+}
+
 func Commands(options ...micro.Option) []*cli.Command {
 	command := &cli.Command{
 		Name:  "config",
@@ -66,19 +224,70 @@ func Commands(options ...micro.Option) []*cli.Command {
 			&cli.StringFlag{
 				Name:    "database_url",
 				EnvVars: []string{"MICRO_CONFIG_DATABASE_URL"},
-				Usage:   "The database URL e.g root:123@(127.0.0.1:3306)/config?charset=utf8&parseTime=true&loc=Asia%2FShanghai",
+				Usage:   "The database URL e.g postgres://postgres@127.0.0.1:5432/config?sslmode=disable&pool_max_open=10",
 			},
 			&cli.StringFlag{
 				Name:    "database",
 				EnvVars: []string{"MICRO_CONFIG_DATABASE"},
-				Usage:   "The database e.g mysql(default), postgresql, but now we only support mysql and cockroach(pg).",
+				Usage:   "The database e.g memory(default), cockroach, etcd, file, mongodb, mysql or postgres",
 			},
 			&cli.StringFlag{
 				Name:    "watch_topic",
 				EnvVars: []string{"MICRO_CONFIG_WATCH_TOPIC"},
 				Usage:   "watch the change event.",
 			},
+			&cli.BoolFlag{
+				Name:    "standby",
+				EnvVars: []string{"MICRO_CONFIG_STANDBY"},
+				Usage:   "Run as one of an active/standby pair, writes are forwarded to the active instance and replicated back",
+			},
+			&cli.StringFlag{
+				Name:    "standby_key",
+				EnvVars: []string{"MICRO_CONFIG_STANDBY_KEY"},
+				Usage:   "Key shared by the active/standby pair to sign the writes replicated between them",
+			},
+			&cli.StringFlag{
+				Name:    "approval",
+				EnvVars: []string{"MICRO_CONFIG_APPROVAL"},
+				Usage:   "Comma separated list of namespaces where changes require approval, or * for all",
+			},
+			&cli.StringFlag{
+				Name:    "approvers",
+				EnvVars: []string{"MICRO_CONFIG_APPROVERS"},
+				Usage:   "Comma separated list of accounts allowed to approve changes",
+			},
+			&cli.StringFlag{
+				Name:    "secret_key",
+				EnvVars: []string{"MICRO_CONFIG_SECRET_KEY"},
+				Usage:   "Encrypt the secrets with local keys e.g v1:base64 or file:///etc/micro/config-keys, or with vault transit e.g vault:https://vault:8200/transit/config",
+			},
+			&cli.StringFlag{
+				Name:    "secret_readers",
+				EnvVars: []string{"MICRO_CONFIG_SECRET_READERS"},
+				Usage:   "Comma separated list of accounts the secrets are decrypted for, they're masked for anyone else",
+			},
+			&cli.StringFlag{
+				Name:    "interpolate_env",
+				EnvVars: []string{"MICRO_CONFIG_INTERPOLATE_ENV"},
+				Usage:   "Comma separated list of environment variables the config can reference as ${env:NAME}, names ending in * are prefixes e.g DB_*",
+			},
+			&cli.StringFlag{
+				Name:    "acl",
+				EnvVars: []string{"MICRO_CONFIG_ACL"},
+				Usage:   "Json or yaml file of rules granting accounts or tokens read or write access to namespaces and paths, everyone has full access if it's not set",
+			},
+			&cli.DurationFlag{
+				Name:    "cache_ttl",
+				EnvVars: []string{"MICRO_CONFIG_CACHE_TTL"},
+				Usage:   "Cache the config read for the duration e.g 30s, stale config is served while it's read again. Disabled if it's not set",
+			},
+			&cli.StringFlag{
+				Name:    "mirror",
+				EnvVars: []string{"MICRO_CONFIG_MIRROR"},
+				Usage:   "Comma separated list of namespace=url mirrored read only from etcd://host:port/prefix, consul://host:port/prefix or configmap://namespace/name",
+			},
 		},
+		Subcommands: append(append(append(append(valueCommands(), changeCommands()...), exportCommands()...), schemaCommands()...), copyCommand(), diffCommand()),
 	}
 
 	for _, p := range Plugins() {
@@ -0,0 +1,172 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/micro/cli/v2"
+	"github.com/micro/go-micro/v2/client"
+	mp "github.com/micro/go-micro/v2/config/source/service/proto"
+	"github.com/micro/go-micro/v2/store"
+)
+
+var (
+	// VersionsSuffix is appended to the namespace the overwritten values are versioned in
+	VersionsSuffix = ".versions"
+)
+
+// copyCommand copies a config subtree between namespaces
+func copyCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "copy",
+		Usage: "Copy config between namespaces e.g micro config copy --from-namespace staging --to-namespace prod --path app/payments",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "from-namespace",
+				Usage:    "Set the namespace copied from",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "to-namespace",
+				Usage:    "Set the namespace copied to",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "path",
+				Usage: "Set the path of the config copied e.g app/payments, defaults to all of it",
+			},
+			&cli.BoolFlag{
+				Name:  "overwrite",
+				Usage: "Overwrite the existing values, the values overwritten are versioned",
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Print the changes without copying them",
+			},
+			&cli.StringFlag{
+				Name:    "token",
+				EnvVars: []string{"MICRO_NAMESPACE_TOKEN"},
+				Usage:   "The token of the account making the changes",
+			},
+		},
+		Action: copyConfig,
+	}
+}
+
+// readConfig reads the config at the path returning nil if the namespace or path doesn't exist
+func readConfig(ctx context.Context, cfg mp.ConfigService, namespace, path string) (interface{}, bool, error) {
+	rsp, err := cfg.Read(ctx, &mp.ReadRequest{Key: namespace, Path: path})
+	if err != nil {
+		if strings.Contains(err.Error(), store.ErrNotFound.Error()) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	v, err := decode(rsp.Change.GetChangeSet().GetData())
+	if err != nil {
+		return nil, true, err
+	}
+	return v, true, nil
+}
+
+// writeConfig sets the config at the path creating the namespace if it doesn't exist
+func writeConfig(ctx context.Context, cfg mp.ConfigService, namespace, path string, exists bool, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		_, err := cfg.Create(ctx, &mp.CreateRequest{Change: &mp.Change{
+			Key:       namespace,
+			ChangeSet: &mp.ChangeSet{Data: []byte(`{}`), Format: "json", Source: "cli"},
+		}})
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = cfg.Update(ctx, &mp.UpdateRequest{Change: &mp.Change{
+		Key:       namespace,
+		Path:      path,
+		ChangeSet: &mp.ChangeSet{Data: b, Format: "json", Source: "cli"},
+	}})
+	return err
+}
+
+// copyConfig copies the config at the path between namespaces, the existing values
+// are only overwritten with --overwrite after being saved as a version
+func copyConfig(c *cli.Context) error {
+	from := c.String("from-namespace")
+	to := c.String("to-namespace")
+	path := strings.Trim(c.String("path"), "/")
+
+	if from == to {
+		return fmt.Errorf("the namespaces copied from and to are the same")
+	}
+
+	// the references are copied rather than the values they resolve to
+	ctx := rawContext(c)
+	cfg := mp.NewConfigService(Name, client.DefaultClient)
+
+	src, ok, err := readConfig(ctx, cfg, from, path)
+	if err != nil {
+		return err
+	}
+	if !ok || src == nil {
+		return fmt.Errorf("no config at %s in %s", path, from)
+	}
+
+	dst, exists, err := readConfig(ctx, cfg, to, path)
+	if err != nil {
+		return err
+	}
+
+	d := compare(src, dst)
+
+	for _, l := range d.Added {
+		fmt.Printf("+ %s = %s\n", l.Path, l.Value)
+	}
+	for _, l := range d.Changed {
+		fmt.Printf("~ %s = %s (was %s)\n", l.Path, l.Value, l.Old)
+	}
+	fmt.Printf("%d added, %d changed, %d unchanged\n", len(d.Added), len(d.Changed), len(d.Unchanged))
+
+	if len(d.Changed) > 0 && !c.Bool("overwrite") {
+		return fmt.Errorf("%d values in %s would be overwritten, copy with --overwrite", len(d.Changed), to)
+	}
+
+	if c.Bool("dry-run") || len(d.Added)+len(d.Changed) == 0 {
+		return nil
+	}
+
+	// version the values overwritten
+	if len(d.Changed) > 0 {
+		version := strconv.FormatInt(time.Now().Unix(), 10)
+		vpath := version
+		if len(path) > 0 {
+			vpath = path + "/" + version
+		}
+
+		_, vexists, err := readConfig(ctx, cfg, to+VersionsSuffix, "")
+		if err != nil {
+			return err
+		}
+		if err := writeConfig(ctx, cfg, to+VersionsSuffix, vpath, vexists, dst); err != nil {
+			return fmt.Errorf("failed to version the values overwritten: %v", err)
+		}
+		fmt.Printf("Saved the values overwritten as version %s in %s\n", vpath, to+VersionsSuffix)
+	}
+
+	if err := writeConfig(ctx, cfg, to, path, exists, merge(src, dst)); err != nil {
+		return err
+	}
+
+	fmt.Printf("Copied %s from %s to %s\n", path, from, to)
+	return nil
+}
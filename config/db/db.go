@@ -13,6 +13,9 @@ var (
 	dbMap       = map[string]DB{}
 	mux         sync.Mutex
 	ErrNotFound = errors.New("not found")
+
+	// ErrWatchNotSupported is returned watching a db which can't stream its changes
+	ErrWatchNotSupported = errors.New("watch not supported")
 )
 
 type DB interface {
@@ -25,6 +28,26 @@ type DB interface {
 	String() string
 }
 
+// Streamer is implemented by the dbs which stream the changes made to them by
+// every instance sharing the db, so the changes don't need to be published
+type Streamer interface {
+	Watch() (Watcher, error)
+}
+
+// Watcher streams the changes made to the db
+type Watcher interface {
+	Next() (*Event, error)
+	Stop() error
+}
+
+// Event is a change made to the db
+type Event struct {
+	// Key of the record changed
+	Key string
+	// Record written, nil if it was deleted
+	Record *store.Record
+}
+
 func Register(backend DB) {
 	mux.Lock()
 	defer mux.Unlock()
@@ -67,3 +90,12 @@ func Delete(key string) error {
 func List(opts ...ListOption) ([]*store.Record, error) {
 	return db.List(opts...)
 }
+
+// Watch the changes made to the db, ErrWatchNotSupported is returned if it can't stream them
+func Watch() (Watcher, error) {
+	s, ok := db.(Streamer)
+	if !ok {
+		return nil, ErrWatchNotSupported
+	}
+	return s.Watch()
+}
@@ -0,0 +1,436 @@
+// Package file is a config db of a directory of json or yaml files, one for the config of
+// each key e.g ~/.micro/config/global.yaml. Editing the files changes the config, the changes
+// are streamed to the watchers of the config so there's no need for a broker or a database.
+package file
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/golang/protobuf/proto"
+	"github.com/micro/go-micro/v2/config/encoder/yaml"
+	"github.com/micro/go-micro/v2/config/source"
+	mp "github.com/micro/go-micro/v2/config/source/service/proto"
+	"github.com/micro/go-micro/v2/store"
+	"github.com/micro/go-micro/v2/util/log"
+	"github.com/micro/micro/v2/config/db"
+)
+
+var (
+	// Dir is the default directory of the files, the url of the db overrides it
+	Dir = filepath.Join(os.TempDir(), "micro", "config")
+	// Format of the files of new keys; json or yaml, set with ?format=yaml in the url
+	Format = "json"
+
+	// internalDir is the directory of the records which aren't config e.g pending changes
+	internalDir = ".micro"
+
+	// extensions of the config files by format, in the order they're read
+	extensions = []string{".json", ".yaml", ".yml"}
+
+	errStopped = errors.New("watcher stopped")
+)
+
+type file struct {
+	sync.Mutex
+	dir    string
+	format string
+}
+
+func init() {
+	if home, err := os.UserHomeDir(); err == nil {
+		Dir = filepath.Join(home, ".micro", "config")
+	}
+
+	db.Register(new(file))
+}
+
+// Init the directory of the url e.g /etc/micro/config or file:///etc/micro/config?format=yaml
+func (f *file) Init(opts db.Options) error {
+	f.dir = Dir
+	f.format = Format
+
+	if len(opts.Url) > 0 {
+		u, err := url.Parse(opts.Url)
+		if err != nil {
+			return fmt.Errorf("invalid url: %v", err)
+		}
+		f.dir = u.Path
+		if v := u.Query().Get("format"); len(v) > 0 {
+			f.format = v
+		}
+	}
+
+	switch f.format {
+	case "json", "yaml":
+	default:
+		return fmt.Errorf("unsupported format %s", f.format)
+	}
+
+	return os.MkdirAll(filepath.Join(f.dir, internalDir), 0700)
+}
+
+// isConfig returns true if the key is the config of a namespace rather than a record
+// of the config service e.g a pending change, they're prefixed with double underscores
+func isConfig(key string) bool {
+	return !strings.HasPrefix(key, "__")
+}
+
+// name of the file of the key without its extension
+func (f *file) name(key string) string {
+	if !isConfig(key) {
+		return filepath.Join(f.dir, internalDir, url.PathEscape(key))
+	}
+	return filepath.Join(f.dir, url.PathEscape(key))
+}
+
+// path returns the file of the config of the key, an empty string if there's none
+func (f *file) path(key string) string {
+	for _, ext := range extensions {
+		p := f.name(key) + ext
+		if _, err := os.Stat(p); err == nil {
+			return p
+		}
+	}
+	return ""
+}
+
+// keyOf returns the key of the config file, false if it's not a config file
+func keyOf(path string) (string, bool) {
+	base := filepath.Base(path)
+	ext := filepath.Ext(base)
+
+	ok := false
+	for _, e := range extensions {
+		if ext == e {
+			ok = true
+		}
+	}
+	if !ok || strings.HasPrefix(base, ".") {
+		return "", false
+	}
+
+	key, err := url.PathUnescape(strings.TrimSuffix(base, ext))
+	if err != nil {
+		return "", false
+	}
+	return key, true
+}
+
+// readConfig reads the config file as a change
+func readConfig(key, path string) (*store.Record, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// the config is stored as json whatever the format of the file
+	if ext := filepath.Ext(path); ext == ".yaml" || ext == ".yml" {
+		var v interface{}
+		if err := yaml.NewEncoder().Decode(b, &v); err != nil {
+			return nil, fmt.Errorf("%s: %v", path, err)
+		}
+		if b, err = json.Marshal(v); err != nil {
+			return nil, err
+		}
+	} else {
+		var buf bytes.Buffer
+		if err := json.Compact(&buf, b); err != nil {
+			return nil, fmt.Errorf("%s: %v", path, err)
+		}
+		b = buf.Bytes()
+	}
+
+	cs := &source.ChangeSet{Data: b, Format: "json", Source: "file", Timestamp: info.ModTime()}
+	ch := &mp.Change{
+		Key: key,
+		ChangeSet: &mp.ChangeSet{
+			Data:      cs.Data,
+			Checksum:  cs.Sum(),
+			Format:    cs.Format,
+			Source:    cs.Source,
+			Timestamp: cs.Timestamp.Unix(),
+		},
+	}
+
+	v, err := proto.Marshal(ch)
+	if err != nil {
+		return nil, err
+	}
+	return &store.Record{Key: key, Value: v}, nil
+}
+
+// write the file atomically so a watcher never reads it partially written
+func write(path string, b []byte) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(path), ".tmp-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (f *file) Create(record *store.Record) error {
+	f.Lock()
+	defer f.Unlock()
+
+	if !isConfig(record.Key) {
+		return write(f.name(record.Key), record.Value)
+	}
+
+	ch := &mp.Change{}
+	if err := proto.Unmarshal(record.Value, ch); err != nil {
+		return err
+	}
+
+	var data []byte
+	if ch.ChangeSet != nil {
+		data = ch.ChangeSet.Data
+	}
+
+	// the file keeps its format
+	path := f.path(record.Key)
+	if len(path) == 0 {
+		path = f.name(record.Key) + "." + f.format
+	}
+
+	var b []byte
+	if ext := filepath.Ext(path); ext == ".yaml" || ext == ".yml" {
+		var v interface{}
+		if err := json.Unmarshal(data, &v); err != nil {
+			return err
+		}
+		var err error
+		if b, err = yaml.NewEncoder().Encode(v); err != nil {
+			return err
+		}
+	} else {
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, data, "", "  "); err != nil {
+			return err
+		}
+		buf.WriteByte('\n')
+		b = buf.Bytes()
+	}
+
+	return write(path, b)
+}
+
+func (f *file) Read(key string) (*store.Record, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	if !isConfig(key) {
+		b, err := ioutil.ReadFile(f.name(key))
+		if os.IsNotExist(err) {
+			return nil, store.ErrNotFound
+		} else if err != nil {
+			return nil, err
+		}
+		return &store.Record{Key: key, Value: b}, nil
+	}
+
+	path := f.path(key)
+	if len(path) == 0 {
+		return nil, store.ErrNotFound
+	}
+	return readConfig(key, path)
+}
+
+func (f *file) Update(record *store.Record) error {
+	return f.Create(record)
+}
+
+func (f *file) Delete(key string) error {
+	f.Lock()
+	defer f.Unlock()
+
+	path := f.name(key)
+	if isConfig(key) {
+		path = f.path(key)
+	}
+	if len(path) == 0 {
+		return store.ErrNotFound
+	}
+
+	err := os.Remove(path)
+	if os.IsNotExist(err) {
+		return store.ErrNotFound
+	}
+	return err
+}
+
+func (f *file) List(opts ...db.ListOption) ([]*store.Record, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	var records []*store.Record
+
+	files, err := ioutil.ReadDir(f.dir)
+	if err != nil {
+		return nil, err
+	}
+	seen := map[string]bool{}
+	for _, fi := range files {
+		key, ok := keyOf(fi.Name())
+		if fi.IsDir() || !ok || seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		record, err := readConfig(key, f.path(key))
+		if err != nil {
+			// a file being edited isn't valid until it's saved
+			log.Errorf("Error reading config %s: %v", key, err)
+			continue
+		}
+		records = append(records, record)
+	}
+
+	files, err = ioutil.ReadDir(filepath.Join(f.dir, internalDir))
+	if err != nil {
+		return nil, err
+	}
+	for _, fi := range files {
+		if fi.IsDir() || strings.HasPrefix(fi.Name(), ".") {
+			continue
+		}
+		key, err := url.PathUnescape(fi.Name())
+		if err != nil {
+			continue
+		}
+		b, err := ioutil.ReadFile(filepath.Join(f.dir, internalDir, fi.Name()))
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, &store.Record{Key: key, Value: b})
+	}
+
+	return records, nil
+}
+
+// Watch the config files for changes, made by the config service or edited
+func (f *file) Watch() (db.Watcher, error) {
+	fw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := fw.Add(f.dir); err != nil {
+		fw.Close()
+		return nil, err
+	}
+
+	w := &watcher{
+		file:     f,
+		fsnotify: fw,
+		sums:     map[string]string{},
+		exit:     make(chan bool),
+	}
+
+	// the config as it is now isn't a change
+	records, err := f.List()
+	if err != nil {
+		fw.Close()
+		return nil, err
+	}
+	for _, r := range records {
+		if isConfig(r.Key) {
+			w.sums[r.Key] = checksum(r)
+		}
+	}
+
+	return w, nil
+}
+
+func (f *file) String() string {
+	return "file"
+}
+
+// checksum of the config of the record
+func checksum(r *store.Record) string {
+	ch := &mp.Change{}
+	if err := proto.Unmarshal(r.Value, ch); err != nil || ch.ChangeSet == nil {
+		return ""
+	}
+	return ch.ChangeSet.Checksum
+}
+
+type watcher struct {
+	file     *file
+	fsnotify *fsnotify.Watcher
+	// sums are the checksums of the config last streamed by key
+	sums map[string]string
+	exit chan bool
+	once sync.Once
+}
+
+// Next returns the next change to the config, a file written which
+// hasn't changed or isn't valid e.g while it's being edited is skipped
+func (w *watcher) Next() (*db.Event, error) {
+	for {
+		select {
+		case <-w.exit:
+			return nil, errStopped
+		case err := <-w.fsnotify.Errors:
+			return nil, err
+		case ev, ok := <-w.fsnotify.Events:
+			if !ok {
+				return nil, errStopped
+			}
+
+			key, ok := keyOf(ev.Name)
+			if !ok {
+				continue
+			}
+
+			record, err := w.file.Read(key)
+			if err == store.ErrNotFound {
+				if _, ok := w.sums[key]; !ok {
+					continue
+				}
+				delete(w.sums, key)
+				return &db.Event{Key: key}, nil
+			} else if err != nil {
+				log.Errorf("Error reading config %s: %v", key, err)
+				continue
+			}
+
+			sum := checksum(record)
+			if w.sums[key] == sum {
+				continue
+			}
+			w.sums[key] = sum
+
+			return &db.Event{Key: key, Record: record}, nil
+		}
+	}
+}
+
+func (w *watcher) Stop() error {
+	w.once.Do(func() {
+		close(w.exit)
+		w.fsnotify.Close()
+	})
+	return nil
+}
@@ -0,0 +1,246 @@
+// Package mongodb is a mongodb backend for the config db, the changes are streamed
+// to every instance by a change stream so they don't need to be published
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/micro/go-micro/v2/store"
+	"github.com/micro/micro/v2/config/db"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/connstring"
+)
+
+var (
+	defaultUrl = "mongodb://127.0.0.1:27017/config"
+	database   = "config"
+	table      = "configs"
+
+	// Timeout of the operations on the db
+	Timeout = 10 * time.Second
+)
+
+// document is a record as stored in the collection
+type document struct {
+	Key     string    `bson:"_id"`
+	Value   []byte    `bson:"value"`
+	Updated time.Time `bson:"updated"`
+}
+
+type mongodb struct {
+	client *mongo.Client
+	coll   *mongo.Collection
+}
+
+func init() {
+	db.Register(new(mongodb))
+}
+
+func (m *mongodb) Init(opts db.Options) error {
+	if opts.Url != "" {
+		defaultUrl = opts.Url
+	}
+
+	if opts.Table != "" {
+		table = opts.Table
+	}
+
+	cs, err := connstring.Parse(defaultUrl)
+	if err != nil {
+		return fmt.Errorf("invalid url: %v", err)
+	}
+	if len(cs.Database) > 0 {
+		database = cs.Database
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(defaultUrl))
+	if err != nil {
+		return err
+	}
+	if err := client.Ping(ctx, nil); err != nil {
+		client.Disconnect(context.Background())
+		return fmt.Errorf("connect to mongodb error: %v", err)
+	}
+
+	m.client = client
+	m.coll = client.Database(database).Collection(table)
+
+	return nil
+}
+
+// Create writes the record replacing the record of the key if there's one
+func (m *mongodb) Create(record *store.Record) error {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+
+	doc := &document{Key: record.Key, Value: record.Value, Updated: time.Now()}
+	_, err := m.coll.ReplaceOne(ctx, bson.M{"_id": record.Key}, doc, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (m *mongodb) Read(key string) (*store.Record, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+
+	doc := &document{}
+	err := m.coll.FindOne(ctx, bson.M{"_id": key}).Decode(doc)
+	if err == mongo.ErrNoDocuments {
+		return nil, store.ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	return &store.Record{Key: doc.Key, Value: doc.Value}, nil
+}
+
+func (m *mongodb) Update(record *store.Record) error {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+
+	doc := &document{Key: record.Key, Value: record.Value, Updated: time.Now()}
+	res, err := m.coll.ReplaceOne(ctx, bson.M{"_id": record.Key}, doc)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
+func (m *mongodb) Delete(key string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+
+	res, err := m.coll.DeleteOne(ctx, bson.M{"_id": key})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
+func (m *mongodb) List(opts ...db.ListOption) ([]*store.Record, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+
+	cur, err := m.coll.Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var records []*store.Record
+	for cur.Next(ctx) {
+		doc := &document{}
+		if err := cur.Decode(doc); err != nil {
+			return nil, err
+		}
+		records = append(records, &store.Record{Key: doc.Key, Value: doc.Value})
+	}
+
+	return records, cur.Err()
+}
+
+// Watch the changes made to the collection, change streams require a replica set
+func (m *mongodb) Watch() (db.Watcher, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	w := &watcher{coll: m.coll, ctx: ctx, cancel: cancel}
+	if err := w.open(); err != nil {
+		cancel()
+		return nil, err
+	}
+
+	return w, nil
+}
+
+func (m *mongodb) String() string {
+	return "mongodb"
+}
+
+// changeEvent is an event of a change stream
+type changeEvent struct {
+	OperationType string `bson:"operationType"`
+	DocumentKey   struct {
+		Key string `bson:"_id"`
+	} `bson:"documentKey"`
+	FullDocument *document `bson:"fullDocument"`
+}
+
+type watcher struct {
+	coll   *mongo.Collection
+	ctx    context.Context
+	cancel context.CancelFunc
+	stream *mongo.ChangeStream
+	// resume is the token of the last event to resume the stream after
+	resume bson.Raw
+}
+
+// open the change stream resuming after the last event if there was one
+func (w *watcher) open() error {
+	opts := options.ChangeStream().SetFullDocument(options.UpdateLookup)
+	if w.resume != nil {
+		opts.SetResumeAfter(w.resume)
+	}
+
+	stream, err := w.coll.Watch(w.ctx, mongo.Pipeline{}, opts)
+	if err != nil {
+		return err
+	}
+	w.stream = stream
+	return nil
+}
+
+func (w *watcher) Next() (*db.Event, error) {
+	for {
+		if !w.stream.Next(w.ctx) {
+			err := w.stream.Err()
+			w.stream.Close(context.Background())
+			if w.ctx.Err() != nil {
+				return nil, w.ctx.Err()
+			}
+			// the stream is reopened after an error e.g the primary stepped down
+			if oerr := w.open(); oerr != nil {
+				return nil, fmt.Errorf("change stream error: %v", err)
+			}
+			continue
+		}
+
+		w.resume = w.stream.ResumeToken()
+
+		ev := &changeEvent{}
+		if err := w.stream.Decode(ev); err != nil {
+			return nil, err
+		}
+
+		switch strings.ToLower(ev.OperationType) {
+		case "insert", "update", "replace":
+			if ev.FullDocument == nil {
+				// the document was deleted before the update was looked up
+				continue
+			}
+			return &db.Event{
+				Key:    ev.FullDocument.Key,
+				Record: &store.Record{Key: ev.FullDocument.Key, Value: ev.FullDocument.Value},
+			}, nil
+		case "delete":
+			return &db.Event{Key: ev.DocumentKey.Key}, nil
+		}
+	}
+}
+
+func (w *watcher) Stop() error {
+	w.cancel()
+	return nil
+}
@@ -0,0 +1,277 @@
+// Package mysql is a mysql backend for the config db
+package mysql
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"database/sql"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/micro/go-micro/v2/store"
+	"github.com/micro/micro/v2/config/db"
+)
+
+var (
+	defaultUrl = "root@tcp(127.0.0.1:3306)/config"
+	table      = "configs"
+
+	// MaxOpenConns is the default maximum number of open connections, set pool_max_open in the url to override it
+	MaxOpenConns = 10
+	// MaxIdleConns is the default maximum number of idle connections, set pool_max_idle in the url to override it
+	MaxIdleConns = 5
+	// ConnMaxLifetime is the default maximum time a connection is reused, set pool_max_lifetime in the url to override it
+	ConnMaxLifetime = time.Hour
+
+	// tlsConfig is the name the tls config of the tls_ca, tls_cert and tls_key parameters is registered as
+	tlsConfig = "micro-config"
+)
+
+type mysqlDB struct {
+	db *sql.DB
+
+	create *sql.Stmt
+	read   *sql.Stmt
+	update *sql.Stmt
+	delete *sql.Stmt
+	list   *sql.Stmt
+}
+
+func init() {
+	db.Register(new(mysqlDB))
+}
+
+// Init connects to the dsn of the url e.g user:pass@tcp(127.0.0.1:3306)/config?tls=true,
+// a client certificate or private CA is set with tls_ca, tls_cert and tls_key
+func (m *mysqlDB) Init(opts db.Options) error {
+	if opts.Url != "" {
+		defaultUrl = opts.Url
+	}
+
+	if opts.Table != "" {
+		table = opts.Table
+	}
+
+	cfg, err := mysql.ParseDSN(defaultUrl)
+	if err != nil {
+		return fmt.Errorf("invalid url: %v", err)
+	}
+
+	// the parameters which aren't mysql system variables are removed from the dsn
+	params := cfg.Params
+	if params == nil {
+		params = map[string]string{}
+	}
+	cfg.Params = map[string]string{}
+	for k, v := range params {
+		if !strings.HasPrefix(k, "pool_") && !strings.HasPrefix(k, "tls_") {
+			cfg.Params[k] = v
+		}
+	}
+
+	if err := configureTLS(cfg, params); err != nil {
+		return err
+	}
+
+	// updates writing the value unchanged still match the row
+	cfg.ClientFoundRows = true
+
+	maxOpen, err := intParam(params, "pool_max_open", MaxOpenConns)
+	if err != nil {
+		return err
+	}
+	maxIdle, err := intParam(params, "pool_max_idle", MaxIdleConns)
+	if err != nil {
+		return err
+	}
+	maxLifetime := ConnMaxLifetime
+	if v := params["pool_max_lifetime"]; len(v) > 0 {
+		if maxLifetime, err = time.ParseDuration(v); err != nil {
+			return fmt.Errorf("invalid pool_max_lifetime: %v", err)
+		}
+	}
+
+	connector, err := mysql.NewConnector(cfg)
+	if err != nil {
+		return err
+	}
+
+	d := sql.OpenDB(connector)
+	d.SetMaxOpenConns(maxOpen)
+	d.SetMaxIdleConns(maxIdle)
+	d.SetConnMaxLifetime(maxLifetime)
+
+	if err := d.Ping(); err != nil {
+		d.Close()
+		return fmt.Errorf("connect to mysql error: %v", err)
+	}
+
+	m.db = d
+
+	if err := m.prepare(quote(table)); err != nil {
+		d.Close()
+		return err
+	}
+
+	return nil
+}
+
+// configureTLS sets the tls config of the tls_ca, tls_cert and tls_key parameters
+func configureTLS(cfg *mysql.Config, params map[string]string) error {
+	ca, cert, key := params["tls_ca"], params["tls_cert"], params["tls_key"]
+	if len(ca) == 0 && len(cert) == 0 {
+		return nil
+	}
+
+	tc := &tls.Config{ServerName: strings.Split(cfg.Addr, ":")[0]}
+
+	if len(ca) > 0 {
+		b, err := ioutil.ReadFile(ca)
+		if err != nil {
+			return fmt.Errorf("read tls_ca error: %v", err)
+		}
+		tc.RootCAs = x509.NewCertPool()
+		if !tc.RootCAs.AppendCertsFromPEM(b) {
+			return fmt.Errorf("no certificates in tls_ca %s", ca)
+		}
+	}
+
+	if len(cert) > 0 {
+		c, err := tls.LoadX509KeyPair(cert, key)
+		if err != nil {
+			return fmt.Errorf("load tls_cert error: %v", err)
+		}
+		tc.Certificates = []tls.Certificate{c}
+	}
+
+	if err := mysql.RegisterTLSConfig(tlsConfig, tc); err != nil {
+		return err
+	}
+	cfg.TLSConfig = tlsConfig
+
+	return nil
+}
+
+// prepare creates the table if it doesn't exist and prepares the statements
+func (m *mysqlDB) prepare(t string) error {
+	if _, err := m.db.Exec(fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s ("+
+		"`key` VARCHAR(512) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NOT NULL PRIMARY KEY, "+
+		"`value` LONGBLOB NOT NULL, "+
+		"`created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP, "+
+		"`updated_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP"+
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4", t)); err != nil {
+		return fmt.Errorf("create table %s error: %v", table, err)
+	}
+
+	stmts := []struct {
+		stmt  **sql.Stmt
+		query string
+	}{
+		{&m.create, "INSERT INTO %s (`key`, `value`) VALUES (?, ?) ON DUPLICATE KEY UPDATE `value` = VALUES(`value`)"},
+		{&m.read, "SELECT `value` FROM %s WHERE `key` = ?"},
+		{&m.update, "UPDATE %s SET `value` = ? WHERE `key` = ?"},
+		{&m.delete, "DELETE FROM %s WHERE `key` = ?"},
+		{&m.list, "SELECT `key`, `value` FROM %s ORDER BY `key`"},
+	}
+
+	for _, s := range stmts {
+		stmt, err := m.db.Prepare(fmt.Sprintf(s.query, t))
+		if err != nil {
+			return fmt.Errorf("prepare %q error: %v", s.query, err)
+		}
+		*s.stmt = stmt
+	}
+
+	return nil
+}
+
+// Create writes the record replacing the record of the key if there's one
+func (m *mysqlDB) Create(record *store.Record) error {
+	_, err := m.create.Exec(record.Key, record.Value)
+	return err
+}
+
+func (m *mysqlDB) Read(key string) (*store.Record, error) {
+	record := &store.Record{Key: key}
+
+	err := m.read.QueryRow(key).Scan(&record.Value)
+	if err == sql.ErrNoRows {
+		return nil, store.ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+func (m *mysqlDB) Update(record *store.Record) error {
+	res, err := m.update.Exec(record.Value, record.Key)
+	if err != nil {
+		return err
+	}
+	return affected(res)
+}
+
+func (m *mysqlDB) Delete(key string) error {
+	res, err := m.delete.Exec(key)
+	if err != nil {
+		return err
+	}
+	return affected(res)
+}
+
+func (m *mysqlDB) List(opts ...db.ListOption) ([]*store.Record, error) {
+	rows, err := m.list.Query()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []*store.Record
+	for rows.Next() {
+		record := &store.Record{}
+		if err := rows.Scan(&record.Key, &record.Value); err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+
+	return records, rows.Err()
+}
+
+func (m *mysqlDB) String() string {
+	return "mysql"
+}
+
+// affected returns store.ErrNotFound if no rows matched
+func affected(res sql.Result) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
+func intParam(params map[string]string, name string, def int) (int, error) {
+	v := params[name]
+	if len(v) == 0 {
+		return def, nil
+	}
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %v", name, err)
+	}
+	return i, nil
+}
+
+// quote the identifier with backticks
+func quote(s string) string {
+	return "`" + strings.Replace(s, "`", "``", -1) + "`"
+}
@@ -0,0 +1,240 @@
+// Package postgres is a postgres backend for the config db
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"hash/fnv"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/micro/go-micro/v2/store"
+	"github.com/micro/go-micro/v2/util/log"
+	"github.com/micro/micro/v2/config/db"
+)
+
+var (
+	defaultUrl = "postgres://postgres@127.0.0.1:5432/config?sslmode=disable"
+	table      = "configs"
+
+	// MaxOpenConns is the default maximum number of open connections, set pool_max_open in the url to override it
+	MaxOpenConns = 10
+	// MaxIdleConns is the default maximum number of idle connections, set pool_max_idle in the url to override it
+	MaxIdleConns = 5
+	// ConnMaxLifetime is the default maximum time a connection is reused, set pool_max_lifetime in the url to override it
+	ConnMaxLifetime = time.Hour
+)
+
+// migrations create and change the table, each is applied once in order
+var migrations = []func(table string) string{
+	func(table string) string {
+		return fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+			key text NOT NULL PRIMARY KEY,
+			value bytea NOT NULL,
+			created_at timestamptz NOT NULL DEFAULT now(),
+			updated_at timestamptz NOT NULL DEFAULT now()
+		)`, pq.QuoteIdentifier(table))
+	},
+	// the pending changes, revisions and schemas are listed by the prefix of their keys
+	func(table string) string {
+		return fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %s ON %s (key text_pattern_ops)`,
+			pq.QuoteIdentifier(table+"_key_prefix"), pq.QuoteIdentifier(table))
+	},
+}
+
+type postgres struct {
+	db    *sql.DB
+	table string
+}
+
+func init() {
+	db.Register(new(postgres))
+}
+
+func (p *postgres) Init(opts db.Options) error {
+	if opts.Url != "" {
+		defaultUrl = opts.Url
+	}
+
+	if opts.Table != "" {
+		table = opts.Table
+	}
+
+	u, err := url.Parse(defaultUrl)
+	if err != nil {
+		return fmt.Errorf("invalid url: %v", err)
+	}
+
+	// the pool options aren't postgres parameters so they're removed from the url
+	q := u.Query()
+	maxOpen, err := intParam(q, "pool_max_open", MaxOpenConns)
+	if err != nil {
+		return err
+	}
+	maxIdle, err := intParam(q, "pool_max_idle", MaxIdleConns)
+	if err != nil {
+		return err
+	}
+	maxLifetime := ConnMaxLifetime
+	if v := q.Get("pool_max_lifetime"); len(v) > 0 {
+		if maxLifetime, err = time.ParseDuration(v); err != nil {
+			return fmt.Errorf("invalid pool_max_lifetime: %v", err)
+		}
+	}
+	q.Del("pool_max_open")
+	q.Del("pool_max_idle")
+	q.Del("pool_max_lifetime")
+	u.RawQuery = q.Encode()
+
+	d, err := sql.Open("postgres", u.String())
+	if err != nil {
+		return err
+	}
+
+	d.SetMaxOpenConns(maxOpen)
+	d.SetMaxIdleConns(maxIdle)
+	d.SetConnMaxLifetime(maxLifetime)
+
+	if err := d.Ping(); err != nil {
+		d.Close()
+		return fmt.Errorf("connect to postgres error: %v", err)
+	}
+
+	p.db = d
+	p.table = pq.QuoteIdentifier(table)
+
+	if err := p.migrate(); err != nil {
+		d.Close()
+		return fmt.Errorf("migrate %s error: %v", table, err)
+	}
+
+	return nil
+}
+
+// migrate applies the migrations which haven't been, instances starting
+// together are serialised by an advisory lock held for the transaction
+func (p *postgres) migrate() error {
+	tx, err := p.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	h := fnv.New64a()
+	h.Write([]byte("go.micro.config.migrate." + table))
+	if _, err := tx.Exec(`SELECT pg_advisory_xact_lock($1)`, int64(h.Sum64())); err != nil {
+		return err
+	}
+
+	versions := pq.QuoteIdentifier(table + "_migrations")
+	if _, err := tx.Exec(fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+		version integer NOT NULL PRIMARY KEY,
+		applied_at timestamptz NOT NULL DEFAULT now()
+	)`, versions)); err != nil {
+		return err
+	}
+
+	var version int
+	if err := tx.QueryRow(fmt.Sprintf(`SELECT COALESCE(MAX(version), 0) FROM %s`, versions)).Scan(&version); err != nil {
+		return err
+	}
+
+	for i := version; i < len(migrations); i++ {
+		if _, err := tx.Exec(migrations[i](table)); err != nil {
+			return fmt.Errorf("migration %d: %v", i+1, err)
+		}
+		if _, err := tx.Exec(fmt.Sprintf(`INSERT INTO %s (version) VALUES ($1)`, versions), i+1); err != nil {
+			return err
+		}
+		log.Logf("Applied config db migration %d to %s", i+1, table)
+	}
+
+	return tx.Commit()
+}
+
+// Create writes the record replacing the record of the key if there's one
+func (p *postgres) Create(record *store.Record) error {
+	_, err := p.db.Exec(fmt.Sprintf(`INSERT INTO %s (key, value) VALUES ($1, $2)
+		ON CONFLICT (key) DO UPDATE SET value = EXCLUDED.value, updated_at = now()`, p.table),
+		record.Key, record.Value)
+	return err
+}
+
+func (p *postgres) Read(key string) (*store.Record, error) {
+	record := &store.Record{Key: key}
+
+	err := p.db.QueryRow(fmt.Sprintf(`SELECT value FROM %s WHERE key = $1`, p.table), key).Scan(&record.Value)
+	if err == sql.ErrNoRows {
+		return nil, store.ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+func (p *postgres) Update(record *store.Record) error {
+	res, err := p.db.Exec(fmt.Sprintf(`UPDATE %s SET value = $2, updated_at = now() WHERE key = $1`, p.table),
+		record.Key, record.Value)
+	if err != nil {
+		return err
+	}
+	return affected(res)
+}
+
+func (p *postgres) Delete(key string) error {
+	res, err := p.db.Exec(fmt.Sprintf(`DELETE FROM %s WHERE key = $1`, p.table), key)
+	if err != nil {
+		return err
+	}
+	return affected(res)
+}
+
+func (p *postgres) List(opts ...db.ListOption) ([]*store.Record, error) {
+	rows, err := p.db.Query(fmt.Sprintf(`SELECT key, value FROM %s ORDER BY key`, p.table))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []*store.Record
+	for rows.Next() {
+		record := &store.Record{}
+		if err := rows.Scan(&record.Key, &record.Value); err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+
+	return records, rows.Err()
+}
+
+func (p *postgres) String() string {
+	return "postgres"
+}
+
+// affected returns store.ErrNotFound if no rows were changed
+func affected(res sql.Result) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
+func intParam(q url.Values, name string, def int) (int, error) {
+	v := q.Get(name)
+	if len(v) == 0 {
+		return def, nil
+	}
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %v", name, err)
+	}
+	return i, nil
+}
@@ -0,0 +1,271 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/micro/cli/v2"
+	"github.com/micro/go-micro/v2/client"
+	mp "github.com/micro/go-micro/v2/config/source/service/proto"
+)
+
+// leaf is a value of the config at a path
+type leaf struct {
+	Path  string
+	Value string
+	// Old value when changed
+	Old string
+}
+
+// diff of the config copied to the existing config
+type diff struct {
+	Added     []leaf
+	Changed   []leaf
+	Unchanged []leaf
+}
+
+// flatten the decoded config into its JSON encoded values keyed by path
+func flatten(prefix string, v interface{}, leaves map[string]string) {
+	if m, ok := v.(map[string]interface{}); ok && len(m) > 0 {
+		for k, val := range m {
+			path := k
+			if len(prefix) > 0 {
+				path = prefix + "/" + k
+			}
+			flatten(path, val, leaves)
+		}
+		return
+	}
+	b, _ := json.Marshal(v)
+	leaves[prefix] = string(b)
+}
+
+// decode the config returning nil if there's none, integers are decoded
+// as int64 so they keep their type when encoded in other formats
+func decode(b []byte) (interface{}, error) {
+	if len(b) == 0 {
+		return nil, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return numbers(v), nil
+}
+
+// numbers replaces the json numbers of the value with int64 or float64
+func numbers(v interface{}) interface{} {
+	switch t := v.(type) {
+	case json.Number:
+		if i, err := t.Int64(); err == nil {
+			return i
+		}
+		f, _ := t.Float64()
+		return f
+	case map[string]interface{}:
+		for k, e := range t {
+			t[k] = numbers(e)
+		}
+	case []interface{}:
+		for i, e := range t {
+			t[i] = numbers(e)
+		}
+	}
+	return v
+}
+
+// lookup the config at the path returning nil if there's none
+func lookup(v interface{}, path string) interface{} {
+	if len(path) == 0 {
+		return v
+	}
+	for _, k := range strings.Split(path, "/") {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		v = m[k]
+	}
+	return v
+}
+
+// compare the config copied from with the config copied to
+func compare(from, to interface{}) *diff {
+	src := make(map[string]string)
+	dst := make(map[string]string)
+
+	if from != nil {
+		flatten("", from, src)
+	}
+	if to != nil {
+		flatten("", to, dst)
+	}
+
+	var paths []string
+	for p := range src {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	d := new(diff)
+	for _, p := range paths {
+		old, ok := dst[p]
+		switch {
+		case !ok:
+			d.Added = append(d.Added, leaf{Path: p, Value: src[p]})
+		case old != src[p]:
+			d.Changed = append(d.Changed, leaf{Path: p, Value: src[p], Old: old})
+		default:
+			d.Unchanged = append(d.Unchanged, leaf{Path: p, Value: src[p]})
+		}
+	}
+	return d
+}
+
+// merge the config copied from into the config copied to,
+// values of the config copied to which aren't copied are kept
+func merge(from, to interface{}) interface{} {
+	src, ok := from.(map[string]interface{})
+	if !ok {
+		return from
+	}
+	dst, ok := to.(map[string]interface{})
+	if !ok {
+		return from
+	}
+
+	merged := make(map[string]interface{})
+	for k, v := range dst {
+		merged[k] = v
+	}
+	for k, v := range src {
+		merged[k] = merge(v, dst[k])
+	}
+	return merged
+}
+
+// diffCommand compares the config of two namespaces
+func diffCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "diff",
+		Usage:     "Compare the config of two namespaces e.g micro config diff staging production --path app/payments",
+		ArgsUsage: "[namespace] [namespace]",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "path",
+				Usage: "Set the path of the config compared e.g app/payments, defaults to all of it",
+			},
+			&cli.BoolFlag{
+				Name:  "json",
+				Usage: "Print the differences as json",
+			},
+			&cli.BoolFlag{
+				Name:  "raw",
+				Usage: "Compare the config with its references e.g ${global:db/host} unresolved",
+			},
+			&cli.BoolFlag{
+				Name:  "exit-code",
+				Usage: "Exit with 1 if the config differs",
+			},
+			&cli.StringFlag{
+				Name:    "token",
+				EnvVars: []string{"MICRO_NAMESPACE_TOKEN"},
+				Usage:   "The token of the account reading the config",
+			},
+		},
+		Action: diffConfig,
+	}
+}
+
+// difference of a value of the config between namespaces
+type difference struct {
+	Path string          `json:"path"`
+	Old  json.RawMessage `json:"old,omitempty"`
+	New  json.RawMessage `json:"new,omitempty"`
+}
+
+// drift of the config from one namespace to another
+type drift struct {
+	Added   []difference `json:"added"`
+	Removed []difference `json:"removed"`
+	Changed []difference `json:"changed"`
+}
+
+// compareNamespaces returns the values added, removed and changed from the config of one namespace to another
+func compareNamespaces(from, to interface{}) *drift {
+	d := &drift{Added: []difference{}, Removed: []difference{}, Changed: []difference{}}
+
+	for _, l := range compare(to, from).Added {
+		d.Added = append(d.Added, difference{Path: l.Path, New: json.RawMessage(l.Value)})
+	}
+	for _, l := range compare(from, to).Added {
+		d.Removed = append(d.Removed, difference{Path: l.Path, Old: json.RawMessage(l.Value)})
+	}
+	for _, l := range compare(to, from).Changed {
+		d.Changed = append(d.Changed, difference{Path: l.Path, Old: json.RawMessage(l.Old), New: json.RawMessage(l.Value)})
+	}
+
+	return d
+}
+
+// diffConfig prints the values added, removed and changed from the first namespace to the second
+func diffConfig(c *cli.Context) error {
+	if c.Args().Len() != 2 {
+		return fmt.Errorf("require the two namespaces compared")
+	}
+	from, to := c.Args().Get(0), c.Args().Get(1)
+	path := configPath(c.String("path"))
+
+	ctx := changesContext(c)
+	if c.Bool("raw") {
+		ctx = rawContext(c)
+	}
+	cfg := mp.NewConfigService(Name, client.DefaultClient)
+
+	src, ok, err := readConfig(ctx, cfg, from, path)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("no config in %s", from)
+	}
+	dst, ok, err := readConfig(ctx, cfg, to, path)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("no config in %s", to)
+	}
+
+	d := compareNamespaces(src, dst)
+
+	if c.Bool("json") {
+		b, err := json.MarshalIndent(d, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+	} else {
+		for _, l := range d.Added {
+			fmt.Printf("+ %s = %s\n", l.Path, l.New)
+		}
+		for _, l := range d.Removed {
+			fmt.Printf("- %s (was %s)\n", l.Path, l.Old)
+		}
+		for _, l := range d.Changed {
+			fmt.Printf("~ %s = %s (was %s)\n", l.Path, l.New, l.Old)
+		}
+		fmt.Printf("%d added, %d removed, %d changed from %s to %s\n", len(d.Added), len(d.Removed), len(d.Changed), from, to)
+	}
+
+	if c.Bool("exit-code") && len(d.Added)+len(d.Removed)+len(d.Changed) > 0 {
+		os.Exit(1)
+	}
+	return nil
+}
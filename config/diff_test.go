@@ -0,0 +1,98 @@
+package config
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	from, _ := decode([]byte(`{"timeout": 10, "db": {"host": "prod-db", "port": 5432}, "tags": ["a"]}`))
+	to, _ := decode([]byte(`{"timeout": 5, "db": {"port": 5432, "user": "app"}}`))
+
+	d := compare(from, to)
+
+	if len(d.Added) != 2 || d.Added[0].Path != "db/host" || d.Added[1].Path != "tags" {
+		t.Fatalf("Expected db/host and tags to be added got %+v", d.Added)
+	}
+	if len(d.Changed) != 1 || d.Changed[0].Path != "timeout" || d.Changed[0].Old != "5" || d.Changed[0].Value != "10" {
+		t.Fatalf("Expected timeout to change from 5 to 10 got %+v", d.Changed)
+	}
+	if len(d.Unchanged) != 1 || d.Unchanged[0].Path != "db/port" {
+		t.Fatalf("Expected db/port to be unchanged got %+v", d.Unchanged)
+	}
+
+	// nothing to copy to
+	if d := compare(from, nil); len(d.Added) != 4 {
+		t.Fatalf("Expected every value to be added got %+v", d.Added)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	from, _ := decode([]byte(`{"timeout": 10, "db": {"host": "prod-db"}}`))
+	to, _ := decode([]byte(`{"timeout": 5, "db": {"port": 5432}, "name": "payments"}`))
+
+	b, err := json.Marshal(merge(from, to))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect := `{"db":{"host":"prod-db","port":5432},"name":"payments","timeout":10}`
+	if string(b) != expect {
+		t.Fatalf("Expected %s got %s", expect, b)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	v, _ := decode([]byte(`{"db": {"host": "prod-db", "port": 5432}}`))
+
+	if host := lookup(v, "db/host"); host != "prod-db" {
+		t.Fatalf("Expected prod-db got %v", host)
+	}
+	if db, ok := lookup(v, "db").(map[string]interface{}); !ok || len(db) != 2 {
+		t.Fatalf("Expected the db config got %v", lookup(v, "db"))
+	}
+	if missing := lookup(v, "db/host/name"); missing != nil {
+		t.Fatalf("Expected nil for a path below a value got %v", missing)
+	}
+	if lookup(v, "") == nil {
+		t.Fatal("Expected the config for the root path")
+	}
+}
+
+func TestDecodeNumbers(t *testing.T) {
+	v, err := decode([]byte(`{"port": 5432, "ratio": 0.5, "ports": [80, 443]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := v.(map[string]interface{})
+	if _, ok := m["port"].(int64); !ok {
+		t.Fatalf("Expected the port to be an int64 got %T", m["port"])
+	}
+	if _, ok := m["ratio"].(float64); !ok {
+		t.Fatalf("Expected the ratio to be a float64 got %T", m["ratio"])
+	}
+	if ports := m["ports"].([]interface{}); ports[1] != int64(443) {
+		t.Fatalf("Expected the ports to be int64s got %T", ports[1])
+	}
+}
+
+func TestCompareNamespaces(t *testing.T) {
+	staging, _ := decode([]byte(`{"timeout": 5, "db": {"host": "staging-db", "port": 5432}, "debug": true}`))
+	production, _ := decode([]byte(`{"timeout": 5, "db": {"host": "prod-db", "port": 5432}, "replicas": 3}`))
+
+	b, err := json.Marshal(compareNamespaces(staging, production))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect := `{"added":[{"path":"replicas","new":3}],"removed":[{"path":"debug","old":true}],"changed":[{"path":"db/host","old":"staging-db","new":"prod-db"}]}`
+	if string(b) != expect {
+		t.Fatalf("Expected %s got %s", expect, b)
+	}
+
+	// the same config
+	if d := compareNamespaces(staging, staging); len(d.Added)+len(d.Removed)+len(d.Changed) > 0 {
+		t.Fatalf("Expected no differences got %+v", d)
+	}
+}
@@ -0,0 +1,202 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/micro/cli/v2"
+	"github.com/micro/go-micro/v2/client"
+	mp "github.com/micro/go-micro/v2/config/source/service/proto"
+)
+
+// exportCommands import documents into the config and export the config as documents
+func exportCommands() []*cli.Command {
+	flags := []cli.Flag{
+		&cli.StringFlag{
+			Name:  "namespace",
+			Usage: "Set the namespace of the config",
+			Value: Namespace,
+		},
+		&cli.StringFlag{
+			Name:  "path",
+			Usage: "Set the path of the config e.g service/foo, defaults to all of it",
+		},
+		&cli.StringFlag{
+			Name:  "format",
+			Usage: "Set the format of the document; json, yaml or toml, defaults to the extension of the file",
+		},
+		&cli.StringFlag{
+			Name:    "token",
+			EnvVars: []string{"MICRO_NAMESPACE_TOKEN"},
+			Usage:   "The token of the account making the changes",
+		},
+	}
+
+	return []*cli.Command{
+		{
+			Name:  "import",
+			Usage: "Merge a document into the config e.g micro config import --file app.yaml --path service/foo",
+			Flags: append([]cli.Flag{
+				&cli.StringFlag{
+					Name:     "file",
+					Aliases:  []string{"f"},
+					Usage:    "Set the file imported, - for stdin",
+					Required: true,
+				},
+				&cli.BoolFlag{
+					Name:  "dry-run",
+					Usage: "Print the changes without importing them",
+				},
+			}, flags...),
+			Action: importConfig,
+		},
+		{
+			Name:  "export",
+			Usage: "Write the config as a document e.g micro config export --path service/foo --file app.yaml",
+			Flags: append([]cli.Flag{
+				&cli.StringFlag{
+					Name:    "file",
+					Aliases: []string{"f"},
+					Usage:   "Set the file exported to, - for stdout",
+					Value:   "-",
+				},
+				&cli.BoolFlag{
+					Name:  "raw",
+					Usage: "Export the config with its references e.g ${global:db/host} unresolved",
+				},
+			}, flags...),
+			Action: exportConfig,
+		},
+	}
+}
+
+// documentFormat returns the format set or the format of the file by its extension, json by default
+func documentFormat(c *cli.Context) string {
+	if f := c.String("format"); len(f) > 0 {
+		return f
+	}
+	switch strings.ToLower(filepath.Ext(c.String("file"))) {
+	case ".yaml", ".yml":
+		return "yaml"
+	case ".toml":
+		return "toml"
+	}
+	return "json"
+}
+
+// readDocument reads the document of the file, - for stdin
+func readDocument(c *cli.Context) (interface{}, error) {
+	file := c.String("file")
+
+	var b []byte
+	var err error
+	if file == "-" {
+		b, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		b, err = ioutil.ReadFile(file)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := decodeValue(b, documentFormat(c))
+	if err != nil {
+		return nil, err
+	}
+	doc, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s is not a document of %s", file, documentFormat(c))
+	}
+
+	// round trip the document through json so its values have the types
+	// of the values read from the config e.g integers of yaml are float64
+	src, err := encodeValue(doc, "json")
+	if err != nil {
+		return nil, err
+	}
+	return decode(src)
+}
+
+// importConfig merges the document into the config at the path, the
+// values of the config which aren't in the document are kept
+func importConfig(c *cli.Context) error {
+	namespace := c.String("namespace")
+	path := configPath(c.String("path"))
+	file := c.String("file")
+
+	from, err := readDocument(c)
+	if err != nil {
+		return err
+	}
+
+	// the references of the config are kept
+	ctx := rawContext(c)
+	cfg := mp.NewConfigService(Name, client.DefaultClient)
+
+	old, _, err := readConfig(ctx, cfg, namespace, path)
+	if err != nil {
+		return err
+	}
+	_, exists, err := readConfig(ctx, cfg, namespace, "")
+	if err != nil {
+		return err
+	}
+
+	d := compare(from, old)
+	for _, l := range d.Added {
+		fmt.Printf("+ %s = %s\n", l.Path, l.Value)
+	}
+	for _, l := range d.Changed {
+		fmt.Printf("~ %s = %s (was %s)\n", l.Path, l.Value, l.Old)
+	}
+	fmt.Printf("%d added, %d changed, %d unchanged\n", len(d.Added), len(d.Changed), len(d.Unchanged))
+
+	if c.Bool("dry-run") || len(d.Added)+len(d.Changed) == 0 {
+		return nil
+	}
+
+	if err := writeConfig(ctx, cfg, namespace, path, exists, merge(from, old)); err != nil {
+		return err
+	}
+
+	fmt.Printf("Imported %s into %s, change %s\n", file, namespace, changeID(c, cfg, namespace))
+	return nil
+}
+
+// exportConfig writes the config at the path as a document
+func exportConfig(c *cli.Context) error {
+	namespace := c.String("namespace")
+	path := configPath(c.String("path"))
+
+	cfg := mp.NewConfigService(Name, client.DefaultClient)
+
+	ctx := changesContext(c)
+	if c.Bool("raw") {
+		ctx = rawContext(c)
+	}
+
+	v, ok, err := readConfig(ctx, cfg, namespace, path)
+	if err != nil {
+		return err
+	}
+	if !ok || v == nil {
+		return fmt.Errorf("no config at %s in %s", c.String("path"), namespace)
+	}
+
+	b, err := encodeValue(v, documentFormat(c))
+	if err != nil {
+		return err
+	}
+	if len(b) > 0 && b[len(b)-1] != '\n' {
+		b = append(b, '\n')
+	}
+
+	if file := c.String("file"); file != "-" {
+		return ioutil.WriteFile(file, b, 0644)
+	}
+	_, err = os.Stdout.Write(b)
+	return err
+}
@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
+
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/metadata"
+	"golang.org/x/net/context"
+)
+
+var (
+	// Rules scope the accounts and tokens to the namespaces and paths of the config
+	// they can read or write, every caller has full access if there are none
+	Rules []*Rule
+)
+
+// Access granted by a rule, write access includes read access
+type Access string
+
+const (
+	ReadAccess  Access = "read"
+	WriteAccess Access = "write"
+)
+
+// Rule grants an account or a token access to the config of a namespace at a path and within it
+type Rule struct {
+	// Account is the account of the verified token of the request
+	Account string `json:"account,omitempty"`
+	// Token is the bearer token of the request, or sha256:<hex> of it
+	Token string `json:"token,omitempty"`
+	// Access is read or write
+	Access Access `json:"access"`
+	// Namespace is the key of the config, * for all of them
+	Namespace string `json:"namespace"`
+	// Path is the path prefix e.g app/db, all of the config if it's empty
+	Path string `json:"path,omitempty"`
+}
+
+// bearer returns the token of the request
+func bearer(ctx context.Context) string {
+	md, ok := metadata.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	return strings.TrimPrefix(md["Authorization"], "Bearer ")
+}
+
+// matches returns true if the rule is for the account or the token
+func (r *Rule) matches(acc, tok string) bool {
+	if len(r.Account) > 0 && r.Account == acc {
+		return true
+	}
+	if len(r.Token) == 0 || len(tok) == 0 {
+		return false
+	}
+	if strings.HasPrefix(r.Token, "sha256:") {
+		sum := sha256.Sum256([]byte(tok))
+		tok = "sha256:" + hex.EncodeToString(sum[:])
+	}
+	return subtle.ConstantTimeCompare([]byte(r.Token), []byte(tok)) == 1
+}
+
+// grants returns true if the rule grants the access to the config of the key at the path
+func (r *Rule) grants(access Access, key, path string) bool {
+	if access == WriteAccess && r.Access != WriteAccess {
+		return false
+	}
+	if r.Namespace != "*" && r.Namespace != key {
+		return false
+	}
+	prefix := strings.Trim(r.Path, PathSplitter)
+	return len(prefix) == 0 || path == prefix || strings.HasPrefix(path, prefix+PathSplitter)
+}
+
+// authorize returns errors.Forbidden with the id if the caller can't access the config of the key at the path
+func authorize(ctx context.Context, id string, access Access, key, path string) error {
+	if len(Rules) == 0 {
+		return nil
+	}
+	// expiring a lease reverts the config as it was set by the lease
+	if v, ok := ctx.Value(expiredKey{}).(bool); ok && v {
+		return nil
+	}
+
+	acc, tok := account(ctx), bearer(ctx)
+	path = strings.Trim(path, PathSplitter)
+
+	for _, r := range Rules {
+		if r.matches(acc, tok) && r.grants(access, key, path) {
+			return nil
+		}
+	}
+
+	if len(path) == 0 {
+		return errors.Forbidden(id, "%s access to %s is forbidden", access, key)
+	}
+	return errors.Forbidden(id, "%s access to %s of %s is forbidden", access, path, key)
+}
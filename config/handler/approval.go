@@ -0,0 +1,280 @@
+package handler
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/uuid"
+	mp "github.com/micro/go-micro/v2/config/source/service/proto"
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/store"
+	"github.com/micro/go-micro/v2/util/log"
+	pb "github.com/micro/micro/v2/config/changes/proto"
+	"github.com/micro/micro/v2/config/db"
+	"github.com/micro/micro/v2/internal/namespace"
+	"golang.org/x/net/context"
+)
+
+var (
+	// ApprovalKeys are the config keys (namespaces) where writes
+	// must be approved by a second account before being applied
+	ApprovalKeys = map[string]bool{}
+	// Approvers are the accounts allowed to approve changes
+	Approvers = map[string]bool{}
+
+	// pendingPrefix is the db key prefix for staged changes
+	pendingPrefix = "__pending__/"
+)
+
+// approvedKey marks a context as applying an approved change
+type approvedKey struct{}
+
+// accountKey sets the account of the changes made by the config service itself
+type accountKey struct{}
+
+// pendingChange is the staged change as stored in the db
+type pendingChange struct {
+	Id      string `json:"id"`
+	Action  string `json:"action"`
+	Account string `json:"account"`
+	Created int64  `json:"created"`
+	// Change is the proto encoded mp.Change
+	Change []byte `json:"change"`
+}
+
+// Changes handles the approval of pending config changes
+type Changes struct {
+	Config *Handler
+}
+
+// account returns the account of the verified token of the request
+func account(ctx context.Context) string {
+	if acc, ok := ctx.Value(accountKey{}).(string); ok {
+		return acc
+	}
+	return namespace.Account(ctx)
+}
+
+// requiresApproval returns true if changes to the key must be staged
+func requiresApproval(ctx context.Context, key string) bool {
+	if v, ok := ctx.Value(approvedKey{}).(bool); ok && v {
+		return false
+	}
+	return ApprovalKeys["*"] || ApprovalKeys[key]
+}
+
+// stage saves the change as pending and returns an error with
+// status 202 so the caller knows the change has not been applied
+func stage(ctx context.Context, id, action string, ch *mp.Change) error {
+	acc := account(ctx)
+	if len(acc) == 0 {
+		return errors.Forbidden(id, "account required to propose a change to %s", ch.Key)
+	}
+
+	b, err := proto.Marshal(ch)
+	if err != nil {
+		return errors.InternalServerError(id, "marshal error: %v", err)
+	}
+
+	pc := &pendingChange{
+		Id:      uuid.New().String(),
+		Action:  action,
+		Account: acc,
+		Created: time.Now().Unix(),
+		Change:  b,
+	}
+
+	v, err := json.Marshal(pc)
+	if err != nil {
+		return errors.InternalServerError(id, "marshal error: %v", err)
+	}
+
+	if err := db.Create(&store.Record{Key: pendingPrefix + pc.Id, Value: v}); err != nil {
+		return errors.InternalServerError(id, "create pending change error: %v", err)
+	}
+
+	log.Infof("Change %s to %s proposed by %s is pending approval", pc.Id, ch.Key, acc)
+
+	return errors.New(id, "change "+pc.Id+" pending approval", 202)
+}
+
+func isPending(key string) bool {
+	return strings.HasPrefix(key, pendingPrefix)
+}
+
+func readPending(id string) (*pendingChange, *mp.Change, error) {
+	rec, err := db.Read(pendingPrefix + id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pc := &pendingChange{}
+	if err := json.Unmarshal(rec.Value, pc); err != nil {
+		return nil, nil, err
+	}
+
+	ch := &mp.Change{}
+	if err := proto.Unmarshal(pc.Change, ch); err != nil {
+		return nil, nil, err
+	}
+
+	return pc, ch, nil
+}
+
+func (c *Changes) List(ctx context.Context, req *pb.ListRequest, rsp *pb.ListResponse) (err error) {
+	defer func() {
+		if err != nil {
+			log.Error(err)
+		}
+	}()
+
+	// pending changes are kept by the active instance
+	if ok, err := c.Config.forward(ctx, "Changes.List", req, rsp); ok {
+		return err
+	}
+
+	list, err := db.List()
+	if err != nil {
+		err = errors.BadRequest("go.micro.config.Changes.List", "query value error: %v", err)
+		return err
+	}
+
+	for _, v := range list {
+		if !isPending(v.Key) {
+			continue
+		}
+
+		// an unreadable change is skipped rather than failing the list for everyone
+		pc, ch, err := readPending(strings.TrimPrefix(v.Key, pendingPrefix))
+		if err != nil {
+			log.Errorf("Error reading pending change %s: %v", v.Key, err)
+			continue
+		}
+
+		if len(req.Key) > 0 && req.Key != ch.Key {
+			continue
+		}
+		if authorize(ctx, "go.micro.config.Changes.List", ReadAccess, ch.Key, ch.Path) != nil {
+			continue
+		}
+
+		change := &pb.PendingChange{
+			Id:      pc.Id,
+			Action:  pc.Action,
+			Account: pc.Account,
+			Created: pc.Created,
+			Key:     ch.Key,
+			Path:    ch.Path,
+		}
+		if ch.ChangeSet != nil {
+			change.Data = mask(ch.ChangeSet.Data)
+		}
+
+		rsp.Changes = append(rsp.Changes, change)
+	}
+
+	return nil
+}
+
+func (c *Changes) Approve(ctx context.Context, req *pb.ApproveRequest, rsp *pb.ApproveResponse) (err error) {
+	defer func() {
+		if err != nil {
+			log.Error(err)
+		}
+	}()
+
+	// pending changes are kept by the active instance
+	if ok, err := c.Config.forward(ctx, "Changes.Approve", req, rsp); ok {
+		return err
+	}
+
+	if len(req.Id) == 0 {
+		err = errors.BadRequest("go.micro.config.Changes.Approve", "invalid id")
+		return err
+	}
+
+	acc := account(ctx)
+	if !Approvers[acc] {
+		err = errors.Forbidden("go.micro.config.Changes.Approve", "account %q is not an approver", acc)
+		return err
+	}
+
+	pc, ch, err := readPending(req.Id)
+	if err != nil {
+		err = errors.NotFound("go.micro.config.Changes.Approve", "change %s not found", req.Id)
+		return err
+	}
+
+	// two person rule
+	if pc.Account == acc {
+		err = errors.Forbidden("go.micro.config.Changes.Approve", "change %s can not be approved by its proposer", req.Id)
+		return err
+	}
+
+	// apply the change bypassing approval
+	actx := context.WithValue(ctx, approvedKey{}, true)
+
+	switch pc.Action {
+	case "create":
+		err = c.Config.Create(actx, &mp.CreateRequest{Change: ch}, &mp.CreateResponse{})
+	case "update":
+		err = c.Config.Update(actx, &mp.UpdateRequest{Change: ch}, &mp.UpdateResponse{})
+	case "delete":
+		err = c.Config.Delete(actx, &mp.DeleteRequest{Change: ch}, &mp.DeleteResponse{})
+	default:
+		err = errors.InternalServerError("go.micro.config.Changes.Approve", "unknown action %s", pc.Action)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := db.Delete(pendingPrefix + req.Id); err != nil {
+		log.Errorf("Error deleting approved change %s: %v", req.Id, err)
+	}
+
+	log.Infof("Change %s to %s proposed by %s approved by %s", pc.Id, ch.Key, pc.Account, acc)
+
+	return nil
+}
+
+func (c *Changes) Reject(ctx context.Context, req *pb.RejectRequest, rsp *pb.RejectResponse) (err error) {
+	defer func() {
+		if err != nil {
+			log.Error(err)
+		}
+	}()
+
+	// pending changes are kept by the active instance
+	if ok, err := c.Config.forward(ctx, "Changes.Reject", req, rsp); ok {
+		return err
+	}
+
+	if len(req.Id) == 0 {
+		err = errors.BadRequest("go.micro.config.Changes.Reject", "invalid id")
+		return err
+	}
+
+	pc, ch, err := readPending(req.Id)
+	if err != nil {
+		err = errors.NotFound("go.micro.config.Changes.Reject", "change %s not found", req.Id)
+		return err
+	}
+
+	// the proposer may withdraw their own change
+	acc := account(ctx)
+	if !Approvers[acc] && pc.Account != acc {
+		err = errors.Forbidden("go.micro.config.Changes.Reject", "account %q is not an approver", acc)
+		return err
+	}
+
+	if err := db.Delete(pendingPrefix + req.Id); err != nil {
+		err = errors.InternalServerError("go.micro.config.Changes.Reject", "delete pending change error: %v", err)
+		return err
+	}
+
+	log.Infof("Change %s to %s proposed by %s rejected by %s", pc.Id, ch.Key, pc.Account, acc)
+
+	return nil
+}
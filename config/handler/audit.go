@@ -0,0 +1,181 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/uuid"
+	mp "github.com/micro/go-micro/v2/config/source/service/proto"
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/store"
+	"github.com/micro/go-micro/v2/util/log"
+	pb "github.com/micro/micro/v2/config/changes/proto"
+	"github.com/micro/micro/v2/config/db"
+	"golang.org/x/net/context"
+)
+
+var (
+	// auditPrefix is the db key prefix for the audit log, its entries are never changed or deleted
+	auditPrefix = "__audit__/"
+)
+
+// auditEntry is a change made to the config as stored in the db
+type auditEntry struct {
+	Id        string `json:"id"`
+	Timestamp int64  `json:"timestamp"`
+	Account   string `json:"account"`
+	Token     string `json:"token,omitempty"`
+	Action    string `json:"action"`
+	Key       string `json:"key"`
+	Path      string `json:"path"`
+	Old       []byte `json:"old,omitempty"`
+	New       []byte `json:"new,omitempty"`
+}
+
+func isAudit(key string) bool {
+	return strings.HasPrefix(key, auditPrefix)
+}
+
+// token returns the fingerprint of the token of the request rather than the token itself
+func token(ctx context.Context) string {
+	t := bearer(ctx)
+	if len(t) == 0 {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(t))
+	return hex.EncodeToString(sum[:6])
+}
+
+// current returns the config data of the key, nil if there's none
+func current(key string) []byte {
+	rec, err := db.Read(key)
+	if err != nil {
+		return nil
+	}
+	ch := &mp.Change{}
+	if err := proto.Unmarshal(rec.Value, ch); err != nil || ch.ChangeSet == nil {
+		return nil
+	}
+	return ch.ChangeSet.Data
+}
+
+// audit the change to the config of the key at the path from the data before to after it,
+// a failure is logged rather than failing the change which has been applied
+func audit(ctx context.Context, action, key, path string, before, after []byte) {
+	now := time.Now()
+
+	e := &auditEntry{
+		// the ids sort by the time of the change
+		Id:        fmt.Sprintf("%020d-%s", now.UnixNano(), uuid.New().String()[:8]),
+		Timestamp: now.Unix(),
+		Account:   account(ctx),
+		Token:     token(ctx),
+		Action:    action,
+		Key:       key,
+		Path:      path,
+		Old:       mask(valueAt(before, path)),
+		New:       mask(valueAt(after, path)),
+	}
+	if _, ok := ctx.Value(rollbackKey{}).(int64); ok {
+		e.Action = "rollback"
+	}
+	if _, ok := ctx.Value(expiredKey{}).(bool); ok {
+		e.Action = "expire"
+	}
+
+	b, err := json.Marshal(e)
+	if err != nil {
+		log.Errorf("Error auditing the change to %s of %s: %v", path, key, err)
+		return
+	}
+
+	if err := db.Create(&store.Record{Key: auditPrefix + e.Id, Value: b}); err != nil {
+		log.Errorf("Error auditing the change to %s of %s: %v", path, key, err)
+	}
+}
+
+// overlaps returns true if either path is within the other, the empty path is all of the config
+func overlaps(a, b string) bool {
+	if len(a) == 0 || len(b) == 0 || a == b {
+		return true
+	}
+	return strings.HasPrefix(a, b+PathSplitter) || strings.HasPrefix(b, a+PathSplitter)
+}
+
+func (c *Changes) Audit(ctx context.Context, req *pb.AuditRequest, rsp *pb.AuditResponse) (err error) {
+	defer func() {
+		if err != nil {
+			log.Error(err)
+		}
+	}()
+
+	// the audit log is recorded by the active instance
+	if ok, err := c.Config.forward(ctx, "Changes.Audit", req, rsp); ok {
+		return err
+	}
+
+	list, err := db.List()
+	if err != nil {
+		err = errors.InternalServerError("go.micro.config.Changes.Audit", "query value error: %v", err)
+		return err
+	}
+
+	path := strings.Trim(req.Path, PathSplitter)
+
+	var entries []*auditEntry
+	for _, v := range list {
+		if !isAudit(v.Key) {
+			continue
+		}
+
+		e := &auditEntry{}
+		if err := json.Unmarshal(v.Value, e); err != nil {
+			err = errors.InternalServerError("go.micro.config.Changes.Audit", "unmarshal audit entry error: %v", err)
+			return err
+		}
+
+		if len(req.Key) > 0 && e.Key != req.Key {
+			continue
+		}
+		if !overlaps(e.Path, path) {
+			continue
+		}
+		if req.Since > 0 && e.Timestamp < req.Since {
+			continue
+		}
+		if authorize(ctx, "go.micro.config.Changes.Audit", ReadAccess, e.Key, e.Path) != nil {
+			continue
+		}
+
+		entries = append(entries, e)
+	}
+
+	// newest first
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Id > entries[j].Id })
+
+	if req.Limit > 0 && int64(len(entries)) > req.Limit {
+		entries = entries[:req.Limit]
+	}
+
+	for _, e := range entries {
+		rsp.Entries = append(rsp.Entries, &pb.AuditEntry{
+			Id:        e.Id,
+			Timestamp: e.Timestamp,
+			Account:   e.Account,
+			Token:     e.Token,
+			Action:    e.Action,
+			Key:       e.Key,
+			Path:      e.Path,
+			Old:       e.Old,
+			New:       e.New,
+		})
+	}
+
+	return nil
+}
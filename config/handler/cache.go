@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"sync"
+	"time"
+
+	"github.com/micro/go-micro/v2/store"
+	"github.com/micro/go-micro/v2/util/log"
+	pb "github.com/micro/micro/v2/config/changes/proto"
+	"github.com/micro/micro/v2/config/db"
+	"golang.org/x/net/context"
+)
+
+var (
+	// CacheTTL is how long the config read is cached, once it's stale it's served
+	// while it's read again. The config isn't cached if it's 0
+	CacheTTL time.Duration
+
+	cache = &readCache{entries: make(map[string]*cacheEntry)}
+)
+
+// cacheEntry is the record of a key as last read from the db
+type cacheEntry struct {
+	record     *store.Record
+	read       time.Time
+	refreshing bool
+}
+
+// readCache of the config records, the keys are invalidated as they're changed
+type readCache struct {
+	sync.Mutex
+	entries map[string]*cacheEntry
+	// version is incremented by each invalidation so reads which started
+	// before one don't cache the config it invalidated
+	version uint64
+
+	hits   uint64
+	misses uint64
+	stale  uint64
+}
+
+// read the record of the key from the cache, the db is read if it's not cached
+// and a stale record is read again in the background
+func (c *readCache) read(key string) (*store.Record, error) {
+	if CacheTTL <= 0 {
+		return db.Read(key)
+	}
+
+	c.Lock()
+	e, ok := c.entries[key]
+	if ok {
+		c.hits++
+		if time.Since(e.read) > CacheTTL {
+			c.stale++
+			if !e.refreshing {
+				e.refreshing = true
+				go c.refresh(key, e)
+			}
+		}
+		c.Unlock()
+		return e.record, nil
+	}
+	c.misses++
+	version := c.version
+	c.Unlock()
+
+	rec, err := db.Read(key)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Lock()
+	if c.version == version {
+		c.entries[key] = &cacheEntry{record: rec, read: time.Now()}
+	}
+	c.Unlock()
+
+	return rec, nil
+}
+
+// refresh the stale entry of the key unless it's invalidated while it's read
+func (c *readCache) refresh(key string, e *cacheEntry) {
+	rec, err := db.Read(key)
+
+	c.Lock()
+	defer c.Unlock()
+
+	if c.entries[key] != e {
+		return
+	}
+	if err != nil {
+		// it's read from the db by the next read
+		delete(c.entries, key)
+		if err != store.ErrNotFound {
+			log.Errorf("Error reading %s to refresh the cache: %v", key, err)
+		}
+		return
+	}
+	c.entries[key] = &cacheEntry{record: rec, read: time.Now()}
+}
+
+// invalidate the key so it's read from the db
+func (c *readCache) invalidate(key string) {
+	if CacheTTL <= 0 {
+		return
+	}
+	c.Lock()
+	c.version++
+	delete(c.entries, key)
+	c.Unlock()
+}
+
+// stats of the cache
+func (c *readCache) stats(rsp *pb.StatsResponse) {
+	c.Lock()
+	defer c.Unlock()
+
+	rsp.Hits = c.hits
+	rsp.Misses = c.misses
+	rsp.Stale = c.stale
+	rsp.Entries = uint64(len(c.entries))
+	rsp.Ttl = int64(CacheTTL.Seconds())
+	if reads := c.hits + c.misses; reads > 0 {
+		rsp.HitRate = float64(c.hits) / float64(reads)
+	}
+}
+
+// Stats of the read cache of the instance
+func (c *Changes) Stats(ctx context.Context, req *pb.StatsRequest, rsp *pb.StatsResponse) error {
+	cache.stats(rsp)
+	return nil
+}
@@ -0,0 +1,247 @@
+package handler
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/uuid"
+	"github.com/micro/go-micro/v2/config/source"
+	mp "github.com/micro/go-micro/v2/config/source/service/proto"
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/store"
+	"github.com/micro/go-micro/v2/util/log"
+	pb "github.com/micro/micro/v2/config/changes/proto"
+	"github.com/micro/micro/v2/config/db"
+	"golang.org/x/net/context"
+)
+
+var (
+	// commitMtx serialises the commits
+	commitMtx sync.Mutex
+)
+
+// committed is the config of a key changed by a commit
+type committed struct {
+	change *mp.Change
+	// record of the key before the commit, nil if it didn't exist
+	record *store.Record
+	// before is the config data before the commit
+	before []byte
+	// paths changed by the commit
+	paths []string
+}
+
+// apply the operation to the config data
+func apply(data []byte, op *pb.Operation) ([]byte, error) {
+	vals, err := values(&source.ChangeSet{Data: data, Format: "json"})
+	if err != nil {
+		return nil, err
+	}
+
+	path := strings.Split(strings.Trim(op.Path, PathSplitter), PathSplitter)
+
+	switch {
+	case op.Delete:
+		vals.Del(path...)
+	case len(strings.Trim(op.Path, PathSplitter)) == 0:
+		cs, err := merge(&source.ChangeSet{Data: data}, &source.ChangeSet{Data: op.Data})
+		if err != nil {
+			return nil, err
+		}
+		return cs.Data, nil
+	default:
+		var v interface{}
+		nv, err := values(&source.ChangeSet{Data: op.Data, Format: "json"})
+		if err != nil {
+			return nil, err
+		}
+		if err := nv.Get().Scan(&v); err != nil {
+			return nil, err
+		}
+		vals.Set(v, path...)
+	}
+
+	cs, err := merge(&source.ChangeSet{Data: vals.Bytes()})
+	if err != nil {
+		return nil, err
+	}
+	return cs.Data, nil
+}
+
+// Commit applies the operations to the config of their keys all together or not at all,
+// each key changed is published as a single change however many of its paths changed
+func (c *Changes) Commit(ctx context.Context, req *pb.CommitRequest, rsp *pb.CommitResponse) (err error) {
+	defer func() {
+		if err != nil {
+			log.Error(err)
+		}
+	}()
+
+	if ok, err := c.Config.forward(ctx, "Changes.Commit", req, rsp); ok {
+		return err
+	}
+
+	if len(req.Operations) == 0 {
+		err = errors.BadRequest("go.micro.config.Changes.Commit", "no operations")
+		return err
+	}
+
+	// replicate the request as made rather than its result
+	orig := proto.Clone(req)
+	replica := c.Config.replica(ctx)
+
+	commitMtx.Lock()
+	defer commitMtx.Unlock()
+
+	var keys []string
+	changes := map[string]*committed{}
+
+	for i, op := range req.Operations {
+		if len(op.Key) == 0 || isInternal(op.Key) {
+			err = errors.BadRequest("go.micro.config.Changes.Commit", "operation %d: invalid id", i)
+			return err
+		}
+		if op.Delete && len(strings.Trim(op.Path, PathSplitter)) == 0 {
+			err = errors.BadRequest("go.micro.config.Changes.Commit", "operation %d: deleting %s requires a path", i, op.Key)
+			return err
+		}
+
+		if err = authorize(ctx, "go.micro.config.Changes.Commit", WriteAccess, op.Key, op.Path); err != nil {
+			return err
+		}
+		if err = writable("go.micro.config.Changes.Commit", op.Key); err != nil {
+			return err
+		}
+		// pending changes are approved one at a time so they can't be part of a commit
+		if !replica && requiresApproval(ctx, op.Key) {
+			err = errors.BadRequest("go.micro.config.Changes.Commit", "changes to %s require approval and can't be committed", op.Key)
+			return err
+		}
+
+		cm, ok := changes[op.Key]
+		if !ok {
+			cm = &committed{change: &mp.Change{Key: op.Key, ChangeSet: &mp.ChangeSet{Data: []byte(`{}`)}}}
+
+			rec, err := db.Read(op.Key)
+			if err != nil && err != store.ErrNotFound {
+				err = errors.InternalServerError("go.micro.config.Changes.Commit", "read %s error: %v", op.Key, err)
+				return err
+			}
+			if err == nil {
+				ch := &mp.Change{}
+				if err := proto.Unmarshal(rec.Value, ch); err != nil {
+					err = errors.InternalServerError("go.micro.config.Changes.Commit", "unmarshal %s error: %v", op.Key, err)
+					return err
+				}
+				if ch.ChangeSet != nil {
+					cm.change.ChangeSet = ch.ChangeSet
+					cm.before = ch.ChangeSet.Data
+				}
+				cm.record = rec
+			}
+
+			keys = append(keys, op.Key)
+			changes[op.Key] = cm
+		}
+
+		sealed := &mp.Change{ChangeSet: &mp.ChangeSet{Data: op.Data}}
+		if err = seal(sealed); err != nil {
+			err = errors.BadRequest("go.micro.config.Changes.Commit", "operation %d: encrypt secrets error: %v", i, err)
+			return err
+		}
+
+		b, err := apply(cm.change.ChangeSet.Data, &pb.Operation{Path: op.Path, Data: sealed.ChangeSet.Data, Delete: op.Delete})
+		if err != nil {
+			err = errors.BadRequest("go.micro.config.Changes.Commit", "operation %d: %v", i, err)
+			return err
+		}
+		cm.change.ChangeSet = &mp.ChangeSet{Data: b}
+		cm.paths = append(cm.paths, strings.Trim(op.Path, PathSplitter))
+	}
+
+	now := time.Now()
+
+	for _, key := range keys {
+		cm := changes[key]
+
+		cs := &source.ChangeSet{Data: cm.change.ChangeSet.Data, Format: "json", Source: "commit", Timestamp: now}
+		cm.change.ChangeSet = &mp.ChangeSet{
+			Data:      cs.Data,
+			Checksum:  cs.Sum(),
+			Format:    cs.Format,
+			Source:    cs.Source,
+			Timestamp: now.Unix(),
+		}
+		if len(cm.paths) == 1 {
+			cm.change.Path = cm.paths[0]
+		}
+
+		if err = validate("go.micro.config.Changes.Commit", key, cm.change.ChangeSet.Data); err != nil {
+			return err
+		}
+	}
+
+	// write the keys restoring the keys written if one fails
+	var written []string
+	for _, key := range keys {
+		cm := changes[key]
+
+		b, err := proto.Marshal(cm.change)
+		if err == nil {
+			err = db.Create(&store.Record{Key: key, Value: b})
+		}
+		cache.invalidate(key)
+		if err != nil {
+			for _, k := range written {
+				restore(k, changes[k].record)
+			}
+			err = errors.InternalServerError("go.micro.config.Changes.Commit", "write %s error: %v", key, err)
+			return err
+		}
+		written = append(written, key)
+	}
+
+	c.Config.replicate(ctx, "Changes.Commit", orig, func() interface{} { return new(pb.CommitResponse) })
+
+	rsp.Id = uuid.New().String()
+
+	if replica {
+		return nil
+	}
+
+	for _, key := range keys {
+		cm := changes[key]
+
+		action := "update"
+		if cm.record == nil {
+			action = "create"
+		}
+
+		rev := recordRevision(ctx, action, cm.change)
+		for _, path := range cm.paths {
+			audit(ctx, "commit", key, path, cm.before, cm.change.ChangeSet.Data)
+		}
+
+		ev := changeEvent(ctx, action, rev, cm.change, cm.before)
+		ev.Commit = rsp.Id
+		_ = publish(ctx, ev)
+	}
+
+	return nil
+}
+
+// restore the record of the key as it was before a commit, deleting it if it didn't exist
+func restore(key string, record *store.Record) {
+	var err error
+	if record == nil {
+		err = db.Delete(key)
+	} else {
+		err = db.Create(record)
+	}
+	if err != nil {
+		log.Errorf("Error restoring %s after a failed commit: %v", key, err)
+	}
+	cache.invalidate(key)
+}
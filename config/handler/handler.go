@@ -14,21 +14,28 @@ import (
 	"github.com/micro/go-micro/v2/errors"
 	"github.com/micro/go-micro/v2/store"
 	"github.com/micro/go-micro/v2/util/log"
+	pb "github.com/micro/micro/v2/config/changes/proto"
 	"github.com/micro/micro/v2/config/db"
+	"github.com/micro/micro/v2/internal/standby"
 	"golang.org/x/net/context"
 )
 
 var (
 	PathSplitter = "/"
 	WatchTopic   = "go.micro.config.events"
-	watchers     = make(map[string][]*watcher)
+	// Publish the changes to WatchTopic, unset when the db streams them to every instance
+	Publish  = true
+	watchers = make(map[string][]*watcher)
 
 	// we now support json only
 	reader = json.NewReader()
 	mtx    sync.RWMutex
 )
 
-type Handler struct{}
+type Handler struct {
+	// Standby is set when run as one of an active/standby pair
+	Standby *standby.Standby
+}
 
 func (c *Handler) Read(ctx context.Context, req *mp.ReadRequest, rsp *mp.ReadResponse) (err error) {
 	defer func() {
@@ -37,12 +44,16 @@ func (c *Handler) Read(ctx context.Context, req *mp.ReadRequest, rsp *mp.ReadRes
 		}
 	}()
 
-	if len(req.Key) == 0 {
+	if len(req.Key) == 0 || isInternal(req.Key) {
 		err = errors.BadRequest("go.micro.config.Read", "invalid id")
 		return err
 	}
 
-	ch, err := db.Read(req.Key)
+	if err = authorize(ctx, "go.micro.config.Read", ReadAccess, req.Key, req.Path); err != nil {
+		return err
+	}
+
+	ch, err := cache.read(req.Key)
 	if err != nil {
 		err = errors.BadRequest("go.micro.config.Read", "read error: %v", err)
 		return err
@@ -56,27 +67,37 @@ func (c *Handler) Read(ctx context.Context, req *mp.ReadRequest, rsp *mp.ReadRes
 	}
 
 	// if dont need path, we return all of the data
-	if len(req.Path) == 0 {
-		return nil
+	if len(req.Path) > 0 {
+		rcc := rsp.Change.ChangeSet
+		values, err := values(&source.ChangeSet{
+			Timestamp: time.Unix(rcc.Timestamp, 0),
+			Data:      rcc.Data,
+			Checksum:  rcc.Checksum,
+			Format:    rcc.Format,
+			Source:    rcc.Source,
+		})
+		if err != nil {
+			err = errors.InternalServerError("go.micro.config.Read", err.Error())
+			return err
+		}
+
+		parts := strings.Split(req.Path, PathSplitter)
+
+		// we just want to pass back bytes
+		rsp.Change.ChangeSet.Data = values.Get(parts...).Bytes()
 	}
 
-	rcc := rsp.Change.ChangeSet
-	values, err := values(&source.ChangeSet{
-		Timestamp: time.Unix(rcc.Timestamp, 0),
-		Data:      rcc.Data,
-		Checksum:  rcc.Checksum,
-		Format:    rcc.Format,
-		Source:    rcc.Source,
-	})
+	rsp.Change.ChangeSet.Data, err = open(ctx, rsp.Change.ChangeSet.Data)
 	if err != nil {
-		err = errors.InternalServerError("go.micro.config.Read", err.Error())
+		err = errors.InternalServerError("go.micro.config.Read", "decrypt secrets error: %v", err)
 		return err
 	}
 
-	parts := strings.Split(req.Path, PathSplitter)
-
-	// we just want to pass back bytes
-	rsp.Change.ChangeSet.Data = values.Get(parts...).Bytes()
+	rsp.Change.ChangeSet.Data, err = resolve(ctx, req.Key, rsp.Change.ChangeSet.Data)
+	if err != nil {
+		err = errors.BadRequest("go.micro.config.Read", "resolve references error: %v", err)
+		return err
+	}
 
 	return nil
 }
@@ -88,16 +109,39 @@ func (c *Handler) Create(ctx context.Context, req *mp.CreateRequest, rsp *mp.Cre
 		}
 	}()
 
+	if ok, err := c.forward(ctx, "Config.Create", req, rsp); ok {
+		return err
+	}
+
 	if req.Change == nil || req.Change.ChangeSet == nil {
 		err = errors.BadRequest("go.micro.config.Create", "invalid change")
 		return err
 	}
 
-	if len(req.Change.Key) == 0 {
+	if len(req.Change.Key) == 0 || isInternal(req.Change.Key) {
 		err = errors.BadRequest("go.micro.config.Create", "invalid id")
 		return err
 	}
 
+	if err = authorize(ctx, "go.micro.config.Create", WriteAccess, req.Change.Key, req.Change.Path); err != nil {
+		return err
+	}
+	if err = writable("go.micro.config.Create", req.Change.Key); err != nil {
+		return err
+	}
+
+	if err = seal(req.Change); err != nil {
+		err = errors.BadRequest("go.micro.config.Create", "encrypt secrets error: %v", err)
+		return err
+	}
+
+	if !c.replica(ctx) && requiresApproval(ctx, req.Change.Key) {
+		return stage(ctx, "go.micro.config.Create", "create", req.Change)
+	}
+
+	// replicate the request as made rather than its result
+	orig := proto.Clone(req)
+
 	req.Change.ChangeSet.Timestamp = time.Now().Unix()
 
 	record := &store.Record{}
@@ -109,12 +153,25 @@ func (c *Handler) Create(ctx context.Context, req *mp.CreateRequest, rsp *mp.Cre
 
 	record.Key = req.Change.Key
 
+	if err = validate("go.micro.config.Create", req.Change.Key, req.Change.ChangeSet.Data); err != nil {
+		return err
+	}
+
+	before := current(req.Change.Key)
+
 	if err := db.Create(record); err != nil {
 		err = errors.BadRequest("go.micro.config.Create", "create new into db error: %v", err)
 		return err
 	}
+	cache.invalidate(record.Key)
+
+	c.replicate(ctx, "Config.Create", orig, func() interface{} { return new(mp.CreateResponse) })
 
-	_ = publish(ctx, &mp.WatchResponse{Key: req.Change.Key, ChangeSet: req.Change.ChangeSet})
+	if !c.replica(ctx) {
+		rev := recordRevision(ctx, "create", req.Change)
+		audit(ctx, "create", req.Change.Key, req.Change.Path, before, req.Change.ChangeSet.Data)
+		_ = publish(ctx, changeEvent(ctx, "create", rev, req.Change, before))
+	}
 
 	return nil
 }
@@ -126,16 +183,40 @@ func (c *Handler) Update(ctx context.Context, req *mp.UpdateRequest, rsp *mp.Upd
 		}
 	}()
 
+	if ok, err := c.forward(ctx, "Config.Update", req, rsp); ok {
+		return err
+	}
+	ctx = c.expired(ctx)
+
 	if req.Change == nil || req.Change.ChangeSet == nil {
 		err = errors.BadRequest("go.micro.config.Update", "invalid change")
 		return err
 	}
 
-	if len(req.Change.Key) == 0 {
+	if len(req.Change.Key) == 0 || isInternal(req.Change.Key) {
 		err = errors.BadRequest("go.micro.config.Update", "invalid id")
 		return err
 	}
 
+	if err = authorize(ctx, "go.micro.config.Update", WriteAccess, req.Change.Key, req.Change.Path); err != nil {
+		return err
+	}
+	if err = writable("go.micro.config.Update", req.Change.Key); err != nil {
+		return err
+	}
+
+	if err = seal(req.Change); err != nil {
+		err = errors.BadRequest("go.micro.config.Update", "encrypt secrets error: %v", err)
+		return err
+	}
+
+	if !c.replica(ctx) && requiresApproval(ctx, req.Change.Key) {
+		return stage(ctx, "go.micro.config.Update", "update", req.Change)
+	}
+
+	// replicate the request as made rather than its result
+	orig := proto.Clone(req)
+
 	req.Change.ChangeSet.Timestamp = time.Now().Unix()
 
 	// Get the current change set
@@ -218,6 +299,10 @@ func (c *Handler) Update(ctx context.Context, req *mp.UpdateRequest, rsp *mp.Upd
 		Format:    newChange.Format,
 	}
 
+	if err = validate("go.micro.config.Update", req.Change.Key, req.Change.ChangeSet.Data); err != nil {
+		return err
+	}
+
 	record.Value, err = proto.Marshal(req.Change)
 	if err != nil {
 		err = errors.BadRequest("go.micro.config.Update", "marshal error: %v", err)
@@ -228,8 +313,15 @@ func (c *Handler) Update(ctx context.Context, req *mp.UpdateRequest, rsp *mp.Upd
 		err = errors.BadRequest("go.micro.config.Update", "update into db error: %v", err)
 		return err
 	}
+	cache.invalidate(record.Key)
+
+	c.replicate(ctx, "Config.Update", orig, func() interface{} { return new(mp.UpdateResponse) })
 
-	_ = publish(ctx, &mp.WatchResponse{Key: req.Change.Key, ChangeSet: req.Change.ChangeSet})
+	if !c.replica(ctx) {
+		rev := recordRevision(ctx, "update", req.Change)
+		audit(ctx, "update", req.Change.Key, req.Change.Path, ch.ChangeSet.Data, req.Change.ChangeSet.Data)
+		_ = publish(ctx, changeEvent(ctx, "update", rev, req.Change, ch.ChangeSet.Data))
+	}
 
 	return nil
 }
@@ -241,29 +333,55 @@ func (c *Handler) Delete(ctx context.Context, req *mp.DeleteRequest, rsp *mp.Del
 		}
 	}()
 
+	if ok, err := c.forward(ctx, "Config.Delete", req, rsp); ok {
+		return err
+	}
+	ctx = c.expired(ctx)
+
 	if req.Change == nil {
 		err = errors.BadRequest("go.micro.srv.Delete", "invalid change")
 		return err
 	}
 
-	if len(req.Change.Key) == 0 {
+	if len(req.Change.Key) == 0 || isInternal(req.Change.Key) {
 		err = errors.BadRequest("go.micro.srv.Delete", "invalid id")
 		return err
 	}
 
+	if err = authorize(ctx, "go.micro.srv.Delete", WriteAccess, req.Change.Key, req.Change.Path); err != nil {
+		return err
+	}
+	if err = writable("go.micro.srv.Delete", req.Change.Key); err != nil {
+		return err
+	}
+
+	if !c.replica(ctx) && requiresApproval(ctx, req.Change.Key) {
+		return stage(ctx, "go.micro.config.Delete", "delete", req.Change)
+	}
+
 	if req.Change.ChangeSet == nil {
 		req.Change.ChangeSet = &mp.ChangeSet{}
 	}
 
+	// replicate the request as made rather than its result
+	orig := proto.Clone(req)
+
 	req.Change.ChangeSet.Timestamp = time.Now().Unix()
 
 	// We're going to delete the record as we have no path and no data
 	if len(req.Change.Path) == 0 {
+		before := current(req.Change.Key)
 		if err := db.Delete(req.Change.Key); err != nil {
 			err = errors.BadRequest("go.micro.srv.Delete", "delete from db error: %v", err)
 			log.Error(err)
 			return err
 		}
+		cache.invalidate(req.Change.Key)
+		c.replicate(ctx, "Config.Delete", orig, func() interface{} { return new(mp.DeleteResponse) })
+		if !c.replica(ctx) {
+			recordRevision(ctx, "delete", &mp.Change{Key: req.Change.Key})
+			audit(ctx, "delete", req.Change.Key, "", before, nil)
+		}
 		return nil
 	}
 
@@ -317,6 +435,10 @@ func (c *Handler) Delete(ctx context.Context, req *mp.DeleteRequest, rsp *mp.Del
 		Source:    change.Source,
 	}
 
+	if err = validate("go.micro.srv.Delete", req.Change.Key, req.Change.ChangeSet.Data); err != nil {
+		return err
+	}
+
 	record.Value, err = proto.Marshal(req.Change)
 	if err != nil {
 		err = errors.BadRequest("go.micro.config.Update", "marshal error: %v", err)
@@ -327,8 +449,15 @@ func (c *Handler) Delete(ctx context.Context, req *mp.DeleteRequest, rsp *mp.Del
 		err = errors.BadRequest("go.micro.srv.Delete", "update record set to db error: %v", err)
 		return err
 	}
+	cache.invalidate(record.Key)
 
-	_ = publish(ctx, &mp.WatchResponse{Key: req.Change.Key, ChangeSet: req.Change.ChangeSet})
+	c.replicate(ctx, "Config.Delete", orig, func() interface{} { return new(mp.DeleteResponse) })
+
+	if !c.replica(ctx) {
+		rev := recordRevision(ctx, "delete", req.Change)
+		audit(ctx, "delete", req.Change.Key, req.Change.Path, ch.ChangeSet.Data, req.Change.ChangeSet.Data)
+		_ = publish(ctx, changeEvent(ctx, "delete", rev, req.Change, ch.ChangeSet.Data))
+	}
 
 	return nil
 }
@@ -347,12 +476,23 @@ func (c *Handler) List(ctx context.Context, req *mp.ListRequest, rsp *mp.ListRes
 	}
 
 	for _, v := range list {
+		// skip changes pending approval, revisions, schemas, the audit log and leases
+		if isInternal(v.Key) {
+			continue
+		}
+		// and the config the caller can't read
+		if authorize(ctx, "go.micro.config.List", ReadAccess, v.Key, "") != nil {
+			continue
+		}
 		ch := &mp.Change{}
 		err := proto.Unmarshal(v.Value, ch)
 		if err != nil {
 			err = errors.BadRequest("go.micro.config.Read", "unmarshal value error: %v", err)
 			return err
 		}
+		if ch.ChangeSet != nil && !encrypted(ctx) {
+			ch.ChangeSet.Data = mask(ch.ChangeSet.Data)
+		}
 		rsp.Values = append(rsp.Values, ch)
 	}
 
@@ -366,11 +506,15 @@ func (c *Handler) Watch(ctx context.Context, req *mp.WatchRequest, stream mp.Con
 		}
 	}()
 
-	if len(req.Key) == 0 {
+	if len(req.Key) == 0 || isInternal(req.Key) {
 		err = errors.BadRequest("go.micro.srv.Watch", "invalid id")
 		return err
 	}
 
+	if err = authorize(ctx, "go.micro.srv.Watch", ReadAccess, req.Key, ""); err != nil {
+		return err
+	}
+
 	watch, err := Watch(req.Key)
 	if err != nil {
 		err = errors.BadRequest("go.micro.srv.Watch", "watch error: %v", err)
@@ -386,7 +530,24 @@ func (c *Handler) Watch(ctx context.Context, req *mp.WatchRequest, stream mp.Con
 			return err
 		}
 
-		if err := stream.Send(ch); err != nil {
+		// the change is sent to every watcher so it's copied to open the secrets
+		rsp := &mp.WatchResponse{Key: ch.Key}
+		if ch.ChangeSet != nil {
+			cs := *ch.ChangeSet
+			if cs.Data, err = open(ctx, cs.Data); err != nil {
+				_ = stream.Close()
+				err = errors.InternalServerError("go.micro.srv.Watch", "decrypt secrets error: %v", err)
+				return err
+			}
+			if cs.Data, err = resolve(ctx, ch.Key, cs.Data); err != nil {
+				_ = stream.Close()
+				err = errors.BadRequest("go.micro.srv.Watch", "resolve references error: %v", err)
+				return err
+			}
+			rsp.ChangeSet = &cs
+		}
+
+		if err := stream.Send(rsp); err != nil {
 			_ = stream.Close()
 			err = errors.BadRequest("go.micro.srv.Watch", "send the Change error: %v", err)
 			return err
@@ -396,6 +557,9 @@ func (c *Handler) Watch(ctx context.Context, req *mp.WatchRequest, stream mp.Con
 
 // Used as a subscriber between config services for events
 func Watcher(ctx context.Context, ch *mp.WatchResponse) error {
+	// the change may have been made by another instance
+	cache.invalidate(ch.Key)
+
 	mtx.RLock()
 	for _, sub := range watchers[ch.Key] {
 		select {
@@ -415,8 +579,42 @@ func values(ch *source.ChangeSet) (cr.Values, error) {
 	return reader.Values(ch)
 }
 
-// publish a change
-func publish(ctx context.Context, ch *mp.WatchResponse) error {
-	req := client.NewMessage(WatchTopic, ch)
+// changeEvent of the change applied to the config from the data before it
+func changeEvent(ctx context.Context, action string, rev int64, ch *mp.Change, before []byte) *pb.ChangeEvent {
+	if _, ok := ctx.Value(rollbackKey{}).(int64); ok {
+		action = "rollback"
+	}
+	if _, ok := ctx.Value(expiredKey{}).(bool); ok {
+		action = "expire"
+	}
+
+	ev := &pb.ChangeEvent{
+		Key:       ch.Key,
+		Path:      ch.Path,
+		Type:      action,
+		Old:       mask(valueAt(before, ch.Path)),
+		Revision:  rev,
+		Account:   account(ctx),
+		Timestamp: time.Now().Unix(),
+	}
+	if cs := ch.ChangeSet; cs != nil {
+		ev.ChangeSet = &pb.ChangeSet{
+			Data:      cs.Data,
+			Checksum:  cs.Checksum,
+			Format:    cs.Format,
+			Source:    cs.Source,
+			Timestamp: cs.Timestamp,
+		}
+		ev.New = mask(valueAt(cs.Data, ch.Path))
+	}
+	return ev
+}
+
+// publish a change, the event is decoded as a mp.WatchResponse by the config services
+func publish(ctx context.Context, ev *pb.ChangeEvent) error {
+	if !Publish {
+		return nil
+	}
+	req := client.NewMessage(WatchTopic, ev)
 	return client.Publish(ctx, req)
 }
@@ -0,0 +1,290 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/micro/go-micro/v2/config/source"
+	mp "github.com/micro/go-micro/v2/config/source/service/proto"
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/store"
+	"github.com/micro/go-micro/v2/util/log"
+	pb "github.com/micro/micro/v2/config/changes/proto"
+	"github.com/micro/micro/v2/config/db"
+	"golang.org/x/net/context"
+)
+
+var (
+	// revisionsPrefix is the db key prefix for the revisions of each key
+	revisionsPrefix = "__revisions__/"
+
+	// revMtx serialises numbering the revisions
+	revMtx sync.Mutex
+)
+
+// rollbackKey marks a context as rolling back to the revision
+type rollbackKey struct{}
+
+// revision is a change applied as stored in the db
+type revision struct {
+	Rev       int64  `json:"rev"`
+	Key       string `json:"key"`
+	Path      string `json:"path"`
+	Action    string `json:"action"`
+	Account   string `json:"account"`
+	Timestamp int64  `json:"timestamp"`
+	Checksum  string `json:"checksum"`
+	// Data is the config of the key after the change
+	Data     []byte `json:"data"`
+	Rollback int64  `json:"rollback,omitempty"`
+}
+
+func isRevision(key string) bool {
+	return strings.HasPrefix(key, revisionsPrefix)
+}
+
+func revisionKey(key string, rev int64) string {
+	return fmt.Sprintf("%s%s/%010d", revisionsPrefix, key, rev)
+}
+
+// revisions returns the revisions of the key oldest first
+func revisions(key string) ([]*revision, error) {
+	list, err := db.List()
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := revisionsPrefix + key + "/"
+
+	var revs []*revision
+	for _, v := range list {
+		if !strings.HasPrefix(v.Key, prefix) {
+			continue
+		}
+		// revisions of keys nested below the key
+		if _, err := strconv.ParseInt(strings.TrimPrefix(v.Key, prefix), 10, 64); err != nil {
+			continue
+		}
+		r := &revision{}
+		if err := json.Unmarshal(v.Value, r); err != nil {
+			return nil, err
+		}
+		revs = append(revs, r)
+	}
+
+	sort.Slice(revs, func(i, j int) bool { return revs[i].Rev < revs[j].Rev })
+
+	return revs, nil
+}
+
+// recordRevision records the change applied as the next revision of its key returning its
+// number, a failure is logged and 0 returned rather than failing the change which has been applied
+func recordRevision(ctx context.Context, action string, ch *mp.Change) int64 {
+	revMtx.Lock()
+	defer revMtx.Unlock()
+
+	revs, err := revisions(ch.Key)
+	if err != nil {
+		log.Errorf("Error reading the revisions of %s: %v", ch.Key, err)
+		return 0
+	}
+
+	r := &revision{
+		Rev:       1,
+		Key:       ch.Key,
+		Path:      ch.Path,
+		Action:    action,
+		Account:   account(ctx),
+		Timestamp: time.Now().Unix(),
+	}
+	if len(revs) > 0 {
+		r.Rev = revs[len(revs)-1].Rev + 1
+	}
+	if rev, ok := ctx.Value(rollbackKey{}).(int64); ok {
+		r.Action = "rollback"
+		r.Rollback = rev
+	}
+	if _, ok := ctx.Value(expiredKey{}).(bool); ok {
+		r.Action = "expire"
+	}
+	if ch.ChangeSet != nil {
+		r.Checksum = ch.ChangeSet.Checksum
+		r.Data = ch.ChangeSet.Data
+	}
+
+	b, err := json.Marshal(r)
+	if err != nil {
+		log.Errorf("Error recording revision %d of %s: %v", r.Rev, ch.Key, err)
+		return 0
+	}
+
+	if err := db.Create(&store.Record{Key: revisionKey(ch.Key, r.Rev), Value: b}); err != nil {
+		log.Errorf("Error recording revision %d of %s: %v", r.Rev, ch.Key, err)
+		return 0
+	}
+
+	return r.Rev
+}
+
+// valueAt returns the config data at the path, nil if there's none
+func valueAt(data []byte, path string) []byte {
+	if len(data) == 0 {
+		return nil
+	}
+	if len(path) == 0 {
+		return data
+	}
+
+	vals, err := values(&source.ChangeSet{Data: data, Format: "json"})
+	if err != nil {
+		return nil
+	}
+
+	b := vals.Get(strings.Split(path, PathSplitter)...).Bytes()
+	if bytes.Equal(b, []byte("null")) {
+		return nil
+	}
+	return b
+}
+
+func (c *Changes) History(ctx context.Context, req *pb.HistoryRequest, rsp *pb.HistoryResponse) (err error) {
+	defer func() {
+		if err != nil {
+			log.Error(err)
+		}
+	}()
+
+	// revisions are recorded by the active instance
+	if ok, err := c.Config.forward(ctx, "Changes.History", req, rsp); ok {
+		return err
+	}
+
+	if len(req.Key) == 0 {
+		err = errors.BadRequest("go.micro.config.Changes.History", "invalid id")
+		return err
+	}
+
+	if err = authorize(ctx, "go.micro.config.Changes.History", ReadAccess, req.Key, req.Path); err != nil {
+		return err
+	}
+
+	revs, err := revisions(req.Key)
+	if err != nil {
+		err = errors.InternalServerError("go.micro.config.Changes.History", "read revisions error: %v", err)
+		return err
+	}
+
+	path := strings.Trim(req.Path, PathSplitter)
+
+	var prev []byte
+	for i, r := range revs {
+		data := valueAt(r.Data, path)
+
+		// skip the changes which didn't change the path
+		if len(path) > 0 && i > 0 && bytes.Equal(data, prev) {
+			continue
+		}
+		prev = data
+
+		rsp.Revisions = append(rsp.Revisions, &pb.Revision{
+			Rev:       r.Rev,
+			Key:       r.Key,
+			Path:      r.Path,
+			Action:    r.Action,
+			Account:   r.Account,
+			Timestamp: r.Timestamp,
+			Checksum:  r.Checksum,
+			Data:      mask(data),
+			Rollback:  r.Rollback,
+		})
+	}
+
+	// newest first
+	for i, j := 0, len(rsp.Revisions)-1; i < j; i, j = i+1, j-1 {
+		rsp.Revisions[i], rsp.Revisions[j] = rsp.Revisions[j], rsp.Revisions[i]
+	}
+
+	if req.Limit > 0 && int64(len(rsp.Revisions)) > req.Limit {
+		rsp.Revisions = rsp.Revisions[:req.Limit]
+	}
+
+	return nil
+}
+
+func (c *Changes) Rollback(ctx context.Context, req *pb.RollbackRequest, rsp *pb.RollbackResponse) (err error) {
+	defer func() {
+		if err != nil {
+			log.Error(err)
+		}
+	}()
+
+	// revisions are recorded by the active instance
+	if ok, err := c.Config.forward(ctx, "Changes.Rollback", req, rsp); ok {
+		return err
+	}
+
+	if len(req.Key) == 0 {
+		err = errors.BadRequest("go.micro.config.Changes.Rollback", "invalid id")
+		return err
+	}
+
+	if err = authorize(ctx, "go.micro.config.Changes.Rollback", WriteAccess, req.Key, req.Path); err != nil {
+		return err
+	}
+	if err = writable("go.micro.config.Changes.Rollback", req.Key); err != nil {
+		return err
+	}
+
+	revs, err := revisions(req.Key)
+	if err != nil {
+		err = errors.InternalServerError("go.micro.config.Changes.Rollback", "read revisions error: %v", err)
+		return err
+	}
+
+	var r *revision
+	for _, rev := range revs {
+		if rev.Rev == req.Rev {
+			r = rev
+		}
+	}
+	if r == nil {
+		err = errors.NotFound("go.micro.config.Changes.Rollback", "revision %d of %s not found", req.Rev, req.Key)
+		return err
+	}
+
+	path := strings.Trim(req.Path, PathSplitter)
+	data := valueAt(r.Data, path)
+
+	// the rollback is applied as a change so it's approved, replicated and published
+	rctx := context.WithValue(ctx, rollbackKey{}, req.Rev)
+	ch := &mp.Change{
+		Key:       req.Key,
+		Path:      path,
+		ChangeSet: &mp.ChangeSet{Data: data, Format: "json", Source: "rollback"},
+	}
+
+	switch {
+	case len(data) == 0:
+		// the config didn't exist at the revision
+		err = c.Config.Delete(rctx, &mp.DeleteRequest{Change: ch}, &mp.DeleteResponse{})
+	case len(path) == 0:
+		// replace the config rather than merging into it
+		ch.ChangeSet.Checksum = r.Checksum
+		err = c.Config.Create(rctx, &mp.CreateRequest{Change: ch}, &mp.CreateResponse{})
+	default:
+		err = c.Config.Update(rctx, &mp.UpdateRequest{Change: ch}, &mp.UpdateResponse{})
+	}
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Rolled back %s of %s to revision %d by %s", path, req.Key, req.Rev, account(ctx))
+
+	return nil
+}
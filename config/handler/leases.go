@@ -0,0 +1,210 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"time"
+
+	mp "github.com/micro/go-micro/v2/config/source/service/proto"
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/store"
+	"github.com/micro/go-micro/v2/util/log"
+	pb "github.com/micro/micro/v2/config/changes/proto"
+	"github.com/micro/micro/v2/config/db"
+	"golang.org/x/net/context"
+)
+
+var (
+	// LeaseInterval is how often the leases are checked for expiry
+	LeaseInterval = time.Second
+
+	// leasesPrefix is the db key prefix for the leases of the config
+	leasesPrefix = "__leases__/"
+)
+
+// expiredKey marks a context as reverting the config of an expired lease
+type expiredKey struct{}
+
+// lease of the config at a path as stored in the db
+type lease struct {
+	Key     string `json:"key"`
+	Path    string `json:"path"`
+	Account string `json:"account"`
+	Expires int64  `json:"expires"`
+	// Value is the config at the path set by the lease, it's only reverted if it's unchanged
+	Value []byte `json:"value"`
+	// Default is the config the path reverts to, the path is deleted if there's none
+	Default []byte `json:"default,omitempty"`
+}
+
+func leaseKey(key, path string) string {
+	return leasesPrefix + key + "/" + path
+}
+
+func isLease(key string) bool {
+	return strings.HasPrefix(key, leasesPrefix)
+}
+
+// Lease sets the config at the path until the ttl expires when it reverts to the default
+func (c *Changes) Lease(ctx context.Context, req *pb.LeaseRequest, rsp *pb.LeaseResponse) (err error) {
+	defer func() {
+		if err != nil {
+			log.Error(err)
+		}
+	}()
+
+	// leases are expired by the active instance
+	if ok, err := c.Config.forward(ctx, "Changes.Lease", req, rsp); ok {
+		return err
+	}
+
+	path := strings.Trim(req.Path, PathSplitter)
+
+	if len(req.Key) == 0 {
+		err = errors.BadRequest("go.micro.config.Changes.Lease", "invalid id")
+		return err
+	}
+	if len(path) == 0 {
+		err = errors.BadRequest("go.micro.config.Changes.Lease", "invalid path")
+		return err
+	}
+	if req.Ttl <= 0 {
+		err = errors.BadRequest("go.micro.config.Changes.Lease", "invalid ttl")
+		return err
+	}
+
+	if err = authorize(ctx, "go.micro.config.Changes.Lease", WriteAccess, req.Key, path); err != nil {
+		return err
+	}
+	if err = writable("go.micro.config.Changes.Lease", req.Key); err != nil {
+		return err
+	}
+
+	if !c.Config.replica(ctx) {
+		// the config would revert before the change was approved
+		if requiresApproval(ctx, req.Key) {
+			err = errors.BadRequest("go.micro.config.Changes.Lease", "changes to %s require approval and can't be leased", req.Key)
+			return err
+		}
+
+		// the config is set as any other change, it's replicated as one
+		if current(req.Key) == nil {
+			err = c.Config.Create(ctx, &mp.CreateRequest{Change: &mp.Change{
+				Key:       req.Key,
+				ChangeSet: &mp.ChangeSet{Data: []byte(`{}`), Format: "json", Source: "lease"},
+			}}, &mp.CreateResponse{})
+			if err != nil {
+				return err
+			}
+		}
+		err = c.Config.Update(ctx, &mp.UpdateRequest{Change: &mp.Change{
+			Key:       req.Key,
+			Path:      path,
+			ChangeSet: &mp.ChangeSet{Data: req.Data, Format: "json", Source: "lease"},
+		}}, &mp.UpdateResponse{})
+		if err != nil {
+			return err
+		}
+	}
+
+	l := &lease{
+		Key:     req.Key,
+		Path:    path,
+		Account: account(ctx),
+		Expires: time.Now().Add(time.Duration(req.Ttl) * time.Second).Unix(),
+		Value:   valueAt(current(req.Key), path),
+		Default: req.Default,
+	}
+
+	b, err := json.Marshal(l)
+	if err != nil {
+		err = errors.InternalServerError("go.micro.config.Changes.Lease", "marshal lease error: %v", err)
+		return err
+	}
+	if err = db.Create(&store.Record{Key: leaseKey(l.Key, l.Path), Value: b}); err != nil {
+		err = errors.InternalServerError("go.micro.config.Changes.Lease", "write lease error: %v", err)
+		return err
+	}
+
+	c.Config.replicate(ctx, "Changes.Lease", req, func() interface{} { return new(pb.LeaseResponse) })
+
+	rsp.Expires = l.Expires
+	return nil
+}
+
+// Expire reverts the config of the leases which have expired until the exit channel is closed
+func (c *Handler) Expire(exit chan bool) {
+	t := time.NewTicker(LeaseInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-exit:
+			return
+		case <-t.C:
+		}
+
+		// the standbys are replicated the changes made by the active instance
+		if c.Standby != nil && !c.Standby.Active() {
+			continue
+		}
+
+		c.expire(time.Now())
+	}
+}
+
+// expire the leases which expired by the time
+func (c *Handler) expire(now time.Time) {
+	list, err := db.List()
+	if err != nil {
+		log.Errorf("Error listing the leases: %v", err)
+		return
+	}
+
+	for _, v := range list {
+		if !isLease(v.Key) {
+			continue
+		}
+
+		l := &lease{}
+		if err := json.Unmarshal(v.Value, l); err != nil {
+			log.Errorf("Error unmarshalling lease %s: %v", v.Key, err)
+			continue
+		}
+		if l.Expires > now.Unix() {
+			continue
+		}
+
+		// the config set since the lease isn't reverted
+		if !bytes.Equal(valueAt(current(l.Key), l.Path), l.Value) {
+			log.Infof("Lease of %s of %s expired after it was changed, it's not reverted", l.Path, l.Key)
+		} else if err := c.revert(l); err != nil {
+			log.Errorf("Error reverting %s of %s when its lease expired: %v", l.Path, l.Key, err)
+		}
+
+		if err := db.Delete(v.Key); err != nil && err != store.ErrNotFound {
+			log.Errorf("Error deleting lease %s: %v", v.Key, err)
+		}
+	}
+}
+
+// revert the config of the expired lease to its default, the change is published as expired
+func (c *Handler) revert(l *lease) error {
+	ctx := context.WithValue(context.Background(), accountKey{}, l.Account)
+	ctx = context.WithValue(ctx, approvedKey{}, true)
+	ctx = context.WithValue(ctx, expiredKey{}, true)
+
+	if len(l.Default) == 0 {
+		return c.Delete(ctx, &mp.DeleteRequest{Change: &mp.Change{
+			Key:  l.Key,
+			Path: l.Path,
+		}}, &mp.DeleteResponse{})
+	}
+
+	return c.Update(ctx, &mp.UpdateRequest{Change: &mp.Change{
+		Key:       l.Key,
+		Path:      l.Path,
+		ChangeSet: &mp.ChangeSet{Data: l.Default, Format: "json", Source: "lease"},
+	}}, &mp.UpdateResponse{})
+}
@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/micro/go-micro/v2/config/source"
+	mp "github.com/micro/go-micro/v2/config/source/service/proto"
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/store"
+	"github.com/micro/go-micro/v2/util/log"
+	"github.com/micro/micro/v2/config/db"
+	"github.com/micro/micro/v2/config/mirror"
+	"golang.org/x/net/context"
+)
+
+var (
+	// ReadOnly are the namespaces which can't be written by the source they're mirrored from
+	ReadOnly = map[string]string{}
+)
+
+// writable returns errors.Forbidden with the id if the config of the key is read only
+func writable(id, key string) error {
+	if src, ok := ReadOnly[key]; ok {
+		return errors.Forbidden(id, "%s is mirrored from %s and is read only", key, src)
+	}
+	return nil
+}
+
+// Mirror the config of the source into the key until the exit channel is closed,
+// the source is watched again after an interval if it fails
+func (c *Handler) Mirror(key string, src mirror.Source, exit chan bool) {
+	for {
+		if err := c.mirror(key, src, exit); err != nil {
+			log.Errorf("Error mirroring %s from %s: %v", key, src, err)
+		}
+
+		select {
+		case <-exit:
+			return
+		case <-time.After(mirror.Interval):
+		}
+	}
+}
+
+// mirror the config of the source until it fails or the exit channel is closed
+func (c *Handler) mirror(key string, src mirror.Source, exit chan bool) error {
+	w, err := src.Watch()
+	if err != nil {
+		return err
+	}
+
+	done := make(chan bool)
+	defer close(done)
+	go func() {
+		select {
+		case <-exit:
+		case <-done:
+		}
+		w.Stop()
+	}()
+
+	// the changes made before the watch started
+	cs, err := src.Read()
+	if err != nil {
+		return err
+	}
+	if err := c.mirrored(key, src.String(), cs); err != nil {
+		return err
+	}
+
+	for {
+		cs, err := w.Next()
+		if err != nil {
+			select {
+			case <-exit:
+				return nil
+			default:
+				return err
+			}
+		}
+		if err := c.mirrored(key, src.String(), cs); err != nil {
+			return err
+		}
+	}
+}
+
+// mirrored writes the config read from the source as the config of the key unless it's
+// unchanged, the active instance records and publishes it as a change made by the source
+func (c *Handler) mirrored(key, src string, cs *source.ChangeSet) error {
+	if len(cs.Checksum) == 0 {
+		cs.Checksum = cs.Sum()
+	}
+
+	rec, err := db.Read(key)
+	if err != nil && err != store.ErrNotFound {
+		return err
+	}
+
+	action := "create"
+	var before []byte
+	if rec != nil {
+		old := &mp.Change{}
+		if err := proto.Unmarshal(rec.Value, old); err == nil && old.ChangeSet != nil {
+			if old.ChangeSet.Checksum == cs.Checksum {
+				return nil
+			}
+			before = old.ChangeSet.Data
+		}
+		action = "update"
+	}
+
+	ch := &mp.Change{
+		Key: key,
+		ChangeSet: &mp.ChangeSet{
+			Data:      cs.Data,
+			Checksum:  cs.Checksum,
+			Format:    cs.Format,
+			Source:    cs.Source,
+			Timestamp: cs.Timestamp.Unix(),
+		},
+	}
+	if err := seal(ch); err != nil {
+		return err
+	}
+
+	b, err := proto.Marshal(ch)
+	if err != nil {
+		return err
+	}
+	if err := db.Create(&store.Record{Key: key, Value: b}); err != nil {
+		return err
+	}
+	cache.invalidate(key)
+
+	// every instance mirrors the source, the active instance publishes the changes
+	if c.Standby != nil && !c.Standby.Active() {
+		return nil
+	}
+
+	ctx := context.WithValue(context.Background(), accountKey{}, "mirror:"+src)
+
+	rev := recordRevision(ctx, action, ch)
+	audit(ctx, action, key, "", before, ch.ChangeSet.Data)
+	_ = publish(ctx, changeEvent(ctx, action, rev, ch, before))
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/micro/go-micro/v2/config/source"
+	mp "github.com/micro/go-micro/v2/config/source/service/proto"
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/store"
+	"github.com/micro/go-micro/v2/util/log"
+	pb "github.com/micro/micro/v2/config/changes/proto"
+	"golang.org/x/net/context"
+)
+
+var (
+	// EnvSeparator separates the namespace from the environment of its overlay e.g app@prod
+	EnvSeparator = "@"
+)
+
+// Overlay returns the key of the overlay of the environment of the namespace. The config
+// of the namespace is the base the overlay is merged over, objects are merged key by key
+// and any other value of the overlay replaces the value of the base.
+func Overlay(key, env string) string {
+	if len(env) == 0 {
+		return key
+	}
+	return key + EnvSeparator + env
+}
+
+// Resolve returns the config of the namespace with the overlay of the environment merged over it
+func (c *Changes) Resolve(ctx context.Context, req *pb.ResolveRequest, rsp *pb.ResolveResponse) (err error) {
+	defer func() {
+		if err != nil {
+			log.Error(err)
+		}
+	}()
+
+	path := strings.Trim(req.Path, PathSplitter)
+
+	if len(req.Key) == 0 || strings.Contains(req.Key, EnvSeparator) {
+		err = errors.BadRequest("go.micro.config.Changes.Resolve", "invalid id")
+		return err
+	}
+	if strings.Contains(req.Env, EnvSeparator) || strings.Contains(req.Env, PathSplitter) {
+		err = errors.BadRequest("go.micro.config.Changes.Resolve", "invalid env %s", req.Env)
+		return err
+	}
+
+	layers := []string{req.Key}
+	if len(req.Env) > 0 {
+		layers = append(layers, Overlay(req.Key, req.Env))
+	}
+
+	// merged in order so the overlay takes precedence
+	var changes []*source.ChangeSet
+	for _, key := range layers {
+		if err = authorize(ctx, "go.micro.config.Changes.Resolve", ReadAccess, key, path); err != nil {
+			return err
+		}
+
+		rec, rerr := cache.read(key)
+		if rerr == store.ErrNotFound {
+			continue
+		} else if rerr != nil {
+			err = errors.InternalServerError("go.micro.config.Changes.Resolve", "read %s error: %v", key, rerr)
+			return err
+		}
+
+		ch := &mp.Change{}
+		if err = proto.Unmarshal(rec.Value, ch); err != nil {
+			err = errors.InternalServerError("go.micro.config.Changes.Resolve", "unmarshal %s error: %v", key, err)
+			return err
+		}
+		if ch.ChangeSet == nil {
+			continue
+		}
+
+		changes = append(changes, &source.ChangeSet{Data: ch.ChangeSet.Data, Format: ch.ChangeSet.Format})
+		rsp.Layers = append(rsp.Layers, key)
+	}
+
+	if len(changes) == 0 {
+		err = errors.NotFound("go.micro.config.Changes.Resolve", "no config in %s", Overlay(req.Key, req.Env))
+		return err
+	}
+
+	cs, err := merge(changes...)
+	if err != nil {
+		err = errors.InternalServerError("go.micro.config.Changes.Resolve", "merge error: %v", err)
+		return err
+	}
+
+	data, err := open(ctx, cs.Data)
+	if err != nil {
+		err = errors.InternalServerError("go.micro.config.Changes.Resolve", "decrypt secrets error: %v", err)
+		return err
+	}
+
+	data, err = resolve(ctx, req.Key, data)
+	if err != nil {
+		err = errors.BadRequest("go.micro.config.Changes.Resolve", "resolve references error: %v", err)
+		return err
+	}
+
+	data = valueAt(data, path)
+	rsp.ChangeSet = &pb.ChangeSet{
+		Data:      data,
+		Checksum:  (&source.ChangeSet{Data: data}).Sum(),
+		Format:    "json",
+		Source:    "resolve",
+		Timestamp: cs.Timestamp.Unix(),
+	}
+
+	return nil
+}
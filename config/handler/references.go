@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"os"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	mp "github.com/micro/go-micro/v2/config/source/service/proto"
+	"github.com/micro/go-micro/v2/metadata"
+	"github.com/micro/go-micro/v2/store"
+	"github.com/micro/micro/v2/config/db"
+	"github.com/micro/micro/v2/config/interpolate"
+	"golang.org/x/net/context"
+)
+
+var (
+	// EnvVars are the environment variables the config can reference as ${env:NAME},
+	// names ending in * are prefixes. None can be referenced if it's empty.
+	EnvVars = map[string]bool{}
+	// RawHeader requests the config with its references unresolved e.g to write it back
+	RawHeader = "Micro-Config-Raw"
+)
+
+// isInternal returns true if the key is a record of the config service e.g a pending change rather than config
+func isInternal(key string) bool {
+	return strings.HasPrefix(key, "__")
+}
+
+// env returns the environment variable if it can be referenced
+func env(name string) (string, bool) {
+	allowed := EnvVars[name]
+	for k := range EnvVars {
+		if strings.HasSuffix(k, "*") && strings.HasPrefix(name, strings.TrimSuffix(k, "*")) {
+			allowed = true
+		}
+	}
+	if !allowed {
+		return "", false
+	}
+	return os.LookupEnv(name)
+}
+
+// resolve the references of the config data of the key for the request,
+// the config referenced is read as the caller so it's scoped and masked the same
+func resolve(ctx context.Context, key string, data []byte) ([]byte, error) {
+	if md, ok := metadata.FromContext(ctx); ok && md[RawHeader] == "true" {
+		return data, nil
+	}
+
+	lookup := func(namespace, path string) ([]byte, error) {
+		// pending changes, revisions etc can't be referenced
+		if isInternal(namespace) {
+			return nil, nil
+		}
+		if err := authorize(ctx, "go.micro.config.Read", ReadAccess, namespace, path); err != nil {
+			return nil, err
+		}
+
+		rec, err := db.Read(namespace)
+		if err == store.ErrNotFound {
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		ch := &mp.Change{}
+		if err := proto.Unmarshal(rec.Value, ch); err != nil || ch.ChangeSet == nil {
+			return nil, err
+		}
+		return open(ctx, valueAt(ch.ChangeSet.Data, path))
+	}
+
+	return interpolate.Resolve(key, data, lookup, env)
+}
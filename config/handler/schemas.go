@@ -0,0 +1,290 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	mp "github.com/micro/go-micro/v2/config/source/service/proto"
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/store"
+	"github.com/micro/go-micro/v2/util/log"
+	pb "github.com/micro/micro/v2/config/changes/proto"
+	"github.com/micro/micro/v2/config/db"
+	"github.com/micro/micro/v2/config/schema"
+	"golang.org/x/net/context"
+)
+
+var (
+	// schemasPrefix is the db key prefix for the schemas of each key
+	schemasPrefix = "__schemas__/"
+
+	// schemaMtx serialises the changes to the schemas of a key
+	schemaMtx sync.Mutex
+)
+
+// Schemas handles the JSON Schemas the config is validated against
+type Schemas struct {
+	Config *Handler
+}
+
+func isSchema(key string) bool {
+	return strings.HasPrefix(key, schemasPrefix)
+}
+
+// readSchemas returns the schemas of the key by path, they're stored as a single record
+func readSchemas(key string) (map[string]json.RawMessage, error) {
+	schemas := map[string]json.RawMessage{}
+
+	rec, err := db.Read(schemasPrefix + key)
+	if err == store.ErrNotFound || err == db.ErrNotFound {
+		return schemas, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(rec.Value, &schemas); err != nil {
+		return nil, err
+	}
+	return schemas, nil
+}
+
+func writeSchemas(key string, schemas map[string]json.RawMessage) error {
+	if len(schemas) == 0 {
+		err := db.Delete(schemasPrefix + key)
+		if err == store.ErrNotFound || err == db.ErrNotFound {
+			return nil
+		}
+		return err
+	}
+
+	b, err := json.Marshal(schemas)
+	if err != nil {
+		return err
+	}
+
+	rec := &store.Record{Key: schemasPrefix + key, Value: b}
+	if _, err := db.Read(rec.Key); err == nil {
+		return db.Update(rec)
+	}
+	return db.Create(rec)
+}
+
+// violations returns the violations of the schema by the config data at the path,
+// the paths of the errors are relative to the config rather than the path
+func violations(s *schema.Schema, data []byte, path string) (schema.Errors, error) {
+	var v interface{}
+	if b := valueAt(data, path); len(b) > 0 {
+		// secrets are validated as the mask rather than their ciphertext
+		dec := json.NewDecoder(bytes.NewReader(mask(b)))
+		dec.UseNumber()
+		if err := dec.Decode(&v); err != nil {
+			return nil, err
+		}
+	} else if len(path) > 0 {
+		// the schema of a path which isn't set, required paths are checked by the schema of the parent
+		return nil, nil
+	}
+
+	errs := s.Validate(v)
+	for _, e := range errs {
+		e.Path = strings.Trim(path+PathSplitter+e.Path, PathSplitter)
+	}
+	return errs, nil
+}
+
+// validate the config data of the key against the schemas of its paths, the
+// violations are returned as a bad request with an error for each field
+func validate(id, key string, data []byte) error {
+	schemas, err := readSchemas(key)
+	if err != nil {
+		return errors.InternalServerError(id, "read schemas error: %v", err)
+	}
+
+	paths := make([]string, 0, len(schemas))
+	for path := range schemas {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var errs schema.Errors
+	for _, path := range paths {
+		s, err := schema.Parse(schemas[path])
+		if err != nil {
+			return errors.InternalServerError(id, "schema of %s error: %v", path, err)
+		}
+		e, err := violations(s, data, path)
+		if err != nil {
+			return errors.InternalServerError(id, "validate error: %v", err)
+		}
+		errs = append(errs, e...)
+	}
+
+	if len(errs) > 0 {
+		return errors.BadRequest(id, "config violates the schema: %v", errs)
+	}
+	return nil
+}
+
+func (s *Schemas) Set(ctx context.Context, req *pb.SetSchemaRequest, rsp *pb.SetSchemaResponse) (err error) {
+	defer func() {
+		if err != nil {
+			log.Error(err)
+		}
+	}()
+
+	if ok, err := s.Config.forward(ctx, "Schemas.Set", req, rsp); ok {
+		return err
+	}
+
+	if req.Schema == nil || len(req.Schema.Key) == 0 {
+		err = errors.BadRequest("go.micro.config.Schemas.Set", "invalid id")
+		return err
+	}
+
+	if err = authorize(ctx, "go.micro.config.Schemas.Set", WriteAccess, req.Schema.Key, req.Schema.Path); err != nil {
+		return err
+	}
+
+	sc, err := schema.Parse(req.Schema.Schema)
+	if err != nil {
+		err = errors.BadRequest("go.micro.config.Schemas.Set", "%v", err)
+		return err
+	}
+
+	path := strings.Trim(req.Schema.Path, PathSplitter)
+
+	// the config must already be valid against the schema
+	if rec, rerr := db.Read(req.Schema.Key); rerr == nil {
+		ch := &mp.Change{}
+		if err := proto.Unmarshal(rec.Value, ch); err != nil {
+			err = errors.InternalServerError("go.micro.config.Schemas.Set", "unmarshal value error: %v", err)
+			return err
+		}
+		errs, err := violations(sc, ch.GetChangeSet().GetData(), path)
+		if err != nil {
+			err = errors.InternalServerError("go.micro.config.Schemas.Set", "validate error: %v", err)
+			return err
+		}
+		if len(errs) > 0 {
+			err = errors.BadRequest("go.micro.config.Schemas.Set", "config violates the schema: %v", errs)
+			return err
+		}
+	}
+
+	schemaMtx.Lock()
+	defer schemaMtx.Unlock()
+
+	schemas, err := readSchemas(req.Schema.Key)
+	if err != nil {
+		err = errors.InternalServerError("go.micro.config.Schemas.Set", "read schemas error: %v", err)
+		return err
+	}
+	schemas[path] = json.RawMessage(req.Schema.Schema)
+
+	if err := writeSchemas(req.Schema.Key, schemas); err != nil {
+		err = errors.InternalServerError("go.micro.config.Schemas.Set", "write schemas error: %v", err)
+		return err
+	}
+
+	s.Config.replicate(ctx, "Schemas.Set", req, func() interface{} { return new(pb.SetSchemaResponse) })
+
+	log.Infof("Schema of %s in %s set by %s", path, req.Schema.Key, account(ctx))
+
+	return nil
+}
+
+func (s *Schemas) Get(ctx context.Context, req *pb.GetSchemaRequest, rsp *pb.GetSchemaResponse) (err error) {
+	defer func() {
+		if err != nil {
+			log.Error(err)
+		}
+	}()
+
+	// the config is validated by the active instance
+	if ok, err := s.Config.forward(ctx, "Schemas.Get", req, rsp); ok {
+		return err
+	}
+
+	if len(req.Key) == 0 {
+		err = errors.BadRequest("go.micro.config.Schemas.Get", "invalid id")
+		return err
+	}
+
+	if err = authorize(ctx, "go.micro.config.Schemas.Get", ReadAccess, req.Key, req.Path); err != nil {
+		return err
+	}
+
+	schemas, err := readSchemas(req.Key)
+	if err != nil {
+		err = errors.InternalServerError("go.micro.config.Schemas.Get", "read schemas error: %v", err)
+		return err
+	}
+
+	path := strings.Trim(req.Path, PathSplitter)
+
+	paths := make([]string, 0, len(schemas))
+	for p := range schemas {
+		if len(path) == 0 || p == path {
+			paths = append(paths, p)
+		}
+	}
+	sort.Strings(paths)
+
+	for _, p := range paths {
+		rsp.Schemas = append(rsp.Schemas, &pb.Schema{Key: req.Key, Path: p, Schema: schemas[p]})
+	}
+
+	return nil
+}
+
+func (s *Schemas) Delete(ctx context.Context, req *pb.DeleteSchemaRequest, rsp *pb.DeleteSchemaResponse) (err error) {
+	defer func() {
+		if err != nil {
+			log.Error(err)
+		}
+	}()
+
+	if ok, err := s.Config.forward(ctx, "Schemas.Delete", req, rsp); ok {
+		return err
+	}
+
+	if len(req.Key) == 0 {
+		err = errors.BadRequest("go.micro.config.Schemas.Delete", "invalid id")
+		return err
+	}
+
+	if err = authorize(ctx, "go.micro.config.Schemas.Delete", WriteAccess, req.Key, req.Path); err != nil {
+		return err
+	}
+
+	schemaMtx.Lock()
+	defer schemaMtx.Unlock()
+
+	schemas, err := readSchemas(req.Key)
+	if err != nil {
+		err = errors.InternalServerError("go.micro.config.Schemas.Delete", "read schemas error: %v", err)
+		return err
+	}
+
+	path := strings.Trim(req.Path, PathSplitter)
+	if _, ok := schemas[path]; !ok {
+		err = errors.NotFound("go.micro.config.Schemas.Delete", "no schema of %q in %s", path, req.Key)
+		return err
+	}
+	delete(schemas, path)
+
+	if err := writeSchemas(req.Key, schemas); err != nil {
+		err = errors.InternalServerError("go.micro.config.Schemas.Delete", "write schemas error: %v", err)
+		return err
+	}
+
+	s.Config.replicate(ctx, "Schemas.Delete", req, func() interface{} { return new(pb.DeleteSchemaResponse) })
+
+	log.Infof("Schema of %s in %s deleted by %s", path, req.Key, account(ctx))
+
+	return nil
+}
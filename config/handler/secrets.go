@@ -0,0 +1,51 @@
+package handler
+
+import (
+	mp "github.com/micro/go-micro/v2/config/source/service/proto"
+	"github.com/micro/go-micro/v2/metadata"
+	"github.com/micro/micro/v2/config/secret"
+	"golang.org/x/net/context"
+)
+
+var (
+	// Secrets encrypts the secrets of the config, if set
+	Secrets secret.Provider
+	// SecretReaders are the accounts the secrets are decrypted for when read
+	SecretReaders = map[string]bool{}
+	// EncryptedHeader lists the secrets encrypted rather than masked e.g for a standby to sync
+	EncryptedHeader = "Micro-Config-Encrypted"
+)
+
+// seal encrypts the secrets of the change before it's written
+func seal(ch *mp.Change) error {
+	if ch.ChangeSet == nil {
+		return nil
+	}
+	b, err := secret.Seal(Secrets, ch.ChangeSet.Data)
+	if err != nil {
+		return err
+	}
+	ch.ChangeSet.Data = b
+	return nil
+}
+
+// open decrypts the secrets of the data for the secret readers and masks them for anyone else
+func open(ctx context.Context, data []byte) ([]byte, error) {
+	acc := account(ctx)
+	return secret.Open(Secrets, data, len(acc) > 0 && SecretReaders[acc])
+}
+
+// mask the secrets of the data
+func mask(data []byte) []byte {
+	b, err := secret.Open(nil, data, false)
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
+// encrypted returns true if the secrets are requested encrypted
+func encrypted(ctx context.Context) bool {
+	md, ok := metadata.FromContext(ctx)
+	return ok && md[EncryptedHeader] == "true"
+}
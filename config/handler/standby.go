@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/micro/go-micro/v2/metadata"
+	"golang.org/x/net/context"
+)
+
+// ExpiredHeader marks a replicated write as reverting the config of an expired lease,
+// it's forwarded with the writes so the standbys authorize it as the active instance did
+var ExpiredHeader = "Micro-Config-Expired"
+
+// forward the write to the active instance returning true if it was, replicated
+// writes are applied locally and are returned as not forwarded
+func (c *Handler) forward(ctx context.Context, endpoint string, req, rsp interface{}) (bool, error) {
+	if c.Standby == nil {
+		return false, nil
+	}
+	if ok, err := c.Standby.Replicated(ctx); ok {
+		return err != nil, err
+	}
+	return c.Standby.Forward(ctx, endpoint, req, rsp)
+}
+
+// replica returns true if the write was replicated by the active instance,
+// it was already approved and published there
+func (c *Handler) replica(ctx context.Context) bool {
+	if c.Standby == nil {
+		return false
+	}
+	ok, err := c.Standby.Replicated(ctx)
+	return ok && err == nil
+}
+
+// expired marks the context of a write replicated by the active instance
+// as reverting an expired lease
+func (c *Handler) expired(ctx context.Context) context.Context {
+	md, ok := metadata.FromContext(ctx)
+	if !ok || md[ExpiredHeader] != "true" || !c.replica(ctx) {
+		return ctx
+	}
+	return context.WithValue(ctx, expiredKey{}, true)
+}
+
+// replicate the write as it was requested to the standbys once applied
+func (c *Handler) replicate(ctx context.Context, endpoint string, req proto.Message, rsp func() interface{}) {
+	if c.Standby == nil || c.replica(ctx) {
+		return
+	}
+
+	if v, ok := ctx.Value(expiredKey{}).(bool); ok && v {
+		md := metadata.Metadata{ExpiredHeader: "true"}
+		if v, ok := metadata.FromContext(ctx); ok {
+			for k, val := range v {
+				md[k] = val
+			}
+		}
+		ctx = metadata.NewContext(ctx, md)
+	}
+
+	c.Standby.Replicate(ctx, endpoint, req, rsp)
+}
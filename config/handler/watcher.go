@@ -2,8 +2,14 @@ package handler
 
 import (
 	"errors"
+	"sync"
+	"time"
 
+	gproto "github.com/golang/protobuf/proto"
 	proto "github.com/micro/go-micro/v2/config/source/service/proto"
+	"github.com/micro/go-micro/v2/util/log"
+	"github.com/micro/micro/v2/config/db"
+	"golang.org/x/net/context"
 )
 
 type watcher struct {
@@ -56,3 +62,69 @@ func Watch(id string) (*watcher, error) {
 	mtx.Unlock()
 	return w, nil
 }
+
+// Stream delivers the changes streamed by the db to the watchers rather than
+// publishing them, the stream is reopened if it fails until the exit channel is closed
+func Stream(w db.Watcher, exit chan bool) {
+	var wmtx sync.Mutex
+
+	go func() {
+		<-exit
+		wmtx.Lock()
+		w.Stop()
+		wmtx.Unlock()
+	}()
+
+	for {
+		wmtx.Lock()
+		current := w
+		wmtx.Unlock()
+
+		ev, err := current.Next()
+		if err != nil {
+			select {
+			case <-exit:
+				return
+			case <-time.After(time.Second):
+			}
+
+			log.Errorf("Error streaming the config changes: %v", err)
+
+			nw, err := db.Watch()
+			if err != nil {
+				continue
+			}
+
+			wmtx.Lock()
+			current.Stop()
+			w = nw
+			wmtx.Unlock()
+
+			// stopped while the stream was reopened
+			select {
+			case <-exit:
+				nw.Stop()
+				return
+			default:
+			}
+			continue
+		}
+
+		// deleting a key isn't published and neither are the internal records
+		if isInternal(ev.Key) {
+			continue
+		}
+		if ev.Record == nil {
+			cache.invalidate(ev.Key)
+			continue
+		}
+
+		ch := &proto.Change{}
+		if err := gproto.Unmarshal(ev.Record.Value, ch); err != nil {
+			log.Errorf("Error unmarshalling the change to %s: %v", ev.Key, err)
+			continue
+		}
+
+		_ = Watcher(context.Background(), &proto.WatchResponse{Key: ch.Key, ChangeSet: ch.ChangeSet})
+	}
+}
@@ -0,0 +1,188 @@
+// Package interpolate resolves the references of the config data when it's read.
+// A string value of ${path/to/value} is replaced by the value at the path of the same
+// namespace, ${namespace:path/to/value} by the value of another namespace and
+// ${env:NAME} by an environment variable. A string which is a single reference takes
+// the value referenced whatever its type, references within a string are replaced by
+// the value as a string. $${ is written as a literal ${.
+package interpolate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+var (
+	// Separator of the path of a reference
+	Separator = "/"
+)
+
+// Lookup returns the config data of the namespace at the path, nil if there's none
+type Lookup func(namespace, path string) ([]byte, error)
+
+// Env returns the value of the environment variable, false if it can't be referenced
+type Env func(name string) (string, bool)
+
+// Resolve the references of the config data of the namespace
+func Resolve(namespace string, data []byte, lookup Lookup, env Env) ([]byte, error) {
+	// most config has no references so it's returned unchanged
+	if !bytes.Contains(data, []byte("${")) {
+		return data, nil
+	}
+
+	v, err := decode(data)
+	if err != nil {
+		// not json so there are no references
+		return data, nil
+	}
+
+	r := &resolver{lookup: lookup, env: env}
+	if v, err = r.walk(namespace, v); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(v)
+}
+
+func decode(b []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+type resolver struct {
+	lookup Lookup
+	env    Env
+	// stack of the references being resolved to detect cycles
+	stack []string
+}
+
+// walk the value resolving the references of its strings
+func (r *resolver) walk(namespace string, v interface{}) (interface{}, error) {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, e := range t {
+			val, err := r.walk(namespace, e)
+			if err != nil {
+				return nil, err
+			}
+			t[k] = val
+		}
+	case []interface{}:
+		for i, e := range t {
+			val, err := r.walk(namespace, e)
+			if err != nil {
+				return nil, err
+			}
+			t[i] = val
+		}
+	case string:
+		return r.interpolate(namespace, t)
+	}
+	return v, nil
+}
+
+// interpolate the references of the string
+func (r *resolver) interpolate(namespace, s string) (interface{}, error) {
+	if !strings.Contains(s, "${") {
+		return s, nil
+	}
+
+	// a single reference takes the value referenced
+	if strings.HasPrefix(s, "${") && strings.Index(s, "}") == len(s)-1 {
+		return r.resolve(namespace, s[2:len(s)-1])
+	}
+
+	var buf strings.Builder
+	for {
+		i := strings.Index(s, "${")
+		if i < 0 {
+			buf.WriteString(s)
+			break
+		}
+
+		// escaped
+		if i > 0 && s[i-1] == '$' {
+			buf.WriteString(s[:i-1])
+			buf.WriteString("${")
+			s = s[i+2:]
+			continue
+		}
+
+		j := strings.Index(s[i:], "}")
+		if j < 0 {
+			return nil, fmt.Errorf("unterminated reference %s", s[i:])
+		}
+
+		v, err := r.resolve(namespace, s[i+2:i+j])
+		if err != nil {
+			return nil, err
+		}
+
+		buf.WriteString(s[:i])
+		if str, ok := v.(string); ok {
+			buf.WriteString(str)
+		} else {
+			b, err := json.Marshal(v)
+			if err != nil {
+				return nil, err
+			}
+			buf.Write(b)
+		}
+		s = s[i+j+1:]
+	}
+
+	return buf.String(), nil
+}
+
+// resolve the reference of the namespace
+func (r *resolver) resolve(namespace, ref string) (interface{}, error) {
+	if strings.HasPrefix(ref, "env:") {
+		name := strings.TrimPrefix(ref, "env:")
+		if r.env == nil {
+			return nil, fmt.Errorf("unresolved reference ${%s}", ref)
+		}
+		v, ok := r.env(name)
+		if !ok {
+			return nil, fmt.Errorf("unresolved reference ${%s}", ref)
+		}
+		return v, nil
+	}
+
+	ns, path := namespace, ref
+	if i := strings.Index(ref, ":"); i >= 0 {
+		ns, path = ref[:i], ref[i+1:]
+	}
+	path = strings.Trim(path, Separator)
+
+	id := ns + ":" + path
+	for i, s := range r.stack {
+		if s == id {
+			return nil, fmt.Errorf("reference cycle %s", strings.Join(append(r.stack[i:], id), " -> "))
+		}
+	}
+	r.stack = append(r.stack, id)
+	defer func() { r.stack = r.stack[:len(r.stack)-1] }()
+
+	b, err := r.lookup(ns, path)
+	if err != nil {
+		return nil, fmt.Errorf("${%s}: %v", ref, err)
+	}
+	if b == nil {
+		return nil, fmt.Errorf("unresolved reference ${%s}", ref)
+	}
+
+	v, err := decode(b)
+	if err != nil {
+		return nil, fmt.Errorf("${%s}: %v", ref, err)
+	}
+
+	// the value referenced is resolved in its own namespace
+	return r.walk(ns, v)
+}
@@ -0,0 +1,102 @@
+package interpolate
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// lookup the config of the namespaces
+func lookup(configs map[string]string) Lookup {
+	return func(namespace, path string) ([]byte, error) {
+		data, ok := configs[namespace]
+		if !ok {
+			return nil, nil
+		}
+		v, err := decode([]byte(data))
+		if err != nil {
+			return nil, err
+		}
+		if len(path) > 0 {
+			for _, p := range strings.Split(path, Separator) {
+				m, ok := v.(map[string]interface{})
+				if !ok {
+					return nil, nil
+				}
+				if v, ok = m[p]; !ok {
+					return nil, nil
+				}
+			}
+		}
+		return json.Marshal(v)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	configs := map[string]string{
+		"global": `{"db": {"host": "prod-db", "port": 5432}, "region": "${env:REGION}"}`,
+		"app":    `{"name": "app"}`,
+	}
+	env := func(name string) (string, bool) {
+		if name == "REGION" {
+			return "eu-west-1", true
+		}
+		return "", false
+	}
+
+	testData := []struct {
+		data   string
+		expect string
+	}{
+		// the same namespace
+		{`{"a": "${name}"}`, `{"a":"app"}`},
+		// another namespace keeps the type of the value
+		{`{"port": "${global:db/port}"}`, `{"port":5432}`},
+		{`{"db": "${global:db}"}`, `{"db":{"host":"prod-db","port":5432}}`},
+		// within a string
+		{`{"url": "postgres://${global:db/host}:${global:db/port}/app"}`, `{"url":"postgres://prod-db:5432/app"}`},
+		// the references of the value referenced
+		{`{"region": "${global:region}"}`, `{"region":"eu-west-1"}`},
+		{`{"env": ["${env:REGION}"]}`, `{"env":["eu-west-1"]}`},
+		// escaped
+		{`{"a": "$${name}"}`, `{"a":"${name}"}`},
+		// no references
+		{`{"a": 1}`, `{"a": 1}`},
+		{`not json ${name}`, `not json ${name}`},
+	}
+
+	for _, d := range testData {
+		b, err := Resolve("app", []byte(d.data), lookup(configs), env)
+		if err != nil {
+			t.Fatalf("Resolving %s error: %v", d.data, err)
+		}
+		if string(b) != d.expect {
+			t.Fatalf("Expected %s resolved to %s got %s", d.data, d.expect, b)
+		}
+	}
+}
+
+func TestResolveErrors(t *testing.T) {
+	configs := map[string]string{
+		"a": `{"x": "${b:y}", "self": "${self}"}`,
+		"b": `{"y": "${a:x}"}`,
+	}
+
+	testData := []struct {
+		data   string
+		expect string
+	}{
+		{`{"v": "${a:x}"}`, "reference cycle a:x -> b:y -> a:x"},
+		{`{"v": "${self}"}`, "reference cycle a:self -> a:self"},
+		{`{"v": "${missing}"}`, "unresolved reference ${missing}"},
+		{`{"v": "${env:HOME}"}`, "unresolved reference ${env:HOME}"},
+		{`{"v": "x${missing"}`, "unterminated reference ${missing"},
+	}
+
+	for _, d := range testData {
+		_, err := Resolve("a", []byte(d.data), lookup(configs), nil)
+		if err == nil || !strings.Contains(err.Error(), d.expect) {
+			t.Fatalf("Expected %s to fail with %q got %v", d.data, d.expect, err)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package mirror
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/micro/go-micro/v2/config/source"
+)
+
+var (
+	// ServiceAccount is the directory of the token and CA of the kubernetes service account
+	ServiceAccount = "/var/run/secrets/kubernetes.io/serviceaccount"
+)
+
+// configMap reads a kubernetes ConfigMap with the service account of the pod, its keys
+// are the keys of the config and their values are decoded if they're json
+type configMap struct {
+	address   string
+	namespace string
+	name      string
+	token     string
+	client    *http.Client
+}
+
+// configMapObject is the part of a ConfigMap which is mirrored
+type configMapObject struct {
+	Metadata struct {
+		ResourceVersion string `json:"resourceVersion"`
+	} `json:"metadata"`
+	Data map[string]string `json:"data"`
+}
+
+// newConfigMap returns the source of the ConfigMap e.g configmap://default/app
+func newConfigMap(u *url.URL) (Source, error) {
+	name := strings.Trim(u.Path, "/")
+	if len(u.Host) == 0 || len(name) == 0 {
+		return nil, fmt.Errorf("invalid configmap url %s, expected configmap://namespace/name", u)
+	}
+
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if len(host) == 0 || len(port) == 0 {
+		return nil, errors.New("configmap sources require running in kubernetes, KUBERNETES_SERVICE_HOST is not set")
+	}
+
+	token, err := ioutil.ReadFile(ServiceAccount + "/token")
+	if err != nil {
+		return nil, fmt.Errorf("read service account token error: %v", err)
+	}
+	ca, err := ioutil.ReadFile(ServiceAccount + "/ca.crt")
+	if err != nil {
+		return nil, fmt.Errorf("read service account ca error: %v", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, errors.New("no certificates in the service account ca")
+	}
+
+	return &configMap{
+		address:   "https://" + net.JoinHostPort(host, port),
+		namespace: u.Host,
+		name:      name,
+		token:     strings.TrimSpace(string(token)),
+		// watches are long lived so there's no timeout, only to connect
+		client: &http.Client{Transport: &http.Transport{
+			TLSClientConfig:       &tls.Config{RootCAs: pool},
+			TLSHandshakeTimeout:   Timeout,
+			ResponseHeaderTimeout: Timeout,
+		}},
+	}, nil
+}
+
+// do the request to the path of the api
+func (c *configMap) do(path string, q url.Values) (*http.Response, error) {
+	r, err := http.NewRequest("GET", c.address+path+"?"+q.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	r.Header.Set("Authorization", "Bearer "+c.token)
+
+	rsp, err := c.client.Do(r)
+	if err != nil {
+		return nil, err
+	}
+	if rsp.StatusCode != http.StatusOK {
+		rsp.Body.Close()
+		return nil, fmt.Errorf("kubernetes %s: %s", path, rsp.Status)
+	}
+	return rsp, nil
+}
+
+func (c *configMap) get() (*configMapObject, error) {
+	rsp, err := c.do(fmt.Sprintf("/api/v1/namespaces/%s/configmaps/%s", c.namespace, c.name), url.Values{})
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+
+	obj := &configMapObject{}
+	if err := json.NewDecoder(rsp.Body).Decode(obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+func (c *configMap) changeSet(obj *configMapObject) (*source.ChangeSet, error) {
+	config := map[string]interface{}{}
+	for k, v := range obj.Data {
+		config[k] = value([]byte(v))
+	}
+	return changeSet(config, c.String())
+}
+
+func (c *configMap) Read() (*source.ChangeSet, error) {
+	obj, err := c.get()
+	if err != nil {
+		return nil, err
+	}
+	return c.changeSet(obj)
+}
+
+// Watch the ConfigMap for changes after the version read
+func (c *configMap) Watch() (source.Watcher, error) {
+	obj, err := c.get()
+	if err != nil {
+		return nil, err
+	}
+
+	rsp, err := c.do(fmt.Sprintf("/api/v1/namespaces/%s/configmaps", c.namespace), url.Values{
+		"watch":           {"true"},
+		"fieldSelector":   {"metadata.name=" + c.name},
+		"resourceVersion": {obj.Metadata.ResourceVersion},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &configMapWatcher{configMap: c, body: rsp.Body, dec: json.NewDecoder(rsp.Body)}, nil
+}
+
+func (c *configMap) String() string {
+	return "configmap"
+}
+
+type configMapWatcher struct {
+	configMap *configMap
+	body      io.Closer
+	dec       *json.Decoder
+	once      sync.Once
+}
+
+// Next returns the ConfigMap when it's changed, it's empty when it's deleted
+func (w *configMapWatcher) Next() (*source.ChangeSet, error) {
+	for {
+		var ev struct {
+			Type   string          `json:"type"`
+			Object configMapObject `json:"object"`
+		}
+		if err := w.dec.Decode(&ev); err != nil {
+			return nil, err
+		}
+
+		switch ev.Type {
+		case "ADDED", "MODIFIED":
+			return w.configMap.changeSet(&ev.Object)
+		case "DELETED":
+			return w.configMap.changeSet(&configMapObject{})
+		case "ERROR":
+			// e.g the version watched from is too old, the source is watched again
+			return nil, errors.New("configmap watch error")
+		}
+	}
+}
+
+func (w *configMapWatcher) Stop() error {
+	var err error
+	w.once.Do(func() { err = w.body.Close() })
+	return err
+}
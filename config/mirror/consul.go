@@ -0,0 +1,177 @@
+package mirror
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/micro/go-micro/v2/config/source"
+)
+
+// consul reads the keys of a prefix of the consul kv store, the token is read from CONSUL_HTTP_TOKEN
+type consul struct {
+	address string
+	prefix  string
+	token   string
+	client  *http.Client
+}
+
+// consulPair is a key of the kv store
+type consulPair struct {
+	Key   string
+	Value string
+}
+
+// newConsul returns the source of the keys of the prefix e.g consul://127.0.0.1:8500/micro/config,
+// the keys are nested by their path below the prefix. Set ?tls=true to connect with https.
+func newConsul(u *url.URL) (Source, error) {
+	if len(u.Host) == 0 {
+		return nil, fmt.Errorf("invalid consul url %s, expected consul://host:port/prefix", u)
+	}
+
+	scheme := "http"
+	if u.Query().Get("tls") == "true" {
+		scheme = "https"
+	}
+
+	return &consul{
+		address: scheme + "://" + u.Host,
+		prefix:  strings.Trim(u.Path, "/"),
+		token:   os.Getenv("CONSUL_HTTP_TOKEN"),
+		// blocking queries wait up to 5 minutes
+		client: &http.Client{Timeout: 5*time.Minute + Timeout},
+	}, nil
+}
+
+// get the keys of the prefix, waiting for a change after the index if it's set
+func (c *consul) get(index uint64) (*source.ChangeSet, uint64, error) {
+	q := url.Values{"recurse": {"true"}}
+	if index > 0 {
+		q.Set("index", strconv.FormatUint(index, 10))
+		q.Set("wait", "5m")
+	}
+
+	r, err := http.NewRequest("GET", fmt.Sprintf("%s/v1/kv/%s?%s", c.address, c.prefix, q.Encode()), nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(c.token) > 0 {
+		r.Header.Set("X-Consul-Token", c.token)
+	}
+
+	rsp, err := c.client.Do(r)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rsp.Body.Close()
+
+	// the index of the keys, a change to them increases it
+	next, _ := strconv.ParseUint(rsp.Header.Get("X-Consul-Index"), 10, 64)
+
+	var pairs []*consulPair
+	switch rsp.StatusCode {
+	case http.StatusOK:
+		if err := json.NewDecoder(rsp.Body).Decode(&pairs); err != nil {
+			return nil, 0, err
+		}
+	case http.StatusNotFound:
+		// there are no keys
+	default:
+		return nil, 0, fmt.Errorf("consul %s: %s", r.URL.Path, rsp.Status)
+	}
+
+	config := map[string]interface{}{}
+	for _, p := range pairs {
+		path := strings.Trim(strings.TrimPrefix(p.Key, c.prefix), "/")
+		// folders have no value
+		if len(path) == 0 || strings.HasSuffix(p.Key, "/") {
+			continue
+		}
+		b, err := base64.StdEncoding.DecodeString(p.Value)
+		if err != nil {
+			return nil, 0, fmt.Errorf("consul key %s: %v", p.Key, err)
+		}
+		nest(config, path, value(b))
+	}
+
+	cs, err := changeSet(config, c.String())
+	if err != nil {
+		return nil, 0, err
+	}
+	return cs, next, nil
+}
+
+func (c *consul) Read() (*source.ChangeSet, error) {
+	cs, _, err := c.get(0)
+	return cs, err
+}
+
+func (c *consul) Watch() (source.Watcher, error) {
+	_, index, err := c.get(0)
+	if err != nil {
+		return nil, err
+	}
+	return &consulWatcher{consul: c, index: index, exit: make(chan bool)}, nil
+}
+
+func (c *consul) String() string {
+	return "consul"
+}
+
+type consulWatcher struct {
+	consul *consul
+	index  uint64
+	exit   chan bool
+}
+
+// Next blocks until the keys change
+func (w *consulWatcher) Next() (*source.ChangeSet, error) {
+	type result struct {
+		cs    *source.ChangeSet
+		index uint64
+		err   error
+	}
+
+	for {
+		ch := make(chan result, 1)
+		go func() {
+			cs, index, err := w.consul.get(w.index)
+			ch <- result{cs, index, err}
+		}()
+
+		select {
+		case <-w.exit:
+			return nil, errors.New("watcher stopped")
+		case r := <-ch:
+			if r.err != nil {
+				return nil, r.err
+			}
+			// the wait timed out without a change
+			if r.index == w.index {
+				continue
+			}
+			// the index is reset if it goes backwards e.g consul was restored
+			if r.index < w.index {
+				r.index = 0
+			}
+			w.index = r.index
+			return r.cs, nil
+		}
+	}
+}
+
+func (w *consulWatcher) Stop() error {
+	select {
+	case <-w.exit:
+	default:
+		close(w.exit)
+	}
+	return nil
+}
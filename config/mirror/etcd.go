@@ -0,0 +1,26 @@
+package mirror
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/micro/go-micro/v2/config/source"
+	"github.com/micro/go-micro/v2/config/source/etcd"
+)
+
+// newEtcd returns the source of the keys of the prefix e.g etcd://127.0.0.1:2379/micro/config,
+// the keys are nested by their path below the prefix
+func newEtcd(u *url.URL) (Source, error) {
+	opts := []source.Option{
+		etcd.WithAddress(strings.Split(u.Host, ",")...),
+		etcd.StripPrefix(true),
+	}
+	if prefix := u.Path; len(prefix) > 0 {
+		opts = append(opts, etcd.WithPrefix(prefix))
+	}
+	if u.User != nil {
+		pass, _ := u.User.Password()
+		opts = append(opts, etcd.Auth(u.User.Username(), pass))
+	}
+	return etcd.NewSource(opts...), nil
+}
@@ -0,0 +1,120 @@
+// Package mirror reads the config of external sources so the config service can mirror them
+// into namespaces, read only. The source of a namespace is a url e.g etcd://127.0.0.1:2379/micro/config,
+// consul://127.0.0.1:8500/micro/config or configmap://default/app for a kubernetes ConfigMap.
+package mirror
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/micro/go-micro/v2/config/source"
+)
+
+var (
+	// Timeout of the requests to the sources
+	Timeout = 10 * time.Second
+	// Interval between retries of a source which failed
+	Interval = 5 * time.Second
+
+	mtx      sync.RWMutex
+	builders = map[string]Builder{
+		"etcd":      newEtcd,
+		"consul":    newConsul,
+		"configmap": newConfigMap,
+	}
+)
+
+// Source of the config mirrored
+type Source interface {
+	// Read the config as json
+	Read() (*source.ChangeSet, error)
+	// Watch the config for changes
+	Watch() (source.Watcher, error)
+	String() string
+}
+
+// Builder returns the source of the url
+type Builder func(u *url.URL) (Source, error)
+
+// Register the builder of the sources of the url scheme
+func Register(scheme string, b Builder) {
+	mtx.Lock()
+	builders[scheme] = b
+	mtx.Unlock()
+}
+
+// NewSource returns the source of the url
+func NewSource(u string) (Source, error) {
+	pu, err := url.Parse(u)
+	if err != nil {
+		return nil, fmt.Errorf("invalid source %s: %v", u, err)
+	}
+
+	mtx.RLock()
+	b, ok := builders[pu.Scheme]
+	mtx.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unsupported source %s, expected etcd, consul or configmap", u)
+	}
+
+	return b(pu)
+}
+
+// Parse the comma separated list of namespace=url pairs of the sources of the namespaces
+func Parse(s string) (map[string]string, error) {
+	mirrors := map[string]string{}
+	for _, m := range strings.Split(s, ",") {
+		if m = strings.TrimSpace(m); len(m) == 0 {
+			continue
+		}
+		parts := strings.SplitN(m, "=", 2)
+		if len(parts) != 2 || len(parts[0]) == 0 || len(parts[1]) == 0 {
+			return nil, fmt.Errorf("invalid mirror %s, expected namespace=url", m)
+		}
+		mirrors[parts[0]] = parts[1]
+	}
+	return mirrors, nil
+}
+
+// value decodes the value if it's json, otherwise it's a string
+func value(b []byte) interface{} {
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return string(b)
+	}
+	return v
+}
+
+// nest the value in the config at the slash separated path
+func nest(config map[string]interface{}, path string, v interface{}) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	for _, p := range parts[:len(parts)-1] {
+		m, ok := config[p].(map[string]interface{})
+		if !ok {
+			m = map[string]interface{}{}
+			config[p] = m
+		}
+		config = m
+	}
+	config[parts[len(parts)-1]] = v
+}
+
+// changeSet of the config of the source
+func changeSet(config map[string]interface{}, src string) (*source.ChangeSet, error) {
+	b, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	cs := &source.ChangeSet{
+		Data:      b,
+		Format:    "json",
+		Source:    src,
+		Timestamp: time.Now(),
+	}
+	cs.Checksum = cs.Sum()
+	return cs, nil
+}
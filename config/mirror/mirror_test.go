@@ -0,0 +1,38 @@
+package mirror
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	mirrors, err := Parse("app=etcd://127.0.0.1:2379/micro/config, k8s=configmap://default/app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mirrors) != 2 || mirrors["app"] != "etcd://127.0.0.1:2379/micro/config" || mirrors["k8s"] != "configmap://default/app" {
+		t.Fatalf("unexpected mirrors %v", mirrors)
+	}
+
+	if _, err := Parse("app"); err == nil {
+		t.Fatal("expected an error for a mirror without a url")
+	}
+	if _, err := NewSource("s3://bucket/config"); err == nil {
+		t.Fatal("expected an error for an unsupported source")
+	}
+}
+
+func TestNest(t *testing.T) {
+	config := map[string]interface{}{}
+	nest(config, "db/address", value([]byte("127.0.0.1")))
+	nest(config, "/db/port/", value([]byte("5432")))
+	nest(config, "debug", value([]byte(`{"level":"info"}`)))
+
+	b, err := json.Marshal(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `{"db":{"address":"127.0.0.1","port":5432},"debug":{"level":"info"}}`; string(b) != expected {
+		t.Fatalf("expected %s got %s", expected, b)
+	}
+}
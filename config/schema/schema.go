@@ -0,0 +1,306 @@
+// Package schema validates the config against a JSON Schema. The keywords
+// supported are type, enum, properties, required, additionalProperties,
+// items, minItems, maxItems, minimum, maximum, minLength, maxLength and pattern.
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Schema of a config value
+type Schema struct {
+	Type       types              `json:"type"`
+	Enum       []interface{}      `json:"enum"`
+	Properties map[string]*Schema `json:"properties"`
+	Required   []string           `json:"required"`
+	// AdditionalProperties is false or the schema of the properties not listed
+	AdditionalProperties *additional `json:"additionalProperties"`
+	Items                *Schema     `json:"items"`
+	MinItems             *int        `json:"minItems"`
+	MaxItems             *int        `json:"maxItems"`
+	Minimum              *float64    `json:"minimum"`
+	Maximum              *float64    `json:"maximum"`
+	MinLength            *int        `json:"minLength"`
+	MaxLength            *int        `json:"maxLength"`
+	Pattern              string      `json:"pattern"`
+
+	pattern *regexp.Regexp
+}
+
+// types of a value, a schema can set a type or a list of them
+type types []string
+
+func (t *types) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*t = types{s}
+		return nil
+	}
+	var l []string
+	if err := json.Unmarshal(b, &l); err != nil {
+		return fmt.Errorf("type must be a string or a list of strings")
+	}
+	*t = l
+	return nil
+}
+
+// additional properties are allowed, disallowed or validated by a schema
+type additional struct {
+	Allowed bool
+	Schema  *Schema
+}
+
+func (a *additional) UnmarshalJSON(b []byte) error {
+	if err := json.Unmarshal(b, &a.Allowed); err == nil {
+		return nil
+	}
+	a.Allowed = true
+	a.Schema = new(Schema)
+	return json.Unmarshal(b, a.Schema)
+}
+
+// FieldError is a violation of the schema by the value at the path
+type FieldError struct {
+	Path    string
+	Message string
+}
+
+func (e *FieldError) Error() string {
+	if len(e.Path) == 0 {
+		return e.Message
+	}
+	return e.Path + ": " + e.Message
+}
+
+// Errors are the violations of the schema
+type Errors []*FieldError
+
+func (e Errors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Parse the JSON Schema
+func Parse(b []byte) (*Schema, error) {
+	s := new(Schema)
+	if err := json.Unmarshal(b, s); err != nil {
+		return nil, fmt.Errorf("invalid schema: %v", err)
+	}
+	if err := s.compile(""); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// compile the patterns of the schema and check its types
+func (s *Schema) compile(path string) error {
+	for _, t := range s.Type {
+		switch t {
+		case "object", "array", "string", "number", "integer", "boolean", "null":
+		default:
+			return fmt.Errorf("invalid schema: %s: unknown type %s", name(path), t)
+		}
+	}
+
+	if len(s.Pattern) > 0 {
+		re, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid schema: %s: invalid pattern: %v", name(path), err)
+		}
+		s.pattern = re
+	}
+
+	for k, p := range s.Properties {
+		if err := p.compile(join(path, k)); err != nil {
+			return err
+		}
+	}
+	if s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil {
+		if err := s.AdditionalProperties.Schema.compile(join(path, "*")); err != nil {
+			return err
+		}
+	}
+	if s.Items != nil {
+		if err := s.Items.compile(join(path, "*")); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Validate the value decoded from json returning the violations of the schema, nil if it's valid
+func (s *Schema) Validate(v interface{}) Errors {
+	var errs Errors
+	s.validate("", v, &errs)
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+func (s *Schema) validate(path string, v interface{}, errs *Errors) {
+	fail := func(format string, args ...interface{}) {
+		*errs = append(*errs, &FieldError{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if len(s.Type) > 0 {
+		t := typeOf(v)
+		ok := false
+		for _, st := range s.Type {
+			if st == t || (st == "number" && t == "integer") {
+				ok = true
+			}
+		}
+		if !ok {
+			fail("expected %s got %s", strings.Join(s.Type, " or "), t)
+			return
+		}
+	}
+
+	if len(s.Enum) > 0 {
+		ok := false
+		for _, e := range s.Enum {
+			if equal(e, v) {
+				ok = true
+			}
+		}
+		if !ok {
+			b, _ := json.Marshal(s.Enum)
+			fail("expected one of %s", b)
+		}
+	}
+
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for _, r := range s.Required {
+			if _, ok := t[r]; !ok {
+				*errs = append(*errs, &FieldError{Path: join(path, r), Message: "required"})
+			}
+		}
+
+		keys := make([]string, 0, len(t))
+		for k := range t {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			if p, ok := s.Properties[k]; ok {
+				p.validate(join(path, k), t[k], errs)
+				continue
+			}
+			if a := s.AdditionalProperties; a != nil {
+				if !a.Allowed {
+					*errs = append(*errs, &FieldError{Path: join(path, k), Message: "not allowed"})
+				} else if a.Schema != nil {
+					a.Schema.validate(join(path, k), t[k], errs)
+				}
+			}
+		}
+	case []interface{}:
+		if s.MinItems != nil && len(t) < *s.MinItems {
+			fail("expected at least %d items got %d", *s.MinItems, len(t))
+		}
+		if s.MaxItems != nil && len(t) > *s.MaxItems {
+			fail("expected at most %d items got %d", *s.MaxItems, len(t))
+		}
+		if s.Items != nil {
+			for i, e := range t {
+				s.Items.validate(join(path, fmt.Sprintf("%d", i)), e, errs)
+			}
+		}
+	case string:
+		n := utf8.RuneCountInString(t)
+		if s.MinLength != nil && n < *s.MinLength {
+			fail("expected at least %d characters got %d", *s.MinLength, n)
+		}
+		if s.MaxLength != nil && n > *s.MaxLength {
+			fail("expected at most %d characters got %d", *s.MaxLength, n)
+		}
+		if s.pattern != nil && !s.pattern.MatchString(t) {
+			fail("expected to match %s", s.Pattern)
+		}
+	default:
+		if f, ok := number(v); ok {
+			if s.Minimum != nil && f < *s.Minimum {
+				fail("expected at least %v got %v", *s.Minimum, f)
+			}
+			if s.Maximum != nil && f > *s.Maximum {
+				fail("expected at most %v got %v", *s.Maximum, f)
+			}
+		}
+	}
+}
+
+// typeOf returns the JSON Schema type of the value
+func typeOf(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	}
+	if f, ok := number(v); ok {
+		if f == math.Trunc(f) {
+			return "integer"
+		}
+		return "number"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+// number returns the value of the number decoded from json
+func number(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case int64:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// equal compares the values as numbers if they're both numbers
+func equal(a, b interface{}) bool {
+	if x, ok := number(a); ok {
+		y, ok := number(b)
+		return ok && x == y
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func join(path, k string) string {
+	if len(path) == 0 {
+		return k
+	}
+	return path + "/" + k
+}
+
+func name(path string) string {
+	if len(path) == 0 {
+		return "root"
+	}
+	return path
+}
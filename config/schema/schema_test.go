@@ -0,0 +1,76 @@
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const testSchema = `{
+	"type": "object",
+	"required": ["host", "port"],
+	"additionalProperties": false,
+	"properties": {
+		"host": {"type": "string", "minLength": 1, "pattern": "^[a-z0-9.-]+$"},
+		"port": {"type": "integer", "minimum": 1, "maximum": 65535},
+		"mode": {"enum": ["primary", "replica"]},
+		"tags": {"type": "array", "maxItems": 2, "items": {"type": "string"}},
+		"ratio": {"type": ["number", "null"]}
+	}
+}`
+
+func TestValidate(t *testing.T) {
+	s, err := Parse([]byte(testSchema))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testData := []struct {
+		config string
+		errors []string
+	}{
+		{`{"host": "prod-db", "port": 5432, "mode": "primary", "tags": ["a"], "ratio": 0.5}`, nil},
+		{`{"host": "prod-db", "port": 5432, "ratio": null}`, nil},
+		{`{"host": "prod-db"}`, []string{"port: required"}},
+		{`{"host": "Prod DB", "port": 70000}`, []string{
+			"host: expected to match ^[a-z0-9.-]+$",
+			"port: expected at most 65535 got 70000",
+		}},
+		{`{"host": "prod-db", "port": 5432.5}`, []string{"port: expected integer got number"}},
+		{`{"host": "prod-db", "port": 5432, "mode": "standby"}`, []string{`mode: expected one of ["primary","replica"]`}},
+		{`{"host": "prod-db", "port": 5432, "tags": ["a", 1, "c"]}`, []string{
+			"tags: expected at most 2 items got 3",
+			"tags/1: expected string got integer",
+		}},
+		{`{"host": "prod-db", "port": 5432, "user": "app"}`, []string{"user: not allowed"}},
+		{`"prod-db"`, []string{"expected object got string"}},
+	}
+
+	for _, d := range testData {
+		var v interface{}
+		if err := json.Unmarshal([]byte(d.config), &v); err != nil {
+			t.Fatal(err)
+		}
+
+		errs := s.Validate(v)
+		if len(errs) != len(d.errors) {
+			t.Fatalf("%s: expected errors %v got %v", d.config, d.errors, errs)
+		}
+		for i, err := range errs {
+			if err.Error() != d.errors[i] {
+				t.Fatalf("%s: expected %s got %s", d.config, d.errors[i], err)
+			}
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	for _, b := range []string{
+		`{"type": "text"}`,
+		`{"properties": {"host": {"pattern": "("}}}`,
+		`{"type": 1}`,
+	} {
+		if _, err := Parse([]byte(b)); err == nil {
+			t.Fatalf("expected %s to be invalid", b)
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/micro/cli/v2"
+	"github.com/micro/go-micro/v2/client"
+	pb "github.com/micro/micro/v2/config/changes/proto"
+	"github.com/micro/micro/v2/config/schema"
+)
+
+// schemaCommands manage the schemas of the config and validate documents against them
+func schemaCommands() []*cli.Command {
+	flags := []cli.Flag{
+		&cli.StringFlag{
+			Name:  "namespace",
+			Usage: "Set the namespace of the config",
+			Value: Namespace,
+		},
+		&cli.StringFlag{
+			Name:    "token",
+			EnvVars: []string{"MICRO_NAMESPACE_TOKEN"},
+			Usage:   "The token of the account making the changes",
+		},
+	}
+
+	return []*cli.Command{
+		{
+			Name:  "schema",
+			Usage: "Manage the JSON Schemas the config is validated against",
+			Subcommands: []*cli.Command{
+				{
+					Name:      "set",
+					Usage:     "Set the schema of a path e.g micro config schema set app.db --file db.schema.json",
+					ArgsUsage: "[path]",
+					Flags: append([]cli.Flag{
+						&cli.StringFlag{
+							Name:     "file",
+							Aliases:  []string{"f"},
+							Usage:    "Set the file of the JSON Schema",
+							Required: true,
+						},
+					}, flags...),
+					Action: setSchema,
+				},
+				{
+					Name:      "get",
+					Usage:     "Get the schemas of the namespace or of a path e.g micro config schema get app.db",
+					ArgsUsage: "[path]",
+					Flags:     flags,
+					Action:    getSchema,
+				},
+				{
+					Name:      "del",
+					Usage:     "Delete the schema of a path e.g micro config schema del app.db",
+					ArgsUsage: "[path]",
+					Flags:     flags,
+					Action:    delSchema,
+				},
+			},
+		},
+		{
+			Name:  "validate",
+			Usage: "Validate a document before importing it e.g micro config validate --file app.yaml --schema app.schema.json",
+			Flags: append([]cli.Flag{
+				&cli.StringFlag{
+					Name:     "file",
+					Aliases:  []string{"f"},
+					Usage:    "Set the file validated, - for stdin",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:  "schema",
+					Usage: "Set the file of the JSON Schema, defaults to the schemas registered for the namespace",
+				},
+				&cli.StringFlag{
+					Name:  "path",
+					Usage: "Set the path of the config the document is for e.g service/foo, defaults to all of it",
+				},
+				&cli.StringFlag{
+					Name:  "format",
+					Usage: "Set the format of the document; json, yaml or toml, defaults to the extension of the file",
+				},
+			}, flags...),
+			Action: validateDocument,
+		},
+	}
+}
+
+func setSchema(c *cli.Context) error {
+	b, err := ioutil.ReadFile(c.String("file"))
+	if err != nil {
+		return err
+	}
+	// fail before calling the service if the schema is invalid
+	if _, err := schema.Parse(b); err != nil {
+		return err
+	}
+
+	path := configPath(c.Args().First())
+
+	schemas := pb.NewSchemasService(Name, client.DefaultClient)
+	if _, err := schemas.Set(changesContext(c), &pb.SetSchemaRequest{
+		Schema: &pb.Schema{Key: c.String("namespace"), Path: path, Schema: b},
+	}); err != nil {
+		return err
+	}
+
+	fmt.Printf("Set the schema of %s in %s\n", schemaName(path), c.String("namespace"))
+	return nil
+}
+
+func getSchema(c *cli.Context) error {
+	schemas := pb.NewSchemasService(Name, client.DefaultClient)
+
+	rsp, err := schemas.Get(changesContext(c), &pb.GetSchemaRequest{
+		Key:  c.String("namespace"),
+		Path: configPath(c.Args().First()),
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(rsp.Schemas) == 0 {
+		fmt.Println("No schemas")
+		return nil
+	}
+
+	for _, s := range rsp.Schemas {
+		fmt.Printf("# %s\n%s\n", schemaName(s.Path), strings.TrimSpace(string(s.Schema)))
+	}
+	return nil
+}
+
+func delSchema(c *cli.Context) error {
+	path := configPath(c.Args().First())
+
+	schemas := pb.NewSchemasService(Name, client.DefaultClient)
+	if _, err := schemas.Delete(changesContext(c), &pb.DeleteSchemaRequest{
+		Key:  c.String("namespace"),
+		Path: path,
+	}); err != nil {
+		return err
+	}
+
+	fmt.Printf("Deleted the schema of %s in %s\n", schemaName(path), c.String("namespace"))
+	return nil
+}
+
+// validateDocument validates the document against the schema file, or the schemas of
+// the namespace at or below the path the document is for which don't need the rest of the config
+func validateDocument(c *cli.Context) error {
+	doc, err := readDocument(c)
+	if err != nil {
+		return err
+	}
+
+	path := configPath(c.String("path"))
+
+	// schemas by the path within the document
+	docSchemas := map[string][]byte{}
+
+	if file := c.String("schema"); len(file) > 0 {
+		b, err := ioutil.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		docSchemas[""] = b
+	} else {
+		schemas := pb.NewSchemasService(Name, client.DefaultClient)
+		rsp, err := schemas.Get(changesContext(c), &pb.GetSchemaRequest{Key: c.String("namespace")})
+		if err != nil {
+			return err
+		}
+		for _, s := range rsp.Schemas {
+			switch {
+			case len(path) == 0:
+				docSchemas[s.Path] = s.Schema
+			case s.Path == path:
+				docSchemas[""] = s.Schema
+			case strings.HasPrefix(s.Path, path+"/"):
+				docSchemas[strings.TrimPrefix(s.Path, path+"/")] = s.Schema
+			}
+		}
+		if len(docSchemas) == 0 {
+			return fmt.Errorf("no schemas of %s in %s", schemaName(path), c.String("namespace"))
+		}
+	}
+
+	paths := make([]string, 0, len(docSchemas))
+	for p := range docSchemas {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	var errs schema.Errors
+	for _, p := range paths {
+		s, err := schema.Parse(docSchemas[p])
+		if err != nil {
+			return fmt.Errorf("schema of %s: %v", schemaName(p), err)
+		}
+
+		v := lookup(doc, p)
+		// the schema of a path which isn't in the document
+		if v == nil && len(p) > 0 {
+			continue
+		}
+
+		for _, e := range s.Validate(v) {
+			e.Path = strings.Trim(p+"/"+e.Path, "/")
+			errs = append(errs, e)
+		}
+	}
+
+	if len(errs) == 0 {
+		fmt.Printf("%s is valid\n", c.String("file"))
+		return nil
+	}
+
+	for _, e := range errs {
+		fmt.Println(e)
+	}
+	return fmt.Errorf("%s has %d errors", c.String("file"), len(errs))
+}
+
+// schemaName returns the path of the schema, the root if it's not set
+func schemaName(path string) string {
+	if len(path) == 0 {
+		return "the root"
+	}
+	return path
+}
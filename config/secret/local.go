@@ -0,0 +1,86 @@
+package secret
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/micro/micro/v2/store/encrypt"
+)
+
+// local encrypts the secrets with AES-GCM keys, the first encrypts
+// and the rest decrypt the secrets encrypted before rotating the key
+type local struct {
+	current string
+	aeads   map[string]cipher.AEAD
+}
+
+func newLocal(v string) (*local, error) {
+	keys, err := encrypt.ParseKeys(v)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &local{
+		current: keys[0].ID,
+		aeads:   make(map[string]cipher.AEAD, len(keys)),
+	}
+
+	for _, k := range keys {
+		block, err := aes.NewCipher(k.Secret)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key %s: %v", k.ID, err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key %s: %v", k.ID, err)
+		}
+		l.aeads[k.ID] = aead
+	}
+
+	return l, nil
+}
+
+// Encrypt the secret as local:<key id>:<base64 nonce and sealed secret>
+func (l *local) Encrypt(plaintext []byte) (string, error) {
+	aead := l.aeads[l.current]
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+
+	b := aead.Seal(nonce, nonce, plaintext, nil)
+	return "local:" + l.current + ":" + base64.StdEncoding.EncodeToString(b), nil
+}
+
+func (l *local) Decrypt(ciphertext string) ([]byte, error) {
+	parts := strings.SplitN(ciphertext, ":", 3)
+	if len(parts) != 3 || parts[0] != "local" {
+		return nil, fmt.Errorf("secret not encrypted with a local key")
+	}
+
+	aead, ok := l.aeads[parts[1]]
+	if !ok {
+		return nil, fmt.Errorf("secret encrypted with an unknown key %s", parts[1])
+	}
+
+	b, err := base64.StdEncoding.DecodeString(parts[2])
+	if err != nil || len(b) < aead.NonceSize() {
+		return nil, fmt.Errorf("invalid secret")
+	}
+
+	v, err := aead.Open(nil, b[:aead.NonceSize()], b[aead.NonceSize():], nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt the secret: %v", err)
+	}
+	return v, nil
+}
+
+func (l *local) String() string {
+	return "local"
+}
@@ -0,0 +1,151 @@
+// Package secret encrypts the secret values of the config before they're
+// written to the db and decrypts them for the accounts allowed to read them.
+// Secrets are written as {"$secret": "value"} and stored as {"$encrypted": "ciphertext"}.
+package secret
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// SecretField is the field of the objects written as secrets
+	SecretField = "$secret"
+	// EncryptedField is the field of the objects the secrets are stored as
+	EncryptedField = "$encrypted"
+)
+
+var (
+	// Mask replaces the secrets which aren't revealed
+	Mask = "******"
+
+	// ErrNoProvider is returned writing a secret when no provider is configured
+	ErrNoProvider = errors.New("no provider to encrypt the secrets with")
+)
+
+// Provider encrypts and decrypts the secrets
+type Provider interface {
+	Encrypt(plaintext []byte) (string, error)
+	Decrypt(ciphertext string) ([]byte, error)
+	String() string
+}
+
+// NewProvider returns the provider for the url; local keys as a comma
+// separated list e.g v2:base64,v1:base64 or a file of them e.g
+// file:///etc/micro/config-keys mounted from a KMS backed secret, or the
+// transit engine of vault e.g vault:https://vault:8200/transit/config
+func NewProvider(url string) (Provider, error) {
+	if strings.HasPrefix(url, "vault:") {
+		return newVault(strings.TrimPrefix(url, "vault:"))
+	}
+	return newLocal(url)
+}
+
+// Seal encrypts the secrets of the config data, data without secrets is returned as is
+func Seal(p Provider, data []byte) ([]byte, error) {
+	return transform(data, func(field string, v interface{}) (interface{}, error) {
+		if field != SecretField {
+			return map[string]interface{}{field: v}, nil
+		}
+		if p == nil {
+			return nil, ErrNoProvider
+		}
+
+		// secrets which aren't strings are stored as json
+		var b []byte
+		if s, ok := v.(string); ok {
+			b = []byte(s)
+		} else {
+			var err error
+			if b, err = json.Marshal(v); err != nil {
+				return nil, err
+			}
+		}
+
+		c, err := p.Encrypt(b)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{EncryptedField: c}, nil
+	})
+}
+
+// Open decrypts the secrets of the config data if reveal is set,
+// otherwise they're masked. Decrypted secrets are returned as strings.
+func Open(p Provider, data []byte, reveal bool) ([]byte, error) {
+	return transform(data, func(field string, v interface{}) (interface{}, error) {
+		c, ok := v.(string)
+		if field != EncryptedField || !ok {
+			return map[string]interface{}{field: v}, nil
+		}
+		if !reveal {
+			return Mask, nil
+		}
+		if p == nil {
+			return nil, ErrNoProvider
+		}
+		b, err := p.Decrypt(c)
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	})
+}
+
+// transform the secrets of the config data, objects with a single secret or
+// encrypted field are replaced with the value returned by fn
+func transform(data []byte, fn func(field string, v interface{}) (interface{}, error)) ([]byte, error) {
+	// most config has no secrets so it's returned unchanged
+	if !bytes.Contains(data, []byte(SecretField)) && !bytes.Contains(data, []byte(EncryptedField)) {
+		return data, nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		// not json so there are no secrets
+		return data, nil
+	}
+
+	var walk func(v interface{}) (interface{}, error)
+	walk = func(v interface{}) (interface{}, error) {
+		switch t := v.(type) {
+		case map[string]interface{}:
+			if len(t) == 1 {
+				for field, val := range t {
+					if field == SecretField || field == EncryptedField {
+						return fn(field, val)
+					}
+				}
+			}
+			for k, e := range t {
+				val, err := walk(e)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %v", k, err)
+				}
+				t[k] = val
+			}
+		case []interface{}:
+			for i, e := range t {
+				val, err := walk(e)
+				if err != nil {
+					return nil, err
+				}
+				t[i] = val
+			}
+		}
+		return v, nil
+	}
+
+	v, err := walk(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(v)
+}
@@ -0,0 +1,113 @@
+package secret
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSealOpen(t *testing.T) {
+	p, err := NewProvider("v2:" + base64.StdEncoding.EncodeToString(bytes.Repeat([]byte("k"), 32)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := []byte(`{"db": {"host": "prod-db", "port": 5432, "password": {"$secret": "hunter2"}}}`)
+
+	sealed, err := Seal(p, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(sealed, []byte("hunter2")) || !bytes.Contains(sealed, []byte(EncryptedField)) {
+		t.Fatalf("Expected the secret to be encrypted got %s", sealed)
+	}
+
+	// sealing again leaves the encrypted secrets as they are
+	if resealed, err := Seal(p, sealed); err != nil || !bytes.Equal(resealed, sealed) {
+		t.Fatalf("Expected the sealed data unchanged got %s %v", resealed, err)
+	}
+
+	opened, err := Open(p, sealed, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := `{"db":{"host":"prod-db","password":"hunter2","port":5432}}`
+	if string(opened) != expect {
+		t.Fatalf("Expected %s got %s", expect, opened)
+	}
+
+	masked, err := Open(p, sealed, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(masked), `"password":"`+Mask+`"`) {
+		t.Fatalf("Expected the secret to be masked got %s", masked)
+	}
+
+	// data without secrets is returned as is
+	plain := []byte(`{"a": 1}`)
+	if b, _ := Seal(nil, plain); !bytes.Equal(b, plain) {
+		t.Fatalf("Expected %s got %s", plain, b)
+	}
+
+	if _, err := Seal(nil, data); err == nil || err.Error() != "db: password: "+ErrNoProvider.Error() {
+		t.Fatalf("Expected ErrNoProvider for db: password got %v", err)
+	}
+}
+
+func TestVault(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"errors": ["permission denied"]}`))
+			return
+		}
+
+		var req map[string]string
+		json.NewDecoder(r.Body).Decode(&req)
+
+		// the fake ciphertext is the plaintext reversed
+		switch r.URL.Path {
+		case "/v1/transit/encrypt/config":
+			json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]string{"ciphertext": "vault:v1:" + reverse(req["plaintext"])}})
+		case "/v1/transit/decrypt/config":
+			json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]string{"plaintext": reverse(strings.TrimPrefix(req["ciphertext"], "vault:v1:"))}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors": []}`))
+		}
+	}))
+	defer srv.Close()
+
+	p, err := NewProvider("vault:" + srv.URL + "/transit/config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.(*vault).token = "token"
+
+	c, err := p.Encrypt([]byte("hunter2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := p.Decrypt(c)
+	if err != nil || string(b) != "hunter2" {
+		t.Fatalf("Expected hunter2 got %s %v", b, err)
+	}
+
+	p.(*vault).token = "wrong"
+	if _, err := p.Encrypt([]byte("hunter2")); err == nil || !strings.Contains(err.Error(), "permission denied") {
+		t.Fatalf("Expected permission denied got %v", err)
+	}
+}
+
+func reverse(s string) string {
+	r := []rune(s)
+	for i, j := 0, len(r)-1; i < j; i, j = i+1, j-1 {
+		r[i], r[j] = r[j], r[i]
+	}
+	return string(r)
+}
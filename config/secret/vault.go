@@ -0,0 +1,108 @@
+package secret
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+var (
+	// Timeout of the requests to vault
+	Timeout = 10 * time.Second
+)
+
+// vault encrypts the secrets with a key of the transit secrets engine,
+// the token is read from VAULT_TOKEN
+type vault struct {
+	address string
+	mount   string
+	key     string
+	token   string
+	client  *http.Client
+}
+
+// newVault returns the provider for the url of the key e.g https://vault:8200/transit/config
+func newVault(v string) (*vault, error) {
+	u, err := url.Parse(v)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(u.Host) == 0 || len(parts) != 2 {
+		return nil, fmt.Errorf("invalid vault url %s, expected https://host:port/mount/key", v)
+	}
+
+	return &vault{
+		address: u.Scheme + "://" + u.Host,
+		mount:   parts[0],
+		key:     parts[1],
+		token:   os.Getenv("VAULT_TOKEN"),
+		client:  &http.Client{Timeout: Timeout},
+	}, nil
+}
+
+// call the transit endpoint e.g encrypt returning the data of the response
+func (v *vault) call(op string, req, data interface{}) error {
+	b, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	r, err := http.NewRequest("POST", fmt.Sprintf("%s/v1/%s/%s/%s", v.address, v.mount, op, v.key), bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	r.Header.Set("X-Vault-Token", v.token)
+	r.Header.Set("Content-Type", "application/json")
+
+	rsp, err := v.client.Do(r)
+	if err != nil {
+		return err
+	}
+	defer rsp.Body.Close()
+
+	var body struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []string        `json:"errors"`
+	}
+	if err := json.NewDecoder(rsp.Body).Decode(&body); err != nil {
+		return fmt.Errorf("vault %s: %s", op, rsp.Status)
+	}
+	if rsp.StatusCode != http.StatusOK {
+		return fmt.Errorf("vault %s: %s %s", op, rsp.Status, strings.Join(body.Errors, ", "))
+	}
+
+	return json.Unmarshal(body.Data, data)
+}
+
+func (v *vault) Encrypt(plaintext []byte) (string, error) {
+	var data struct {
+		Ciphertext string `json:"ciphertext"`
+	}
+	req := map[string]string{"plaintext": base64.StdEncoding.EncodeToString(plaintext)}
+	if err := v.call("encrypt", req, &data); err != nil {
+		return "", err
+	}
+	return data.Ciphertext, nil
+}
+
+func (v *vault) Decrypt(ciphertext string) ([]byte, error) {
+	var data struct {
+		Plaintext string `json:"plaintext"`
+	}
+	if err := v.call("decrypt", map[string]string{"ciphertext": ciphertext}, &data); err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(data.Plaintext)
+}
+
+func (v *vault) String() string {
+	return "vault"
+}
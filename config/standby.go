@@ -0,0 +1,44 @@
+package config
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/micro/go-micro/v2/client"
+	mp "github.com/micro/go-micro/v2/config/source/service/proto"
+	"github.com/micro/go-micro/v2/metadata"
+	"github.com/micro/go-micro/v2/store"
+	"github.com/micro/micro/v2/config/db"
+	"github.com/micro/micro/v2/config/handler"
+)
+
+// syncFrom copies the config from the active instance at the address
+func syncFrom(address string) error {
+	// the secrets are copied encrypted rather than masked
+	ctx := metadata.NewContext(context.Background(), map[string]string{
+		handler.EncryptedHeader: "true",
+	})
+
+	rsp, err := mp.NewConfigService(Name, client.DefaultClient).List(ctx, &mp.ListRequest{}, client.WithAddress(address))
+	if err != nil {
+		return err
+	}
+
+	for _, ch := range rsp.Values {
+		b, err := proto.Marshal(ch)
+		if err != nil {
+			return err
+		}
+
+		record := &store.Record{Key: ch.Key, Value: b}
+		write := db.Create
+		if _, err := db.Read(ch.Key); err == nil {
+			write = db.Update
+		}
+		if err := write(record); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
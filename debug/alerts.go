@@ -0,0 +1,76 @@
+package debug
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/micro/cli/v2"
+	"github.com/micro/go-micro/v2"
+	"github.com/micro/go-micro/v2/client"
+	pbstats "github.com/micro/micro/v2/debug/stats/proto"
+	"github.com/micro/micro/v2/internal/namespace"
+)
+
+// alertsFlags are the flags of the alerts command
+func alertsFlags() []cli.Flag {
+	return append(statusFlags(),
+		&cli.BoolFlag{
+			Name:  "pending",
+			Usage: "Include the alerts pending for the duration of their rule",
+		},
+		&cli.BoolFlag{
+			Name:  "rules",
+			Usage: "List the alert rules rather than the alerts",
+		},
+	)
+}
+
+// writeAlerts writes a table of the alerts
+func writeAlerts(w io.Writer, alerts []*pbstats.Alert, now time.Time) {
+	writer := tabwriter.NewWriter(w, 0, 8, 1, '\t', 0)
+	fmt.Fprintln(writer, "SERVICE\tRULE\tSTATE\tVALUE\tSINCE")
+	for _, al := range alerts {
+		since := al.Started
+		if al.Fired > 0 {
+			since = al.Fired
+		}
+
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%g\t%v\n",
+			al.Service,
+			al.Rule,
+			al.State,
+			al.Value,
+			now.Sub(time.Unix(since, 0)).Truncate(time.Second),
+		)
+	}
+	writer.Flush()
+}
+
+// getAlerts prints the alerts of the services in the namespace
+func getAlerts(ctx *cli.Context, srvOpts ...micro.Option) {
+	c := pbstats.NewStatsService(Name, client.DefaultClient)
+
+	req := &pbstats.AlertsRequest{Pending: ctx.Bool("pending")}
+	if ctx.Args().Len() > 0 {
+		req.Service = &pbstats.Service{Name: ctx.Args().First()}
+	}
+
+	rsp, err := c.Alerts(namespace.NewContext(context.Background(), ctx.String("token")), req)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	if ctx.Bool("rules") {
+		for _, r := range rsp.Rules {
+			fmt.Println(r)
+		}
+		return
+	}
+
+	writeAlerts(os.Stdout, rsp.Alerts, time.Now())
+}
@@ -8,11 +8,17 @@ import (
 	"github.com/micro/go-micro/v2/debug/log/kubernetes"
 	dservice "github.com/micro/go-micro/v2/debug/service"
 	ulog "github.com/micro/go-micro/v2/util/log"
+	debuglog "github.com/micro/micro/v2/debug/log"
 	logHandler "github.com/micro/micro/v2/debug/log/handler"
 	pblog "github.com/micro/micro/v2/debug/log/proto"
 	"github.com/micro/micro/v2/debug/stats"
 	statshandler "github.com/micro/micro/v2/debug/stats/handler"
 	pbstats "github.com/micro/micro/v2/debug/stats/proto"
+	"github.com/micro/micro/v2/debug/stats/sink"
+	_ "github.com/micro/micro/v2/debug/stats/sink/clickhouse"
+	_ "github.com/micro/micro/v2/debug/stats/sink/influxdb"
+	_ "github.com/micro/micro/v2/debug/stats/sink/s3"
+	debugtrace "github.com/micro/micro/v2/debug/trace"
 	"github.com/micro/micro/v2/debug/web"
 )
 
@@ -82,12 +88,33 @@ func Run(ctx *cli.Context, srvOpts ...micro.Option) {
 		close(done)
 	}()
 
+	// long term storage for stats snapshots
+	var sinks []sink.Sink
+	for _, addr := range ctx.StringSlice("sink") {
+		sk, err := sink.New(addr)
+		if err != nil {
+			ulog.Fatal(err)
+		}
+		sinks = append(sinks, sk)
+	}
+
 	// stats handler
-	statsHandler, err := statshandler.New(done, ctx.Int("window"))
+	stats.Configure(ctx)
+	statsHandler, err := statshandler.New(done, stats.Window(ctx), sinks...)
 	if err != nil {
 		ulog.Fatal(err)
 	}
 
+	// expose the stats for prometheus to scrape
+	if addr := ctx.String("metrics_address"); len(addr) > 0 {
+		stats.ServeMetrics(addr, statsHandler, done)
+	}
+
+	// evaluate the alert rules against the stats
+	if err := stats.StartAlerts(ctx, statsHandler, done); err != nil {
+		ulog.Fatal(err)
+	}
+
 	// log handler
 	lgHandler := &logHandler.Log{
 		// create the log map
@@ -99,8 +126,12 @@ func Run(ctx *cli.Context, srvOpts ...micro.Option) {
 	// Register the stats handler
 	pbstats.RegisterStatsHandler(service.Server(), statsHandler)
 
-	// Register the logs handler
-	pblog.RegisterLogHandler(service.Server(), lgHandler)
+	// Register the logs handler, the log files are read from the runtime nodes writing them
+	if source == "file" {
+		pblog.RegisterLogHandler(service.Server(), logHandler.NewRuntime())
+	} else {
+		pblog.RegisterLogHandler(service.Server(), lgHandler)
+	}
 
 	// TODO: implement debug service for k8s cruft
 
@@ -116,7 +147,7 @@ func Commands(options ...micro.Option) []*cli.Command {
 		{
 			Name:  "debug",
 			Usage: "Run the micro debug service",
-			Flags: []cli.Flag{
+			Flags: append([]cli.Flag{
 				&cli.StringFlag{
 					Name:    "address",
 					Usage:   "Set the registry http address e.g 0.0.0.0:8089",
@@ -124,17 +155,16 @@ func Commands(options ...micro.Option) []*cli.Command {
 				},
 				&cli.StringFlag{
 					Name:    "log",
-					Usage:   "Specify the log source to use e.g service, kubernetes",
+					Usage:   "Specify the log source to use e.g service, kubernetes, file",
 					EnvVars: []string{"MICRO_DEBUG_LOG"},
 					Value:   "service",
 				},
-				&cli.IntFlag{
-					Name:    "window",
-					Usage:   "Specifies how many seconds of stats snapshots to retain in memory",
-					EnvVars: []string{"MICRO_DEBUG_WINDOW"},
-					Value:   0,
+				&cli.StringSliceFlag{
+					Name:    "sink",
+					Usage:   "Write stats snapshots to long term storage e.g influxdb://localhost:8086/micro, clickhouse://localhost:8123/micro_stats, s3://bucket/prefix",
+					EnvVars: []string{"MICRO_DEBUG_SINK"},
 				},
-			},
+			}, stats.Flags()...),
 			Action: func(ctx *cli.Context) error {
 				Run(ctx, options...)
 				return nil
@@ -156,9 +186,28 @@ func Commands(options ...micro.Option) []*cli.Command {
 						return nil
 					},
 				},
+				&cli.Command{
+					Name:  "log",
+					Usage: "Start the debug log aggregator",
+					Flags: debuglog.Flags(),
+					Action: func(c *cli.Context) error {
+						debuglog.Run(c)
+						return nil
+					},
+				},
+				&cli.Command{
+					Name:  "trace",
+					Usage: "Start the debug trace collector",
+					Flags: debugtrace.Flags(),
+					Action: func(c *cli.Context) error {
+						debugtrace.Run(c)
+						return nil
+					},
+				},
 				&cli.Command{
 					Name:  "stats",
 					Usage: "Start the debug stats scraper",
+					Flags: stats.Flags(),
 					Action: func(c *cli.Context) error {
 						stats.Run(c)
 						return nil
@@ -175,9 +224,46 @@ func Commands(options ...micro.Option) []*cli.Command {
 				return nil
 			},
 		},
+		{
+			Name:  "logs",
+			Usage: "Get the logs of services from the debug log service e.g micro logs --level error",
+			Flags: logsFlags(),
+			Action: func(ctx *cli.Context) error {
+				getLogs(ctx, options...)
+				return nil
+			},
+		},
+		{
+			Name:  "status",
+			Usage: "Get the status of services e.g scoped to a namespace with --token",
+			Flags: statusFlags(),
+			Action: func(ctx *cli.Context) error {
+				getStatus(ctx, options...)
+				return nil
+			},
+		},
+		{
+			Name:  "top",
+			Usage: "Interactively display the stats of services sorted by memory, req/s or error rate",
+			Flags: TopFlags(),
+			Action: func(ctx *cli.Context) error {
+				getTop(ctx, options...)
+				return nil
+			},
+		},
+		{
+			Name:  "alerts",
+			Usage: "List the alerts firing for services e.g micro alerts go.micro.srv.foo",
+			Flags: alertsFlags(),
+			Action: func(ctx *cli.Context) error {
+				getAlerts(ctx, options...)
+				return nil
+			},
+		},
 		{
 			Name:  "trace",
-			Usage: "Get tracing info from a service",
+			Usage: "Get tracing info from a service, or follow a trace across services with --id",
+			Flags: traceFlags(),
 			Action: func(ctx *cli.Context) error {
 				getTrace(ctx, options...)
 				return nil
@@ -1,14 +1,19 @@
 package debug
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"time"
 
 	"github.com/micro/cli/v2"
 	"github.com/micro/go-micro/v2"
+	"github.com/micro/go-micro/v2/client"
 	"github.com/micro/go-micro/v2/debug/service"
 	ulog "github.com/micro/go-micro/v2/util/log"
+	logpb "github.com/micro/micro/v2/debug/log/proto"
+	"github.com/micro/micro/v2/internal/namespace"
+	"github.com/micro/micro/v2/internal/redact"
 )
 
 const (
@@ -24,6 +29,10 @@ func getLog(ctx *cli.Context, srvOpts ...micro.Option) {
 	count := ctx.Int("count")
 	stream := ctx.Bool("stream")
 
+	if ctx.Bool("show-secrets") {
+		redact.Show = true
+	}
+
 	if ctx.Args().Len() == 0 {
 		fmt.Println("Require service name")
 		return
@@ -37,6 +46,12 @@ func getLog(ctx *cli.Context, srvOpts ...micro.Option) {
 		return
 	}
 
+	// namespace scoped logs are read via the debug service
+	if tk := ctx.String("token"); len(tk) > 0 {
+		getScopedLog(ctx, name, tk)
+		return
+	}
+
 	// initialise a new service log
 	// TODO: allow "--source" e.g. kubernetes
 	service := service.NewClient(name)
@@ -58,9 +73,33 @@ func getLog(ctx *cli.Context, srvOpts ...micro.Option) {
 		switch output {
 		case "json":
 			b, _ := json.Marshal(record)
-			fmt.Printf("%v\n", string(b))
+			fmt.Printf("%v\n", string(redact.Bytes(b)))
+		default:
+			fmt.Printf("%v\n", redact.String(fmt.Sprintf("%v", record.Message)))
+		}
+	}
+}
+
+// getScopedLog reads the logs via the debug service which enforces the namespace
+func getScopedLog(ctx *cli.Context, name, tk string) {
+	c := logpb.NewLogService(Name, client.DefaultClient)
+
+	rsp, err := c.Read(namespace.NewContext(context.Background(), tk), &logpb.ReadRequest{
+		Service: name,
+	})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	output := ctx.String("output")
+	for _, record := range rsp.Records {
+		switch output {
+		case "json":
+			b, _ := json.Marshal(record)
+			fmt.Printf("%v\n", string(redact.Bytes(b)))
 		default:
-			fmt.Printf("%v\n", record.Message)
+			fmt.Printf("%v\n", redact.String(fmt.Sprintf("%v", record.Message)))
 		}
 	}
 }
@@ -89,5 +128,14 @@ func logFlags() []cli.Flag {
 			Name:  "count",
 			Usage: "Set to query the last number of log events",
 		},
+		&cli.StringFlag{
+			Name:    "token",
+			Usage:   "Set the namespace token used to read logs for services in the namespace",
+			EnvVars: []string{"MICRO_NAMESPACE_TOKEN"},
+		},
+		&cli.BoolFlag{
+			Name:  "show-secrets",
+			Usage: "Show the values of secret keys in the logs rather than masking them",
+		},
 	}
 }
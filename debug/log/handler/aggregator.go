@@ -0,0 +1,318 @@
+package handler
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/micro/go-micro/v2/client"
+	"github.com/micro/go-micro/v2/config/cmd"
+	debug "github.com/micro/go-micro/v2/debug/service/proto"
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/registry"
+	"github.com/micro/go-micro/v2/registry/cache"
+	ulog "github.com/micro/go-micro/v2/util/log"
+	"github.com/micro/go-micro/v2/util/ring"
+	pb "github.com/micro/micro/v2/debug/log/proto"
+	"github.com/micro/micro/v2/internal/namespace"
+)
+
+var (
+	// TailBacklog is the number of the last records read from a node when it's first tailed
+	TailBacklog = 100
+	// TailRetry is how long after the log of a node stops streaming it's tailed again
+	TailRetry = time.Second * 5
+	// ScanInterval is how often the registry is scanned for the nodes to tail
+	ScanInterval = time.Second * 10
+	// StreamBuffer is the number of records buffered for each stream, the oldest
+	// are dropped for streams which can't keep up rather than blocking tailing
+	StreamBuffer = 256
+)
+
+// NewAggregator returns an aggregator tailing the logs of the services in the
+// registry which keeps the last size records, it tails until done is closed
+func NewAggregator(done <-chan bool, size int) *Aggregator {
+	a := &Aggregator{
+		registry: cache.New(*cmd.DefaultOptions().Registry),
+		client:   *cmd.DefaultOptions().Client,
+		records:  ring.New(size),
+		tails:    make(map[string]chan bool),
+		streams:  make(map[string]chan *pb.Record),
+	}
+
+	go a.run(done)
+	return a
+}
+
+// Aggregator is the Log handler of the logs of every service
+type Aggregator struct {
+	registry registry.Registry
+	client   client.Client
+
+	// the recent records of every node
+	records *ring.Buffer
+
+	// tails of the nodes keyed by id, closing the channel stops the tail
+	tailMtx sync.Mutex
+	tails   map[string]chan bool
+
+	// active streams keyed by id
+	streamMtx sync.RWMutex
+	streams   map[string]chan *pb.Record
+}
+
+// run scans the registry for the nodes to tail until the done channel is closed
+func (a *Aggregator) run(done <-chan bool) {
+	t := time.NewTicker(ScanInterval)
+	defer t.Stop()
+
+	for {
+		if err := a.scan(); err != nil {
+			ulog.Debug(err)
+		}
+
+		select {
+		case <-done:
+			a.tailMtx.Lock()
+			for id, stop := range a.tails {
+				close(stop)
+				delete(a.tails, id)
+			}
+			a.tailMtx.Unlock()
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// scan the registry tailing the new nodes and stopping the tails of those which left
+func (a *Aggregator) scan() error {
+	services, err := a.registry.ListServices()
+	if err != nil {
+		return err
+	}
+
+	var list []*registry.Service
+	for _, service := range services {
+		if len(service.Nodes) > 0 {
+			list = append(list, service)
+			continue
+		}
+		// get the nodes of the service
+		versions, err := a.registry.GetService(service.Name)
+		if err != nil {
+			continue
+		}
+		list = append(list, versions...)
+	}
+
+	protocol := a.client.String()
+	live := make(map[string]bool)
+
+	a.tailMtx.Lock()
+	defer a.tailMtx.Unlock()
+
+	for _, service := range list {
+		for _, node := range service.Nodes {
+			// only mucp services can be tailed
+			if node.Metadata["protocol"] != protocol {
+				continue
+			}
+			live[node.Id] = true
+
+			if _, ok := a.tails[node.Id]; ok {
+				continue
+			}
+			stop := make(chan bool)
+			a.tails[node.Id] = stop
+			go a.tail(service, node, stop)
+		}
+	}
+
+	for id, stop := range a.tails {
+		if !live[id] {
+			close(stop)
+			delete(a.tails, id)
+		}
+	}
+
+	return nil
+}
+
+// tail the log of the node until the stop channel is closed, it's streamed
+// again after the retry from the last record if the stream fails
+func (a *Aggregator) tail(service *registry.Service, node *registry.Node, stop chan bool) {
+	req := &debug.LogRequest{Service: service.Name, Count: int64(TailBacklog), Stream: true}
+
+	for {
+		if last := a.tailOnce(service, node, req, stop); last > 0 {
+			req.Count = 0
+			req.Since = last + 1
+		}
+
+		select {
+		case <-stop:
+			return
+		case <-time.After(TailRetry):
+		}
+	}
+}
+
+// tailOnce streams the log of the node until it fails or is stopped,
+// the timestamp of the last record streamed is returned
+func (a *Aggregator) tailOnce(service *registry.Service, node *registry.Node, req *debug.LogRequest, stop chan bool) int64 {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		select {
+		case <-stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	stream, err := debug.NewDebugService(service.Name, a.client).Log(ctx, req, client.WithAddress(node.Address))
+	if err != nil {
+		ulog.Debugf("Error tailing the log of %s@%s: %v", service.Name, node.Address, err)
+		return 0
+	}
+	defer stream.Close()
+
+	var last int64
+	for {
+		rec, err := stream.Recv()
+		if err != nil {
+			return last
+		}
+		last = rec.Timestamp
+
+		a.write(&pb.Record{
+			Timestamp: rec.Timestamp,
+			Metadata:  rec.Metadata,
+			Message:   rec.Message,
+			Service:   service.Name,
+			Version:   service.Version,
+			Node:      node.Id,
+			Level:     Level(rec.Metadata, rec.Message),
+		})
+	}
+}
+
+// write the record to the recent records and the streams
+func (a *Aggregator) write(rec *pb.Record) {
+	a.records.Put(rec)
+
+	a.streamMtx.RLock()
+	defer a.streamMtx.RUnlock()
+
+	for _, next := range a.streams {
+		select {
+		case next <- rec:
+			continue
+		default:
+		}
+
+		// make room by dropping the oldest record
+		select {
+		case <-next:
+		default:
+		}
+		select {
+		case next <- rec:
+		default:
+		}
+	}
+}
+
+// validate the request returning the namespace of the caller
+func (a *Aggregator) validate(ctx context.Context, req *pb.ReadRequest) (string, error) {
+	if _, ok := levels[strings.ToLower(req.Level)]; len(req.Level) > 0 && !ok {
+		return "", errors.BadRequest("go.micro.debug.log", "Invalid level %s", req.Level)
+	}
+
+	// scope the logs to the namespace of the caller
+	ns, err := namespace.FromContext(ctx)
+	if err != nil {
+		return "", errors.Forbidden("go.micro.debug.log", err.Error())
+	}
+	if len(req.Service) > 0 && !namespace.Allowed(ns, req.Service) {
+		return "", errors.Forbidden("go.micro.debug.log", "service %s is not in namespace %s", req.Service, ns)
+	}
+	return ns, nil
+}
+
+// recent returns the recent records which match the request
+func (a *Aggregator) recent(ns string, req *pb.ReadRequest) []*pb.Record {
+	entries := a.records.Get(a.records.Size())
+	if req.Since > 0 {
+		entries = a.records.Since(time.Unix(req.Since, 0))
+	}
+
+	var records []*pb.Record
+	for _, entry := range entries {
+		rec := entry.Value.(*pb.Record)
+		if namespace.Allowed(ns, rec.Service) && match(req, rec) {
+			records = append(records, rec)
+		}
+	}
+	return last(records, req.Count)
+}
+
+// Read the recent records of the services, every service in the namespace if it's not set
+func (a *Aggregator) Read(ctx context.Context, req *pb.ReadRequest, rsp *pb.ReadResponse) error {
+	ns, err := a.validate(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	rsp.Records = a.recent(ns, req)
+	return nil
+}
+
+// Stream the records of the services as they're logged, starting with
+// the recent records if the request sets the time or count to read
+func (a *Aggregator) Stream(ctx context.Context, req *pb.ReadRequest, stream pb.Log_StreamStream) error {
+	ns, err := a.validate(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	id := uuid.New().String()
+	next := make(chan *pb.Record, StreamBuffer)
+
+	a.streamMtx.Lock()
+	a.streams[id] = next
+	a.streamMtx.Unlock()
+
+	defer func() {
+		a.streamMtx.Lock()
+		delete(a.streams, id)
+		a.streamMtx.Unlock()
+		stream.Close()
+	}()
+
+	if req.Since > 0 || req.Count > 0 {
+		for _, rec := range a.recent(ns, req) {
+			if err := stream.Send(rec); err != nil {
+				return err
+			}
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case rec := <-next:
+			if !namespace.Allowed(ns, rec.Service) || !match(req, rec) {
+				continue
+			}
+			if err := stream.Send(rec); err != nil {
+				return err
+			}
+		}
+	}
+}
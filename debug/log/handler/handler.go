@@ -2,11 +2,16 @@ package handler
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
 	"sync"
 
 	"github.com/micro/go-micro/v2/debug/log"
 	"github.com/micro/go-micro/v2/errors"
 	pb "github.com/micro/micro/v2/debug/log/proto"
+	"github.com/micro/micro/v2/internal/namespace"
+	"github.com/micro/micro/v2/runtime/logfile"
 )
 
 type Log struct {
@@ -16,23 +21,143 @@ type Log struct {
 
 	// Ability to create new logger
 	New func(string) log.Log
+
+	// Files returns the log files of the service version if read from files
+	Files func(service, version string) ([]string, error)
 }
 
-func (l *Log) Read(ctx context.Context, req *pb.ReadRequest, rsp *pb.ReadResponse) error {
-	if len(req.Service) == 0 {
-		return errors.BadRequest("go.micro.debug.log", "Invalid service name")
+var (
+	// MaxLines is the number of lines read from the log files if the request has no count
+	MaxLines = 1000
+)
+
+// fileRecord converts the line of the log file
+func fileRecord(req *pb.ReadRequest, path string, line *logfile.Line) *pb.Record {
+	rec := &pb.Record{
+		Metadata: map[string]string{"file": path},
+		Message:  line.Text,
+		Service:  req.Service,
+		Version:  req.Version,
+		Level:    Level(nil, line.Text),
+	}
+	if !line.Time.IsZero() {
+		rec.Timestamp = line.Time.Unix()
 	}
+	return rec
+}
 
-	l.Lock()
-	defer l.Unlock()
+// readFiles reads the last records of the log files of the service which match the request
+func (l *Log) readFiles(req *pb.ReadRequest) ([]*pb.Record, error) {
+	paths, err := l.Files(req.Service, req.Version)
+	if err != nil {
+		return nil, errors.InternalServerError("go.micro.debug.log", err.Error())
+	}
+	if len(paths) == 0 {
+		return nil, errors.NotFound("go.micro.debug.log", "no log files for %s", req.Service)
+	}
 
-	// get the service log
-	serviceLog, ok := l.Logs[req.Service]
-	if !ok {
-		serviceLog = l.New(req.Service)
-		l.Logs[req.Service] = serviceLog
+	count := MaxLines
+	if req.Count > 0 {
+		count = int(req.Count)
+	}
+
+	var records []*pb.Record
+	for _, path := range paths {
+		lines, err := logfile.Read(path, count, func(line *logfile.Line) bool {
+			return match(req, fileRecord(req, path, line))
+		})
+		if err != nil {
+			return nil, errors.InternalServerError("go.micro.debug.log", err.Error())
+		}
+		for _, line := range lines {
+			records = append(records, fileRecord(req, path, line))
+		}
+	}
+
+	// the files of each version are merged by time
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Timestamp < records[j].Timestamp
+	})
+
+	return last(records, int64(count)), nil
+}
+
+// streamFiles tails the log files of the service, starting with the last
+// records if the request sets the time or count to read
+func (l *Log) streamFiles(ctx context.Context, req *pb.ReadRequest, stream pb.Log_StreamStream) error {
+	paths, err := l.Files(req.Service, req.Version)
+	if err != nil {
+		return errors.InternalServerError("go.micro.debug.log", err.Error())
+	}
+	if len(paths) == 0 {
+		return errors.NotFound("go.micro.debug.log", "no log files for %s", req.Service)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// tail before reading so no lines are missed in between
+	records := make(chan *pb.Record)
+	for _, path := range paths {
+		lines, err := logfile.Tail(ctx, path)
+		if err != nil {
+			return errors.InternalServerError("go.micro.debug.log", err.Error())
+		}
+		go func(path string) {
+			for line := range lines {
+				select {
+				case records <- fileRecord(req, path, line):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(path)
+	}
+
+	if req.Since > 0 || req.Count > 0 {
+		recent, err := l.readFiles(req)
+		if err != nil {
+			return err
+		}
+		for _, rec := range recent {
+			if err := stream.Send(rec); err != nil {
+				return err
+			}
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case rec := <-records:
+			if !match(req, rec) {
+				continue
+			}
+			if err := stream.Send(rec); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (l *Log) Read(ctx context.Context, req *pb.ReadRequest, rsp *pb.ReadResponse) error {
+	if err := l.allowed(ctx, req); err != nil {
+		return err
 	}
 
+	// the output of services run locally is written to files
+	if l.Files != nil {
+		records, err := l.readFiles(req)
+		if err != nil {
+			return err
+		}
+		rsp.Records = records
+		return nil
+	}
+
+	serviceLog := l.serviceLog(req.Service)
+
 	// TODO: specify how many log records to read
 	records, err := serviceLog.Read()
 	if err != nil {
@@ -41,12 +166,93 @@ func (l *Log) Read(ctx context.Context, req *pb.ReadRequest, rsp *pb.ReadRespons
 
 	// append to records
 	for _, rec := range records {
-		rsp.Records = append(rsp.Records, &pb.Record{
-			Timestamp: rec.Timestamp.Unix(),
-			Metadata:  rec.Metadata,
-			Message:   rec.Message.(string),
-		})
+		if r := l.record(req, rec); match(req, r) {
+			rsp.Records = append(rsp.Records, r)
+		}
+	}
+	rsp.Records = last(rsp.Records, req.Count)
+
+	return nil
+}
+
+// Stream the records of the service as they're logged
+func (l *Log) Stream(ctx context.Context, req *pb.ReadRequest, stream pb.Log_StreamStream) error {
+	defer stream.Close()
+
+	if err := l.allowed(ctx, req); err != nil {
+		return err
+	}
+	if l.Files != nil {
+		return l.streamFiles(ctx, req, stream)
+	}
+
+	ls, err := l.serviceLog(req.Service).Stream()
+	if err != nil {
+		return errors.InternalServerError("go.micro.debug.log", err.Error())
+	}
+	defer ls.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case rec, ok := <-ls.Chan():
+			if !ok {
+				return nil
+			}
+			r := l.record(req, rec)
+			if !match(req, r) {
+				continue
+			}
+			if err := stream.Send(r); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// allowed validates the request is for a service in the namespace of the caller
+func (l *Log) allowed(ctx context.Context, req *pb.ReadRequest) error {
+	if len(req.Service) == 0 {
+		return errors.BadRequest("go.micro.debug.log", "Invalid service name")
+	}
+	if _, ok := levels[strings.ToLower(req.Level)]; len(req.Level) > 0 && !ok {
+		return errors.BadRequest("go.micro.debug.log", "Invalid level %s", req.Level)
 	}
 
+	// scope the logs to the namespace of the caller
+	ns, err := namespace.FromContext(ctx)
+	if err != nil {
+		return errors.Forbidden("go.micro.debug.log", err.Error())
+	}
+	if !namespace.Allowed(ns, req.Service) {
+		return errors.Forbidden("go.micro.debug.log", "service %s is not in namespace %s", req.Service, ns)
+	}
 	return nil
 }
+
+// serviceLog returns the log of the service creating it if it's new
+func (l *Log) serviceLog(service string) log.Log {
+	l.Lock()
+	defer l.Unlock()
+
+	serviceLog, ok := l.Logs[service]
+	if !ok {
+		serviceLog = l.New(service)
+		l.Logs[service] = serviceLog
+	}
+	return serviceLog
+}
+
+// record converts the record of the service log
+func (l *Log) record(req *pb.ReadRequest, rec log.Record) *pb.Record {
+	msg := fmt.Sprintf("%v", rec.Message)
+	return &pb.Record{
+		Timestamp: rec.Timestamp.Unix(),
+		Metadata:  rec.Metadata,
+		Message:   msg,
+		Service:   req.Service,
+		Version:   req.Version,
+		Level:     Level(rec.Metadata, msg),
+	}
+}
@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	pb "github.com/micro/micro/v2/debug/log/proto"
+	"github.com/micro/micro/v2/runtime/logfile"
+)
+
+// testStream records the records sent
+type testStream struct {
+	records chan *pb.Record
+}
+
+func (t *testStream) SendMsg(interface{}) error { return nil }
+func (t *testStream) RecvMsg(interface{}) error { return nil }
+func (t *testStream) Close() error              { return nil }
+func (t *testStream) Send(r *pb.Record) error {
+	t.records <- r
+	return nil
+}
+
+func testFiles(t *testing.T) (*Log, string, func()) {
+	dir, err := ioutil.TempDir("", "log")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(dir, "greeter.log")
+	l := &Log{
+		Files: func(service, version string) ([]string, error) {
+			return []string{path}, nil
+		},
+	}
+
+	return l, path, func() { os.RemoveAll(dir) }
+}
+
+func TestReadFiles(t *testing.T) {
+	l, path, cleanup := testFiles(t)
+	defer cleanup()
+
+	// a line a minute from an hour ago
+	start := time.Now().Add(-time.Hour).Truncate(time.Minute)
+	var lines string
+	for i := 0; i < 10; i++ {
+		level := "info"
+		if i%2 == 1 {
+			level = "error"
+		}
+		lines += fmt.Sprintf("%s [%s] line %d\n", start.Add(time.Duration(i)*time.Minute).Format(time.RFC3339), level, i)
+	}
+	if err := ioutil.WriteFile(path, []byte(lines), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	testData := []struct {
+		req    *pb.ReadRequest
+		expect []string
+	}{
+		{&pb.ReadRequest{Service: "greeter", Count: 2}, []string{"[info] line 8", "[error] line 9"}},
+		{&pb.ReadRequest{Service: "greeter", Count: 3}, []string{"[error] line 7", "[info] line 8", "[error] line 9"}},
+		{&pb.ReadRequest{Service: "greeter", Since: start.Add(time.Minute * 8).Unix()}, []string{"[info] line 8", "[error] line 9"}},
+		{&pb.ReadRequest{Service: "greeter", Level: "error", Count: 2}, []string{"[error] line 7", "[error] line 9"}},
+	}
+
+	for _, d := range testData {
+		rsp := &pb.ReadResponse{}
+		if err := l.Read(context.Background(), d.req, rsp); err != nil {
+			t.Fatal(err)
+		}
+		if len(rsp.Records) != len(d.expect) {
+			t.Fatalf("Expected %d records for %+v got %d", len(d.expect), d.req, len(rsp.Records))
+		}
+		for i, rec := range rsp.Records {
+			if rec.Message != d.expect[i] {
+				t.Fatalf("Expected %s for %+v got %s", d.expect[i], d.req, rec.Message)
+			}
+			if rec.Timestamp < start.Unix() {
+				t.Fatalf("Unexpected timestamp %d", rec.Timestamp)
+			}
+		}
+	}
+}
+
+func TestStreamFiles(t *testing.T) {
+	l, path, cleanup := testFiles(t)
+	defer cleanup()
+
+	interval := logfile.TailInterval
+	logfile.TailInterval = time.Millisecond * 10
+	defer func() {
+		logfile.TailInterval = interval
+	}()
+
+	f, err := logfile.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	fmt.Fprintln(f, "[info] before")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream := &testStream{records: make(chan *pb.Record, 8)}
+	errc := make(chan error, 1)
+	go func() {
+		errc <- l.Stream(ctx, &pb.ReadRequest{Service: "greeter", Level: "error", Count: 1}, stream)
+	}()
+
+	expect := func(msg string) {
+		select {
+		case rec := <-stream.records:
+			if rec.Message != msg {
+				t.Fatalf("Expected %s got %s", msg, rec.Message)
+			}
+		case err := <-errc:
+			t.Fatalf("Stream ended %v", err)
+		case <-time.After(time.Second):
+			t.Fatalf("Expected %s to be streamed", msg)
+		}
+	}
+
+	// only the errors are streamed
+	fmt.Fprintln(f, "[info] skipped")
+	fmt.Fprintln(f, "[error] streamed")
+	expect("[error] streamed")
+
+	cancel()
+	if err := <-errc; err != nil {
+		t.Fatal(err)
+	}
+}
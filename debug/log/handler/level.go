@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"strings"
+
+	pb "github.com/micro/micro/v2/debug/log/proto"
+)
+
+var (
+	// severity of the levels, higher is more severe
+	levels = map[string]int{
+		"trace":   0,
+		"debug":   1,
+		"info":    2,
+		"warn":    3,
+		"warning": 3,
+		"error":   4,
+		"fatal":   5,
+	}
+)
+
+// Level returns the level of a record from its metadata or the start of its
+// message e.g [error] ..., ERROR: ... or level=error ..., it's info if it has none
+func Level(metadata map[string]string, message string) string {
+	if l, ok := levels[strings.ToLower(metadata["level"])]; ok {
+		return name(l)
+	}
+
+	fields := strings.Fields(message)
+	if len(fields) == 0 {
+		return "info"
+	}
+
+	word := strings.ToLower(strings.TrimPrefix(fields[0], "level="))
+	word = strings.Trim(word, "[]:")
+	if l, ok := levels[word]; ok {
+		return name(l)
+	}
+	return "info"
+}
+
+// name of the severity so warn and warning are the same
+func name(severity int) string {
+	switch severity {
+	case 0:
+		return "trace"
+	case 1:
+		return "debug"
+	case 3:
+		return "warn"
+	case 4:
+		return "error"
+	case 5:
+		return "fatal"
+	}
+	return "info"
+}
+
+// match returns whether the record passes the filters of the request
+func match(req *pb.ReadRequest, rec *pb.Record) bool {
+	if len(req.Service) > 0 && len(rec.Service) > 0 && rec.Service != req.Service {
+		return false
+	}
+	if len(req.Version) > 0 && len(rec.Version) > 0 && rec.Version != req.Version {
+		return false
+	}
+	if req.Since > 0 && rec.Timestamp < req.Since {
+		return false
+	}
+	if min, ok := levels[strings.ToLower(req.Level)]; ok && levels[rec.Level] < min {
+		return false
+	}
+	return true
+}
+
+// last returns the last count of the records, all of them if count is 0
+func last(records []*pb.Record, count int64) []*pb.Record {
+	if count > 0 && int64(len(records)) > count {
+		return records[int64(len(records))-count:]
+	}
+	return records
+}
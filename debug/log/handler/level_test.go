@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"testing"
+
+	pb "github.com/micro/micro/v2/debug/log/proto"
+)
+
+func TestLevel(t *testing.T) {
+	testData := []struct {
+		metadata map[string]string
+		message  string
+		level    string
+	}{
+		{map[string]string{"level": "ERROR"}, "failed", "error"},
+		{nil, "[warning] disk nearly full", "warn"},
+		{nil, "DEBUG: connected", "debug"},
+		{nil, "level=fatal msg=exiting", "fatal"},
+		{nil, "Listening on [::]:8080", "info"},
+		{nil, "", "info"},
+	}
+
+	for _, d := range testData {
+		if l := Level(d.metadata, d.message); l != d.level {
+			t.Fatalf("expected %q to be %s got %s", d.message, d.level, l)
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	rec := &pb.Record{Service: "go.micro.srv.foo", Version: "latest", Timestamp: 10, Level: "warn"}
+
+	testData := []struct {
+		req   *pb.ReadRequest
+		match bool
+	}{
+		{&pb.ReadRequest{}, true},
+		{&pb.ReadRequest{Service: "go.micro.srv.foo", Level: "warn"}, true},
+		{&pb.ReadRequest{Level: "info"}, true},
+		{&pb.ReadRequest{Level: "error"}, false},
+		{&pb.ReadRequest{Service: "go.micro.srv.bar"}, false},
+		{&pb.ReadRequest{Version: "v2"}, false},
+		{&pb.ReadRequest{Since: 11}, false},
+	}
+
+	for _, d := range testData {
+		if m := match(d.req, rec); m != d.match {
+			t.Fatalf("expected %+v to match %v", d.req, d.match)
+		}
+	}
+
+	records := []*pb.Record{rec, rec, rec}
+	if l := last(records, 2); len(l) != 2 {
+		t.Fatalf("expected the last 2 records got %d", len(l))
+	}
+	if l := last(records, 0); len(l) != 3 {
+		t.Fatalf("expected every record got %d", len(l))
+	}
+}
@@ -0,0 +1,180 @@
+package handler
+
+import (
+	"context"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/micro/go-micro/v2/client"
+	"github.com/micro/go-micro/v2/config/cmd"
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/registry"
+	"github.com/micro/go-micro/v2/registry/cache"
+	pb "github.com/micro/micro/v2/debug/log/proto"
+)
+
+var (
+	// RuntimeName is the name of the runtime service the log files are read from
+	RuntimeName = "go.micro.runtime"
+)
+
+// NewRuntime returns a Log handler which reads the log files of the services
+// from the runtime nodes which run them, as they're only on those nodes
+func NewRuntime() *Runtime {
+	return &Runtime{
+		registry: cache.New(*cmd.DefaultOptions().Registry),
+		client:   *cmd.DefaultOptions().Client,
+	}
+}
+
+// Runtime is the Log handler of the log files written by the runtime
+type Runtime struct {
+	registry registry.Registry
+	client   client.Client
+}
+
+// nodes returns the addresses of the runtime nodes
+func (r *Runtime) nodes() ([]string, error) {
+	services, err := r.registry.GetService(RuntimeName)
+	if err != nil {
+		return nil, errors.InternalServerError("go.micro.debug.log", err.Error())
+	}
+
+	var nodes []string
+	for _, s := range services {
+		for _, n := range s.Nodes {
+			nodes = append(nodes, n.Address)
+		}
+	}
+	if len(nodes) == 0 {
+		return nil, errors.NotFound("go.micro.debug.log", "%s is not running", RuntimeName)
+	}
+
+	return nodes, nil
+}
+
+// Read the records of the service from every runtime node merged by time. The
+// caller's token is passed on in the context so the nodes scope the request.
+func (r *Runtime) Read(ctx context.Context, req *pb.ReadRequest, rsp *pb.ReadResponse) error {
+	nodes, err := r.nodes()
+	if err != nil {
+		return err
+	}
+
+	logs := pb.NewLogService(RuntimeName, r.client)
+
+	var wg sync.WaitGroup
+	var mtx sync.Mutex
+	var records []*pb.Record
+	var errs []error
+
+	for _, node := range nodes {
+		wg.Add(1)
+		go func(node string) {
+			defer wg.Done()
+
+			nrsp, err := logs.Read(ctx, req, client.WithAddress(node))
+
+			mtx.Lock()
+			defer mtx.Unlock()
+
+			if err != nil {
+				errs = append(errs, err)
+				return
+			}
+			records = append(records, nrsp.Records...)
+		}(node)
+	}
+	wg.Wait()
+
+	// the nodes not running the service have no files
+	for _, err := range errs {
+		if errors.Parse(err.Error()).Code != 404 {
+			return err
+		}
+	}
+	if len(errs) == len(nodes) {
+		return errs[0]
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Timestamp < records[j].Timestamp
+	})
+	rsp.Records = last(records, req.Count)
+
+	return nil
+}
+
+// Stream the records of the service from every runtime node running it
+func (r *Runtime) Stream(ctx context.Context, req *pb.ReadRequest, stream pb.Log_StreamStream) error {
+	defer stream.Close()
+
+	nodes, err := r.nodes()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	logs := pb.NewLogService(RuntimeName, r.client)
+
+	records := make(chan *pb.Record)
+	errs := make(chan error, len(nodes))
+
+	for _, node := range nodes {
+		go func(node string) {
+			ns, err := logs.Stream(ctx, req, client.WithAddress(node))
+			if err != nil {
+				errs <- err
+				return
+			}
+			defer ns.Close()
+
+			for {
+				rec, err := ns.Recv()
+				if err == io.EOF {
+					errs <- nil
+					return
+				}
+				if err != nil {
+					errs <- err
+					return
+				}
+				select {
+				case records <- rec:
+				case <-ctx.Done():
+					errs <- nil
+					return
+				}
+			}
+		}(node)
+	}
+
+	// the nodes not running the service have no files
+	var notFound int
+	for done := 0; done < len(nodes); {
+		select {
+		case <-ctx.Done():
+			return nil
+		case rec := <-records:
+			if err := stream.Send(rec); err != nil {
+				return err
+			}
+		case err := <-errs:
+			done++
+			if err != nil && errors.Parse(err.Error()).Code == 404 {
+				notFound++
+			} else if err != nil {
+				return err
+			}
+		}
+	}
+
+	if notFound == len(nodes) {
+		return errors.NotFound("go.micro.debug.log", "no log files for %s", req.Service)
+	}
+
+	return nil
+}
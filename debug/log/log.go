@@ -0,0 +1,51 @@
+// Package log provides a service that aggregates the logs of all services in the registry.
+package log
+
+import (
+	"github.com/micro/cli/v2"
+	"github.com/micro/go-micro/v2"
+	ulog "github.com/micro/go-micro/v2/util/log"
+
+	"github.com/micro/micro/v2/debug/log/handler"
+	pb "github.com/micro/micro/v2/debug/log/proto"
+)
+
+// Run is the entrypoint for debug/log
+func Run(c *cli.Context) {
+	service := micro.NewService(
+		micro.Name("go.micro.debug.log"),
+	)
+
+	if n := c.Int("backlog"); n > 0 {
+		handler.TailBacklog = n
+	}
+
+	done := make(chan bool)
+	defer close(done)
+
+	// Register Handler
+	pb.RegisterLogHandler(service.Server(), handler.NewAggregator(done, c.Int("records")))
+
+	// Run service
+	if err := service.Run(); err != nil {
+		ulog.Fatal(err)
+	}
+}
+
+// Flags of the log aggregator
+func Flags() []cli.Flag {
+	return []cli.Flag{
+		&cli.IntFlag{
+			Name:    "records",
+			Usage:   "Specifies how many of the recent log records of all services to retain in memory",
+			EnvVars: []string{"MICRO_DEBUG_LOG_RECORDS"},
+			Value:   10000,
+		},
+		&cli.IntFlag{
+			Name:    "backlog",
+			Usage:   "Set how many of the last records of a service are read when it's first tailed",
+			EnvVars: []string{"MICRO_DEBUG_LOG_BACKLOG"},
+			Value:   handler.TailBacklog,
+		},
+	}
+}
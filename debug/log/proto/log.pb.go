@@ -26,7 +26,15 @@ type Record struct {
 	// record metadata
 	Metadata map[string]string `protobuf:"bytes,2,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	// record value
-	Message              string   `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Message string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	// service which logged the record
+	Service string `protobuf:"bytes,4,opt,name=service,proto3" json:"service,omitempty"`
+	// version of the service
+	Version string `protobuf:"bytes,5,opt,name=version,proto3" json:"version,omitempty"`
+	// id of the node of the service
+	Node string `protobuf:"bytes,6,opt,name=node,proto3" json:"node,omitempty"`
+	// level of the record e.g debug, info, warn, error
+	Level                string   `protobuf:"bytes,7,opt,name=level,proto3" json:"level,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -78,9 +86,44 @@ func (m *Record) GetMessage() string {
 	return ""
 }
 
+func (m *Record) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
+}
+
+func (m *Record) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *Record) GetNode() string {
+	if m != nil {
+		return m.Node
+	}
+	return ""
+}
+
+func (m *Record) GetLevel() string {
+	if m != nil {
+		return m.Level
+	}
+	return ""
+}
+
 type ReadRequest struct {
-	Service              string   `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
-	Version              string   `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	// service to read the logs of, every service if empty
+	Service string `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	// minimum level of the records e.g warn includes warn, error and fatal
+	Level string `protobuf:"bytes,3,opt,name=level,proto3" json:"level,omitempty"`
+	// unix timestamp to read the records from
+	Since int64 `protobuf:"varint,4,opt,name=since,proto3" json:"since,omitempty"`
+	// number of the last records to read
+	Count                int64    `protobuf:"varint,5,opt,name=count,proto3" json:"count,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -125,6 +168,27 @@ func (m *ReadRequest) GetVersion() string {
 	return ""
 }
 
+func (m *ReadRequest) GetLevel() string {
+	if m != nil {
+		return m.Level
+	}
+	return ""
+}
+
+func (m *ReadRequest) GetSince() int64 {
+	if m != nil {
+		return m.Since
+	}
+	return 0
+}
+
+func (m *ReadRequest) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
 type ReadResponse struct {
 	Records              []*Record `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
@@ -176,23 +240,28 @@ func init() {
 }
 
 var fileDescriptor_23adf446d3f28816 = []byte{
-	// 287 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x51, 0x41, 0x4b, 0xf4, 0x30,
-	0x14, 0xfc, 0xb2, 0xfd, 0xdc, 0xb5, 0x6f, 0x15, 0x24, 0x78, 0x08, 0x45, 0xb0, 0x14, 0x84, 0x9e,
-	0x52, 0x58, 0x3d, 0x88, 0x9e, 0x84, 0xf5, 0xa4, 0x5e, 0xf2, 0x0f, 0xb2, 0xed, 0x23, 0x14, 0xdb,
-	0xa6, 0x26, 0x69, 0x61, 0x7f, 0x9f, 0x7f, 0x4c, 0x9a, 0xb6, 0x2b, 0xa2, 0x7b, 0x09, 0x6f, 0x5e,
-	0x66, 0x26, 0x33, 0x04, 0x6e, 0xea, 0x32, 0x37, 0x3a, 0x1b, 0xcf, 0x02, 0x77, 0x9d, 0xca, 0x2a,
-	0xad, 0xb2, 0xd6, 0x68, 0xa7, 0x87, 0x89, 0xfb, 0x89, 0x52, 0xa5, 0xb9, 0xe7, 0x70, 0xcf, 0xe1,
-	0x95, 0x56, 0xc9, 0x27, 0x81, 0xa5, 0xc0, 0x5c, 0x9b, 0x82, 0x5e, 0x41, 0xe8, 0xca, 0x1a, 0xad,
-	0x93, 0x75, 0xcb, 0x48, 0x4c, 0xd2, 0x40, 0x7c, 0x2f, 0xe8, 0x16, 0x4e, 0x6b, 0x74, 0xb2, 0x90,
-	0x4e, 0xb2, 0x45, 0x1c, 0xa4, 0xeb, 0x4d, 0xca, 0x7f, 0xfb, 0xf1, 0xd1, 0x8b, 0xbf, 0x4d, 0xd4,
-	0xe7, 0xc6, 0x99, 0xbd, 0x38, 0x28, 0x29, 0x83, 0x55, 0x8d, 0xd6, 0x4a, 0x85, 0x2c, 0x88, 0x49,
-	0x1a, 0x8a, 0x19, 0x46, 0x8f, 0x70, 0xfe, 0x43, 0x44, 0x2f, 0x20, 0x78, 0xc7, 0xbd, 0x0f, 0x12,
-	0x8a, 0x61, 0xa4, 0x97, 0x70, 0xd2, 0xcb, 0xaa, 0x43, 0xb6, 0xf0, 0xbb, 0x11, 0x3c, 0x2c, 0xee,
-	0x49, 0xf2, 0x04, 0x6b, 0x81, 0xb2, 0x10, 0xf8, 0xd1, 0xa1, 0x75, 0xc3, 0x2b, 0x16, 0x4d, 0x5f,
-	0xe6, 0x38, 0xc9, 0x67, 0x38, 0xdc, 0xf4, 0x68, 0x6c, 0xa9, 0x9b, 0xc9, 0x64, 0x86, 0xc9, 0x16,
-	0xce, 0x46, 0x0b, 0xdb, 0xea, 0xc6, 0x22, 0xbd, 0x83, 0x95, 0xf1, 0x5d, 0x2c, 0x23, 0xbe, 0x6e,
-	0x74, 0xbc, 0xae, 0x98, 0xa9, 0x1b, 0x01, 0xc1, 0xab, 0x56, 0xf4, 0x05, 0xfe, 0x0f, 0x66, 0xf4,
-	0xfa, 0x6f, 0xcd, 0x21, 0x69, 0x14, 0x1f, 0x27, 0x8c, 0x39, 0x92, 0x7f, 0xbb, 0xa5, 0xff, 0xbd,
-	0xdb, 0xaf, 0x00, 0x00, 0x00, 0xff, 0xff, 0x86, 0xd3, 0x6b, 0x8e, 0xe6, 0x01, 0x00, 0x00,
+	// 360 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x85, 0x52, 0xcb, 0x4e, 0xc3, 0x30,
+	0x10, 0x6c, 0x92, 0x3e, 0xe8, 0x16, 0x24, 0x64, 0x71, 0xb0, 0x2a, 0x24, 0xaa, 0x48, 0x48, 0x3d,
+	0xa5, 0xa8, 0x70, 0x40, 0x70, 0x2d, 0x07, 0x04, 0x5c, 0xcc, 0x17, 0xb8, 0xc9, 0x2a, 0x8a, 0x48,
+	0xe2, 0x62, 0x3b, 0x91, 0x7a, 0xe6, 0x3b, 0x38, 0xf0, 0xa7, 0xc4, 0x76, 0x5a, 0x8a, 0xa0, 0x70,
+	0x89, 0x76, 0x66, 0x67, 0xd7, 0x33, 0x76, 0xe0, 0xbc, 0xc8, 0x62, 0x29, 0x66, 0xee, 0x9b, 0xe0,
+	0xb2, 0x4a, 0x67, 0xb9, 0x48, 0x67, 0x2b, 0x29, 0xb4, 0x30, 0x55, 0x64, 0x2b, 0x42, 0x52, 0x11,
+	0x59, 0x4d, 0x64, 0x35, 0x51, 0xd3, 0x09, 0x3f, 0x7c, 0xe8, 0x33, 0x8c, 0x85, 0x4c, 0xc8, 0x29,
+	0x0c, 0x75, 0x56, 0xa0, 0xd2, 0xbc, 0x58, 0x51, 0x6f, 0xe2, 0x4d, 0x03, 0xf6, 0x45, 0x90, 0x05,
+	0x1c, 0x14, 0xa8, 0x79, 0xc2, 0x35, 0xa7, 0xfe, 0x24, 0x98, 0x8e, 0xe6, 0xd3, 0xe8, 0xe7, 0xbe,
+	0xc8, 0xed, 0x8a, 0x9e, 0x5a, 0xe9, 0x5d, 0xa9, 0xe5, 0x9a, 0x6d, 0x27, 0x09, 0x85, 0x41, 0xb3,
+	0x50, 0xf1, 0x14, 0x69, 0xd0, 0x9c, 0x30, 0x64, 0x1b, 0x68, 0x3a, 0x0a, 0x65, 0x9d, 0xc5, 0x48,
+	0xbb, 0xae, 0xd3, 0x42, 0xd3, 0xa9, 0x51, 0xaa, 0x4c, 0x94, 0xb4, 0xe7, 0x3a, 0x2d, 0x24, 0x04,
+	0xba, 0xa5, 0x48, 0x90, 0xf6, 0x2d, 0x6d, 0x6b, 0x72, 0x02, 0xbd, 0x1c, 0x6b, 0xcc, 0xe9, 0xc0,
+	0x92, 0x0e, 0x8c, 0x6f, 0xe1, 0xe8, 0x9b, 0x25, 0x72, 0x0c, 0xc1, 0x0b, 0xae, 0x6d, 0xcc, 0x21,
+	0x33, 0xa5, 0x19, 0xac, 0x79, 0x5e, 0x61, 0x93, 0xce, 0x0e, 0x5a, 0x70, 0xe3, 0x5f, 0x7b, 0xe1,
+	0x9b, 0x07, 0x23, 0x86, 0x3c, 0x61, 0xf8, 0x5a, 0x35, 0xb7, 0xb1, 0x6b, 0xd5, 0xdb, 0x6b, 0xd5,
+	0xff, 0x6e, 0x75, 0x6b, 0x2b, 0xd8, 0xb1, 0x65, 0x58, 0x95, 0x95, 0x6d, 0xe4, 0x80, 0x39, 0x60,
+	0xd8, 0x58, 0x54, 0xa5, 0xb6, 0x71, 0x1b, 0xd6, 0x82, 0x70, 0x01, 0x87, 0xce, 0x84, 0x5a, 0x89,
+	0x52, 0x21, 0xb9, 0x82, 0x81, 0xb4, 0x97, 0xad, 0x1a, 0x17, 0xe6, 0x3d, 0xc6, 0xfb, 0xdf, 0x83,
+	0x6d, 0xa4, 0xf3, 0x77, 0x0f, 0x82, 0x47, 0x91, 0x92, 0x07, 0xe8, 0x9a, 0x6d, 0xe4, 0xec, 0xf7,
+	0xa1, 0x6d, 0xd8, 0xf1, 0x64, 0xbf, 0xc0, 0x19, 0x09, 0x3b, 0xe4, 0x1e, 0xfa, 0xcf, 0x5a, 0x22,
+	0x2f, 0xfe, 0x5f, 0xf7, 0x87, 0xc9, 0xb0, 0x73, 0xe1, 0x2d, 0xfb, 0xf6, 0x57, 0xbd, 0xfc, 0x04,
+	0xd0, 0x84, 0x16, 0x6d, 0xd3, 0x02, 0x00, 0x00,
 }
@@ -35,6 +35,7 @@ var _ server.Option
 
 type LogService interface {
 	Read(ctx context.Context, in *ReadRequest, opts ...client.CallOption) (*ReadResponse, error)
+	Stream(ctx context.Context, in *ReadRequest, opts ...client.CallOption) (Log_StreamService, error)
 }
 
 type logService struct {
@@ -65,15 +66,61 @@ func (c *logService) Read(ctx context.Context, in *ReadRequest, opts ...client.C
 	return out, nil
 }
 
+func (c *logService) Stream(ctx context.Context, in *ReadRequest, opts ...client.CallOption) (Log_StreamService, error) {
+	req := c.c.NewRequest(c.name, "Log.Stream", &ReadRequest{})
+	stream, err := c.c.Stream(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.Send(in); err != nil {
+		return nil, err
+	}
+	return &logServiceStream{stream}, nil
+}
+
+type Log_StreamService interface {
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	Close() error
+	Recv() (*Record, error)
+}
+
+type logServiceStream struct {
+	stream client.Stream
+}
+
+func (x *logServiceStream) Close() error {
+	return x.stream.Close()
+}
+
+func (x *logServiceStream) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *logServiceStream) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *logServiceStream) Recv() (*Record, error) {
+	m := new(Record)
+	err := x.stream.Recv(m)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // Server API for Log service
 
 type LogHandler interface {
 	Read(context.Context, *ReadRequest, *ReadResponse) error
+	Stream(context.Context, *ReadRequest, Log_StreamStream) error
 }
 
 func RegisterLogHandler(s server.Server, hdlr LogHandler, opts ...server.HandlerOption) error {
 	type log interface {
 		Read(ctx context.Context, in *ReadRequest, out *ReadResponse) error
+		Stream(ctx context.Context, stream server.Stream) error
 	}
 	type Log struct {
 		log
@@ -89,3 +136,38 @@ type logHandler struct {
 func (h *logHandler) Read(ctx context.Context, in *ReadRequest, out *ReadResponse) error {
 	return h.LogHandler.Read(ctx, in, out)
 }
+
+func (h *logHandler) Stream(ctx context.Context, stream server.Stream) error {
+	m := new(ReadRequest)
+	if err := stream.Recv(m); err != nil {
+		return err
+	}
+	return h.LogHandler.Stream(ctx, m, &logStreamStream{stream})
+}
+
+type Log_StreamStream interface {
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	Close() error
+	Send(*Record) error
+}
+
+type logStreamStream struct {
+	stream server.Stream
+}
+
+func (x *logStreamStream) Close() error {
+	return x.stream.Close()
+}
+
+func (x *logStreamStream) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *logStreamStream) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *logStreamStream) Send(m *Record) error {
+	return x.stream.Send(m)
+}
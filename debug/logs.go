@@ -0,0 +1,125 @@
+package debug
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/micro/cli/v2"
+	"github.com/micro/go-micro/v2"
+	"github.com/micro/go-micro/v2/client"
+	logpb "github.com/micro/micro/v2/debug/log/proto"
+	"github.com/micro/micro/v2/internal/namespace"
+	"github.com/micro/micro/v2/internal/redact"
+)
+
+var (
+	// LogName is the name of the log aggregation service
+	LogName = "go.micro.debug.log"
+)
+
+// logsFlags are the flags of the logs command
+func logsFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:  "version",
+			Usage: "Set the version of the service to read the logs of",
+		},
+		&cli.StringFlag{
+			Name:  "level",
+			Usage: "Set the minimum level of the logs e.g debug, info, warn, error",
+		},
+		&cli.StringFlag{
+			Name:  "since",
+			Usage: "Set to the relative time from which to show the logs for e.g. 1h",
+		},
+		&cli.IntFlag{
+			Name:  "count",
+			Usage: "Set to query the last number of log records",
+		},
+		&cli.BoolFlag{
+			Name:  "stream",
+			Usage: "Set to stream the logs continuously",
+		},
+		&cli.StringFlag{
+			Name:  "output, o",
+			Usage: "Set the output format e.g json, text",
+		},
+		&cli.StringFlag{
+			Name:    "token",
+			Usage:   "Set the namespace token used to read logs for services in the namespace",
+			EnvVars: []string{"MICRO_NAMESPACE_TOKEN"},
+		},
+		&cli.BoolFlag{
+			Name:  "show-secrets",
+			Usage: "Show the values of secret keys in the logs rather than masking them",
+		},
+	}
+}
+
+// printRecord prints the aggregated record in the output format
+func printRecord(output string, record *logpb.Record) {
+	switch output {
+	case "json":
+		b, _ := json.Marshal(record)
+		fmt.Printf("%v\n", string(redact.Bytes(b)))
+	default:
+		fmt.Printf("%s %s %s %v\n",
+			time.Unix(record.Timestamp, 0).Format(time.RFC3339),
+			record.Service,
+			record.Level,
+			redact.String(record.Message),
+		)
+	}
+}
+
+// getLogs prints the logs of the services read via the log aggregation service
+func getLogs(ctx *cli.Context, srvOpts ...micro.Option) {
+	if ctx.Bool("show-secrets") {
+		redact.Show = true
+	}
+
+	req := &logpb.ReadRequest{
+		Service: ctx.Args().First(),
+		Version: ctx.String("version"),
+		Level:   ctx.String("level"),
+		Count:   int64(ctx.Int("count")),
+	}
+	if d, err := time.ParseDuration(ctx.String("since")); err == nil {
+		req.Since = time.Now().Add(-d).Unix()
+	}
+
+	c := logpb.NewLogService(LogName, client.DefaultClient)
+	cctx := namespace.NewContext(context.Background(), ctx.String("token"))
+	output := ctx.String("output")
+
+	if !ctx.Bool("stream") {
+		rsp, err := c.Read(cctx, req)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		for _, record := range rsp.Records {
+			printRecord(output, record)
+		}
+		return
+	}
+
+	// the recent records are streamed first if the time or count to read is set
+	stream, err := c.Stream(cctx, req)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer stream.Close()
+
+	for {
+		record, err := stream.Recv()
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		printRecord(output, record)
+	}
+}
@@ -0,0 +1,134 @@
+// Package alert evaluates alert rules against the stats of the services
+package alert
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	stats "github.com/micro/micro/v2/debug/stats/proto"
+)
+
+// Alerts raised by the rules, an alert is pending until its rule has
+// matched for the duration of the rule and then fires until it resolves
+type Alerts struct {
+	rules []*Rule
+
+	sync.Mutex
+	// alerts pending or firing keyed by rule and service
+	active map[string]*stats.Alert
+}
+
+// New returns the alerts of the rules
+func New(rules ...*Rule) *Alerts {
+	return &Alerts{
+		rules:  rules,
+		active: make(map[string]*stats.Alert),
+	}
+}
+
+// Rules evaluated
+func (a *Alerts) Rules() []*Rule {
+	return a.rules
+}
+
+// Evaluate the rules against the aggregate of each service and the services in the
+// registry at the time, the alerts which fired or resolved are returned to notify
+func (a *Alerts) Evaluate(now time.Time, aggs []*stats.Aggregate, registered map[string]bool) []*stats.Alert {
+	a.Lock()
+	defer a.Unlock()
+
+	var changed []*stats.Alert
+	matched := make(map[string]bool)
+
+	for _, r := range a.rules {
+		for service, v := range r.evaluate(aggs, registered) {
+			key := r.String() + "/" + service
+			matched[key] = true
+
+			al, ok := a.active[key]
+			if !ok {
+				al = &stats.Alert{
+					Rule:      r.String(),
+					Service:   service,
+					State:     "pending",
+					Threshold: r.Threshold,
+					Started:   now.Unix(),
+				}
+				a.active[key] = al
+			}
+			al.Value = v
+
+			if al.State == "pending" && now.Sub(time.Unix(al.Started, 0)) >= r.For {
+				al.State = "firing"
+				al.Fired = now.Unix()
+				changed = append(changed, copyAlert(al))
+			}
+		}
+	}
+
+	for key, al := range a.active {
+		if matched[key] {
+			continue
+		}
+		delete(a.active, key)
+		if al.State != "firing" {
+			continue
+		}
+		al.State = "resolved"
+		al.Resolved = now.Unix()
+		changed = append(changed, al)
+	}
+
+	return changed
+}
+
+// evaluate the rule returning the value of the metric of each service it matches
+func (r *Rule) evaluate(aggs []*stats.Aggregate, registered map[string]bool) map[string]float64 {
+	matches := make(map[string]float64)
+
+	if r.Metric == "missing" {
+		if !registered[r.Service] {
+			matches[r.Service] = 1
+		}
+		return matches
+	}
+
+	for _, agg := range aggs {
+		if len(r.Service) > 0 && agg.Service != r.Service {
+			continue
+		}
+		if v := r.value(agg); r.match(v) {
+			matches[agg.Service] = v
+		}
+	}
+	return matches
+}
+
+// List the alerts which are firing, and those pending if set
+func (a *Alerts) List(pending bool) []*stats.Alert {
+	a.Lock()
+	defer a.Unlock()
+
+	alerts := make([]*stats.Alert, 0, len(a.active))
+	for _, al := range a.active {
+		if al.State == "pending" && !pending {
+			continue
+		}
+		alerts = append(alerts, copyAlert(al))
+	}
+
+	sort.Slice(alerts, func(i, j int) bool {
+		if alerts[i].Service != alerts[j].Service {
+			return alerts[i].Service < alerts[j].Service
+		}
+		return alerts[i].Rule < alerts[j].Rule
+	})
+
+	return alerts
+}
+
+func copyAlert(al *stats.Alert) *stats.Alert {
+	cp := *al
+	return &cp
+}
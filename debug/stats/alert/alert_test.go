@@ -0,0 +1,84 @@
+package alert
+
+import (
+	"testing"
+	"time"
+
+	stats "github.com/micro/micro/v2/debug/stats/proto"
+)
+
+func TestParse(t *testing.T) {
+	testData := []struct {
+		expr      string
+		metric    string
+		threshold float64
+		dur       time.Duration
+		service   string
+		err       bool
+	}{
+		{"error_rate > 5% for 5m", "error_rate", 0.05, time.Minute * 5, "", false},
+		{"memory >= 512mb on go.micro.srv.foo", "memory", 512 << 20, 0, "go.micro.srv.foo", false},
+		{"request_rate < 0.5", "request_rate", 0.5, 0, "", false},
+		{"missing for 1m on go.micro.srv.foo", "missing", 0, time.Minute, "go.micro.srv.foo", false},
+		{"missing for 1m", "", 0, 0, "", true},
+		{"latency > 5", "", 0, 0, "", true},
+		{"threads ! 5", "", 0, 0, "", true},
+		{"threads > 5 for", "", 0, 0, "", true},
+	}
+
+	for _, d := range testData {
+		r, err := Parse(d.expr)
+		if d.err {
+			if err == nil {
+				t.Fatalf("expected %q to be invalid", d.expr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("parse %q: %v", d.expr, err)
+		}
+		if r.Metric != d.metric || r.Threshold != d.threshold || r.For != d.dur || r.Service != d.service {
+			t.Fatalf("unexpected rule %+v parsed from %q", r, d.expr)
+		}
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	errRate, _ := Parse("error_rate > 5% for 1m")
+	missing, _ := Parse("missing on go.micro.srv.bar")
+	a := New(errRate, missing)
+
+	start := time.Unix(1000, 0)
+	aggs := []*stats.Aggregate{{Service: "go.micro.srv.foo", ErrorRate: 0.1}}
+	registered := map[string]bool{"go.micro.srv.foo": true, "go.micro.srv.bar": true}
+
+	if changed := a.Evaluate(start, aggs, registered); len(changed) != 0 {
+		t.Fatalf("expected the alert to be pending got %v", changed)
+	}
+	if l := a.List(false); len(l) != 0 {
+		t.Fatalf("expected no firing alerts got %v", l)
+	}
+	if l := a.List(true); len(l) != 1 || l[0].State != "pending" {
+		t.Fatalf("expected a pending alert got %v", l)
+	}
+
+	changed := a.Evaluate(start.Add(time.Minute), aggs, registered)
+	if len(changed) != 1 || changed[0].State != "firing" || changed[0].Value != 0.1 {
+		t.Fatalf("expected the alert to fire got %v", changed)
+	}
+
+	// the missing service fires at once
+	changed = a.Evaluate(start.Add(time.Minute*2), aggs, map[string]bool{"go.micro.srv.foo": true})
+	if len(changed) != 1 || changed[0].Service != "go.micro.srv.bar" {
+		t.Fatalf("expected the missing service to fire got %v", changed)
+	}
+
+	aggs[0].ErrorRate = 0.01
+	changed = a.Evaluate(start.Add(time.Minute*3), aggs, registered)
+	if len(changed) != 2 || changed[0].State != "resolved" || changed[1].State != "resolved" {
+		t.Fatalf("expected both alerts to resolve got %v", changed)
+	}
+	if l := a.List(true); len(l) != 0 {
+		t.Fatalf("expected no alerts got %v", l)
+	}
+}
@@ -0,0 +1,168 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/micro/go-micro/v2/client"
+	stats "github.com/micro/micro/v2/debug/stats/proto"
+)
+
+var (
+	notifiers = map[string]func() Notifier{
+		"http":   func() Notifier { return new(webhook) },
+		"https":  func() Notifier { return new(webhook) },
+		"slack":  func() Notifier { return new(slack) },
+		"broker": func() Notifier { return new(broker) },
+	}
+	mux sync.Mutex
+
+	// NotifyTimeout is how long a notifier has to deliver an alert
+	NotifyTimeout = time.Second * 10
+)
+
+// Notifier delivers the alerts as they fire and resolve
+type Notifier interface {
+	// Init the notifier from its url e.g slack://hooks.slack.com/services/T0/B0/X
+	Init(u *url.URL) error
+	// Notify of an alert which fired or resolved
+	Notify(*stats.Alert) error
+	String() string
+}
+
+// Register a notifier for the url scheme
+func Register(scheme string, fn func() Notifier) {
+	mux.Lock()
+	defer mux.Unlock()
+	notifiers[scheme] = fn
+}
+
+// NewNotifier returns a notifier initialised from the url e.g
+// https://example.com/hook, slack://hooks.slack.com/services/T0/B0/X or broker://go.micro.alerts
+func NewNotifier(addr string) (Notifier, error) {
+	u, err := url.Parse(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	mux.Lock()
+	fn, ok := notifiers[u.Scheme]
+	mux.Unlock()
+
+	if !ok {
+		return nil, fmt.Errorf("unknown alert notifier %s", u.Scheme)
+	}
+
+	n := fn()
+	if err := n.Init(u); err != nil {
+		return nil, err
+	}
+
+	return n, nil
+}
+
+// Message describes the alert for a person to read
+func Message(al *stats.Alert) string {
+	switch al.State {
+	case "resolved":
+		return fmt.Sprintf("[resolved] %s on %s", al.Rule, al.Service)
+	default:
+		return fmt.Sprintf("[%s] %s on %s, value %g", al.State, al.Rule, al.Service, al.Value)
+	}
+}
+
+// post the json of the value to the url
+func post(c *http.Client, addr string, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	rsp, err := c.Post(addr, "application/json", bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode < 200 || rsp.StatusCode > 299 {
+		body, _ := ioutil.ReadAll(rsp.Body)
+		return fmt.Errorf("%s: %s", rsp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// webhook posts the alert as json to the url
+type webhook struct {
+	addr   string
+	client *http.Client
+}
+
+func (w *webhook) Init(u *url.URL) error {
+	w.addr = u.String()
+	w.client = &http.Client{Timeout: NotifyTimeout}
+	return nil
+}
+
+func (w *webhook) Notify(al *stats.Alert) error {
+	return post(w.client, w.addr, al)
+}
+
+func (w *webhook) String() string {
+	return "webhook"
+}
+
+// slack posts the alert to an incoming webhook of a channel
+type slack struct {
+	addr   string
+	client *http.Client
+}
+
+func (s *slack) Init(u *url.URL) error {
+	if len(u.Host) == 0 {
+		return fmt.Errorf("slack notifier has no webhook host")
+	}
+	cp := *u
+	cp.Scheme = "https"
+	s.addr = cp.String()
+	s.client = &http.Client{Timeout: NotifyTimeout}
+	return nil
+}
+
+func (s *slack) Notify(al *stats.Alert) error {
+	return post(s.client, s.addr, map[string]string{"text": Message(al)})
+}
+
+func (s *slack) String() string {
+	return "slack"
+}
+
+// broker publishes the alert to the topic
+type broker struct {
+	topic string
+}
+
+func (b *broker) Init(u *url.URL) error {
+	b.topic = u.Host + u.Path
+	if len(b.topic) == 0 {
+		return fmt.Errorf("broker notifier has no topic")
+	}
+	return nil
+}
+
+func (b *broker) Notify(al *stats.Alert) error {
+	ctx, cancel := context.WithTimeout(context.Background(), NotifyTimeout)
+	defer cancel()
+	return client.Publish(ctx, client.NewMessage(b.topic, al))
+}
+
+func (b *broker) String() string {
+	return "broker"
+}
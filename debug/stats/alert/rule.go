@@ -0,0 +1,169 @@
+package alert
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	stats "github.com/micro/micro/v2/debug/stats/proto"
+)
+
+// Rule raises an alert for a service while its metric crosses the threshold
+type Rule struct {
+	// Metric of the rule e.g error_rate, request_rate, memory, threads or missing
+	Metric string
+	// Op compares the metric to the threshold e.g >, >=, <, <=
+	Op string
+	// Threshold of the metric, a fraction for the error rate and bytes for the memory
+	Threshold float64
+	// For is how long the rule has to match before the alert fires
+	For time.Duration
+	// Service the rule applies to, every service if empty
+	Service string
+
+	// expression the rule was parsed from
+	expr string
+}
+
+var (
+	metrics = map[string]bool{
+		"error_rate":   true,
+		"request_rate": true,
+		"memory":       true,
+		"threads":      true,
+		"missing":      true,
+	}
+
+	// units of the memory threshold
+	units = map[string]float64{
+		"b":  1,
+		"kb": 1 << 10,
+		"mb": 1 << 20,
+		"gb": 1 << 30,
+	}
+)
+
+// Parse a rule from its expression e.g
+//
+//	error_rate > 5% for 5m
+//	memory > 512mb on go.micro.srv.foo
+//	missing for 1m on go.micro.srv.foo
+func Parse(expr string) (*Rule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("empty alert rule")
+	}
+
+	r := &Rule{Metric: fields[0], expr: strings.Join(fields, " ")}
+	if !metrics[r.Metric] {
+		return nil, fmt.Errorf("unknown metric %s in alert rule %q", r.Metric, expr)
+	}
+	fields = fields[1:]
+
+	// a missing service has no threshold
+	if r.Metric != "missing" {
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("alert rule %q has no threshold", expr)
+		}
+		switch fields[0] {
+		case ">", ">=", "<", "<=":
+			r.Op = fields[0]
+		default:
+			return nil, fmt.Errorf("unknown operator %s in alert rule %q", fields[0], expr)
+		}
+		v, err := threshold(r.Metric, fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid threshold in alert rule %q: %v", expr, err)
+		}
+		r.Threshold = v
+		fields = fields[2:]
+	}
+
+	for len(fields) > 0 {
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("alert rule %q ends in %s", expr, fields[0])
+		}
+		switch fields[0] {
+		case "for":
+			d, err := time.ParseDuration(fields[1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid duration in alert rule %q: %v", expr, err)
+			}
+			r.For = d
+		case "on":
+			r.Service = fields[1]
+		default:
+			return nil, fmt.Errorf("unexpected %s in alert rule %q", fields[0], expr)
+		}
+		fields = fields[2:]
+	}
+
+	if r.Metric == "missing" && len(r.Service) == 0 {
+		return nil, fmt.Errorf("alert rule %q has no service", expr)
+	}
+
+	return r, nil
+}
+
+// threshold parses the value of the metric, the error rate can be a
+// percentage and the memory can have a unit e.g 5% or 512mb
+func threshold(metric, v string) (float64, error) {
+	scale := 1.0
+
+	switch {
+	case metric == "error_rate" && strings.HasSuffix(v, "%"):
+		v = strings.TrimSuffix(v, "%")
+		scale = 0.01
+	case metric == "memory":
+		lower := strings.ToLower(v)
+		for _, unit := range []string{"kb", "mb", "gb", "b"} {
+			if strings.HasSuffix(lower, unit) {
+				v = v[:len(v)-len(unit)]
+				scale = units[unit]
+				break
+			}
+		}
+	}
+
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return 0, err
+	}
+	return f * scale, nil
+}
+
+// String returns the expression of the rule
+func (r *Rule) String() string {
+	return r.expr
+}
+
+// value of the metric of the rule in the aggregate
+func (r *Rule) value(agg *stats.Aggregate) float64 {
+	switch r.Metric {
+	case "error_rate":
+		return agg.ErrorRate
+	case "request_rate":
+		return agg.RequestRate
+	case "memory":
+		return float64(agg.Memory)
+	case "threads":
+		return float64(agg.Threads)
+	}
+	return 0
+}
+
+// match returns whether the value crosses the threshold
+func (r *Rule) match(v float64) bool {
+	switch r.Op {
+	case ">":
+		return v > r.Threshold
+	case ">=":
+		return v >= r.Threshold
+	case "<":
+		return v < r.Threshold
+	case "<=":
+		return v <= r.Threshold
+	}
+	return false
+}
@@ -0,0 +1,39 @@
+package stats
+
+import (
+	"github.com/micro/cli/v2"
+	"github.com/micro/go-micro/v2/util/log"
+	"github.com/micro/micro/v2/debug/stats/alert"
+	"github.com/micro/micro/v2/debug/stats/handler"
+)
+
+// StartAlerts evaluates the alert rules of the flags with the handler until the
+// done channel is closed, nothing is evaluated if there are no rules
+func StartAlerts(c *cli.Context, h *handler.Stats, done <-chan bool) error {
+	exprs := c.StringSlice("alert")
+	if len(exprs) == 0 {
+		return nil
+	}
+
+	rules := make([]*alert.Rule, 0, len(exprs))
+	for _, expr := range exprs {
+		r, err := alert.Parse(expr)
+		if err != nil {
+			return err
+		}
+		rules = append(rules, r)
+	}
+
+	var notifiers []alert.Notifier
+	for _, addr := range c.StringSlice("notify") {
+		n, err := alert.NewNotifier(addr)
+		if err != nil {
+			return err
+		}
+		notifiers = append(notifiers, n)
+	}
+
+	log.Logf("Evaluating %d alert rules every %v", len(rules), handler.AlertInterval)
+	h.StartAlerts(done, rules, notifiers...)
+	return nil
+}
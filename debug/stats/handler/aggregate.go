@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/micro/go-micro/v2/errors"
+	stats "github.com/micro/micro/v2/debug/stats/proto"
+	"github.com/micro/micro/v2/internal/namespace"
+)
+
+var (
+	// AggregateRange is the range aggregated if the request doesn't set where it starts
+	AggregateRange = time.Minute * 5
+)
+
+// group is the key the snapshots are rolled up by
+func group(snap *stats.Snapshot, groupBy string) (string, string) {
+	switch groupBy {
+	case "cluster":
+		return "", ""
+	case "version":
+		return snap.Service.Name, snap.Service.Version
+	default:
+		return snap.Service.Name, ""
+	}
+}
+
+// aggregate rolls up the snapshots of the nodes of each group. The requests and errors of
+// a node are the increase of its counters, a counter which goes down is taken as a restart
+// of the node so its value is what it's served since. The request rate is the sum of the
+// rate of each node and the memory and threads the average of the last of each node.
+func aggregate(snaps []*stats.Snapshot, groupBy string) []*stats.Aggregate {
+	// snapshots of each node of each group
+	type groupKey struct{ service, version string }
+	nodes := make(map[groupKey]map[string][]*stats.Snapshot)
+	var order []groupKey
+
+	for _, snap := range snaps {
+		if snap.Service == nil || snap.Service.Node == nil {
+			continue
+		}
+		name, version := group(snap, groupBy)
+		k := groupKey{name, version}
+		if _, ok := nodes[k]; !ok {
+			nodes[k] = make(map[string][]*stats.Snapshot)
+			order = append(order, k)
+		}
+		nodes[k][nodeKey(snap)] = append(nodes[k][nodeKey(snap)], snap)
+	}
+
+	sort.Slice(order, func(i, j int) bool {
+		if order[i].service != order[j].service {
+			return order[i].service < order[j].service
+		}
+		return order[i].version < order[j].version
+	})
+
+	aggregates := make([]*stats.Aggregate, 0, len(order))
+
+	for _, k := range order {
+		agg := &stats.Aggregate{
+			Service: k.service,
+			Version: k.version,
+			Nodes:   uint64(len(nodes[k])),
+		}
+
+		var memory, threads uint64
+		for _, snapshots := range nodes[k] {
+			sort.SliceStable(snapshots, func(i, j int) bool {
+				return snapshots[i].Timestamp < snapshots[j].Timestamp
+			})
+
+			var requests, errs uint64
+			for i := 1; i < len(snapshots); i++ {
+				requests += increase(snapshots[i-1].Requests, snapshots[i].Requests)
+				errs += increase(snapshots[i-1].Errors, snapshots[i].Errors)
+			}
+
+			first, last := snapshots[0], snapshots[len(snapshots)-1]
+			if last.Timestamp > first.Timestamp {
+				agg.RequestRate += float64(requests) / float64(last.Timestamp-first.Timestamp)
+			}
+
+			agg.Requests += requests
+			agg.Errors += errs
+			memory += last.Memory
+			threads += last.Threads
+		}
+
+		if agg.Requests > 0 {
+			agg.ErrorRate = float64(agg.Errors) / float64(agg.Requests)
+		}
+		agg.Memory = memory / agg.Nodes
+		agg.Threads = threads / agg.Nodes
+
+		aggregates = append(aggregates, agg)
+	}
+
+	return aggregates
+}
+
+// increase of the counter between two snapshots, it's reset if the node restarted
+func increase(prev, next uint64) uint64 {
+	if next < prev {
+		return next
+	}
+	return next - prev
+}
+
+// Aggregate returns the rates and rollups of the snapshots taken over the range
+func (s *Stats) Aggregate(ctx context.Context, req *stats.AggregateRequest, rsp *stats.AggregateResponse) error {
+	ns, err := namespace.FromContext(ctx)
+	if err != nil {
+		return errors.Forbidden("go.micro.debug.stats", err.Error())
+	}
+
+	switch req.GroupBy {
+	case "", "service", "version", "cluster":
+	default:
+		return errors.BadRequest("go.micro.debug.stats", "invalid group_by %s", req.GroupBy)
+	}
+
+	now := time.Now()
+	from, to := now.Add(-AggregateRange), now
+	if req.From > 0 {
+		from = time.Unix(req.From, 0)
+	}
+	if req.To > 0 {
+		to = time.Unix(req.To, 0)
+	}
+	if to.Before(from) {
+		return errors.BadRequest("go.micro.debug.stats", "range ends before it starts")
+	}
+
+	var snaps []*stats.Snapshot
+	if s.history != nil {
+		var name string
+		if req.Service != nil {
+			name = req.Service.Name
+		}
+		snaps, err = s.history.past(name, from)
+		if err != nil {
+			return errors.InternalServerError("go.micro.debug.stats", "read stats error: %v", err)
+		}
+	} else {
+		s.RLock()
+		for _, entry := range s.historicalSnapshots.Since(from) {
+			snaps = append(snaps, entry.Value.([]*stats.Snapshot)...)
+		}
+		s.RUnlock()
+	}
+
+	inRange := make([]*stats.Snapshot, 0, len(snaps))
+	for _, snap := range snaps {
+		if t := int64(snap.Timestamp); t >= from.Unix() && t <= to.Unix() {
+			inRange = append(inRange, snap)
+		}
+	}
+
+	rsp.Aggregates = aggregate(filter(inRange, ns, req.Service), req.GroupBy)
+	return nil
+}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"testing"
+
+	stats "github.com/micro/micro/v2/debug/stats/proto"
+)
+
+func TestAggregate(t *testing.T) {
+	counters := func(snap *stats.Snapshot, node string, requests, errs, memory uint64) *stats.Snapshot {
+		snap.Service.Node.Id = node
+		snap.Requests = requests
+		snap.Errors = errs
+		snap.Memory = memory
+		return snap
+	}
+
+	snaps := []*stats.Snapshot{
+		// out of order and restarted between 20 and 30
+		counters(snapshot("go.micro.srv.foo", "v1", 30), "a", 10, 1, 300),
+		counters(snapshot("go.micro.srv.foo", "v1", 10), "a", 0, 0, 100),
+		counters(snapshot("go.micro.srv.foo", "v1", 20), "a", 40, 4, 200),
+		counters(snapshot("go.micro.srv.foo", "v2", 10), "b", 100, 0, 100),
+		counters(snapshot("go.micro.srv.foo", "v2", 20), "b", 150, 5, 100),
+		counters(snapshot("go.micro.srv.bar", "v1", 10), "c", 7, 0, 50),
+	}
+
+	aggs := aggregate(snaps, "service")
+	if len(aggs) != 2 {
+		t.Fatalf("expected 2 aggregates got %d", len(aggs))
+	}
+	bar, foo := aggs[0], aggs[1]
+	if bar.Service != "go.micro.srv.bar" || bar.Requests != 0 || bar.RequestRate != 0 || bar.Memory != 50 {
+		t.Fatalf("unexpected aggregate of the single snapshot %+v", bar)
+	}
+	if foo.Nodes != 2 || foo.Requests != 100 || foo.Errors != 10 {
+		t.Fatalf("unexpected totals %+v", foo)
+	}
+	// 50 requests in 20s and 50 in 10s
+	if foo.RequestRate != 7.5 {
+		t.Fatalf("expected 7.5 req/s got %v", foo.RequestRate)
+	}
+	if foo.ErrorRate != 0.1 {
+		t.Fatalf("expected an error rate of 0.1 got %v", foo.ErrorRate)
+	}
+	if foo.Memory != 200 {
+		t.Fatalf("expected the average of the last memory got %d", foo.Memory)
+	}
+
+	aggs = aggregate(snaps, "version")
+	if len(aggs) != 3 || aggs[1].Version != "v1" || aggs[2].Version != "v2" || aggs[2].Requests != 50 {
+		t.Fatalf("unexpected aggregates by version %+v", aggs)
+	}
+
+	aggs = aggregate(snaps, "cluster")
+	if len(aggs) != 1 || aggs[0].Service != "" || aggs[0].Nodes != 3 || aggs[0].Requests != 100 {
+		t.Fatalf("unexpected aggregate of the cluster %+v", aggs)
+	}
+}
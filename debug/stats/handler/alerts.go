@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/util/log"
+	"github.com/micro/micro/v2/debug/stats/alert"
+	stats "github.com/micro/micro/v2/debug/stats/proto"
+	"github.com/micro/micro/v2/internal/namespace"
+)
+
+var (
+	// AlertInterval is how often the alert rules are evaluated
+	AlertInterval = time.Second * 15
+	// AlertWindow is the range of the snapshots the rates of the alert rules are computed over
+	AlertWindow = time.Minute
+)
+
+// StartAlerts evaluates the rules against the stats until the done channel
+// is closed, the notifiers are notified as the alerts fire and resolve
+func (s *Stats) StartAlerts(done <-chan bool, rules []*alert.Rule, notifiers ...alert.Notifier) {
+	s.Lock()
+	s.alerts = alert.New(rules...)
+	s.Unlock()
+
+	go func() {
+		t := time.NewTicker(AlertInterval)
+		defer t.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-t.C:
+				changed := s.evaluate(time.Now())
+				if len(changed) > 0 && len(notifiers) > 0 {
+					go notify(changed, notifiers)
+				}
+			}
+		}
+	}()
+}
+
+// evaluate the alert rules against the snapshots of the window
+func (s *Stats) evaluate(now time.Time) []*stats.Alert {
+	var snaps []*stats.Snapshot
+	s.RLock()
+	alerts := s.alerts
+	for _, entry := range s.historicalSnapshots.Since(now.Add(-AlertWindow)) {
+		snaps = append(snaps, entry.Value.([]*stats.Snapshot)...)
+	}
+	s.RUnlock()
+
+	registered := make(map[string]bool)
+	for _, svc := range s.cached.Load().(*serviceList).services {
+		registered[svc.Name] = true
+	}
+
+	return alerts.Evaluate(now, aggregate(snaps, "service"), registered)
+}
+
+// notify each notifier of the alerts
+func notify(alerts []*stats.Alert, notifiers []alert.Notifier) {
+	for _, al := range alerts {
+		log.Logf("Alert %s", alert.Message(al))
+
+		for _, n := range notifiers {
+			if err := n.Notify(al); err != nil {
+				log.Errorf("Error notifying %s of alert %s: %v", n.String(), al.Rule, err)
+			}
+		}
+	}
+}
+
+// Alerts returns the alerts raised by the rules for the services
+func (s *Stats) Alerts(ctx context.Context, req *stats.AlertsRequest, rsp *stats.AlertsResponse) error {
+	ns, err := namespace.FromContext(ctx)
+	if err != nil {
+		return errors.Forbidden("go.micro.debug.stats", err.Error())
+	}
+
+	s.RLock()
+	alerts := s.alerts
+	s.RUnlock()
+
+	if alerts == nil {
+		return nil
+	}
+
+	for _, r := range alerts.Rules() {
+		rsp.Rules = append(rsp.Rules, r.String())
+	}
+
+	for _, al := range alerts.List(req.Pending) {
+		if !namespace.Allowed(ns, al.Service) {
+			continue
+		}
+		if req.Service != nil && len(req.Service.Name) > 0 && al.Service != req.Service.Name {
+			continue
+		}
+		rsp.Alerts = append(rsp.Alerts, al)
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@ package handler
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/micro/go-micro/v2/client"
@@ -14,15 +15,72 @@ import (
 	"github.com/micro/go-micro/v2/registry/cache"
 	"github.com/micro/go-micro/v2/util/log"
 	"github.com/micro/go-micro/v2/util/ring"
+	"github.com/micro/micro/v2/debug/stats/alert"
 	stats "github.com/micro/micro/v2/debug/stats/proto"
+	"github.com/micro/micro/v2/debug/stats/sink"
+	"github.com/micro/micro/v2/internal/namespace"
 )
 
+var (
+	// Grace is how long the last snapshot of a node which can't be scraped is kept
+	Grace = time.Second * 30
+	// TombstoneTTL is how long a node is not scraped once it's failed beyond the grace
+	TombstoneTTL = time.Minute * 5
+	// ScrapeInterval is how long after a scrape completes the services are scraped again
+	ScrapeInterval = time.Second
+	// ScrapeTimeout is how long a node has to respond to a scrape
+	ScrapeTimeout = time.Second * 2
+)
+
+// serviceList is an immutable list of the services to scrape,
+// each scan swaps in a new version rather than modifying it
+type serviceList struct {
+	version  uint64
+	services []*registry.Service
+	// ids of the nodes in the list
+	nodes map[string]bool
+}
+
+// liveness of a node across scrapes
+type liveness struct {
+	// last snapshot scraped from the node
+	last *stats.Snapshot
+	// when the node first failed to be scraped, zero if live
+	failed time.Time
+	// when the node was tombstoned, zero if not
+	tombstoned time.Time
+}
+
+// WindowSize returns the number of scrapes in the window of history kept in memory
+func WindowSize(window time.Duration) int {
+	if ScrapeInterval <= 0 || window <= 0 {
+		return 0
+	}
+	if n := int(window / ScrapeInterval); n > 0 {
+		return n
+	}
+	return 1
+}
+
 // New initialises and returns a new Stats service handler
-func New(done <-chan bool, windowSize int) (*Stats, error) {
+func New(done <-chan bool, windowSize int, sinks ...sink.Sink) (*Stats, error) {
 	s := &Stats{
 		registry:            cache.New(*cmd.DefaultOptions().Registry),
 		client:              *cmd.DefaultOptions().Client,
 		historicalSnapshots: ring.New(windowSize),
+		nodes:               make(map[string]*liveness),
+		sinks:               sinks,
+		sinkQueue:           make(chan []*stats.Snapshot, 64),
+		streams:             make(map[string]chan []*stats.Snapshot),
+		pushed:              make(map[string]*push),
+	}
+	s.cached.Store(&serviceList{})
+
+	// persist the snapshots rather than only keeping the window in memory
+	if Retention > 0 {
+		st := *cmd.DefaultOptions().Store
+		log.Logf("Persisting the stats snapshots to the %s store for %v", st.String(), Retention)
+		s.history = newHistory(st)
 	}
 
 	if err := s.scan(); err != nil {
@@ -43,17 +101,63 @@ type Stats struct {
 	snapshots []*stats.Snapshot
 	// historical snapshots from the start
 	historicalSnapshots *ring.Buffer
-	cached              []*registry.Service
+	// timestamp of the last snapshot of each node added to the history
+	added map[string]uint64
+	// snapshots persisted to the store if the retention is set
+	history *history
+	// alerts raised by the rules if any are set
+	alerts *alert.Alerts
+
+	// the latest *serviceList swapped in by scan
+	cached atomic.Value
+	// liveness of the nodes keyed by id
+	nodeMtx sync.Mutex
+	nodes   map[string]*liveness
+
+	// long term storage for snapshots
+	sinks     []sink.Sink
+	sinkQueue chan []*stats.Snapshot
+
+	// active streams keyed by id
+	streamMtx sync.RWMutex
+	streams   map[string]chan []*stats.Snapshot
+
+	// snapshots written by the services keyed by service and node
+	pushMtx sync.Mutex
+	pushed  map[string]*push
 }
 
 // Read returns gets a snapshot of all current stats
 func (s *Stats) Read(ctx context.Context, req *stats.ReadRequest, rsp *stats.ReadResponse) error {
+	// scope the stats to the namespace of the caller
+	ns, err := namespace.FromContext(ctx)
+	if err != nil {
+		return errors.Forbidden("go.micro.debug.stats", err.Error())
+	}
+
+	// the persisted snapshots are read from the store
+	if req.Past && s.history != nil {
+		var name string
+		if req.Service != nil {
+			name = req.Service.Name
+		}
+		past, err := s.history.past(name, time.Unix(req.Since, 0))
+		if err != nil {
+			return errors.InternalServerError("go.micro.debug.stats", "read stats error: %v", err)
+		}
+		rsp.Stats = filter(past, ns, req.Service)
+		return nil
+	}
+
 	allSnapshots := []*stats.Snapshot{}
 	func() {
 		s.RLock()
 		defer s.RUnlock()
 		if req.Past {
 			entries := s.historicalSnapshots.Get(3600)
+			if req.Since > 0 {
+				entries = s.historicalSnapshots.Since(time.Unix(req.Since, 0))
+			}
 			for _, entry := range entries {
 				allSnapshots = append(allSnapshots, entry.Value.([]*stats.Snapshot)...)
 			}
@@ -62,53 +166,52 @@ func (s *Stats) Read(ctx context.Context, req *stats.ReadRequest, rsp *stats.Rea
 			allSnapshots = append(allSnapshots, s.snapshots...)
 		}
 	}()
-	if req.Service == nil {
-		rsp.Stats = allSnapshots
-		return nil
-	}
-	filter := func(a, b string) bool {
-		if len(b) == 0 {
-			return true
-		}
-		return a == b
+	rsp.Stats = filter(allSnapshots, ns, req.Service)
+	return nil
+}
+
+// filter returns the snapshots of the services in the namespace which match the service,
+// an empty name or version of the service matches any
+func filter(snaps []*stats.Snapshot, ns string, service *stats.Service) []*stats.Snapshot {
+	match := func(a, b string) bool {
+		return len(b) == 0 || a == b
 	}
-	filteredSnapshots := []*stats.Snapshot{}
-	for _, s := range allSnapshots {
-		if !filter(s.Service.Name, req.Service.Name) {
+
+	filtered := []*stats.Snapshot{}
+	for _, s := range snaps {
+		if !namespace.Allowed(ns, s.Service.Name) {
 			continue
 		}
-		if !filter(s.Service.Version, req.Service.Version) {
+		if service != nil && (!match(s.Service.Name, service.Name) || !match(s.Service.Version, service.Version)) {
 			continue
 		}
-		filteredSnapshots = append(filteredSnapshots, s)
+		filtered = append(filtered, s)
 	}
-	rsp.Stats = filteredSnapshots
-	return nil
-}
-
-func (s *Stats) Write(ctx context.Context, req *stats.WriteRequest, rsp *stats.WriteResponse) error {
-	return errors.BadRequest("go.micro.debug.stats", "not implemented")
-}
-
-// Stream starts streaming stats
-func (s *Stats) Stream(ctx context.Context, req *stats.StreamRequest, rsp stats.Stats_StreamStream) error {
-	return errors.BadRequest("go.micro.debug.stats", "not implemented")
+	return filtered
 }
 
 // Start Starts scraping other services until the provided channel is closed
 func (s *Stats) Start(done <-chan bool) {
 	go func() {
 		for {
+			s.scrape()
+
 			select {
 			case <-done:
 				return
-			default:
-				s.scrape()
-				time.Sleep(time.Second)
+			case <-time.After(ScrapeInterval):
 			}
 		}
 	}()
 
+	if len(s.sinks) > 0 {
+		go s.drain(done)
+	}
+
+	if s.history != nil {
+		go s.history.compact(done)
+	}
+
 	go func() {
 		t := time.NewTicker(10 * time.Second)
 		defer t.Stop()
@@ -126,6 +229,27 @@ func (s *Stats) Start(done <-chan bool) {
 	}()
 }
 
+// drain writes snapshots to the sinks until the provided channel is closed
+func (s *Stats) drain(done <-chan bool) {
+	for {
+		select {
+		case <-done:
+			for _, sk := range s.sinks {
+				if err := sk.Close(); err != nil {
+					log.Errorf("Error closing %s sink: %v", sk.String(), err)
+				}
+			}
+			return
+		case snaps := <-s.sinkQueue:
+			for _, sk := range s.sinks {
+				if err := sk.Write(snaps); err != nil {
+					log.Errorf("Error writing to %s sink: %v", sk.String(), err)
+				}
+			}
+		}
+	}
+}
+
 func (s *Stats) scan() error {
 	services, err := s.registry.ListServices()
 	if err != nil {
@@ -157,26 +281,87 @@ func (s *Stats) scan() error {
 		}
 	}
 
-	// flatten the map
-	var serviceList []*registry.Service
+	s.nodeMtx.Lock()
+	defer s.nodeMtx.Unlock()
+
+	prev := s.cached.Load().(*serviceList)
+	list := &serviceList{
+		version: prev.version + 1,
+		nodes:   make(map[string]bool),
+	}
 
+	// flatten the map copying the services without tombstoned nodes
 	for _, service := range serviceMap {
-		serviceList = append(serviceList, service)
+		cp := new(registry.Service)
+		*cp = *service
+		cp.Nodes = nil
+
+		for _, node := range service.Nodes {
+			if l, ok := s.nodes[node.Id]; ok && !l.tombstoned.IsZero() {
+				// scrape it again once the tombstone expires
+				if time.Since(l.tombstoned) < TombstoneTTL {
+					continue
+				}
+				delete(s.nodes, node.Id)
+			}
+			cp.Nodes = append(cp.Nodes, node)
+			list.nodes[node.Id] = true
+		}
+
+		list.services = append(list.services, cp)
+	}
+
+	// forget the nodes which have left the registry
+	for id := range s.nodes {
+		if !list.nodes[id] && time.Since(s.nodes[id].tombstoned) >= TombstoneTTL {
+			delete(s.nodes, id)
+		}
+	}
+
+	// swap in the new version
+	s.cached.Store(list)
+	return nil
+}
+
+// live records the result of scraping a node returning the snapshot to
+// use, the last snapshot is used within the grace of a failed scrape
+func (s *Stats) live(id string, snap *stats.Snapshot) *stats.Snapshot {
+	s.nodeMtx.Lock()
+	defer s.nodeMtx.Unlock()
+
+	l, ok := s.nodes[id]
+	if !ok {
+		l = new(liveness)
+		s.nodes[id] = l
+	}
+
+	if snap != nil {
+		l.last = snap
+		l.failed = time.Time{}
+		return snap
+	}
+
+	if l.failed.IsZero() {
+		l.failed = time.Now()
+	}
+
+	if time.Since(l.failed) < Grace {
+		return l.last
+	}
+
+	// tombstone the node until the next scan drops it
+	if l.tombstoned.IsZero() {
+		l.tombstoned = time.Now()
+		log.Debugf("Tombstoned node %s after failing to be scraped for %v", id, Grace)
 	}
 
-	// save the list
-	s.Lock()
-	s.cached = serviceList
-	s.Unlock()
 	return nil
 }
 
 func (s *Stats) scrape() {
-	s.RLock()
-	// Create a local copy of cached services
-	services := make([]*registry.Service, len(s.cached))
-	copy(services, s.cached)
-	s.RUnlock()
+	// the list is immutable so can be read without a lock
+	list := s.cached.Load().(*serviceList)
+	services := list.services
 
 	// Start building the next list of snapshots
 	var mtx sync.Mutex
@@ -203,14 +388,21 @@ func (s *Stats) scrape() {
 			go func(st *Stats, service *registry.Service, node *registry.Node) {
 				defer wg.Done()
 
-				// create new context to cancel within a few seconds
-				ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+				// create new context to cancel after the scrape timeout
+				ctx, cancel := context.WithTimeout(context.Background(), ScrapeTimeout)
 				defer cancel()
 
 				req := s.client.NewRequest(service.Name, "Debug.Stats", &debug.StatsRequest{})
 				rsp := new(debug.StatsResponse)
 				if err := s.client.Call(ctx, req, rsp, client.WithAddress(node.Address)); err != nil {
 					log.Errorf("Error calling %s@%s (%s)", service.Name, node.Address, err.Error())
+
+					// keep the last snapshot within the grace
+					if last := st.live(node.Id, nil); last != nil {
+						mtx.Lock()
+						next = append(next, last)
+						mtx.Unlock()
+					}
 					return
 				}
 
@@ -234,6 +426,7 @@ func (s *Stats) scrape() {
 				}
 				timestamp := time.Now().Unix()
 				snap.Timestamp = uint64(timestamp)
+				st.live(node.Id, snap)
 				mtx.Lock()
 				next = append(next, snap)
 				mtx.Unlock()
@@ -242,9 +435,53 @@ func (s *Stats) scrape() {
 	}
 	wg.Wait()
 
-	// Swap in the snapshots
+	// drop the nodes removed by a scan while scraping
+	if current := s.cached.Load().(*serviceList); current.version != list.version {
+		live := next[:0]
+		for _, snap := range next {
+			if current.nodes[snap.Service.Node.Id] {
+				live = append(live, snap)
+			}
+		}
+		next = live
+	}
+
+	s.swap(s.merge(next))
+}
+
+// swap in the current snapshots of the scrape. The last snapshots kept within the
+// grace are already in the history so only the newer snapshots are added to it.
+func (s *Stats) swap(current []*stats.Snapshot) {
 	s.Lock()
-	s.snapshots = next
+	var next []*stats.Snapshot
+	added := make(map[string]uint64, len(current))
+	for _, snap := range current {
+		k := nodeKey(snap)
+		added[k] = snap.Timestamp
+		if t, ok := s.added[k]; ok && snap.Timestamp <= t {
+			continue
+		}
+		next = append(next, snap)
+	}
+	s.added = added
+	s.snapshots = current
 	s.historicalSnapshots.Put(next)
 	s.Unlock()
+
+	if s.history != nil {
+		s.history.add(time.Now(), next)
+	}
+
+	s.publish(next)
+
+	if len(s.sinks) == 0 {
+		return
+	}
+
+	// don't block scraping on slow sinks
+	select {
+	case s.sinkQueue <- next:
+	default:
+		log.Errorf("Stats sink queue full, dropping %d snapshots", len(next))
+	}
 }
@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/micro/go-micro/v2/util/ring"
+	stats "github.com/micro/micro/v2/debug/stats/proto"
+)
+
+func TestLiveGrace(t *testing.T) {
+	grace := Grace
+	Grace = time.Millisecond * 50
+	defer func() {
+		Grace = grace
+	}()
+
+	s := &Stats{nodes: make(map[string]*liveness)}
+
+	snap := snapshot("go.micro.srv.foo", "latest", 1)
+	if got := s.live("foo-1", snap); got != snap {
+		t.Fatal("expected the scraped snapshot")
+	}
+
+	// the last snapshot is kept within the grace
+	if got := s.live("foo-1", nil); got != snap {
+		t.Fatal("expected the last snapshot within the grace")
+	}
+
+	time.Sleep(Grace)
+
+	if got := s.live("foo-1", nil); got != nil {
+		t.Fatal("expected no snapshot after the grace")
+	}
+	if s.nodes["foo-1"].tombstoned.IsZero() {
+		t.Fatal("expected the node to be tombstoned")
+	}
+}
+
+func TestSwapKeepsHistory(t *testing.T) {
+	s := &Stats{
+		historicalSnapshots: ring.New(10),
+		streams:             make(map[string]chan []*stats.Snapshot),
+	}
+
+	foo := snapshot("go.micro.srv.foo", "latest", 1)
+	bar := snapshot("go.micro.srv.bar", "latest", 1)
+	s.swap([]*stats.Snapshot{foo, bar})
+
+	// foo failed to be scraped so its last snapshot is kept
+	s.swap([]*stats.Snapshot{foo, snapshot("go.micro.srv.bar", "latest", 2)})
+
+	rsp := &stats.ReadResponse{}
+	if err := s.Read(context.Background(), &stats.ReadRequest{}, rsp); err != nil {
+		t.Fatal(err)
+	}
+	if len(rsp.Stats) != 2 {
+		t.Fatalf("expected the current snapshot of both nodes got %d", len(rsp.Stats))
+	}
+
+	rsp = &stats.ReadResponse{}
+	if err := s.Read(context.Background(), &stats.ReadRequest{Past: true}, rsp); err != nil {
+		t.Fatal(err)
+	}
+
+	var count int
+	for _, snap := range rsp.Stats {
+		if snap.Service.Name == "go.micro.srv.foo" {
+			count++
+		}
+	}
+	if len(rsp.Stats) != 3 || count != 1 {
+		t.Fatalf("expected the last snapshot of foo once in the history got %d of %d", count, len(rsp.Stats))
+	}
+}
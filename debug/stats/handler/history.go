@@ -0,0 +1,363 @@
+package handler
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/micro/go-micro/v2/store"
+	"github.com/micro/go-micro/v2/util/log"
+	stats "github.com/micro/micro/v2/debug/stats/proto"
+)
+
+var (
+	// Retention is how long the snapshots are persisted to the store, they're only kept in memory if it's 0
+	Retention time.Duration
+	// CompactAfter is how old the persisted snapshots are when only the last of each node in a bucket is kept
+	CompactAfter = time.Hour
+	// BucketSize is the period of the snapshots of a service persisted as one record
+	BucketSize = time.Minute
+	// CompactInterval is how often the persisted snapshots are compacted and expired
+	CompactInterval = time.Minute * 10
+
+	// historyPrefix is the store key prefix of the buckets of a service e.g stats/go.micro.srv.foo/1580428800
+	historyPrefix = "stats/"
+	// servicesKey is the store key of the services with persisted snapshots
+	servicesKey = "stats-services"
+
+	// the most buckets read one by one rather than by their prefix
+	maxBucketReads = 60
+)
+
+// history of the snapshots persisted to the store
+type history struct {
+	store store.Store
+
+	sync.Mutex
+	// snapshots of each service scraped in the current bucket, persisted once it ends
+	bucket  int64
+	pending map[string][]*stats.Snapshot
+
+	// services with persisted snapshots
+	idxMtx   sync.Mutex
+	services map[string]bool
+}
+
+func newHistory(st store.Store) *history {
+	h := &history{
+		store:    st,
+		pending:  make(map[string][]*stats.Snapshot),
+		services: make(map[string]bool),
+	}
+
+	recs, err := st.Read(servicesKey)
+	if err == nil && len(recs) > 0 {
+		if err := json.Unmarshal(recs[0].Value, &h.services); err != nil {
+			log.Errorf("Error reading the services with persisted stats: %v", err)
+		}
+	}
+
+	return h
+}
+
+// bucket returns the start of the bucket of the time
+func bucket(t time.Time) int64 {
+	size := int64(BucketSize / time.Second)
+	if size < 1 {
+		size = 1
+	}
+	return t.Unix() / size * size
+}
+
+func historyKey(service string, bucket int64) string {
+	return historyPrefix + service + "/" + strconv.FormatInt(bucket, 10)
+}
+
+// keyBucket returns the bucket of the key
+func keyBucket(key string) (int64, bool) {
+	i := strings.LastIndex(key, "/")
+	if i < 0 {
+		return 0, false
+	}
+	b, err := strconv.ParseInt(key[i+1:], 10, 64)
+	return b, err == nil
+}
+
+// add the snapshots of a scrape, the bucket before is persisted once it ends
+func (h *history) add(now time.Time, snaps []*stats.Snapshot) {
+	b := bucket(now)
+
+	h.Lock()
+	defer h.Unlock()
+
+	if b != h.bucket && len(h.pending) > 0 {
+		go h.flush(h.bucket, h.pending)
+		h.pending = make(map[string][]*stats.Snapshot)
+	}
+	h.bucket = b
+
+	for _, snap := range snaps {
+		h.pending[snap.Service.Name] = append(h.pending[snap.Service.Name], snap)
+	}
+}
+
+// unique returns the snapshots without those repeated by the scrapes
+// e.g of a node which failed to be scraped within the grace
+func unique(snaps []*stats.Snapshot) []*stats.Snapshot {
+	seen := make(map[string]bool, len(snaps))
+	var uniq []*stats.Snapshot
+	for _, snap := range snaps {
+		key := nodeKey(snap) + "/" + strconv.FormatUint(snap.Timestamp, 10)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		uniq = append(uniq, snap)
+	}
+	return uniq
+}
+
+// flush the snapshots of the bucket of each service to the store
+func (h *history) flush(bucket int64, pending map[string][]*stats.Snapshot) {
+	// the store expires the bucket if it can, otherwise it's deleted by compact
+	expiry := time.Until(time.Unix(bucket, 0).Add(Retention))
+
+	var added bool
+	for name, snaps := range pending {
+		b, err := proto.Marshal(&stats.ReadResponse{Stats: unique(snaps)})
+		if err != nil {
+			log.Errorf("Error marshalling the stats of %s: %v", name, err)
+			continue
+		}
+		if err := h.store.Write(&store.Record{Key: historyKey(name, bucket), Value: b, Expiry: expiry}); err != nil {
+			log.Errorf("Error persisting the stats of %s: %v", name, err)
+			continue
+		}
+
+		h.idxMtx.Lock()
+		if !h.services[name] {
+			h.services[name] = true
+			added = true
+		}
+		h.idxMtx.Unlock()
+	}
+
+	if added {
+		h.writeServices()
+	}
+}
+
+// writeServices writes the services with persisted snapshots to the store
+func (h *history) writeServices() {
+	h.idxMtx.Lock()
+	defer h.idxMtx.Unlock()
+
+	b, err := json.Marshal(h.services)
+	if err != nil {
+		return
+	}
+	if err := h.store.Write(&store.Record{Key: servicesKey, Value: b}); err != nil {
+		log.Errorf("Error persisting the services with stats: %v", err)
+	}
+}
+
+// read the buckets of the service from the time
+func (h *history) read(service string, from time.Time) ([]*store.Record, error) {
+	first, last := bucket(from), bucket(time.Now())
+	size := int64(BucketSize / time.Second)
+	if size < 1 {
+		size = 1
+	}
+
+	// the buckets are read one by one rather than all of them when there are a few
+	if (last-first)/size < int64(maxBucketReads) {
+		var recs []*store.Record
+		for b := first; b <= last; b += size {
+			r, err := h.store.Read(historyKey(service, b))
+			if err == store.ErrNotFound {
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+			recs = append(recs, r...)
+		}
+		return recs, nil
+	}
+
+	recs, err := h.store.Read(historyPrefix+service+"/", store.ReadPrefix())
+	if err == store.ErrNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var inRange []*store.Record
+	for _, r := range recs {
+		if b, ok := keyBucket(r.Key); ok && b >= first {
+			inRange = append(inRange, r)
+		}
+	}
+	return inRange, nil
+}
+
+// past returns the snapshots of the service, or every service if it's empty, taken since the
+// time within the retention including those of the current bucket which aren't persisted yet
+func (h *history) past(service string, since time.Time) ([]*stats.Snapshot, error) {
+	if oldest := time.Now().Add(-Retention); since.Before(oldest) {
+		since = oldest
+	}
+
+	var services []string
+	if len(service) > 0 {
+		services = []string{service}
+	} else {
+		h.idxMtx.Lock()
+		for name := range h.services {
+			services = append(services, name)
+		}
+		h.idxMtx.Unlock()
+	}
+
+	var snaps []*stats.Snapshot
+	for _, name := range services {
+		recs, err := h.read(name, since)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range recs {
+			rsp := new(stats.ReadResponse)
+			if err := proto.Unmarshal(r.Value, rsp); err != nil {
+				log.Errorf("Error unmarshalling the stats %s: %v", r.Key, err)
+				continue
+			}
+			snaps = append(snaps, rsp.Stats...)
+		}
+	}
+
+	h.Lock()
+	for name, pending := range h.pending {
+		if len(service) == 0 || name == service {
+			snaps = append(snaps, unique(pending)...)
+		}
+	}
+	h.Unlock()
+
+	taken := snaps[:0]
+	for _, snap := range snaps {
+		if int64(snap.Timestamp) >= since.Unix() {
+			taken = append(taken, snap)
+		}
+	}
+	sort.SliceStable(taken, func(i, j int) bool {
+		return taken[i].Timestamp < taken[j].Timestamp
+	})
+
+	return taken, nil
+}
+
+// compact the persisted snapshots until the done channel is closed
+func (h *history) compact(done <-chan bool) {
+	t := time.NewTicker(CompactInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-t.C:
+			h.compactOnce(time.Now())
+		}
+	}
+}
+
+// last returns the last snapshot of each node
+func last(snaps []*stats.Snapshot) []*stats.Snapshot {
+	index := make(map[string]int)
+	var lasts []*stats.Snapshot
+	for _, snap := range snaps {
+		key := nodeKey(snap)
+		if i, ok := index[key]; ok {
+			if snap.Timestamp >= lasts[i].Timestamp {
+				lasts[i] = snap
+			}
+			continue
+		}
+		index[key] = len(lasts)
+		lasts = append(lasts, snap)
+	}
+	return lasts
+}
+
+// compactOnce deletes the buckets older than the retention and reduces those
+// older than CompactAfter to the last snapshot of each node
+func (h *history) compactOnce(now time.Time) {
+	expired, compact := bucket(now.Add(-Retention)), bucket(now.Add(-CompactAfter))
+
+	h.idxMtx.Lock()
+	services := make([]string, 0, len(h.services))
+	for name := range h.services {
+		services = append(services, name)
+	}
+	h.idxMtx.Unlock()
+
+	var removed bool
+	for _, name := range services {
+		recs, err := h.store.Read(historyPrefix+name+"/", store.ReadPrefix())
+		if err != nil && err != store.ErrNotFound {
+			log.Errorf("Error reading the stats of %s to compact: %v", name, err)
+			continue
+		}
+
+		var kept int
+		for _, r := range recs {
+			b, ok := keyBucket(r.Key)
+			if !ok {
+				continue
+			}
+			if b < expired {
+				if err := h.store.Delete(r.Key); err != nil && err != store.ErrNotFound {
+					log.Errorf("Error deleting the expired stats %s: %v", r.Key, err)
+				}
+				continue
+			}
+			kept++
+
+			if b >= compact {
+				continue
+			}
+
+			rsp := new(stats.ReadResponse)
+			if err := proto.Unmarshal(r.Value, rsp); err != nil {
+				continue
+			}
+			// compacted already
+			lasts := last(rsp.Stats)
+			if len(lasts) == len(rsp.Stats) {
+				continue
+			}
+			v, err := proto.Marshal(&stats.ReadResponse{Stats: lasts})
+			if err != nil {
+				continue
+			}
+			expiry := time.Until(time.Unix(b, 0).Add(Retention))
+			if err := h.store.Write(&store.Record{Key: r.Key, Value: v, Expiry: expiry}); err != nil {
+				log.Errorf("Error compacting the stats %s: %v", r.Key, err)
+			}
+		}
+
+		if kept == 0 {
+			h.idxMtx.Lock()
+			delete(h.services, name)
+			h.idxMtx.Unlock()
+			removed = true
+		}
+	}
+
+	if removed {
+		h.writeServices()
+	}
+}
@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/micro/go-micro/v2/store"
+	"github.com/micro/go-micro/v2/store/memory"
+	stats "github.com/micro/micro/v2/debug/stats/proto"
+)
+
+func TestHistory(t *testing.T) {
+	Retention = time.Hour * 24
+	defer func() { Retention = 0 }()
+
+	st := memory.NewStore()
+	h := newHistory(st)
+
+	now := time.Now()
+	old := now.Add(-CompactAfter * 2)
+
+	// two scrapes of a bucket older than CompactAfter, the second repeated within the grace
+	snaps := []*stats.Snapshot{
+		snapshot("go.micro.srv.foo", "latest", uint64(old.Unix())),
+		snapshot("go.micro.srv.foo", "latest", uint64(old.Unix()+1)),
+		snapshot("go.micro.srv.foo", "latest", uint64(old.Unix()+1)),
+	}
+	h.flush(bucket(old), map[string][]*stats.Snapshot{"go.micro.srv.foo": snaps})
+
+	// the current bucket isn't persisted yet
+	h.add(now, []*stats.Snapshot{snapshot("go.micro.srv.foo", "latest", uint64(now.Unix()))})
+
+	past, err := h.past("", time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(past) != 3 {
+		t.Fatalf("expected 3 snapshots got %d", len(past))
+	}
+
+	past, err = h.past("go.micro.srv.foo", now.Add(-time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(past) != 1 || past[0].Timestamp != uint64(now.Unix()) {
+		t.Fatalf("expected the snapshot of the current bucket got %v", past)
+	}
+
+	// the old bucket is reduced to the last snapshot of the node
+	h.compactOnce(now)
+	if past, _ := h.past("go.micro.srv.foo", old.Add(-time.Minute)); len(past) != 2 {
+		t.Fatalf("expected 2 snapshots after compacting got %d", len(past))
+	}
+
+	// and deleted once it's older than the retention
+	h.compactOnce(now.Add(Retention))
+	if _, err := st.Read(historyKey("go.micro.srv.foo", bucket(old))); err != store.ErrNotFound {
+		t.Fatalf("expected the expired bucket to be deleted got %v", err)
+	}
+}
@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	stats "github.com/micro/micro/v2/debug/stats/proto"
+)
+
+// metric rendered for each snapshot in the prometheus text format
+type metric struct {
+	name  string
+	help  string
+	kind  string
+	value func(*stats.Snapshot) float64
+}
+
+var metrics = []metric{
+	{"micro_started_timestamp_seconds", "Unix time the node started", "gauge", func(s *stats.Snapshot) float64 { return float64(s.Started) }},
+	{"micro_uptime_seconds", "Seconds the node has been up", "gauge", func(s *stats.Snapshot) float64 { return float64(s.Uptime) }},
+	{"micro_memory_bytes", "Heap allocated by the node in bytes", "gauge", func(s *stats.Snapshot) float64 { return float64(s.Memory) }},
+	{"micro_threads", "Goroutines of the node", "gauge", func(s *stats.Snapshot) float64 { return float64(s.Threads) }},
+	{"micro_gc_pause_seconds_total", "Total GC pause of the node in seconds", "counter", func(s *stats.Snapshot) float64 { return float64(s.Gc) / 1e9 }},
+	{"micro_requests_total", "Requests served by the node", "counter", func(s *stats.Snapshot) float64 { return float64(s.Requests) }},
+	{"micro_errors_total", "Requests served by the node which errored", "counter", func(s *stats.Snapshot) float64 { return float64(s.Errors) }},
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// labels of the service and node of the snapshot followed by the extra name and value pairs
+func labels(s *stats.Snapshot, extra ...string) string {
+	var node, address string
+	if s.Service.Node != nil {
+		node, address = s.Service.Node.Id, s.Service.Node.Address
+	}
+	l := fmt.Sprintf(`service="%s",version="%s",node="%s",address="%s"`,
+		labelEscaper.Replace(s.Service.Name),
+		labelEscaper.Replace(s.Service.Version),
+		labelEscaper.Replace(node),
+		labelEscaper.Replace(address),
+	)
+	for i := 0; i+1 < len(extra); i += 2 {
+		l += fmt.Sprintf(`,%s="%s"`, extra[i], labelEscaper.Replace(extra[i+1]))
+	}
+	return "{" + l + "}"
+}
+
+// quantiles of the latency
+func quantiles(l *stats.Latency) map[string]float64 {
+	return map[string]float64{"0.5": l.P50, "0.9": l.P90, "0.99": l.P99}
+}
+
+// writeLatency writes the percentiles of the latency of the snapshot
+func writeLatency(w io.Writer, name string, s *stats.Snapshot, l *stats.Latency, extra ...string) {
+	if l == nil {
+		return
+	}
+	q := quantiles(l)
+	for _, k := range []string{"0.5", "0.9", "0.99"} {
+		fmt.Fprintf(w, "%s%s %g\n", name, labels(s, append(extra, "quantile", k)...), q[k])
+	}
+}
+
+// WriteMetrics writes the snapshots in the prometheus text format
+func WriteMetrics(w io.Writer, snaps []*stats.Snapshot) error {
+	sorted := make([]*stats.Snapshot, 0, len(snaps))
+	for _, s := range snaps {
+		if s.Service != nil {
+			sorted = append(sorted, s)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return labels(sorted[i]) < labels(sorted[j])
+	})
+
+	bw := bufio.NewWriter(w)
+	for _, m := range metrics {
+		fmt.Fprintf(bw, "# HELP %s %s\n", m.name, m.help)
+		fmt.Fprintf(bw, "# TYPE %s %s\n", m.name, m.kind)
+		for _, s := range sorted {
+			fmt.Fprintf(bw, "%s%s %g\n", m.name, labels(s), m.value(s))
+		}
+	}
+
+	fmt.Fprintln(bw, "# HELP micro_latency_milliseconds Latency percentiles of the recent requests to the node")
+	fmt.Fprintln(bw, "# TYPE micro_latency_milliseconds gauge")
+	for _, s := range sorted {
+		writeLatency(bw, "micro_latency_milliseconds", s, s.Latency)
+	}
+
+	fmt.Fprintln(bw, "# HELP micro_endpoint_requests_total Requests served by the endpoint of the node")
+	fmt.Fprintln(bw, "# TYPE micro_endpoint_requests_total counter")
+	for _, s := range sorted {
+		for _, e := range s.Endpoints {
+			fmt.Fprintf(bw, "micro_endpoint_requests_total%s %d\n", labels(s, "endpoint", e.Name), e.Requests)
+		}
+	}
+
+	fmt.Fprintln(bw, "# HELP micro_endpoint_errors_total Requests served by the endpoint of the node which errored")
+	fmt.Fprintln(bw, "# TYPE micro_endpoint_errors_total counter")
+	for _, s := range sorted {
+		for _, e := range s.Endpoints {
+			fmt.Fprintf(bw, "micro_endpoint_errors_total%s %d\n", labels(s, "endpoint", e.Name), e.Errors)
+		}
+	}
+
+	fmt.Fprintln(bw, "# HELP micro_endpoint_latency_milliseconds Latency percentiles of the recent requests to the endpoint of the node")
+	fmt.Fprintln(bw, "# TYPE micro_endpoint_latency_milliseconds gauge")
+	for _, s := range sorted {
+		for _, e := range s.Endpoints {
+			writeLatency(bw, "micro_endpoint_latency_milliseconds", s, e.Latency, "endpoint", e.Name)
+		}
+	}
+
+	return bw.Flush()
+}
+
+// ServeHTTP renders the latest snapshots of every namespace for prometheus to scrape
+func (s *Stats) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.RLock()
+	snaps := s.snapshots
+	s.RUnlock()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	if err := WriteMetrics(w, snaps); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
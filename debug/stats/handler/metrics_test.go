@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	stats "github.com/micro/micro/v2/debug/stats/proto"
+)
+
+func TestWriteMetrics(t *testing.T) {
+	snap := snapshot("go.micro.srv.foo", `v"1`, 1)
+	snap.Service.Node.Address = "10.0.0.1:8080"
+	snap.Memory = 2048
+	snap.Gc = 1500000000
+	snap.Requests = 12
+	snap.Endpoints = []*stats.Endpoint{
+		{Name: "Foo.Bar", Requests: 12, Errors: 2, Latency: &stats.Latency{P50: 3, P90: 8.5, P99: 20}},
+	}
+
+	var b bytes.Buffer
+	if err := WriteMetrics(&b, []*stats.Snapshot{snap}); err != nil {
+		t.Fatal(err)
+	}
+
+	labels := `{service="go.micro.srv.foo",version="v\"1",node="go.micro.srv.foo-1",address="10.0.0.1:8080"}`
+	for _, line := range []string{
+		"# TYPE micro_memory_bytes gauge",
+		"micro_memory_bytes" + labels + " 2048",
+		"micro_gc_pause_seconds_total" + labels + " 1.5",
+		"# TYPE micro_requests_total counter",
+		"micro_requests_total" + labels + " 12",
+		"micro_endpoint_errors_total" + labels[:len(labels)-1] + `,endpoint="Foo.Bar"} 2`,
+		"micro_endpoint_latency_milliseconds" + labels[:len(labels)-1] + `,endpoint="Foo.Bar",quantile="0.9"} 8.5`,
+	} {
+		if !strings.Contains(b.String(), line+"\n") {
+			t.Fatalf("expected %s in\n%s", line, b.String())
+		}
+	}
+}
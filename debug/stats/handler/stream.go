@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/micro/go-micro/v2/errors"
+	stats "github.com/micro/micro/v2/debug/stats/proto"
+	"github.com/micro/micro/v2/internal/namespace"
+)
+
+var (
+	// StreamBuffer is the number of scrapes buffered for each stream, the oldest
+	// are dropped for streams which can't keep up rather than blocking scraping
+	StreamBuffer = 8
+)
+
+// publish the snapshots of a scrape to the streams
+func (s *Stats) publish(snaps []*stats.Snapshot) {
+	s.streamMtx.RLock()
+	defer s.streamMtx.RUnlock()
+
+	for _, next := range s.streams {
+		select {
+		case next <- snaps:
+			continue
+		default:
+		}
+
+		// make room by dropping the oldest scrape
+		select {
+		case <-next:
+		default:
+		}
+		select {
+		case next <- snaps:
+		default:
+		}
+	}
+}
+
+// Stream the snapshots of each scrape as it completes, starting with the current snapshots
+func (s *Stats) Stream(ctx context.Context, req *stats.StreamRequest, stream stats.Stats_StreamStream) error {
+	// scope the stats to the namespace of the caller
+	ns, err := namespace.FromContext(ctx)
+	if err != nil {
+		return errors.Forbidden("go.micro.debug.stats", err.Error())
+	}
+	if len(req.Namespace) > 0 {
+		if !namespace.Allowed(ns, req.Namespace) {
+			return errors.Forbidden("go.micro.debug.stats", "namespace %s is not in namespace %s", req.Namespace, ns)
+		}
+		ns = req.Namespace
+	}
+
+	id := uuid.New().String()
+	next := make(chan []*stats.Snapshot, StreamBuffer)
+
+	s.streamMtx.Lock()
+	s.streams[id] = next
+	s.streamMtx.Unlock()
+
+	defer func() {
+		s.streamMtx.Lock()
+		delete(s.streams, id)
+		s.streamMtx.Unlock()
+		stream.Close()
+	}()
+
+	s.RLock()
+	current := s.snapshots
+	s.RUnlock()
+
+	send := func(snaps []*stats.Snapshot) error {
+		if snaps = filter(snaps, ns, req.Service); len(snaps) == 0 {
+			return nil
+		}
+		return stream.Send(&stats.StreamResponse{Stats: snaps})
+	}
+
+	if err := send(current); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case snaps := <-next:
+			if err := send(snaps); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"testing"
+
+	stats "github.com/micro/micro/v2/debug/stats/proto"
+)
+
+func snapshot(name, version string, timestamp uint64) *stats.Snapshot {
+	return &stats.Snapshot{
+		Service:   &stats.Service{Name: name, Version: version, Node: &stats.Node{Id: name + "-1"}},
+		Timestamp: timestamp,
+	}
+}
+
+func TestPublishDropsOldest(t *testing.T) {
+	next := make(chan []*stats.Snapshot, 2)
+	s := &Stats{streams: map[string]chan []*stats.Snapshot{"slow": next}}
+
+	for i := uint64(1); i <= 3; i++ {
+		s.publish([]*stats.Snapshot{snapshot("go.micro.srv.foo", "latest", i)})
+	}
+
+	for _, expected := range []uint64{2, 3} {
+		snaps := <-next
+		if snaps[0].Timestamp != expected {
+			t.Fatalf("expected the scrape at %d got %d", expected, snaps[0].Timestamp)
+		}
+	}
+}
+
+func TestFilter(t *testing.T) {
+	snaps := []*stats.Snapshot{
+		snapshot("go.micro.srv.foo", "1", 1),
+		snapshot("go.micro.srv.foo", "2", 1),
+		snapshot("go.micro.api.bar", "1", 1),
+	}
+
+	if got := filter(snaps, "go.micro.srv", nil); len(got) != 2 {
+		t.Fatalf("expected 2 snapshots in the namespace got %d", len(got))
+	}
+	if got := filter(snaps, "*", &stats.Service{Name: "go.micro.srv.foo", Version: "2"}); len(got) != 1 || got[0].Service.Version != "2" {
+		t.Fatalf("expected the snapshot of version 2 got %v", got)
+	}
+}
@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/micro/go-micro/v2/errors"
+	stats "github.com/micro/micro/v2/debug/stats/proto"
+	"github.com/micro/micro/v2/internal/namespace"
+)
+
+// push is a snapshot written by a service rather than scraped
+type push struct {
+	snap     *stats.Snapshot
+	received time.Time
+}
+
+// nodeKey of the snapshot's service and node
+func nodeKey(snap *stats.Snapshot) string {
+	return snap.Service.Name + "/" + snap.Service.Node.Id
+}
+
+// Write a snapshot pushed by a service which can't be scraped e.g behind NAT or short lived,
+// it's merged with the scraped snapshots until it's older than the grace
+func (s *Stats) Write(ctx context.Context, req *stats.WriteRequest, rsp *stats.WriteResponse) error {
+	// scope the stats to the namespace of the caller
+	ns, err := namespace.FromContext(ctx)
+	if err != nil {
+		return errors.Forbidden("go.micro.debug.stats", err.Error())
+	}
+
+	snap := req.Stats
+	if snap == nil {
+		return errors.BadRequest("go.micro.debug.stats", "missing snapshot")
+	}
+	if snap.Service == nil {
+		snap.Service = req.Service
+	}
+	if snap.Service == nil || len(snap.Service.Name) == 0 {
+		return errors.BadRequest("go.micro.debug.stats", "missing service name")
+	}
+	if snap.Service.Node == nil || len(snap.Service.Node.Id) == 0 {
+		return errors.BadRequest("go.micro.debug.stats", "missing node id")
+	}
+	if !namespace.Allowed(ns, snap.Service.Name) {
+		return errors.Forbidden("go.micro.debug.stats", "service %s is not in namespace %s", snap.Service.Name, ns)
+	}
+	if snap.Timestamp == 0 {
+		snap.Timestamp = uint64(time.Now().Unix())
+	}
+
+	key := nodeKey(snap)
+
+	s.pushMtx.Lock()
+	defer s.pushMtx.Unlock()
+
+	// the snapshot was retried or arrived out of order
+	if p, ok := s.pushed[key]; ok && p.snap.Timestamp >= snap.Timestamp {
+		return nil
+	}
+	s.pushed[key] = &push{snap: snap, received: time.Now()}
+
+	return nil
+}
+
+// merge the snapshots pushed within the grace into the scraped snapshots,
+// the newest is kept of a node which is both scraped and pushed
+func (s *Stats) merge(scraped []*stats.Snapshot) []*stats.Snapshot {
+	s.pushMtx.Lock()
+	defer s.pushMtx.Unlock()
+
+	if len(s.pushed) == 0 {
+		return scraped
+	}
+
+	index := make(map[string]int, len(scraped))
+	for i, snap := range scraped {
+		index[nodeKey(snap)] = i
+	}
+
+	for key, p := range s.pushed {
+		if time.Since(p.received) >= Grace {
+			delete(s.pushed, key)
+			continue
+		}
+		if i, ok := index[key]; ok {
+			if p.snap.Timestamp > scraped[i].Timestamp {
+				scraped[i] = p.snap
+			} else if len(p.snap.Endpoints) > 0 {
+				// Debug.Stats doesn't report the endpoints so they're kept from the push
+				snap := *scraped[i]
+				snap.Endpoints = p.snap.Endpoints
+				snap.Latency = p.snap.Latency
+				scraped[i] = &snap
+			}
+			continue
+		}
+		scraped = append(scraped, p.snap)
+	}
+
+	return scraped
+}
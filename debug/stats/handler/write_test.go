@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	stats "github.com/micro/micro/v2/debug/stats/proto"
+)
+
+func TestWriteMerge(t *testing.T) {
+	s := &Stats{pushed: make(map[string]*push)}
+
+	for _, ts := range []uint64{5, 5, 3} {
+		req := &stats.WriteRequest{Stats: snapshot("go.micro.srv.job", "latest", ts)}
+		if err := s.Write(context.Background(), req, &stats.WriteResponse{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if p := s.pushed["go.micro.srv.job/go.micro.srv.job-1"]; p == nil || p.snap.Timestamp != 5 {
+		t.Fatalf("expected the newest snapshot to be kept got %v", p)
+	}
+
+	// the pushed snapshot is newer than the one scraped from the node
+	scraped := []*stats.Snapshot{
+		snapshot("go.micro.srv.job", "latest", 4),
+		snapshot("go.micro.srv.foo", "latest", 4),
+	}
+	merged := s.merge(scraped)
+	if len(merged) != 2 {
+		t.Fatalf("expected 2 snapshots got %d", len(merged))
+	}
+	if merged[0].Timestamp != 5 {
+		t.Fatalf("expected the pushed snapshot got the one at %d", merged[0].Timestamp)
+	}
+
+	if err := s.Write(context.Background(), &stats.WriteRequest{Stats: &stats.Snapshot{}}, &stats.WriteResponse{}); err == nil {
+		t.Fatal("expected an error writing a snapshot without a service")
+	}
+}
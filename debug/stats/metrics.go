@@ -0,0 +1,28 @@
+package stats
+
+import (
+	"net/http"
+
+	"github.com/micro/go-micro/v2/util/log"
+	"github.com/micro/micro/v2/debug/stats/handler"
+)
+
+// ServeMetrics serves the latest snapshots of the handler at /metrics of the address in the
+// prometheus text format until the done channel is closed
+func ServeMetrics(address string, h *handler.Stats, done <-chan bool) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", h)
+	srv := &http.Server{Addr: address, Handler: mux}
+
+	go func() {
+		<-done
+		srv.Close()
+	}()
+
+	go func() {
+		log.Logf("Serving the stats metrics at %s/metrics", address)
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("Error serving the stats metrics: %v", err)
+		}
+	}()
+}
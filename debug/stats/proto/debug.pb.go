@@ -143,7 +143,11 @@ type Snapshot struct {
 	// Total number of errors
 	Errors uint64 `protobuf:"varint,8,opt,name=errors,proto3" json:"errors,omitempty"`
 	// Timestamp at the time of the taking of the snapshot, seconds since unix epoch
-	Timestamp            uint64   `protobuf:"varint,9,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Timestamp uint64 `protobuf:"varint,9,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Requests and errors of each endpoint, set by services which report them
+	Endpoints []*Endpoint `protobuf:"bytes,10,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
+	// Latency of the recent requests to every endpoint
+	Latency              *Latency `protobuf:"bytes,11,opt,name=latency,proto3" json:"latency,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -237,12 +241,151 @@ func (m *Snapshot) GetTimestamp() uint64 {
 	return 0
 }
 
+func (m *Snapshot) GetEndpoints() []*Endpoint {
+	if m != nil {
+		return m.Endpoints
+	}
+	return nil
+}
+
+func (m *Snapshot) GetLatency() *Latency {
+	if m != nil {
+		return m.Latency
+	}
+	return nil
+}
+
+// Endpoint is the requests served by an endpoint of a service e.g Greeter.Hello
+type Endpoint struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Total number of requests
+	Requests uint64 `protobuf:"varint,2,opt,name=requests,proto3" json:"requests,omitempty"`
+	// Total number of errors
+	Errors uint64 `protobuf:"varint,3,opt,name=errors,proto3" json:"errors,omitempty"`
+	// Latency of the recent requests to the endpoint
+	Latency              *Latency `protobuf:"bytes,4,opt,name=latency,proto3" json:"latency,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Endpoint) Reset()         { *m = Endpoint{} }
+func (m *Endpoint) String() string { return proto.CompactTextString(m) }
+func (*Endpoint) ProtoMessage()    {}
+func (*Endpoint) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8f2de2571cb9c61f, []int{3}
+}
+
+func (m *Endpoint) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Endpoint.Unmarshal(m, b)
+}
+func (m *Endpoint) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Endpoint.Marshal(b, m, deterministic)
+}
+func (m *Endpoint) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Endpoint.Merge(m, src)
+}
+func (m *Endpoint) XXX_Size() int {
+	return xxx_messageInfo_Endpoint.Size(m)
+}
+func (m *Endpoint) XXX_DiscardUnknown() {
+	xxx_messageInfo_Endpoint.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Endpoint proto.InternalMessageInfo
+
+func (m *Endpoint) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Endpoint) GetRequests() uint64 {
+	if m != nil {
+		return m.Requests
+	}
+	return 0
+}
+
+func (m *Endpoint) GetErrors() uint64 {
+	if m != nil {
+		return m.Errors
+	}
+	return 0
+}
+
+func (m *Endpoint) GetLatency() *Latency {
+	if m != nil {
+		return m.Latency
+	}
+	return nil
+}
+
+// Latency percentiles in milliseconds
+type Latency struct {
+	P50                  float64  `protobuf:"fixed64,1,opt,name=p50,proto3" json:"p50,omitempty"`
+	P90                  float64  `protobuf:"fixed64,2,opt,name=p90,proto3" json:"p90,omitempty"`
+	P99                  float64  `protobuf:"fixed64,3,opt,name=p99,proto3" json:"p99,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Latency) Reset()         { *m = Latency{} }
+func (m *Latency) String() string { return proto.CompactTextString(m) }
+func (*Latency) ProtoMessage()    {}
+func (*Latency) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8f2de2571cb9c61f, []int{4}
+}
+
+func (m *Latency) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Latency.Unmarshal(m, b)
+}
+func (m *Latency) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Latency.Marshal(b, m, deterministic)
+}
+func (m *Latency) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Latency.Merge(m, src)
+}
+func (m *Latency) XXX_Size() int {
+	return xxx_messageInfo_Latency.Size(m)
+}
+func (m *Latency) XXX_DiscardUnknown() {
+	xxx_messageInfo_Latency.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Latency proto.InternalMessageInfo
+
+func (m *Latency) GetP50() float64 {
+	if m != nil {
+		return m.P50
+	}
+	return 0
+}
+
+func (m *Latency) GetP90() float64 {
+	if m != nil {
+		return m.P90
+	}
+	return 0
+}
+
+func (m *Latency) GetP99() float64 {
+	if m != nil {
+		return m.P99
+	}
+	return 0
+}
+
 type ReadRequest struct {
 	// If set, only return services matching the filter
 	Service *Service `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
 	// If false, only the current snapshots will be returned.
 	// If true, all historical snapshots in memory will be returned.
-	Past                 bool     `protobuf:"varint,2,opt,name=past,proto3" json:"past,omitempty"`
+	Past bool `protobuf:"varint,2,opt,name=past,proto3" json:"past,omitempty"`
+	// Unix timestamp, if set only the historical snapshots taken since it are returned
+	Since                int64    `protobuf:"varint,3,opt,name=since,proto3" json:"since,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -252,7 +395,7 @@ func (m *ReadRequest) Reset()         { *m = ReadRequest{} }
 func (m *ReadRequest) String() string { return proto.CompactTextString(m) }
 func (*ReadRequest) ProtoMessage()    {}
 func (*ReadRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8f2de2571cb9c61f, []int{3}
+	return fileDescriptor_8f2de2571cb9c61f, []int{5}
 }
 
 func (m *ReadRequest) XXX_Unmarshal(b []byte) error {
@@ -287,6 +430,13 @@ func (m *ReadRequest) GetPast() bool {
 	return false
 }
 
+func (m *ReadRequest) GetSince() int64 {
+	if m != nil {
+		return m.Since
+	}
+	return 0
+}
+
 type ReadResponse struct {
 	Stats                []*Snapshot `protobuf:"bytes,1,rep,name=stats,proto3" json:"stats,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
@@ -298,7 +448,7 @@ func (m *ReadResponse) Reset()         { *m = ReadResponse{} }
 func (m *ReadResponse) String() string { return proto.CompactTextString(m) }
 func (*ReadResponse) ProtoMessage()    {}
 func (*ReadResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8f2de2571cb9c61f, []int{4}
+	return fileDescriptor_8f2de2571cb9c61f, []int{6}
 }
 
 func (m *ReadResponse) XXX_Unmarshal(b []byte) error {
@@ -340,7 +490,7 @@ func (m *WriteRequest) Reset()         { *m = WriteRequest{} }
 func (m *WriteRequest) String() string { return proto.CompactTextString(m) }
 func (*WriteRequest) ProtoMessage()    {}
 func (*WriteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8f2de2571cb9c61f, []int{5}
+	return fileDescriptor_8f2de2571cb9c61f, []int{7}
 }
 
 func (m *WriteRequest) XXX_Unmarshal(b []byte) error {
@@ -385,7 +535,7 @@ func (m *WriteResponse) Reset()         { *m = WriteResponse{} }
 func (m *WriteResponse) String() string { return proto.CompactTextString(m) }
 func (*WriteResponse) ProtoMessage()    {}
 func (*WriteResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8f2de2571cb9c61f, []int{6}
+	return fileDescriptor_8f2de2571cb9c61f, []int{8}
 }
 
 func (m *WriteResponse) XXX_Unmarshal(b []byte) error {
@@ -420,7 +570,7 @@ func (m *StreamRequest) Reset()         { *m = StreamRequest{} }
 func (m *StreamRequest) String() string { return proto.CompactTextString(m) }
 func (*StreamRequest) ProtoMessage()    {}
 func (*StreamRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8f2de2571cb9c61f, []int{7}
+	return fileDescriptor_8f2de2571cb9c61f, []int{9}
 }
 
 func (m *StreamRequest) XXX_Unmarshal(b []byte) error {
@@ -466,7 +616,7 @@ func (m *StreamResponse) Reset()         { *m = StreamResponse{} }
 func (m *StreamResponse) String() string { return proto.CompactTextString(m) }
 func (*StreamResponse) ProtoMessage()    {}
 func (*StreamResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8f2de2571cb9c61f, []int{8}
+	return fileDescriptor_8f2de2571cb9c61f, []int{10}
 }
 
 func (m *StreamResponse) XXX_Unmarshal(b []byte) error {
@@ -494,16 +644,445 @@ func (m *StreamResponse) GetStats() []*Snapshot {
 	return nil
 }
 
+type AggregateRequest struct {
+	// If set, only aggregate services matching the filter
+	Service *Service `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	// Unix timestamp the range starts, 5 minutes ago if not set
+	From int64 `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"`
+	// Unix timestamp the range ends, now if not set
+	To int64 `protobuf:"varint,3,opt,name=to,proto3" json:"to,omitempty"`
+	// Roll up the nodes of each service, each version of a service or the cluster
+	// e.g service, version or cluster, each service if not set
+	GroupBy              string   `protobuf:"bytes,4,opt,name=group_by,json=groupBy,proto3" json:"group_by,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AggregateRequest) Reset()         { *m = AggregateRequest{} }
+func (m *AggregateRequest) String() string { return proto.CompactTextString(m) }
+func (*AggregateRequest) ProtoMessage()    {}
+func (*AggregateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8f2de2571cb9c61f, []int{11}
+}
+
+func (m *AggregateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AggregateRequest.Unmarshal(m, b)
+}
+func (m *AggregateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AggregateRequest.Marshal(b, m, deterministic)
+}
+func (m *AggregateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AggregateRequest.Merge(m, src)
+}
+func (m *AggregateRequest) XXX_Size() int {
+	return xxx_messageInfo_AggregateRequest.Size(m)
+}
+func (m *AggregateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AggregateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AggregateRequest proto.InternalMessageInfo
+
+func (m *AggregateRequest) GetService() *Service {
+	if m != nil {
+		return m.Service
+	}
+	return nil
+}
+
+func (m *AggregateRequest) GetFrom() int64 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *AggregateRequest) GetTo() int64 {
+	if m != nil {
+		return m.To
+	}
+	return 0
+}
+
+func (m *AggregateRequest) GetGroupBy() string {
+	if m != nil {
+		return m.GroupBy
+	}
+	return ""
+}
+
+// Aggregate of the snapshots of the nodes rolled up over the range
+type Aggregate struct {
+	// Service name, empty if the cluster is rolled up
+	Service string `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	// Service version, empty unless the versions are rolled up
+	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	// Number of nodes rolled up
+	Nodes uint64 `protobuf:"varint,3,opt,name=nodes,proto3" json:"nodes,omitempty"`
+	// Requests served in the range
+	Requests uint64 `protobuf:"varint,4,opt,name=requests,proto3" json:"requests,omitempty"`
+	// Errors in the range
+	Errors uint64 `protobuf:"varint,5,opt,name=errors,proto3" json:"errors,omitempty"`
+	// Requests per second of the nodes together
+	RequestRate float64 `protobuf:"fixed64,6,opt,name=request_rate,json=requestRate,proto3" json:"request_rate,omitempty"`
+	// Fraction of the requests which errored
+	ErrorRate float64 `protobuf:"fixed64,7,opt,name=error_rate,json=errorRate,proto3" json:"error_rate,omitempty"`
+	// Average of the last heap allocated by each node in bytes
+	Memory uint64 `protobuf:"varint,8,opt,name=memory,proto3" json:"memory,omitempty"`
+	// Average of the last number of goroutines of each node
+	Threads              uint64   `protobuf:"varint,9,opt,name=threads,proto3" json:"threads,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Aggregate) Reset()         { *m = Aggregate{} }
+func (m *Aggregate) String() string { return proto.CompactTextString(m) }
+func (*Aggregate) ProtoMessage()    {}
+func (*Aggregate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8f2de2571cb9c61f, []int{12}
+}
+
+func (m *Aggregate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Aggregate.Unmarshal(m, b)
+}
+func (m *Aggregate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Aggregate.Marshal(b, m, deterministic)
+}
+func (m *Aggregate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Aggregate.Merge(m, src)
+}
+func (m *Aggregate) XXX_Size() int {
+	return xxx_messageInfo_Aggregate.Size(m)
+}
+func (m *Aggregate) XXX_DiscardUnknown() {
+	xxx_messageInfo_Aggregate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Aggregate proto.InternalMessageInfo
+
+func (m *Aggregate) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
+}
+
+func (m *Aggregate) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *Aggregate) GetNodes() uint64 {
+	if m != nil {
+		return m.Nodes
+	}
+	return 0
+}
+
+func (m *Aggregate) GetRequests() uint64 {
+	if m != nil {
+		return m.Requests
+	}
+	return 0
+}
+
+func (m *Aggregate) GetErrors() uint64 {
+	if m != nil {
+		return m.Errors
+	}
+	return 0
+}
+
+func (m *Aggregate) GetRequestRate() float64 {
+	if m != nil {
+		return m.RequestRate
+	}
+	return 0
+}
+
+func (m *Aggregate) GetErrorRate() float64 {
+	if m != nil {
+		return m.ErrorRate
+	}
+	return 0
+}
+
+func (m *Aggregate) GetMemory() uint64 {
+	if m != nil {
+		return m.Memory
+	}
+	return 0
+}
+
+func (m *Aggregate) GetThreads() uint64 {
+	if m != nil {
+		return m.Threads
+	}
+	return 0
+}
+
+type AggregateResponse struct {
+	Aggregates           []*Aggregate `protobuf:"bytes,1,rep,name=aggregates,proto3" json:"aggregates,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *AggregateResponse) Reset()         { *m = AggregateResponse{} }
+func (m *AggregateResponse) String() string { return proto.CompactTextString(m) }
+func (*AggregateResponse) ProtoMessage()    {}
+func (*AggregateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8f2de2571cb9c61f, []int{13}
+}
+
+func (m *AggregateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AggregateResponse.Unmarshal(m, b)
+}
+func (m *AggregateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AggregateResponse.Marshal(b, m, deterministic)
+}
+func (m *AggregateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AggregateResponse.Merge(m, src)
+}
+func (m *AggregateResponse) XXX_Size() int {
+	return xxx_messageInfo_AggregateResponse.Size(m)
+}
+func (m *AggregateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AggregateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AggregateResponse proto.InternalMessageInfo
+
+func (m *AggregateResponse) GetAggregates() []*Aggregate {
+	if m != nil {
+		return m.Aggregates
+	}
+	return nil
+}
+
+type AlertsRequest struct {
+	// If set, only return the alerts of services matching the filter
+	Service *Service `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	// Include the alerts pending for the duration of their rule
+	Pending              bool     `protobuf:"varint,2,opt,name=pending,proto3" json:"pending,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AlertsRequest) Reset()         { *m = AlertsRequest{} }
+func (m *AlertsRequest) String() string { return proto.CompactTextString(m) }
+func (*AlertsRequest) ProtoMessage()    {}
+func (*AlertsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8f2de2571cb9c61f, []int{14}
+}
+
+func (m *AlertsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AlertsRequest.Unmarshal(m, b)
+}
+func (m *AlertsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AlertsRequest.Marshal(b, m, deterministic)
+}
+func (m *AlertsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AlertsRequest.Merge(m, src)
+}
+func (m *AlertsRequest) XXX_Size() int {
+	return xxx_messageInfo_AlertsRequest.Size(m)
+}
+func (m *AlertsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AlertsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AlertsRequest proto.InternalMessageInfo
+
+func (m *AlertsRequest) GetService() *Service {
+	if m != nil {
+		return m.Service
+	}
+	return nil
+}
+
+func (m *AlertsRequest) GetPending() bool {
+	if m != nil {
+		return m.Pending
+	}
+	return false
+}
+
+// Alert raised by a rule for a service
+type Alert struct {
+	// Rule which raised the alert e.g error_rate > 5% for 5m
+	Rule string `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	// Service the alert was raised for
+	Service string `protobuf:"bytes,2,opt,name=service,proto3" json:"service,omitempty"`
+	// State of the alert e.g pending, firing, resolved
+	State string `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	// Value of the metric of the rule when last evaluated
+	Value float64 `protobuf:"fixed64,4,opt,name=value,proto3" json:"value,omitempty"`
+	// Threshold of the rule
+	Threshold float64 `protobuf:"fixed64,5,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	// Unix timestamp the rule first matched
+	Started int64 `protobuf:"varint,6,opt,name=started,proto3" json:"started,omitempty"`
+	// Unix timestamp the alert fired, 0 if pending
+	Fired int64 `protobuf:"varint,7,opt,name=fired,proto3" json:"fired,omitempty"`
+	// Unix timestamp the alert resolved, 0 unless resolved
+	Resolved             int64    `protobuf:"varint,8,opt,name=resolved,proto3" json:"resolved,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Alert) Reset()         { *m = Alert{} }
+func (m *Alert) String() string { return proto.CompactTextString(m) }
+func (*Alert) ProtoMessage()    {}
+func (*Alert) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8f2de2571cb9c61f, []int{15}
+}
+
+func (m *Alert) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Alert.Unmarshal(m, b)
+}
+func (m *Alert) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Alert.Marshal(b, m, deterministic)
+}
+func (m *Alert) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Alert.Merge(m, src)
+}
+func (m *Alert) XXX_Size() int {
+	return xxx_messageInfo_Alert.Size(m)
+}
+func (m *Alert) XXX_DiscardUnknown() {
+	xxx_messageInfo_Alert.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Alert proto.InternalMessageInfo
+
+func (m *Alert) GetRule() string {
+	if m != nil {
+		return m.Rule
+	}
+	return ""
+}
+
+func (m *Alert) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
+}
+
+func (m *Alert) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *Alert) GetValue() float64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+func (m *Alert) GetThreshold() float64 {
+	if m != nil {
+		return m.Threshold
+	}
+	return 0
+}
+
+func (m *Alert) GetStarted() int64 {
+	if m != nil {
+		return m.Started
+	}
+	return 0
+}
+
+func (m *Alert) GetFired() int64 {
+	if m != nil {
+		return m.Fired
+	}
+	return 0
+}
+
+func (m *Alert) GetResolved() int64 {
+	if m != nil {
+		return m.Resolved
+	}
+	return 0
+}
+
+type AlertsResponse struct {
+	Alerts []*Alert `protobuf:"bytes,1,rep,name=alerts,proto3" json:"alerts,omitempty"`
+	// Rules evaluated by the service
+	Rules                []string `protobuf:"bytes,2,rep,name=rules,proto3" json:"rules,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AlertsResponse) Reset()         { *m = AlertsResponse{} }
+func (m *AlertsResponse) String() string { return proto.CompactTextString(m) }
+func (*AlertsResponse) ProtoMessage()    {}
+func (*AlertsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8f2de2571cb9c61f, []int{16}
+}
+
+func (m *AlertsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AlertsResponse.Unmarshal(m, b)
+}
+func (m *AlertsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AlertsResponse.Marshal(b, m, deterministic)
+}
+func (m *AlertsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AlertsResponse.Merge(m, src)
+}
+func (m *AlertsResponse) XXX_Size() int {
+	return xxx_messageInfo_AlertsResponse.Size(m)
+}
+func (m *AlertsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AlertsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AlertsResponse proto.InternalMessageInfo
+
+func (m *AlertsResponse) GetAlerts() []*Alert {
+	if m != nil {
+		return m.Alerts
+	}
+	return nil
+}
+
+func (m *AlertsResponse) GetRules() []string {
+	if m != nil {
+		return m.Rules
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Service)(nil), "go.micro.debug.stats.Service")
 	proto.RegisterType((*Node)(nil), "go.micro.debug.stats.Node")
 	proto.RegisterType((*Snapshot)(nil), "go.micro.debug.stats.Snapshot")
+	proto.RegisterType((*Endpoint)(nil), "go.micro.debug.stats.Endpoint")
+	proto.RegisterType((*Latency)(nil), "go.micro.debug.stats.Latency")
 	proto.RegisterType((*ReadRequest)(nil), "go.micro.debug.stats.ReadRequest")
 	proto.RegisterType((*ReadResponse)(nil), "go.micro.debug.stats.ReadResponse")
 	proto.RegisterType((*WriteRequest)(nil), "go.micro.debug.stats.WriteRequest")
 	proto.RegisterType((*WriteResponse)(nil), "go.micro.debug.stats.WriteResponse")
 	proto.RegisterType((*StreamRequest)(nil), "go.micro.debug.stats.StreamRequest")
 	proto.RegisterType((*StreamResponse)(nil), "go.micro.debug.stats.StreamResponse")
+	proto.RegisterType((*AggregateRequest)(nil), "go.micro.debug.stats.AggregateRequest")
+	proto.RegisterType((*Aggregate)(nil), "go.micro.debug.stats.Aggregate")
+	proto.RegisterType((*AggregateResponse)(nil), "go.micro.debug.stats.AggregateResponse")
+	proto.RegisterType((*AlertsRequest)(nil), "go.micro.debug.stats.AlertsRequest")
+	proto.RegisterType((*Alert)(nil), "go.micro.debug.stats.Alert")
+	proto.RegisterType((*AlertsResponse)(nil), "go.micro.debug.stats.AlertsResponse")
 }
 
 func init() {
@@ -511,35 +1090,60 @@ func init() {
 }
 
 var fileDescriptor_8f2de2571cb9c61f = []byte{
-	// 465 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xa4, 0x54, 0x5d, 0x8b, 0xd3, 0x40,
-	0x14, 0xa5, 0x6d, 0xfa, 0x75, 0xbb, 0xbb, 0xc2, 0x20, 0x32, 0x04, 0x15, 0x1d, 0x7d, 0x10, 0x84,
-	0x74, 0xa9, 0x82, 0x7f, 0x40, 0x7c, 0x53, 0x64, 0x8a, 0x08, 0xbe, 0x65, 0x93, 0xbb, 0xd9, 0x3c,
-	0x24, 0x13, 0x67, 0x26, 0x0b, 0x3e, 0xf8, 0x03, 0xfc, 0xd5, 0x3a, 0x73, 0x67, 0xb2, 0x5b, 0xa1,
-	0x2d, 0x62, 0xdf, 0xee, 0x3d, 0x73, 0xee, 0x39, 0xf7, 0x23, 0x2d, 0xbc, 0x2e, 0x74, 0x7f, 0x6d,
-	0x51, 0xaf, 0x9b, 0xba, 0xd0, 0x6a, 0x5d, 0xe2, 0x55, 0x5f, 0xad, 0x8d, 0xcd, 0xad, 0x59, 0x77,
-	0x5a, 0xd9, 0x88, 0x64, 0x14, 0xb3, 0x87, 0x95, 0xca, 0x88, 0x97, 0x05, 0x94, 0x78, 0xa2, 0x82,
-	0xf9, 0x16, 0xf5, 0x6d, 0x5d, 0x20, 0x63, 0x90, 0xb4, 0x79, 0x83, 0x7c, 0xf4, 0x6c, 0xf4, 0x6a,
-	0x29, 0x29, 0x66, 0x1c, 0xe6, 0xb7, 0xa8, 0x4d, 0xad, 0x5a, 0x3e, 0x26, 0x78, 0x48, 0x59, 0xe6,
-	0xd8, 0xaa, 0x44, 0x3e, 0x71, 0xf0, 0x6a, 0x93, 0x66, 0xfb, 0xd4, 0xb3, 0x4f, 0x8e, 0x21, 0x89,
-	0x27, 0x2e, 0x21, 0xf1, 0x19, 0xbb, 0x80, 0x71, 0x5d, 0x46, 0x0f, 0x17, 0x79, 0x87, 0xbc, 0x2c,
-	0x35, 0x1a, 0x33, 0x38, 0xc4, 0x54, 0xfc, 0x1a, 0xc3, 0x62, 0xdb, 0xe6, 0x9d, 0xb9, 0x51, 0x96,
-	0xbd, 0x83, 0xb9, 0x09, 0x7d, 0x52, 0xed, 0x6a, 0xf3, 0x64, 0xbf, 0x63, 0x1c, 0x46, 0x0e, 0x6c,
-	0xaf, 0xef, 0x5e, 0xb4, 0xc5, 0x92, 0xf4, 0x27, 0x72, 0x48, 0xd9, 0x23, 0x98, 0xf5, 0x9d, 0xad,
-	0x9b, 0x30, 0x43, 0x22, 0x63, 0xe6, 0xf1, 0x06, 0x1b, 0xa5, 0x7f, 0xf0, 0x24, 0xe0, 0x21, 0xf3,
-	0x4a, 0xf6, 0x46, 0x63, 0x5e, 0x1a, 0x3e, 0xa5, 0x87, 0x21, 0xf5, 0x33, 0x55, 0x05, 0x9f, 0x11,
-	0xe8, 0x22, 0x96, 0xc2, 0x42, 0xe3, 0xf7, 0x1e, 0x8d, 0x35, 0x7c, 0x4e, 0xe8, 0x5d, 0xee, 0xd5,
-	0x51, 0x6b, 0xa5, 0x0d, 0x5f, 0x04, 0xf5, 0x90, 0xb1, 0xc7, 0xb0, 0xf4, 0xee, 0xae, 0xb9, 0xa6,
-	0xe3, 0x4b, 0x7a, 0xba, 0x07, 0xc4, 0x37, 0x58, 0x49, 0x67, 0x25, 0x83, 0xca, 0xff, 0x6f, 0xc3,
-	0xdd, 0xb8, 0xcb, 0x8d, 0xa5, 0x55, 0x2c, 0x24, 0xc5, 0xe2, 0x3d, 0x9c, 0x05, 0x6d, 0xd3, 0xa9,
-	0xd6, 0x20, 0x7b, 0x0b, 0x53, 0xaa, 0x76, 0xd2, 0x13, 0x27, 0xfd, 0xf4, 0x80, 0x74, 0xbc, 0x8c,
-	0x0c, 0x64, 0xf1, 0x13, 0xce, 0xbe, 0xea, 0xda, 0xe2, 0xc9, 0x2d, 0xde, 0xd9, 0x8f, 0xa9, 0xec,
-	0x1f, 0xed, 0x1f, 0xc0, 0x79, 0xb4, 0x0f, 0x53, 0x88, 0x6b, 0x38, 0xdf, 0x5a, 0x77, 0x9e, 0xe6,
-	0xe4, 0x86, 0xdc, 0x65, 0xfc, 0x6f, 0xc1, 0x74, 0xb9, 0x2b, 0x0d, 0xdf, 0xe8, 0x3d, 0x20, 0x3e,
-	0xc0, 0xc5, 0xe0, 0x73, 0xca, 0xfe, 0x36, 0xbf, 0x47, 0x30, 0xdd, 0xfa, 0x88, 0x7d, 0x84, 0xc4,
-	0xdf, 0x83, 0x3d, 0xdf, 0x5f, 0xb8, 0xf3, 0x1d, 0xa4, 0xe2, 0x18, 0x25, 0xb6, 0xf3, 0x19, 0xa6,
-	0xb4, 0x19, 0x76, 0x80, 0xbc, 0x7b, 0xb5, 0xf4, 0xc5, 0x51, 0x4e, 0x54, 0xfc, 0x02, 0xb3, 0x30,
-	0x32, 0x3b, 0x40, 0xff, 0x6b, 0xf1, 0xe9, 0xcb, 0xe3, 0xa4, 0x20, 0x7a, 0x39, 0xba, 0x9a, 0xd1,
-	0xff, 0xd4, 0x9b, 0x3f, 0x01, 0x00, 0x00, 0xff, 0xff, 0x8b, 0xbf, 0x54, 0x1e, 0xd6, 0x04, 0x00,
-	0x00,
+	// 874 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xad, 0x56, 0xdf, 0x6b, 0xd4, 0x40,
+	0x10, 0x26, 0x97, 0xfb, 0x95, 0xb9, 0xb6, 0xd6, 0xa5, 0x48, 0x3c, 0xad, 0xb6, 0x51, 0x54, 0x10,
+	0xee, 0x4a, 0xab, 0x48, 0x41, 0x28, 0x15, 0xf5, 0x49, 0x45, 0xf6, 0x14, 0x1f, 0x14, 0x4a, 0xee,
+	0xb2, 0x97, 0x06, 0xee, 0x92, 0xb8, 0x9b, 0x2b, 0xf4, 0x41, 0xf0, 0x59, 0xff, 0x1b, 0xff, 0x0d,
+	0xff, 0x26, 0xc1, 0xdd, 0xd9, 0xcd, 0x5d, 0x02, 0x97, 0xab, 0x5a, 0xdf, 0x76, 0xbe, 0xfd, 0x76,
+	0x66, 0x76, 0x66, 0xf6, 0x4b, 0xe0, 0xe1, 0x88, 0xcf, 0xc6, 0x19, 0xe3, 0xfd, 0x69, 0x34, 0xe2,
+	0x49, 0x3f, 0x60, 0xc3, 0x59, 0xd8, 0x17, 0x99, 0x9f, 0x89, 0x7e, 0xca, 0x93, 0xcc, 0x20, 0x3d,
+	0x5c, 0x93, 0xad, 0x30, 0xe9, 0x21, 0xaf, 0xa7, 0x51, 0xe4, 0x79, 0x21, 0xb4, 0x06, 0x8c, 0x9f,
+	0x45, 0x23, 0x46, 0x08, 0xd4, 0x63, 0x7f, 0xca, 0x5c, 0x6b, 0xc7, 0x7a, 0xe0, 0x50, 0x5c, 0x13,
+	0x17, 0x5a, 0x67, 0x8c, 0x8b, 0x28, 0x89, 0xdd, 0x1a, 0xc2, 0xb9, 0x49, 0x7a, 0x92, 0x9d, 0x04,
+	0xcc, 0xb5, 0x25, 0xdc, 0xd9, 0xef, 0xf6, 0x96, 0x79, 0xef, 0xbd, 0x91, 0x0c, 0x8a, 0x3c, 0x6f,
+	0x0f, 0xea, 0xca, 0x22, 0x1b, 0x50, 0x8b, 0x02, 0x13, 0x43, 0xae, 0x54, 0x04, 0x3f, 0x08, 0x38,
+	0x13, 0x22, 0x8f, 0x60, 0x4c, 0xef, 0xab, 0x0d, 0xed, 0x41, 0xec, 0xa7, 0xe2, 0x34, 0xc9, 0xc8,
+	0x13, 0x68, 0x09, 0x9d, 0x27, 0x9e, 0xed, 0xec, 0x6f, 0x2f, 0x8f, 0x68, 0x2e, 0x43, 0x73, 0xb6,
+	0xf2, 0x2f, 0x77, 0x78, 0xc6, 0x02, 0xf4, 0x6f, 0xd3, 0xdc, 0x24, 0xd7, 0xa0, 0x39, 0x4b, 0xb3,
+	0x68, 0xaa, 0xef, 0x50, 0xa7, 0xc6, 0x52, 0xf8, 0x94, 0x4d, 0x13, 0x7e, 0xee, 0xd6, 0x35, 0xae,
+	0x2d, 0xe5, 0x29, 0x3b, 0xe5, 0xcc, 0x0f, 0x84, 0xdb, 0xc0, 0x8d, 0xdc, 0x54, 0x77, 0x0a, 0x47,
+	0x6e, 0x13, 0x41, 0xb9, 0x22, 0x5d, 0x68, 0x73, 0xf6, 0x79, 0xc6, 0x44, 0x26, 0xdc, 0x16, 0xa2,
+	0x73, 0x5b, 0x79, 0x67, 0x9c, 0x27, 0x5c, 0xb8, 0x6d, 0xed, 0x5d, 0x5b, 0xe4, 0x26, 0x38, 0x2a,
+	0xba, 0x4c, 0x6e, 0x9a, 0xba, 0x0e, 0x6e, 0x2d, 0x00, 0xf2, 0x14, 0x1c, 0x16, 0x07, 0x69, 0x12,
+	0xc5, 0xd2, 0x25, 0xec, 0xd8, 0xb2, 0x00, 0xb7, 0x96, 0x17, 0xe0, 0x85, 0xa1, 0xd1, 0xc5, 0x01,
+	0x55, 0xbc, 0x89, 0x9f, 0xb1, 0x78, 0x74, 0xee, 0x76, 0x56, 0x15, 0xef, 0x95, 0x26, 0xd1, 0x9c,
+	0xed, 0x7d, 0xb7, 0xa0, 0x9d, 0x3b, 0x5c, 0x3a, 0x1f, 0xc5, 0x9b, 0xd6, 0x2a, 0x6f, 0x6a, 0x97,
+	0x6e, 0x5a, 0xc8, 0xa6, 0xfe, 0x57, 0xd9, 0x1c, 0x41, 0xcb, 0x60, 0x64, 0x13, 0xec, 0xf4, 0xf1,
+	0x1e, 0xa6, 0x62, 0x51, 0xb5, 0x44, 0xe4, 0x70, 0x0f, 0x93, 0x50, 0xc8, 0xa1, 0x41, 0x0e, 0x31,
+	0x38, 0x22, 0x87, 0x5e, 0x0a, 0x1d, 0x2a, 0x1b, 0x46, 0x75, 0x86, 0xff, 0x3e, 0x53, 0xb2, 0x12,
+	0xa9, 0x2f, 0x32, 0x0c, 0xd6, 0xa6, 0xb8, 0x26, 0x5b, 0xd0, 0x10, 0x51, 0x3c, 0xd2, 0xc3, 0x64,
+	0x53, 0x6d, 0x78, 0xcf, 0x61, 0x4d, 0x47, 0x14, 0x69, 0x12, 0x0b, 0x46, 0x1e, 0x49, 0x96, 0xf2,
+	0x29, 0x03, 0xae, 0xe8, 0x61, 0x3e, 0xf5, 0x54, 0x93, 0xbd, 0x2f, 0xb0, 0xf6, 0x81, 0x47, 0x19,
+	0xbb, 0x74, 0xe2, 0xf3, 0xf0, 0x35, 0x3c, 0xf6, 0x87, 0xe1, 0xaf, 0xc0, 0xba, 0x09, 0xaf, 0x6f,
+	0xe1, 0x8d, 0x61, 0x7d, 0x90, 0xc9, 0xd1, 0x9f, 0x5e, 0x3a, 0x21, 0x39, 0xf5, 0x6a, 0x8e, 0x44,
+	0xea, 0xcb, 0xa3, 0xfa, 0xfd, 0x2f, 0x00, 0xef, 0x25, 0x6c, 0xe4, 0x71, 0x2e, 0x55, 0xbf, 0x6f,
+	0x16, 0x6c, 0x1e, 0x87, 0x21, 0x67, 0xa1, 0xff, 0x1f, 0x8a, 0x28, 0xbb, 0x3f, 0xe6, 0xc9, 0xd4,
+	0xc8, 0x09, 0xae, 0x95, 0x02, 0x64, 0x89, 0x69, 0xbd, 0x5c, 0x91, 0xeb, 0xd0, 0x0e, 0x79, 0x32,
+	0x4b, 0x4f, 0x86, 0x7a, 0xc8, 0xa5, 0xac, 0xa1, 0xfd, 0xec, 0xdc, 0xfb, 0x65, 0x81, 0x33, 0x4f,
+	0x06, 0xe5, 0xa9, 0x90, 0x85, 0x53, 0x12, 0xae, 0x0a, 0xe9, 0x95, 0xa3, 0xa6, 0x24, 0x35, 0x7f,
+	0x57, 0xda, 0x28, 0x3d, 0xc5, 0x7a, 0xe5, 0x53, 0x6c, 0x94, 0x9e, 0xe2, 0x2e, 0xac, 0x19, 0xce,
+	0x09, 0x97, 0xd9, 0xa0, 0x84, 0x59, 0xb4, 0x63, 0x30, 0xaa, 0x12, 0xdc, 0x06, 0x40, 0xb2, 0x26,
+	0xb4, 0x90, 0xe0, 0x20, 0x82, 0xdb, 0x0b, 0xb1, 0x6c, 0x57, 0x89, 0xa5, 0x53, 0x12, 0x4b, 0xef,
+	0x1d, 0x5c, 0x2d, 0xf4, 0xc2, 0xf4, 0xf5, 0x08, 0xc0, 0xcf, 0xc1, 0xbc, 0xb9, 0xb7, 0x97, 0xf7,
+	0x63, 0x71, 0xb8, 0x70, 0xc4, 0x1b, 0xc2, 0xfa, 0xf1, 0x84, 0xf1, 0x4c, 0x5c, 0xba, 0xbd, 0x32,
+	0xf3, 0x54, 0x4a, 0x67, 0x14, 0x87, 0xe6, 0x7d, 0xe7, 0xa6, 0xf7, 0xd3, 0x82, 0x06, 0x06, 0x51,
+	0x23, 0xc0, 0x67, 0x93, 0xb9, 0x14, 0xaa, 0x75, 0xb1, 0x93, 0xb5, 0x72, 0x27, 0xb7, 0xf4, 0xd0,
+	0x6a, 0x69, 0x70, 0xf4, 0x50, 0x22, 0x7a, 0xe6, 0x4f, 0x66, 0x0c, 0x9b, 0x65, 0x51, 0x6d, 0xe0,
+	0x67, 0x40, 0x16, 0x4a, 0x4e, 0xef, 0x24, 0xc0, 0x66, 0xc9, 0x6a, 0xcf, 0x81, 0xe2, 0xc7, 0xac,
+	0x59, 0xfe, 0x98, 0x49, 0x6f, 0xe3, 0x88, 0x4b, 0xbc, 0xa5, 0xe5, 0x07, 0x0d, 0x3d, 0x13, 0x22,
+	0x99, 0x9c, 0xc9, 0x8d, 0x36, 0x6e, 0xcc, 0x6d, 0xef, 0x23, 0x6c, 0xe4, 0x15, 0x33, 0x4d, 0x38,
+	0x80, 0xa6, 0x8f, 0x88, 0x69, 0xc0, 0x8d, 0x8a, 0x06, 0x28, 0x0e, 0x35, 0x54, 0x15, 0x58, 0x5d,
+	0x5f, 0x49, 0x8a, 0xad, 0x2e, 0x87, 0xc6, 0xfe, 0x0f, 0x1b, 0x1a, 0x03, 0xc5, 0x26, 0xaf, 0xa1,
+	0xae, 0x14, 0x90, 0xec, 0x2e, 0x77, 0x56, 0xd0, 0xe3, 0xae, 0xb7, 0x8a, 0x62, 0x72, 0x7c, 0x0b,
+	0x0d, 0xd4, 0x22, 0x52, 0x41, 0x2e, 0xea, 0x64, 0xf7, 0xce, 0x4a, 0x8e, 0xf1, 0xf8, 0x1e, 0x9a,
+	0x5a, 0x64, 0x48, 0x05, 0xbd, 0x24, 0x75, 0xdd, 0xbb, 0xab, 0x49, 0xda, 0xe9, 0x9e, 0x45, 0x3e,
+	0x15, 0x5f, 0xf9, 0xbd, 0x8b, 0x46, 0xd9, 0x38, 0xbf, 0x7f, 0x21, 0xcf, 0x24, 0x3d, 0x80, 0xa6,
+	0x6e, 0x5e, 0x55, 0xd2, 0xa5, 0xc7, 0x50, 0x95, 0x74, 0xb9, 0xff, 0xc3, 0x26, 0xfe, 0x28, 0x1e,
+	0xfc, 0x06, 0x29, 0xd1, 0x82, 0x1e, 0x57, 0x0a, 0x00, 0x00,
 }
@@ -37,6 +37,8 @@ type StatsService interface {
 	Read(ctx context.Context, in *ReadRequest, opts ...client.CallOption) (*ReadResponse, error)
 	Write(ctx context.Context, in *WriteRequest, opts ...client.CallOption) (*WriteResponse, error)
 	Stream(ctx context.Context, in *StreamRequest, opts ...client.CallOption) (Stats_StreamService, error)
+	Aggregate(ctx context.Context, in *AggregateRequest, opts ...client.CallOption) (*AggregateResponse, error)
+	Alerts(ctx context.Context, in *AlertsRequest, opts ...client.CallOption) (*AlertsResponse, error)
 }
 
 type statsService struct {
@@ -121,12 +123,34 @@ func (x *statsServiceStream) Recv() (*StreamResponse, error) {
 	return m, nil
 }
 
+func (c *statsService) Aggregate(ctx context.Context, in *AggregateRequest, opts ...client.CallOption) (*AggregateResponse, error) {
+	req := c.c.NewRequest(c.name, "Stats.Aggregate", in)
+	out := new(AggregateResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statsService) Alerts(ctx context.Context, in *AlertsRequest, opts ...client.CallOption) (*AlertsResponse, error) {
+	req := c.c.NewRequest(c.name, "Stats.Alerts", in)
+	out := new(AlertsResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Stats service
 
 type StatsHandler interface {
 	Read(context.Context, *ReadRequest, *ReadResponse) error
 	Write(context.Context, *WriteRequest, *WriteResponse) error
 	Stream(context.Context, *StreamRequest, Stats_StreamStream) error
+	Aggregate(context.Context, *AggregateRequest, *AggregateResponse) error
+	Alerts(context.Context, *AlertsRequest, *AlertsResponse) error
 }
 
 func RegisterStatsHandler(s server.Server, hdlr StatsHandler, opts ...server.HandlerOption) error {
@@ -134,6 +158,8 @@ func RegisterStatsHandler(s server.Server, hdlr StatsHandler, opts ...server.Han
 		Read(ctx context.Context, in *ReadRequest, out *ReadResponse) error
 		Write(ctx context.Context, in *WriteRequest, out *WriteResponse) error
 		Stream(ctx context.Context, stream server.Stream) error
+		Aggregate(ctx context.Context, in *AggregateRequest, out *AggregateResponse) error
+		Alerts(ctx context.Context, in *AlertsRequest, out *AlertsResponse) error
 	}
 	type Stats struct {
 		stats
@@ -188,3 +214,11 @@ func (x *statsStreamStream) RecvMsg(m interface{}) error {
 func (x *statsStreamStream) Send(m *StreamResponse) error {
 	return x.stream.Send(m)
 }
+
+func (h *statsHandler) Aggregate(ctx context.Context, in *AggregateRequest, out *AggregateResponse) error {
+	return h.StatsHandler.Aggregate(ctx, in, out)
+}
+
+func (h *statsHandler) Alerts(ctx context.Context, in *AlertsRequest, out *AlertsResponse) error {
+	return h.StatsHandler.Alerts(ctx, in, out)
+}
@@ -0,0 +1,177 @@
+// Package recorder records the requests, errors and latency of each endpoint of a service
+// which Debug.Stats doesn't report, and pushes them to the debug stats service
+package recorder
+
+import (
+	"context"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/micro/go-micro/v2/client"
+	"github.com/micro/go-micro/v2/server"
+	"github.com/micro/go-micro/v2/util/log"
+	stats "github.com/micro/micro/v2/debug/stats/proto"
+)
+
+var (
+	// Name of the debug stats service the snapshots are pushed to
+	Name = "go.micro.debug.stats"
+	// Samples is the number of recent latencies of each endpoint its percentiles are computed from
+	Samples = 1024
+)
+
+// endpoint is the requests recorded of an endpoint
+type endpoint struct {
+	requests uint64
+	errors   uint64
+	// recent latencies in milliseconds, next is the oldest once it's full
+	latencies []float64
+	next      int
+}
+
+// Recorder of the requests to each endpoint of a service
+type Recorder struct {
+	sync.Mutex
+	started   time.Time
+	endpoints map[string]*endpoint
+}
+
+// New returns a recorder, its HandlerWrapper records the requests
+func New() *Recorder {
+	return &Recorder{
+		started:   time.Now(),
+		endpoints: make(map[string]*endpoint),
+	}
+}
+
+// HandlerWrapper records the requests to each endpoint of the server
+func (r *Recorder) HandlerWrapper() server.HandlerWrapper {
+	return func(h server.HandlerFunc) server.HandlerFunc {
+		return func(ctx context.Context, req server.Request, rsp interface{}) error {
+			start := time.Now()
+			err := h(ctx, req, rsp)
+			r.Record(req.Endpoint(), time.Since(start), err)
+			return err
+		}
+	}
+}
+
+// Record a request to the endpoint which took the duration
+func (r *Recorder) Record(name string, d time.Duration, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	e, ok := r.endpoints[name]
+	if !ok {
+		e = new(endpoint)
+		r.endpoints[name] = e
+	}
+
+	e.requests++
+	if err != nil {
+		e.errors++
+	}
+
+	ms := float64(d) / float64(time.Millisecond)
+	if len(e.latencies) < Samples {
+		e.latencies = append(e.latencies, ms)
+		return
+	}
+	e.latencies[e.next] = ms
+	e.next = (e.next + 1) % len(e.latencies)
+}
+
+// percentile of the sorted latencies by the nearest rank
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	i := int(p*float64(len(sorted))+0.5) - 1
+	if i < 0 {
+		i = 0
+	}
+	if i >= len(sorted) {
+		i = len(sorted) - 1
+	}
+	return sorted[i]
+}
+
+// latency percentiles of the latencies
+func latency(latencies []float64) *stats.Latency {
+	if len(latencies) == 0 {
+		return nil
+	}
+	sorted := append([]float64(nil), latencies...)
+	sort.Float64s(sorted)
+	return &stats.Latency{
+		P50: percentile(sorted, 0.5),
+		P90: percentile(sorted, 0.9),
+		P99: percentile(sorted, 0.99),
+	}
+}
+
+// Snapshot of the node of the service
+func (r *Recorder) Snapshot(service *stats.Service) *stats.Snapshot {
+	var mstat runtime.MemStats
+	runtime.ReadMemStats(&mstat)
+
+	now := time.Now()
+	snap := &stats.Snapshot{
+		Service:   service,
+		Started:   r.started.Unix(),
+		Uptime:    uint64(now.Sub(r.started).Seconds()),
+		Memory:    mstat.Alloc,
+		Threads:   uint64(runtime.NumGoroutine()),
+		Gc:        mstat.PauseTotalNs,
+		Timestamp: uint64(now.Unix()),
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	var all []float64
+	for name, e := range r.endpoints {
+		snap.Requests += e.requests
+		snap.Errors += e.errors
+		snap.Endpoints = append(snap.Endpoints, &stats.Endpoint{
+			Name:     name,
+			Requests: e.requests,
+			Errors:   e.errors,
+			Latency:  latency(e.latencies),
+		})
+		all = append(all, e.latencies...)
+	}
+	snap.Latency = latency(all)
+
+	sort.Slice(snap.Endpoints, func(i, j int) bool {
+		return snap.Endpoints[i].Name < snap.Endpoints[j].Name
+	})
+
+	return snap
+}
+
+// Push a snapshot of the node of the service to the debug stats service every interval
+// until the done channel is closed, for services which can't be scraped
+func (r *Recorder) Push(c client.Client, service *stats.Service, interval time.Duration, done <-chan bool) {
+	st := stats.NewStatsService(Name, c)
+
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-t.C:
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), interval)
+		_, err := st.Write(ctx, &stats.WriteRequest{Stats: r.Snapshot(service)})
+		cancel()
+		if err != nil {
+			log.Errorf("Error pushing the stats of %s: %v", service.Name, err)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package recorder
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	stats "github.com/micro/micro/v2/debug/stats/proto"
+)
+
+func TestRecorder(t *testing.T) {
+	r := New()
+	for i := 1; i <= 100; i++ {
+		r.Record("Greeter.Hello", time.Duration(i)*time.Millisecond, nil)
+	}
+	r.Record("Greeter.Bye", time.Second, errors.New("failed"))
+
+	snap := r.Snapshot(&stats.Service{Name: "go.micro.srv.greeter"})
+	if snap.Requests != 101 || snap.Errors != 1 {
+		t.Fatalf("expected 101 requests and 1 error got %d and %d", snap.Requests, snap.Errors)
+	}
+	if len(snap.Endpoints) != 2 || snap.Endpoints[0].Name != "Greeter.Bye" {
+		t.Fatalf("expected the endpoints sorted by name got %v", snap.Endpoints)
+	}
+
+	hello := snap.Endpoints[1].Latency
+	if hello.P50 != 50 || hello.P90 != 90 || hello.P99 != 99 {
+		t.Fatalf("expected the percentiles 50, 90 and 99 got %v", hello)
+	}
+}
+
+func TestRecorderSamples(t *testing.T) {
+	Samples = 10
+	defer func() { Samples = 1024 }()
+
+	r := New()
+	for i := 1; i <= 20; i++ {
+		r.Record("Greeter.Hello", time.Duration(i)*time.Millisecond, nil)
+	}
+
+	// only the last 10 latencies are kept
+	if l := r.Snapshot(&stats.Service{}).Endpoints[0].Latency; l.P50 != 15 {
+		t.Fatalf("expected the median of the recent latencies to be 15 got %v", l.P50)
+	}
+}
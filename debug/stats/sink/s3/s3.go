@@ -0,0 +1,146 @@
+// Package s3 writes snapshots to s3 compatible storage as parquet files
+package s3
+
+import (
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	stats "github.com/micro/micro/v2/debug/stats/proto"
+	"github.com/micro/micro/v2/debug/stats/sink"
+	"github.com/micro/micro/v2/internal/parquet"
+	bs3 "github.com/micro/micro/v2/internal/s3"
+)
+
+// columns of the parquet files, a row per snapshot
+var columns = []parquet.Column{
+	{Name: "service", Type: parquet.String},
+	{Name: "version", Type: parquet.String},
+	{Name: "node_id", Type: parquet.String},
+	{Name: "node_address", Type: parquet.String},
+	{Name: "timestamp", Type: parquet.Int64},
+	{Name: "started", Type: parquet.Int64},
+	{Name: "uptime", Type: parquet.Int64},
+	{Name: "memory", Type: parquet.Int64},
+	{Name: "threads", Type: parquet.Int64},
+	{Name: "gc", Type: parquet.Int64},
+	{Name: "requests", Type: parquet.Int64},
+	{Name: "errors", Type: parquet.Int64},
+	{Name: "latency_p50", Type: parquet.Double},
+	{Name: "latency_p90", Type: parquet.Double},
+	{Name: "latency_p99", Type: parquet.Double},
+}
+
+type s3 struct {
+	client   *bs3.Client
+	interval time.Duration
+
+	sync.Mutex
+	table   *parquet.Writer
+	flushed time.Time
+}
+
+func init() {
+	sink.Register("s3", func() sink.Sink {
+		return new(s3)
+	})
+}
+
+// Init from a url e.g s3://bucket/prefix?region=eu-west-1&interval=5m
+// Credentials are read from AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY.
+// Set endpoint to use s3 compatible storage e.g endpoint=http://localhost:9000
+func (s *s3) Init(u *url.URL) error {
+	s.interval = time.Minute
+	if i := u.Query().Get("interval"); len(i) > 0 {
+		d, err := time.ParseDuration(i)
+		if err != nil {
+			return fmt.Errorf("invalid s3 interval: %v", err)
+		}
+		s.interval = d
+	}
+
+	client, err := bs3.New(u)
+	if err != nil {
+		return fmt.Errorf("s3 sink: %v", err)
+	}
+
+	s.client = client
+	s.flushed = time.Now()
+
+	return nil
+}
+
+// Write buffers the snapshots and uploads an object every interval
+func (s *s3) Write(snaps []*stats.Snapshot) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.table == nil {
+		s.table = parquet.NewWriter(columns...)
+	}
+
+	for _, snap := range snaps {
+		if err := s.table.Append(row(snap)...); err != nil {
+			return err
+		}
+	}
+
+	if time.Since(s.flushed) < s.interval {
+		return nil
+	}
+
+	return s.flush()
+}
+
+// flush uploads the buffer, it must be called with the lock held
+func (s *s3) flush() error {
+	s.flushed = time.Now()
+
+	if s.table == nil || s.table.Rows() == 0 {
+		return nil
+	}
+
+	body, err := s.table.Bytes()
+	if err != nil {
+		return err
+	}
+	s.table = nil
+
+	// e.g prefix/2020/01/31/15-04-05.parquet
+	key := s.client.Key(time.Now().UTC().Format("2006/01/02/15-04-05") + ".parquet")
+
+	return s.client.Put(key, "application/vnd.apache.parquet", body)
+}
+
+// row returns the values of the snapshot in the order of the columns
+func row(snap *stats.Snapshot) []interface{} {
+	var name, version, id, address string
+	if srv := snap.Service; srv != nil {
+		name, version = srv.Name, srv.Version
+		if srv.Node != nil {
+			id, address = srv.Node.Id, srv.Node.Address
+		}
+	}
+
+	var p50, p90, p99 float64
+	if l := snap.Latency; l != nil {
+		p50, p90, p99 = l.P50, l.P90, l.P99
+	}
+
+	return []interface{}{
+		name, version, id, address,
+		snap.Timestamp, snap.Started, snap.Uptime, snap.Memory, snap.Threads, snap.Gc, snap.Requests, snap.Errors,
+		p50, p90, p99,
+	}
+}
+
+func (s *s3) Close() error {
+	s.Lock()
+	defer s.Unlock()
+	return s.flush()
+}
+
+func (s *s3) String() string {
+	return "s3"
+}
@@ -0,0 +1,56 @@
+// Package sink writes stats snapshots to long term storage
+package sink
+
+import (
+	"fmt"
+	"net/url"
+	"sync"
+
+	stats "github.com/micro/micro/v2/debug/stats/proto"
+)
+
+var (
+	sinks = map[string]func() Sink{}
+	mux   sync.Mutex
+)
+
+// Sink is long term storage for snapshots
+type Sink interface {
+	// Init the sink from its url e.g influxdb://localhost:8086/micro
+	Init(u *url.URL) error
+	// Write a batch of snapshots
+	Write([]*stats.Snapshot) error
+	// Close flushes any buffered snapshots
+	Close() error
+	String() string
+}
+
+// Register a sink for the url scheme
+func Register(scheme string, fn func() Sink) {
+	mux.Lock()
+	defer mux.Unlock()
+	sinks[scheme] = fn
+}
+
+// New returns a sink initialised from the url
+func New(addr string) (Sink, error) {
+	u, err := url.Parse(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	mux.Lock()
+	fn, ok := sinks[u.Scheme]
+	mux.Unlock()
+
+	if !ok {
+		return nil, fmt.Errorf("unknown stats sink %s", u.Scheme)
+	}
+
+	s := fn()
+	if err := s.Init(u); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
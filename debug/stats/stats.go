@@ -2,6 +2,8 @@
 package stats
 
 import (
+	"time"
+
 	"github.com/micro/cli/v2"
 	"github.com/micro/go-micro/v2"
 	"github.com/micro/go-micro/v2/util/log"
@@ -19,7 +21,8 @@ func Run(c *cli.Context) {
 	// Create handler
 	done := make(chan bool)
 	defer close(done)
-	h, err := handler.New(done, c.Int("window"))
+	Configure(c)
+	h, err := handler.New(done, Window(c))
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -27,8 +30,94 @@ func Run(c *cli.Context) {
 	// Register Handler
 	stats.RegisterStatsHandler(service.Server(), h)
 
+	if addr := c.String("metrics_address"); len(addr) > 0 {
+		ServeMetrics(addr, h, done)
+	}
+
+	if err := StartAlerts(c, h, done); err != nil {
+		log.Fatal(err)
+	}
+
 	// Run service
 	if err := service.Run(); err != nil {
 		log.Fatal(err)
 	}
 }
+
+// Flags of the stats scraper
+func Flags() []cli.Flag {
+	return []cli.Flag{
+		&cli.IntFlag{
+			Name:    "window",
+			Usage:   "Specifies how many seconds of stats snapshots to retain in memory",
+			EnvVars: []string{"MICRO_DEBUG_WINDOW"},
+			Value:   3600,
+		},
+		&cli.DurationFlag{
+			Name:    "scrape_interval",
+			Usage:   "Set how long after scraping the services they're scraped again e.g 10s",
+			EnvVars: []string{"MICRO_DEBUG_SCRAPE_INTERVAL"},
+			Value:   handler.ScrapeInterval,
+		},
+		&cli.DurationFlag{
+			Name:    "scrape_timeout",
+			Usage:   "Set how long a node has to respond to being scraped",
+			EnvVars: []string{"MICRO_DEBUG_SCRAPE_TIMEOUT"},
+			Value:   handler.ScrapeTimeout,
+		},
+		&cli.DurationFlag{
+			Name:    "retention",
+			Usage:   "Persist the stats snapshots to the store for the duration e.g 168h, they're only kept in memory if it's not set",
+			EnvVars: []string{"MICRO_DEBUG_RETENTION"},
+		},
+		&cli.DurationFlag{
+			Name:    "compact_after",
+			Usage:   "Set how old the persisted snapshots are when only the last snapshot of each node per minute is kept",
+			EnvVars: []string{"MICRO_DEBUG_COMPACT_AFTER"},
+			Value:   handler.CompactAfter,
+		},
+		&cli.StringFlag{
+			Name:    "metrics_address",
+			Usage:   "Serve the stats in the prometheus text format at /metrics of the address e.g :9100",
+			EnvVars: []string{"MICRO_DEBUG_METRICS_ADDRESS"},
+		},
+		&cli.StringSliceFlag{
+			Name:    "alert",
+			Usage:   "Raise an alert while a rule matches e.g 'error_rate > 5% for 5m', 'memory > 512mb on go.micro.srv.foo', 'missing for 1m on go.micro.srv.foo'",
+			EnvVars: []string{"MICRO_DEBUG_ALERT"},
+		},
+		&cli.StringSliceFlag{
+			Name:    "notify",
+			Usage:   "Notify of the alerts as they fire and resolve e.g https://example.com/hook, slack://hooks.slack.com/services/T0/B0/X, broker://go.micro.alerts",
+			EnvVars: []string{"MICRO_DEBUG_NOTIFY"},
+		},
+		&cli.DurationFlag{
+			Name:    "alert_interval",
+			Usage:   "Set how often the alert rules are evaluated",
+			EnvVars: []string{"MICRO_DEBUG_ALERT_INTERVAL"},
+			Value:   handler.AlertInterval,
+		},
+	}
+}
+
+// Configure the scraping of the handler from the flags
+func Configure(c *cli.Context) {
+	if d := c.Duration("scrape_interval"); d > 0 {
+		handler.ScrapeInterval = d
+	}
+	if d := c.Duration("scrape_timeout"); d > 0 {
+		handler.ScrapeTimeout = d
+	}
+	handler.Retention = c.Duration("retention")
+	if d := c.Duration("compact_after"); d > 0 {
+		handler.CompactAfter = d
+	}
+	if d := c.Duration("alert_interval"); d > 0 {
+		handler.AlertInterval = d
+	}
+}
+
+// Window returns the number of scrapes kept in memory for the window flag
+func Window(c *cli.Context) int {
+	return handler.WindowSize(time.Duration(c.Int("window")) * time.Second)
+}
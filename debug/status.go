@@ -0,0 +1,83 @@
+package debug
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/micro/cli/v2"
+	"github.com/micro/go-micro/v2"
+	"github.com/micro/go-micro/v2/client"
+	pbstats "github.com/micro/micro/v2/debug/stats/proto"
+	"github.com/micro/micro/v2/internal/namespace"
+)
+
+// statusFlags are shared by status and top
+func statusFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:    "token",
+			Usage:   "Set the namespace token used to scope the output to a namespace",
+			EnvVars: []string{"MICRO_NAMESPACE_TOKEN"},
+		},
+	}
+}
+
+// readStats reads the current snapshots from the debug service
+func readStats(ctx *cli.Context) ([]*pbstats.Snapshot, error) {
+	c := pbstats.NewStatsService(Name, client.DefaultClient)
+
+	rsp, err := c.Read(namespace.NewContext(context.Background(), ctx.String("token")), &pbstats.ReadRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(rsp.Stats, func(i, j int) bool {
+		a, b := rsp.Stats[i].Service, rsp.Stats[j].Service
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Version < b.Version
+	})
+
+	return rsp.Stats, nil
+}
+
+// writeStatus writes a table of the snapshots
+func writeStatus(w io.Writer, snaps []*pbstats.Snapshot) {
+	writer := tabwriter.NewWriter(w, 0, 8, 1, '\t', 0)
+	fmt.Fprintln(writer, "SERVICE\tVERSION\tNODE\tUPTIME\tMEMORY\tTHREADS\tREQUESTS\tERRORS")
+	for _, s := range snaps {
+		var node string
+		if s.Service.Node != nil {
+			node = s.Service.Node.Id
+		}
+
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%v\t%.2fmb\t%d\t%d\t%d\n",
+			s.Service.Name,
+			s.Service.Version,
+			node,
+			time.Duration(s.Uptime)*time.Second,
+			float64(s.Memory)/(1024.0*1024.0),
+			s.Threads,
+			s.Requests,
+			s.Errors,
+		)
+	}
+	writer.Flush()
+}
+
+// getStatus prints the status of the services in the namespace
+func getStatus(ctx *cli.Context, srvOpts ...micro.Option) {
+	snaps, err := readStats(ctx)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	writeStatus(os.Stdout, snaps)
+}
@@ -0,0 +1,312 @@
+package debug
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/chzyer/readline"
+	"github.com/micro/cli/v2"
+	"github.com/micro/go-micro/v2"
+	"github.com/micro/go-micro/v2/client"
+	pbstats "github.com/micro/micro/v2/debug/stats/proto"
+	"github.com/micro/micro/v2/internal/namespace"
+)
+
+// TopFlags are the flags of the stats dashboard
+func TopFlags() []cli.Flag {
+	return append(statusFlags(),
+		&cli.DurationFlag{
+			Name:  "interval",
+			Usage: "Set the refresh interval",
+			Value: time.Second * 2,
+		},
+		&cli.DurationFlag{
+			Name:  "window",
+			Usage: "Set the period the request and error rates are computed over",
+			Value: time.Minute,
+		},
+		&cli.StringFlag{
+			Name:  "sort",
+			Usage: "Sort the services by memory, requests, errors or name",
+			Value: "requests",
+		},
+	)
+}
+
+// dashboard is the state of the stats dashboard
+type dashboard struct {
+	// sort the services by memory, requests, errors or name
+	sortBy string
+	// index of the selected service
+	selected int
+	// service drilled down into, the overview if empty
+	service string
+
+	aggregates []*pbstats.Aggregate
+	// current snapshots of the nodes of the service drilled down into
+	snapshots []*pbstats.Snapshot
+	err       error
+	updated   time.Time
+}
+
+// refresh the stats of the dashboard from the debug service
+func (d *dashboard) refresh(ctx context.Context, window time.Duration) {
+	c := pbstats.NewStatsService(Name, client.DefaultClient)
+
+	d.updated = time.Now()
+	rsp, err := c.Aggregate(ctx, &pbstats.AggregateRequest{
+		From:    d.updated.Add(-window).Unix(),
+		GroupBy: "service",
+	})
+	if err != nil {
+		d.err = err
+		return
+	}
+	d.err = nil
+	d.aggregates = rsp.Aggregates
+	d.sort()
+
+	if len(d.service) == 0 {
+		d.snapshots = nil
+		return
+	}
+
+	srsp, err := c.Read(ctx, &pbstats.ReadRequest{Service: &pbstats.Service{Name: d.service}})
+	if err != nil {
+		d.err = err
+		return
+	}
+	d.snapshots = srsp.Stats
+	sort.Slice(d.snapshots, func(i, j int) bool {
+		a, b := d.snapshots[i].Service, d.snapshots[j].Service
+		if a.Version != b.Version {
+			return a.Version < b.Version
+		}
+		return a.Node.GetId() < b.Node.GetId()
+	})
+}
+
+// sort the services keeping the selected service selected
+func (d *dashboard) sort() {
+	var name string
+	if d.selected < len(d.aggregates) {
+		name = d.aggregates[d.selected].Service
+	}
+
+	aggs := d.aggregates
+	sort.SliceStable(aggs, func(i, j int) bool {
+		switch d.sortBy {
+		case "memory":
+			return aggs[i].Memory > aggs[j].Memory
+		case "errors":
+			return aggs[i].ErrorRate > aggs[j].ErrorRate
+		case "name":
+			return aggs[i].Service < aggs[j].Service
+		default:
+			return aggs[i].RequestRate > aggs[j].RequestRate
+		}
+	})
+
+	d.selected = 0
+	for i, agg := range aggs {
+		if agg.Service == name {
+			d.selected = i
+		}
+	}
+}
+
+// key handles a key press returning false to quit
+func (d *dashboard) key(k string) bool {
+	switch k {
+	case "q", "\x03":
+		return false
+	case "m":
+		d.sortBy = "memory"
+	case "r":
+		d.sortBy = "requests"
+	case "e":
+		d.sortBy = "errors"
+	case "n":
+		d.sortBy = "name"
+	case "k", "\x1b[A":
+		if d.selected > 0 {
+			d.selected--
+		}
+		return true
+	case "j", "\x1b[B":
+		if d.selected < len(d.aggregates)-1 {
+			d.selected++
+		}
+		return true
+	case "\r", "\n":
+		if d.selected < len(d.aggregates) {
+			d.service = d.aggregates[d.selected].Service
+		}
+		return true
+	case "\x1b", "\x7f", "b":
+		d.service = ""
+		return true
+	default:
+		return true
+	}
+	d.sort()
+	return true
+}
+
+// render the dashboard
+func (d *dashboard) render(w io.Writer) {
+	if len(d.service) > 0 {
+		fmt.Fprintf(w, "%s at %s (esc back, q quit)\n\n", d.service, d.updated.Format("15:04:05"))
+	} else {
+		fmt.Fprintf(w, "services by %s at %s (m memory, r req/s, e error rate, n name, enter drill down, q quit)\n\n",
+			d.sortBy, d.updated.Format("15:04:05"))
+	}
+
+	if d.err != nil {
+		fmt.Fprintln(w, d.err)
+		return
+	}
+
+	if len(d.service) > 0 {
+		d.renderService(w)
+		return
+	}
+
+	writer := tabwriter.NewWriter(w, 0, 8, 1, '\t', 0)
+	fmt.Fprintln(writer, " \tSERVICE\tNODES\tREQ/S\tERROR RATE\tREQUESTS\tERRORS\tMEMORY\tTHREADS")
+	for i, agg := range d.aggregates {
+		marker := " "
+		if i == d.selected {
+			marker = ">"
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%d\t%.2f\t%.2f%%\t%d\t%d\t%.2fmb\t%d\n",
+			marker,
+			agg.Service,
+			agg.Nodes,
+			agg.RequestRate,
+			agg.ErrorRate*100,
+			agg.Requests,
+			agg.Errors,
+			float64(agg.Memory)/(1024.0*1024.0),
+			agg.Threads,
+		)
+	}
+	writer.Flush()
+}
+
+// renderService renders the nodes and endpoints of the service drilled down into
+func (d *dashboard) renderService(w io.Writer) {
+	writeStatus(w, d.snapshots)
+
+	// the endpoints are reported by the services which push their stats
+	endpoints := make(map[string]*pbstats.Endpoint)
+	var names []string
+	for _, s := range d.snapshots {
+		for _, ep := range s.Endpoints {
+			if e, ok := endpoints[ep.Name]; ok {
+				e.Requests += ep.Requests
+				e.Errors += ep.Errors
+				continue
+			}
+			cp := *ep
+			endpoints[ep.Name] = &cp
+			names = append(names, ep.Name)
+		}
+	}
+	if len(names) == 0 {
+		return
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(w)
+	writer := tabwriter.NewWriter(w, 0, 8, 1, '\t', 0)
+	fmt.Fprintln(writer, "ENDPOINT\tREQUESTS\tERRORS\tP50\tP90\tP99")
+	for _, name := range names {
+		ep := endpoints[name]
+		lat := ep.Latency
+		if lat == nil {
+			lat = new(pbstats.Latency)
+		}
+		fmt.Fprintf(writer, "%s\t%d\t%d\t%.1fms\t%.1fms\t%.1fms\n",
+			ep.Name, ep.Requests, ep.Errors, lat.P50, lat.P90, lat.P99)
+	}
+	writer.Flush()
+}
+
+// keys reads the key presses from the terminal
+func keys(in io.Reader) <-chan string {
+	pressed := make(chan string)
+	go func() {
+		buf := make([]byte, 8)
+		for {
+			n, err := in.Read(buf)
+			if err != nil {
+				close(pressed)
+				return
+			}
+			pressed <- string(buf[:n])
+		}
+	}()
+	return pressed
+}
+
+// getTop runs an interactive dashboard of the stats of the services in the namespace
+func getTop(ctx *cli.Context, srvOpts ...micro.Option) {
+	d := &dashboard{sortBy: ctx.String("sort")}
+	cctx := namespace.NewContext(context.Background(), ctx.String("token"))
+	window := ctx.Duration("window")
+
+	t := time.NewTicker(ctx.Duration("interval"))
+	defer t.Stop()
+
+	// the keys are read in raw mode so they're pressed without enter
+	var pressed <-chan string
+	fd := int(os.Stdin.Fd())
+	if readline.IsTerminal(fd) {
+		state, err := readline.MakeRaw(fd)
+		if err == nil {
+			defer readline.Restore(fd, state)
+			pressed = keys(os.Stdin)
+		}
+	}
+
+	draw := func() {
+		buf := bytes.NewBuffer(nil)
+		d.render(buf)
+		// the terminal doesn't return the cursor on a new line in raw mode
+		fmt.Print("\033[H\033[2J" + strings.Replace(buf.String(), "\n", "\r\n", -1))
+	}
+
+	d.refresh(cctx, window)
+	draw()
+
+	for {
+		select {
+		case <-t.C:
+			d.refresh(cctx, window)
+		case k, ok := <-pressed:
+			if !ok || !d.key(k) {
+				fmt.Print("\r\n")
+				return
+			}
+			// drilling down or back reads the stats again
+			if k == "\r" || k == "\n" || k == "\x1b" || k == "\x7f" || k == "b" {
+				d.refresh(cctx, window)
+			}
+		}
+		draw()
+	}
+}
+
+// Top runs the stats dashboard, it's exported for `micro stats --top`
+func Top(ctx *cli.Context) error {
+	getTop(ctx)
+	return nil
+}
@@ -1,13 +1,27 @@
 package debug
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/micro/cli/v2"
 	"github.com/micro/go-micro/v2"
+	"github.com/micro/go-micro/v2/client"
 	"github.com/micro/go-micro/v2/debug/service"
 	ulog "github.com/micro/go-micro/v2/util/log"
+	tracepb "github.com/micro/micro/v2/debug/trace/proto"
+	"github.com/micro/micro/v2/internal/namespace"
+)
+
+var (
+	// TraceName is the name of the trace collection service
+	TraceName = "go.micro.debug.trace"
 )
 
 const (
@@ -18,7 +32,11 @@ const (
 func getTrace(ctx *cli.Context, srvOpts ...micro.Option) {
 	ulog.Name("debug")
 
-	// TODO look for trace id
+	// the collected traces are read via the trace service
+	if len(ctx.String("id")) > 0 || ctx.Bool("stream") {
+		getCollectedTrace(ctx)
+		return
+	}
 
 	if ctx.Args().Len() == 0 {
 		fmt.Println("Require service name")
@@ -47,15 +65,122 @@ func getTrace(ctx *cli.Context, srvOpts ...micro.Option) {
 		return
 	}
 
-	fmt.Println("Id\tName\tTime\tDuration\tStatus")
+	fmt.Println("Id\tName\tTime\tDuration\tStatus\tMetadata")
 
 	for _, span := range spans {
-		fmt.Printf("%s\t%s\t%s\t%v\t%s\n",
+		fmt.Printf("%s\t%s\t%s\t%v\t%s\t%s\n",
 			span.Trace,
 			span.Name,
 			time.Unix(0, int64(span.Started)).String(),
 			time.Duration(span.Duration),
 			"",
+			formatMetadata(span.Metadata),
+		)
+	}
+}
+
+// formatMetadata returns the span metadata e.g the deadline budget consumed by the hop
+func formatMetadata(md map[string]string) string {
+	keys := make([]string, 0, len(md))
+	for k := range md {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, k+"="+md[k])
+	}
+	return strings.Join(parts, " ")
+}
+
+// traceFlags are the flags of the trace command
+func traceFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:  "id",
+			Usage: "Follow the trace across the services from the spans collected by the trace service",
+		},
+		&cli.BoolFlag{
+			Name:  "stream",
+			Usage: "Stream the spans as they're collected by the trace service",
+		},
+		&cli.StringFlag{
+			Name:    "token",
+			Usage:   "Set the namespace token used to read the traces of services in the namespace",
+			EnvVars: []string{"MICRO_NAMESPACE_TOKEN"},
+		},
+	}
+}
+
+// writeSpans writes a table of the spans of each trace with the
+// name of a span indented under its parent
+func writeSpans(w io.Writer, spans []*tracepb.Span) {
+	depth := make(map[string]int, len(spans))
+	parents := make(map[string]string, len(spans))
+	for _, s := range spans {
+		parents[s.Id] = s.Parent
+	}
+	var depthOf func(id string, n int) int
+	depthOf = func(id string, n int) int {
+		p, ok := parents[id]
+		// the parent isn't collected or the spans loop
+		if !ok || len(p) == 0 || n > len(spans) {
+			return 0
+		}
+		return depthOf(p, n+1) + 1
+	}
+	for _, s := range spans {
+		depth[s.Id] = depthOf(s.Id, 0)
+	}
+
+	writer := tabwriter.NewWriter(w, 0, 8, 1, '\t', 0)
+	fmt.Fprintln(writer, "TRACE\tSPAN\tSERVICE\tSTARTED\tDURATION\tMETADATA")
+	for _, s := range spans {
+		fmt.Fprintf(writer, "%s\t%s%s\t%s\t%s\t%v\t%s\n",
+			s.Trace,
+			strings.Repeat("  ", depth[s.Id]),
+			s.Name,
+			s.Service,
+			time.Unix(0, int64(s.Started)).Format(time.RFC3339Nano),
+			time.Duration(s.Duration),
+			formatMetadata(s.Metadata),
 		)
 	}
+	writer.Flush()
+}
+
+// getCollectedTrace prints the spans of a trace, or streams them as they're collected
+func getCollectedTrace(ctx *cli.Context) {
+	c := tracepb.NewTraceService(TraceName, client.DefaultClient)
+	cctx := namespace.NewContext(context.Background(), ctx.String("token"))
+
+	if !ctx.Bool("stream") {
+		rsp, err := c.Read(cctx, &tracepb.ReadRequest{Id: ctx.String("id")})
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		writeSpans(os.Stdout, rsp.Spans)
+		return
+	}
+
+	stream, err := c.Stream(cctx, &tracepb.StreamRequest{
+		Id:      ctx.String("id"),
+		Service: ctx.Args().First(),
+	})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer stream.Close()
+
+	for {
+		rsp, err := stream.Recv()
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		writeSpans(os.Stdout, rsp.Spans)
+	}
 }
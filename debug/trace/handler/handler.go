@@ -0,0 +1,343 @@
+// Package handler is the handler for the `micro debug trace` service
+package handler
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/micro/go-micro/v2/client"
+	"github.com/micro/go-micro/v2/config/cmd"
+	debug "github.com/micro/go-micro/v2/debug/service/proto"
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/registry"
+	"github.com/micro/go-micro/v2/registry/cache"
+	"github.com/micro/go-micro/v2/util/log"
+	pb "github.com/micro/micro/v2/debug/trace/proto"
+	"github.com/micro/micro/v2/internal/namespace"
+)
+
+var (
+	// MaxTraces is the number of traces kept, the oldest are evicted beyond it
+	MaxTraces = 10000
+	// ScrapeInterval is how long after the spans are collected they're collected again
+	ScrapeInterval = time.Second * 5
+	// ScrapeTimeout is how long a node has to respond with its spans
+	ScrapeTimeout = time.Second * 2
+	// ReadLimit is the number of the recent traces read if the request has no limit
+	ReadLimit = 20
+	// StreamBuffer is the number of batches of spans buffered for each stream, the
+	// oldest are dropped for streams which can't keep up rather than blocking collection
+	StreamBuffer = 64
+)
+
+// trace is the spans of a trace collected so far
+type trace struct {
+	// spans keyed by id
+	spans map[string]*pb.Span
+	// services with spans in the trace
+	services map[string]bool
+}
+
+// New returns a handler collecting the spans of the services in the registry until done is closed
+func New(done <-chan bool) *Trace {
+	t := &Trace{
+		registry: cache.New(*cmd.DefaultOptions().Registry),
+		client:   *cmd.DefaultOptions().Client,
+		traces:   make(map[string]*trace),
+		streams:  make(map[string]chan []*pb.Span),
+	}
+
+	go t.run(done)
+	return t
+}
+
+// Trace is the Trace handler collecting the spans of every service
+type Trace struct {
+	registry registry.Registry
+	client   client.Client
+
+	sync.RWMutex
+	// traces keyed by id
+	traces map[string]*trace
+	// ids of the traces in the order they were first seen so the oldest are evicted
+	order []string
+
+	// active streams keyed by id
+	streamMtx sync.RWMutex
+	streams   map[string]chan []*pb.Span
+}
+
+// run collects the spans until the done channel is closed
+func (t *Trace) run(done <-chan bool) {
+	for {
+		t.scrape()
+
+		select {
+		case <-done:
+			return
+		case <-time.After(ScrapeInterval):
+		}
+	}
+}
+
+// scrape the spans of every node of the services in the registry
+func (t *Trace) scrape() {
+	services, err := t.registry.ListServices()
+	if err != nil {
+		log.Debug(err)
+		return
+	}
+
+	protocol := t.client.String()
+
+	var wg sync.WaitGroup
+	for _, svc := range services {
+		nodes := []*registry.Service{svc}
+		if len(svc.Nodes) == 0 {
+			if nodes, err = t.registry.GetService(svc.Name); err != nil {
+				continue
+			}
+		}
+
+		for _, service := range nodes {
+			for _, node := range service.Nodes {
+				// only mucp services can be scraped
+				if node.Metadata["protocol"] != protocol {
+					continue
+				}
+
+				wg.Add(1)
+				go func(service *registry.Service, node *registry.Node) {
+					defer wg.Done()
+
+					ctx, cancel := context.WithTimeout(context.Background(), ScrapeTimeout)
+					defer cancel()
+
+					req := t.client.NewRequest(service.Name, "Debug.Trace", &debug.TraceRequest{})
+					rsp := new(debug.TraceResponse)
+					if err := t.client.Call(ctx, req, rsp, client.WithAddress(node.Address)); err != nil {
+						log.Debugf("Error collecting the spans of %s@%s: %v", service.Name, node.Address, err)
+						return
+					}
+
+					spans := make([]*pb.Span, 0, len(rsp.Spans))
+					for _, s := range rsp.Spans {
+						spans = append(spans, &pb.Span{
+							Trace:    s.Trace,
+							Id:       s.Id,
+							Parent:   s.Parent,
+							Name:     s.Name,
+							Started:  s.Started,
+							Duration: s.Duration,
+							Metadata: s.Metadata,
+							Service:  service.Name,
+							Node:     node.Id,
+						})
+					}
+
+					t.publish(t.add(spans))
+				}(service, node)
+			}
+		}
+	}
+	wg.Wait()
+}
+
+// add the spans to their traces returning those which are new,
+// the oldest traces are evicted beyond the max
+func (t *Trace) add(spans []*pb.Span) []*pb.Span {
+	t.Lock()
+	defer t.Unlock()
+
+	var added []*pb.Span
+	for _, s := range spans {
+		if len(s.Trace) == 0 || len(s.Id) == 0 {
+			continue
+		}
+
+		tr, ok := t.traces[s.Trace]
+		if !ok {
+			tr = &trace{spans: make(map[string]*pb.Span), services: make(map[string]bool)}
+			t.traces[s.Trace] = tr
+			t.order = append(t.order, s.Trace)
+		}
+		// collected already
+		if _, ok := tr.spans[s.Id]; ok {
+			continue
+		}
+		tr.spans[s.Id] = s
+		tr.services[s.Service] = true
+		added = append(added, s)
+	}
+
+	for len(t.order) > MaxTraces {
+		delete(t.traces, t.order[0])
+		t.order = t.order[1:]
+	}
+
+	return added
+}
+
+// publish the spans to the streams
+func (t *Trace) publish(spans []*pb.Span) {
+	if len(spans) == 0 {
+		return
+	}
+
+	t.streamMtx.RLock()
+	defer t.streamMtx.RUnlock()
+
+	for _, next := range t.streams {
+		select {
+		case next <- spans:
+			continue
+		default:
+		}
+
+		// make room by dropping the oldest spans
+		select {
+		case <-next:
+		default:
+		}
+		select {
+		case next <- spans:
+		default:
+		}
+	}
+}
+
+// filter returns the spans of the services in the namespace which match the trace and service
+func filter(spans []*pb.Span, ns, id, service string) []*pb.Span {
+	var filtered []*pb.Span
+	for _, s := range spans {
+		if !namespace.Allowed(ns, s.Service) {
+			continue
+		}
+		if len(id) > 0 && s.Trace != id {
+			continue
+		}
+		if len(service) > 0 && s.Service != service {
+			continue
+		}
+		filtered = append(filtered, s)
+	}
+	return filtered
+}
+
+// Read the spans of a trace, or of the recent traces of the service if the id isn't set
+func (t *Trace) Read(ctx context.Context, req *pb.ReadRequest, rsp *pb.ReadResponse) error {
+	// scope the spans to the namespace of the caller
+	ns, err := namespace.FromContext(ctx)
+	if err != nil {
+		return errors.Forbidden("go.micro.debug.trace", err.Error())
+	}
+
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = ReadLimit
+	}
+
+	t.RLock()
+	var ids []string
+	if len(req.Id) > 0 {
+		ids = []string{req.Id}
+	} else {
+		// the most recent traces of the service
+		for i := len(t.order) - 1; i >= 0 && len(ids) < limit; i-- {
+			tr := t.traces[t.order[i]]
+			if len(req.Service) == 0 || tr.services[req.Service] {
+				ids = append(ids, t.order[i])
+			}
+		}
+	}
+
+	var spans []*pb.Span
+	for _, id := range ids {
+		tr, ok := t.traces[id]
+		if !ok {
+			continue
+		}
+		for _, s := range tr.spans {
+			if namespace.Allowed(ns, s.Service) {
+				spans = append(spans, s)
+			}
+		}
+	}
+	t.RUnlock()
+
+	if len(req.Id) > 0 && len(spans) == 0 {
+		return errors.NotFound("go.micro.debug.trace", "trace %s not found", req.Id)
+	}
+
+	sort.Slice(spans, func(i, j int) bool {
+		if spans[i].Trace != spans[j].Trace {
+			return spans[i].Trace < spans[j].Trace
+		}
+		return spans[i].Started < spans[j].Started
+	})
+
+	rsp.Spans = spans
+	return nil
+}
+
+// Write the spans pushed by a service which can't be scraped e.g short lived
+func (t *Trace) Write(ctx context.Context, req *pb.WriteRequest, rsp *pb.WriteResponse) error {
+	ns, err := namespace.FromContext(ctx)
+	if err != nil {
+		return errors.Forbidden("go.micro.debug.trace", err.Error())
+	}
+
+	for _, s := range req.Spans {
+		if len(s.Trace) == 0 || len(s.Id) == 0 {
+			return errors.BadRequest("go.micro.debug.trace", "missing trace or span id")
+		}
+		if len(s.Service) == 0 {
+			return errors.BadRequest("go.micro.debug.trace", "missing service name of span %s", s.Id)
+		}
+		if !namespace.Allowed(ns, s.Service) {
+			return errors.Forbidden("go.micro.debug.trace", "service %s is not in namespace %s", s.Service, ns)
+		}
+	}
+
+	t.publish(t.add(req.Spans))
+	return nil
+}
+
+// Stream the spans as they're collected
+func (t *Trace) Stream(ctx context.Context, req *pb.StreamRequest, stream pb.Trace_StreamStream) error {
+	ns, err := namespace.FromContext(ctx)
+	if err != nil {
+		return errors.Forbidden("go.micro.debug.trace", err.Error())
+	}
+
+	id := uuid.New().String()
+	next := make(chan []*pb.Span, StreamBuffer)
+
+	t.streamMtx.Lock()
+	t.streams[id] = next
+	t.streamMtx.Unlock()
+
+	defer func() {
+		t.streamMtx.Lock()
+		delete(t.streams, id)
+		t.streamMtx.Unlock()
+		stream.Close()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case spans := <-next:
+			if spans = filter(spans, ns, req.Id, req.Service); len(spans) == 0 {
+				continue
+			}
+			if err := stream.Send(&pb.StreamResponse{Spans: spans}); err != nil {
+				return err
+			}
+		}
+	}
+}
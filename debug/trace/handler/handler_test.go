@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	pb "github.com/micro/micro/v2/debug/trace/proto"
+)
+
+func TestAdd(t *testing.T) {
+	MaxTraces = 2
+	defer func() { MaxTraces = 10000 }()
+
+	tr := &Trace{traces: make(map[string]*trace)}
+
+	span := func(trace, id, service string, started uint64) *pb.Span {
+		return &pb.Span{Trace: trace, Id: id, Service: service, Started: started}
+	}
+
+	added := tr.add([]*pb.Span{
+		span("a", "1", "go.micro.api", 1),
+		span("a", "2", "go.micro.srv.foo", 2),
+		span("a", "1", "go.micro.api", 1),
+	})
+	if len(added) != 2 {
+		t.Fatalf("expected the repeated span to be dropped got %d spans", len(added))
+	}
+
+	// collected again by the next scrape
+	if added := tr.add([]*pb.Span{span("a", "2", "go.micro.srv.foo", 2)}); len(added) != 0 {
+		t.Fatalf("expected no new spans got %d", len(added))
+	}
+
+	tr.add([]*pb.Span{span("b", "3", "go.micro.api", 3), span("c", "4", "go.micro.srv.foo", 4)})
+	if _, ok := tr.traces["a"]; ok {
+		t.Fatal("expected the oldest trace to be evicted")
+	}
+
+	rsp := new(pb.ReadResponse)
+	if err := tr.Read(context.Background(), &pb.ReadRequest{Service: "go.micro.srv.foo"}, rsp); err != nil {
+		t.Fatal(err)
+	}
+	if len(rsp.Spans) != 1 || rsp.Spans[0].Trace != "c" {
+		t.Fatalf("expected the spans of trace c got %v", rsp.Spans)
+	}
+
+	if err := tr.Read(context.Background(), &pb.ReadRequest{Id: "a"}, new(pb.ReadResponse)); err == nil {
+		t.Fatal("expected the evicted trace to be not found")
+	}
+}
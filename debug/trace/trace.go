@@ -0,0 +1,54 @@
+// Package trace provides a service that collects the spans of all services in the registry.
+package trace
+
+import (
+	"github.com/micro/cli/v2"
+	"github.com/micro/go-micro/v2"
+	"github.com/micro/go-micro/v2/util/log"
+
+	"github.com/micro/micro/v2/debug/trace/handler"
+	pb "github.com/micro/micro/v2/debug/trace/proto"
+)
+
+// Run is the entrypoint for debug/trace
+func Run(c *cli.Context) {
+	service := micro.NewService(
+		micro.Name("go.micro.debug.trace"),
+	)
+
+	if n := c.Int("max_traces"); n > 0 {
+		handler.MaxTraces = n
+	}
+	if d := c.Duration("scrape_interval"); d > 0 {
+		handler.ScrapeInterval = d
+	}
+
+	done := make(chan bool)
+	defer close(done)
+
+	// Register Handler
+	pb.RegisterTraceHandler(service.Server(), handler.New(done))
+
+	// Run service
+	if err := service.Run(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// Flags of the trace collector
+func Flags() []cli.Flag {
+	return []cli.Flag{
+		&cli.IntFlag{
+			Name:    "max_traces",
+			Usage:   "Specifies how many of the recent traces to retain in memory",
+			EnvVars: []string{"MICRO_DEBUG_MAX_TRACES"},
+			Value:   handler.MaxTraces,
+		},
+		&cli.DurationFlag{
+			Name:    "scrape_interval",
+			Usage:   "Set how long after collecting the spans of the services they're collected again e.g 10s",
+			EnvVars: []string{"MICRO_DEBUG_TRACE_SCRAPE_INTERVAL"},
+			Value:   handler.ScrapeInterval,
+		},
+	}
+}
@@ -0,0 +1,214 @@
+package edge
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Entry is a record in the local cache
+type Entry struct {
+	Value []byte `json:"value"`
+	// Vector of the writes seen for the record
+	Vector Vector `json:"vector"`
+	// Deleted entries are kept until the delete is synced
+	Deleted bool `json:"deleted"`
+	// Updated is the unix nano time of the last write
+	Updated int64 `json:"updated"`
+	// Dirty entries have local writes not yet synced upstream
+	Dirty bool `json:"dirty"`
+	// Checksum of the value when last synced
+	Checksum string `json:"checksum"`
+}
+
+// Cache is a local copy of the records served while offline
+type Cache struct {
+	node string
+	path string
+
+	sync.RWMutex
+	entries map[string]*Entry
+}
+
+// NewCache loads the cache from path if it exists
+func NewCache(node, path string) (*Cache, error) {
+	c := &Cache{
+		node:    node,
+		path:    path,
+		entries: make(map[string]*Entry),
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(b, &c.entries); err != nil {
+		return nil, fmt.Errorf("invalid cache %s: %v", path, err)
+	}
+
+	return c, nil
+}
+
+func checksum(b []byte) string {
+	return fmt.Sprintf("%x", sha256.Sum256(b))
+}
+
+// Read returns the value of the key
+func (c *Cache) Read(key string) ([]byte, bool) {
+	c.RLock()
+	defer c.RUnlock()
+
+	e, ok := c.entries[key]
+	if !ok || e.Deleted {
+		return nil, false
+	}
+	return e.Value, true
+}
+
+// List returns the keys and values in the cache
+func (c *Cache) List() map[string][]byte {
+	c.RLock()
+	defer c.RUnlock()
+
+	values := make(map[string][]byte, len(c.entries))
+	for k, e := range c.entries {
+		if !e.Deleted {
+			values[k] = e.Value
+		}
+	}
+	return values
+}
+
+// Write a value locally to be synced upstream
+func (c *Cache) Write(key string, value []byte) error {
+	c.Lock()
+	defer c.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		e = &Entry{}
+		c.entries[key] = e
+	}
+
+	e.Value = value
+	e.Deleted = false
+	e.Dirty = true
+	e.Updated = time.Now().UnixNano()
+	e.Vector = e.Vector.Increment(c.node)
+
+	return c.save()
+}
+
+// Delete a key locally to be synced upstream
+func (c *Cache) Delete(key string) error {
+	c.Lock()
+	defer c.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok || e.Deleted {
+		return nil
+	}
+
+	e.Value = nil
+	e.Deleted = true
+	e.Dirty = true
+	e.Updated = time.Now().UnixNano()
+	e.Vector = e.Vector.Increment(c.node)
+
+	return c.save()
+}
+
+// snapshot returns a copy of every entry including deletes
+func (c *Cache) snapshot() map[string]Entry {
+	c.RLock()
+	defer c.RUnlock()
+
+	entries := make(map[string]Entry, len(c.entries))
+	for k, e := range c.entries {
+		entries[k] = *e
+	}
+	return entries
+}
+
+// apply an upstream value unless the entry was written since seen
+func (c *Cache) apply(key string, seen int64, value []byte, deleted bool, vector Vector) {
+	c.Lock()
+	defer c.Unlock()
+
+	e, ok := c.entries[key]
+	if ok && e.Updated != seen {
+		return
+	}
+
+	if deleted {
+		delete(c.entries, key)
+		return
+	}
+
+	c.entries[key] = &Entry{
+		Value:    value,
+		Vector:   vector,
+		Updated:  seen,
+		Checksum: checksum(value),
+	}
+}
+
+// synced marks the entry as synced unless written since it was pushed
+func (c *Cache) synced(key string, seen int64, vector Vector) {
+	c.Lock()
+	defer c.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok || e.Updated != seen {
+		return
+	}
+
+	if e.Deleted {
+		delete(c.entries, key)
+		return
+	}
+
+	e.Dirty = false
+	e.Vector = vector
+	e.Checksum = checksum(e.Value)
+}
+
+// Save the cache to disk
+func (c *Cache) Save() error {
+	c.RLock()
+	defer c.RUnlock()
+	return c.save()
+}
+
+// save writes the cache to a temp file and renames it so a crash
+// never leaves a partially written cache, it requires the lock
+func (c *Cache) save() error {
+	if len(c.path) == 0 {
+		return nil
+	}
+
+	b, err := json.Marshal(c.entries)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0700); err != nil {
+		return err
+	}
+
+	tmp := c.path + ".tmp"
+	if err := ioutil.WriteFile(tmp, b, 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, c.path)
+}
@@ -0,0 +1,57 @@
+package edge
+
+import (
+	"errors"
+
+	"github.com/micro/go-micro/v2/store"
+	"github.com/micro/micro/v2/config/db"
+)
+
+// configCache is the local cache served by the edge config db
+var configCache *Cache
+
+// edgeDB is the config db backed by the local config cache
+type edgeDB struct{}
+
+func init() {
+	db.Register(new(edgeDB))
+}
+
+func (e *edgeDB) Init(opts db.Options) error {
+	if configCache == nil {
+		return errors.New("edge config db requires the edge agent")
+	}
+	return nil
+}
+
+func (e *edgeDB) Create(record *store.Record) error {
+	return configCache.Write(record.Key, record.Value)
+}
+
+func (e *edgeDB) Read(key string) (*store.Record, error) {
+	v, ok := configCache.Read(key)
+	if !ok {
+		return nil, db.ErrNotFound
+	}
+	return &store.Record{Key: key, Value: v}, nil
+}
+
+func (e *edgeDB) Update(record *store.Record) error {
+	return configCache.Write(record.Key, record.Value)
+}
+
+func (e *edgeDB) Delete(key string) error {
+	return configCache.Delete(key)
+}
+
+func (e *edgeDB) List(opts ...db.ListOption) ([]*store.Record, error) {
+	var records []*store.Record
+	for k, v := range configCache.List() {
+		records = append(records, &store.Record{Key: k, Value: v})
+	}
+	return records, nil
+}
+
+func (e *edgeDB) String() string {
+	return "edge"
+}
@@ -0,0 +1,261 @@
+// Package edge is an agent serving the store and config from local caches
+// which sync with the central services whenever connectivity is available
+package edge
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/micro/cli/v2"
+	"github.com/micro/go-micro/v2"
+	"github.com/micro/go-micro/v2/client"
+	mp "github.com/micro/go-micro/v2/config/source/service/proto"
+	pb "github.com/micro/go-micro/v2/store/service/proto"
+	"github.com/micro/go-micro/v2/util/log"
+	"github.com/micro/micro/v2/config/db"
+	"github.com/micro/micro/v2/config/handler"
+)
+
+var (
+	// StoreName is the name the local store is served as
+	StoreName = "go.micro.store"
+	// ConfigName is the name the local config is served as
+	ConfigName = "go.micro.config"
+	// Interval between syncs with the upstream
+	Interval = time.Second * 30
+)
+
+// agent keeps the local caches in sync with the upstream
+type agent struct {
+	node     string
+	path     string
+	upstream string
+	client   client.Client
+
+	sync.Mutex
+	replicas map[string]*replica
+	online   bool
+}
+
+// store returns the cache for the store namespace and prefix
+func (a *agent) store(namespace, prefix string) (*Cache, error) {
+	name := "store:" + namespace + ":" + prefix
+
+	a.Lock()
+	defer a.Unlock()
+
+	if r, ok := a.replicas[name]; ok {
+		return r.cache, nil
+	}
+
+	file := url.PathEscape(fmt.Sprintf("store-%s-%s", namespace, prefix)) + ".json"
+
+	c, err := NewCache(a.node, filepath.Join(a.path, file))
+	if err != nil {
+		return nil, err
+	}
+
+	a.replicas[name] = &replica{
+		name:   name,
+		node:   a.node,
+		cache:  c,
+		remote: newStoreRemote(a.client, a.upstream, namespace, prefix),
+	}
+
+	return c, nil
+}
+
+// config returns the config cache
+func (a *agent) config() (*Cache, error) {
+	a.Lock()
+	defer a.Unlock()
+
+	if r, ok := a.replicas["config"]; ok {
+		return r.cache, nil
+	}
+
+	c, err := NewCache(a.node, filepath.Join(a.path, "config.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	a.replicas["config"] = &replica{
+		name:   "config",
+		node:   a.node,
+		cache:  c,
+		remote: newConfigRemote(a.client, a.upstream),
+	}
+
+	return c, nil
+}
+
+// sync every replica logging when connectivity changes
+func (a *agent) sync() {
+	a.Lock()
+	replicas := make([]*replica, 0, len(a.replicas))
+	for _, r := range a.replicas {
+		replicas = append(replicas, r)
+	}
+	a.Unlock()
+
+	online := true
+
+	for _, r := range replicas {
+		if err := r.sync(); err != nil {
+			log.Debugf("Edge sync of %s failed: %v", r.name, err)
+			online = false
+		}
+	}
+
+	a.Lock()
+	defer a.Unlock()
+
+	if online == a.online {
+		return
+	}
+	a.online = online
+
+	if online {
+		log.Logf("Upstream %s available, local changes synced", a.upstream)
+	} else {
+		log.Logf("Upstream %s unavailable, serving from the local cache", a.upstream)
+	}
+}
+
+func (a *agent) run(exit <-chan bool) {
+	t := time.NewTicker(Interval)
+	defer t.Stop()
+
+	a.sync()
+
+	for {
+		select {
+		case <-t.C:
+			a.sync()
+		case <-exit:
+			// try to push any final changes
+			a.sync()
+			return
+		}
+	}
+}
+
+func run(ctx *cli.Context, srvOpts ...micro.Option) {
+	log.Name("edge")
+
+	upstream := ctx.String("upstream")
+	if len(upstream) == 0 {
+		log.Fatal("edge agent requires the upstream address e.g --upstream=proxy.example.com:8081")
+	}
+
+	if i := ctx.Duration("interval"); i > 0 {
+		Interval = i
+	}
+
+	node := ctx.String("node")
+	if len(node) == 0 {
+		node, _ = os.Hostname()
+	}
+
+	path := ctx.String("path")
+	if len(path) == 0 {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			log.Fatalf("Failed to get the cache path: %v", err)
+		}
+		path = filepath.Join(home, ".micro", "edge")
+	}
+
+	storeSrv := micro.NewService(append(srvOpts, micro.Name(StoreName))...)
+	configSrv := micro.NewService(append(srvOpts, micro.Name(ConfigName))...)
+
+	a := &agent{
+		node:     node,
+		path:     path,
+		upstream: upstream,
+		client:   storeSrv.Client(),
+		replicas: make(map[string]*replica),
+	}
+
+	// serve config from the local cache using the edge config db
+	var err error
+	configCache, err = a.config()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := db.Init(db.WithDBName("edge")); err != nil {
+		log.Fatalf("Failed to init the config db: %v", err)
+	}
+
+	// load the default store cache so it syncs on start
+	if _, err := a.store("", ""); err != nil {
+		log.Fatal(err)
+	}
+
+	pb.RegisterStoreHandler(storeSrv.Server(), &Store{agent: a})
+	mp.RegisterConfigHandler(configSrv.Server(), new(handler.Handler))
+	configSrv.Server().Subscribe(configSrv.Server().NewSubscriber(handler.WatchTopic, handler.Watcher))
+
+	exit := make(chan bool)
+	done := make(chan bool)
+
+	go func() {
+		a.run(exit)
+		close(done)
+	}()
+
+	go func() {
+		if err := configSrv.Run(); err != nil {
+			log.Fatal(err)
+		}
+	}()
+
+	log.Logf("Edge node %s syncing with %s every %v", node, upstream, Interval)
+
+	if err := storeSrv.Run(); err != nil {
+		log.Fatal(err)
+	}
+
+	close(exit)
+	<-done
+}
+
+// Commands is the cli interface for the edge agent
+func Commands(options ...micro.Option) []*cli.Command {
+	command := &cli.Command{
+		Name:  "edge",
+		Usage: "Run the edge agent serving the store and config while offline",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "upstream",
+				Usage:   "Set the address of the upstream proxy to sync with e.g proxy.example.com:8081",
+				EnvVars: []string{"MICRO_EDGE_UPSTREAM"},
+			},
+			&cli.StringFlag{
+				Name:    "node",
+				Usage:   "Set the id of the edge node, defaults to the hostname",
+				EnvVars: []string{"MICRO_EDGE_NODE"},
+			},
+			&cli.StringFlag{
+				Name:    "path",
+				Usage:   "Set the path of the local caches, defaults to ~/.micro/edge",
+				EnvVars: []string{"MICRO_EDGE_PATH"},
+			},
+			&cli.DurationFlag{
+				Name:    "interval",
+				Usage:   "Set how often to sync with the upstream e.g 30s",
+				EnvVars: []string{"MICRO_EDGE_INTERVAL"},
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			run(ctx, options...)
+			return nil
+		},
+	}
+
+	return []*cli.Command{command}
+}
@@ -0,0 +1,185 @@
+package edge
+
+import (
+	"context"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/micro/go-micro/v2/client"
+	mp "github.com/micro/go-micro/v2/config/source/service/proto"
+	"github.com/micro/go-micro/v2/metadata"
+	"github.com/micro/go-micro/v2/store"
+	pb "github.com/micro/go-micro/v2/store/service/proto"
+)
+
+var (
+	// Timeout for calls to the upstream
+	Timeout = time.Second * 10
+)
+
+// storeRemote syncs with the upstream store service
+type storeRemote struct {
+	address   string
+	namespace string
+	prefix    string
+	client    pb.StoreService
+}
+
+func newStoreRemote(c client.Client, address, namespace, prefix string) *storeRemote {
+	return &storeRemote{
+		address:   address,
+		namespace: namespace,
+		prefix:    prefix,
+		client:    pb.NewStoreService(StoreName, c),
+	}
+}
+
+func (s *storeRemote) context() (context.Context, context.CancelFunc) {
+	ctx := context.Background()
+
+	md := metadata.Metadata{}
+	if len(s.namespace) > 0 {
+		md["Micro-Namespace"] = s.namespace
+	}
+	if len(s.prefix) > 0 {
+		md["Micro-Prefix"] = s.prefix
+	}
+	if len(md) > 0 {
+		ctx = metadata.NewContext(ctx, md)
+	}
+
+	return context.WithTimeout(ctx, Timeout)
+}
+
+func (s *storeRemote) List() ([]*store.Record, error) {
+	ctx, cancel := s.context()
+	defer cancel()
+
+	stream, err := s.client.List(ctx, &pb.ListRequest{}, client.WithAddress(s.address))
+	if err != nil {
+		return nil, err
+	}
+	defer stream.Close()
+
+	var records []*store.Record
+
+	for {
+		rsp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, r := range rsp.Records {
+			records = append(records, &store.Record{
+				Key:    r.Key,
+				Value:  r.Value,
+				Expiry: time.Duration(r.Expiry) * time.Second,
+			})
+		}
+	}
+
+	return records, nil
+}
+
+func (s *storeRemote) Write(r *store.Record) error {
+	ctx, cancel := s.context()
+	defer cancel()
+
+	_, err := s.client.Write(ctx, &pb.WriteRequest{
+		Record: &pb.Record{
+			Key:   r.Key,
+			Value: r.Value,
+		},
+	}, client.WithAddress(s.address))
+	return err
+}
+
+func (s *storeRemote) Delete(key string) error {
+	ctx, cancel := s.context()
+	defer cancel()
+
+	_, err := s.client.Delete(ctx, &pb.DeleteRequest{Key: key}, client.WithAddress(s.address))
+	return err
+}
+
+// configRemote syncs with the upstream config service. Values are the
+// proto encoded changes as stored by the config db, vectors are stored
+// as the data of a change.
+type configRemote struct {
+	address string
+	client  mp.ConfigService
+}
+
+func newConfigRemote(c client.Client, address string) *configRemote {
+	return &configRemote{
+		address: address,
+		client:  mp.NewConfigService(ConfigName, c),
+	}
+}
+
+func (c *configRemote) List() ([]*store.Record, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+
+	rsp, err := c.client.List(ctx, &mp.ListRequest{}, client.WithAddress(c.address))
+	if err != nil {
+		return nil, err
+	}
+
+	var records []*store.Record
+
+	for _, ch := range rsp.Values {
+		if strings.HasPrefix(ch.Key, vectorPrefix) {
+			if ch.ChangeSet != nil {
+				records = append(records, &store.Record{Key: ch.Key, Value: ch.ChangeSet.Data})
+			}
+			continue
+		}
+
+		b, err := proto.Marshal(ch)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, &store.Record{Key: ch.Key, Value: b})
+	}
+
+	return records, nil
+}
+
+// Write replaces the upstream change, the config service merges
+// updates so the key is deleted and created again
+func (c *configRemote) Write(r *store.Record) error {
+	ch := &mp.Change{Key: r.Key}
+
+	if strings.HasPrefix(r.Key, vectorPrefix) {
+		ch.ChangeSet = &mp.ChangeSet{
+			Data:   r.Value,
+			Format: "json",
+			Source: "edge",
+		}
+	} else if err := proto.Unmarshal(r.Value, ch); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+
+	// ignore the error if it does not exist yet
+	c.client.Delete(ctx, &mp.DeleteRequest{Change: &mp.Change{Key: r.Key}}, client.WithAddress(c.address))
+
+	_, err := c.client.Create(ctx, &mp.CreateRequest{Change: ch}, client.WithAddress(c.address))
+	return err
+}
+
+func (c *configRemote) Delete(key string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+
+	_, err := c.client.Delete(ctx, &mp.DeleteRequest{Change: &mp.Change{Key: key}}, client.WithAddress(c.address))
+	return err
+}
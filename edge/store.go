@@ -0,0 +1,108 @@
+package edge
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/metadata"
+	"github.com/micro/go-micro/v2/store"
+	pb "github.com/micro/go-micro/v2/store/service/proto"
+)
+
+// Store serves the store from the local caches
+type Store struct {
+	agent *agent
+}
+
+func (s *Store) get(ctx context.Context) (*Cache, error) {
+	var namespace, prefix string
+
+	if md, ok := metadata.FromContext(ctx); ok {
+		namespace = md["Micro-Namespace"]
+		prefix = md["Micro-Prefix"]
+	}
+
+	c, err := s.agent.store(namespace, prefix)
+	if err != nil {
+		return nil, errors.InternalServerError("go.micro.store", err.Error())
+	}
+	return c, nil
+}
+
+func (s *Store) Read(ctx context.Context, req *pb.ReadRequest, rsp *pb.ReadResponse) error {
+	c, err := s.get(ctx)
+	if err != nil {
+		return err
+	}
+
+	if req.Options != nil && req.Options.Prefix {
+		for k, v := range c.List() {
+			if strings.HasPrefix(k, req.Key) {
+				rsp.Records = append(rsp.Records, &pb.Record{Key: k, Value: v})
+			}
+		}
+		return nil
+	}
+
+	v, ok := c.Read(req.Key)
+	if !ok {
+		return errors.InternalServerError("go.micro.store", store.ErrNotFound.Error())
+	}
+
+	rsp.Records = append(rsp.Records, &pb.Record{Key: req.Key, Value: v})
+
+	return nil
+}
+
+func (s *Store) Write(ctx context.Context, req *pb.WriteRequest, rsp *pb.WriteResponse) error {
+	c, err := s.get(ctx)
+	if err != nil {
+		return err
+	}
+
+	if req.Record == nil {
+		return errors.BadRequest("go.micro.store", "no record specified")
+	}
+
+	if err := c.Write(req.Record.Key, req.Record.Value); err != nil {
+		return errors.InternalServerError("go.micro.store", err.Error())
+	}
+
+	return nil
+}
+
+func (s *Store) Delete(ctx context.Context, req *pb.DeleteRequest, rsp *pb.DeleteResponse) error {
+	c, err := s.get(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := c.Delete(req.Key); err != nil {
+		return errors.InternalServerError("go.micro.store", err.Error())
+	}
+
+	return nil
+}
+
+func (s *Store) List(ctx context.Context, req *pb.ListRequest, stream pb.Store_ListStream) error {
+	c, err := s.get(ctx)
+	if err != nil {
+		return err
+	}
+
+	rsp := new(pb.ListResponse)
+	for k, v := range c.List() {
+		rsp.Records = append(rsp.Records, &pb.Record{Key: k, Value: v})
+	}
+
+	err = stream.Send(rsp)
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return errors.InternalServerError("go.micro.store", err.Error())
+	}
+	return nil
+}
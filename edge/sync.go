@@ -0,0 +1,192 @@
+package edge
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/micro/go-micro/v2/store"
+	"github.com/micro/go-micro/v2/util/log"
+)
+
+var (
+	// vectorPrefix is the upstream key prefix for the record vectors
+	vectorPrefix = "__edge__/"
+	// upstreamNode counts writes made upstream by clients without a vector
+	upstreamNode = "upstream"
+)
+
+// Remote is the upstream service a cache is synced with
+type Remote interface {
+	List() ([]*store.Record, error)
+	Write(*store.Record) error
+	Delete(key string) error
+}
+
+// version is stored upstream alongside each record synced by an edge node
+type version struct {
+	Vector   Vector `json:"vector"`
+	Checksum string `json:"checksum"`
+	Deleted  bool   `json:"deleted"`
+	Updated  int64  `json:"updated"`
+}
+
+// replica is a local cache synced with an upstream
+type replica struct {
+	name   string
+	node   string
+	cache  *Cache
+	remote Remote
+}
+
+// sync pushes local writes upstream and pulls upstream changes
+func (r *replica) sync() error {
+	records, err := r.remote.List()
+	if err != nil {
+		return err
+	}
+
+	upstream := make(map[string]*store.Record)
+	versions := make(map[string]*version)
+
+	for _, rec := range records {
+		if !strings.HasPrefix(rec.Key, vectorPrefix) {
+			upstream[rec.Key] = rec
+			continue
+		}
+
+		v := new(version)
+		if err := json.Unmarshal(rec.Value, v); err != nil {
+			continue
+		}
+		versions[strings.TrimPrefix(rec.Key, vectorPrefix)] = v
+	}
+
+	local := r.cache.snapshot()
+
+	keys := make(map[string]bool)
+	for k := range local {
+		keys[k] = true
+	}
+	for k := range upstream {
+		keys[k] = true
+	}
+
+	for k := range keys {
+		e, ok := local[k]
+		rec := upstream[k]
+
+		// without local writes upstream is authoritative
+		if !ok || !e.Dirty {
+			switch {
+			case rec == nil && ok:
+				r.cache.apply(k, e.Updated, nil, true, nil)
+			case rec != nil && (!ok || e.Checksum != checksum(rec.Value)):
+				r.cache.apply(k, e.Updated, rec.Value, false, r.upstreamVector(rec, versions[k]))
+			}
+			continue
+		}
+
+		uv := r.upstreamVector(rec, versions[k])
+
+		switch e.Vector.Compare(uv) {
+		case Equal, After:
+			err = r.push(k, e, rec != nil, e.Vector)
+		case Before:
+			r.pull(k, e, rec, uv)
+		case Concurrent:
+			// last writer wins, writes without a vector are newest
+			var updated int64 = 1<<63 - 1
+			if v := versions[k]; v != nil && v.Checksum == recordChecksum(rec) {
+				updated = v.Updated
+			}
+
+			merged := e.Vector.Merge(uv).Increment(r.node)
+
+			if e.Updated > updated {
+				log.Logf("Edge sync conflict on %s %s resolved with the local write", r.name, k)
+				err = r.push(k, e, rec != nil, merged)
+			} else {
+				log.Logf("Edge sync conflict on %s %s resolved with the upstream write", r.name, k)
+				r.pull(k, e, rec, merged)
+			}
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return r.cache.Save()
+}
+
+// upstreamVector returns the vector of the upstream record, counting
+// any write made since the vector was stored as an upstream write
+func (r *replica) upstreamVector(rec *store.Record, v *version) Vector {
+	if v == nil {
+		if rec == nil {
+			return Vector{}
+		}
+		return Vector{}.Increment(upstreamNode)
+	}
+
+	if rec == nil && v.Deleted {
+		return v.Vector
+	}
+
+	if recordChecksum(rec) != v.Checksum {
+		return v.Vector.Increment(upstreamNode)
+	}
+
+	return v.Vector
+}
+
+// recordChecksum returns the checksum of the record or none if deleted
+func recordChecksum(rec *store.Record) string {
+	if rec == nil {
+		return ""
+	}
+	return checksum(rec.Value)
+}
+
+// push the local entry upstream along with its vector
+func (r *replica) push(key string, e Entry, exists bool, vector Vector) error {
+	v := &version{
+		Vector:  vector,
+		Deleted: e.Deleted,
+		Updated: e.Updated,
+	}
+
+	switch {
+	case e.Deleted && exists:
+		if err := r.remote.Delete(key); err != nil {
+			return err
+		}
+	case !e.Deleted:
+		if err := r.remote.Write(&store.Record{Key: key, Value: e.Value}); err != nil {
+			return err
+		}
+		v.Checksum = checksum(e.Value)
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	if err := r.remote.Write(&store.Record{Key: vectorPrefix + key, Value: b}); err != nil {
+		return err
+	}
+
+	r.cache.synced(key, e.Updated, vector)
+
+	return nil
+}
+
+// pull the upstream record over the local entry
+func (r *replica) pull(key string, e Entry, rec *store.Record, vector Vector) {
+	if rec == nil {
+		r.cache.apply(key, e.Updated, nil, true, nil)
+		return
+	}
+	r.cache.apply(key, e.Updated, rec.Value, false, vector)
+}
@@ -0,0 +1,72 @@
+package edge
+
+// Vector is a version vector counting the writes seen from each node
+type Vector map[string]uint64
+
+// Ordering is how two vectors relate to each other
+type Ordering int
+
+const (
+	// Equal vectors have seen the same writes
+	Equal Ordering = iota
+	// Before means the vector has seen a subset of the other's writes
+	Before
+	// After means the vector has seen a superset of the other's writes
+	After
+	// Concurrent vectors have each seen writes the other has not
+	Concurrent
+)
+
+// Increment returns a copy of the vector with a write from node
+func (v Vector) Increment(node string) Vector {
+	cp := v.copy()
+	cp[node]++
+	return cp
+}
+
+// Merge returns the pointwise maximum of both vectors
+func (v Vector) Merge(o Vector) Vector {
+	cp := v.copy()
+	for node, n := range o {
+		if n > cp[node] {
+			cp[node] = n
+		}
+	}
+	return cp
+}
+
+// Compare the vector to another
+func (v Vector) Compare(o Vector) Ordering {
+	var before, after bool
+
+	for node, n := range v {
+		if n > o[node] {
+			after = true
+		}
+	}
+
+	for node, n := range o {
+		if n > v[node] {
+			before = true
+		}
+	}
+
+	switch {
+	case before && after:
+		return Concurrent
+	case before:
+		return Before
+	case after:
+		return After
+	default:
+		return Equal
+	}
+}
+
+func (v Vector) copy() Vector {
+	cp := make(Vector, len(v)+1)
+	for node, n := range v {
+		cp[node] = n
+	}
+	return cp
+}
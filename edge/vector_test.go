@@ -0,0 +1,39 @@
+package edge
+
+import "testing"
+
+func TestVector(t *testing.T) {
+	a := Vector{}.Increment("a")
+	b := a.Increment("b")
+
+	if o := a.Compare(a); o != Equal {
+		t.Fatalf("expected equal got %v", o)
+	}
+	if o := a.Compare(b); o != Before {
+		t.Fatalf("expected before got %v", o)
+	}
+	if o := b.Compare(a); o != After {
+		t.Fatalf("expected after got %v", o)
+	}
+
+	// a write on each node after b
+	c := b.Increment("a")
+	d := b.Increment("c")
+
+	if o := c.Compare(d); o != Concurrent {
+		t.Fatalf("expected concurrent got %v", o)
+	}
+
+	m := c.Merge(d)
+	if o := m.Compare(c); o != After {
+		t.Fatalf("expected merge after got %v", o)
+	}
+	if o := m.Compare(d); o != After {
+		t.Fatalf("expected merge after got %v", o)
+	}
+
+	// increment must not modify the original
+	if a["b"] != 0 {
+		t.Fatal("increment modified the original vector")
+	}
+}
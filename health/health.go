@@ -2,6 +2,7 @@
 package health
 
 import (
+	"bufio"
 	"fmt"
 	"net/http"
 
@@ -26,7 +27,9 @@ func Run(ctx *cli.Context) {
 
 	// just check service health
 	if ctx.Args().Len() > 0 {
-		mcli.Print(qcli.QueryHealth)(ctx)
+		mcli.Print(func(c *cli.Context, args []string, w *bufio.Writer) error {
+			return qcli.QueryHealth(c, args, w)
+		})(ctx)
 		return
 	}
 
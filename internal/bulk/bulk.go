@@ -0,0 +1,106 @@
+// Package bulk runs operations across many items with a bounded worker pool
+package bulk
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+var (
+	// Concurrency is the default number of operations run at once
+	Concurrency = 10
+	// Width of the progress bar
+	Width = 30
+)
+
+// Failure is an item the operation failed for
+type Failure struct {
+	Item string
+	Err  error
+}
+
+// Summary of a bulk operation
+type Summary struct {
+	Total     int
+	Succeeded int
+	Failed    []Failure
+}
+
+// Run calls fn for every item with at most concurrency calls in flight,
+// drawing a progress bar to progress if it's not nil. Failures are
+// returned in the order of the items.
+func Run(items []string, concurrency int, progress io.Writer, fn func(item string) error) *Summary {
+	if concurrency < 1 {
+		concurrency = Concurrency
+	}
+
+	errs := make([]error, len(items))
+	sem := make(chan struct{}, concurrency)
+
+	var mtx sync.Mutex
+	var wg sync.WaitGroup
+	var done int
+
+	draw(progress, done, len(items))
+
+	for i, item := range items {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(i int, item string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			errs[i] = fn(item)
+
+			mtx.Lock()
+			done++
+			draw(progress, done, len(items))
+			mtx.Unlock()
+		}(i, item)
+	}
+
+	wg.Wait()
+
+	if progress != nil && len(items) > 0 {
+		fmt.Fprintln(progress)
+	}
+
+	summary := &Summary{Total: len(items)}
+	for i, err := range errs {
+		if err != nil {
+			summary.Failed = append(summary.Failed, Failure{Item: items[i], Err: err})
+			continue
+		}
+		summary.Succeeded++
+	}
+
+	return summary
+}
+
+// draw the progress bar e.g [=========>          ] 12/40
+func draw(w io.Writer, done, total int) {
+	if w == nil || total == 0 {
+		return
+	}
+
+	n := done * Width / total
+	bar := strings.Repeat("=", n)
+	if n < Width {
+		bar += ">" + strings.Repeat(" ", Width-n-1)
+	}
+
+	fmt.Fprintf(w, "\r[%s] %d/%d", bar, done, total)
+}
+
+// Print writes the successes and failures of the operation
+func (s *Summary) Print(w io.Writer) {
+	fmt.Fprintf(w, "%d succeeded, %d failed\n", s.Succeeded, len(s.Failed))
+	for _, f := range s.Failed {
+		fmt.Fprintf(w, "%s: %v\n", f.Item, f.Err)
+	}
+}
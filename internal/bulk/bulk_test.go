@@ -0,0 +1,56 @@
+package bulk
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRun(t *testing.T) {
+	var items []string
+	for i := 0; i < 20; i++ {
+		items = append(items, string('a'+rune(i)))
+	}
+
+	var active, peak int32
+
+	b := bytes.NewBuffer(nil)
+	summary := Run(items, 3, b, func(item string) error {
+		n := atomic.AddInt32(&active, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		atomic.AddInt32(&active, -1)
+
+		if item == "c" || item == "k" {
+			return errors.New("failed")
+		}
+		return nil
+	})
+
+	if peak > 3 {
+		t.Fatalf("Expected at most 3 operations at once got %d", peak)
+	}
+	if summary.Total != 20 || summary.Succeeded != 18 {
+		t.Fatalf("Expected 18 of 20 to succeed got %+v", summary)
+	}
+	if len(summary.Failed) != 2 || summary.Failed[0].Item != "c" || summary.Failed[1].Item != "k" {
+		t.Fatalf("Expected c and k to fail got %+v", summary.Failed)
+	}
+	if !strings.Contains(b.String(), "20/20") {
+		t.Fatalf("Expected the progress to reach 20/20 got %q", b.String())
+	}
+
+	b.Reset()
+	summary.Print(b)
+	if !strings.HasPrefix(b.String(), "18 succeeded, 2 failed\nc: failed\n") {
+		t.Fatalf("Unexpected summary %q", b.String())
+	}
+}
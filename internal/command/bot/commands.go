@@ -1,6 +1,7 @@
 package bot
 
 import (
+	"bytes"
 	"strings"
 	"time"
 
@@ -56,11 +57,11 @@ func Get(ctx *cli.Context) command.Command {
 			if len(args) < 3 {
 				return []byte("require service name"), nil
 			}
-			rsp, err := clic.GetService(ctx, args[2:])
-			if err != nil {
+			b := bytes.NewBuffer(nil)
+			if err := clic.GetService(ctx, args[2:], b); err != nil {
 				return nil, err
 			}
-			return rsp, nil
+			return bytes.TrimSpace(b.Bytes()), nil
 		default:
 			return []byte("unknown command...\nsupported commands: \nget service [name]"), nil
 		}
@@ -76,11 +77,11 @@ func Health(ctx *cli.Context) command.Command {
 		if len(args) < 2 {
 			return []byte("health of what?"), nil
 		}
-		rsp, err := clic.QueryHealth(ctx, args[1:])
-		if err != nil {
+		b := bytes.NewBuffer(nil)
+		if err := clic.QueryHealth(ctx, args[1:], b); err != nil {
 			return nil, err
 		}
-		return rsp, nil
+		return bytes.TrimSpace(b.Bytes()), nil
 	})
 }
 
@@ -95,11 +96,11 @@ func List(ctx *cli.Context) command.Command {
 		}
 		switch args[1] {
 		case "services":
-			rsp, err := clic.ListServices(ctx)
-			if err != nil {
+			b := bytes.NewBuffer(nil)
+			if err := clic.ListServices(ctx, b); err != nil {
 				return nil, err
 			}
-			return rsp, nil
+			return bytes.TrimSpace(b.Bytes()), nil
 		default:
 			return []byte("unknown command...\nsupported commands: \nlist services"), nil
 		}
@@ -125,11 +126,11 @@ func Call(ctx *cli.Context) command.Command {
 			return []byte("call what?"), nil
 		}
 
-		rsp, err := clic.CallService(ctx, cargs[1:])
-		if err != nil {
+		b := bytes.NewBuffer(nil)
+		if err := clic.CallService(ctx, cargs[1:], b); err != nil {
 			return nil, err
 		}
-		return rsp, nil
+		return bytes.TrimSpace(b.Bytes()), nil
 	})
 }
 
@@ -147,11 +148,11 @@ func Register(ctx *cli.Context) command.Command {
 			if len(args) < 3 {
 				return []byte("require service definition"), nil
 			}
-			rsp, err := clic.RegisterService(ctx, args[2:])
-			if err != nil {
+			b := bytes.NewBuffer(nil)
+			if err := clic.RegisterService(ctx, args[2:], b); err != nil {
 				return nil, err
 			}
-			return rsp, nil
+			return bytes.TrimSpace(b.Bytes()), nil
 		default:
 			return []byte("unknown command...\nsupported commands: \nregister service [definition]"), nil
 		}
@@ -172,11 +173,11 @@ func Deregister(ctx *cli.Context) command.Command {
 			if len(args) < 3 {
 				return []byte("require service definition"), nil
 			}
-			rsp, err := clic.DeregisterService(ctx, args[2:])
-			if err != nil {
+			b := bytes.NewBuffer(nil)
+			if err := clic.DeregisterService(ctx, args[2:], b); err != nil {
 				return nil, err
 			}
-			return rsp, nil
+			return bytes.TrimSpace(b.Bytes()), nil
 		default:
 			return []byte("unknown command...\nsupported commands: \nderegister service [definition]"), nil
 		}
@@ -6,13 +6,18 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"math"
 	"net/http"
+	"os"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/micro/cli/v2"
 	"github.com/micro/go-micro/v2/client"
 	cbytes "github.com/micro/go-micro/v2/codec/bytes"
@@ -22,6 +27,7 @@ import (
 
 	proto "github.com/micro/go-micro/v2/debug/service/proto"
 
+	"github.com/micro/micro/v2/internal/bulk"
 	dns "github.com/micro/micro/v2/network/dns/proto/dns"
 
 	"github.com/olekukonko/tablewriter"
@@ -171,32 +177,72 @@ func callContext(c *cli.Context) context.Context {
 	return metadata.NewContext(context.Background(), callMD)
 }
 
-func RegisterService(c *cli.Context, args []string) ([]byte, error) {
-	if len(args) == 0 {
-		return nil, errors.New("require service definition")
+// serviceFromProto derives the service definition from a proto file or FileDescriptorSet
+func serviceFromProto(c *cli.Context, path string) (*registry.Service, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
 	}
 
-	req := strings.Join(args, " ")
+	var def *protoDef
+	if strings.HasSuffix(path, ".proto") {
+		def, err = parseProto(string(b))
+	} else {
+		def, err = parseDescriptorSet(b)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error parsing %s: %v", path, err)
+	}
+
+	service, err := def.service(c.String("name"), c.String("version"))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing %s: %v", path, err)
+	}
 
+	if addr := c.String("address"); len(addr) > 0 {
+		service.Nodes = append(service.Nodes, &registry.Node{
+			Id:      service.Name + "-" + uuid.New().String(),
+			Address: addr,
+		})
+	}
+
+	return service, nil
+}
+
+func RegisterService(c *cli.Context, args []string, w io.Writer) error {
 	var service *registry.Service
 
-	d := json.NewDecoder(strings.NewReader(req))
-	d.UseNumber()
+	if path := c.String("from-proto"); len(path) > 0 {
+		var err error
+		if service, err = serviceFromProto(c, path); err != nil {
+			return err
+		}
+	} else {
+		if len(args) == 0 {
+			return errors.New("require service definition")
+		}
 
-	if err := d.Decode(&service); err != nil {
-		return nil, err
+		req := strings.Join(args, " ")
+
+		d := json.NewDecoder(strings.NewReader(req))
+		d.UseNumber()
+
+		if err := d.Decode(&service); err != nil {
+			return err
+		}
 	}
 
 	if err := (*cmd.DefaultOptions().Registry).Register(service); err != nil {
-		return nil, err
+		return err
 	}
 
-	return []byte("ok"), nil
+	_, err := fmt.Fprintln(w, "ok")
+	return err
 }
 
-func DeregisterService(c *cli.Context, args []string) ([]byte, error) {
+func DeregisterService(c *cli.Context, args []string, w io.Writer) error {
 	if len(args) == 0 {
-		return nil, errors.New("require service definition")
+		return errors.New("require service definition")
 	}
 
 	req := strings.Join(args, " ")
@@ -207,48 +253,122 @@ func DeregisterService(c *cli.Context, args []string) ([]byte, error) {
 	d.UseNumber()
 
 	if err := d.Decode(&service); err != nil {
-		return nil, err
+		return err
 	}
 
 	if err := (*cmd.DefaultOptions().Registry).Deregister(service); err != nil {
-		return nil, err
+		return err
 	}
 
-	return []byte("ok"), nil
+	_, err := fmt.Fprintln(w, "ok")
+	return err
 }
 
-func GetService(c *cli.Context, args []string) ([]byte, error) {
-	if len(args) == 0 {
-		return nil, errors.New("service required")
+// PruneServices deregisters the nodes which fail their health check,
+// checking at most --concurrency nodes at once
+func PruneServices(c *cli.Context, w io.Writer) error {
+	reg := *cmd.DefaultOptions().Registry
+
+	list, err := reg.ListServices()
+	if err != nil {
+		return err
 	}
 
-	var output []string
-	var service []*registry.Service
-	var err error
+	var items []string
+	services := make(map[string]*registry.Service)
 
-	service, err = (*cmd.DefaultOptions().Registry).GetService(args[0])
+	for _, l := range list {
+		records, err := reg.GetService(l.Name)
+		if err != nil {
+			return err
+		}
+		for _, srv := range records {
+			for _, node := range srv.Nodes {
+				item := srv.Name + " " + node.Id
+				services[item] = &registry.Service{
+					Name:     srv.Name,
+					Version:  srv.Version,
+					Metadata: srv.Metadata,
+					Nodes:    []*registry.Node{node},
+				}
+				items = append(items, item)
+			}
+		}
+	}
+
+	sort.Strings(items)
+
+	var mtx sync.Mutex
+	var pruned []string
+
+	timeout := c.Duration("timeout")
+
+	summary := bulk.Run(items, c.Int("concurrency"), os.Stderr, func(item string) error {
+		srv := services[item]
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		req := (*cmd.DefaultOptions().Client).NewRequest(srv.Name, "Debug.Health", &proto.HealthRequest{})
+		rsp := &proto.HealthResponse{}
+		err := (*cmd.DefaultOptions().Client).Call(ctx, req, rsp, client.WithAddress(srv.Nodes[0].Address))
+		if err == nil && rsp.Status == "ok" {
+			return nil
+		}
+
+		if err := reg.Deregister(srv); err != nil {
+			return err
+		}
+
+		mtx.Lock()
+		pruned = append(pruned, item)
+		mtx.Unlock()
+
+		return nil
+	})
+
+	sort.Strings(pruned)
+
+	for _, item := range pruned {
+		fmt.Fprintf(w, "pruned %s\n", item)
+	}
+	summary.Print(w)
+
+	if len(summary.Failed) > 0 {
+		return fmt.Errorf("failed to prune %d nodes", len(summary.Failed))
+	}
+
+	return nil
+}
+
+func GetService(c *cli.Context, args []string, w io.Writer) error {
+	if len(args) == 0 {
+		return errors.New("service required")
+	}
+
+	service, err := (*cmd.DefaultOptions().Registry).GetService(args[0])
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	if len(service) == 0 {
-		return nil, errors.New("Service not found")
+		return errors.New("Service not found")
 	}
 
-	output = append(output, "service  "+service[0].Name)
+	fmt.Fprintln(w, "service  "+service[0].Name)
 
 	for _, serv := range service {
 		if len(serv.Version) > 0 {
-			output = append(output, "\nversion "+serv.Version)
+			fmt.Fprintln(w, "\nversion "+serv.Version)
 		}
 
-		output = append(output, "\nID\tAddress\tMetadata")
+		fmt.Fprintln(w, "\nID\tAddress\tMetadata")
 		for _, node := range serv.Nodes {
 			var meta []string
 			for k, v := range node.Metadata {
 				meta = append(meta, k+"="+v)
 			}
-			output = append(output, fmt.Sprintf("%s\t%s\t%s", node.Id, node.Address, strings.Join(meta, ",")))
+			fmt.Fprintf(w, "%s\t%s\t%s\n", node.Id, node.Address, strings.Join(meta, ","))
 		}
 	}
 
@@ -277,22 +397,22 @@ func GetService(c *cli.Context, args []string) ([]byte, error) {
 			response = "{}"
 		}
 
-		output = append(output, fmt.Sprintf("\nEndpoint: %s\n", e.Name))
+		fmt.Fprintf(w, "\nEndpoint: %s\n\n", e.Name)
 
 		// set metadata if exists
 		if len(meta) > 0 {
-			output = append(output, fmt.Sprintf("Metadata: %s\n", strings.Join(meta, ",")))
+			fmt.Fprintf(w, "Metadata: %s\n\n", strings.Join(meta, ","))
 		}
 
-		output = append(output, fmt.Sprintf("Request: %s\n\nResponse: %s\n", request, response))
+		fmt.Fprintf(w, "Request: %s\n\nResponse: %s\n\n", request, response)
 	}
 
-	return []byte(strings.Join(output, "\n")), nil
+	return nil
 }
 
-func NetworkConnect(c *cli.Context, args []string) ([]byte, error) {
+func NetworkConnect(c *cli.Context, args []string, w io.Writer) error {
 	if len(args) == 0 {
-		return nil, nil
+		return nil
 	}
 
 	cli := *cmd.DefaultOptions().Client
@@ -310,14 +430,15 @@ func NetworkConnect(c *cli.Context, args []string) ([]byte, error) {
 	req := cli.NewRequest("go.micro.network", "Network.Connect", request, client.WithContentType("application/json"))
 	err := cli.Call(context.TODO(), req, &rsp)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	b, _ := json.MarshalIndent(rsp, "", "\t")
-	return b, nil
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "\t")
+	return enc.Encode(rsp)
 }
 
-func NetworkConnections(c *cli.Context) ([]byte, error) {
+func NetworkConnections(c *cli.Context, w io.Writer) error {
 	cli := *cmd.DefaultOptions().Client
 
 	request := map[string]interface{}{
@@ -329,21 +450,20 @@ func NetworkConnections(c *cli.Context) ([]byte, error) {
 	req := cli.NewRequest("go.micro.network", "Network.Graph", request, client.WithContentType("application/json"))
 	err := cli.Call(context.TODO(), req, &rsp)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	if rsp["root"] == nil {
-		return nil, nil
+		return nil
 	}
 
 	peers := rsp["root"].(map[string]interface{})["peers"]
 
 	if peers == nil {
-		return nil, nil
+		return nil
 	}
 
-	b := bytes.NewBuffer(nil)
-	table := tablewriter.NewWriter(b)
+	table := tablewriter.NewWriter(w)
 	table.SetHeader([]string{"NODE", "ADDRESS"})
 
 	// root node
@@ -356,14 +476,14 @@ func NetworkConnections(c *cli.Context) ([]byte, error) {
 		table.Append(strEntry)
 	}
 
-	// render table into b
+	// render table into w
 	table.SetAlignment(tablewriter.ALIGN_LEFT)
 	table.Render()
 
-	return b.Bytes(), nil
+	return nil
 }
 
-func NetworkGraph(c *cli.Context) ([]byte, error) {
+func NetworkGraph(c *cli.Context, w io.Writer) error {
 	cli := *cmd.DefaultOptions().Client
 
 	var rsp map[string]interface{}
@@ -371,14 +491,15 @@ func NetworkGraph(c *cli.Context) ([]byte, error) {
 	req := cli.NewRequest("go.micro.network", "Network.Graph", map[string]interface{}{}, client.WithContentType("application/json"))
 	err := cli.Call(context.TODO(), req, &rsp)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	b, _ := json.MarshalIndent(rsp, "", "\t")
-	return b, nil
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "\t")
+	return enc.Encode(rsp)
 }
 
-func NetworkNodes(c *cli.Context) ([]byte, error) {
+func NetworkNodes(c *cli.Context, w io.Writer) error {
 	cli := *cmd.DefaultOptions().Client
 
 	var rsp map[string]interface{}
@@ -387,16 +508,15 @@ func NetworkNodes(c *cli.Context) ([]byte, error) {
 	req := cli.NewRequest("go.micro.network", "Network.Nodes", map[string]interface{}{}, client.WithContentType("application/json"))
 	err := cli.Call(context.TODO(), req, &rsp)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	// return if nil
 	if rsp["nodes"] == nil {
-		return nil, nil
+		return nil
 	}
 
-	b := bytes.NewBuffer(nil)
-	table := tablewriter.NewWriter(b)
+	table := tablewriter.NewWriter(w)
 	table.SetHeader([]string{"ID", "ADDRESS"})
 
 	// get nodes
@@ -413,14 +533,14 @@ func NetworkNodes(c *cli.Context) ([]byte, error) {
 		}
 	}
 
-	// render table into b
+	// render table into w
 	table.SetAlignment(tablewriter.ALIGN_LEFT)
 	table.Render()
 
-	return b.Bytes(), nil
+	return nil
 }
 
-func NetworkRoutes(c *cli.Context) ([]byte, error) {
+func NetworkRoutes(c *cli.Context, w io.Writer) error {
 	cli := (*cmd.DefaultOptions().Client)
 
 	query := map[string]string{}
@@ -440,15 +560,14 @@ func NetworkRoutes(c *cli.Context) ([]byte, error) {
 	req := cli.NewRequest("go.micro.network", "Network.Routes", request, client.WithContentType("application/json"))
 	err := cli.Call(context.TODO(), req, &rsp)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	if len(rsp) == 0 {
-		return []byte(``), nil
+		return nil
 	}
 
-	b := bytes.NewBuffer(nil)
-	table := tablewriter.NewWriter(b)
+	table := tablewriter.NewWriter(w)
 	table.SetHeader([]string{"SERVICE", "ADDRESS", "GATEWAY", "ROUTER", "NETWORK", "METRIC", "LINK"})
 
 	routes := rsp["routes"].([]interface{})
@@ -498,14 +617,14 @@ func NetworkRoutes(c *cli.Context) ([]byte, error) {
 	sort.Slice(sortedRoutes, func(i, j int) bool { return sortedRoutes[i][0] < sortedRoutes[j][0] })
 
 	table.AppendBulk(sortedRoutes)
-	// render table into b
+	// render table into w
 	table.SetAlignment(tablewriter.ALIGN_LEFT)
 	table.Render()
 
-	return b.Bytes(), nil
+	return nil
 }
 
-func NetworkServices(c *cli.Context) ([]byte, error) {
+func NetworkServices(c *cli.Context, w io.Writer) error {
 	cli := (*cmd.DefaultOptions().Client)
 
 	var rsp map[string]interface{}
@@ -513,11 +632,11 @@ func NetworkServices(c *cli.Context) ([]byte, error) {
 	req := cli.NewRequest("go.micro.network", "Network.Services", map[string]interface{}{}, client.WithContentType("application/json"))
 	err := cli.Call(context.TODO(), req, &rsp)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	if len(rsp) == 0 || rsp["services"] == nil {
-		return []byte(``), nil
+		return nil
 	}
 
 	rspSrv := rsp["services"].([]interface{})
@@ -530,26 +649,32 @@ func NetworkServices(c *cli.Context) ([]byte, error) {
 
 	sort.Strings(services)
 
-	return []byte(strings.Join(services, "\n")), nil
+	for _, v := range services {
+		fmt.Fprintln(w, v)
+	}
+
+	return nil
 }
 
-func NetworkDNSAdvertise(c *cli.Context) ([]byte, error) {
-	err := networkDNSHelper("Dns.Advertise", c.String("address"), c.String("domain"), c.String("token"))
+func NetworkDNSAdvertise(c *cli.Context, w io.Writer) error {
+	err := networkDNSHelper("Dns.Advertise", c.String("address"), c.String("domain"), c.String("token"), uint32(c.Int("ttl")))
 	if err != nil {
-		return []byte(``), err
+		return err
 	}
-	return []byte("Registered " + c.String("domain") + ": " + c.String("address")), nil
+	_, err = fmt.Fprintln(w, "Registered "+c.String("domain")+": "+c.String("address"))
+	return err
 }
 
-func NetworkDNSRemove(c *cli.Context) ([]byte, error) {
-	err := networkDNSHelper("Dns.Remove", c.String("address"), c.String("domain"), c.String("token"))
+func NetworkDNSRemove(c *cli.Context, w io.Writer) error {
+	err := networkDNSHelper("Dns.Remove", c.String("address"), c.String("domain"), c.String("token"), 0)
 	if err != nil {
-		return []byte(``), err
+		return err
 	}
-	return []byte("Removed " + c.String("domain") + ": " + c.String("address")), nil
+	_, err = fmt.Fprintln(w, "Removed "+c.String("domain")+": "+c.String("address"))
+	return err
 }
 
-func NetworkDNSResolve(c *cli.Context) ([]byte, error) {
+func NetworkDNSResolve(c *cli.Context, w io.Writer) error {
 	request := make(map[string]interface{})
 	request["name"] = c.String("domain")
 	request["type"] = c.String("type")
@@ -566,22 +691,78 @@ func NetworkDNSResolve(c *cli.Context) ([]byte, error) {
 		client.WithRetries(3),
 	)
 	if err != nil {
-		return []byte(``), err
+		return err
 	}
 
 	rawRecords, ok := rsp["records"]
 	if !ok {
-		return []byte(``), errors.New("Response did not contain any records")
+		return errors.New("Response did not contain any records")
 	}
 	var resolved []string
 	for _, r := range rawRecords {
 		resolved = append(resolved, r.Value)
 	}
 
-	return []byte(strings.Join(resolved, "\n")), nil
+	for _, v := range resolved {
+		fmt.Fprintln(w, v)
+	}
+
+	return nil
 }
 
-func networkDNSHelper(action, address, domain, token string) error {
+func NetworkDNSList(c *cli.Context, w io.Writer) error {
+	request := map[string]interface{}{
+		"name": c.String("domain"),
+	}
+
+	cli := (*cmd.DefaultOptions().Client)
+	req := cli.NewRequest("go.micro.network.dns", "Dns.List", request, client.WithContentType("application/json"))
+	var rsp map[string][]*dns.Record
+	err := cli.Call(
+		metadata.NewContext(context.Background(), map[string]string{
+			"Authorization": "Bearer " + c.String("token"),
+		}),
+		req,
+		&rsp,
+		client.WithRetries(3),
+	)
+	if err != nil {
+		return err
+	}
+
+	records := rsp["records"]
+	if len(records) == 0 {
+		return nil
+	}
+
+	sort.Slice(records, func(i, j int) bool { return records[i].Name < records[j].Name })
+
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"NAME", "TYPE", "VALUE", "TTL", "CREATED"})
+
+	for _, r := range records {
+		// a ttl of 1 means automatic
+		ttl := fmt.Sprintf("%d", r.Ttl)
+		if r.Ttl <= 1 {
+			ttl = "auto"
+		}
+
+		created := ""
+		if r.Created > 0 {
+			created = time.Unix(r.Created, 0).Format(time.RFC3339)
+		}
+
+		table.Append([]string{r.Name, r.Type, r.Value, ttl, created})
+	}
+
+	// render table into w
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.Render()
+
+	return nil
+}
+
+func networkDNSHelper(action, address, domain, token string, ttl uint32) error {
 	request := map[string]interface{}{
 		"records": []*dns.Record{},
 	}
@@ -592,7 +773,7 @@ func networkDNSHelper(action, address, domain, token string) error {
 				Type:  "AAAA",
 				Name:  domain,
 				Value: address,
-				Ttl:   1,
+				Ttl:   ttl,
 			},
 		}
 	} else {
@@ -601,7 +782,7 @@ func networkDNSHelper(action, address, domain, token string) error {
 				Type:  "A",
 				Name:  domain,
 				Value: address,
-				Ttl:   1,
+				Ttl:   ttl,
 			},
 		}
 	}
@@ -623,13 +804,13 @@ func networkDNSHelper(action, address, domain, token string) error {
 	return nil
 }
 
-func ListServices(c *cli.Context) ([]byte, error) {
+func ListServices(c *cli.Context, w io.Writer) error {
 	var rsp []*registry.Service
 	var err error
 
 	rsp, err = (*cmd.DefaultOptions().Registry).ListServices()
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	sort.Sort(sortedServices{rsp})
@@ -640,7 +821,11 @@ func ListServices(c *cli.Context) ([]byte, error) {
 		services = append(services, service.Name)
 	}
 
-	return []byte(strings.Join(services, "\n")), nil
+	for _, v := range services {
+		fmt.Fprintln(w, v)
+	}
+
+	return nil
 }
 
 func Publish(c *cli.Context, args []string) error {
@@ -671,9 +856,10 @@ func Publish(c *cli.Context, args []string) error {
 	return cl.Publish(ctx, m)
 }
 
-func CallService(c *cli.Context, args []string) ([]byte, error) {
+// CallService calls a service and streams the formatted response to w
+func CallService(c *cli.Context, args []string, w io.Writer) error {
 	if len(args) < 2 {
-		return nil, errors.New(`require service and endpoint e.g micro call greeeter Say.Hello '{"name": "john"}'`)
+		return errors.New(`require service and endpoint e.g micro call greeeter Say.Hello '{"name": "john"}'`)
 	}
 
 	var req, service, endpoint string
@@ -690,54 +876,149 @@ func CallService(c *cli.Context, args []string) ([]byte, error) {
 	}
 
 	var request map[string]interface{}
-	var response []byte
 
 	d := json.NewDecoder(strings.NewReader(req))
 	d.UseNumber()
 
 	if err := d.Decode(&request); err != nil {
-		return nil, err
+		return err
 	}
 
 	ctx := callContext(c)
 	creq := (*cmd.DefaultOptions().Client).NewRequest(service, endpoint, request, client.WithContentType("application/json"))
 
+	// fan out the call to every node
+	if c.Bool("all-nodes") {
+		return callAllNodes(ctx, creq, c.Int("concurrency"), w)
+	}
+
 	var opts []client.CallOption
 
 	if addr := c.String("address"); len(addr) > 0 {
 		opts = append(opts, client.WithAddress(addr))
 	}
 
-	var err error
+	// the response is read as is rather than decoded
+	rsp := cbytes.Frame{}
+	if err := (*cmd.DefaultOptions().Client).Call(ctx, creq, &rsp, opts...); err != nil {
+		return fmt.Errorf("error calling %s.%s: %v", service, endpoint, err)
+	}
+
 	if output := c.String("output"); output == "raw" {
-		rsp := cbytes.Frame{}
-		err = (*cmd.DefaultOptions().Client).Call(ctx, creq, &rsp, opts...)
-		// set the raw output
-		response = rsp.Data
-	} else {
-		var rsp json.RawMessage
-		err = (*cmd.DefaultOptions().Client).Call(ctx, creq, &rsp, opts...)
-		// set the response
-		if err == nil {
-			var out bytes.Buffer
-			defer out.Reset()
-			if err := json.Indent(&out, rsp, "", "\t"); err != nil {
-				return nil, err
-			}
-			response = out.Bytes()
+		// write the raw output
+		if _, err := w.Write(rsp.Data); err != nil {
+			return err
 		}
+	} else if err := WriteJSON(w, bytes.NewReader(rsp.Data)); err != nil {
+		// stream the indented response
+		return err
 	}
+	_, err := fmt.Fprintln(w)
+	return err
+}
+
+// nodeResponse is the result of calling a single node
+type nodeResponse struct {
+	Address  string          `json:"address"`
+	Version  string          `json:"version"`
+	Response json.RawMessage `json:"response,omitempty"`
+	Error    string          `json:"error,omitempty"`
+}
 
+// callAllNodes calls every node of the service with at most
+// concurrency calls in flight and writes the responses keyed by node id
+func callAllNodes(ctx context.Context, req client.Request, concurrency int, w io.Writer) error {
+	services, err := (*cmd.DefaultOptions().Registry).GetService(req.Service())
 	if err != nil {
-		return nil, fmt.Errorf("error calling %s.%s: %v", service, endpoint, err)
+		return err
+	}
+
+	if len(services) == 0 {
+		return errors.New("Service not found")
 	}
 
-	return response, nil
+	var ids []string
+	versions := make(map[string]string)
+	nodes := make(map[string]*registry.Node)
+
+	for _, srv := range services {
+		for _, node := range srv.Nodes {
+			ids = append(ids, node.Id)
+			versions[node.Id] = srv.Version
+			nodes[node.Id] = node
+		}
+	}
+
+	var mtx sync.Mutex
+	responses := make(map[string]*nodeResponse)
+
+	// failures are reported in the responses
+	bulk.Run(ids, concurrency, nil, func(id string) error {
+		node := nodes[id]
+
+		nrsp := &nodeResponse{
+			Address: node.Address,
+			Version: versions[id],
+		}
+
+		rsp := cbytes.Frame{}
+		err := (*cmd.DefaultOptions().Client).Call(ctx, req, &rsp, client.WithAddress(node.Address))
+		if err != nil {
+			nrsp.Error = err.Error()
+		} else {
+			nrsp.Response = rsp.Data
+		}
+
+		mtx.Lock()
+		responses[id] = nrsp
+		mtx.Unlock()
+
+		return err
+	})
+
+	sort.Strings(ids)
+
+	// the responses are encoded a node at a time as they're indented
+	pr, pw := io.Pipe()
+	defer pr.Close()
+
+	go func() {
+		enc := json.NewEncoder(pw)
+
+		if _, err := io.WriteString(pw, "{"); err != nil {
+			return
+		}
+		for i, id := range ids {
+			if i > 0 {
+				if _, err := io.WriteString(pw, ","); err != nil {
+					return
+				}
+			}
+			if err := enc.Encode(id); err != nil {
+				return
+			}
+			if _, err := io.WriteString(pw, ":"); err != nil {
+				return
+			}
+			if err := enc.Encode(responses[id]); err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+		}
+		io.WriteString(pw, "}")
+		pw.Close()
+	}()
+
+	if err := WriteJSON(w, pr); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w)
+	return err
 }
 
-func QueryHealth(c *cli.Context, args []string) ([]byte, error) {
+func QueryHealth(c *cli.Context, args []string, w io.Writer) error {
 	if len(args) == 0 {
-		return nil, errors.New("require service name")
+		return errors.New("require service name")
 	}
 
 	req := (*cmd.DefaultOptions().Client).NewRequest(args[0], "Debug.Health", &proto.HealthRequest{})
@@ -752,30 +1033,29 @@ func QueryHealth(c *cli.Context, args []string) ([]byte, error) {
 			client.WithAddress(addr),
 		)
 		if err != nil {
-			return nil, err
+			return err
 		}
-		return []byte(rsp.Status), nil
+		_, err = fmt.Fprintln(w, rsp.Status)
+		return err
 	}
 
 	// otherwise get the service and call each instance individually
 	service, err := (*cmd.DefaultOptions().Registry).GetService(args[0])
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	if len(service) == 0 {
-		return nil, errors.New("Service not found")
+		return errors.New("Service not found")
 	}
 
-	var output []string
-
 	// print things
-	output = append(output, "service  "+service[0].Name)
+	fmt.Fprintln(w, "service  "+service[0].Name)
 
 	for _, serv := range service {
 		// print things
-		output = append(output, "\nversion "+serv.Version)
-		output = append(output, "\nnode\t\taddress:port\t\tstatus")
+		fmt.Fprintln(w, "\nversion "+serv.Version)
+		fmt.Fprintln(w, "\nnode\t\taddress:port\t\tstatus")
 
 		// query health for every node
 		for _, node := range serv.Nodes {
@@ -798,38 +1078,36 @@ func QueryHealth(c *cli.Context, args []string) ([]byte, error) {
 			} else {
 				status = rsp.Status
 			}
-			output = append(output, fmt.Sprintf("%s\t\t%s\t\t%s", node.Id, node.Address, status))
+			fmt.Fprintf(w, "%s\t\t%s\t\t%s\n", node.Id, node.Address, status)
 		}
 	}
 
-	return []byte(strings.Join(output, "\n")), nil
+	return nil
 }
 
-func QueryStats(c *cli.Context, args []string) ([]byte, error) {
+func QueryStats(c *cli.Context, args []string, w io.Writer) error {
 	if len(args) == 0 {
-		return nil, errors.New("require service name")
+		return errors.New("require service name")
 	}
 
 	service, err := (*cmd.DefaultOptions().Registry).GetService(args[0])
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	if len(service) == 0 {
-		return nil, errors.New("Service not found")
+		return errors.New("Service not found")
 	}
 
 	req := (*cmd.DefaultOptions().Client).NewRequest(service[0].Name, "Debug.Stats", &proto.StatsRequest{})
 
-	var output []string
-
 	// print things
-	output = append(output, "service  "+service[0].Name)
+	fmt.Fprintln(w, "service  "+service[0].Name)
 
 	for _, serv := range service {
 		// print things
-		output = append(output, "\nversion "+serv.Version)
-		output = append(output, "\nnode\t\taddress:port\t\tstarted\tuptime\tmemory\tthreads\tgc")
+		fmt.Fprintln(w, "\nversion "+serv.Version)
+		fmt.Fprintln(w, "\nnode\t\taddress:port\t\tstarted\tuptime\tmemory\tthreads\tgc")
 
 		// query health for every node
 		for _, node := range serv.Nodes {
@@ -854,12 +1132,10 @@ func QueryStats(c *cli.Context, args []string) ([]byte, error) {
 				gc = fmt.Sprintf("%v", time.Duration(rsp.Gc))
 			}
 
-			line := fmt.Sprintf("%s\t\t%s\t\t%s\t%s\t%s\t%d\t%s",
+			fmt.Fprintf(w, "%s\t\t%s\t\t%s\t%s\t%s\t%d\t%s\n",
 				node.Id, node.Address, started, uptime, memory, rsp.Threads, gc)
-
-			output = append(output, line)
 		}
 	}
 
-	return []byte(strings.Join(output, "\n")), nil
+	return nil
 }
@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// parseDescriptorSet parses a FileDescriptorSet as output by protoc -o
+func parseDescriptorSet(b []byte) (*protoDef, error) {
+	set := new(descriptor.FileDescriptorSet)
+	if err := proto.Unmarshal(b, set); err != nil {
+		return nil, err
+	}
+
+	def := newProtoDef()
+
+	// the services are registered under the package of the file defining them
+	for _, f := range set.File {
+		if len(f.Service) > 0 {
+			def.pkg = f.GetPackage()
+		}
+	}
+
+	// map fields reference the entry messages generated for them
+	entries := make(map[string]*descriptor.DescriptorProto)
+
+	var collect func(scope string, msgs []*descriptor.DescriptorProto)
+	collect = func(scope string, msgs []*descriptor.DescriptorProto) {
+		for _, m := range msgs {
+			name := scope + "." + m.GetName()
+			if m.GetOptions().GetMapEntry() {
+				entries[name] = m
+			}
+			collect(name, m.NestedType)
+		}
+	}
+
+	for _, f := range set.File {
+		collect("."+f.GetPackage(), f.MessageType)
+	}
+
+	fieldType := func(f *descriptor.FieldDescriptorProto) string {
+		switch f.GetType() {
+		case descriptor.FieldDescriptorProto_TYPE_MESSAGE, descriptor.FieldDescriptorProto_TYPE_ENUM:
+			return f.GetTypeName()
+		}
+		return strings.ToLower(strings.TrimPrefix(f.GetType().String(), "TYPE_"))
+	}
+
+	var add func(pkg, scope string, msgs []*descriptor.DescriptorProto, enums []*descriptor.EnumDescriptorProto)
+	add = func(pkg, scope string, msgs []*descriptor.DescriptorProto, enums []*descriptor.EnumDescriptorProto) {
+		for _, e := range enums {
+			def.enums[e.GetName()] = true
+		}
+
+		for _, m := range msgs {
+			if m.GetOptions().GetMapEntry() {
+				continue
+			}
+
+			name := m.GetName()
+			if len(scope) > 0 {
+				name = scope + "." + name
+			}
+
+			msg := &protoMessage{name: name}
+			for _, f := range m.Field {
+				pf := &protoField{
+					name:     f.GetName(),
+					typ:      fieldType(f),
+					repeated: f.GetLabel() == descriptor.FieldDescriptorProto_LABEL_REPEATED,
+				}
+				if e, ok := entries[f.GetTypeName()]; ok && len(e.Field) == 2 {
+					pf.key = fieldType(e.Field[0])
+					pf.typ = fieldType(e.Field[1])
+					pf.repeated = false
+				}
+				msg.fields = append(msg.fields, pf)
+			}
+
+			// messages of other packages are keyed by their full name
+			key := name
+			if pkg != def.pkg && len(pkg) > 0 {
+				key = pkg + "." + name
+			}
+			def.messages[key] = msg
+
+			add(pkg, name, m.NestedType, m.EnumType)
+		}
+	}
+
+	for _, f := range set.File {
+		add(f.GetPackage(), "", f.MessageType, f.EnumType)
+
+		for _, s := range f.Service {
+			svc := &protoService{name: s.GetName()}
+			for _, m := range s.Method {
+				svc.methods = append(svc.methods, &protoMethod{
+					name:     m.GetName(),
+					request:  m.GetInputType(),
+					response: m.GetOutputType(),
+					stream:   m.GetClientStreaming() || m.GetServerStreaming(),
+				})
+			}
+			def.services = append(def.services, svc)
+		}
+	}
+
+	return def, nil
+}
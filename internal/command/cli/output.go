@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+var (
+	// ChunkSize is the size of the chunks written to the terminal
+	ChunkSize = 32 * 1024
+)
+
+// NewWriter returns a writer which flushes output to w in chunks
+func NewWriter(w io.Writer) *bufio.Writer {
+	return bufio.NewWriterSize(w, ChunkSize)
+}
+
+// level is an object or array being written
+type level struct {
+	object bool
+	// keys and values written
+	n int
+}
+
+// WriteJSON streams an indented copy of the json read from r to w. It's
+// decoded a token at a time so memory usage stays flat regardless of the
+// size of the response, and invalid json fails once it's reached.
+func WriteJSON(w io.Writer, r io.Reader) error {
+	d := json.NewDecoder(r)
+	d.UseNumber()
+
+	bw := NewWriter(w)
+
+	// strings are escaped as they were by json.Indent
+	var str bytes.Buffer
+	enc := json.NewEncoder(&str)
+	enc.SetEscapeHTML(false)
+
+	var levels []*level
+	var written bool
+
+	newline := func() {
+		bw.WriteByte('\n')
+		for range levels {
+			bw.WriteByte('\t')
+		}
+	}
+
+	for {
+		t, err := d.Token()
+		if err == io.EOF && len(levels) > 0 {
+			err = io.ErrUnexpectedEOF
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("invalid json response: %v", err)
+		}
+
+		// close the object or array
+		if t == json.Delim('}') || t == json.Delim(']') {
+			l := levels[len(levels)-1]
+			levels = levels[:len(levels)-1]
+			if l.n > 0 {
+				newline()
+			}
+			bw.WriteString(t.(json.Delim).String())
+			continue
+		}
+
+		// separate the key or value from the previous
+		if len(levels) > 0 {
+			l := levels[len(levels)-1]
+			if l.object && l.n%2 == 1 {
+				bw.WriteString(": ")
+			} else {
+				if l.n > 0 {
+					bw.WriteByte(',')
+				}
+				newline()
+			}
+			l.n++
+		} else if written {
+			bw.WriteByte('\n')
+		}
+		written = true
+
+		switch v := t.(type) {
+		case json.Delim:
+			bw.WriteString(v.String())
+			levels = append(levels, &level{object: v == '{'})
+		case string:
+			str.Reset()
+			enc.Encode(v)
+			// the encoder appends a newline
+			bw.Write(bytes.TrimSuffix(str.Bytes(), []byte{'\n'}))
+		case json.Number:
+			bw.WriteString(v.String())
+		case bool:
+			fmt.Fprint(bw, v)
+		case nil:
+			bw.WriteString("null")
+		}
+	}
+
+	return bw.Flush()
+}
@@ -0,0 +1,37 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestWriteJSON(t *testing.T) {
+	testData := []string{
+		`{"name":"john","tags":["a","b"],"empty":{},"none":[],"nested":{"n":1.50,"ok":true,"nil":null}}`,
+		`[{"html":"<b>&</b>","escaped":"line\nbreak \"quoted\""}, 1e3]`,
+		`"text"`,
+		`{}`,
+	}
+
+	for _, d := range testData {
+		var expect bytes.Buffer
+		if err := json.Indent(&expect, []byte(d), "", "\t"); err != nil {
+			t.Fatal(err)
+		}
+
+		var out bytes.Buffer
+		if err := WriteJSON(&out, strings.NewReader(d)); err != nil {
+			t.Fatal(err)
+		}
+
+		if out.String() != expect.String() {
+			t.Fatalf("Expected\n%s\ngot\n%s", expect.String(), out.String())
+		}
+	}
+
+	if err := WriteJSON(&bytes.Buffer{}, strings.NewReader(`{"name":`)); err == nil {
+		t.Fatal("Expected invalid json to fail")
+	}
+}
@@ -0,0 +1,454 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/micro/go-micro/v2/registry"
+)
+
+var (
+	// maxDepth of the request and response value trees
+	maxDepth = 3
+
+	// Go types of the proto scalar types as reported by go-micro
+	scalarTypes = map[string]string{
+		"double":   "float64",
+		"float":    "float32",
+		"int32":    "int32",
+		"sint32":   "int32",
+		"sfixed32": "int32",
+		"int64":    "int64",
+		"sint64":   "int64",
+		"sfixed64": "int64",
+		"uint32":   "uint32",
+		"fixed32":  "uint32",
+		"uint64":   "uint64",
+		"fixed64":  "uint64",
+		"bool":     "bool",
+		"string":   "string",
+		"bytes":    "[]uint8",
+	}
+)
+
+// protoField is a field of a message
+type protoField struct {
+	name     string
+	typ      string
+	repeated bool
+	// key type of a map field
+	key string
+}
+
+// protoMessage is a message keyed by its name within the package e.g Outer.Inner
+type protoMessage struct {
+	name   string
+	fields []*protoField
+}
+
+// protoMethod is an rpc of a service
+type protoMethod struct {
+	name     string
+	request  string
+	response string
+	stream   bool
+}
+
+// protoService is a service of the proto
+type protoService struct {
+	name    string
+	methods []*protoMethod
+}
+
+// protoDef is the definition parsed from a proto file or descriptor set
+type protoDef struct {
+	pkg      string
+	messages map[string]*protoMessage
+	enums    map[string]bool
+	services []*protoService
+}
+
+func newProtoDef() *protoDef {
+	return &protoDef{
+		messages: make(map[string]*protoMessage),
+		enums:    make(map[string]bool),
+	}
+}
+
+// protoParser is a lenient parser of the messages, enums and services of a proto file
+type protoParser struct {
+	def    *protoDef
+	tokens []string
+	pos    int
+}
+
+// tokenize splits the proto into identifiers, strings and symbols dropping comments
+func tokenize(src string) []string {
+	var tokens []string
+
+	for i := 0; i < len(src); {
+		c := src[i]
+
+		switch {
+		case unicode.IsSpace(rune(c)):
+			i++
+		case strings.HasPrefix(src[i:], "//"):
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				return tokens
+			}
+			i += end + 4
+		case c == '"' || c == '\'':
+			j := i + 1
+			for j < len(src) && src[j] != c {
+				if src[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j >= len(src) {
+				j = len(src) - 1
+			}
+			tokens = append(tokens, src[i:j+1])
+			i = j + 1
+		case c == '_' || c == '.' || unicode.IsLetter(rune(c)) || unicode.IsDigit(rune(c)):
+			j := i
+			for j < len(src) && (src[j] == '_' || src[j] == '.' || unicode.IsLetter(rune(src[j])) || unicode.IsDigit(rune(src[j]))) {
+				j++
+			}
+			tokens = append(tokens, src[i:j])
+			i = j
+		default:
+			tokens = append(tokens, string(c))
+			i++
+		}
+	}
+
+	return tokens
+}
+
+// parseProto parses the source of a proto file
+func parseProto(src string) (*protoDef, error) {
+	p := &protoParser{
+		def:    newProtoDef(),
+		tokens: tokenize(src),
+	}
+	if err := p.parse(); err != nil {
+		return nil, err
+	}
+	return p.def, nil
+}
+
+func (p *protoParser) peek() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *protoParser) next() string {
+	t := p.peek()
+	p.pos++
+	return t
+}
+
+func (p *protoParser) expect(t string) error {
+	if got := p.next(); got != t {
+		return fmt.Errorf("expected %q got %q", t, got)
+	}
+	return nil
+}
+
+// skipStatement skips to the end of the statement including any block
+func (p *protoParser) skipStatement() {
+	for p.pos < len(p.tokens) {
+		switch p.next() {
+		case ";":
+			return
+		case "{":
+			p.skipBlock()
+			return
+		}
+	}
+}
+
+// skipBlock skips to the brace closing the opened block
+func (p *protoParser) skipBlock() {
+	for depth := 1; depth > 0 && p.pos < len(p.tokens); {
+		switch p.next() {
+		case "{":
+			depth++
+		case "}":
+			depth--
+		}
+	}
+}
+
+func (p *protoParser) parse() error {
+	for p.pos < len(p.tokens) {
+		switch p.next() {
+		case "package":
+			p.def.pkg = p.next()
+			p.skipStatement()
+		case "message":
+			if err := p.parseMessage(""); err != nil {
+				return err
+			}
+		case "enum":
+			p.def.enums[p.next()] = true
+			p.skipStatement()
+		case "service":
+			if err := p.parseService(); err != nil {
+				return err
+			}
+		case ";":
+		default:
+			// syntax, import, option and extend
+			p.skipStatement()
+		}
+	}
+	return nil
+}
+
+func (p *protoParser) parseMessage(scope string) error {
+	name := p.next()
+	if len(scope) > 0 {
+		name = scope + "." + name
+	}
+	if err := p.expect("{"); err != nil {
+		return fmt.Errorf("message %s: %v", name, err)
+	}
+
+	msg := &protoMessage{name: name}
+	p.def.messages[name] = msg
+
+	for {
+		switch t := p.next(); t {
+		case "}":
+			return nil
+		case "":
+			return fmt.Errorf("message %s is not closed", name)
+		case "message":
+			if err := p.parseMessage(name); err != nil {
+				return err
+			}
+		case "enum":
+			p.def.enums[name+"."+p.next()] = true
+			p.skipStatement()
+		case "oneof":
+			// the fields of a oneof are fields of the message
+			p.next()
+			if err := p.expect("{"); err != nil {
+				return fmt.Errorf("message %s: %v", name, err)
+			}
+			for p.peek() != "}" && p.peek() != "" {
+				if p.peek() == "option" {
+					p.skipStatement()
+					continue
+				}
+				msg.fields = append(msg.fields, p.parseField(p.next()))
+			}
+			p.next()
+		case "option", "reserved", "extensions", "extend", ";":
+			if t != ";" {
+				p.skipStatement()
+			}
+		default:
+			msg.fields = append(msg.fields, p.parseField(t))
+		}
+	}
+}
+
+// parseField parses a field starting with the token e.g repeated string names = 1;
+func (p *protoParser) parseField(t string) *protoField {
+	f := new(protoField)
+
+	switch t {
+	case "repeated":
+		f.repeated = true
+		t = p.next()
+	case "optional", "required":
+		t = p.next()
+	}
+
+	if t == "map" {
+		// map < key , value >
+		p.next()
+		f.key = p.next()
+		p.next()
+		t = p.next()
+		p.next()
+	}
+
+	f.typ = t
+	f.name = p.next()
+	p.skipStatement()
+
+	return f
+}
+
+func (p *protoParser) parseService() error {
+	svc := &protoService{name: p.next()}
+	if err := p.expect("{"); err != nil {
+		return fmt.Errorf("service %s: %v", svc.name, err)
+	}
+
+	for {
+		switch p.next() {
+		case "}":
+			p.def.services = append(p.def.services, svc)
+			return nil
+		case "":
+			return fmt.Errorf("service %s is not closed", svc.name)
+		case "rpc":
+			m, err := p.parseMethod()
+			if err != nil {
+				return fmt.Errorf("service %s: %v", svc.name, err)
+			}
+			svc.methods = append(svc.methods, m)
+		case ";":
+		default:
+			p.skipStatement()
+		}
+	}
+}
+
+// parseMethod parses an rpc e.g rpc Call(Request) returns (stream Response) {}
+func (p *protoParser) parseMethod() (*protoMethod, error) {
+	m := &protoMethod{name: p.next()}
+
+	arg := func() (string, error) {
+		if err := p.expect("("); err != nil {
+			return "", err
+		}
+		t := p.next()
+		if t == "stream" {
+			m.stream = true
+			t = p.next()
+		}
+		return t, p.expect(")")
+	}
+
+	var err error
+	if m.request, err = arg(); err != nil {
+		return nil, fmt.Errorf("rpc %s: %v", m.name, err)
+	}
+	if err := p.expect("returns"); err != nil {
+		return nil, fmt.Errorf("rpc %s: %v", m.name, err)
+	}
+	if m.response, err = arg(); err != nil {
+		return nil, fmt.Errorf("rpc %s: %v", m.name, err)
+	}
+	p.skipStatement()
+
+	return m, nil
+}
+
+// message resolves the type referenced from the scope to a message of the package
+func (d *protoDef) message(scope, typ string) (*protoMessage, bool) {
+	typ = strings.TrimPrefix(typ, ".")
+	if len(d.pkg) > 0 {
+		typ = strings.TrimPrefix(typ, d.pkg+".")
+	}
+
+	// search the enclosing scopes innermost first
+	for {
+		name := typ
+		if len(scope) > 0 {
+			name = scope + "." + typ
+		}
+		if m, ok := d.messages[name]; ok {
+			return m, true
+		}
+		if len(scope) == 0 {
+			return nil, false
+		}
+		if i := strings.LastIndex(scope, "."); i > 0 {
+			scope = scope[:i]
+		} else {
+			scope = ""
+		}
+	}
+}
+
+// goType returns the type name reported by go-micro for the proto type
+func (d *protoDef) goType(scope, typ string) string {
+	if t, ok := scalarTypes[typ]; ok {
+		return t
+	}
+	if m, ok := d.message(scope, typ); ok {
+		// nested messages are named Outer_Inner in Go
+		return strings.Replace(m.name, ".", "_", -1)
+	}
+	// enums and types imported from other files
+	if i := strings.LastIndex(typ, "."); i >= 0 {
+		typ = typ[i+1:]
+	}
+	if d.enums[typ] {
+		return "int32"
+	}
+	return typ
+}
+
+// value returns the value tree of the message as extracted by go-micro
+func (d *protoDef) value(name, scope, typ string, depth int) *registry.Value {
+	v := &registry.Value{
+		Name: name,
+		Type: d.goType(scope, typ),
+	}
+
+	m, ok := d.message(scope, typ)
+	if !ok || depth >= maxDepth {
+		return v
+	}
+
+	for _, f := range m.fields {
+		fv := d.value(f.name, m.name, f.typ, depth+1)
+		switch {
+		case len(f.key) > 0:
+			fv.Type = fmt.Sprintf("map[%s]%s", d.goType(m.name, f.key), fv.Type)
+		case f.repeated:
+			fv.Type = "[]" + fv.Type
+		}
+		v.Values = append(v.Values, fv)
+	}
+
+	return v
+}
+
+// service returns the definition of the services of the proto for registration
+func (d *protoDef) service(name, version string) (*registry.Service, error) {
+	if len(d.services) == 0 {
+		return nil, fmt.Errorf("no services defined")
+	}
+
+	if len(name) == 0 {
+		name = d.pkg
+	}
+	if len(name) == 0 {
+		return nil, fmt.Errorf("no package to name the service by, set the name")
+	}
+
+	srv := &registry.Service{
+		Name:    name,
+		Version: version,
+	}
+
+	for _, s := range d.services {
+		for _, m := range s.methods {
+			srv.Endpoints = append(srv.Endpoints, &registry.Endpoint{
+				Name:     s.name + "." + m.name,
+				Request:  d.value(d.goType("", m.request), "", m.request, 0),
+				Response: d.value(d.goType("", m.response), "", m.response, 0),
+				Metadata: map[string]string{"stream": fmt.Sprintf("%v", m.stream)},
+			})
+		}
+	}
+
+	return srv, nil
+}
@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"testing"
+)
+
+const testProto = `
+syntax = "proto3";
+
+// Package greeter
+package go.micro.srv.greeter;
+
+import "google/protobuf/timestamp.proto";
+
+option go_package = "greeter";
+
+service Say {
+	// Hello says hello
+	rpc Hello(Request) returns (Response) {}
+	rpc Stream(stream Request) returns (stream Response) {
+		option (google.api.http) = { post: "/stream"; body: "*" };
+	}
+}
+
+enum Mood {
+	HAPPY = 0;
+	SAD = 1;
+}
+
+message Request {
+	string name = 1; /* the name */
+	repeated string tags = 2 [deprecated = true];
+	map<string, Meta> meta = 3;
+	Mood mood = 4;
+	oneof from {
+		string email = 5;
+		int64 id = 6;
+	}
+	google.protobuf.Timestamp sent = 7;
+
+	message Meta {
+		bytes data = 1;
+	}
+}
+
+message Response {
+	string msg = 1;
+	Request.Meta meta = 2;
+}
+`
+
+func TestParseProto(t *testing.T) {
+	def, err := parseProto(testProto)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv, err := def.service("", "latest")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if srv.Name != "go.micro.srv.greeter" {
+		t.Fatalf("Expected the service to be named by the package got %s", srv.Name)
+	}
+	if len(srv.Endpoints) != 2 {
+		t.Fatalf("Expected 2 endpoints got %d", len(srv.Endpoints))
+	}
+
+	hello := srv.Endpoints[0]
+	if hello.Name != "Say.Hello" || hello.Metadata["stream"] != "false" {
+		t.Fatalf("Unexpected endpoint %s %v", hello.Name, hello.Metadata)
+	}
+	if srv.Endpoints[1].Name != "Say.Stream" || srv.Endpoints[1].Metadata["stream"] != "true" {
+		t.Fatalf("Expected Say.Stream to stream got %s %v", srv.Endpoints[1].Name, srv.Endpoints[1].Metadata)
+	}
+
+	req := hello.Request
+	if req.Name != "Request" || req.Type != "Request" {
+		t.Fatalf("Unexpected request %s %s", req.Name, req.Type)
+	}
+
+	expect := map[string]string{
+		"name":  "string",
+		"tags":  "[]string",
+		"meta":  "map[string]Request_Meta",
+		"mood":  "int32",
+		"email": "string",
+		"id":    "int64",
+		"sent":  "Timestamp",
+	}
+	if len(req.Values) != len(expect) {
+		t.Fatalf("Expected %d fields got %d", len(expect), len(req.Values))
+	}
+	for _, v := range req.Values {
+		if expect[v.Name] != v.Type {
+			t.Fatalf("Expected %s to be %s got %s", v.Name, expect[v.Name], v.Type)
+		}
+	}
+
+	meta := hello.Response.Values[1]
+	if meta.Type != "Request_Meta" || len(meta.Values) != 1 || meta.Values[0].Type != "[]uint8" {
+		t.Fatalf("Expected the nested message to be resolved got %+v", meta)
+	}
+
+	if _, err := parseProto(`message Broken { string name = 1;`); err == nil {
+		t.Fatal("Expected an error for an unclosed message")
+	}
+}
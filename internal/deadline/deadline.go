@@ -0,0 +1,220 @@
+// Package deadline propagates the remaining deadline budget across service
+// hops so calls are rejected once the caller has already timed out
+package deadline
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/micro/go-micro/v2/client"
+	"github.com/micro/go-micro/v2/debug/trace"
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/metadata"
+	"github.com/micro/go-micro/v2/server"
+)
+
+var (
+	// BudgetHeader is the remaining budget in milliseconds
+	BudgetHeader = "Micro-Deadline-Budget"
+	// HopsHeader is the budget consumed by each hop e.g go.micro.api=3ms,go.micro.proxy=1ms
+	HopsHeader = "Micro-Deadline-Hops"
+	// Minimum budget below which calls are rejected rather than made
+	Minimum = time.Millisecond * 5
+)
+
+type hopKey struct{}
+
+// hop is the budget as it arrived at this hop
+type hop struct {
+	name    string
+	arrived time.Time
+	budget  time.Duration
+	span    *trace.Span
+}
+
+// Budget returns the remaining budget set in the metadata
+func Budget(ctx context.Context) (time.Duration, bool) {
+	md, ok := metadata.FromContext(ctx)
+	if !ok {
+		return 0, false
+	}
+	return parse(md[BudgetHeader])
+}
+
+func parse(v string) (time.Duration, bool) {
+	if len(v) == 0 {
+		return 0, false
+	}
+	ms, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return time.Duration(ms) * time.Millisecond, true
+}
+
+// Start the hop setting the deadline from the budget, an error is
+// returned if the budget is already exhausted. Done must be called
+// once the hop has completed to record its trace.
+func Start(ctx context.Context, name string) (context.Context, func(), error) {
+	budget, ok := Budget(ctx)
+	return start(ctx, name, budget, ok)
+}
+
+func start(ctx context.Context, name string, budget time.Duration, ok bool) (context.Context, func(), error) {
+	// fall back to the deadline set by the caller e.g the timeout header
+	if !ok {
+		d, dok := ctx.Deadline()
+		if !dok {
+			return ctx, func() {}, nil
+		}
+		budget = time.Until(d)
+	}
+
+	if budget < Minimum {
+		return ctx, func() {}, fmt.Errorf("deadline budget exhausted with %v remaining", budget)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, budget)
+
+	h := &hop{
+		name:    name,
+		arrived: time.Now(),
+		budget:  budget,
+	}
+
+	ctx, h.span = trace.DefaultTracer.Start(ctx, name+".Budget")
+	if h.span.Metadata == nil {
+		h.span.Metadata = make(map[string]string)
+	}
+	h.span.Metadata["budget"] = budget.String()
+	if md, ok := metadata.FromContext(ctx); ok && len(md[HopsHeader]) > 0 {
+		h.span.Metadata["hops"] = md[HopsHeader]
+	}
+
+	ctx = context.WithValue(ctx, hopKey{}, h)
+
+	done := func() {
+		cancel()
+		// the hop never forwarded the call
+		if _, ok := h.span.Metadata["consumed"]; !ok {
+			h.span.Metadata["consumed"] = time.Since(h.arrived).String()
+		}
+		trace.DefaultTracer.Finish(h.span)
+	}
+
+	return ctx, done, nil
+}
+
+// Forward returns the context for an outgoing call with the remaining
+// budget and the budget consumed by this hop added to the metadata
+func Forward(ctx context.Context) (context.Context, time.Duration, error) {
+	d, ok := ctx.Deadline()
+	if !ok {
+		return ctx, 0, nil
+	}
+
+	remaining := time.Until(d)
+	if remaining < Minimum {
+		return ctx, 0, fmt.Errorf("deadline budget exhausted with %v remaining", remaining)
+	}
+
+	md := metadata.Metadata{}
+	if cur, ok := metadata.FromContext(ctx); ok {
+		for k, v := range cur {
+			md[k] = v
+		}
+	}
+
+	md[BudgetHeader] = strconv.FormatInt(int64(remaining/time.Millisecond), 10)
+
+	if h, ok := ctx.Value(hopKey{}).(*hop); ok {
+		consumed := h.budget - remaining
+		if consumed < 0 {
+			consumed = 0
+		}
+
+		hops := h.name + "=" + consumed.Round(time.Millisecond).String()
+		if len(md[HopsHeader]) > 0 {
+			hops = md[HopsHeader] + "," + hops
+		}
+		md[HopsHeader] = hops
+
+		h.span.Metadata["consumed"] = consumed.String()
+		h.span.Metadata["remaining"] = remaining.String()
+	}
+
+	return metadata.NewContext(ctx, md), remaining, nil
+}
+
+type deadlineClient struct {
+	client.Client
+}
+
+func (c *deadlineClient) Call(ctx context.Context, req client.Request, rsp interface{}, opts ...client.CallOption) error {
+	ctx, remaining, err := Forward(ctx)
+	if err != nil {
+		return errors.Timeout(req.Service(), err.Error())
+	}
+	if remaining > 0 {
+		opts = append(opts, client.WithRequestTimeout(remaining))
+	}
+	return c.Client.Call(ctx, req, rsp, opts...)
+}
+
+func (c *deadlineClient) Stream(ctx context.Context, req client.Request, opts ...client.CallOption) (client.Stream, error) {
+	ctx, remaining, err := Forward(ctx)
+	if err != nil {
+		return nil, errors.Timeout(req.Service(), err.Error())
+	}
+	if remaining > 0 {
+		opts = append(opts, client.WithRequestTimeout(remaining))
+	}
+	return c.Client.Stream(ctx, req, opts...)
+}
+
+// NewClientWrapper passes the remaining budget on to outgoing calls
+func NewClientWrapper() client.Wrapper {
+	return func(c client.Client) client.Client {
+		return &deadlineClient{c}
+	}
+}
+
+type deadlineRouter struct {
+	server.Router
+	name string
+}
+
+func (r *deadlineRouter) ServeRequest(ctx context.Context, req server.Request, rsp server.Response) error {
+	ctx, done, err := Start(ctx, r.name)
+	if err != nil {
+		return errors.Timeout(r.name, err.Error())
+	}
+	defer done()
+	return r.Router.ServeRequest(ctx, req, rsp)
+}
+
+// NewRouter starts a hop for each request served by the router e.g the proxy
+func NewRouter(name string, r server.Router) server.Router {
+	return &deadlineRouter{r, name}
+}
+
+// NewHandler starts a hop for each http request using the budget header
+func NewHandler(name string, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		budget, ok := parse(r.Header.Get(BudgetHeader))
+
+		ctx, done, err := start(r.Context(), name, budget, ok)
+		if err != nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusGatewayTimeout)
+			w.Write([]byte(errors.Timeout(name, err.Error()).Error()))
+			return
+		}
+		defer done()
+
+		h.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
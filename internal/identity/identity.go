@@ -0,0 +1,90 @@
+// Package identity mints the signed identity documents the runtime
+// injects into services so they never need static credentials
+package identity
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/micro/micro/v2/internal/token"
+)
+
+var (
+	// Key used to sign identity documents, minting is disabled when blank
+	Key = os.Getenv("MICRO_IDENTITY_KEY")
+	// Env is the environment variable the document is injected as
+	Env = "MICRO_IDENTITY"
+	// TTL of a document, services are restarted with a new one by the runtime
+	TTL = time.Hour * 24 * 30
+	// Refresh is how long before a document expires the service is restarted with a new one
+	Refresh = time.Hour * 24
+
+	ErrInvalid = errors.New("identity document invalid")
+	ErrExpired = errors.New("identity document expired")
+)
+
+// Document identifies a service started by the runtime
+type Document struct {
+	Service   string
+	Version   string
+	Namespace string
+	Revision  int
+	Issued    int64
+	Expires   int64
+}
+
+// Mint a signed document for the service
+func Mint(doc *Document) (string, error) {
+	if len(Key) == 0 {
+		return "", errors.New("MICRO_IDENTITY_KEY is not set")
+	}
+
+	now := time.Now()
+	doc.Issued = now.Unix()
+	doc.Expires = now.Add(TTL).Unix()
+
+	tk := token.New()
+	tk.Expires = uint64(doc.Expires)
+	tk.Claims["type"] = "identity"
+	tk.Claims["service"] = doc.Service
+	tk.Claims["version"] = doc.Version
+	tk.Claims["namespace"] = doc.Namespace
+	tk.Claims["revision"] = strconv.Itoa(doc.Revision)
+	tk.Claims["issued"] = strconv.FormatInt(doc.Issued, 10)
+
+	return tk.Encode(Key)
+}
+
+// Verify the signature and expiry of a document
+func Verify(doc string) (*Document, error) {
+	if len(Key) == 0 {
+		return nil, errors.New("MICRO_IDENTITY_KEY is not set")
+	}
+
+	tk := token.New()
+	if err := tk.Decode(Key, []byte(doc)); err != nil {
+		return nil, ErrInvalid
+	}
+
+	if tk.Claims["type"] != "identity" || len(tk.Claims["service"]) == 0 {
+		return nil, ErrInvalid
+	}
+
+	if int64(tk.Expires) < time.Now().Unix() {
+		return nil, ErrExpired
+	}
+
+	revision, _ := strconv.Atoi(tk.Claims["revision"])
+	issued, _ := strconv.ParseInt(tk.Claims["issued"], 10, 64)
+
+	return &Document{
+		Service:   tk.Claims["service"],
+		Version:   tk.Claims["version"],
+		Namespace: tk.Claims["namespace"],
+		Revision:  revision,
+		Issued:    issued,
+		Expires:   int64(tk.Expires),
+	}, nil
+}
@@ -0,0 +1,117 @@
+// Package namespace scopes platform requests to a tenant namespace
+package namespace
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/micro/go-micro/v2/metadata"
+	"github.com/micro/micro/v2/internal/token"
+)
+
+var (
+	// Key used to sign namespace tokens, scoping is disabled when blank
+	Key = os.Getenv("MICRO_NAMESPACE_KEY")
+	// All is the namespace claim which grants access to every namespace
+	All = "*"
+
+	ErrTokenRequired = errors.New("namespace token required")
+	ErrTokenExpired  = errors.New("namespace token expired")
+	ErrTokenInvalid  = errors.New("namespace token invalid")
+)
+
+// Generate a token scoped to the namespace, the account is optional
+func Generate(namespace, account string, ttl time.Duration) (string, error) {
+	if len(Key) == 0 {
+		return "", errors.New("MICRO_NAMESPACE_KEY is not set")
+	}
+
+	tk := token.New()
+	tk.Expires = uint64(time.Now().Add(ttl).Unix())
+	tk.Claims["namespace"] = namespace
+	if len(account) > 0 {
+		tk.Claims["account"] = account
+	}
+
+	return tk.Encode(Key)
+}
+
+// decode the token of the request verifying its signature and expiry
+func decode(ctx context.Context) (*token.Token, error) {
+	md, _ := metadata.FromContext(ctx)
+	auth := md["Authorization"]
+	if !strings.HasPrefix(auth, "Bearer ") {
+		return nil, ErrTokenRequired
+	}
+
+	tk := token.New()
+	if err := tk.Decode(Key, []byte(strings.TrimPrefix(auth, "Bearer "))); err != nil {
+		return nil, ErrTokenInvalid
+	}
+
+	if int64(tk.Expires) < time.Now().Unix() {
+		return nil, ErrTokenExpired
+	}
+
+	return tk, nil
+}
+
+// FromContext returns the namespace the request is scoped to. All
+// namespaces are accessible when scoping is disabled.
+func FromContext(ctx context.Context) (string, error) {
+	if len(Key) == 0 {
+		return All, nil
+	}
+
+	tk, err := decode(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	ns := tk.Claims["namespace"]
+	if len(ns) == 0 {
+		return "", ErrTokenInvalid
+	}
+
+	return ns, nil
+}
+
+// Account returns the account of the verified token of the request,
+// it's blank if the token has none or scoping is disabled
+func Account(ctx context.Context) string {
+	if len(Key) == 0 {
+		return ""
+	}
+
+	tk, err := decode(ctx)
+	if err != nil {
+		return ""
+	}
+
+	return tk.Claims["account"]
+}
+
+// Allowed returns true if the service is within the namespace
+// e.g foo.api.orders is within the namespace foo
+func Allowed(namespace, service string) bool {
+	return namespace == All || service == namespace || strings.HasPrefix(service, namespace+".")
+}
+
+// NewContext returns a context which passes the token to platform services
+func NewContext(ctx context.Context, tk string) context.Context {
+	if len(tk) == 0 {
+		return ctx
+	}
+
+	md, _ := metadata.FromContext(ctx)
+	cp := make(map[string]string, len(md)+1)
+	for k, v := range md {
+		cp[k] = v
+	}
+	cp["Authorization"] = "Bearer " + tk
+
+	return metadata.NewContext(ctx, cp)
+}
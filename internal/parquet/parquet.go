@@ -0,0 +1,224 @@
+// Package parquet writes flat tables as apache parquet files. It supports
+// required int64, double and string columns in a single gzipped row group,
+// which is enough to export snapshots for athena, spark or duckdb.
+package parquet
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// Type of the values of a column
+type Type int32
+
+const (
+	Int64  Type = 2
+	Double Type = 5
+	String Type = 6
+)
+
+const (
+	magic = "PAR1"
+
+	// encodings and codecs of the format
+	encodingPlain = 0
+	encodingRLE   = 3
+	codecGzip     = 2
+
+	// converted type of strings
+	convertedUTF8 = 0
+)
+
+// Column of a table
+type Column struct {
+	Name string
+	Type Type
+}
+
+// Writer buffers the rows of a table and encodes them as a parquet file
+type Writer struct {
+	columns []Column
+	values  []*bytes.Buffer
+	rows    int
+}
+
+// NewWriter returns a writer of a table with the columns
+func NewWriter(columns ...Column) *Writer {
+	values := make([]*bytes.Buffer, len(columns))
+	for i := range values {
+		values[i] = bytes.NewBuffer(nil)
+	}
+	return &Writer{
+		columns: columns,
+		values:  values,
+	}
+}
+
+// Append a row, its values are in the order of the columns. Int64 columns
+// take any integer, Double columns float64 and String columns string.
+func (w *Writer) Append(row ...interface{}) error {
+	if len(row) != len(w.columns) {
+		return fmt.Errorf("parquet: expected %d values got %d", len(w.columns), len(row))
+	}
+
+	// check the row before writing any of it
+	for i, v := range row {
+		if err := check(w.columns[i], v); err != nil {
+			return err
+		}
+	}
+
+	for i, v := range row {
+		buf := w.values[i]
+		switch v := v.(type) {
+		case int:
+			binary.Write(buf, binary.LittleEndian, int64(v))
+		case int32:
+			binary.Write(buf, binary.LittleEndian, int64(v))
+		case int64:
+			binary.Write(buf, binary.LittleEndian, v)
+		case uint32:
+			binary.Write(buf, binary.LittleEndian, int64(v))
+		case uint64:
+			binary.Write(buf, binary.LittleEndian, int64(v))
+		case float64:
+			binary.Write(buf, binary.LittleEndian, math.Float64bits(v))
+		case string:
+			binary.Write(buf, binary.LittleEndian, uint32(len(v)))
+			buf.WriteString(v)
+		}
+	}
+
+	w.rows++
+	return nil
+}
+
+// check the value can be written to the column
+func check(c Column, v interface{}) error {
+	var ok bool
+	switch v.(type) {
+	case int, int32, int64, uint32, uint64:
+		ok = c.Type == Int64
+	case float64:
+		ok = c.Type == Double
+	case string:
+		ok = c.Type == String
+	}
+	if !ok {
+		return fmt.Errorf("parquet: invalid value %T for column %s", v, c.Name)
+	}
+	return nil
+}
+
+// Rows returns the number of rows appended
+func (w *Writer) Rows() int {
+	return w.rows
+}
+
+// Bytes encodes the rows as a parquet file
+func (w *Writer) Bytes() ([]byte, error) {
+	file := bytes.NewBufferString(magic)
+
+	var chunks [][]byte
+	var size int64
+
+	for i, c := range w.columns {
+		data := w.values[i].Bytes()
+
+		var gz bytes.Buffer
+		zw := gzip.NewWriter(&gz)
+		if _, err := zw.Write(data); err != nil {
+			return nil, err
+		}
+		if err := zw.Close(); err != nil {
+			return nil, err
+		}
+
+		// a single v1 data page, required columns have no levels
+		header := newEncoder()
+		header.i32(1, 0)
+		header.i32(2, int32(len(data)))
+		header.i32(3, int32(gz.Len()))
+		header.begin(5)
+		header.i32(1, int32(w.rows))
+		header.i32(2, encodingPlain)
+		header.i32(3, encodingRLE)
+		header.i32(4, encodingRLE)
+		header.end()
+		header.stop()
+
+		offset := int64(file.Len())
+		file.Write(header.Bytes())
+		file.Write(gz.Bytes())
+
+		uncompressed := int64(header.Len() + len(data))
+		compressed := int64(header.Len() + gz.Len())
+		size += uncompressed
+
+		chunk := newEncoder()
+		chunk.i64(2, offset)
+		chunk.begin(3)
+		chunk.i32(1, int32(c.Type))
+		chunk.list(2, typeI32, 2)
+		chunk.zigzag(encodingPlain)
+		chunk.zigzag(encodingRLE)
+		chunk.list(3, typeBinary, 1)
+		chunk.raw(c.Name)
+		chunk.i32(4, codecGzip)
+		chunk.i64(5, int64(w.rows))
+		chunk.i64(6, uncompressed)
+		chunk.i64(7, compressed)
+		chunk.i64(9, offset)
+		chunk.end()
+		chunk.stop()
+
+		chunks = append(chunks, chunk.Bytes())
+	}
+
+	// the root of the schema then a leaf per column
+	schema := []*encoder{newEncoder()}
+	schema[0].str(4, "schema")
+	schema[0].i32(5, int32(len(w.columns)))
+	schema[0].stop()
+	for _, c := range w.columns {
+		leaf := newEncoder()
+		leaf.i32(1, int32(c.Type))
+		leaf.i32(3, 0)
+		leaf.str(4, c.Name)
+		if c.Type == String {
+			leaf.i32(6, convertedUTF8)
+		}
+		leaf.stop()
+		schema = append(schema, leaf)
+	}
+
+	group := newEncoder()
+	group.list(1, typeStruct, len(chunks))
+	for _, c := range chunks {
+		group.Write(c)
+	}
+	group.i64(2, size)
+	group.i64(3, int64(w.rows))
+	group.stop()
+
+	meta := newEncoder()
+	meta.i32(1, 1)
+	meta.list(2, typeStruct, len(schema))
+	for _, e := range schema {
+		meta.Write(e.Bytes())
+	}
+	meta.i64(3, int64(w.rows))
+	meta.list(4, typeStruct, 1)
+	meta.Write(group.Bytes())
+	meta.str(6, "micro")
+	meta.stop()
+
+	file.Write(meta.Bytes())
+	binary.Write(file, binary.LittleEndian, uint32(meta.Len()))
+	file.WriteString(magic)
+
+	return file.Bytes(), nil
+}
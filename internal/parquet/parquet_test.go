@@ -0,0 +1,155 @@
+package parquet
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"io/ioutil"
+	"math"
+	"testing"
+)
+
+// decoder reads thrift compact structs into maps of field id to value
+type decoder struct {
+	b []byte
+}
+
+func (d *decoder) varint() uint64 {
+	v, n := binary.Uvarint(d.b)
+	d.b = d.b[n:]
+	return v
+}
+
+func (d *decoder) zigzag() int64 {
+	v := d.varint()
+	return int64(v>>1) ^ -int64(v&1)
+}
+
+func (d *decoder) value(typ byte) interface{} {
+	switch typ {
+	case typeI32, typeI64:
+		return d.zigzag()
+	case typeBinary:
+		n := d.varint()
+		v := string(d.b[:n])
+		d.b = d.b[n:]
+		return v
+	case typeList:
+		h := d.b[0]
+		d.b = d.b[1:]
+		size := int(h >> 4)
+		if size == 15 {
+			size = int(d.varint())
+		}
+		list := make([]interface{}, size)
+		for i := range list {
+			list[i] = d.value(h & 0x0f)
+		}
+		return list
+	case typeStruct:
+		return d.structure()
+	}
+	panic("unexpected type")
+}
+
+func (d *decoder) structure() map[int16]interface{} {
+	fields := make(map[int16]interface{})
+	var id int16
+	for {
+		h := d.b[0]
+		d.b = d.b[1:]
+		if h == 0 {
+			return fields
+		}
+		if delta := int16(h >> 4); delta > 0 {
+			id += delta
+		} else {
+			id = int16(d.zigzag())
+		}
+		fields[id] = d.value(h & 0x0f)
+	}
+}
+
+func TestWriter(t *testing.T) {
+	w := NewWriter(
+		Column{Name: "service", Type: String},
+		Column{Name: "requests", Type: Int64},
+		Column{Name: "p99", Type: Double},
+	)
+
+	if err := w.Append("go.micro.srv.foo", uint64(10), 1.5); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Append("go.micro.srv.bar", 20, 2.5); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Append("go.micro.srv.baz", "30", 3.5); err == nil {
+		t.Fatal("expected an error appending a string to an int64 column")
+	}
+
+	b, err := w.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(b[:4]) != magic || string(b[len(b)-4:]) != magic {
+		t.Fatal("expected the file to start and end with the magic")
+	}
+
+	size := binary.LittleEndian.Uint32(b[len(b)-8:])
+	footer := &decoder{b: b[len(b)-8-int(size) : len(b)-8]}
+	meta := footer.structure()
+
+	if rows := meta[3].(int64); rows != 2 {
+		t.Fatalf("expected 2 rows got %d", rows)
+	}
+
+	schema := meta[2].([]interface{})
+	if len(schema) != 4 {
+		t.Fatalf("expected the root and 3 columns in the schema got %d", len(schema))
+	}
+	if name := schema[1].(map[int16]interface{})[4]; name != "service" {
+		t.Fatalf("expected the service column got %v", name)
+	}
+
+	// read the values of the requests column back
+	group := meta[4].([]interface{})[0].(map[int16]interface{})
+	chunk := group[1].([]interface{})[1].(map[int16]interface{})
+	offset := chunk[3].(map[int16]interface{})[9].(int64)
+
+	page := &decoder{b: b[offset:]}
+	header := page.structure()
+	compressed := header[3].(int64)
+
+	zr, err := gzip.NewReader(bytes.NewReader(page.b[:compressed]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadAll(zr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != int(header[2].(int64)) {
+		t.Fatalf("expected %d bytes got %d", header[2], len(data))
+	}
+
+	for i, expect := range []uint64{10, 20} {
+		if v := binary.LittleEndian.Uint64(data[i*8:]); v != expect {
+			t.Fatalf("expected %d got %d", expect, v)
+		}
+	}
+
+	// and the doubles of the p99 column
+	chunk = group[1].([]interface{})[2].(map[int16]interface{})
+	if codec := chunk[3].(map[int16]interface{})[4].(int64); codec != codecGzip {
+		t.Fatalf("expected the gzip codec got %d", codec)
+	}
+	offset = chunk[3].(map[int16]interface{})[9].(int64)
+	page = &decoder{b: b[offset:]}
+	header = page.structure()
+	zr, _ = gzip.NewReader(bytes.NewReader(page.b[:header[3].(int64)]))
+	data, _ = ioutil.ReadAll(zr)
+	if v := math.Float64frombits(binary.LittleEndian.Uint64(data[8:])); v != 2.5 {
+		t.Fatalf("expected 2.5 got %v", v)
+	}
+}
@@ -0,0 +1,96 @@
+package parquet
+
+import (
+	"bytes"
+)
+
+// types of the thrift compact protocol the metadata is encoded with
+const (
+	typeI32    = 5
+	typeI64    = 6
+	typeBinary = 8
+	typeList   = 9
+	typeStruct = 12
+)
+
+// encoder writes thrift structs with the compact protocol
+type encoder struct {
+	bytes.Buffer
+	// last field id of each struct being written
+	last []int16
+}
+
+func newEncoder() *encoder {
+	return &encoder{last: []int16{0}}
+}
+
+func (e *encoder) varint(v uint64) {
+	for v >= 0x80 {
+		e.WriteByte(byte(v) | 0x80)
+		v >>= 7
+	}
+	e.WriteByte(byte(v))
+}
+
+func (e *encoder) zigzag(v int64) {
+	e.varint(uint64((v << 1) ^ (v >> 63)))
+}
+
+func (e *encoder) field(id int16, typ byte) {
+	last := e.last[len(e.last)-1]
+	if delta := id - last; delta > 0 && delta <= 15 {
+		e.WriteByte(byte(delta)<<4 | typ)
+	} else {
+		e.WriteByte(typ)
+		e.zigzag(int64(id))
+	}
+	e.last[len(e.last)-1] = id
+}
+
+func (e *encoder) i32(id int16, v int32) {
+	e.field(id, typeI32)
+	e.zigzag(int64(v))
+}
+
+func (e *encoder) i64(id int16, v int64) {
+	e.field(id, typeI64)
+	e.zigzag(v)
+}
+
+func (e *encoder) str(id int16, v string) {
+	e.field(id, typeBinary)
+	e.raw(v)
+}
+
+// raw writes a string without a field header e.g as an element of a list
+func (e *encoder) raw(v string) {
+	e.varint(uint64(len(v)))
+	e.WriteString(v)
+}
+
+// list writes the header of a list, its elements are written after it
+func (e *encoder) list(id int16, typ byte, size int) {
+	e.field(id, typeList)
+	if size < 15 {
+		e.WriteByte(byte(size)<<4 | typ)
+		return
+	}
+	e.WriteByte(0xf0 | typ)
+	e.varint(uint64(size))
+}
+
+// begin a struct field, its fields are written until end
+func (e *encoder) begin(id int16) {
+	e.field(id, typeStruct)
+	e.last = append(e.last, 0)
+}
+
+func (e *encoder) end() {
+	e.stop()
+	e.last = e.last[:len(e.last)-1]
+}
+
+// stop ends the struct being written
+func (e *encoder) stop() {
+	e.WriteByte(0)
+}
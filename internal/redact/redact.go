@@ -0,0 +1,163 @@
+// Package redact masks secret values in cli output
+package redact
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+var (
+	// Show disables redaction e.g with --show-secrets
+	Show = false
+	// Mask replaces the secret values
+	Mask = "******"
+
+	// DefaultPatterns are the secret-like key names masked by default. A bare
+	// "key" isn't one as it would mask e.g the key of store records.
+	DefaultPatterns = []string{
+		"password", "token", "secret",
+		"api_key", "apikey", "access_key", "accesskey", "private_key", "privatekey", "tls_key",
+	}
+
+	mtx sync.RWMutex
+	// patterns are matched case insensitively against keys
+	patterns = DefaultPatterns
+	// matches key=value, key: value and "key": "value"
+	re = compile(patterns)
+)
+
+func init() {
+	if p := os.Getenv("MICRO_REDACT_PATTERNS"); len(p) > 0 {
+		SetPatterns(strings.Split(p, ","))
+	}
+}
+
+func compile(p []string) *regexp.Regexp {
+	quoted := make([]string, 0, len(p))
+	for _, v := range p {
+		if v = strings.TrimSpace(v); len(v) > 0 {
+			quoted = append(quoted, regexp.QuoteMeta(v))
+		}
+	}
+	if len(quoted) == 0 {
+		return nil
+	}
+
+	return regexp.MustCompile(`(?i)("?[\w.-]*(?:` + strings.Join(quoted, "|") + `)[\w.-]*"?\s*[:=]\s*)` +
+		`("(?:[^"\\]|\\.)*"|[^\s,;&{}\[\]"]+)`)
+}
+
+// SetPatterns sets the key patterns whose values are masked
+func SetPatterns(p []string) {
+	mtx.Lock()
+	defer mtx.Unlock()
+	patterns = p
+	re = compile(p)
+}
+
+// Patterns returns the key patterns whose values are masked
+func Patterns() []string {
+	mtx.RLock()
+	defer mtx.RUnlock()
+	return patterns
+}
+
+// Sensitive returns true if the key matches a pattern
+func Sensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, p := range Patterns() {
+		if p = strings.ToLower(strings.TrimSpace(p)); len(p) > 0 && strings.Contains(key, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// Value masks the value if the key is sensitive
+func Value(key, value string) string {
+	if Show || len(value) == 0 || !Sensitive(key) {
+		return value
+	}
+	return Mask
+}
+
+// Env masks the value of an environment variable e.g DB_PASSWORD=foo
+func Env(kv string) string {
+	parts := strings.SplitN(kv, "=", 2)
+	if len(parts) != 2 {
+		return kv
+	}
+	return parts[0] + "=" + Value(parts[0], parts[1])
+}
+
+// Bytes masks the values of sensitive keys in text or json
+func Bytes(b []byte) []byte {
+	if Show {
+		return b
+	}
+
+	mtx.RLock()
+	r := re
+	mtx.RUnlock()
+
+	if r == nil {
+		return b
+	}
+
+	return r.ReplaceAllFunc(b, func(m []byte) []byte {
+		sub := r.FindSubmatch(m)
+		value := sub[2]
+		if bytes.HasPrefix(value, []byte(`"`)) {
+			return append(sub[1], `"`+Mask+`"`...)
+		}
+		return append(sub[1], Mask...)
+	})
+}
+
+// String masks the values of sensitive keys in text or json
+func String(s string) string {
+	return string(Bytes([]byte(s)))
+}
+
+// Writer masks each line before writing it
+type Writer struct {
+	w   io.Writer
+	buf []byte
+}
+
+// NewWriter returns a writer which masks secrets line by line
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+func (w *Writer) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+
+		if _, err := w.w.Write(Bytes(w.buf[:i+1])); err != nil {
+			return 0, err
+		}
+		w.buf = w.buf[i+1:]
+	}
+
+	return len(p), nil
+}
+
+// Flush writes any partial line
+func (w *Writer) Flush() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+	_, err := w.w.Write(Bytes(w.buf))
+	w.buf = nil
+	return err
+}
@@ -0,0 +1,49 @@
+package redact
+
+import "testing"
+
+func TestString(t *testing.T) {
+	testData := []struct {
+		in  string
+		out string
+	}{
+		{`password=hunter2`, `password=******`},
+		{`DB_PASSWORD=hunter2 user=bob`, `DB_PASSWORD=****** user=bob`},
+		{`{"name": "bob", "apiToken": "abc\"def"}`, `{"name": "bob", "apiToken": "******"}`},
+		{`{"client_secret":123,"id":1}`, `{"client_secret":******,"id":1}`},
+		{`tls_key: /etc/tls.key`, `tls_key: ******`},
+		{`AWS_ACCESS_KEY_ID=abc`, `AWS_ACCESS_KEY_ID=******`},
+		{`{"apiKey": "abc"}`, `{"apiKey": "******"}`},
+		// the key of store records isn't a secret
+		{`{"key": "foo", "value": "bar"}`, `{"key": "foo", "value": "bar"}`},
+		// nested values are left as is
+		{`{"secrets": {"a": 1}}`, `{"secrets": {"a": 1}}`},
+		{`nothing to see here`, `nothing to see here`},
+	}
+
+	for _, d := range testData {
+		if out := String(d.in); out != d.out {
+			t.Fatalf("expected %s got %s", d.out, out)
+		}
+	}
+
+	Show = true
+	defer func() { Show = false }()
+
+	if out := String(`password=hunter2`); out != `password=hunter2` {
+		t.Fatalf("expected no redaction got %s", out)
+	}
+}
+
+func TestPatterns(t *testing.T) {
+	defer SetPatterns(DefaultPatterns)
+
+	SetPatterns([]string{"pin"})
+
+	if out := String(`pin=1234 password=foo`); out != `pin=****** password=foo` {
+		t.Fatalf("unexpected output %s", out)
+	}
+	if out := Env("CARD_PIN=1234"); out != "CARD_PIN=******" {
+		t.Fatalf("unexpected output %s", out)
+	}
+}
@@ -0,0 +1,318 @@
+// Package s3 is a client of s3 compatible object storage e.g aws s3, minio
+// or google cloud storage with its interoperability api
+package s3
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrNotFound is returned getting an object which doesn't exist
+	ErrNotFound = errors.New("s3 object not found")
+)
+
+// Client puts, gets, lists and deletes the objects of a bucket
+type Client struct {
+	Bucket   string
+	Prefix   string
+	Region   string
+	Endpoint string
+
+	AccessKey    string
+	SecretKey    string
+	SessionToken string
+
+	HTTP *http.Client
+}
+
+// New returns a client from a url e.g s3://bucket/prefix?region=eu-west-1
+// Credentials are read from AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY.
+// Set endpoint to use s3 compatible storage e.g endpoint=http://localhost:9000
+// or endpoint=https://storage.googleapis.com with gcs hmac keys.
+func New(u *url.URL) (*Client, error) {
+	q := u.Query()
+
+	c := &Client{
+		Bucket:       u.Host,
+		Prefix:       strings.Trim(u.Path, "/"),
+		Region:       q.Get("region"),
+		Endpoint:     q.Get("endpoint"),
+		AccessKey:    os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretKey:    os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken: os.Getenv("AWS_SESSION_TOKEN"),
+		HTTP:         &http.Client{Timeout: 30 * time.Second},
+	}
+
+	if len(c.Region) == 0 {
+		c.Region = os.Getenv("AWS_REGION")
+	}
+	if len(c.Region) == 0 {
+		c.Region = "us-east-1"
+	}
+	if len(c.Endpoint) == 0 {
+		c.Endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", c.Region)
+	}
+
+	if len(c.Bucket) == 0 {
+		return nil, errors.New("s3 requires a bucket")
+	}
+	if len(c.AccessKey) == 0 || len(c.SecretKey) == 0 {
+		return nil, errors.New("s3 requires AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY")
+	}
+
+	return c, nil
+}
+
+// Key returns the key of the object prefixed with the prefix of the client
+func (c *Client) Key(name string) string {
+	if len(c.Prefix) == 0 {
+		return name
+	}
+	return c.Prefix + "/" + name
+}
+
+// Put an object using a path style request signed with aws signature v4
+func (c *Client) Put(key, contentType string, body []byte) error {
+	return c.PutMetadata(key, contentType, body, nil)
+}
+
+// PutMetadata puts an object with the user metadata e.g expires sent as x-amz-meta-expires
+func (c *Client) PutMetadata(key, contentType string, body []byte, md map[string]string) error {
+	headers := make(map[string]string)
+	if len(contentType) > 0 {
+		headers["content-type"] = contentType
+	}
+	for k, v := range md {
+		headers["x-amz-meta-"+strings.ToLower(k)] = v
+	}
+
+	_, _, err := c.do("PUT", key, nil, headers, body)
+	return err
+}
+
+// Get an object returning its body and user metadata, ErrNotFound if it doesn't exist
+func (c *Client) Get(key string) ([]byte, map[string]string, error) {
+	rsp, b, err := c.do("GET", key, nil, nil, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	md := make(map[string]string)
+	for k := range rsp.Header {
+		if lk := strings.ToLower(k); strings.HasPrefix(lk, "x-amz-meta-") {
+			md[strings.TrimPrefix(lk, "x-amz-meta-")] = rsp.Header.Get(k)
+		}
+	}
+
+	return b, md, nil
+}
+
+// Delete an object, deleting an object which doesn't exist isn't an error
+func (c *Client) Delete(key string) error {
+	_, _, err := c.do("DELETE", key, nil, nil, nil)
+	if err == ErrNotFound {
+		return nil
+	}
+	return err
+}
+
+// List the keys of the objects starting with the prefix
+func (c *Client) List(prefix string) ([]string, error) {
+	var keys []string
+	var token string
+
+	for {
+		q := map[string]string{"list-type": "2", "prefix": prefix}
+		if len(token) > 0 {
+			q["continuation-token"] = token
+		}
+
+		_, b, err := c.do("GET", "", q, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var res listResult
+		if err := xml.Unmarshal(b, &res); err != nil {
+			return nil, fmt.Errorf("s3 list error: %v", err)
+		}
+		for _, obj := range res.Contents {
+			keys = append(keys, obj.Key)
+		}
+
+		if !res.IsTruncated || len(res.NextContinuationToken) == 0 {
+			return keys, nil
+		}
+		token = res.NextContinuationToken
+	}
+}
+
+// listResult is the result of a ListObjectsV2 request
+type listResult struct {
+	Contents []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// do a path style request of the object in the bucket signed with aws signature v4,
+// the key is blank for requests of the bucket
+func (c *Client) do(method, key string, query, extra map[string]string, body []byte) (*http.Response, []byte, error) {
+	path := "/" + c.Bucket
+	if len(key) > 0 {
+		path += "/" + uriEncode(key, false)
+	}
+
+	// the canonical query string is sorted by key
+	var names []string
+	for k := range query {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	var params []string
+	for _, k := range names {
+		params = append(params, uriEncode(k, true)+"="+uriEncode(query[k], true))
+	}
+	rawQuery := strings.Join(params, "&")
+
+	rawURL := c.Endpoint + path
+	if len(rawQuery) > 0 {
+		rawURL += "?" + rawQuery
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := hashHex(body)
+
+	headers := map[string]string{
+		"host":                 u.Host,
+		"x-amz-content-sha256": payloadHash,
+		"x-amz-date":           amzDate,
+	}
+	for k, v := range extra {
+		headers[k] = v
+	}
+	if len(c.SessionToken) > 0 {
+		headers["x-amz-security-token"] = c.SessionToken
+	}
+
+	var signed []string
+	for h := range headers {
+		signed = append(signed, h)
+	}
+	sort.Strings(signed)
+
+	var canonicalHeaders string
+	for _, h := range signed {
+		canonicalHeaders += h + ":" + strings.TrimSpace(headers[h]) + "\n"
+	}
+	signedHeaders := strings.Join(signed, ";")
+
+	canonicalRequest := strings.Join([]string{
+		method,
+		path,
+		rawQuery,
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + c.Region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hashHex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key4 := hmacSHA256([]byte("AWS4"+c.SecretKey), date)
+	key4 = hmacSHA256(key4, c.Region)
+	key4 = hmacSHA256(key4, "s3")
+	key4 = hmacSHA256(key4, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key4, stringToSign))
+
+	req, err := http.NewRequest(method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, h := range signed {
+		if h == "host" {
+			continue
+		}
+		req.Header.Set(h, headers[h])
+	}
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		c.AccessKey, scope, signedHeaders, signature,
+	))
+
+	rsp, err := c.HTTP.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rsp.Body.Close()
+
+	b, err := ioutil.ReadAll(rsp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if rsp.StatusCode == http.StatusNotFound && len(key) > 0 {
+		return nil, nil, ErrNotFound
+	}
+	if rsp.StatusCode >= 300 {
+		return nil, nil, fmt.Errorf("s3 %s error %d: %s", strings.ToLower(method), rsp.StatusCode, string(b))
+	}
+
+	return rsp, b, nil
+}
+
+// uriEncode encodes the string as required by aws signature v4, every byte
+// but the unreserved characters is escaped and the slash if set
+func uriEncode(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func hashHex(b []byte) string {
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
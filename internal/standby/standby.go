@@ -0,0 +1,275 @@
+// Package standby runs instances of a service as an active/standby pair. The
+// standby serves reads itself, forwards writes to the active instance and
+// applies the writes the active instance replicates to it.
+package standby
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/micro/go-micro/v2/client"
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/metadata"
+	"github.com/micro/go-micro/v2/registry"
+	"github.com/micro/go-micro/v2/util/log"
+)
+
+var (
+	// Interval between elections
+	Interval = time.Second * 5
+	// Timeout for replicating a write to a standby
+	Timeout = time.Second * 10
+	// ReplicaHeader is set to the id of the active instance on replicated writes
+	ReplicaHeader = "Micro-Standby-Replica"
+	// SignatureHeader holds the time a write was replicated and its signature
+	// with the key shared by the instances
+	SignatureHeader = "Micro-Standby-Signature"
+	// MaxAge of a replicated write, older signatures are rejected so they can't be replayed
+	MaxAge = time.Second * 30
+	// StartedKey is the node metadata holding the time the instance started,
+	// only nodes with it take part in the election
+	StartedKey = "standby_started"
+	// Forwarded is the metadata passed on with replicated writes, it's signed with the write
+	Forwarded = []string{"Micro-Namespace", "Micro-Prefix", "Authorization"}
+)
+
+// Metadata returns the node metadata registered by an instance taking part in the election
+func Metadata() map[string]string {
+	return map[string]string{
+		StartedKey: strconv.FormatInt(time.Now().UnixNano(), 10),
+	}
+}
+
+// elect the instance which started first so a new instance never takes over
+func elect(services []*registry.Service) (*registry.Node, []*registry.Node) {
+	var nodes []*registry.Node
+	for _, srv := range services {
+		for _, node := range srv.Nodes {
+			if _, ok := node.Metadata[StartedKey]; ok {
+				nodes = append(nodes, node)
+			}
+		}
+	}
+	if len(nodes) == 0 {
+		return nil, nil
+	}
+
+	started := func(n *registry.Node) int64 {
+		v, _ := strconv.ParseInt(n.Metadata[StartedKey], 10, 64)
+		return v
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		si, sj := started(nodes[i]), started(nodes[j])
+		if si != sj {
+			return si < sj
+		}
+		return nodes[i].Id < nodes[j].Id
+	})
+
+	return nodes[0], nodes[1:]
+}
+
+// sign the write replicated by the instance at the time with the key
+func sign(key []byte, id string, at int64, md metadata.Metadata) string {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(id + "\n" + strconv.FormatInt(at, 10)))
+	for _, k := range Forwarded {
+		if v, ok := md[k]; ok {
+			h.Write([]byte("\n" + k + "=" + v))
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Standby tracks the active instance of the service
+type Standby struct {
+	name     string
+	id       string
+	key      []byte
+	registry registry.Registry
+	client   client.Client
+
+	sync.RWMutex
+	active   *registry.Node
+	standbys []*registry.Node
+
+	exit chan bool
+}
+
+// New returns the standby for the instance of the service with the node id,
+// the writes replicated between the instances are signed with the key
+func New(name, id, key string, r registry.Registry, c client.Client) *Standby {
+	return &Standby{
+		name:     name,
+		id:       id,
+		key:      []byte(key),
+		registry: r,
+		client:   c,
+		exit:     make(chan bool),
+	}
+}
+
+func (s *Standby) elect() {
+	services, err := s.registry.GetService(s.name)
+	if err != nil {
+		log.Debugf("Standby failed to get the instances of %s: %v", s.name, err)
+		return
+	}
+
+	active, standbys := elect(services)
+
+	s.Lock()
+	defer s.Unlock()
+
+	was := s.active != nil && s.active.Id == s.id
+	is := active != nil && active.Id == s.id
+
+	switch {
+	case is && !was:
+		log.Logf("Standby %s is now the active instance", s.id)
+	case !is && active != nil && (s.active == nil || s.active.Id != active.Id):
+		log.Logf("Standby %s is forwarding writes to %s", s.id, active.Id)
+	}
+
+	s.active = active
+	s.standbys = standbys
+}
+
+// Start electing the active instance
+func (s *Standby) Start() {
+	s.elect()
+
+	go func() {
+		t := time.NewTicker(Interval)
+		defer t.Stop()
+
+		for {
+			select {
+			case <-t.C:
+				s.elect()
+			case <-s.exit:
+				return
+			}
+		}
+	}()
+}
+
+// Stop electing
+func (s *Standby) Stop() {
+	close(s.exit)
+}
+
+// Active returns true if this is the active instance, before the
+// instance has registered it's active until another is elected
+func (s *Standby) Active() bool {
+	s.RLock()
+	defer s.RUnlock()
+	return s.active == nil || s.active.Id == s.id
+}
+
+// Replicated returns true if the request is a write replicated by another instance,
+// an error is returned unless it was signed by the active instance
+func (s *Standby) Replicated(ctx context.Context) (bool, error) {
+	md, ok := metadata.FromContext(ctx)
+	if !ok || len(md[ReplicaHeader]) == 0 {
+		return false, nil
+	}
+
+	// the signature is the time the write was replicated and its hmac
+	parts := strings.SplitN(md[SignatureHeader], ".", 2)
+	if len(s.key) == 0 || len(parts) != 2 {
+		return true, errors.Forbidden(s.name, "write not signed by the active instance")
+	}
+	at, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return true, errors.Forbidden(s.name, "write not signed by the active instance")
+	}
+	if age := time.Since(time.Unix(0, at)); age > MaxAge || age < -MaxAge {
+		return true, errors.Forbidden(s.name, "replicated write expired")
+	}
+	if !hmac.Equal([]byte(parts[1]), []byte(sign(s.key, md[ReplicaHeader], at, md))) {
+		return true, errors.Forbidden(s.name, "write not signed by the active instance")
+	}
+
+	s.RLock()
+	defer s.RUnlock()
+
+	if s.active == nil || s.active.Id != md[ReplicaHeader] || s.active.Id == s.id {
+		return true, errors.Forbidden(s.name, "write not replicated by the active instance")
+	}
+	return true, nil
+}
+
+// ActiveNode returns the active instance or nil if this is the active instance
+func (s *Standby) ActiveNode() *registry.Node {
+	s.RLock()
+	defer s.RUnlock()
+	if s.active == nil || s.active.Id == s.id {
+		return nil
+	}
+	return s.active
+}
+
+// Forward calls the endpoint on the active instance unless this is the
+// active instance, it returns true if the request was forwarded
+func (s *Standby) Forward(ctx context.Context, endpoint string, req, rsp interface{}) (bool, error) {
+	active := s.ActiveNode()
+	if active == nil {
+		return false, nil
+	}
+
+	r := s.client.NewRequest(s.name, endpoint, req)
+	if err := s.client.Call(ctx, r, rsp, client.WithAddress(active.Address)); err != nil {
+		if e := errors.Parse(err.Error()); e.Code > 0 {
+			return true, e
+		}
+		return true, errors.InternalServerError(s.name, "failed to forward to the active instance: %v", err)
+	}
+
+	return true, nil
+}
+
+// Replicate the write to the standby instances in the background,
+// rsp returns a new response to decode each reply into
+func (s *Standby) Replicate(ctx context.Context, endpoint string, req interface{}, rsp func() interface{}) {
+	s.RLock()
+	standbys := s.standbys
+	s.RUnlock()
+
+	if len(standbys) == 0 {
+		return
+	}
+
+	// pass on who made the write and where to
+	md := metadata.Metadata{}
+	if v, ok := metadata.FromContext(ctx); ok {
+		for _, k := range Forwarded {
+			if val, ok := v[k]; ok {
+				md[k] = val
+			}
+		}
+	}
+	at := time.Now().UnixNano()
+	md[ReplicaHeader] = s.id
+	md[SignatureHeader] = strconv.FormatInt(at, 10) + "." + sign(s.key, s.id, at, md)
+
+	for _, node := range standbys {
+		go func(node *registry.Node) {
+			rctx, cancel := context.WithTimeout(metadata.NewContext(context.Background(), md), Timeout)
+			defer cancel()
+
+			r := s.client.NewRequest(s.name, endpoint, req)
+			if err := s.client.Call(rctx, r, rsp(), client.WithAddress(node.Address)); err != nil {
+				log.Logf("Standby failed to replicate %s to %s: %v", endpoint, node.Id, err)
+			}
+		}(node)
+	}
+}
@@ -49,6 +49,20 @@ func (d *DNS) Resolve(ctx context.Context, req *dns.ResolveRequest, rsp *dns.Res
 	return nil
 }
 
+// List returns all the records for a name along with their TTLs
+func (d *DNS) List(ctx context.Context, req *dns.ListRequest, rsp *dns.ListResponse) error {
+	log.Trace("Received List Request")
+	if err := d.validateMetadata(ctx); err != nil {
+		return err
+	}
+	records, err := d.provider.List(req.Name)
+	if err != nil {
+		return err
+	}
+	rsp.Records = records
+	return nil
+}
+
 func (d *DNS) validateMetadata(ctx context.Context) error {
 	md, ok := metadata.FromContext(ctx)
 	if !ok {
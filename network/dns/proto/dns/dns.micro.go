@@ -38,6 +38,7 @@ type DnsService interface {
 	Advertise(ctx context.Context, in *AdvertiseRequest, opts ...client.CallOption) (*AdvertiseResponse, error)
 	Remove(ctx context.Context, in *RemoveRequest, opts ...client.CallOption) (*RemoveResponse, error)
 	Resolve(ctx context.Context, in *ResolveRequest, opts ...client.CallOption) (*ResolveResponse, error)
+	List(ctx context.Context, in *ListRequest, opts ...client.CallOption) (*ListResponse, error)
 }
 
 type dnsService struct {
@@ -88,12 +89,23 @@ func (c *dnsService) Resolve(ctx context.Context, in *ResolveRequest, opts ...cl
 	return out, nil
 }
 
+func (c *dnsService) List(ctx context.Context, in *ListRequest, opts ...client.CallOption) (*ListResponse, error) {
+	req := c.c.NewRequest(c.name, "Dns.List", in)
+	out := new(ListResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Dns service
 
 type DnsHandler interface {
 	Advertise(context.Context, *AdvertiseRequest, *AdvertiseResponse) error
 	Remove(context.Context, *RemoveRequest, *RemoveResponse) error
 	Resolve(context.Context, *ResolveRequest, *ResolveResponse) error
+	List(context.Context, *ListRequest, *ListResponse) error
 }
 
 func RegisterDnsHandler(s server.Server, hdlr DnsHandler, opts ...server.HandlerOption) error {
@@ -101,6 +113,7 @@ func RegisterDnsHandler(s server.Server, hdlr DnsHandler, opts ...server.Handler
 		Advertise(ctx context.Context, in *AdvertiseRequest, out *AdvertiseResponse) error
 		Remove(ctx context.Context, in *RemoveRequest, out *RemoveResponse) error
 		Resolve(ctx context.Context, in *ResolveRequest, out *ResolveResponse) error
+		List(ctx context.Context, in *ListRequest, out *ListResponse) error
 	}
 	type Dns struct {
 		dns
@@ -124,3 +137,7 @@ func (h *dnsHandler) Remove(ctx context.Context, in *RemoveRequest, out *RemoveR
 func (h *dnsHandler) Resolve(ctx context.Context, in *ResolveRequest, out *ResolveResponse) error {
 	return h.DnsHandler.Resolve(ctx, in, out)
 }
+
+func (h *dnsHandler) List(ctx context.Context, in *ListRequest, out *ListResponse) error {
+	return h.DnsHandler.List(ctx, in, out)
+}
@@ -32,7 +32,9 @@ type Record struct {
 	// MX and SRV records have priority
 	Priority uint32 `protobuf:"varint,4,opt,name=priority,proto3" json:"priority,omitempty"`
 	// TTL
-	Ttl                  uint32   `protobuf:"varint,5,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	Ttl uint32 `protobuf:"varint,5,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	// Unix timestamp the record was created
+	Created              int64    `protobuf:"varint,6,opt,name=created,proto3" json:"created,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -98,6 +100,13 @@ func (m *Record) GetTtl() uint32 {
 	return 0
 }
 
+func (m *Record) GetCreated() int64 {
+	if m != nil {
+		return m.Created
+	}
+	return 0
+}
+
 type AdvertiseRequest struct {
 	// Send an arbitrary number of records to advertise
 	Records              []*Record `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
@@ -327,6 +336,86 @@ func (m *ResolveResponse) GetRecords() []*Record {
 	return nil
 }
 
+type ListRequest struct {
+	// e.g. network.micro.mu
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListRequest) Reset()         { *m = ListRequest{} }
+func (m *ListRequest) String() string { return proto.CompactTextString(m) }
+func (*ListRequest) ProtoMessage()    {}
+func (*ListRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7cf2fb1bb2efee5c, []int{7}
+}
+
+func (m *ListRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListRequest.Unmarshal(m, b)
+}
+func (m *ListRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListRequest.Marshal(b, m, deterministic)
+}
+func (m *ListRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListRequest.Merge(m, src)
+}
+func (m *ListRequest) XXX_Size() int {
+	return xxx_messageInfo_ListRequest.Size(m)
+}
+func (m *ListRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListRequest proto.InternalMessageInfo
+
+func (m *ListRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type ListResponse struct {
+	// All records for the name
+	Records              []*Record `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *ListResponse) Reset()         { *m = ListResponse{} }
+func (m *ListResponse) String() string { return proto.CompactTextString(m) }
+func (*ListResponse) ProtoMessage()    {}
+func (*ListResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7cf2fb1bb2efee5c, []int{8}
+}
+
+func (m *ListResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListResponse.Unmarshal(m, b)
+}
+func (m *ListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListResponse.Marshal(b, m, deterministic)
+}
+func (m *ListResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListResponse.Merge(m, src)
+}
+func (m *ListResponse) XXX_Size() int {
+	return xxx_messageInfo_ListResponse.Size(m)
+}
+func (m *ListResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListResponse proto.InternalMessageInfo
+
+func (m *ListResponse) GetRecords() []*Record {
+	if m != nil {
+		return m.Records
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Record)(nil), "go.micro.network.dns.Record")
 	proto.RegisterType((*AdvertiseRequest)(nil), "go.micro.network.dns.AdvertiseRequest")
@@ -335,30 +424,35 @@ func init() {
 	proto.RegisterType((*RemoveResponse)(nil), "go.micro.network.dns.RemoveResponse")
 	proto.RegisterType((*ResolveRequest)(nil), "go.micro.network.dns.ResolveRequest")
 	proto.RegisterType((*ResolveResponse)(nil), "go.micro.network.dns.ResolveResponse")
+	proto.RegisterType((*ListRequest)(nil), "go.micro.network.dns.ListRequest")
+	proto.RegisterType((*ListResponse)(nil), "go.micro.network.dns.ListResponse")
 }
 
 func init() { proto.RegisterFile("proto/dns/dns.proto", fileDescriptor_7cf2fb1bb2efee5c) }
 
 var fileDescriptor_7cf2fb1bb2efee5c = []byte{
-	// 314 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x92, 0xdb, 0x4b, 0xc3, 0x30,
-	0x14, 0xc6, 0xe9, 0xba, 0x8b, 0x3b, 0x32, 0xad, 0xd9, 0x1e, 0x42, 0xf1, 0xa1, 0xd4, 0x5b, 0x9f,
-	0x22, 0x4c, 0x10, 0x5f, 0x05, 0x41, 0xf4, 0x31, 0x82, 0x4f, 0xbe, 0xcc, 0x35, 0x48, 0xb1, 0x4d,
-	0x6a, 0x92, 0x55, 0xf6, 0x5f, 0xf8, 0x27, 0x4b, 0xd2, 0xcb, 0x8a, 0x74, 0x0e, 0xf4, 0x21, 0x70,
-	0xce, 0xe1, 0x3b, 0x3f, 0xbe, 0x7c, 0x1c, 0x98, 0xe6, 0x52, 0x68, 0x71, 0x19, 0x73, 0x65, 0x1e,
-	0xb1, 0x1d, 0x9a, 0xbd, 0x09, 0x92, 0x25, 0x4b, 0x29, 0x08, 0x67, 0xfa, 0x53, 0xc8, 0x77, 0x12,
-	0x73, 0x15, 0x6a, 0x18, 0x52, 0xb6, 0x14, 0x32, 0x46, 0x08, 0xfa, 0x7c, 0x91, 0x31, 0xec, 0x04,
-	0x4e, 0x34, 0xa6, 0xb6, 0x46, 0x33, 0x18, 0x14, 0x8b, 0x74, 0xc5, 0x70, 0xcf, 0x0e, 0xcb, 0xc6,
-	0x28, 0xf5, 0x3a, 0x67, 0xd8, 0x2d, 0x95, 0xa6, 0x46, 0x3e, 0xec, 0xe5, 0x32, 0x11, 0x32, 0xd1,
-	0x6b, 0xdc, 0x0f, 0x9c, 0x68, 0x42, 0x9b, 0x1e, 0x79, 0xe0, 0x6a, 0x9d, 0xe2, 0x81, 0x1d, 0x9b,
-	0x32, 0x7c, 0x04, 0xef, 0x36, 0x2e, 0x98, 0xd4, 0x89, 0x62, 0x94, 0x7d, 0xac, 0x98, 0xd2, 0xe8,
-	0x1a, 0x46, 0xd2, 0x3a, 0x51, 0xd8, 0x09, 0xdc, 0x68, 0x7f, 0x7e, 0x4c, 0xba, 0x1c, 0x93, 0xd2,
-	0x2e, 0xad, 0xc5, 0xe1, 0x14, 0x8e, 0x5a, 0x2c, 0x95, 0x0b, 0xae, 0x58, 0x78, 0x0f, 0x13, 0xca,
-	0x32, 0x51, 0xfc, 0x9b, 0xee, 0xc1, 0x41, 0x0d, 0xaa, 0xd0, 0x37, 0x66, 0xa2, 0x44, 0xba, 0x61,
-	0x77, 0x25, 0x57, 0x67, 0xd4, 0xdb, 0x64, 0x14, 0x3e, 0xc0, 0x61, 0xb3, 0x59, 0xc2, 0xfe, 0x6a,
-	0x6b, 0xfe, 0xd5, 0x03, 0xf7, 0x8e, 0x2b, 0xf4, 0x02, 0xe3, 0xe6, 0xf3, 0xe8, 0xbc, 0x7b, 0xf7,
-	0x67, 0xd2, 0xfe, 0xc5, 0x4e, 0x5d, 0xe5, 0xee, 0xc9, 0x1c, 0x87, 0xf9, 0x3c, 0x3a, 0xd9, 0x66,
-	0xab, 0x95, 0xb1, 0x7f, 0xfa, 0xbb, 0xa8, 0x82, 0x3e, 0xc3, 0xa8, 0x4a, 0x01, 0x6d, 0x5d, 0x68,
-	0xc7, 0xeb, 0x9f, 0xed, 0x50, 0x95, 0xdc, 0xd7, 0xa1, 0x3d, 0xf3, 0xab, 0xef, 0x00, 0x00, 0x00,
-	0xff, 0xff, 0xa6, 0x92, 0x27, 0xf9, 0xfd, 0x02, 0x00, 0x00,
+	// 359 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa5, 0x53, 0xcb, 0x4e, 0xc2, 0x40,
+	0x14, 0x0d, 0x94, 0x87, 0x5c, 0x44, 0xeb, 0x85, 0xc5, 0xa4, 0x71, 0x21, 0xe3, 0x8b, 0x55, 0x4d,
+	0x30, 0x31, 0x6e, 0x4d, 0x8c, 0x46, 0xa3, 0x9b, 0x31, 0x71, 0xe5, 0x06, 0xe9, 0xc4, 0x34, 0x42,
+	0xa7, 0xcc, 0x0c, 0x18, 0x7e, 0xc2, 0x5f, 0xf4, 0x57, 0xec, 0xf4, 0x01, 0x8d, 0x29, 0x90, 0xc8,
+	0xa2, 0xc9, 0xbd, 0xa7, 0xe7, 0x9e, 0x39, 0x73, 0x6e, 0x0b, 0xed, 0x50, 0x0a, 0x2d, 0x2e, 0xbc,
+	0x40, 0x99, 0xc7, 0x8d, 0x3b, 0xec, 0x7c, 0x08, 0x77, 0xec, 0x0f, 0xa5, 0x70, 0x03, 0xae, 0xbf,
+	0x84, 0xfc, 0x74, 0xa3, 0x77, 0xf4, 0xbb, 0x04, 0x35, 0xc6, 0x87, 0x42, 0x7a, 0x88, 0x50, 0x09,
+	0x06, 0x63, 0x4e, 0x4a, 0x47, 0xa5, 0x5e, 0x83, 0xc5, 0x35, 0x76, 0xa0, 0x3a, 0x1b, 0x8c, 0xa6,
+	0x9c, 0x94, 0x63, 0x30, 0x69, 0x0c, 0x53, 0xcf, 0x43, 0x4e, 0xac, 0x84, 0x69, 0x6a, 0x74, 0x60,
+	0x27, 0x94, 0xbe, 0x90, 0xbe, 0x9e, 0x93, 0x4a, 0x84, 0xb7, 0xd8, 0xa2, 0x47, 0x1b, 0x2c, 0xad,
+	0x47, 0xa4, 0x1a, 0xc3, 0xa6, 0x44, 0x02, 0xf5, 0xa1, 0xe4, 0x03, 0xcd, 0x3d, 0x52, 0x8b, 0x50,
+	0x8b, 0x65, 0x2d, 0x7d, 0x04, 0xfb, 0xc6, 0x9b, 0x71, 0xa9, 0x7d, 0xc5, 0x19, 0x9f, 0x4c, 0xb9,
+	0xd2, 0x78, 0x05, 0x75, 0x19, 0x7b, 0x54, 0x91, 0x39, 0xab, 0xd7, 0xec, 0x1f, 0xba, 0x45, 0x97,
+	0x71, 0x93, 0x8b, 0xb0, 0x8c, 0x4c, 0xdb, 0x70, 0x90, 0xd3, 0x52, 0xa1, 0x08, 0x14, 0xa7, 0xf7,
+	0xd0, 0x62, 0x7c, 0x2c, 0x66, 0x5b, 0xab, 0xdb, 0xb0, 0x97, 0x09, 0xa5, 0xd2, 0xd7, 0x06, 0x51,
+	0x62, 0xb4, 0xd4, 0x2e, 0xca, 0x34, 0x4b, 0xaf, 0xbc, 0x4c, 0x8f, 0x3e, 0xc0, 0xfe, 0x62, 0x32,
+	0x11, 0xfb, 0xb7, 0xad, 0x2e, 0x34, 0x9f, 0x7c, 0xa5, 0xd7, 0x38, 0xa0, 0x77, 0xb0, 0x9b, 0x50,
+	0xb6, 0x3b, 0xaa, 0xff, 0x53, 0x06, 0xeb, 0x36, 0x50, 0xf8, 0x06, 0x8d, 0x45, 0xce, 0x78, 0x56,
+	0x3c, 0xfb, 0x77, 0xa9, 0xce, 0xf9, 0x46, 0x5e, 0xea, 0xee, 0xc5, 0x7c, 0xa1, 0x26, 0x67, 0x3c,
+	0x5e, 0x65, 0x2b, 0xb7, 0x4e, 0xe7, 0x64, 0x3d, 0x29, 0x15, 0x7d, 0x85, 0x7a, 0x1a, 0x38, 0xae,
+	0x1c, 0xc8, 0x6f, 0xd2, 0x39, 0xdd, 0xc0, 0x4a, 0x75, 0x9f, 0xa1, 0x62, 0xa2, 0xc5, 0x6e, 0x31,
+	0x3d, 0xb7, 0x19, 0x87, 0xae, 0xa3, 0x24, 0x72, 0xef, 0xb5, 0xf8, 0xdf, 0xbd, 0xfc, 0x05, 0x28,
+	0x8c, 0x3f, 0x11, 0xd2, 0x03, 0x00, 0x00,
 }
@@ -35,12 +35,17 @@ func New(apiToken, zoneID string) (provider.Provider, error) {
 
 func (cf *cfProvider) Advertise(records ...*dns.Record) error {
 	for _, r := range records {
+		// a ttl of 1 is automatic in cloudflare
+		ttl := int(r.GetTtl())
+		if ttl == 0 {
+			ttl = 1
+		}
 		_, err := cf.api.CreateDNSRecord(cf.zoneID, cloudflare.DNSRecord{
 			Name:     r.GetName(),
 			Content:  r.GetValue(),
 			Type:     r.GetType(),
 			Priority: int(r.GetPriority()),
-			TTL:      1,
+			TTL:      ttl,
 		})
 		if err != nil {
 			return err
@@ -78,6 +83,25 @@ func (cf *cfProvider) Remove(records ...*dns.Record) error {
 	return nil
 }
 
+func (cf *cfProvider) List(name string) ([]*dns.Record, error) {
+	existingRecords, err := cf.api.DNSRecords(cf.zoneID, cloudflare.DNSRecord{Name: name})
+	if err != nil {
+		return nil, err
+	}
+	var response []*dns.Record
+	for _, e := range existingRecords {
+		response = append(response, &dns.Record{
+			Name:     e.Name,
+			Value:    e.Content,
+			Type:     e.Type,
+			Priority: uint32(e.Priority),
+			Ttl:      uint32(e.TTL),
+			Created:  e.CreatedOn.Unix(),
+		})
+	}
+	return response, nil
+}
+
 func (cf *cfProvider) Resolve(name, recordType string) ([]*dns.Record, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -13,4 +13,6 @@ type Provider interface {
 	Remove(...*dns.Record) error
 	// Resolve looks up a record in DNS
 	Resolve(name, recordType string) ([]*dns.Record, error)
+	// List returns all the records held by the provider for a name
+	List(name string) ([]*dns.Record, error)
 }
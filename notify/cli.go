@@ -0,0 +1,90 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/micro/cli/v2"
+	"github.com/micro/go-micro/v2/client"
+	pb "github.com/micro/micro/v2/notify/proto"
+)
+
+func notifyService() pb.NotifyService {
+	return pb.NewNotifyService(Name, client.DefaultClient)
+}
+
+// channelName returns the name passed as the first arg exiting if missing
+func channelName(ctx *cli.Context) string {
+	name := ctx.Args().First()
+	if len(name) == 0 {
+		fmt.Println("Channel name required")
+		os.Exit(1)
+	}
+	return name
+}
+
+func createChannel(ctx *cli.Context) {
+	var severities []string
+	for _, s := range ctx.StringSlice("severity") {
+		for _, v := range strings.Split(s, ",") {
+			if v = strings.TrimSpace(v); len(v) > 0 {
+				severities = append(severities, v)
+			}
+		}
+	}
+
+	_, err := notifyService().Create(context.Background(), &pb.CreateRequest{
+		Channel: &pb.Channel{
+			Name:       channelName(ctx),
+			Type:       ctx.String("type"),
+			Url:        ctx.String("url"),
+			Template:   ctx.String("template"),
+			Severities: severities,
+		},
+	})
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
+func deleteChannel(ctx *cli.Context) {
+	if _, err := notifyService().Delete(context.Background(), &pb.DeleteRequest{Name: channelName(ctx)}); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
+func listChannels(ctx *cli.Context) {
+	rsp, err := notifyService().List(context.Background(), &pb.ListRequest{})
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
+	fmt.Fprintln(writer, "NAME\tTYPE\tSEVERITIES\tTEMPLATE")
+	for _, c := range rsp.Channels {
+		severities := "all"
+		if len(c.Severities) > 0 {
+			severities = strings.Join(c.Severities, ",")
+		}
+		template := "default"
+		if len(c.Template) > 0 {
+			template = c.Template
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", c.Name, c.Type, severities, template)
+	}
+	writer.Flush()
+}
+
+func testChannel(ctx *cli.Context) {
+	if _, err := notifyService().Test(context.Background(), &pb.TestRequest{Name: channelName(ctx)}); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	fmt.Println("Sent a test notification")
+}
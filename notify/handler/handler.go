@@ -0,0 +1,214 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/store"
+	"github.com/micro/go-micro/v2/util/log"
+	"github.com/micro/micro/v2/notify/channel"
+	pb "github.com/micro/micro/v2/notify/proto"
+	epb "github.com/micro/micro/v2/runtime/events/proto"
+	rnotify "github.com/micro/micro/v2/runtime/notify"
+)
+
+var (
+	// Prefix of the keys the channels are stored under
+	Prefix = "notify/channel/"
+)
+
+// Notify manages the channels and dispatches the notifications received to them
+type Notify struct {
+	Store      store.Store
+	Dispatcher *channel.Dispatcher
+}
+
+// Load the stored channels into the dispatcher
+func (n *Notify) Load() error {
+	records, err := n.Store.List()
+	if err != nil {
+		return err
+	}
+
+	for _, r := range records {
+		if !strings.HasPrefix(r.Key, Prefix) {
+			continue
+		}
+		var c *channel.Channel
+		if err := json.Unmarshal(r.Value, &c); err != nil {
+			log.Logf("Failed to load channel %s: %v", strings.TrimPrefix(r.Key, Prefix), err)
+			continue
+		}
+		n.Dispatcher.Set(c)
+	}
+
+	return nil
+}
+
+func toChannel(c *pb.Channel) *channel.Channel {
+	return &channel.Channel{
+		Name:       c.Name,
+		Type:       c.Type,
+		URL:        c.Url,
+		Template:   c.Template,
+		Severities: c.Severities,
+	}
+}
+
+func toProto(c *channel.Channel) *pb.Channel {
+	return &pb.Channel{
+		Name:       c.Name,
+		Type:       c.Type,
+		Url:        c.URL,
+		Template:   c.Template,
+		Severities: c.Severities,
+	}
+}
+
+func (n *Notify) Create(ctx context.Context, req *pb.CreateRequest, rsp *pb.CreateResponse) error {
+	if req.Channel == nil {
+		return errors.BadRequest("go.micro.notify", "blank channel")
+	}
+
+	c := toChannel(req.Channel)
+	if err := c.Validate(); err != nil {
+		return errors.BadRequest("go.micro.notify", err.Error())
+	}
+
+	b, err := json.Marshal(c)
+	if err != nil {
+		return errors.InternalServerError("go.micro.notify", err.Error())
+	}
+	if err := n.Store.Write(&store.Record{Key: Prefix + c.Name, Value: b}); err != nil {
+		return errors.InternalServerError("go.micro.notify", err.Error())
+	}
+
+	n.Dispatcher.Set(c)
+
+	return nil
+}
+
+func (n *Notify) Delete(ctx context.Context, req *pb.DeleteRequest, rsp *pb.DeleteResponse) error {
+	if _, ok := n.Dispatcher.Get(req.Name); !ok {
+		return errors.NotFound("go.micro.notify", "channel %s not found", req.Name)
+	}
+
+	if err := n.Store.Delete(Prefix + req.Name); err != nil && err != store.ErrNotFound {
+		return errors.InternalServerError("go.micro.notify", err.Error())
+	}
+
+	n.Dispatcher.Remove(req.Name)
+
+	return nil
+}
+
+func (n *Notify) List(ctx context.Context, req *pb.ListRequest, rsp *pb.ListResponse) error {
+	channels := n.Dispatcher.Channels()
+	sort.Slice(channels, func(i, j int) bool { return channels[i].Name < channels[j].Name })
+
+	for _, c := range channels {
+		rsp.Channels = append(rsp.Channels, toProto(c))
+	}
+
+	return nil
+}
+
+func (n *Notify) Test(ctx context.Context, req *pb.TestRequest, rsp *pb.TestResponse) error {
+	c, ok := n.Dispatcher.Get(req.Name)
+	if !ok {
+		return errors.NotFound("go.micro.notify", "channel %s not found", req.Name)
+	}
+
+	err := c.Send(&channel.Notification{
+		Topic:     "test",
+		Severity:  "info",
+		Title:     "Test notification",
+		Message:   "Sent by micro notify test to the " + c.Name + " channel",
+		Source:    "go.micro.notify",
+		Timestamp: time.Now().Unix(),
+	})
+	if err != nil {
+		return errors.InternalServerError("go.micro.notify", err.Error())
+	}
+
+	return nil
+}
+
+// dispatch the notification without blocking the subscriber
+func (n *Notify) dispatch(nt *channel.Notification) {
+	go func() {
+		if err := n.Dispatcher.Dispatch(nt); err != nil {
+			log.Logf("Notify error: %v", err)
+		}
+	}()
+}
+
+// Alerts returns the subscriber for alerts published to the topic
+func (n *Notify) Alerts(topic string) func(context.Context, *pb.Alert) error {
+	return func(ctx context.Context, a *pb.Alert) error {
+		severity := a.Severity
+		if len(severity) == 0 {
+			severity = "info"
+		}
+
+		n.dispatch(&channel.Notification{
+			Topic:     topic,
+			Severity:  severity,
+			Title:     a.Title,
+			Message:   a.Message,
+			Source:    a.Source,
+			Labels:    a.Labels,
+			Timestamp: a.Timestamp,
+		})
+
+		return nil
+	}
+}
+
+// eventSeverity returns the severity of the runtime event
+func eventSeverity(t string) string {
+	switch t {
+	case "crash":
+		return "error"
+	case "unhealthy", "kill":
+		return "warning"
+	}
+	return "info"
+}
+
+// Events returns the subscriber for the runtime events published to the topic
+func (n *Notify) Events(topic string) func(context.Context, *epb.Event) error {
+	return func(ctx context.Context, ev *epb.Event) error {
+		title := rnotify.Text(&rnotify.Event{
+			Type:      ev.Type,
+			Service:   ev.Service,
+			Version:   ev.Version,
+			Namespace: ev.Namespace,
+		})
+
+		labels := map[string]string{
+			"type":    ev.Type,
+			"service": ev.Service,
+			"version": ev.Version,
+		}
+		if len(ev.Namespace) > 0 {
+			labels["namespace"] = ev.Namespace
+		}
+
+		n.dispatch(&channel.Notification{
+			Topic:     topic,
+			Severity:  eventSeverity(ev.Type),
+			Title:     title,
+			Message:   ev.Error,
+			Source:    "go.micro.runtime",
+			Labels:    labels,
+			Timestamp: ev.Timestamp,
+		})
+
+		return nil
+	}
+}
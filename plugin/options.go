@@ -6,11 +6,12 @@ import (
 
 // Options are used as part of a new plugin
 type Options struct {
-	Name     string
-	Flags    []cli.Flag
-	Commands []*cli.Command
-	Handlers []Handler
-	Init     func(*cli.Context) error
+	Name      string
+	Flags     []cli.Flag
+	Commands  []*cli.Command
+	Handlers  []Handler
+	Renderers map[string]Renderer
+	Init      func(*cli.Context) error
 }
 
 type Option func(o *Options)
@@ -36,6 +37,16 @@ func WithHandler(h ...Handler) Option {
 	}
 }
 
+// WithRenderer adds an output format selected with --output
+func WithRenderer(name string, r Renderer) Option {
+	return func(o *Options) {
+		if o.Renderers == nil {
+			o.Renderers = make(map[string]Renderer)
+		}
+		o.Renderers[name] = r
+	}
+}
+
 // WithName defines the name of the plugin
 func WithName(n string) Option {
 	return func(o *Options) {
@@ -1,6 +1,7 @@
 package plugin
 
 import (
+	"io"
 	"net/http"
 
 	"github.com/micro/cli/v2"
@@ -23,6 +24,13 @@ type Plugin interface {
 	String() string
 }
 
+// Renderers is optionally implemented by plugins which add output formats to the CLI
+type Renderers interface {
+	// Renderers are additional output formats keyed by
+	// the name they're selected with e.g --output csv
+	Renderers() map[string]Renderer
+}
+
 // Manager is the plugin manager which stores plugins and allows them to be retrieved.
 // This is used by all the components of micro.
 type Manager interface {
@@ -34,6 +42,10 @@ type Manager interface {
 // Its the responsibility of the Handler to call the next http.Handler in the chain.
 type Handler func(http.Handler) http.Handler
 
+// Renderer writes the output of a cli command in a custom format. The
+// output is usually json but may be text for commands which print tables.
+type Renderer func(w io.Writer, output []byte) error
+
 type plugin struct {
 	opts    Options
 	init    func(ctx *cli.Context) error
@@ -56,6 +68,10 @@ func (p *plugin) Init(ctx *cli.Context) error {
 	return p.opts.Init(ctx)
 }
 
+func (p *plugin) Renderers() map[string]Renderer {
+	return p.opts.Renderers
+}
+
 func (p *plugin) String() string {
 	return p.opts.Name
 }
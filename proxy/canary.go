@@ -0,0 +1,227 @@
+package proxy
+
+import (
+	"context"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/micro/go-micro/v2/client"
+	"github.com/micro/go-micro/v2/registry"
+	"github.com/micro/go-micro/v2/registry/cache"
+	"github.com/micro/go-micro/v2/runtime"
+	rs "github.com/micro/go-micro/v2/runtime/service"
+	"github.com/micro/go-micro/v2/util/log"
+)
+
+var (
+	// CanaryRefresh is how often the traffic weights are read from the runtime
+	CanaryRefresh = time.Second * 10
+)
+
+// canary splits the traffic between versions of a service by the
+// weights recorded in the runtime metadata by micro deploy --canary
+type canary struct {
+	client.Client
+
+	runtime  runtime.Runtime
+	registry registry.Registry
+
+	sync.RWMutex
+	// runtime name to version weights
+	weights map[string]map[string]int
+}
+
+func newCanary(c client.Client, r registry.Registry) *canary {
+	cn := &canary{
+		Client:   c,
+		runtime:  rs.NewRuntime(),
+		registry: cache.New(r),
+		weights:  make(map[string]map[string]int),
+	}
+	go cn.run()
+	return cn
+}
+
+func (c *canary) run() {
+	t := time.NewTicker(CanaryRefresh)
+	defer t.Stop()
+
+	for {
+		c.refresh()
+		<-t.C
+	}
+}
+
+// refresh the weights of the services with a canary
+func (c *canary) refresh() {
+	services, err := c.runtime.List()
+	if err != nil {
+		log.Debugf("Failed to read the canary weights: %v", err)
+		return
+	}
+
+	all := make(map[string]map[string]string)
+	canaries := make(map[string]bool)
+
+	for _, s := range services {
+		if _, ok := all[s.Name]; !ok {
+			all[s.Name] = make(map[string]string)
+		}
+		all[s.Name][s.Version] = s.Metadata["weight"]
+
+		if len(s.Metadata["weight"]) > 0 {
+			canaries[s.Name] = true
+		}
+	}
+
+	weights := make(map[string]map[string]int)
+
+	for name := range canaries {
+		var total int
+		var unweighted []string
+
+		versions := make(map[string]int)
+
+		for version, w := range all[name] {
+			n, err := strconv.Atoi(w)
+			if err != nil {
+				unweighted = append(unweighted, version)
+				continue
+			}
+			versions[version] = n
+			total += n
+		}
+
+		// versions without a weight share what's left
+		if remaining := 100 - total; remaining > 0 && len(unweighted) > 0 {
+			for _, version := range unweighted {
+				versions[version] = remaining / len(unweighted)
+			}
+		}
+
+		weights[name] = versions
+	}
+
+	c.Lock()
+	c.weights = weights
+	c.Unlock()
+}
+
+// versions returns the weights for the registered service name, the
+// runtime name is matched as the last part e.g go.micro.srv.greeter
+func (c *canary) versions(service string) map[string]int {
+	c.RLock()
+	defer c.RUnlock()
+
+	if v, ok := c.weights[service]; ok {
+		return v
+	}
+
+	for name, v := range c.weights {
+		if strings.HasSuffix(service, "."+name) {
+			return v
+		}
+	}
+
+	return nil
+}
+
+// pick a version by weight
+func pick(versions map[string]int) string {
+	names := make([]string, 0, len(versions))
+	var total int
+
+	for v, w := range versions {
+		if w <= 0 {
+			continue
+		}
+		names = append(names, v)
+		total += w
+	}
+
+	if total == 0 {
+		return ""
+	}
+
+	sort.Strings(names)
+
+	n := rand.Intn(total)
+	for _, v := range names {
+		if n < versions[v] {
+			return v
+		}
+		n -= versions[v]
+	}
+
+	return ""
+}
+
+// options restricts the addresses to the nodes of the picked version
+func (c *canary) options(service string, opts []client.CallOption) []client.CallOption {
+	versions := c.versions(service)
+	if len(versions) == 0 {
+		return opts
+	}
+
+	version := pick(versions)
+	if len(version) == 0 {
+		return opts
+	}
+
+	services, err := c.registry.GetService(service)
+	if err != nil {
+		return opts
+	}
+
+	nodes := make(map[string]bool)
+	for _, s := range services {
+		if s.Version != version {
+			continue
+		}
+		for _, n := range s.Nodes {
+			nodes[n.Address] = true
+		}
+	}
+
+	if len(nodes) == 0 {
+		return opts
+	}
+
+	var options client.CallOptions
+	for _, o := range opts {
+		o(&options)
+	}
+
+	var address []string
+
+	// without addresses any node of the version will do
+	if len(options.Address) == 0 {
+		for addr := range nodes {
+			address = append(address, addr)
+		}
+	} else {
+		for _, addr := range options.Address {
+			if nodes[addr] {
+				address = append(address, addr)
+			}
+		}
+	}
+
+	if len(address) == 0 {
+		return opts
+	}
+
+	return append(opts, client.WithAddress(address...))
+}
+
+func (c *canary) Call(ctx context.Context, req client.Request, rsp interface{}, opts ...client.CallOption) error {
+	return c.Client.Call(ctx, req, rsp, c.options(req.Service(), opts)...)
+}
+
+func (c *canary) Stream(ctx context.Context, req client.Request, opts ...client.CallOption) (client.Stream, error) {
+	return c.Client.Stream(ctx, req, c.options(req.Service(), opts)...)
+}
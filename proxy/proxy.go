@@ -2,6 +2,7 @@
 package proxy
 
 import (
+	nethttp "net/http"
 	"os"
 	"strings"
 	"time"
@@ -22,7 +23,7 @@ import (
 	"github.com/micro/go-micro/v2/server"
 	sgrpc "github.com/micro/go-micro/v2/server/grpc"
 	"github.com/micro/go-micro/v2/util/log"
-	"github.com/micro/go-micro/v2/util/mux"
+	"github.com/micro/micro/v2/internal/deadline"
 )
 
 var (
@@ -100,6 +101,24 @@ func run(ctx *cli.Context, srvOpts ...micro.Option) {
 
 	popts = append(popts, proxy.WithRouter(r))
 
+	// record the stats of each upstream service
+	st := newStats()
+
+	if addr := ctx.String("metrics_address"); len(addr) > 0 {
+		mux := nethttp.NewServeMux()
+		mux.Handle("/metrics", st)
+		go func() {
+			log.Logf("Proxy serving metrics on %s/metrics", addr)
+			if err := nethttp.ListenAndServe(addr, mux); err != nil {
+				log.Logf("Proxy error serving metrics: %v", err)
+			}
+		}()
+	}
+
+	// split traffic between canary versions passing on the deadline budget
+	wrap := deadline.NewClientWrapper()
+	popts = append(popts, proxy.WithClient(st.wrap(wrap(newCanary(client.DefaultClient, registry.DefaultRegistry)))))
+
 	// new proxy
 	var p proxy.Proxy
 	var srv server.Server
@@ -129,7 +148,7 @@ func run(ctx *cli.Context, srvOpts ...micro.Option) {
 			p = http.NewProxy(popts...)
 			// TODO: http server
 		case "mucp":
-			popts = append(popts, proxy.WithClient(mucli.NewClient()))
+			popts = append(popts, proxy.WithClient(st.wrap(wrap(newCanary(mucli.NewClient(), registry.DefaultRegistry)))))
 			p = mucp.NewProxy(popts...)
 
 			srv = server.NewServer(
@@ -139,7 +158,7 @@ func run(ctx *cli.Context, srvOpts ...micro.Option) {
 				// reset broker to memory
 				server.Broker(bmem.NewBroker()),
 				// hande it the router
-				server.WithRouter(p),
+				server.WithRouter(deadline.NewRouter(Name, p)),
 			)
 		default:
 			p = mucp.NewProxy(popts...)
@@ -151,7 +170,7 @@ func run(ctx *cli.Context, srvOpts ...micro.Option) {
 				// reset broker to memory
 				server.Broker(bmem.NewBroker()),
 				// hande it the router
-				server.WithRouter(p),
+				server.WithRouter(deadline.NewRouter(Name, p)),
 			)
 		}
 	}
@@ -166,7 +185,7 @@ func run(ctx *cli.Context, srvOpts ...micro.Option) {
 	service := micro.NewService(srvOpts...)
 
 	// create a new proxy muxer which includes the debug handler
-	muxer := mux.New(Name, p)
+	muxer := newMuxer(Name, p, st)
 
 	// set the router
 	service.Server().Init(
@@ -219,6 +238,11 @@ func Commands(options ...micro.Option) []*cli.Command {
 				Usage:   "Set the endpoint to route to e.g greeter or localhost:9090",
 				EnvVars: []string{"MICRO_PROXY_ENDPOINT"},
 			},
+			&cli.StringFlag{
+				Name:    "metrics_address",
+				Usage:   "Set the address to serve the upstream stats in the prometheus format on e.g 0.0.0.0:9100",
+				EnvVars: []string{"MICRO_PROXY_METRICS_ADDRESS"},
+			},
 		},
 		Action: func(ctx *cli.Context) error {
 			run(ctx, options...)
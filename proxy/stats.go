@@ -0,0 +1,302 @@
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/micro/go-micro/v2/client"
+	"github.com/micro/go-micro/v2/client/selector"
+	"github.com/micro/go-micro/v2/debug/service/handler"
+	proto "github.com/micro/go-micro/v2/debug/service/proto"
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/proxy"
+	"github.com/micro/go-micro/v2/server"
+	pb "github.com/micro/micro/v2/proxy/proto"
+)
+
+var (
+	// LatencyBuckets are the upper bounds in seconds of the latency histograms
+	LatencyBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+)
+
+// upstream is the stats of a service the proxy routes to
+type upstream struct {
+	requests uint64
+	active   int64
+	// requests in each of the LatencyBuckets plus the overflow
+	buckets []uint64
+	sum     float64
+	errors  map[string]uint64
+}
+
+// stats records the latency, requests in flight and errors of each upstream
+type stats struct {
+	sync.Mutex
+	upstreams map[string]*upstream
+}
+
+func newStats() *stats {
+	return &stats{
+		upstreams: make(map[string]*upstream),
+	}
+}
+
+// get the upstream creating it if needed, the lock must be held
+func (s *stats) get(service string) *upstream {
+	u, ok := s.upstreams[service]
+	if !ok {
+		u = &upstream{
+			buckets: make([]uint64, len(LatencyBuckets)+1),
+			errors:  make(map[string]uint64),
+		}
+		s.upstreams[service] = u
+	}
+	return u
+}
+
+// begin a request returning the func to call when it's no longer in flight
+func (s *stats) begin(service string) func() {
+	s.Lock()
+	s.get(service).active++
+	s.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			s.Lock()
+			s.get(service).active--
+			s.Unlock()
+		})
+	}
+}
+
+// observe the latency and error of a request
+func (s *stats) observe(service string, d time.Duration, err error) {
+	s.Lock()
+	defer s.Unlock()
+
+	u := s.get(service)
+	u.requests++
+	u.sum += d.Seconds()
+	u.buckets[sort.SearchFloat64s(LatencyBuckets, d.Seconds())]++
+
+	if err != nil {
+		u.errors[errorClass(err)]++
+	}
+}
+
+// errorClass returns the class of the error e.g timeout, unavailable
+func errorClass(err error) string {
+	switch err {
+	case context.DeadlineExceeded:
+		return "timeout"
+	case context.Canceled:
+		return "canceled"
+	case selector.ErrNotFound, selector.ErrNoneAvailable:
+		return "unavailable"
+	}
+
+	code := errors.Parse(err.Error()).Code
+	switch {
+	case code == 408 || code == 504:
+		return "timeout"
+	case code == 502 || code == 503:
+		return "unavailable"
+	case code >= 500:
+		return "internal"
+	case code >= 400:
+		return "client"
+	}
+	return "unknown"
+}
+
+// services returns the names of the upstreams sorted, the lock must be held
+func (s *stats) services() []string {
+	services := make([]string, 0, len(s.upstreams))
+	for service := range s.upstreams {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+	return services
+}
+
+// list returns the stats of the upstreams with cumulative latency buckets
+func (s *stats) list() []*pb.Upstream {
+	s.Lock()
+	defer s.Unlock()
+
+	var upstreams []*pb.Upstream
+
+	for _, service := range s.services() {
+		u := s.upstreams[service]
+
+		var count uint64
+		latency := make([]*pb.Bucket, 0, len(LatencyBuckets))
+		for i, le := range LatencyBuckets {
+			count += u.buckets[i]
+			latency = append(latency, &pb.Bucket{Le: le, Count: count})
+		}
+
+		errs := make(map[string]uint64, len(u.errors))
+		for class, n := range u.errors {
+			errs[class] = n
+		}
+
+		upstreams = append(upstreams, &pb.Upstream{
+			Service:    service,
+			Requests:   u.requests,
+			Active:     u.active,
+			Latency:    latency,
+			LatencySum: u.sum,
+			Errors:     errs,
+		})
+	}
+
+	return upstreams
+}
+
+// write the stats in the prometheus text format
+func (s *stats) write(w io.Writer) {
+	upstreams := s.list()
+
+	fmt.Fprintln(w, "# HELP micro_proxy_request_duration_seconds Latency of the requests to the upstream service.")
+	fmt.Fprintln(w, "# TYPE micro_proxy_request_duration_seconds histogram")
+	for _, u := range upstreams {
+		for _, b := range u.Latency {
+			fmt.Fprintf(w, "micro_proxy_request_duration_seconds_bucket{service=%q,le=\"%g\"} %d\n", u.Service, b.Le, b.Count)
+		}
+		fmt.Fprintf(w, "micro_proxy_request_duration_seconds_bucket{service=%q,le=\"+Inf\"} %d\n", u.Service, u.Requests)
+		fmt.Fprintf(w, "micro_proxy_request_duration_seconds_sum{service=%q} %g\n", u.Service, u.LatencySum)
+		fmt.Fprintf(w, "micro_proxy_request_duration_seconds_count{service=%q} %d\n", u.Service, u.Requests)
+	}
+
+	fmt.Fprintln(w, "# HELP micro_proxy_active_requests Requests in flight to the upstream service.")
+	fmt.Fprintln(w, "# TYPE micro_proxy_active_requests gauge")
+	for _, u := range upstreams {
+		fmt.Fprintf(w, "micro_proxy_active_requests{service=%q} %d\n", u.Service, u.Active)
+	}
+
+	fmt.Fprintln(w, "# HELP micro_proxy_errors_total Errors returned by the upstream service by class.")
+	fmt.Fprintln(w, "# TYPE micro_proxy_errors_total counter")
+	for _, u := range upstreams {
+		classes := make([]string, 0, len(u.Errors))
+		for class := range u.Errors {
+			classes = append(classes, class)
+		}
+		sort.Strings(classes)
+		for _, class := range classes {
+			fmt.Fprintf(w, "micro_proxy_errors_total{service=%q,class=%q} %d\n", u.Service, class, u.Errors[class])
+		}
+	}
+}
+
+// ServeHTTP serves the prometheus metrics
+func (s *stats) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	s.write(w)
+}
+
+// wrap the client recording the stats of the requests it makes
+func (s *stats) wrap(c client.Client) client.Client {
+	return &statsClient{Client: c, stats: s}
+}
+
+type statsClient struct {
+	client.Client
+	stats *stats
+}
+
+func (c *statsClient) Call(ctx context.Context, req client.Request, rsp interface{}, opts ...client.CallOption) error {
+	end := c.stats.begin(req.Service())
+	defer end()
+
+	start := time.Now()
+	err := c.Client.Call(ctx, req, rsp, opts...)
+	c.stats.observe(req.Service(), time.Since(start), err)
+	return err
+}
+
+// Stream records the latency of opening the stream, it's in flight until closed
+func (c *statsClient) Stream(ctx context.Context, req client.Request, opts ...client.CallOption) (client.Stream, error) {
+	end := c.stats.begin(req.Service())
+
+	start := time.Now()
+	stream, err := c.Client.Stream(ctx, req, opts...)
+	c.stats.observe(req.Service(), time.Since(start), err)
+	if err != nil {
+		end()
+		return nil, err
+	}
+
+	return &statsStream{Stream: stream, end: end}, nil
+}
+
+type statsStream struct {
+	client.Stream
+	end func()
+}
+
+func (s *statsStream) Close() error {
+	defer s.end()
+	return s.Stream.Close()
+}
+
+// Debug is the debug handler of the proxy, Debug.Stats includes the upstream stats
+type Debug struct {
+	*handler.Debug
+	stats *stats
+}
+
+func (d *Debug) Stats(ctx context.Context, req *proto.StatsRequest, rsp *pb.StatsResponse) error {
+	base := new(proto.StatsResponse)
+	if err := d.Debug.Stats(ctx, req, base); err != nil {
+		return err
+	}
+
+	rsp.Timestamp = base.Timestamp
+	rsp.Started = base.Started
+	rsp.Uptime = base.Uptime
+	rsp.Memory = base.Memory
+	rsp.Threads = base.Threads
+	rsp.Gc = base.Gc
+	rsp.Requests = base.Requests
+	rsp.Errors = base.Errors
+	rsp.Upstreams = d.stats.list()
+
+	return nil
+}
+
+// muxer serves the requests to the proxy itself with the debug handler and proxies the rest
+type muxer struct {
+	name  string
+	proxy proxy.Proxy
+}
+
+func newMuxer(name string, p proxy.Proxy, s *stats) *muxer {
+	server.DefaultRouter.Handle(
+		server.DefaultRouter.NewHandler(
+			&Debug{Debug: handler.NewHandler(), stats: s},
+			server.InternalHandler(true),
+		),
+	)
+	return &muxer{name: name, proxy: p}
+}
+
+func (m *muxer) ProcessMessage(ctx context.Context, msg server.Message) error {
+	if msg.Topic() == m.name {
+		return server.DefaultRouter.ProcessMessage(ctx, msg)
+	}
+	return m.proxy.ProcessMessage(ctx, msg)
+}
+
+func (m *muxer) ServeRequest(ctx context.Context, req server.Request, rsp server.Response) error {
+	if req.Service() == m.name {
+		return server.DefaultRouter.ServeRequest(ctx, req, rsp)
+	}
+	return m.proxy.ServeRequest(ctx, req, rsp)
+}
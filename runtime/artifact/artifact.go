@@ -0,0 +1,193 @@
+// Package artifact caches the binaries built from service sources in the store,
+// content addressed by their digest, so a source is built once per cluster
+// rather than on every runtime node
+package artifact
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/micro/go-micro/v2/store"
+)
+
+var (
+	// Dir is where the artifacts fetched from the store are kept on the node
+	Dir = filepath.Join(os.TempDir(), "micro", "artifacts")
+	// Prefix of the keys in the store
+	Prefix = "artifact/"
+	// Namespace of the store the artifacts are cached in, apart from the runtime state
+	Namespace = "micro-runtime-artifacts"
+
+	// ErrNotCached is returned when there's no artifact for the source
+	ErrNotCached = errors.New("artifact not cached")
+)
+
+func init() {
+	if home, err := os.UserHomeDir(); err == nil {
+		Dir = filepath.Join(home, ".micro", "artifacts")
+	}
+}
+
+// Cache of artifacts
+type Cache struct {
+	store store.Store
+	dir   string
+
+	sync.Mutex
+	// sources being built
+	building map[string]bool
+}
+
+// New returns a cache backed by the store
+func New(s store.Store) *Cache {
+	return &Cache{
+		store:    s,
+		dir:      Dir,
+		building: make(map[string]bool),
+	}
+}
+
+// Digest returns the content address of the artifact
+func Digest(b []byte) string {
+	return fmt.Sprintf("%x", sha256.Sum256(b))
+}
+
+// Cacheable returns true if the source is pinned to a version e.g
+// github.com/my/service@v1.0.0 so its artifact never changes
+func Cacheable(source string) bool {
+	parts := strings.Split(source, "@")
+	return len(parts) == 2 && len(parts[0]) > 0 && len(parts[1]) > 0
+}
+
+// refKey maps the source built for this platform to the digest of the artifact
+func refKey(source string) string {
+	return Prefix + "ref/" + source + "/" + runtime.GOOS + "-" + runtime.GOARCH
+}
+
+func blobKey(digest string) string {
+	return Prefix + "blob/" + digest
+}
+
+// Get returns the path of the artifact for the source, fetching it
+// from the store if it's not on the node. ErrNotCached is returned
+// if the source hasn't been built.
+func (c *Cache) Get(source string) (string, error) {
+	recs, err := c.store.Read(refKey(source))
+	if err == store.ErrNotFound || (err == nil && len(recs) == 0) {
+		return "", ErrNotCached
+	}
+	if err != nil {
+		return "", err
+	}
+
+	digest := string(recs[0].Value)
+	path := filepath.Join(c.dir, digest)
+
+	// already fetched
+	if _, err := os.Stat(path); err == nil {
+		return path, nil
+	}
+
+	recs, err = c.store.Read(blobKey(digest))
+	if err == store.ErrNotFound || (err == nil && len(recs) == 0) {
+		return "", ErrNotCached
+	}
+	if err != nil {
+		return "", err
+	}
+
+	b := recs[0].Value
+	if d := Digest(b); d != digest {
+		return "", fmt.Errorf("artifact %s is corrupt, got digest %s", digest, d)
+	}
+
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		return "", err
+	}
+
+	// write then rename so a partial file is never run
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, b, 0755); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return "", err
+	}
+
+	return path, nil
+}
+
+// Put the artifact at the path into the cache for the source returning its digest
+func (c *Cache) Put(source, path string) (string, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	digest := Digest(b)
+
+	// blobs are immutable so are only written once
+	if _, err := c.store.Read(blobKey(digest)); err == store.ErrNotFound {
+		if err := c.store.Write(&store.Record{Key: blobKey(digest), Value: b}); err != nil {
+			return "", err
+		}
+	} else if err != nil {
+		return "", err
+	}
+
+	if err := c.store.Write(&store.Record{Key: refKey(source), Value: []byte(digest)}); err != nil {
+		return "", err
+	}
+
+	return digest, nil
+}
+
+// Build the source and put the artifact in the cache, it's
+// a no-op if the source is already being built on the node
+func (c *Cache) Build(source string) (string, error) {
+	c.Lock()
+	if c.building[source] {
+		c.Unlock()
+		return "", fmt.Errorf("%s is already being built", source)
+	}
+	c.building[source] = true
+	c.Unlock()
+
+	defer func() {
+		c.Lock()
+		delete(c.building, source)
+		c.Unlock()
+	}()
+
+	dir, err := ioutil.TempDir("", "micro-artifact")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(dir)
+
+	// go get installs the binary into GOBIN
+	cmd := exec.Command("go", "get", source)
+	cmd.Env = append(os.Environ(), "GOBIN="+dir)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("failed to build %s: %v: %s", source, err, strings.TrimSpace(string(out)))
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+	if len(files) != 1 {
+		return "", fmt.Errorf("expected building %s to produce one binary, got %d", source, len(files))
+	}
+
+	return c.Put(source, filepath.Join(dir, files[0].Name()))
+}
@@ -0,0 +1,75 @@
+package artifact
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/micro/go-micro/v2/store"
+	"github.com/micro/go-micro/v2/store/memory"
+)
+
+func TestCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "artifact")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	s := memory.NewStore()
+
+	// the node which built the source
+	built := New(s)
+	built.dir = filepath.Join(dir, "built")
+
+	// another node sharing the store
+	node := New(s)
+	node.dir = filepath.Join(dir, "node")
+
+	source := "github.com/micro/services/helloworld@v1.0.0"
+
+	if _, err := node.Get(source); err != ErrNotCached {
+		t.Fatalf("Expected %v got %v", ErrNotCached, err)
+	}
+
+	bin := filepath.Join(dir, "helloworld")
+	if err := ioutil.WriteFile(bin, []byte("binary"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	digest, err := built.Put(source, bin)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path, err := node.Get(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path != filepath.Join(node.dir, digest) {
+		t.Fatalf("Unexpected path %s", path)
+	}
+	if b, _ := ioutil.ReadFile(path); string(b) != "binary" {
+		t.Fatalf("Unexpected artifact %s", b)
+	}
+
+	// a corrupt blob isn't used
+	os.Remove(path)
+	s.Write(&store.Record{Key: blobKey(digest), Value: []byte("corrupt")})
+	if _, err := node.Get(source); err == nil {
+		t.Fatal("Expected an error for a corrupt artifact")
+	}
+}
+
+func TestCacheable(t *testing.T) {
+	for source, want := range map[string]bool{
+		"github.com/micro/services/helloworld@v1.0.0": true,
+		"github.com/micro/services/helloworld":        false,
+		"github.com/micro/services/helloworld@":       false,
+	} {
+		if got := Cacheable(source); got != want {
+			t.Errorf("Cacheable(%s) = %v, want %v", source, got, want)
+		}
+	}
+}
@@ -0,0 +1,447 @@
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/micro/cli/v2"
+	"github.com/micro/go-micro/v2"
+	"github.com/micro/go-micro/v2/client"
+	"github.com/micro/go-micro/v2/runtime"
+	"github.com/micro/go-micro/v2/store"
+	"github.com/micro/go-micro/v2/util/log"
+	spb "github.com/micro/micro/v2/debug/stats/proto"
+	pb "github.com/micro/micro/v2/runtime/events/proto"
+)
+
+var (
+	// AutoscaleUsage message for the autoscale command
+	AutoscaleUsage = "Required usage: micro autoscale set [service] --min 2 --max 10 --target-rps 500 --target-latency 200ms"
+	// AutoscaleInterval is how often the stats are checked
+	AutoscaleInterval = time.Second * 30
+	// AutoscaleCooldown is the minimum time between scaling a service
+	AutoscaleCooldown = time.Minute * 3
+	// StatsName is the name of the debug stats service
+	StatsName = "go.micro.debug.stats"
+
+	// scalingKeys are the metadata keys which only change the number of replicas
+	scalingKeys = []string{"replicas", "autoscale"}
+	// replicaSeparator separates the version and replica number of an instance
+	replicaSeparator = "#"
+)
+
+// policy for scaling a service between min and max replicas, the
+// latency targeted is the 99th percentile of the recent requests
+type policy struct {
+	min     int
+	max     int
+	rps     float64
+	memory  int64
+	latency time.Duration
+}
+
+// parsePolicy parses the autoscale metadata e.g min=2,max=10,rps=500,memory=268435456,latency=200ms
+func parsePolicy(v string) (*policy, error) {
+	p := &policy{min: 1}
+
+	for _, field := range strings.Split(v, ",") {
+		kv := strings.SplitN(strings.TrimSpace(field), "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid autoscale policy %s", v)
+		}
+
+		var err error
+
+		switch kv[0] {
+		case "min":
+			p.min, err = strconv.Atoi(kv[1])
+		case "max":
+			p.max, err = strconv.Atoi(kv[1])
+		case "rps":
+			p.rps, err = strconv.ParseFloat(kv[1], 64)
+		case "memory":
+			p.memory, err = parseMemory(kv[1])
+		case "latency":
+			p.latency, err = time.ParseDuration(kv[1])
+		default:
+			err = fmt.Errorf("unknown field %s", kv[0])
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid autoscale policy %s: %v", v, err)
+		}
+	}
+
+	if p.min < 1 || p.max < p.min {
+		return nil, fmt.Errorf("invalid autoscale policy %s: expected 1 <= min <= max", v)
+	}
+	if p.rps <= 0 && p.memory <= 0 && p.latency <= 0 {
+		return nil, fmt.Errorf("invalid autoscale policy %s: a target rps, memory or latency is required", v)
+	}
+
+	return p, nil
+}
+
+func (p *policy) String() string {
+	s := fmt.Sprintf("min=%d,max=%d", p.min, p.max)
+	if p.rps > 0 {
+		s += ",rps=" + strconv.FormatFloat(p.rps, 'f', -1, 64)
+	}
+	if p.memory > 0 {
+		s += ",memory=" + strconv.FormatInt(p.memory, 10)
+	}
+	if p.latency > 0 {
+		s += ",latency=" + p.latency.String()
+	}
+	return s
+}
+
+// desired returns the replicas needed for the request rate, the average
+// memory and the latency of each replica within the min and max of the policy
+func (p *policy) desired(current int, u *usage) int {
+	n := p.min
+
+	if p.rps > 0 {
+		if r := int(math.Ceil(u.rps / p.rps)); r > n {
+			n = r
+		}
+	}
+
+	if p.memory > 0 && u.memory > 0 && current > 0 {
+		if r := int(math.Ceil(float64(current) * float64(u.memory) / float64(p.memory))); r > n {
+			n = r
+		}
+	}
+
+	// latency is assumed to fall in proportion to the replicas added
+	if p.latency > 0 && u.latency > 0 && current > 0 {
+		if r := int(math.Ceil(float64(current) * float64(u.latency) / float64(p.latency))); r > n {
+			n = r
+		}
+	}
+
+	if n > p.max {
+		n = p.max
+	}
+
+	return n
+}
+
+// replicas returns the number of instances of the service to run
+func replicas(s *runtime.Service) int {
+	n, err := strconv.Atoi(s.Metadata["replicas"])
+	if err != nil || n < 1 {
+		return 1
+	}
+	return n
+}
+
+// replicaService returns the nth additional instance of the service
+func replicaService(s *runtime.Service, n int) *runtime.Service {
+	md := make(map[string]string, len(s.Metadata))
+	for k, v := range s.Metadata {
+		md[k] = v
+	}
+
+	return &runtime.Service{
+		Name:     s.Name,
+		Version:  s.Version + replicaSeparator + strconv.Itoa(n),
+		Source:   s.Source,
+		Metadata: md,
+	}
+}
+
+// replicate runs the additional replicas of a running service, the
+// keys of the replicas are added to keep so they aren't stopped
+func (m *manager) replicate(rs *runtimeService, running map[string]*runtime.Service, keep map[string]bool) {
+	for i := 1; i < replicas(rs.Service); i++ {
+		r := replicaService(rs.Service, i)
+		keep[key(r)] = true
+
+		if _, ok := running[key(r)]; ok {
+			continue
+		}
+
+		opts, err := m.createOptions(r, rs.Options)
+		if err != nil {
+			log.Logf("Erroring running replica %d of %s: %v", i, rs.Service.Name, err)
+			continue
+		}
+
+		log.Logf("Creating replica %d of %s %s", i, rs.Service.Name, rs.Service.Version)
+
+		if err := m.Runtime.Create(r, opts...); err != nil && err != runtime.ErrAlreadyExists {
+			log.Logf("Erroring running replica %d of %s: %v", i, rs.Service.Name, err)
+		}
+	}
+}
+
+// usage is the request rate, average memory and latency of a service
+type usage struct {
+	rps    float64
+	memory uint64
+	// the highest 99th percentile latency of its nodes
+	latency time.Duration
+}
+
+// readUsage reads the usage of the services from the debug stats
+func readUsage(c client.Client) (map[string]*usage, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	stats := spb.NewStatsService(StatsName, c)
+
+	rsp, err := stats.Aggregate(ctx, &spb.AggregateRequest{
+		From:    time.Now().Add(-AutoscaleInterval * 2).Unix(),
+		GroupBy: "service",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	services := make(map[string]*usage)
+	for _, agg := range rsp.Aggregates {
+		services[agg.Service] = &usage{rps: agg.RequestRate, memory: agg.Memory}
+	}
+
+	// the latency is only reported in the current snapshots
+	snaps, err := stats.Read(ctx, &spb.ReadRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, snap := range snaps.Stats {
+		if snap.Service == nil || snap.Latency == nil {
+			continue
+		}
+		u, ok := services[snap.Service.Name]
+		if !ok {
+			continue
+		}
+		if l := time.Duration(snap.Latency.P99 * float64(time.Millisecond)); l > u.latency {
+			u.latency = l
+		}
+	}
+
+	return services, nil
+}
+
+// usageOf returns the usage of the runtime service, the runtime
+// name is matched as the last part e.g go.micro.srv.greeter
+func usageOf(services map[string]*usage, name string) *usage {
+	if u, ok := services[name]; ok {
+		return u
+	}
+	for n, u := range services {
+		if strings.HasSuffix(n, "."+name) {
+			return u
+		}
+	}
+	return nil
+}
+
+// autoscale adjusts the replicas of the services with a policy
+func (m *manager) autoscale() {
+	t := time.NewTicker(AutoscaleInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-m.exit:
+			return
+		case <-t.C:
+		}
+
+		m.RLock()
+		var scaled []*runtimeService
+		for _, rs := range m.services {
+			if len(rs.Service.Metadata["autoscale"]) > 0 {
+				scaled = append(scaled, rs)
+			}
+		}
+		m.RUnlock()
+
+		if len(scaled) == 0 {
+			continue
+		}
+
+		services, err := readUsage(client.DefaultClient)
+		if err != nil {
+			log.Logf("Failed to read the stats to autoscale: %v", err)
+			continue
+		}
+
+		for _, rs := range scaled {
+			p, err := parsePolicy(rs.Service.Metadata["autoscale"])
+			if err != nil {
+				log.Logf("Not autoscaling %s: %v", rs.Service.Name, err)
+				continue
+			}
+
+			u := usageOf(services, rs.Service.Name)
+			if u == nil {
+				continue
+			}
+
+			current := replicas(rs.Service)
+			if n := p.desired(current, u); n != current {
+				m.scale(rs.Service, n, u)
+			}
+		}
+	}
+}
+
+// scale the service to n replicas
+func (m *manager) scale(s *runtime.Service, n int, u *usage) {
+	m.Lock()
+	defer m.Unlock()
+
+	k := key(s)
+
+	rs, ok := m.services[k]
+	if !ok {
+		return
+	}
+
+	if time.Since(time.Unix(rs.Scaled, 0)) < AutoscaleCooldown {
+		return
+	}
+
+	log.Logf("Scaling %s %s from %d to %d replicas at %.1f rps, %d bytes of memory and %v latency",
+		s.Name, s.Version, replicas(rs.Service), n, u.rps, u.memory, u.latency)
+
+	md := make(map[string]string, len(rs.Service.Metadata))
+	for k, v := range rs.Service.Metadata {
+		md[k] = v
+	}
+	md["replicas"] = strconv.Itoa(n)
+
+	rs.Service = &runtime.Service{
+		Name:     rs.Service.Name,
+		Version:  rs.Service.Version,
+		Source:   rs.Service.Source,
+		Metadata: md,
+	}
+	rs.Scaled = time.Now().Unix()
+
+	b, err := json.Marshal(rs)
+	if err != nil {
+		return
+	}
+
+	if err := m.Store.Write(&store.Record{Key: k, Value: b}); err != nil {
+		log.Logf("Failed to save the replicas of %s: %v", s.Name, err)
+		return
+	}
+
+	go m.publishEvent(&pb.Event{
+		Type:      "scale",
+		Timestamp: time.Now().Unix(),
+		Service:   s.Name,
+		Version:   s.Version,
+		Namespace: md[namespaceKey],
+		Replicas:  int32(n),
+	})
+}
+
+// setAutoscale sets the autoscale policy of a service
+func setAutoscale(ctx *cli.Context, srvOpts ...micro.Option) {
+	p := &policy{
+		min:     ctx.Int("min"),
+		max:     ctx.Int("max"),
+		rps:     ctx.Float64("target-rps"),
+		latency: ctx.Duration("target-latency"),
+	}
+
+	if mem := ctx.String("target-memory"); len(mem) > 0 {
+		v, err := parseMemory(mem)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		p.memory = v
+	}
+
+	// validate the policy
+	if _, err := parsePolicy(p.String()); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	updateAutoscale(ctx, p.String())
+}
+
+// unsetAutoscale removes the autoscale policy of a service
+func unsetAutoscale(ctx *cli.Context, srvOpts ...micro.Option) {
+	updateAutoscale(ctx, "")
+}
+
+// updateAutoscale sets the policy on every version of the service
+func updateAutoscale(ctx *cli.Context, value string) {
+	name := ctx.Args().Get(0)
+	if len(name) == 0 {
+		fmt.Println(AutoscaleUsage)
+		return
+	}
+
+	r := newRuntime(ctx)
+
+	services, err := r.Read(runtime.ReadService(name), runtime.ReadVersion(ctx.String("version")))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	if len(services) == 0 {
+		fmt.Printf("Service %s not found\n", name)
+		return
+	}
+
+	for _, s := range services {
+		srv := &runtime.Service{
+			Name:     s.Name,
+			Version:  s.Version,
+			Source:   s.Source,
+			Metadata: withoutKeys(s.Metadata, append(statusKeys, "autoscale")...),
+		}
+		if len(value) > 0 {
+			srv.Metadata["autoscale"] = value
+		}
+
+		if err := r.Update(srv); err != nil {
+			fmt.Printf("Failed to update %s %s: %v\n", s.Name, s.Version, err)
+			return
+		}
+	}
+
+	if len(value) == 0 {
+		fmt.Printf("Removed the autoscale policy of %s\n", name)
+		return
+	}
+
+	fmt.Printf("Autoscaling %s with %s\n", name, value)
+}
+
+// listAutoscale lists the services with an autoscale policy
+func listAutoscale(ctx *cli.Context, srvOpts ...micro.Option) {
+	services, err := newRuntime(ctx).List()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
+	fmt.Fprintln(writer, "NAME\tVERSION\tREPLICAS\tPOLICY")
+	for _, s := range services {
+		if len(s.Metadata["autoscale"]) == 0 {
+			continue
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%d\t%s\n", s.Name, s.Version, replicas(s), s.Metadata["autoscale"])
+	}
+	writer.Flush()
+}
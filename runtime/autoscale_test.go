@@ -0,0 +1,53 @@
+package runtime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParsePolicy(t *testing.T) {
+	p, err := parsePolicy("min=2,max=10,rps=500,memory=256Mi,latency=200ms")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.min != 2 || p.max != 10 || p.rps != 500 || p.memory != 256<<20 || p.latency != 200*time.Millisecond {
+		t.Fatalf("Unexpected policy %+v", p)
+	}
+
+	// the policy is stored in the metadata as a string
+	if v := p.String(); v != "min=2,max=10,rps=500,memory=268435456,latency=200ms" {
+		t.Fatalf("Unexpected policy string %s", v)
+	}
+
+	for _, v := range []string{"", "min=2", "min=0,max=2,rps=1", "min=3,max=2,rps=1", "min=1,max=2,latency=5"} {
+		if _, err := parsePolicy(v); err == nil {
+			t.Fatalf("Expected error parsing %s", v)
+		}
+	}
+}
+
+func TestDesiredReplicas(t *testing.T) {
+	p := &policy{min: 2, max: 10, rps: 500, memory: 100, latency: 200 * time.Millisecond}
+
+	testData := []struct {
+		current int
+		usage   usage
+		expect  int
+	}{
+		// idle scales down to the minimum
+		{4, usage{}, 2},
+		{2, usage{rps: 1200, memory: 50}, 3},
+		// memory pressure across the current replicas
+		{3, usage{rps: 100, memory: 200}, 6},
+		// latency above the target across the current replicas
+		{3, usage{rps: 100, memory: 50, latency: 500 * time.Millisecond}, 8},
+		// capped at the maximum
+		{2, usage{rps: 100000, memory: 50}, 10},
+	}
+
+	for _, d := range testData {
+		if n := p.desired(d.current, &d.usage); n != d.expect {
+			t.Fatalf("Expected %d replicas for %+v got %d", d.expect, d, n)
+		}
+	}
+}
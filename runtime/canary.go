@@ -0,0 +1,261 @@
+package runtime
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/micro/cli/v2"
+	"github.com/micro/go-micro/v2"
+	"github.com/micro/go-micro/v2/runtime"
+)
+
+var (
+	// DeployUsage message for the deploy command
+	DeployUsage = "Required usage: micro deploy --canary 10% --version v2 github.com/my/service"
+	// PromoteUsage message for the promote command
+	PromoteUsage = "Required usage: micro promote [service] [version]"
+
+	// statusKeys are the metadata keys set by the runtime on read
+	statusKeys = []string{
+		"status", "error", "started", "restarts", "last_error",
+		"last_run", "next_run", "exit_status", "log_file",
+		"cpu_time", "rss", "fds",
+	}
+	// routingKeys are the metadata keys which only affect routing
+	routingKeys = []string{"weight", "canary"}
+)
+
+// parseWeight parses the share of traffic e.g 10%
+func parseWeight(s string) (int, error) {
+	w, err := strconv.Atoi(strings.TrimSuffix(strings.TrimSpace(s), "%"))
+	if err != nil || w <= 0 || w >= 100 {
+		return 0, fmt.Errorf("invalid canary weight %q, expected 1%% to 99%%", s)
+	}
+	return w, nil
+}
+
+// withoutKeys returns a copy of the metadata without the keys
+func withoutKeys(md map[string]string, keys ...string) map[string]string {
+	cp := make(map[string]string, len(md))
+	for k, v := range md {
+		cp[k] = v
+	}
+	for _, k := range keys {
+		delete(cp, k)
+	}
+	return cp
+}
+
+// routingOnly returns true if the services only differ by their routing or scaling metadata
+func routingOnly(a, b *runtime.Service) bool {
+	if a.Source != b.Source {
+		return false
+	}
+
+	keys := append(append(statusKeys, routingKeys...), scalingKeys...)
+	am := withoutKeys(a.Metadata, keys...)
+	bm := withoutKeys(b.Metadata, keys...)
+
+	if len(am) != len(bm) {
+		return false
+	}
+	for k, v := range am {
+		if bm[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// setWeight records the share of traffic the service version receives
+func setWeight(r runtime.Runtime, s *runtime.Service, weight int, canary bool) error {
+	srv := &runtime.Service{
+		Name:     s.Name,
+		Version:  s.Version,
+		Source:   s.Source,
+		Metadata: withoutKeys(s.Metadata, append(statusKeys, routingKeys...)...),
+	}
+
+	if weight > 0 {
+		srv.Metadata["weight"] = strconv.Itoa(weight)
+	}
+	if canary {
+		srv.Metadata["canary"] = "true"
+	}
+
+	return r.Update(srv)
+}
+
+// splitWeight gives the stable versions the remaining share of traffic
+func splitWeight(r runtime.Runtime, stable []*runtime.Service, weight int) error {
+	remaining := 100 - weight
+
+	for i, s := range stable {
+		share := remaining / len(stable)
+		// give the remainder to the first
+		if i == 0 {
+			share += remaining % len(stable)
+		}
+
+		if err := setWeight(r, s, share, false); err != nil {
+			return fmt.Errorf("failed to set the weight of %s %s: %v", s.Name, s.Version, err)
+		}
+	}
+
+	return nil
+}
+
+// deployService runs a canary version of a service alongside the
+// running versions which receives the given share of the traffic
+func deployService(ctx *cli.Context, srvOpts ...micro.Option) {
+	// without a canary it's the same as run
+	if len(ctx.String("canary")) == 0 {
+		runService(ctx, srvOpts...)
+		return
+	}
+
+	weight, err := parseWeight(ctx.String("canary"))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	if ctx.Bool("local") {
+		fmt.Println("Canaries are run by the runtime service, start it with micro runtime")
+		return
+	}
+
+	name := ctx.String("name")
+	version := ctx.String("version")
+	source := ctx.String("source")
+
+	if v := ctx.Args().Get(0); len(v) > 0 && v != "service" {
+		source = v
+	}
+	if len(name) == 0 && len(source) > 0 {
+		name = filepath.Base(source)
+	}
+	if len(name) == 0 || len(version) == 0 || version == "latest" {
+		fmt.Println(DeployUsage)
+		return
+	}
+
+	r := newRuntime(ctx)
+
+	services, err := r.Read(runtime.ReadService(name))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	var stable []*runtime.Service
+	var current *runtime.Service
+
+	for _, s := range services {
+		if s.Version == version {
+			current = s
+			continue
+		}
+		stable = append(stable, s)
+	}
+
+	if len(stable) == 0 {
+		fmt.Printf("No running version of %s to canary against, use micro run\n", name)
+		return
+	}
+
+	// change the weight of an existing canary
+	if current != nil {
+		if err := setWeight(r, current, weight, true); err != nil {
+			fmt.Println(err)
+			return
+		}
+		if err := splitWeight(r, stable, weight); err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Printf("Canary %s %s now receives %d%% of traffic\n", name, version, weight)
+		return
+	}
+
+	// runService records the weight of the canary
+	runService(ctx, srvOpts...)
+
+	services, err = r.Read(runtime.ReadService(name), runtime.ReadVersion(version))
+	if err != nil || len(services) == 0 {
+		return
+	}
+
+	if err := splitWeight(r, stable, weight); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Printf("Canary %s %s receives %d%% of traffic\n", name, version, weight)
+}
+
+// promoteService sends all traffic to the canary and kills the other versions
+func promoteService(ctx *cli.Context, srvOpts ...micro.Option) {
+	name := ctx.String("name")
+	version := ctx.String("version")
+
+	if ctx.Args().Len() > 0 {
+		name = ctx.Args().Get(0)
+		if ctx.Args().Len() > 1 {
+			version = ctx.Args().Get(1)
+		} else {
+			version = ""
+		}
+	}
+
+	if len(name) == 0 {
+		fmt.Println(PromoteUsage)
+		return
+	}
+
+	r := newRuntime(ctx)
+
+	services, err := r.Read(runtime.ReadService(name))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	var canary *runtime.Service
+
+	for _, s := range services {
+		if len(version) > 0 && version != "latest" {
+			if s.Version == version {
+				canary = s
+			}
+		} else if s.Metadata["canary"] == "true" {
+			canary = s
+		}
+	}
+
+	if canary == nil {
+		fmt.Println(errors.New("no canary found for " + name))
+		return
+	}
+
+	// route everything to the canary before killing the rest
+	if err := setWeight(r, canary, 0, false); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	for _, s := range services {
+		if s.Version == canary.Version {
+			continue
+		}
+		if err := r.Delete(s); err != nil {
+			fmt.Printf("Failed to kill %s %s: %v\n", s.Name, s.Version, err)
+			return
+		}
+	}
+
+	fmt.Printf("Promoted %s %s\n", canary.Name, canary.Version)
+}
@@ -0,0 +1,27 @@
+// Package cgroup applies resource limits to locally run services
+package cgroup
+
+import (
+	"errors"
+)
+
+var (
+	// Root is the cgroup (v2) hierarchy under which service groups are created
+	Root = "/sys/fs/cgroup/micro"
+
+	// ErrNotSupported is returned on platforms without cgroups
+	ErrNotSupported = errors.New("cgroups are not supported on this platform")
+)
+
+// Limits are the resource limits applied to a service
+type Limits struct {
+	// CPU in cores e.g 0.5
+	CPU float64
+	// Memory in bytes
+	Memory int64
+}
+
+// Empty returns true if no limits are set
+func (l Limits) Empty() bool {
+	return l.CPU <= 0 && l.Memory <= 0
+}
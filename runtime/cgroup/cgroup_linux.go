@@ -0,0 +1,71 @@
+package cgroup
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// Supported is true as limits are enforced with cgroups on linux
+	Supported = true
+
+	// cpu period in microseconds used for the cpu.max quota
+	cpuPeriod = 100000
+)
+
+func write(path, value string) error {
+	return ioutil.WriteFile(path, []byte(value), 0644)
+}
+
+// Command creates a cgroup for the named service with the given limits and
+// returns the command wrapped so the process joins the group before it execs.
+func Command(name string, l Limits, command []string) ([]string, error) {
+	if l.Empty() || len(command) == 0 {
+		return command, nil
+	}
+
+	if err := os.MkdirAll(Root, 0755); err != nil {
+		return nil, err
+	}
+
+	// enable the controllers for the child groups
+	if err := write(filepath.Join(Root, "cgroup.subtree_control"), "+cpu +memory"); err != nil {
+		return nil, fmt.Errorf("failed to enable cgroup controllers: %v", err)
+	}
+
+	group := filepath.Join(Root, strings.Replace(name, "/", "-", -1))
+	if err := os.MkdirAll(group, 0755); err != nil {
+		return nil, err
+	}
+
+	if l.Memory > 0 {
+		if err := write(filepath.Join(group, "memory.max"), fmt.Sprintf("%d", l.Memory)); err != nil {
+			return nil, fmt.Errorf("failed to set memory limit: %v", err)
+		}
+	}
+
+	if l.CPU > 0 {
+		quota := int64(l.CPU * cpuPeriod)
+		if err := write(filepath.Join(group, "cpu.max"), fmt.Sprintf("%d %d", quota, cpuPeriod)); err != nil {
+			return nil, fmt.Errorf("failed to set cpu limit: %v", err)
+		}
+	}
+
+	// move the shell into the group then exec the actual command
+	// so it and any children inherit the limits
+	script := fmt.Sprintf(`echo $$ > %s && exec "$0" "$@"`, filepath.Join(group, "cgroup.procs"))
+
+	return append([]string{"sh", "-c", script}, command...), nil
+}
+
+// Delete removes the cgroup for the named service
+func Delete(name string) error {
+	group := filepath.Join(Root, strings.Replace(name, "/", "-", -1))
+	if err := os.Remove(group); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
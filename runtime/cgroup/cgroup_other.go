@@ -0,0 +1,20 @@
+//go:build !linux
+// +build !linux
+
+package cgroup
+
+// Supported is false as cgroups are linux only
+const Supported = false
+
+// Command returns the command unchanged as cgroups are linux only
+func Command(name string, l Limits, command []string) ([]string, error) {
+	if l.Empty() {
+		return command, nil
+	}
+	return command, ErrNotSupported
+}
+
+// Delete is a no-op on platforms without cgroups
+func Delete(name string) error {
+	return nil
+}
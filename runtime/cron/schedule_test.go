@@ -0,0 +1,68 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNext(t *testing.T) {
+	// a wednesday
+	from := time.Date(2020, 1, 29, 10, 17, 30, 0, time.UTC)
+
+	testData := []struct {
+		expr string
+		next time.Time
+	}{
+		{"* * * * *", time.Date(2020, 1, 29, 10, 18, 0, 0, time.UTC)},
+		{"*/5 * * * *", time.Date(2020, 1, 29, 10, 20, 0, 0, time.UTC)},
+		{"0 * * * *", time.Date(2020, 1, 29, 11, 0, 0, 0, time.UTC)},
+		{"30 2 * * *", time.Date(2020, 1, 30, 2, 30, 0, 0, time.UTC)},
+		{"0 9 * * 1-5", time.Date(2020, 1, 30, 9, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2020, 2, 2, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"15,45 10 * * *", time.Date(2020, 1, 29, 10, 45, 0, 0, time.UTC)},
+		{"@daily", time.Date(2020, 1, 30, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"@every 90s", from.Add(90 * time.Second)},
+		// either day of month or week may match when both are set
+		{"0 0 1 * 5", time.Date(2020, 1, 31, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, d := range testData {
+		s, err := Parse(d.expr)
+		if err != nil {
+			t.Fatalf("%s: unexpected error %v", d.expr, err)
+		}
+		if next := s.Next(from); !next.Equal(d.next) {
+			t.Fatalf("%s: expected %v got %v", d.expr, d.next, next)
+		}
+	}
+
+	// never runs
+	s, err := Parse("0 0 30 2 *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next := s.Next(from); !next.IsZero() {
+		t.Fatalf("expected zero time got %v", next)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"@every nope",
+		"@every 1ms",
+	} {
+		if _, err := Parse(expr); err == nil {
+			t.Fatalf("%q: expected error", expr)
+		}
+	}
+}
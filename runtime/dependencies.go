@@ -0,0 +1,103 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/micro/go-micro/v2/client"
+	"github.com/micro/go-micro/v2/config/cmd"
+	proto "github.com/micro/go-micro/v2/debug/service/proto"
+)
+
+var (
+	// DependencyTimeout is how long to wait for dependencies to be ready
+	DependencyTimeout = time.Minute * 2
+	// ReadyTimeout is how long a service is given to report it's healthy
+	ReadyTimeout = time.Second * 5
+)
+
+// dependencies returns the dependencies declared in the metadata
+func dependencies(md map[string]string) []string {
+	var deps []string
+	for _, dep := range strings.Split(md["dependencies"], ",") {
+		if dep = strings.TrimSpace(dep); len(dep) > 0 {
+			deps = append(deps, dep)
+		}
+	}
+	return deps
+}
+
+// ready returns nil if the service is registered and healthy within the ReadyTimeout
+func ready(name string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), ReadyTimeout)
+	defer cancel()
+
+	// the registry can't be given a deadline so is left to return in the background
+	errc := make(chan error, 1)
+	go func() {
+		errc <- reportsHealthy(ctx, name)
+	}()
+
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("not ready after %v", ReadyTimeout)
+	}
+}
+
+// reportsHealthy returns nil if a node of the service reports it's healthy
+func reportsHealthy(ctx context.Context, name string) error {
+	services, err := (*cmd.DefaultCmd.Options().Registry).GetService(name)
+	if err != nil {
+		return err
+	}
+
+	c := *cmd.DefaultCmd.Options().Client
+	req := c.NewRequest(name, "Debug.Health", &proto.HealthRequest{})
+
+	for _, srv := range services {
+		for _, node := range srv.Nodes {
+			rsp := &proto.HealthResponse{}
+			if err := c.Call(ctx, req, rsp, client.WithAddress(node.Address)); err != nil {
+				continue
+			}
+			if rsp.Status == "ok" {
+				return nil
+			}
+		}
+	}
+
+	return errors.New("not healthy")
+}
+
+// waitingOn returns the first dependency which is not ready
+func waitingOn(md map[string]string) (string, error) {
+	for _, dep := range dependencies(md) {
+		if err := ready(dep); err != nil {
+			return dep, err
+		}
+	}
+	return "", nil
+}
+
+// waitForDependencies blocks until the dependencies are ready or the timeout is reached
+func waitForDependencies(md map[string]string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+
+	for {
+		dep, err := waitingOn(md)
+		if err == nil {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("dependency %s not ready after %v: %v", dep, timeout, err)
+		}
+
+		time.Sleep(time.Second)
+	}
+}
@@ -0,0 +1,316 @@
+package runtime
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/micro/go-micro/v2/config/cmd"
+	"github.com/micro/go-micro/v2/registry"
+	"github.com/micro/go-micro/v2/runtime"
+	"github.com/micro/go-micro/v2/store"
+	"github.com/micro/go-micro/v2/util/log"
+	"github.com/micro/micro/v2/runtime/process"
+)
+
+const (
+	// pidsPrefix is the store key prefix for the pids of the processes run
+	pidsPrefix = "pids/"
+)
+
+var (
+	// GracePeriod is how long a service is given to drain and exit before it's killed
+	GracePeriod = time.Second * 10
+	// DrainInterval is how often a draining service is checked
+	DrainInterval = time.Second
+	// DeregisterDelay is how long clients are given to stop routing to a deregistered service
+	DeregisterDelay = time.Second * 2
+	// InstanceKey is the metadata set on the nodes of a service identifying the runtime that runs it
+	InstanceKey = "micro_runtime_instance"
+)
+
+// gracePeriod returns the grace period set in the metadata or the default
+func (m *manager) gracePeriod(s *runtime.Service) time.Duration {
+	if d, err := time.ParseDuration(s.Metadata["grace"]); err == nil && d >= 0 {
+		return d
+	}
+	return m.grace
+}
+
+// instance identifies the service as run by this runtime
+func (m *manager) instance(s *runtime.Service) string {
+	return m.id + "/" + key(s)
+}
+
+// registered returns the records of the service with only the nodes registered by
+// the instance, it's matched as the last part of the name e.g go.micro.srv.greeter
+func registered(name, instance string) ([]*registry.Service, error) {
+	reg := *cmd.DefaultCmd.Options().Registry
+
+	services, err := reg.ListServices()
+	if err != nil {
+		return nil, err
+	}
+
+	var owned []*registry.Service
+
+	for _, srv := range services {
+		if srv.Name != name && !strings.HasSuffix(srv.Name, "."+name) {
+			continue
+		}
+
+		records, err := reg.GetService(srv.Name)
+		if err != nil {
+			continue
+		}
+
+		for _, record := range records {
+			var nodes []*registry.Node
+			for _, node := range record.Nodes {
+				if node.Metadata[InstanceKey] == instance {
+					nodes = append(nodes, node)
+				}
+			}
+			if len(nodes) == 0 {
+				continue
+			}
+
+			owned = append(owned, &registry.Service{
+				Name:    record.Name,
+				Version: record.Version,
+				Nodes:   nodes,
+			})
+		}
+	}
+
+	return owned, nil
+}
+
+// deregister removes the nodes registered by this runtime's instance of the
+// service returning them. Nodes of other services, replicas and runtimes are left.
+func (m *manager) deregister(s *runtime.Service) []*registry.Node {
+	records, err := registered(s.Name, m.instance(s))
+	if err != nil {
+		log.Logf("Failed to list services to deregister %s: %v", s.Name, err)
+		return nil
+	}
+
+	var nodes []*registry.Node
+
+	for _, record := range records {
+		if err := (*cmd.DefaultCmd.Options().Registry).Deregister(record); err != nil {
+			log.Logf("Failed to deregister %s %s: %v", record.Name, record.Version, err)
+			continue
+		}
+		nodes = append(nodes, record.Nodes...)
+	}
+
+	return nodes
+}
+
+// stop the service gracefully. It's deregistered, given time for clients to stop
+// routing to it then sent a SIGTERM. The server finishes its in-flight requests
+// before it exits so it has drained once it exits, anything still running at the
+// end of the grace period is killed by the runtime.
+func (m *manager) stop(s *runtime.Service) error {
+	grace := m.gracePeriod(s)
+	deadline := time.Now().Add(grace)
+
+	if grace > 0 {
+		// stop new requests being routed to the service
+		nodes := m.deregister(s)
+		m.publish("deregister", s, nil)
+
+		if len(nodes) > 0 {
+			log.Logf("Draining %s %s for up to %v", s.Name, s.Version, grace)
+			delay := DeregisterDelay
+			if d := time.Until(deadline); d < delay {
+				delay = d
+			}
+			time.Sleep(delay)
+		}
+
+		// ask the processes run locally to drain and exit
+		if m.local() && m.terminate(s, deadline) {
+			m.publish("drain", s, nil)
+		}
+	}
+
+	// the runtime kills anything left running
+	return m.remove(s)
+}
+
+// terminate sends SIGTERM to the processes of the service waiting until
+// the deadline for them to exit. It returns true if they exited.
+func (m *manager) terminate(s *runtime.Service, deadline time.Time) bool {
+	pids, err := process.Find(m.instance(s))
+	if err != nil {
+		log.Debugf("Failed to find the processes of %s: %v", s.Name, err)
+		return false
+	}
+	if len(pids) == 0 {
+		return false
+	}
+
+	if err := process.Terminate(pids); err != nil {
+		log.Logf("Failed to terminate %s %s: %v", s.Name, s.Version, err)
+	}
+	m.publish("terminate", s, nil)
+
+	for time.Now().Before(deadline) {
+		if pids = process.Running(pids); len(pids) == 0 {
+			return true
+		}
+		time.Sleep(DrainInterval / 10)
+	}
+
+	if pids = process.Running(pids); len(pids) > 0 {
+		log.Logf("Killing %s %s after the grace period", s.Name, s.Version)
+		m.publish("kill", s, nil)
+		return false
+	}
+
+	return true
+}
+
+// draining returns true if the service is being stopped
+func (m *manager) draining(k string) bool {
+	m.RLock()
+	defer m.RUnlock()
+	return m.drains[k]
+}
+
+// instances returns the service and its replicas
+func instances(s *runtime.Service) []*runtime.Service {
+	services := []*runtime.Service{s}
+	for i := 1; i < replicas(s); i++ {
+		services = append(services, replicaService(s, i))
+	}
+	return services
+}
+
+// stopAll stops the service and its replicas gracefully,
+// they're marked as draining by Delete
+func (m *manager) stopAll(s *runtime.Service) error {
+	instances := instances(s)
+
+	errs := make(chan error, len(instances))
+
+	for _, i := range instances {
+		go func(i *runtime.Service) {
+			errs <- m.stop(i)
+		}(i)
+	}
+
+	var err error
+	for range instances {
+		if e := <-errs; e != nil && err == nil {
+			err = e
+		}
+	}
+
+	m.Lock()
+	for _, i := range instances {
+		delete(m.drains, key(i))
+	}
+	m.Unlock()
+
+	return err
+}
+
+// pidsKey is the store key of the pids recorded by the runtimes on this host
+func pidsKey() string {
+	host, _ := os.Hostname()
+	return pidsPrefix + host
+}
+
+// recordPids records the pids of the processes run by this runtime when they
+// change so they can be reaped by the next runtime started with the same state
+func (m *manager) recordPids(services map[string][]int) {
+	pids := make(map[string][]int)
+	for instance, p := range services {
+		if strings.HasPrefix(instance, m.id+"/") {
+			pids[instance] = p
+		}
+	}
+
+	if reflect.DeepEqual(pids, m.pids) {
+		return
+	}
+
+	b, err := json.Marshal(pids)
+	if err != nil {
+		return
+	}
+	if err := m.Store.Write(&store.Record{Key: pidsKey(), Value: b}); err != nil {
+		log.Logf("Failed to record the processes of services: %v", err)
+		return
+	}
+	m.pids = pids
+}
+
+// reap terminates the processes recorded by a previous runtime which are still
+// running, they can't be managed so are replaced when the state is reconciled.
+// Those which don't exit within the grace period are killed before they are.
+func (m *manager) reap() {
+	recs, err := m.Store.Read(pidsKey())
+	if err != nil || len(recs) == 0 {
+		return
+	}
+
+	var recorded map[string][]int
+	if err := json.Unmarshal(recs[0].Value, &recorded); err != nil {
+		return
+	}
+
+	services, err := process.List()
+	if err != nil {
+		log.Debugf("Failed to list the processes of services: %v", err)
+		return
+	}
+
+	var orphans []int
+
+	for instance, pids := range recorded {
+		// the pid may have been reused by a process of another service
+		running := make(map[int]bool)
+		for _, pid := range services[instance] {
+			running[pid] = true
+		}
+
+		var reap []int
+		for _, pid := range pids {
+			if running[pid] {
+				reap = append(reap, pid)
+			}
+		}
+		if len(reap) == 0 {
+			continue
+		}
+
+		log.Logf("Terminating %s left running by a previous runtime", instance)
+		if err := process.Terminate(reap); err != nil {
+			log.Logf("Failed to terminate %s: %v", instance, err)
+		}
+		orphans = append(orphans, reap...)
+	}
+
+	deadline := time.Now().Add(m.grace)
+	for time.Now().Before(deadline) {
+		if orphans = process.Running(orphans); len(orphans) == 0 {
+			break
+		}
+		time.Sleep(DrainInterval / 10)
+	}
+
+	if orphans = process.Running(orphans); len(orphans) > 0 {
+		log.Logf("Killing %d processes left running by a previous runtime after the grace period", len(orphans))
+		if err := process.Kill(orphans); err != nil {
+			log.Logf("Failed to kill the processes left running: %v", err)
+		}
+	}
+
+	m.Store.Delete(pidsKey())
+}
@@ -0,0 +1,57 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/micro/cli/v2"
+	"github.com/micro/go-micro/v2"
+	"github.com/micro/go-micro/v2/config/cmd"
+	"github.com/micro/micro/v2/internal/namespace"
+	pb "github.com/micro/micro/v2/runtime/events/proto"
+)
+
+// streamEvents streams lifecycle events from the runtime
+func streamEvents(ctx *cli.Context, srvOpts ...micro.Option) {
+	service := ctx.String("service")
+	if ctx.Args().Len() > 0 {
+		service = ctx.Args().Get(0)
+	}
+
+	events := pb.NewEventsService(Name, *cmd.DefaultOptions().Client)
+
+	c := namespace.NewContext(context.Background(), ctx.String("token"))
+
+	stream, err := events.Stream(c, &pb.StreamRequest{
+		Service: service,
+		Type:    ctx.String("type"),
+	})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer stream.Close()
+
+	for {
+		ev, err := stream.Recv()
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+
+		line := fmt.Sprintf("%s\t%s\t%s\t%s",
+			time.Unix(ev.Timestamp, 0).Format(time.RFC3339),
+			ev.Type,
+			ev.Service,
+			ev.Version,
+		)
+		if ev.Replicas > 0 {
+			line += fmt.Sprintf("\treplicas=%d", ev.Replicas)
+		}
+		if len(ev.Error) > 0 {
+			line += "\t" + ev.Error
+		}
+		fmt.Println(line)
+	}
+}
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	pb "github.com/micro/micro/v2/runtime/events/proto"
+)
+
+// Events streams runtime lifecycle events to subscribers
+type Events struct {
+	sync.RWMutex
+	// active streams keyed by id
+	streams map[string]chan *pb.Event
+}
+
+// NewEvents returns a new events handler
+func NewEvents() *Events {
+	return &Events{
+		streams: make(map[string]chan *pb.Event),
+	}
+}
+
+// Process is subscribed to the runtime events topic
+func (e *Events) Process(ctx context.Context, ev *pb.Event) error {
+	e.RLock()
+	defer e.RUnlock()
+
+	for _, next := range e.streams {
+		select {
+		case next <- ev:
+		case <-time.After(time.Millisecond * 100):
+		}
+	}
+
+	return nil
+}
+
+func (e *Events) Stream(ctx context.Context, req *pb.StreamRequest, stream pb.Events_StreamStream) error {
+	ns, err := scope(ctx)
+	if err != nil {
+		return err
+	}
+
+	id := uuid.New().String()
+	next := make(chan *pb.Event, 32)
+
+	e.Lock()
+	e.streams[id] = next
+	e.Unlock()
+
+	defer func() {
+		e.Lock()
+		delete(e.streams, id)
+		e.Unlock()
+		stream.Close()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev := <-next:
+			if len(ns) > 0 && ev.Namespace != ns {
+				continue
+			}
+			if len(req.Service) > 0 && ev.Service != req.Service {
+				continue
+			}
+			if len(req.Type) > 0 && ev.Type != req.Type {
+				continue
+			}
+			if err := stream.Send(ev); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/micro/go-micro/v2"
@@ -9,6 +10,8 @@ import (
 	"github.com/micro/go-micro/v2/runtime"
 	pb "github.com/micro/go-micro/v2/runtime/service/proto"
 	"github.com/micro/go-micro/v2/util/log"
+	epb "github.com/micro/micro/v2/runtime/events/proto"
+	"github.com/micro/micro/v2/runtime/profile"
 )
 
 type Runtime struct {
@@ -18,6 +21,18 @@ type Runtime struct {
 	Client micro.Publisher
 }
 
+// checkProfile returns an error if the profile selected by the service doesn't exist
+func checkProfile(s *runtime.Service) error {
+	name := s.Metadata["profile"]
+	if len(name) == 0 {
+		return nil
+	}
+	if _, err := profile.Env(name); err != nil {
+		return errors.BadRequest("go.micro.runtime", "%v, expected one of %s", err, strings.Join(profile.Names(), ", "))
+	}
+	return nil
+}
+
 func (r *Runtime) Create(ctx context.Context, req *pb.CreateRequest, rsp *pb.CreateResponse) error {
 	if req.Service == nil {
 		return errors.BadRequest("go.micro.runtime", "blank service")
@@ -28,26 +43,42 @@ func (r *Runtime) Create(ctx context.Context, req *pb.CreateRequest, rsp *pb.Cre
 		options = toCreateOptions(req.Options)
 	}
 
+	ns, err := scope(ctx)
+	if err != nil {
+		return err
+	}
+
 	service := toService(req.Service)
+	setNamespace(ns, service)
+
+	if err := checkProfile(service); err != nil {
+		return err
+	}
 
 	log.Logf("Creating service %s version %s source %s", service.Name, service.Version, service.Source)
 
 	if err := r.Runtime.Create(service, options...); err != nil {
-		return errors.InternalServerError("go.micro.runtime", err.Error())
+		return runtimeError(err)
 	}
 
 	// publish the delete event
-	r.Client.Publish(ctx, &pb.Event{
+	r.Client.Publish(ctx, &epb.Event{
 		Type:      "create",
 		Timestamp: time.Now().Unix(),
 		Service:   req.Service.Name,
 		Version:   req.Service.Version,
+		Namespace: service.Metadata["namespace"],
 	})
 
 	return nil
 }
 
 func (r *Runtime) Read(ctx context.Context, req *pb.ReadRequest, rsp *pb.ReadResponse) error {
+	ns, err := scope(ctx)
+	if err != nil {
+		return err
+	}
+
 	var options []runtime.ReadOption
 
 	if req.Options != nil {
@@ -59,7 +90,7 @@ func (r *Runtime) Read(ctx context.Context, req *pb.ReadRequest, rsp *pb.ReadRes
 		return errors.InternalServerError("go.micro.runtime", err.Error())
 	}
 
-	for _, service := range services {
+	for _, service := range filter(ns, services) {
 		rsp.Services = append(rsp.Services, toProto(service))
 	}
 
@@ -74,18 +105,32 @@ func (r *Runtime) Update(ctx context.Context, req *pb.UpdateRequest, rsp *pb.Upd
 	// TODO: add opts
 	service := toService(req.Service)
 
+	ns, err := scope(ctx)
+	if err != nil {
+		return err
+	}
+	if err := r.owned(ns, service); err != nil {
+		return err
+	}
+	setNamespace(ns, service)
+
+	if err := checkProfile(service); err != nil {
+		return err
+	}
+
 	log.Logf("Updating service %s version %s source %s", service.Name, service.Version, service.Source)
 
 	if err := r.Runtime.Update(service); err != nil {
-		return errors.InternalServerError("go.micro.runtime", err.Error())
+		return runtimeError(err)
 	}
 
 	// publish the delete event
-	r.Client.Publish(ctx, &pb.Event{
+	r.Client.Publish(ctx, &epb.Event{
 		Type:      "update",
 		Timestamp: time.Now().Unix(),
 		Service:   req.Service.Name,
 		Version:   req.Service.Version,
+		Namespace: service.Metadata["namespace"],
 	})
 
 	return nil
@@ -99,30 +144,45 @@ func (r *Runtime) Delete(ctx context.Context, req *pb.DeleteRequest, rsp *pb.Del
 	// TODO: add opts
 	service := toService(req.Service)
 
+	ns, err := scope(ctx)
+	if err != nil {
+		return err
+	}
+	if err := r.owned(ns, service); err != nil {
+		return err
+	}
+	setNamespace(ns, service)
+
 	log.Logf("Deleting service %s version %s source %s", service.Name, service.Version, service.Source)
 
 	if err := r.Runtime.Delete(service); err != nil {
-		return errors.InternalServerError("go.micro.runtime", err.Error())
+		return runtimeError(err)
 	}
 
 	// publish the delete event
-	r.Client.Publish(ctx, &pb.Event{
+	r.Client.Publish(ctx, &epb.Event{
 		Type:      "delete",
 		Timestamp: time.Now().Unix(),
 		Service:   req.Service.Name,
 		Version:   req.Service.Version,
+		Namespace: service.Metadata["namespace"],
 	})
 
 	return nil
 }
 
 func (r *Runtime) List(ctx context.Context, req *pb.ListRequest, rsp *pb.ListResponse) error {
+	ns, err := scope(ctx)
+	if err != nil {
+		return err
+	}
+
 	services, err := r.Runtime.List()
 	if err != nil {
 		return errors.InternalServerError("go.micro.runtime", err.Error())
 	}
 
-	for _, service := range services {
+	for _, service := range filter(ns, services) {
 		rsp.Services = append(rsp.Services, toProto(service))
 	}
 
@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/runtime"
+	"github.com/micro/micro/v2/internal/namespace"
+)
+
+// scope returns the namespace the request is scoped to from the verified namespace
+// token of the caller, it's blank for callers which can see and manage every service
+func scope(ctx context.Context) (string, error) {
+	ns, err := namespace.FromContext(ctx)
+	if err != nil {
+		return "", errors.Forbidden("go.micro.runtime", err.Error())
+	}
+	if ns == namespace.All {
+		return "", nil
+	}
+	return ns, nil
+}
+
+// setNamespace runs the service in the namespace of the request overriding any
+// set in its metadata, only callers with access to every namespace can choose one
+func setNamespace(ns string, s *runtime.Service) {
+	if len(ns) == 0 {
+		return
+	}
+	if s.Metadata == nil {
+		s.Metadata = make(map[string]string)
+	}
+	s.Metadata["namespace"] = ns
+}
+
+// filter returns the services within the namespace
+func filter(ns string, services []*runtime.Service) []*runtime.Service {
+	if len(ns) == 0 {
+		return services
+	}
+
+	var filtered []*runtime.Service
+	for _, s := range services {
+		if s.Metadata["namespace"] == ns {
+			filtered = append(filtered, s)
+		}
+	}
+	return filtered
+}
+
+// owned returns an error unless the service exists within the namespace,
+// services in other namespaces are reported as not found
+func (r *Runtime) owned(ns string, s *runtime.Service) error {
+	if len(ns) == 0 {
+		return nil
+	}
+
+	services, err := r.Runtime.Read(runtime.ReadService(s.Name), runtime.ReadVersion(s.Version))
+	if err != nil {
+		return errors.InternalServerError("go.micro.runtime", err.Error())
+	}
+
+	if len(filter(ns, services)) == 0 {
+		return errors.NotFound("go.micro.runtime", "service %s not found", s.Name)
+	}
+
+	return nil
+}
+
+// runtimeError passes on errors already set by the runtime e.g forbidden
+func runtimeError(err error) error {
+	if merr, ok := err.(*errors.Error); ok {
+		return merr
+	}
+	return errors.InternalServerError("go.micro.runtime", err.Error())
+}
@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/micro/micro/v2/runtime/profile"
+	pb "github.com/micro/micro/v2/runtime/profile/proto"
+)
+
+// Profiles lists the profiles of the runtime and their capabilities
+type Profiles struct {
+	// Active is the profile the runtime was started with
+	Active string
+}
+
+// List the compiled in and loaded profiles
+func (p *Profiles) List(ctx context.Context, req *pb.ListRequest, rsp *pb.ListResponse) error {
+	active := p.Active
+	if len(active) == 0 {
+		active = "local"
+	}
+
+	for _, name := range profile.Names() {
+		c := profile.CapabilitiesOf(name)
+		rsp.Profiles = append(rsp.Profiles, &pb.Profile{
+			Name:    name,
+			Builtin: profile.Builtin(name),
+			Active:  name == active,
+			Capabilities: &pb.Capabilities{
+				Scale:  c.Scale,
+				Logs:   c.Logs,
+				Exec:   c.Exec,
+				Limits: c.Limits,
+			},
+		})
+	}
+
+	return nil
+}
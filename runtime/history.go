@@ -0,0 +1,290 @@
+package runtime
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/micro/cli/v2"
+	"github.com/micro/go-micro/v2"
+	"github.com/micro/go-micro/v2/runtime"
+	"github.com/micro/go-micro/v2/store"
+	"github.com/micro/micro/v2/internal/redact"
+)
+
+var (
+	// HistoryLimit is the number of revisions kept for each service
+	HistoryLimit = 10
+	// HistoryUsage message for the history command
+	HistoryUsage = "Required usage: micro history [service]"
+	// RollbackUsage message for the rollback command
+	RollbackUsage = "Required usage: micro rollback [service] [--revision 2]"
+
+	// historyPrefix is the store key prefix for the revisions
+	historyPrefix = "history/"
+)
+
+// revision is a deployment of a service. The values of sensitive env vars
+// are masked so the history never holds secrets.
+type revision struct {
+	Revision int               `json:"revision"`
+	Version  string            `json:"version"`
+	Source   string            `json:"source"`
+	Env      []string          `json:"env"`
+	Command  []string          `json:"command"`
+	Metadata map[string]string `json:"metadata"`
+	Created  int64             `json:"created"`
+}
+
+// same returns true if the revisions deploy the same thing
+func (r *revision) same(o *revision) bool {
+	a, _ := json.Marshal(&revision{Version: r.Version, Source: r.Source, Env: r.Env, Command: r.Command, Metadata: r.Metadata})
+	b, _ := json.Marshal(&revision{Version: o.Version, Source: o.Source, Env: o.Env, Command: o.Command, Metadata: o.Metadata})
+	return string(a) == string(b)
+}
+
+// service returns the revision as a runtime service for reads
+func (r *revision) service(name string) *runtime.Service {
+	md := make(map[string]string, len(r.Metadata)+4)
+	for k, v := range r.Metadata {
+		md[k] = v
+	}
+
+	env, _ := json.Marshal(r.Env)
+	command, _ := json.Marshal(r.Command)
+
+	md["revision"] = strconv.Itoa(r.Revision)
+	md["created"] = strconv.FormatInt(r.Created, 10)
+	md["env"] = string(env)
+	md["command"] = string(command)
+
+	return &runtime.Service{
+		Name:     name,
+		Version:  r.Version,
+		Source:   r.Source,
+		Metadata: md,
+	}
+}
+
+// history returns the revisions of the service oldest first
+func (m *manager) history(name string) ([]*revision, error) {
+	recs, err := m.Store.Read(historyPrefix + name)
+	if err == store.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(recs) == 0 {
+		return nil, nil
+	}
+
+	var revisions []*revision
+	if err := json.Unmarshal(recs[0].Value, &revisions); err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+// record a revision of the service unless it's the same as the last
+func (m *manager) record(s *runtime.Service, options *runtime.CreateOptions) error {
+	revisions, err := m.history(s.Name)
+	if err != nil {
+		return err
+	}
+
+	rev := &revision{
+		Version:  s.Version,
+		Source:   s.Source,
+		Metadata: withoutKeys(s.Metadata, append(statusKeys, routingKeys...)...),
+		Created:  time.Now().Unix(),
+	}
+	if options != nil {
+		rev.Env = maskEnv(options.Env)
+		rev.Command = options.Command
+	}
+
+	if n := len(revisions); n > 0 {
+		if revisions[n-1].same(rev) {
+			return nil
+		}
+		rev.Revision = revisions[n-1].Revision
+	}
+	rev.Revision++
+
+	revisions = append(revisions, rev)
+	if len(revisions) > HistoryLimit {
+		revisions = revisions[len(revisions)-HistoryLimit:]
+	}
+
+	b, err := json.Marshal(revisions)
+	if err != nil {
+		return err
+	}
+
+	return m.Store.Write(&store.Record{
+		Key:   historyPrefix + s.Name,
+		Value: b,
+	})
+}
+
+// maskEnv masks the values of the sensitive env vars, even with redact.Show
+func maskEnv(env []string) []string {
+	masked := make([]string, 0, len(env))
+	for _, kv := range env {
+		parts := strings.SplitN(kv, "=", 2)
+		if len(parts) == 2 && len(parts[1]) > 0 && redact.Sensitive(parts[0]) {
+			kv = parts[0] + "=" + redact.Mask
+		}
+		masked = append(masked, kv)
+	}
+	return masked
+}
+
+// readHistory reads the revisions of a service from the runtime
+func readHistory(r runtime.Runtime, name string) ([]*runtime.Service, error) {
+	revisions, err := r.Read(runtime.ReadService(name), runtime.ReadType("history"))
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(revisions, func(i, j int) bool {
+		a, _ := strconv.Atoi(revisions[i].Metadata["revision"])
+		b, _ := strconv.Atoi(revisions[j].Metadata["revision"])
+		return a < b
+	})
+
+	return revisions, nil
+}
+
+// historyService lists the revisions of a service
+func historyService(ctx *cli.Context, srvOpts ...micro.Option) {
+	name := ctx.Args().Get(0)
+	if len(name) == 0 {
+		fmt.Println(HistoryUsage)
+		return
+	}
+
+	revisions, err := readHistory(newRuntime(ctx), name)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	if len(revisions) == 0 {
+		fmt.Printf("No history for %s\n", name)
+		return
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
+	fmt.Fprintln(writer, "REVISION\tVERSION\tSOURCE\tCOMMIT\tCREATED\tENV")
+	for _, rev := range revisions {
+		var env []string
+		json.Unmarshal([]byte(rev.Metadata["env"]), &env)
+
+		created := "n/a"
+		if t, err := strconv.ParseInt(rev.Metadata["created"], 10, 64); err == nil && t > 0 {
+			created = time.Unix(t, 0).Format("2006-01-02 15:04:05")
+		}
+
+		commit := rev.Metadata["commit"]
+		if len(commit) == 0 {
+			commit = "n/a"
+		}
+
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			rev.Metadata["revision"],
+			rev.Version,
+			rev.Source,
+			commit,
+			created,
+			strings.Join(env, " "))
+	}
+	writer.Flush()
+}
+
+// rollbackService redeploys the prior revision of a service
+func rollbackService(ctx *cli.Context, srvOpts ...micro.Option) {
+	name := ctx.Args().Get(0)
+	if len(name) == 0 {
+		fmt.Println(RollbackUsage)
+		return
+	}
+
+	r := newRuntime(ctx)
+
+	revisions, err := readHistory(r, name)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	var target *runtime.Service
+
+	if n := ctx.Int("revision"); n > 0 {
+		for _, rev := range revisions {
+			if rev.Metadata["revision"] == strconv.Itoa(n) {
+				target = rev
+			}
+		}
+		if target == nil {
+			fmt.Printf("Revision %d of %s not found\n", n, name)
+			return
+		}
+	} else {
+		if len(revisions) < 2 {
+			fmt.Printf("No prior revision of %s to rollback to\n", name)
+			return
+		}
+		target = revisions[len(revisions)-2]
+	}
+
+	var recorded, command []string
+	json.Unmarshal([]byte(target.Metadata["env"]), &recorded)
+	json.Unmarshal([]byte(target.Metadata["command"]), &command)
+
+	// the masked secrets aren't redeployed, they have to be set again
+	var env, masked []string
+	for _, kv := range recorded {
+		if strings.HasSuffix(kv, "="+redact.Mask) {
+			masked = append(masked, strings.SplitN(kv, "=", 2)[0])
+			continue
+		}
+		env = append(env, kv)
+	}
+
+	service := &runtime.Service{
+		Name:     target.Name,
+		Version:  target.Version,
+		Source:   target.Source,
+		Metadata: withoutKeys(target.Metadata, "revision", "created", "env", "command"),
+	}
+
+	// stop the running versions before starting the revision
+	current, err := r.Read(runtime.ReadService(name))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, s := range current {
+		if err := r.Delete(s); err != nil {
+			fmt.Printf("Failed to kill %s %s: %v\n", s.Name, s.Version, err)
+			return
+		}
+	}
+
+	if err := r.Create(service, runtime.WithCommand(command...), runtime.WithEnv(env)); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Printf("Rolled back %s to revision %s version %s\n", name, target.Metadata["revision"], target.Version)
+	if len(masked) > 0 {
+		fmt.Printf("Secrets not in the history must be set again: %s\n", strings.Join(masked, ", "))
+	}
+}
@@ -0,0 +1,314 @@
+// Package logfile writes the output of services to log files rotated by size
+package logfile
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// Dir is the directory the log files are written to
+	Dir = filepath.Join(os.TempDir(), "micro", "logs")
+	// MaxSize of a log file in bytes before it's rotated
+	MaxSize int64 = 10 << 20
+	// MaxFiles is the number of rotated files kept for each log
+	MaxFiles = 5
+	// TailInterval is how often a tailed file is checked for new lines
+	TailInterval = time.Second
+)
+
+func init() {
+	if home, err := os.UserHomeDir(); err == nil {
+		Dir = filepath.Join(home, ".micro", "logs")
+	}
+	if d := os.Getenv("MICRO_LOG_DIR"); len(d) > 0 {
+		Dir = d
+	}
+}
+
+// Path returns the log file for the name e.g greeter:latest is ~/.micro/logs/greeter-latest.log
+func Path(name string) string {
+	name = strings.NewReplacer(":", "-", "#", "-", "/", "-", string(filepath.Separator), "-").Replace(name)
+	return filepath.Join(Dir, name+".log")
+}
+
+// rotated returns the path of the nth rotated file
+func rotated(path string, n int) string {
+	return fmt.Sprintf("%s.%d", path, n)
+}
+
+// Line of a log file
+type Line struct {
+	// Time the line was written, zero if it has no timestamp
+	Time time.Time
+	Text string
+}
+
+// parse the line written with the timestamp prefix
+func parse(s string) *Line {
+	i := strings.IndexByte(s, ' ')
+	if i < 0 {
+		return &Line{Text: s}
+	}
+	t, err := time.Parse(time.RFC3339, s[:i])
+	if err != nil {
+		return &Line{Text: s}
+	}
+	return &Line{Time: t, Text: s[i+1:]}
+}
+
+// File is a log file rotated once it reaches the MaxSize
+type File struct {
+	sync.Mutex
+	path string
+	file *os.File
+	size int64
+	// whether the next write starts a line
+	start bool
+}
+
+// Open the log file for appending
+func Open(path string) (*File, error) {
+	f := &File{path: path}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *File) open() error {
+	if err := os.MkdirAll(filepath.Dir(f.path), 0755); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	f.file = file
+	f.size = info.Size()
+	f.start = true
+	return nil
+}
+
+// rotate shifts the rotated files dropping the oldest then starts a new file
+func (f *File) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+
+	os.Remove(rotated(f.path, MaxFiles))
+	for i := MaxFiles - 1; i > 0; i-- {
+		os.Rename(rotated(f.path, i), rotated(f.path, i+1))
+	}
+
+	if MaxFiles > 0 {
+		if err := os.Rename(f.path, rotated(f.path, 1)); err != nil {
+			return err
+		}
+	} else if err := os.Remove(f.path); err != nil {
+		return err
+	}
+
+	return f.open()
+}
+
+// Path of the file being written
+func (f *File) Path() string {
+	return f.path
+}
+
+// Write the output prefixing each line with the time it's written
+func (f *File) Write(b []byte) (int, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	if f.file == nil {
+		return 0, os.ErrClosed
+	}
+
+	prefix := time.Now().Format(time.RFC3339) + " "
+
+	var buf bytes.Buffer
+	for rest := b; len(rest) > 0; {
+		if f.start {
+			buf.WriteString(prefix)
+		}
+		i := bytes.IndexByte(rest, '\n')
+		if i < 0 {
+			buf.Write(rest)
+			f.start = false
+			break
+		}
+		buf.Write(rest[:i+1])
+		rest = rest[i+1:]
+		f.start = true
+	}
+
+	if f.size > 0 && f.size+int64(buf.Len()) > MaxSize {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(buf.Bytes())
+	f.size += int64(n)
+	if err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// Close the file
+func (f *File) Close() error {
+	f.Lock()
+	defer f.Unlock()
+
+	if f.file == nil {
+		return nil
+	}
+
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+// Read returns up to the last n lines of the log which match including the
+// rotated files, every line matches if match is nil
+func Read(path string, n int, match func(*Line) bool) ([]*Line, error) {
+	var lines []*Line
+
+	for i := 0; i <= MaxFiles && len(lines) < n; i++ {
+		p := path
+		if i > 0 {
+			p = rotated(path, i)
+		}
+
+		b, err := ioutil.ReadFile(p)
+		if os.IsNotExist(err) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		v := strings.TrimRight(string(b), "\n")
+		if len(v) == 0 {
+			continue
+		}
+
+		var matched []*Line
+		for _, s := range strings.Split(v, "\n") {
+			if l := parse(s); match == nil || match(l) {
+				matched = append(matched, l)
+			}
+		}
+		lines = append(matched, lines...)
+	}
+
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+
+	return lines, nil
+}
+
+// Tail sends the lines written to the log from now on until the context is
+// done, the file is checked every TailInterval and reopened once it's rotated
+func Tail(ctx context.Context, path string) (<-chan *Line, error) {
+	var offset int64
+
+	// the file is tailed from the start once it's created
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		file = nil
+	} else if err != nil {
+		return nil, err
+	} else if offset, err = file.Seek(0, io.SeekEnd); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	ch := make(chan *Line, 32)
+	t := time.NewTicker(TailInterval)
+
+	go func() {
+		defer close(ch)
+		defer t.Stop()
+		defer func() {
+			if file != nil {
+				file.Close()
+			}
+		}()
+
+		// the partial line read so far
+		var partial string
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-t.C:
+			}
+
+			// start from the beginning of the new file once rotated
+			if info, err := os.Stat(path); err == nil {
+				var current os.FileInfo
+				if file != nil {
+					current, _ = file.Stat()
+				}
+				if current == nil || !os.SameFile(info, current) || info.Size() < offset {
+					if f, err := os.Open(path); err == nil {
+						if file != nil {
+							file.Close()
+						}
+						file, offset, partial = f, 0, ""
+					}
+				}
+			}
+
+			if file == nil {
+				continue
+			}
+
+			if _, err := file.Seek(offset, io.SeekStart); err != nil {
+				continue
+			}
+
+			r := bufio.NewReader(file)
+			for {
+				s, err := r.ReadString('\n')
+				offset += int64(len(s))
+				if err != nil {
+					partial += s
+					break
+				}
+
+				select {
+				case ch <- parse(strings.TrimSuffix(partial+s, "\n")):
+				case <-ctx.Done():
+					return
+				}
+				partial = ""
+			}
+		}
+	}()
+
+	return ch, nil
+}
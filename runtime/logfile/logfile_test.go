@@ -0,0 +1,142 @@
+package logfile
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPath(t *testing.T) {
+	if p := Path("greeter:latest#2"); p != filepath.Join(Dir, "greeter-latest-2.log") {
+		t.Fatalf("Unexpected path %s", p)
+	}
+}
+
+func TestRotate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logfile")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	size, files := MaxSize, MaxFiles
+	MaxSize, MaxFiles = 70, 2
+	defer func() {
+		MaxSize, MaxFiles = size, files
+	}()
+
+	path := filepath.Join(dir, "greeter.log")
+
+	f, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// each line is around 30 bytes with the timestamp so 2 fit in a file
+	for i := 0; i < 10; i++ {
+		fmt.Fprintf(f, "line %d\n", i)
+	}
+	f.Close()
+
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Fatal("Expected only 2 rotated files to be kept")
+	}
+
+	lines, err := Read(path, 100, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lines) != 6 || lines[0].Text != "line 4" || lines[5].Text != "line 9" {
+		t.Fatalf("Unexpected lines %v", lines)
+	}
+
+	lines, err = Read(path, 3, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lines) != 3 || lines[0].Text != "line 7" {
+		t.Fatalf("Unexpected lines %v", lines)
+	}
+}
+
+func TestRead(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logfile")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "greeter.log")
+
+	f, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// lines may be written in parts
+	fmt.Fprint(f, "[error] fail")
+	fmt.Fprint(f, "ed\nok\n[error] again\n")
+	f.Close()
+
+	lines, err := Read(path, 100, func(l *Line) bool {
+		return strings.HasPrefix(l.Text, "[error]")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lines) != 2 || lines[0].Text != "[error] failed" || lines[1].Text != "[error] again" {
+		t.Fatalf("Unexpected lines %v", lines)
+	}
+	if since := time.Since(lines[0].Time); since < 0 || since > time.Minute {
+		t.Fatalf("Unexpected time %v", lines[0].Time)
+	}
+}
+
+func TestTail(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logfile")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	interval, size, files := TailInterval, MaxSize, MaxFiles
+	TailInterval, MaxSize, MaxFiles = time.Millisecond*10, 40, 2
+	defer func() {
+		TailInterval, MaxSize, MaxFiles = interval, size, files
+	}()
+
+	path := filepath.Join(dir, "greeter.log")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// the file is tailed once it's created
+	ch, err := Tail(ctx, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	// the file is rotated after each line
+	for i := 0; i < 3; i++ {
+		fmt.Fprintf(f, "line %d\n", i)
+
+		select {
+		case l := <-ch:
+			if l.Text != fmt.Sprintf("line %d", i) {
+				t.Fatalf("Unexpected line %s", l.Text)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Expected line %d to be tailed", i)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package runtime
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/micro/go-micro/v2/runtime"
+	"github.com/micro/micro/v2/runtime/logfile"
+)
+
+// logFiles are the log files of the services run by the local profile
+type logFiles struct {
+	sync.Mutex
+	files map[string]*logfile.File
+}
+
+func newLogFiles() *logFiles {
+	return &logFiles{
+		files: make(map[string]*logfile.File),
+	}
+}
+
+// open the log file of the service, restarts append to the same file
+func (l *logFiles) open(s *runtime.Service) (*logfile.File, error) {
+	l.Lock()
+	defer l.Unlock()
+
+	k := key(s)
+	if f, ok := l.files[k]; ok {
+		return f, nil
+	}
+
+	f, err := logfile.Open(logfile.Path(k))
+	if err != nil {
+		return nil, err
+	}
+	l.files[k] = f
+
+	return f, nil
+}
+
+// close the log file of the service, the file is kept to be read
+func (l *logFiles) close(s *runtime.Service) {
+	l.Lock()
+	defer l.Unlock()
+
+	k := key(s)
+	if f, ok := l.files[k]; ok {
+		f.Close()
+		delete(l.files, k)
+	}
+}
+
+// local returns true if services are run as local processes
+// whose output is written to log files
+func (m *manager) local() bool {
+	return m.profileName == "" || m.profileName == "local"
+}
+
+// setLogFile adds the path of the log file to the metadata read
+func (m *manager) setLogFile(s *runtime.Service) {
+	if m.local() {
+		s.Metadata["log_file"] = logfile.Path(key(s))
+	}
+}
+
+// logPaths returns the log files of the instances of the service run locally,
+// the runtime name is matched as the last part e.g go.micro.srv.greeter
+func (m *manager) logPaths(service, version string) ([]string, error) {
+	if !m.local() {
+		return nil, nil
+	}
+
+	m.RLock()
+	defer m.RUnlock()
+
+	var paths []string
+	for _, rs := range m.services {
+		s := rs.Service
+		if s.Name != service && !strings.HasSuffix(service, "."+s.Name) {
+			continue
+		}
+		if len(version) > 0 && s.Version != version {
+			continue
+		}
+		for _, i := range instances(s) {
+			paths = append(paths, logfile.Path(key(i)))
+		}
+	}
+
+	return paths, nil
+}
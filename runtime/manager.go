@@ -1,17 +1,31 @@
 package runtime
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/micro/cli/v2"
+	"github.com/micro/go-micro/v2"
+	"github.com/micro/go-micro/v2/config/cmd"
 	"github.com/micro/go-micro/v2/runtime"
 	"github.com/micro/go-micro/v2/store"
 	"github.com/micro/go-micro/v2/util/log"
+	"github.com/micro/micro/v2/internal/identity"
+	"github.com/micro/micro/v2/internal/namespace"
+	"github.com/micro/micro/v2/runtime/artifact"
+	"github.com/micro/micro/v2/runtime/cgroup"
+	"github.com/micro/micro/v2/runtime/cron"
+	pb "github.com/micro/micro/v2/runtime/events/proto"
+	"github.com/micro/micro/v2/runtime/process"
 	mprofile "github.com/micro/micro/v2/runtime/profile"
+	"github.com/micro/micro/v2/runtime/secrets"
 )
 
 type manager struct {
@@ -26,9 +40,35 @@ type manager struct {
 	exit    chan bool
 	// used to propagate events
 	events chan *event
-
-	// a runtime profile to set for the service
-	profile []string
+	// used to publish lifecycle events
+	publisher micro.Publisher
+
+	// the name of the runtime profile e.g local, kubernetes, platform
+	profileName string
+	// how long to wait for dependencies to be ready
+	dependencyTimeout time.Duration
+	// failed health probes before a service is restarted
+	probeFailures int
+	// limits on the services run in each namespace
+	limits map[string]*limits
+	// log files of the services run locally
+	logs *logFiles
+	// how long services are given to drain and exit when killed
+	grace time.Duration
+	// services being drained keyed by name:version
+	drains map[string]bool
+	// binaries built from the sources shared by the cluster
+	artifacts *artifact.Cache
+	// identifies the nodes registered by the services this runtime runs
+	id string
+	// pids of the processes last recorded keyed by instance
+	pids map[string][]int
+	// checks of the platform dependencies of the services
+	preflights *preflights
+
+	// resource usage of the services sampled on reconcile
+	usageMtx   sync.RWMutex
+	usageStats map[string]*process.Stats
 }
 
 // stored in store
@@ -37,6 +77,24 @@ type runtimeService struct {
 	Options *runtime.CreateOptions `json:"options"`
 	Status  string                 `json:"status"`
 	Error   error                  `json:"error"`
+	// unix time the service was last started
+	Started int64 `json:"started"`
+	// number of times the service has been restarted
+	Restarts int `json:"restarts"`
+	// the last error message seen
+	LastError string `json:"last_error"`
+	// unix time the service started waiting for its dependencies
+	Queued int64 `json:"queued"`
+	// number of consecutive failed health probes
+	Failures int `json:"failures"`
+	// unix time a scheduled service last ran
+	LastRun int64 `json:"last_run"`
+	// unix time a scheduled service will next run
+	NextRun int64 `json:"next_run"`
+	// how the last scheduled run exited
+	ExitStatus string `json:"exit_status"`
+	// unix time the service was last autoscaled
+	Scaled int64 `json:"scaled"`
 }
 
 type event struct {
@@ -62,9 +120,32 @@ func copyService(s *runtimeService) *runtime.Service {
 	if s.Error != nil {
 		cp.Metadata["error"] = s.Error.Error()
 	}
+	if s.Started > 0 {
+		cp.Metadata["started"] = strconv.FormatInt(s.Started, 10)
+	}
+	cp.Metadata["restarts"] = strconv.Itoa(s.Restarts)
+	if len(s.LastError) > 0 {
+		cp.Metadata["last_error"] = s.LastError
+	}
+	if s.LastRun > 0 {
+		cp.Metadata["last_run"] = strconv.FormatInt(s.LastRun, 10)
+	}
+	if s.NextRun > 0 {
+		cp.Metadata["next_run"] = strconv.FormatInt(s.NextRun, 10)
+	}
+	if len(s.ExitStatus) > 0 {
+		cp.Metadata["exit_status"] = s.ExitStatus
+	}
 	return cp
 }
 
+// setError sets the error status on the service
+func (s *runtimeService) setError(err error) {
+	s.Status = "error"
+	s.Error = err
+	s.LastError = err.Error()
+}
+
 func key(s *runtime.Service) string {
 	return s.Name + ":" + s.Version
 }
@@ -73,6 +154,32 @@ func (m *manager) sendEvent(ev *event) {
 	m.events <- ev
 }
 
+// publish a lifecycle event for the service
+func (m *manager) publish(typ string, s *runtime.Service, err error) {
+	ev := &pb.Event{
+		Type:      typ,
+		Timestamp: time.Now().Unix(),
+		Service:   s.Name,
+		Version:   s.Version,
+		Namespace: s.Metadata[namespaceKey],
+	}
+	if err != nil {
+		ev.Error = err.Error()
+	}
+
+	m.publishEvent(ev)
+}
+
+func (m *manager) publishEvent(ev *pb.Event) {
+	if m.publisher == nil {
+		return
+	}
+
+	if err := m.publisher.Publish(context.Background(), ev); err != nil {
+		log.Logf("Failed to publish %s event for %s: %v", ev.Type, ev.Service, err)
+	}
+}
+
 func (m *manager) Init(opts ...runtime.Option) error {
 	return nil
 }
@@ -94,15 +201,32 @@ func (m *manager) Create(s *runtime.Service, opts ...runtime.CreateOption) error
 	// create service key
 	k := key(s)
 
+	if err := m.checkNamespace(s); err != nil {
+		return err
+	}
+
 	rs := &runtimeService{
 		Service: s,
 		Options: &options,
-		Status:  "started",
+		Status:  "starting",
+		Started: time.Now().Unix(),
+		Queued:  time.Now().Unix(),
+	}
+
+	// scheduled services wait for their next run
+	if len(s.Metadata["schedule"]) > 0 {
+		rs.Status = "scheduled"
+		rs.Started = 0
 	}
 
 	// save locally
 	m.services[k] = rs
 
+	// keep the deployment history for rollbacks
+	if err := m.record(s, &options); err != nil {
+		log.Logf("Failed to record the history of %s: %v", s.Name, err)
+	}
+
 	// send event
 	go m.sendEvent(&event{
 		Type:    "create",
@@ -129,8 +253,29 @@ func (m *manager) Read(opts ...runtime.ReadOption) ([]*runtime.Service, error) {
 		o(&options)
 	}
 
+	// the deployment history of a service
+	if options.Type == "history" {
+		if len(options.Service) == 0 {
+			return nil, errors.New("service required to read history")
+		}
+
+		revisions, err := m.history(options.Service)
+		if err != nil {
+			return nil, err
+		}
+
+		var services []*runtime.Service
+		for _, rev := range revisions {
+			services = append(services, rev.service(options.Service))
+		}
+		return services, nil
+	}
+
 	var services []*runtime.Service
 
+	// read from /proc before locking
+	usage := m.usage()
+
 	m.RLock()
 	defer m.RUnlock()
 
@@ -146,7 +291,10 @@ func (m *manager) Read(opts ...runtime.ReadOption) ([]*runtime.Service, error) {
 			continue
 		}
 
-		services = append(services, copyService(rs))
+		cp := copyService(rs)
+		m.setLogFile(cp)
+		setUsage(cp, usage)
+		services = append(services, cp)
 	}
 
 	return services, nil
@@ -174,6 +322,16 @@ func (m *manager) Update(s *runtime.Service) error {
 	if err := json.Unmarshal(r[0].Value, &rs); err != nil {
 		return err
 	}
+
+	// changing the traffic weight does not need a restart
+	restart := rs.Service == nil || !routingOnly(rs.Service, s)
+
+	if restart {
+		if err := m.checkNamespace(s); err != nil {
+			return err
+		}
+	}
+
 	// set the service
 	rs.Service = s
 	// TODO: allow setting opts
@@ -182,19 +340,32 @@ func (m *manager) Update(s *runtime.Service) error {
 	evType := "update"
 
 	// check if it exists
-	if _, ok := m.services[k]; !ok {
+	if v, ok := m.services[k]; !ok {
 		// set starting status
-		rs.Status = "started"
+		rs.Status = "starting"
+		rs.Started = time.Now().Unix()
+		rs.Queued = time.Now().Unix()
 		evType = "create"
 		m.services[k] = &rs
+	} else if !restart {
+		evType = ""
+		v.Service = s
+	}
+
+	if restart {
+		if err := m.record(s, rs.Options); err != nil {
+			log.Logf("Failed to record the history of %s: %v", s.Name, err)
+		}
 	}
 
 	// fire an update
-	go m.sendEvent(&event{
-		Type:    evType,
-		Service: rs.Service,
-		Options: rs.Options,
-	})
+	if len(evType) > 0 {
+		go m.sendEvent(&event{
+			Type:    evType,
+			Service: rs.Service,
+			Options: rs.Options,
+		})
+	}
 
 	// marshall the content
 	b, err := json.Marshal(rs)
@@ -224,6 +395,19 @@ func (m *manager) Delete(s *runtime.Service) error {
 	// set status
 	v.Status = "stopped"
 
+	// override the grace period for this kill
+	if g := s.Metadata["grace"]; len(g) > 0 {
+		if v.Service.Metadata == nil {
+			v.Service.Metadata = make(map[string]string)
+		}
+		v.Service.Metadata["grace"] = g
+	}
+
+	// stop the run loop killing it while it drains
+	for _, i := range instances(v.Service) {
+		m.drains[key(i)] = true
+	}
+
 	// send event
 	go m.sendEvent(&event{
 		Type:    "delete",
@@ -235,19 +419,24 @@ func (m *manager) Delete(s *runtime.Service) error {
 }
 
 func (m *manager) List() ([]*runtime.Service, error) {
+	usage := m.usage()
+
 	m.RLock()
 	defer m.RUnlock()
 
 	services := make([]*runtime.Service, 0, len(m.services))
 
 	for _, service := range m.services {
-		services = append(services, copyService(service))
+		cp := copyService(service)
+		m.setLogFile(cp)
+		setUsage(cp, usage)
+		services = append(services, cp)
 	}
 
 	return services, nil
 }
 
-func (m *manager) runtimeEnv(options *runtime.CreateOptions) []string {
+func (m *manager) runtimeEnv(s *runtime.Service, options *runtime.CreateOptions) []string {
 	setEnv := func(p []string, env map[string]string) {
 		for _, v := range p {
 			parts := strings.Split(v, "=")
@@ -264,8 +453,25 @@ func (m *manager) runtimeEnv(options *runtime.CreateOptions) []string {
 	// set the env vars provided
 	setEnv(options.Env, env)
 
-	// override with vars from the profile
-	setEnv(m.profile, env)
+	// override with vars from the profile, the service may select
+	// a different profile to the one the runtime was started with
+	profile, _ := mprofile.Env(m.profileName)
+	if name := s.Metadata["profile"]; len(name) > 0 {
+		p, err := mprofile.Env(name)
+		if err != nil {
+			log.Logf("Failed to set the profile of %s: %v", s.Name, err)
+		} else {
+			profile = p
+		}
+	}
+	setEnv(profile, env)
+
+	// tag the nodes the service registers so only they're deregistered when it's stopped
+	md := InstanceKey + "=" + m.instance(s)
+	if v := env["MICRO_SERVER_METADATA"]; len(v) > 0 {
+		md = v + "," + md
+	}
+	env["MICRO_SERVER_METADATA"] = md
 
 	// create a new env
 	var vars []string
@@ -277,123 +483,603 @@ func (m *manager) runtimeEnv(options *runtime.CreateOptions) []string {
 	return vars
 }
 
-// TODO: watch events rather than poll
-func (m *manager) run() {
-	//
-	t := time.NewTicker(eventTick)
-	defer t.Stop()
+// createOptions generates the runtime create options for a service
+func (m *manager) createOptions(s *runtime.Service, options *runtime.CreateOptions) ([]runtime.CreateOption, error) {
+	// generate the runtime environment
+	env := m.runtimeEnv(s, options)
+	command := options.Command
+
+	// inject secrets at start time so they're never persisted
+	if specs := secrets.Split(s.Metadata["secrets"]); len(specs) > 0 {
+		vars, err := secrets.Inject(key(s), specs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to inject secrets: %v", err)
+		}
+		env = append(env, vars...)
+	}
 
-	for {
-		select {
-		case <-t.C:
-			// list the keys from store
-			records, err := m.Store.List()
+	// inject an identity the service can exchange for a token
+	if len(identity.Key) > 0 {
+		doc, err := m.identity(s)
+		if err != nil {
+			return nil, fmt.Errorf("failed to mint identity: %v", err)
+		}
+		env = append(env, identity.Env+"="+doc)
+	}
+
+	// run the binary built once for the cluster rather than on every node
+	if m.artifacts != nil && isGoRun(command, s.Source) && artifact.Cacheable(s.Source) {
+		command = m.cachedCommand(s.Source, command)
+	}
+
+	// prebuilt sources bypass the build
+	if prebuilt(s.Source) {
+		cmd, err := prebuiltCommand(s.Name, s.Version, s.Source, env, resourceLimits(s.Metadata))
+		if err != nil {
+			return nil, err
+		}
+		command = cmd
+	}
+
+	// apply any resource limits
+	if limits := resourceLimits(s.Metadata); !limits.Empty() {
+		switch m.profileName {
+		case "kubernetes", "platform":
+			// translate to resource requests and limits
+			for k, v := range mprofile.KubernetesResources(limits.CPU, limits.Memory) {
+				s.Metadata[k] = v
+			}
+		default:
+			// images are limited by docker
+			if docker(s.Source) {
+				break
+			}
+			// enforce the limits locally using cgroups
+			cmd, err := cgroup.Command(key(s), limits, command)
 			if err != nil {
-				log.Logf("Failed to list records from store: %v", err)
-				continue
+				log.Logf("Failed to apply resource limits to %s: %v", s.Name, err)
+			} else {
+				command = cmd
 			}
+		}
+	}
 
-			// list whats already runnning
-			services, err := m.Runtime.List()
-			if err != nil {
-				log.Logf("Failed to list runtime services: %v", err)
-				continue
+	// mark the processes so they can be signalled when killed
+	if m.local() {
+		env = append(env, process.Env+"="+m.instance(s))
+	}
+
+	opts := []runtime.CreateOption{
+		runtime.WithCommand(command...),
+		runtime.WithEnv(env),
+		runtime.CreateType(options.Type),
+	}
+
+	// write the output to a log file rotated by size
+	if m.local() {
+		f, err := m.logs.open(s)
+		if err != nil {
+			log.Logf("Failed to open the log file of %s: %v", s.Name, err)
+		} else {
+			opts = append(opts, runtime.WithOutput(f))
+		}
+	}
+
+	return opts, nil
+}
+
+// identity mints the identity document for the latest revision of the service
+func (m *manager) identity(s *runtime.Service) (string, error) {
+	// the namespace is set by the runtime handler from the verified token of the caller
+	ns := s.Metadata[namespaceKey]
+	if ns == namespace.All {
+		return "", fmt.Errorf("service %s can't run in every namespace", s.Name)
+	}
+
+	doc := &identity.Document{
+		Service:   s.Name,
+		Version:   s.Version,
+		Namespace: ns,
+	}
+
+	revisions, err := m.history(s.Name)
+	if err != nil {
+		return "", err
+	}
+	if n := len(revisions); n > 0 {
+		doc.Revision = revisions[n-1].Revision
+	}
+
+	return identity.Mint(doc)
+}
+
+// remove the service from the runtime, the container of an image is stopped
+// first as killing the docker cli which started it would leave it running
+func (m *manager) remove(s *runtime.Service) error {
+	if docker(m.source(s)) {
+		if err := stopContainer(s.Name, s.Version); err != nil {
+			log.Logf("Error stopping the container of %s: %v", s.Name, err)
+		}
+	}
+	return m.Runtime.Delete(s)
+}
+
+// source returns the source of the service, services deleted by name
+// and version are looked up in those being run
+func (m *manager) source(s *runtime.Service) string {
+	if len(s.Source) > 0 {
+		return s.Source
+	}
+
+	m.RLock()
+	defer m.RUnlock()
+
+	if rs, ok := m.services[key(s)]; ok {
+		return rs.Service.Source
+	}
+	return ""
+}
+
+// refresh restarts the service with a new identity document before its document
+// expires, it returns true if the service was restarted
+func (m *manager) refresh(rs *runtimeService) bool {
+	if len(identity.Key) == 0 || rs.Started == 0 {
+		return false
+	}
+	if time.Since(time.Unix(rs.Started, 0)) < identity.TTL-identity.Refresh {
+		return false
+	}
+
+	log.Logf("Restarting %s %s with a new identity document", rs.Service.Name, rs.Service.Version)
+
+	if err := m.remove(rs.Service); err != nil {
+		log.Logf("Error stopping %s: %v", rs.Service.Name, err)
+	}
+
+	rs.Status = "starting"
+	rs.Started = time.Now().Unix()
+
+	opts, err := m.createOptions(rs.Service, rs.Options)
+	if err != nil {
+		rs.setError(err)
+		return true
+	}
+
+	if err := m.Runtime.Create(rs.Service, opts...); err != nil {
+		rs.setError(err)
+		return true
+	}
+
+	go m.publish("restart", rs.Service, nil)
+	return true
+}
+
+// probe the health of running services restarting any which fail too many probes
+func (m *manager) probe(services []*runtimeService) {
+	var wg sync.WaitGroup
+
+	for _, rs := range services {
+		wg.Add(1)
+
+		go func(rs *runtimeService) {
+			defer wg.Done()
+
+			probed, err := probe(rs.Service, m.instance(rs.Service))
+			if !probed {
+				return
+			}
+
+			if err == nil {
+				rs.Failures = 0
+				return
 			}
 
-			// generate service map of running things
-			running := make(map[string]*runtime.Service)
+			rs.Failures++
+			log.Logf("Service %s failed health probe %d/%d: %v", rs.Service.Name, rs.Failures, m.probeFailures, err)
 
-			for _, service := range services {
-				k := key(service)
-				running[k] = service
+			if rs.Failures < m.probeFailures {
+				return
 			}
 
-			// create a map of services that should actually run
-			shouldRun := make(map[string]*runtimeService)
+			go m.publish("unhealthy", rs.Service, err)
 
-			// iterate through and see what we need to run
-			for _, record := range records {
-				// decode the record
-				var rs *runtimeService
-				if err := json.Unmarshal(record.Value, &rs); err != nil {
-					continue
-				}
+			log.Logf("Restarting unhealthy service %s %s", rs.Service.Name, rs.Service.Version)
 
-				// things to run
-				shouldRun[record.Key] = rs
+			rs.Failures = 0
+			rs.Restarts++
+			rs.LastError = fmt.Sprintf("failed %d health probes: %v", m.probeFailures, err)
 
-				// check if its already running
-				if v, ok := running[record.Key]; ok {
-					// TODO: have actual runtime status
-					rs.Status = v.Metadata["status"]
-					if e := v.Metadata["error"]; len(e) > 0 {
-						rs.Error = errors.New(e)
-					}
-					continue
-				}
+			if err := m.remove(rs.Service); err != nil {
+				log.Logf("Error stopping %s: %v", rs.Service.Name, err)
+			}
 
-				// generate the runtime environment
-				env := m.runtimeEnv(rs.Options)
+			rs.Status = "starting"
+			rs.Started = time.Now().Unix()
 
-				// create a new set of options to use
-				opts := []runtime.CreateOption{
-					runtime.WithCommand(rs.Options.Command...),
-					runtime.WithEnv(env),
-					runtime.CreateType(rs.Options.Type),
-				}
+			opts, err := m.createOptions(rs.Service, rs.Options)
+			if err != nil {
+				rs.setError(err)
+				return
+			}
+
+			if err := m.Runtime.Create(rs.Service, opts...); err != nil {
+				rs.setError(err)
+				return
+			}
 
-				log.Logf("Creating service %s version %s source %s", rs.Service.Name, rs.Service.Version, rs.Service.Source)
+			go m.publish("restart", rs.Service, nil)
+		}(rs)
+	}
 
-				// set the status to starting
-				rs.Status = "started"
+	wg.Wait()
+}
 
-				// service does not exist so start it
-				if err := m.Runtime.Create(rs.Service, opts...); err != nil {
-					if err != runtime.ErrAlreadyExists {
-						log.Logf("Erroring running %s: %v", rs.Service.Name, err)
+// schedule runs a scheduled service when it's due, tracking the last
+// and next run along with how the last run exited
+func (m *manager) schedule(rs *runtimeService, prev *runtimeService, current *runtime.Service) {
+	sched, err := cron.Parse(rs.Service.Metadata["schedule"])
+	if err != nil {
+		rs.setError(err)
+		return
+	}
 
-						// save the error
-						rs.Status = "error"
-						rs.Error = err
-					}
-				}
+	if prev != nil {
+		rs.LastRun = prev.LastRun
+		rs.NextRun = prev.NextRun
+		rs.ExitStatus = prev.ExitStatus
+		rs.Status = prev.Status
+	}
+
+	// the last run is still in progress
+	if current != nil {
+		rs.Status = "running"
+		if e := current.Metadata["error"]; len(e) > 0 {
+			rs.ExitStatus = e
+		}
+		return
+	}
+
+	// the last run has exited
+	if rs.LastRun > 0 && (rs.Status == "running" || rs.Status == "starting") {
+		if len(rs.ExitStatus) == 0 {
+			rs.ExitStatus = "ok"
+		} else {
+			rs.LastError = rs.ExitStatus
+		}
+		go m.publish("exit", rs.Service, nil)
+	}
+
+	now := time.Now()
+
+	if rs.NextRun == 0 {
+		rs.NextRun = sched.Next(now).Unix()
+	}
+
+	// never runs e.g 30th of february
+	if rs.NextRun <= 0 {
+		rs.setError(errors.New("schedule never runs"))
+		return
+	}
+
+	if now.Unix() < rs.NextRun {
+		if rs.Status != "error" {
+			rs.Status = "scheduled"
+		}
+		return
+	}
+
+	opts, err := m.createOptions(rs.Service, rs.Options)
+	if err != nil {
+		rs.setError(err)
+		rs.ExitStatus = err.Error()
+		rs.NextRun = sched.Next(now).Unix()
+		return
+	}
+
+	log.Logf("Running scheduled service %s version %s", rs.Service.Name, rs.Service.Version)
+
+	rs.Status = "starting"
+	rs.Started = now.Unix()
+	rs.LastRun = now.Unix()
+	rs.NextRun = sched.Next(now).Unix()
+	rs.ExitStatus = ""
+
+	// clear out the last run if the runtime still has it
+	m.remove(rs.Service)
+
+	if err := m.Runtime.Create(rs.Service, opts...); err != nil {
+		log.Logf("Erroring running %s: %v", rs.Service.Name, err)
+		rs.setError(err)
+		rs.ExitStatus = err.Error()
+		return
+	}
+
+	go m.publish("run", rs.Service, nil)
+}
+
+// TODO: watch events rather than poll
+// reconcile the services running against the desired state in the store,
+// starting those which should be running and stopping those which shouldn't
+func (m *manager) reconcile() {
+	// list the keys from store
+	records, err := m.Store.List()
+	if err != nil {
+		log.Logf("Failed to list records from store: %v", err)
+		return
+	}
+
+	// list whats already runnning
+	services, err := m.Runtime.List()
+	if err != nil {
+		log.Logf("Failed to list runtime services: %v", err)
+		return
+	}
+
+	// generate service map of running things
+	running := make(map[string]*runtime.Service)
+
+	for _, service := range services {
+		k := key(service)
+		running[k] = service
+	}
+
+	// create a map of services that should actually run
+	shouldRun := make(map[string]*runtimeService)
+
+	// running services to health check
+	var probes []*runtimeService
+
+	// replicas of the running services
+	keep := make(map[string]bool)
+
+	// check the platform dependencies of the services to start together
+	for _, record := range records {
+		if _, ok := running[record.Key]; ok || strings.HasPrefix(record.Key, historyPrefix) || strings.HasPrefix(record.Key, pidsPrefix) {
+			continue
+		}
+		var rs *runtimeService
+		if err := json.Unmarshal(record.Value, &rs); err != nil || rs.Service == nil {
+			continue
+		}
+		m.preflights.prewarm(rs.Service.Metadata, m.runtimeEnv(rs.Service, rs.Options))
+	}
+
+	// iterate through and see what we need to run
+	for _, record := range records {
+		// skip the deployment history
+		if strings.HasPrefix(record.Key, historyPrefix) {
+			continue
+		}
+
+		// skip the pids recorded for the next runtime
+		if strings.HasPrefix(record.Key, pidsPrefix) {
+			continue
+		}
+
+		// decode the record
+		var rs *runtimeService
+		if err := json.Unmarshal(record.Value, &rs); err != nil {
+			continue
+		}
+
+		// things to run
+		shouldRun[record.Key] = rs
+
+		// carry over the status we've been tracking
+		m.RLock()
+		prev, seen := m.services[record.Key]
+		m.RUnlock()
+		if seen {
+			rs.Started = prev.Started
+			rs.Restarts = prev.Restarts
+			rs.LastError = prev.LastError
+			rs.Queued = prev.Queued
+			rs.Failures = prev.Failures
+			rs.Scaled = prev.Scaled
+		}
+
+		// scheduled services run when due rather than continuously
+		if len(rs.Service.Metadata["schedule"]) > 0 {
+			m.schedule(rs, prev, running[record.Key])
+			continue
+		}
+
+		// check if its already running
+		if v, ok := running[record.Key]; ok {
+			// TODO: have actual runtime status
+			rs.Status = v.Metadata["status"]
+			if len(rs.Status) == 0 {
+				rs.Status = "running"
 			}
+			if e := v.Metadata["error"]; len(e) > 0 {
+				rs.setError(errors.New(e))
+			}
+			m.replicate(rs, running, keep)
+			// services are restarted before their identity expires rather than probed
+			if !m.refresh(rs) {
+				probes = append(probes, rs)
+			}
+			continue
+		}
 
-			// check what we need to stop from the running list
-			for _, service := range services {
-				k := key(service)
+		// it was previously running so it has crashed
+		if seen && prev.Status == "running" {
+			rs.Restarts++
 
-				// check if it should be running
-				if _, ok := shouldRun[k]; ok {
-					continue
-				}
+			crashErr := errors.New("service not running")
+			if len(prev.LastError) > 0 {
+				crashErr = errors.New(prev.LastError)
+			}
+			go m.publish("crash", rs.Service, crashErr)
+
+			// restart the dependency timeout
+			rs.Queued = 0
+		}
 
-				log.Logf("Stopping %s", k)
+		// wait for the dependencies to be ready
+		if dep, err := waitingOn(rs.Service.Metadata); err != nil {
+			if rs.Queued == 0 {
+				rs.Queued = time.Now().Unix()
+			}
 
-				// should not be running
-				m.Runtime.Delete(service)
+			if time.Since(time.Unix(rs.Queued, 0)) > m.dependencyTimeout {
+				rs.setError(fmt.Errorf("dependency %s not ready after %v: %v", dep, m.dependencyTimeout, err))
+			} else {
+				rs.Status = "waiting"
 			}
+			continue
+		}
+
+		// fail fast rather than crash loop on missing infrastructure
+		if err := m.preflights.preflight(rs.Service.Metadata, m.runtimeEnv(rs.Service, rs.Options)); err != nil {
+			rs.setError(err)
+			continue
+		}
+
+		// create a new set of options to use
+		opts, err := m.createOptions(rs.Service, rs.Options)
+		if err != nil {
+			log.Logf("Erroring running %s: %v", rs.Service.Name, err)
+			rs.setError(err)
+			continue
+		}
 
-			// save the current list of running things
-			m.services = shouldRun
+		log.Logf("Creating service %s version %s source %s", rs.Service.Name, rs.Service.Version, rs.Service.Source)
+
+		// set the status to starting
+		rs.Status = "starting"
+		rs.Started = time.Now().Unix()
+
+		// service does not exist so start it
+		if err := m.Runtime.Create(rs.Service, opts...); err != nil {
+			if err != runtime.ErrAlreadyExists {
+				log.Logf("Erroring running %s: %v", rs.Service.Name, err)
+
+				// save the error
+				rs.setError(err)
+			}
+		}
+	}
+
+	// restart any unhealthy services
+	m.probe(probes)
+
+	// check what we need to stop from the running list
+	for _, service := range services {
+		k := key(service)
+
+		// check if it should be running
+		if _, ok := shouldRun[k]; ok || keep[k] {
+			continue
+		}
+
+		// it's being stopped gracefully
+		if m.draining(k) {
+			continue
+		}
+
+		log.Logf("Stopping %s", k)
+
+		// should not be running
+		m.remove(service)
+	}
+
+	// save the current list of running things, the killed services
+	// are listed as stopped by Delete until they've drained
+	m.Lock()
+	for k, v := range m.services {
+		if _, ok := shouldRun[k]; !ok && m.drains[k] {
+			shouldRun[k] = v
+		}
+	}
+	m.services = shouldRun
+	m.Unlock()
+
+	if !m.local() {
+		return
+	}
+
+	// the processes are listed once for the usage and the pids
+	procs, err := process.List()
+	if err != nil {
+		log.Debugf("Failed to list the processes of services: %v", err)
+		return
+	}
+
+	m.sampleUsage(procs)
+
+	// record the processes so they're reaped if the runtime is restarted
+	m.recordPids(procs)
+}
+
+func (m *manager) run() {
+	//
+	t := time.NewTicker(eventTick)
+	defer t.Stop()
+
+	// relaunch the services which should be running straight away
+	m.reconcile()
+
+	for {
+		select {
+		case <-t.C:
+			m.reconcile()
 		case ev := <-m.events:
 			var err error
 
 			switch ev.Type {
 			case "delete":
 				log.Logf("Deleting %s %s", ev.Service.Name, ev.Service.Version)
-				err = m.Runtime.Delete(ev.Service)
+				// drain in the background so other events aren't blocked
+				go func(s *runtime.Service) {
+					if err := m.stopAll(s); err != nil {
+						log.Logf("Erroring deleting %s: %v", s.Name, err)
+					}
+					// clean up the resource limits of the stopped instances
+					for _, i := range instances(s) {
+						if err := cgroup.Delete(key(i)); err != nil {
+							log.Logf("Failed to remove the resource limits of %s: %v", key(i), err)
+						}
+					}
+					// and close the log file
+					m.logs.close(s)
+					// and any secret files
+					secrets.Remove(key(s))
+				}(ev.Service)
 			case "update":
 				log.Logf("Updating %s %s", ev.Service.Name, ev.Service.Version)
 				err = m.Runtime.Update(ev.Service)
+				for i := 1; i < replicas(ev.Service); i++ {
+					m.Runtime.Update(replicaService(ev.Service, i))
+				}
 			case "create":
-				// generate the runtime environment
-				env := m.runtimeEnv(ev.Options)
+				// leave it to the run loop to start on schedule
+				if len(ev.Service.Metadata["schedule"]) > 0 {
+					log.Logf("Scheduled %s %s to run %s", ev.Service.Name, ev.Service.Version, ev.Service.Metadata["schedule"])
+					continue
+				}
 
-				opts := []runtime.CreateOption{
-					runtime.WithCommand(ev.Options.Command...),
-					runtime.WithEnv(env),
-					runtime.CreateType(ev.Options.Type),
+				// leave it to the run loop to start once dependencies are ready
+				if dep, derr := waitingOn(ev.Service.Metadata); derr != nil {
+					log.Logf("Service %s waiting for dependency %s: %v", ev.Service.Name, dep, derr)
+
+					m.Lock()
+					if v, ok := m.services[key(ev.Service)]; ok {
+						v.Status = "waiting"
+					}
+					m.Unlock()
+					continue
+				}
+
+				// fail fast rather than crash loop on missing infrastructure
+				if err = m.preflights.preflight(ev.Service.Metadata, m.runtimeEnv(ev.Service, ev.Options)); err != nil {
+					break
+				}
+
+				var opts []runtime.CreateOption
+				opts, err = m.createOptions(ev.Service, ev.Options)
+				if err != nil {
+					break
 				}
 
 				log.Logf("Creating %s %s", ev.Service.Name, ev.Service.Version)
@@ -408,8 +1094,7 @@ func (m *manager) run() {
 				m.Lock()
 				v, ok := m.services[key(ev.Service)]
 				if ok {
-					v.Status = "error"
-					v.Error = err
+					v.setError(err)
 				}
 				m.Unlock()
 			}
@@ -435,9 +1120,20 @@ func (m *manager) Start() error {
 		return err
 	}
 
+	// stop the processes left by a previous runtime so they're relaunched
+	if m.local() {
+		m.reap()
+	}
+
 	// start the internal manager
 	go m.run()
 
+	// read the profiles defined in config as they change
+	go mprofile.Watch(*cmd.DefaultOptions().Client, m.exit)
+
+	// scale the services with an autoscale policy
+	go m.autoscale()
+
 	// set to running
 	m.running = true
 
@@ -464,20 +1160,67 @@ func (m *manager) Stop() error {
 	return nil
 }
 
-func newManager(ctx *cli.Context, r runtime.Runtime, s store.Store) *manager {
-	var profile []string
-	// peel out the env
-	switch ctx.String("profile") {
-	case "platform":
-		profile = mprofile.Platform()
+func newManager(ctx *cli.Context, r runtime.Runtime, s, artifacts store.Store) *manager {
+	path := mprofile.Path
+	if p := ctx.String("profiles"); len(p) > 0 {
+		path = p
+	}
+	if err := mprofile.Load(path); err != nil {
+		log.Fatal(err)
+	}
+
+	// profiles defined in the config service override those in the file
+	if err := mprofile.LoadConfig(*cmd.DefaultOptions().Client); err != nil {
+		log.Logf("Failed to read the profiles from %s: %v", mprofile.ConfigName, err)
+	}
+
+	// an unknown profile is ignored until it's defined in config
+	if name := ctx.String("profile"); len(name) > 0 {
+		if _, err := mprofile.Env(name); err != nil {
+			log.Logf("Ignoring the profile: %v", err)
+		}
+	}
+
+	timeout := DependencyTimeout
+	if t := ctx.Duration("dependency_timeout"); t > 0 {
+		timeout = t
+	}
+
+	failures := ProbeFailures
+	if f := ctx.Int("probe_failures"); f > 0 {
+		failures = f
 	}
 
-	return &manager{
-		Runtime:  r,
-		Store:    s,
-		profile:  profile,
-		services: make(map[string]*runtimeService),
-		exit:     make(chan bool),
-		events:   make(chan *event, 8),
+	grace := GracePeriod
+	if ctx.IsSet("grace_period") {
+		grace = ctx.Duration("grace_period")
 	}
+
+	limits, err := parseLimits(ctx.String("namespace_limits"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	m := &manager{
+		Runtime:           r,
+		Store:             s,
+		profileName:       ctx.String("profile"),
+		dependencyTimeout: timeout,
+		probeFailures:     failures,
+		limits:            limits,
+		logs:              newLogFiles(),
+		grace:             grace,
+		drains:            make(map[string]bool),
+		services:          make(map[string]*runtimeService),
+		exit:              make(chan bool),
+		events:            make(chan *event, 8),
+		id:                uuid.New().String(),
+		preflights:        newPreflights(),
+	}
+
+	if ctx.Bool("artifact_cache") {
+		m.artifacts = artifact.New(artifacts)
+	}
+
+	return m
 }
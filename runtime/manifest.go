@@ -0,0 +1,211 @@
+package runtime
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/micro/go-micro/v2/config/encoder/yaml"
+	"github.com/micro/go-micro/v2/runtime"
+	"github.com/micro/micro/v2/runtime/cron"
+)
+
+// Manifest describes a set of services to run
+type Manifest struct {
+	// Name of the manifest, defaults to the name of the directory
+	Name string `json:"name"`
+	// Services to run
+	Services []*ManifestService `json:"services"`
+}
+
+// ManifestService is a service declared in a manifest
+type ManifestService struct {
+	Name     string   `json:"name"`
+	Version  string   `json:"version"`
+	Source   string   `json:"source"`
+	Env      []string `json:"env"`
+	Replicas int      `json:"replicas"`
+	// Dependencies are the registered names of services which
+	// must be healthy before this service is started
+	Dependencies []string `json:"dependencies"`
+	// Requires are the platform dependencies which must
+	// be reachable before starting e.g store, config, broker
+	Requires []string `json:"requires,omitempty"`
+	// Probe is the health probe e.g tcp://localhost:8080
+	Probe string `json:"probe"`
+	// Schedule is a cron expression to run the service on
+	Schedule string `json:"schedule"`
+	// Profile selects the defaults injected into the service e.g platform
+	Profile string `json:"profile,omitempty"`
+}
+
+// readManifest reads and validates the manifest file
+func readManifest(path string) (*Manifest, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var m *Manifest
+	if err := yaml.NewEncoder().Decode(b, &m); err != nil {
+		return nil, fmt.Errorf("invalid manifest %s: %v", path, err)
+	}
+
+	if m == nil || len(m.Services) == 0 {
+		return nil, errors.New("manifest has no services")
+	}
+
+	if len(m.Name) == 0 {
+		abs, _ := filepath.Abs(path)
+		m.Name = filepath.Base(filepath.Dir(abs))
+	}
+
+	seen := make(map[string]bool)
+
+	for _, s := range m.Services {
+		if len(s.Name) == 0 {
+			return nil, errors.New("manifest service requires a name")
+		}
+		if len(s.Source) == 0 {
+			return nil, fmt.Errorf("manifest service %s requires a source", s.Name)
+		}
+		if len(s.Version) == 0 {
+			s.Version = "latest"
+		}
+		if s.Replicas <= 0 {
+			s.Replicas = 1
+		}
+		if len(s.Schedule) > 0 {
+			if _, err := cron.Parse(s.Schedule); err != nil {
+				return nil, fmt.Errorf("manifest service %s: %v", s.Name, err)
+			}
+		}
+		if err := validateRequires(s.Requires); err != nil {
+			return nil, fmt.Errorf("manifest service %s: %v", s.Name, err)
+		}
+		if seen[s.Name] {
+			return nil, fmt.Errorf("manifest service %s is declared twice", s.Name)
+		}
+		seen[s.Name] = true
+	}
+
+	for _, s := range m.Services {
+		for _, dep := range s.Dependencies {
+			if !declared(seen, dep) {
+				return nil, fmt.Errorf("manifest service %s depends on unknown service %s", s.Name, dep)
+			}
+		}
+	}
+
+	return m, nil
+}
+
+// declared returns true if the dependency is a service of the manifest, it's
+// either the name of the service or the name it registers e.g go.micro.srv.greeter
+func declared(services map[string]bool, dep string) bool {
+	if services[dep] {
+		return true
+	}
+	for name := range services {
+		if strings.HasSuffix(dep, "."+name) {
+			return true
+		}
+	}
+	return false
+}
+
+// checksum of the service declaration used to detect changes
+func (s *ManifestService) checksum() string {
+	b, _ := json.Marshal(s)
+	return fmt.Sprintf("%x", sha256.Sum256(b))[:12]
+}
+
+// service returns the runtime service for the declaration
+func (s *ManifestService) service(manifest string) *runtime.Service {
+	return &runtime.Service{
+		Name:    s.Name,
+		Version: s.Version,
+		Source:  s.Source,
+		Metadata: map[string]string{
+			"manifest":     manifest,
+			"checksum":     s.checksum(),
+			"replicas":     strconv.Itoa(s.Replicas),
+			"dependencies": strings.Join(s.Dependencies, ","),
+			"requires":     strings.Join(s.Requires, ","),
+			"probe":        s.Probe,
+			"schedule":     s.Schedule,
+			"profile":      s.Profile,
+		},
+	}
+}
+
+// reconcile the runtime against the manifest printing the diff
+func reconcile(r runtime.Runtime, m *Manifest, env []string) error {
+	current, err := r.List()
+	if err != nil {
+		return err
+	}
+
+	running := make(map[string]*runtime.Service)
+	for _, s := range current {
+		running[key(s)] = s
+	}
+
+	var changes int
+	declared := make(map[string]bool)
+
+	for _, ms := range m.Services {
+		service := ms.service(m.Name)
+		k := key(service)
+		declared[k] = true
+
+		cur, ok := running[k]
+		switch {
+		case !ok:
+			fmt.Printf("+ %s %s %s\n", service.Name, service.Version, service.Source)
+
+			err = r.Create(service,
+				runtime.WithCommand("go", "run", service.Source),
+				runtime.WithEnv(append(env, ms.Env...)),
+			)
+		case cur.Metadata["checksum"] != service.Metadata["checksum"]:
+			fmt.Printf("~ %s %s %s\n", service.Name, service.Version, service.Source)
+
+			err = r.Update(service)
+		default:
+			continue
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to run %s: %v", service.Name, err)
+		}
+
+		changes++
+	}
+
+	// remove services previously run from the manifest
+	for k, s := range running {
+		if declared[k] || s.Metadata["manifest"] != m.Name {
+			continue
+		}
+
+		fmt.Printf("- %s %s %s\n", s.Name, s.Version, s.Source)
+
+		if err := r.Delete(s); err != nil {
+			return fmt.Errorf("failed to kill %s: %v", s.Name, err)
+		}
+
+		changes++
+	}
+
+	if changes == 0 {
+		fmt.Println("No changes")
+	}
+
+	return nil
+}
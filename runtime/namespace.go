@@ -0,0 +1,231 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/micro/cli/v2"
+	"github.com/micro/go-micro/v2/client"
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/runtime"
+	rs "github.com/micro/go-micro/v2/runtime/service"
+	pb "github.com/micro/go-micro/v2/runtime/service/proto"
+	"github.com/micro/micro/v2/internal/namespace"
+)
+
+const (
+	// metadata key of the namespace a service is run in
+	namespaceKey = "namespace"
+)
+
+// limits on the services run in a namespace, zero is unlimited
+type limits struct {
+	services int
+	cpu      float64
+	memory   int64
+}
+
+// parseLimits parses the namespace limits flag, * sets the
+// default e.g *=services:5;team-a=services:10,cpu:4,memory:8Gi
+func parseLimits(v string) (map[string]*limits, error) {
+	all := make(map[string]*limits)
+
+	for _, entry := range strings.Split(v, ";") {
+		entry = strings.TrimSpace(entry)
+		if len(entry) == 0 {
+			continue
+		}
+
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 || len(parts[0]) == 0 {
+			return nil, fmt.Errorf("invalid namespace limits %s", entry)
+		}
+
+		l := new(limits)
+
+		for _, limit := range strings.Split(parts[1], ",") {
+			kv := strings.SplitN(strings.TrimSpace(limit), ":", 2)
+			if len(kv) != 2 {
+				return nil, fmt.Errorf("invalid namespace limits %s", entry)
+			}
+
+			var err error
+
+			switch kv[0] {
+			case "services":
+				l.services, err = strconv.Atoi(kv[1])
+			case "cpu":
+				l.cpu, err = strconv.ParseFloat(kv[1], 64)
+			case "memory":
+				l.memory, err = parseMemory(kv[1])
+			default:
+				err = fmt.Errorf("unknown limit %s", kv[0])
+			}
+			if err != nil {
+				return nil, fmt.Errorf("invalid namespace limits %s: %v", entry, err)
+			}
+		}
+
+		all[strings.TrimSpace(parts[0])] = l
+	}
+
+	return all, nil
+}
+
+// namespaceLimits returns the limits of the namespace falling back to the default
+func (m *manager) namespaceLimits(namespace string) *limits {
+	if l, ok := m.limits[namespace]; ok {
+		return l
+	}
+	return m.limits["*"]
+}
+
+// checkNamespace ensures the service does not belong to another namespace
+// and is within the limits of its own. The caller must hold the lock.
+func (m *manager) checkNamespace(s *runtime.Service) error {
+	namespace := s.Metadata[namespaceKey]
+
+	var count int
+	var cpu float64
+	var memory int64
+
+	for k, v := range m.services {
+		if v.Service.Name == s.Name && v.Service.Metadata[namespaceKey] != namespace {
+			return errors.Forbidden(Name, "service %s belongs to another namespace", s.Name)
+		}
+		if len(namespace) == 0 || k == key(s) || v.Service.Metadata[namespaceKey] != namespace {
+			continue
+		}
+
+		r := resourceLimits(v.Service.Metadata)
+		count++
+		cpu += r.CPU
+		memory += r.Memory
+	}
+
+	if len(namespace) == 0 {
+		return nil
+	}
+
+	l := m.namespaceLimits(namespace)
+	if l == nil {
+		return nil
+	}
+
+	r := resourceLimits(s.Metadata)
+
+	if l.services > 0 && count+1 > l.services {
+		return errors.Forbidden(Name, "namespace %s is limited to %d services", namespace, l.services)
+	}
+	// services without resource limits can't be counted against the namespace
+	if l.cpu > 0 && (r.CPU == 0 || cpu+r.CPU > l.cpu) {
+		return errors.Forbidden(Name, "namespace %s is limited to %v cpu, set --cpu within the limit", namespace, l.cpu)
+	}
+	if l.memory > 0 && (r.Memory == 0 || memory+r.Memory > l.memory) {
+		return errors.Forbidden(Name, "namespace %s is limited to %d bytes of memory, set --memory within the limit", namespace, l.memory)
+	}
+
+	return nil
+}
+
+// scopedRuntime calls the runtime service with the namespace token
+// so the services run, listed and killed are scoped to its namespace
+type scopedRuntime struct {
+	runtime.Runtime
+	token  string
+	client pb.RuntimeService
+}
+
+func (r *scopedRuntime) context() context.Context {
+	return namespace.NewContext(context.Background(), r.token)
+}
+
+func (r *scopedRuntime) Create(s *runtime.Service, opts ...runtime.CreateOption) error {
+	var options runtime.CreateOptions
+	for _, o := range opts {
+		o(&options)
+	}
+
+	_, err := r.client.Create(r.context(), &pb.CreateRequest{
+		Service: toProto(s),
+		Options: &pb.CreateOptions{
+			Command: options.Command,
+			Env:     options.Env,
+		},
+	})
+	return err
+}
+
+func (r *scopedRuntime) Read(opts ...runtime.ReadOption) ([]*runtime.Service, error) {
+	var options runtime.ReadOptions
+	for _, o := range opts {
+		o(&options)
+	}
+
+	rsp, err := r.client.Read(r.context(), &pb.ReadRequest{
+		Options: &pb.ReadOptions{
+			Service: options.Service,
+			Version: options.Version,
+			Type:    options.Type,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toServices(rsp.Services), nil
+}
+
+func (r *scopedRuntime) Update(s *runtime.Service) error {
+	_, err := r.client.Update(r.context(), &pb.UpdateRequest{Service: toProto(s)})
+	return err
+}
+
+func (r *scopedRuntime) Delete(s *runtime.Service) error {
+	_, err := r.client.Delete(r.context(), &pb.DeleteRequest{Service: toProto(s)})
+	return err
+}
+
+func (r *scopedRuntime) List() ([]*runtime.Service, error) {
+	rsp, err := r.client.List(r.context(), &pb.ListRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return toServices(rsp.Services), nil
+}
+
+func toProto(s *runtime.Service) *pb.Service {
+	return &pb.Service{
+		Name:     s.Name,
+		Version:  s.Version,
+		Source:   s.Source,
+		Metadata: s.Metadata,
+	}
+}
+
+func toServices(services []*pb.Service) []*runtime.Service {
+	list := make([]*runtime.Service, 0, len(services))
+	for _, s := range services {
+		list = append(list, &runtime.Service{
+			Name:     s.Name,
+			Version:  s.Version,
+			Source:   s.Source,
+			Metadata: s.Metadata,
+		})
+	}
+	return list
+}
+
+// newRuntime returns the runtime service client scoped to the namespace of the token flag
+func newRuntime(ctx *cli.Context) runtime.Runtime {
+	r := rs.NewRuntime()
+	if tk := ctx.String("token"); len(tk) > 0 {
+		return &scopedRuntime{
+			Runtime: r,
+			token:   tk,
+			client:  pb.NewRuntimeService(Name, client.DefaultClient),
+		}
+	}
+	return r
+}
@@ -0,0 +1,49 @@
+package runtime
+
+import (
+	"context"
+	"strings"
+
+	"github.com/micro/go-micro/v2/util/log"
+	pb "github.com/micro/micro/v2/runtime/events/proto"
+	"github.com/micro/micro/v2/runtime/notify"
+)
+
+// newNotifier returns a subscriber which posts the lifecycle events to the webhooks
+func newNotifier(webhooks, events string) (func(context.Context, *pb.Event) error, error) {
+	var hooks []*notify.Webhook
+	for _, spec := range strings.Split(webhooks, ",") {
+		if len(strings.TrimSpace(spec)) == 0 {
+			continue
+		}
+		w, err := notify.Parse(spec)
+		if err != nil {
+			return nil, err
+		}
+		hooks = append(hooks, w)
+	}
+
+	var types []string
+	if len(events) > 0 {
+		types = strings.Split(events, ",")
+	}
+
+	n := notify.New(hooks, types)
+
+	return func(ctx context.Context, ev *pb.Event) error {
+		go func() {
+			err := n.Notify(&notify.Event{
+				Type:      ev.Type,
+				Service:   ev.Service,
+				Version:   ev.Version,
+				Namespace: ev.Namespace,
+				Error:     ev.Error,
+				Timestamp: ev.Timestamp,
+			})
+			if err != nil {
+				log.Logf("Notify error: %v", err)
+			}
+		}()
+		return nil
+	}, nil
+}
@@ -0,0 +1,211 @@
+// Package notify posts runtime lifecycle events to webhooks
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// Timeout for posting to a webhook
+	Timeout = time.Second * 10
+	// CrashLoop is the number of crashes within the CrashWindow notified as a crash loop
+	CrashLoop = 3
+	// CrashWindow is the period crashes are counted over
+	CrashWindow = time.Minute * 5
+	// Events are the event types notified by default
+	Events = []string{"create", "update", "delete", "crash_loop"}
+)
+
+// Event is a runtime lifecycle event
+type Event struct {
+	Type      string `json:"type"`
+	Service   string `json:"service"`
+	Version   string `json:"version"`
+	Namespace string `json:"namespace,omitempty"`
+	Error     string `json:"error,omitempty"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// Formatter encodes the event as the body posted to a webhook
+type Formatter func(*Event) ([]byte, error)
+
+// JSON posts the event as is
+func JSON(ev *Event) ([]byte, error) {
+	return json.Marshal(ev)
+}
+
+// Slack posts the event as a message to a slack incoming webhook
+func Slack(ev *Event) ([]byte, error) {
+	return json.Marshal(map[string]string{
+		"text": Text(ev),
+	})
+}
+
+// Text describes the event e.g Service greeter latest was killed
+func Text(ev *Event) string {
+	var action string
+
+	switch ev.Type {
+	case "create":
+		action = "was created"
+	case "update":
+		action = "was updated"
+	case "delete":
+		action = "was killed"
+	case "crash_loop":
+		action = fmt.Sprintf("is crash looping after %d crashes in %v", CrashLoop, CrashWindow)
+	default:
+		action = "had a " + ev.Type + " event"
+	}
+
+	text := fmt.Sprintf("Service %s %s %s", ev.Service, ev.Version, action)
+	if len(ev.Namespace) > 0 {
+		text += " in namespace " + ev.Namespace
+	}
+	if len(ev.Error) > 0 {
+		text += ": " + ev.Error
+	}
+
+	return text
+}
+
+// Webhook is a url posted to on events
+type Webhook struct {
+	URL    string
+	Format Formatter
+}
+
+// Parse a webhook e.g https://example.com/hook or slack=https://hooks.slack.com/services/...
+func Parse(spec string) (*Webhook, error) {
+	format := "json"
+	url := strings.TrimSpace(spec)
+
+	if parts := strings.SplitN(url, "=", 2); len(parts) == 2 && !strings.Contains(parts[0], "/") {
+		format, url = parts[0], parts[1]
+	}
+
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		return nil, fmt.Errorf("invalid webhook %s", spec)
+	}
+
+	switch format {
+	case "json":
+		return &Webhook{URL: url, Format: JSON}, nil
+	case "slack":
+		return &Webhook{URL: url, Format: Slack}, nil
+	}
+
+	return nil, fmt.Errorf("unknown webhook format %s", format)
+}
+
+// Notifier posts events to the webhooks
+type Notifier struct {
+	webhooks []*Webhook
+	events   map[string]bool
+	client   *http.Client
+
+	sync.Mutex
+	// times each service crashed within the window
+	crashes map[string][]time.Time
+}
+
+// New returns a notifier for the webhooks and event types
+func New(webhooks []*Webhook, events []string) *Notifier {
+	if len(events) == 0 {
+		events = Events
+	}
+
+	types := make(map[string]bool, len(events))
+	for _, e := range events {
+		types[strings.TrimSpace(e)] = true
+	}
+
+	return &Notifier{
+		webhooks: webhooks,
+		events:   types,
+		client:   &http.Client{Timeout: Timeout},
+		crashes:  make(map[string][]time.Time),
+	}
+}
+
+// crashLoop records the crash returning true once the service is crash looping
+func (n *Notifier) crashLoop(ev *Event) bool {
+	n.Lock()
+	defer n.Unlock()
+
+	k := ev.Namespace + "/" + ev.Service + ":" + ev.Version
+	now := time.Unix(ev.Timestamp, 0)
+
+	var recent []time.Time
+	for _, t := range n.crashes[k] {
+		if now.Sub(t) < CrashWindow {
+			recent = append(recent, t)
+		}
+	}
+	recent = append(recent, now)
+
+	// notify once per loop then start counting again
+	if len(recent) >= CrashLoop {
+		delete(n.crashes, k)
+		return true
+	}
+
+	n.crashes[k] = recent
+	return false
+}
+
+// Notify posts the event to the webhooks if its type is notified,
+// crashes are only notified once the service is crash looping
+func (n *Notifier) Notify(ev *Event) error {
+	if ev.Type == "crash" {
+		if !n.crashLoop(ev) {
+			return nil
+		}
+		cp := *ev
+		cp.Type = "crash_loop"
+		ev = &cp
+	}
+
+	if !n.events[ev.Type] {
+		return nil
+	}
+
+	var errs []string
+
+	for _, w := range n.webhooks {
+		if err := n.post(w, ev); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to notify %s event for %s: %s", ev.Type, ev.Service, strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+func (n *Notifier) post(w *Webhook, ev *Event) error {
+	b, err := w.Format(ev)
+	if err != nil {
+		return err
+	}
+
+	rsp, err := n.client.Post(w.URL, "application/json", bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	rsp.Body.Close()
+
+	if rsp.StatusCode >= 300 {
+		return fmt.Errorf("%s returned %s", w.URL, rsp.Status)
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package notify
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	w, err := Parse("slack=https://hooks.slack.com/services/T0/B0/x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.URL != "https://hooks.slack.com/services/T0/B0/x" {
+		t.Fatalf("Unexpected url %s", w.URL)
+	}
+
+	if _, err := Parse("https://example.com/hook?key=value"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, v := range []string{"example.com/hook", "teams=https://example.com/hook"} {
+		if _, err := Parse(v); err == nil {
+			t.Fatalf("Expected error parsing %s", v)
+		}
+	}
+}
+
+func TestNotify(t *testing.T) {
+	var bodies []map[string]string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		var body map[string]string
+		json.Unmarshal(b, &body)
+		bodies = append(bodies, body)
+	}))
+	defer srv.Close()
+
+	n := New([]*Webhook{{URL: srv.URL, Format: Slack}}, nil)
+
+	if err := n.Notify(&Event{Type: "delete", Service: "greeter", Version: "latest"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(bodies) != 1 || bodies[0]["text"] != "Service greeter latest was killed" {
+		t.Fatalf("Unexpected notifications %v", bodies)
+	}
+
+	// crashes are only notified once the service is crash looping
+	now := time.Now().Unix()
+	for i := 0; i < CrashLoop; i++ {
+		if err := n.Notify(&Event{Type: "crash", Service: "greeter", Version: "latest", Timestamp: now}); err != nil {
+			t.Fatal(err)
+		}
+		if i < CrashLoop-1 && len(bodies) != 1 {
+			t.Fatalf("Expected crash %d not to be notified", i)
+		}
+	}
+	if len(bodies) != 2 {
+		t.Fatalf("Expected the crash loop to be notified got %v", bodies)
+	}
+
+	// event types which aren't notified
+	n.Notify(&Event{Type: "restart", Service: "greeter"})
+	if len(bodies) != 2 {
+		t.Fatalf("Expected restart not to be notified")
+	}
+}
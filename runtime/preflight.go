@@ -0,0 +1,227 @@
+package runtime
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// PreflightTimeout is how long to wait for the platform dependencies to be checked
+	PreflightTimeout = time.Second * 5
+	// PreflightTTL is how long the result of checking a platform dependency is reused
+	PreflightTTL = time.Second * 30
+
+	// platformServices serve each platform dependency when its backend is "service"
+	platformServices = map[string]string{
+		"store":    "go.micro.store",
+		"config":   "go.micro.config",
+		"broker":   "go.micro.broker",
+		"registry": "go.micro.registry",
+	}
+)
+
+// requires returns the platform dependencies declared in the metadata e.g store,config,broker
+func requires(md map[string]string) []string {
+	var deps []string
+	for _, dep := range strings.Split(md["requires"], ",") {
+		if dep = strings.TrimSpace(dep); len(dep) > 0 {
+			deps = append(deps, dep)
+		}
+	}
+	return deps
+}
+
+// validateRequires returns an error if a platform dependency is unknown
+func validateRequires(deps []string) error {
+	for _, dep := range deps {
+		if _, ok := platformServices[dep]; !ok {
+			return fmt.Errorf("unknown platform dependency %s, expected store, config, broker or registry", dep)
+		}
+	}
+	return nil
+}
+
+// preflights is a pool of the checks of the platform dependencies shared by the
+// services so each backend is checked once per PreflightTTL in the background.
+// It's prewarmed with the dependencies of the services about to be started.
+type preflights struct {
+	sync.Mutex
+	// checks keyed by the dependency and its backend
+	checks map[string]*platformCheck
+}
+
+// platformCheck is the check of the backend of a platform dependency
+type platformCheck struct {
+	// closed once checked
+	done    chan struct{}
+	err     error
+	checked time.Time
+}
+
+func newPreflights() *preflights {
+	return &preflights{
+		checks: make(map[string]*platformCheck),
+	}
+}
+
+// envVars returns the environment as a map
+func envVars(env []string) map[string]string {
+	vars := make(map[string]string, len(env))
+	for _, kv := range env {
+		if parts := strings.SplitN(kv, "=", 2); len(parts) == 2 {
+			vars[parts[0]] = parts[1]
+		}
+	}
+	return vars
+}
+
+// check returns the check of the backend of the dependency, it's checked
+// in the background if it's not been checked within the PreflightTTL
+func (p *preflights) check(dep string, env map[string]string) *platformCheck {
+	prefix := "MICRO_" + strings.ToUpper(dep)
+	k := dep + ":" + env[prefix] + ":" + env[prefix+"_ADDRESS"]
+
+	p.Lock()
+	defer p.Unlock()
+
+	if c, ok := p.checks[k]; ok {
+		select {
+		case <-c.done:
+			if time.Since(c.checked) < PreflightTTL {
+				return c
+			}
+		default:
+			// still being checked
+			return c
+		}
+	}
+
+	c := &platformCheck{done: make(chan struct{})}
+	p.checks[k] = c
+
+	go func() {
+		c.err = checkPlatform(dep, env)
+		c.checked = time.Now()
+		close(c.done)
+	}()
+
+	return c
+}
+
+// prewarm starts checking the platform dependencies of the service in the background
+func (p *preflights) prewarm(md map[string]string, env []string) {
+	vars := envVars(env)
+	for _, dep := range requires(md) {
+		p.check(dep, vars)
+	}
+}
+
+// preflight checks the platform dependencies of the service can be reached
+// with the environment it will run with e.g MICRO_STORE=service or
+// MICRO_BROKER_ADDRESS=nats://localhost:4222. The dependencies are checked
+// together and fail if they're not checked within the PreflightTimeout.
+func (p *preflights) preflight(md map[string]string, env []string) error {
+	deps := requires(md)
+	if len(deps) == 0 {
+		return nil
+	}
+
+	vars := envVars(env)
+
+	checks := make([]*platformCheck, len(deps))
+	for i, dep := range deps {
+		checks[i] = p.check(dep, vars)
+	}
+
+	deadline := time.NewTimer(PreflightTimeout)
+	defer deadline.Stop()
+
+	for i, c := range checks {
+		select {
+		case <-c.done:
+			if c.err != nil {
+				return fmt.Errorf("platform dependency %s unavailable: %v", deps[i], c.err)
+			}
+		case <-deadline.C:
+			return fmt.Errorf("platform dependency %s unavailable: not checked within %v", deps[i], PreflightTimeout)
+		}
+	}
+
+	return nil
+}
+
+// checkPlatform resolves the backend of the dependency and checks it's reachable
+func checkPlatform(dep string, env map[string]string) error {
+	name, ok := platformServices[dep]
+	if !ok {
+		return fmt.Errorf("unknown platform dependency")
+	}
+
+	prefix := "MICRO_" + strings.ToUpper(dep)
+
+	// config is always read from the config service
+	if dep == "config" || env[prefix] == "service" {
+		if err := ready(name); err != nil {
+			return fmt.Errorf("%s is not ready: %v", name, err)
+		}
+		return nil
+	}
+
+	// the default backends run in process
+	addrs := env[prefix+"_ADDRESS"]
+	if len(addrs) == 0 {
+		return nil
+	}
+
+	// dial the addresses together
+	var hosts []string
+	for _, addr := range strings.Split(addrs, ",") {
+		if host := hostPort(addr); len(host) > 0 {
+			hosts = append(hosts, host)
+		}
+	}
+
+	errs := make(chan error, len(hosts))
+	for _, host := range hosts {
+		go func(host string) {
+			conn, err := net.DialTimeout("tcp", host, PreflightTimeout)
+			if err == nil {
+				conn.Close()
+			}
+			errs <- err
+		}(host)
+	}
+
+	var err error
+	for range hosts {
+		if e := <-errs; e != nil && err == nil {
+			err = e
+		}
+	}
+
+	return err
+}
+
+// hostPort returns the host and port of an address e.g nats://localhost:4222,
+// blank if it has no port to dial
+func hostPort(addr string) string {
+	addr = strings.TrimSpace(addr)
+
+	if strings.Contains(addr, "://") {
+		u, err := url.Parse(addr)
+		if err != nil {
+			return ""
+		}
+		addr = u.Host
+	}
+
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		return ""
+	}
+
+	return addr
+}
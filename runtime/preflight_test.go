@@ -0,0 +1,63 @@
+package runtime
+
+import (
+	"net"
+	"testing"
+)
+
+func TestHostPort(t *testing.T) {
+	testData := []struct {
+		addr   string
+		expect string
+	}{
+		{"nats://localhost:4222", "localhost:4222"},
+		{" 127.0.0.1:6379 ", "127.0.0.1:6379"},
+		{"postgres://user:pass@db:5432/micro", "db:5432"},
+		// nothing to dial without a port
+		{"nats://localhost", ""},
+		{"localhost", ""},
+		{"", ""},
+	}
+
+	for _, d := range testData {
+		if host := hostPort(d.addr); host != d.expect {
+			t.Fatalf("Expected %q for %s got %q", d.expect, d.addr, host)
+		}
+	}
+}
+
+func TestValidateRequires(t *testing.T) {
+	if err := validateRequires([]string{"store", "config", "broker", "registry"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := validateRequires([]string{"store", "database"}); err == nil {
+		t.Fatal("Expected an unknown dependency to fail")
+	}
+}
+
+func TestPreflight(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skip(err)
+	}
+	addr := l.Addr().String()
+
+	p := newPreflights()
+	md := map[string]string{"requires": "broker"}
+
+	if err := p.preflight(md, []string{"MICRO_BROKER_ADDRESS=nats://" + addr}); err != nil {
+		t.Fatal(err)
+	}
+
+	// the backend isn't dialed again within the ttl
+	l.Close()
+	if err := p.preflight(md, []string{"MICRO_BROKER_ADDRESS=nats://" + addr}); err != nil {
+		t.Fatal(err)
+	}
+
+	// each backend is checked
+	p.prewarm(md, []string{"MICRO_BROKER_ADDRESS=" + addr})
+	if err := p.preflight(md, []string{"MICRO_BROKER_ADDRESS=" + addr}); err == nil {
+		t.Fatal("Expected the closed address to be unavailable")
+	}
+}
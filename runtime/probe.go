@@ -0,0 +1,107 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/micro/go-micro/v2/client"
+	"github.com/micro/go-micro/v2/config/cmd"
+	proto "github.com/micro/go-micro/v2/debug/service/proto"
+	"github.com/micro/go-micro/v2/runtime"
+)
+
+var (
+	// ProbeFailures is the number of consecutive failed probes before a restart
+	ProbeFailures = 3
+	// ProbeTimeout is the timeout for tcp and http probes
+	ProbeTimeout = time.Second * 5
+)
+
+// probe checks the health of the service using the probe set in its
+// metadata e.g rpc, rpc://go.micro.srv.greeter, tcp://localhost:8080,
+// http://localhost:8080/health or none. The rpc probe checks the node
+// registered by the instance of the service. It returns false if the
+// service could not be probed.
+func probe(s *runtime.Service, instance string) (bool, error) {
+	p := s.Metadata["probe"]
+
+	switch {
+	case p == "none":
+		return false, nil
+	case len(p) == 0, p == "rpc":
+		return true, probeNode(s.Name, instance)
+	case strings.HasPrefix(p, "rpc://"):
+		return true, ready(strings.TrimPrefix(p, "rpc://"))
+	case strings.HasPrefix(p, "tcp://"):
+		conn, err := net.DialTimeout("tcp", strings.TrimPrefix(p, "tcp://"), ProbeTimeout)
+		if err != nil {
+			return true, err
+		}
+		return true, conn.Close()
+	case strings.HasPrefix(p, "http://"), strings.HasPrefix(p, "https://"):
+		c := &http.Client{Timeout: ProbeTimeout}
+		rsp, err := c.Get(p)
+		if err != nil {
+			return true, err
+		}
+		rsp.Body.Close()
+		if rsp.StatusCode >= 400 {
+			return true, fmt.Errorf("http status %d", rsp.StatusCode)
+		}
+		return true, nil
+	}
+
+	return false, fmt.Errorf("unknown probe %s", p)
+}
+
+// probeNode calls Debug.Health on the nodes registered by the instance of the service.
+// It fails if there are none as the instance has exited or been deregistered.
+func probeNode(name, instance string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), ProbeTimeout)
+	defer cancel()
+
+	// the registry can't be given a deadline so is left to return in the background
+	errc := make(chan error, 1)
+	go func() {
+		records, err := registered(name, instance)
+		if err != nil {
+			errc <- err
+			return
+		}
+		if len(records) == 0 {
+			errc <- fmt.Errorf("%s is not registered", name)
+			return
+		}
+
+		c := *cmd.DefaultCmd.Options().Client
+
+		for _, record := range records {
+			req := c.NewRequest(record.Name, "Debug.Health", &proto.HealthRequest{})
+			for _, node := range record.Nodes {
+				rsp := &proto.HealthResponse{}
+				if err := c.Call(ctx, req, rsp, client.WithAddress(node.Address)); err != nil {
+					errc <- err
+					return
+				}
+				if rsp.Status != "ok" {
+					errc <- errors.New("not healthy")
+					return
+				}
+			}
+		}
+
+		errc <- nil
+	}()
+
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("not probed within %v", ProbeTimeout)
+	}
+}
@@ -0,0 +1,27 @@
+// Package process signals the processes of locally run services
+package process
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// Env is set to the name:version of the service in the environment of its processes
+	Env = "MICRO_RUNTIME_SERVICE"
+)
+
+var (
+	// ErrNotSupported is returned on platforms where processes can't be found
+	ErrNotSupported = errors.New("signalling processes is not supported on this platform")
+)
+
+// Stats is the resource usage of the processes of a service
+type Stats struct {
+	// CPU time spent in user and system mode
+	CPU time.Duration
+	// RSS is the resident set size in bytes
+	RSS uint64
+	// FDs is the number of open file descriptors
+	FDs int
+}
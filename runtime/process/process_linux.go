@@ -0,0 +1,135 @@
+package process
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// clockTicks is the USER_HZ the kernel reports cpu time in
+const clockTicks = 100
+
+// List returns the pids of the processes run for each service
+// by reading the Env set in their environment
+func List() (map[string][]int, error) {
+	dirs, err := filepath.Glob("/proc/[0-9]*")
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := []byte(Env + "=")
+	services := make(map[string][]int)
+
+	for _, dir := range dirs {
+		pid, err := strconv.Atoi(filepath.Base(dir))
+		if err != nil {
+			continue
+		}
+
+		// processes we can't read aren't ours
+		b, err := ioutil.ReadFile(filepath.Join(dir, "environ"))
+		if err != nil {
+			continue
+		}
+
+		for _, v := range bytes.Split(b, []byte{0}) {
+			if bytes.HasPrefix(v, prefix) {
+				service := string(bytes.TrimPrefix(v, prefix))
+				services[service] = append(services[service], pid)
+				break
+			}
+		}
+	}
+
+	return services, nil
+}
+
+// Find returns the pids of the processes run for the service
+func Find(service string) ([]int, error) {
+	services, err := List()
+	if err != nil {
+		return nil, err
+	}
+	return services[service], nil
+}
+
+// Terminate sends SIGTERM to the processes
+func Terminate(pids []int) error {
+	var err error
+	for _, pid := range pids {
+		if e := syscall.Kill(pid, syscall.SIGTERM); e != nil && e != syscall.ESRCH {
+			err = e
+		}
+	}
+	return err
+}
+
+// Kill sends SIGKILL to the processes
+func Kill(pids []int) error {
+	var err error
+	for _, pid := range pids {
+		if e := syscall.Kill(pid, syscall.SIGKILL); e != nil && e != syscall.ESRCH {
+			err = e
+		}
+	}
+	return err
+}
+
+// Running returns the pids of the processes which are still running
+func Running(pids []int) []int {
+	var running []int
+	for _, pid := range pids {
+		// zombies have exited but not been reaped
+		b, err := ioutil.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "stat"))
+		if err != nil {
+			continue
+		}
+		if i := bytes.LastIndexByte(b, ')'); i > 0 && i+2 < len(b) && b[i+2] == 'Z' {
+			continue
+		}
+		running = append(running, pid)
+	}
+	return running
+}
+
+// Usage returns the resource usage summed across the processes
+func Usage(pids []int) (*Stats, error) {
+	stats := new(Stats)
+	pageSize := uint64(os.Getpagesize())
+
+	for _, pid := range pids {
+		dir := filepath.Join("/proc", strconv.Itoa(pid))
+
+		// the fields after the command name are space separated
+		b, err := ioutil.ReadFile(filepath.Join(dir, "stat"))
+		if err != nil {
+			// the process exited
+			continue
+		}
+		i := bytes.LastIndexByte(b, ')')
+		if i < 0 || i+2 >= len(b) {
+			continue
+		}
+		fields := bytes.Fields(b[i+2:])
+		// state is field 3 so utime, stime and rss are 14, 15 and 24
+		if len(fields) < 22 {
+			continue
+		}
+		utime, _ := strconv.ParseUint(string(fields[11]), 10, 64)
+		stime, _ := strconv.ParseUint(string(fields[12]), 10, 64)
+		rss, _ := strconv.ParseUint(string(fields[21]), 10, 64)
+
+		stats.CPU += time.Duration(utime+stime) * time.Second / clockTicks
+		stats.RSS += rss * pageSize
+
+		if fds, err := ioutil.ReadDir(filepath.Join(dir, "fd")); err == nil {
+			stats.FDs += len(fds)
+		}
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,56 @@
+package process
+
+import (
+	"os"
+	"os/exec"
+	"testing"
+)
+
+func TestTerminate(t *testing.T) {
+	cmd := exec.Command("sleep", "30")
+	cmd.Env = append(os.Environ(), Env+"=sleeper:test")
+	if err := cmd.Start(); err != nil {
+		t.Skip(err)
+	}
+	defer cmd.Process.Kill()
+
+	pids, err := Find("sleeper:test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pids) != 1 || pids[0] != cmd.Process.Pid {
+		t.Fatalf("Expected to find pid %d got %v", cmd.Process.Pid, pids)
+	}
+
+	if err := Terminate(pids); err != nil {
+		t.Fatal(err)
+	}
+	cmd.Wait()
+
+	if running := Running(pids); len(running) > 0 {
+		t.Fatalf("Expected the process to have exited got %v", running)
+	}
+}
+
+func TestUsage(t *testing.T) {
+	stats, err := Usage([]int{os.Getpid()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.RSS == 0 {
+		t.Fatal("Expected the resident set size to be reported")
+	}
+	// stdin, stdout and stderr at least
+	if stats.FDs < 3 {
+		t.Fatalf("Expected at least 3 open file descriptors got %d", stats.FDs)
+	}
+
+	// exited processes are skipped
+	stats, err = Usage([]int{1 << 30})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.RSS != 0 || stats.FDs != 0 {
+		t.Fatalf("Expected no usage for a missing process got %+v", stats)
+	}
+}
@@ -0,0 +1,34 @@
+//go:build !linux
+// +build !linux
+
+package process
+
+// List is not supported on platforms without /proc
+func List() (map[string][]int, error) {
+	return nil, ErrNotSupported
+}
+
+// Find is not supported on platforms without /proc
+func Find(service string) ([]int, error) {
+	return nil, ErrNotSupported
+}
+
+// Terminate is not supported on platforms without /proc
+func Terminate(pids []int) error {
+	return ErrNotSupported
+}
+
+// Kill is not supported on platforms without /proc
+func Kill(pids []int) error {
+	return ErrNotSupported
+}
+
+// Running returns no processes as none can be found
+func Running(pids []int) []int {
+	return nil
+}
+
+// Usage is not supported on platforms without /proc
+func Usage(pids []int) (*Stats, error) {
+	return nil, ErrNotSupported
+}
@@ -0,0 +1,48 @@
+package profile
+
+import (
+	"github.com/micro/micro/v2/runtime/cgroup"
+)
+
+// Capabilities are what the runtime honours for services run with a profile
+type Capabilities struct {
+	// Scale services to many replicas
+	Scale bool
+	// Logs of services can be read from the runtime
+	Logs bool
+	// Exec commands in running services
+	Exec bool
+	// Limits on cpu and memory are enforced
+	Limits bool
+}
+
+// CapabilitiesOf returns the capabilities of the named profile. Services
+// run with the local profile log to files read by the runtime, kubernetes
+// and platform translate limits to resource requests and every other
+// profile is run locally with limits enforced by cgroups where supported.
+func CapabilitiesOf(name string) Capabilities {
+	switch name {
+	case "", "local":
+		return Capabilities{Scale: true, Logs: true, Limits: cgroup.Supported}
+	case "kubernetes", "platform":
+		return Capabilities{Scale: true, Limits: true}
+	default:
+		return Capabilities{Scale: true, Limits: cgroup.Supported}
+	}
+}
+
+// Builtin returns true if the named profile is compiled in and not
+// overridden by one loaded from config
+func Builtin(name string) bool {
+	mtx.RLock()
+	defer mtx.RUnlock()
+
+	if _, ok := loaded[name]; ok {
+		return false
+	}
+	if _, ok := configured[name]; ok {
+		return false
+	}
+	_, ok := builtin[name]
+	return ok
+}
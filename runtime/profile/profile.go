@@ -1,6 +1,168 @@
 // Package profile is for specific profiles
 package profile
 
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/micro/go-micro/v2/client"
+	"github.com/micro/go-micro/v2/config/encoder/yaml"
+	mp "github.com/micro/go-micro/v2/config/source/service/proto"
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/util/log"
+)
+
+var (
+	// Path is the config file profiles are loaded from, it maps the
+	// name of each profile to the env vars injected into services e.g
+	//
+	//	staging:
+	//	  - MICRO_REGISTRY=etcd
+	//	  - MICRO_REGISTRY_ADDRESS=etcd.staging:2379
+	Path = filepath.Join(os.TempDir(), "micro", "profiles.yaml")
+
+	// ConfigName is the name of the config service profiles are read from
+	ConfigName = "go.micro.config"
+	// ConfigKey and ConfigPath are where the profiles are set in the config service,
+	// they're in the same format as the file e.g {"staging": ["MICRO_REGISTRY=etcd"]}
+	ConfigKey  = "micro"
+	ConfigPath = "runtime.profiles"
+	// RefreshInterval is how often the profiles are read from the config service
+	RefreshInterval = time.Second * 30
+
+	// profiles compiled in
+	builtin = map[string]func() []string{
+		"local":      Local,
+		"kubernetes": Kubernetes,
+		"platform":   Platform,
+	}
+
+	mtx sync.RWMutex
+	// profiles loaded from the file
+	loaded = map[string][]string{}
+	// profiles read from the config service
+	configured = map[string][]string{}
+)
+
+func init() {
+	if home, err := os.UserHomeDir(); err == nil {
+		Path = filepath.Join(home, ".micro", "profiles.yaml")
+	}
+	if p := os.Getenv("MICRO_RUNTIME_PROFILES"); len(p) > 0 {
+		Path = p
+	}
+}
+
+// Load the profiles from the config file at the path, a missing file is ignored.
+// Profiles loaded override any compiled in profile with the same name.
+func Load(path string) error {
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	profiles := map[string][]string{}
+	if err := yaml.NewEncoder().Decode(b, &profiles); err != nil {
+		return fmt.Errorf("failed to parse profiles %s: %v", path, err)
+	}
+
+	mtx.Lock()
+	loaded = profiles
+	mtx.Unlock()
+	return nil
+}
+
+// LoadConfig reads the profiles from the config service, they override
+// the profiles loaded from the file. It's not an error if none are set.
+func LoadConfig(c client.Client) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	rsp, err := mp.NewConfigService(ConfigName, c).Read(ctx, &mp.ReadRequest{Key: ConfigKey, Path: ConfigPath})
+	if err != nil && errors.Parse(err.Error()).Code != 404 {
+		return err
+	}
+
+	profiles := map[string][]string{}
+	if data := rsp.GetChange().GetChangeSet().GetData(); len(data) > 0 {
+		if err := json.Unmarshal(data, &profiles); err != nil {
+			return fmt.Errorf("failed to parse profiles %s.%s: %v", ConfigKey, ConfigPath, err)
+		}
+	}
+
+	mtx.Lock()
+	configured = profiles
+	mtx.Unlock()
+	return nil
+}
+
+// Watch reads the profiles from the config service every RefreshInterval until done is closed
+func Watch(c client.Client, done <-chan bool) {
+	t := time.NewTicker(RefreshInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-t.C:
+			if err := LoadConfig(c); err != nil {
+				log.Debugf("Failed to read the profiles from %s: %v", ConfigName, err)
+			}
+		}
+	}
+}
+
+// Env returns the env vars injected by the named profile
+func Env(name string) ([]string, error) {
+	mtx.RLock()
+	defer mtx.RUnlock()
+
+	if env, ok := configured[name]; ok {
+		return env, nil
+	}
+	if env, ok := loaded[name]; ok {
+		return env, nil
+	}
+	if fn, ok := builtin[name]; ok {
+		return fn(), nil
+	}
+	return nil, fmt.Errorf("unknown profile %s", name)
+}
+
+// Names returns the names of the profiles sorted
+func Names() []string {
+	mtx.RLock()
+	defer mtx.RUnlock()
+
+	set := make(map[string]bool)
+	for name := range builtin {
+		set[name] = true
+	}
+	for _, profiles := range []map[string][]string{loaded, configured} {
+		for name := range profiles {
+			set[name] = true
+		}
+	}
+
+	names := make([]string, 0, len(set))
+	for name := range set {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // Local is a profile for local environments
 func Local() []string {
 	return []string{}
@@ -11,6 +173,29 @@ func Kubernetes() []string {
 	return []string{}
 }
 
+// KubernetesResources translates cpu cores and memory bytes into kubernetes
+// resource requests and limits to be set as service metadata. Requests are
+// set equal to limits so services get a guaranteed quality of service.
+func KubernetesResources(cpu float64, memory int64) map[string]string {
+	res := make(map[string]string)
+
+	if cpu > 0 {
+		// cpu is expressed in millicores e.g 500m
+		v := fmt.Sprintf("%dm", int64(cpu*1000))
+		res["resources.requests.cpu"] = v
+		res["resources.limits.cpu"] = v
+	}
+
+	if memory > 0 {
+		// memory is expressed in mebibytes e.g 256Mi
+		v := fmt.Sprintf("%dMi", (memory+(1<<20)-1)>>20)
+		res["resources.requests.memory"] = v
+		res["resources.limits.memory"] = v
+	}
+
+	return res
+}
+
 // Platform is a platform profile
 func Platform() []string {
 	return []string{
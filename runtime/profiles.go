@@ -0,0 +1,76 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/micro/cli/v2"
+	"github.com/micro/go-micro/v2"
+	"github.com/micro/go-micro/v2/config/cmd"
+	"github.com/micro/micro/v2/runtime/handler"
+	"github.com/micro/micro/v2/runtime/profile"
+	pb "github.com/micro/micro/v2/runtime/profile/proto"
+)
+
+// listProfiles prints the profiles of the runtime and their capabilities
+func listProfiles(ctx *cli.Context, srvOpts ...micro.Option) {
+	var profiles []*pb.Profile
+
+	if ctx.Bool("local") {
+		// the profiles compiled into this binary and loaded from config
+		path := profile.Path
+		if p := ctx.String("profiles"); len(p) > 0 {
+			path = p
+		}
+		if err := profile.Load(path); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		rsp := new(pb.ListResponse)
+		h := &handler.Profiles{Active: ctx.String("profile")}
+		if err := h.List(context.Background(), &pb.ListRequest{}, rsp); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		profiles = rsp.Profiles
+	} else {
+		rsp, err := pb.NewProfilesService(Name, *cmd.DefaultOptions().Client).List(context.Background(), &pb.ListRequest{})
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		profiles = rsp.Profiles
+	}
+
+	yesNo := func(b bool) string {
+		if b {
+			return "yes"
+		}
+		return "no"
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
+	fmt.Fprintln(writer, "NAME\tACTIVE\tBUILTIN\tSCALE\tLOGS\tEXEC\tLIMITS")
+	for _, p := range profiles {
+		c := p.Capabilities
+		if c == nil {
+			c = new(pb.Capabilities)
+		}
+		active := ""
+		if p.Active {
+			active = "*"
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			p.Name,
+			active,
+			yesNo(p.Builtin),
+			yesNo(c.Scale),
+			yesNo(c.Logs),
+			yesNo(c.Exec),
+			yesNo(c.Limits))
+	}
+	writer.Flush()
+}
@@ -0,0 +1,86 @@
+package runtime
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/micro/micro/v2/runtime/cgroup"
+)
+
+const (
+	// metadata keys used to pass resource limits to the runtime
+	cpuKey    = "cpu"
+	memoryKey = "memory"
+)
+
+var (
+	// memory suffixes, longest first so Mi matches before M
+	memoryUnits = []struct {
+		suffix string
+		size   int64
+	}{
+		{"Ki", 1 << 10},
+		{"Mi", 1 << 20},
+		{"Gi", 1 << 30},
+		{"K", 1 << 10},
+		{"M", 1 << 20},
+		{"G", 1 << 30},
+	}
+)
+
+// parseMemory parses a memory value e.g 256M, 1Gi or 1048576
+func parseMemory(v string) (int64, error) {
+	v = strings.TrimSpace(v)
+	for _, unit := range memoryUnits {
+		if !strings.HasSuffix(v, unit.suffix) {
+			continue
+		}
+		n, err := strconv.ParseFloat(strings.TrimSuffix(v, unit.suffix), 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid memory value %s", v)
+		}
+		return int64(n * float64(unit.size)), nil
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid memory value %s", v)
+	}
+	return n, nil
+}
+
+// setResources validates the cpu and memory flags and stores them in the metadata
+func setResources(md map[string]string, cpu, memory string) error {
+	if len(cpu) > 0 {
+		c, err := strconv.ParseFloat(cpu, 64)
+		if err != nil || c <= 0 {
+			return fmt.Errorf("invalid cpu value %s", cpu)
+		}
+		md[cpuKey] = strconv.FormatFloat(c, 'f', -1, 64)
+	}
+
+	if len(memory) > 0 {
+		m, err := parseMemory(memory)
+		if err != nil {
+			return err
+		}
+		if m <= 0 {
+			return fmt.Errorf("invalid memory value %s", memory)
+		}
+		md[memoryKey] = strconv.FormatInt(m, 10)
+	}
+
+	return nil
+}
+
+// resourceLimits returns the limits stored in the service metadata
+func resourceLimits(md map[string]string) cgroup.Limits {
+	var l cgroup.Limits
+	if v, ok := md[cpuKey]; ok {
+		l.CPU, _ = strconv.ParseFloat(v, 64)
+	}
+	if v, ok := md[memoryKey]; ok {
+		l.Memory, _ = strconv.ParseInt(v, 10, 64)
+	}
+	return l
+}
@@ -0,0 +1,77 @@
+package runtime
+
+import (
+	"testing"
+)
+
+func TestParseMemory(t *testing.T) {
+	testData := []struct {
+		value  string
+		expect int64
+		err    bool
+	}{
+		{"1048576", 1 << 20, false},
+		{"256M", 256 << 20, false},
+		{"256Mi", 256 << 20, false},
+		{"1.5G", 3 << 29, false},
+		{"64Ki", 64 << 10, false},
+		{"lots", 0, true},
+		{"12MB", 0, true},
+	}
+
+	for _, d := range testData {
+		v, err := parseMemory(d.value)
+		if d.err {
+			if err == nil {
+				t.Fatalf("Expected error parsing %s", d.value)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Unexpected error parsing %s: %v", d.value, err)
+		}
+		if v != d.expect {
+			t.Fatalf("Expected %d for %s got %d", d.expect, d.value, v)
+		}
+	}
+}
+
+func TestSetResources(t *testing.T) {
+	md := make(map[string]string)
+
+	if err := setResources(md, "0.5", "256M"); err != nil {
+		t.Fatal(err)
+	}
+
+	l := resourceLimits(md)
+	if l.CPU != 0.5 {
+		t.Fatalf("Expected cpu 0.5 got %v", l.CPU)
+	}
+	if l.Memory != 256<<20 {
+		t.Fatalf("Expected memory %d got %d", 256<<20, l.Memory)
+	}
+
+	if err := setResources(md, "-1", ""); err == nil {
+		t.Fatal("Expected error for negative cpu")
+	}
+}
+
+func TestParseLimits(t *testing.T) {
+	l, err := parseLimits("*=services:5; team-a=services:10,cpu:4,memory:8Gi")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if l["*"].services != 5 || l["*"].cpu != 0 || l["*"].memory != 0 {
+		t.Fatalf("Unexpected default limits %+v", l["*"])
+	}
+	if l["team-a"].services != 10 || l["team-a"].cpu != 4 || l["team-a"].memory != 8<<30 {
+		t.Fatalf("Unexpected team-a limits %+v", l["team-a"])
+	}
+
+	for _, v := range []string{"team-a", "=services:1", "team-a=services", "team-a=disk:1", "team-a=cpu:lots"} {
+		if _, err := parseLimits(v); err == nil {
+			t.Fatalf("Expected error parsing %s", v)
+		}
+	}
+}
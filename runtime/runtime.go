@@ -3,13 +3,23 @@ package runtime
 
 import (
 	"os"
+	"strings"
 
 	"github.com/micro/cli/v2"
 	"github.com/micro/go-micro/v2"
 	"github.com/micro/go-micro/v2/config/cmd"
 	pb "github.com/micro/go-micro/v2/runtime/service/proto"
+	"github.com/micro/go-micro/v2/server"
+	"github.com/micro/go-micro/v2/store"
 	"github.com/micro/go-micro/v2/util/log"
+	logHandler "github.com/micro/micro/v2/debug/log/handler"
+	pblog "github.com/micro/micro/v2/debug/log/proto"
+	"github.com/micro/micro/v2/internal/bulk"
+	"github.com/micro/micro/v2/runtime/artifact"
+	epb "github.com/micro/micro/v2/runtime/events/proto"
 	"github.com/micro/micro/v2/runtime/handler"
+	ppb "github.com/micro/micro/v2/runtime/profile/proto"
+	"github.com/micro/micro/v2/runtime/state"
 )
 
 var (
@@ -17,6 +27,8 @@ var (
 	Name = "go.micro.runtime"
 	// Address of the runtime
 	Address = ":8088"
+	// EventsTopic is the topic lifecycle events are published to
+	EventsTopic = "go.micro.runtime.events"
 )
 
 // Run the runtime service
@@ -46,8 +58,39 @@ func Run(ctx *cli.Context, srvOpts ...micro.Option) {
 	// use default store
 	muStore := *cmd.DefaultCmd.Options().Store
 
+	// the artifacts are cached in their own database rather than with the
+	// desired state, which is listed on every reconcile. The memory store is
+	// left to the artifacts as the state is then persisted to disk.
+	artifactStore := muStore
+	if newStore, ok := cmd.DefaultStores[muStore.String()]; ok && muStore.String() != "memory" {
+		opts := []store.Option{store.Namespace(artifact.Namespace)}
+		if addr := ctx.String("store_address"); len(addr) > 0 {
+			opts = append(opts, store.Nodes(strings.Split(addr, ",")...))
+		}
+		artifactStore = newStore(opts...)
+	}
+
+	// persist the desired state to disk rather than forgetting it on restart
+	if muStore.String() == "memory" {
+		dir := state.Dir
+		if d := ctx.String("state_dir"); len(d) > 0 {
+			dir = d
+		}
+		muStore = state.NewStore(dir)
+	}
+
+	// append name
+	srvOpts = append(srvOpts, micro.Name(Name))
+
+	// new service
+	service := micro.NewService(srvOpts...)
+
+	// publisher for lifecycle events
+	publisher := micro.NewEvent(EventsTopic, service.Client())
+
 	// create a new runtime manager
-	manager := newManager(ctx, muRuntime, muStore)
+	manager := newManager(ctx, muRuntime, muStore, artifactStore)
+	manager.publisher = publisher
 
 	log.Logf("using store %s", muStore.String())
 
@@ -57,20 +100,39 @@ func Run(ctx *cli.Context, srvOpts ...micro.Option) {
 		os.Exit(1)
 	}
 
-	// append name
-	srvOpts = append(srvOpts, micro.Name(Name))
-
-	// new service
-	service := micro.NewService(srvOpts...)
-
 	// register the runtime handler
 	pb.RegisterRuntimeHandler(service.Server(), &handler.Runtime{
 		// Client to publish events
-		Client: micro.NewEvent("go.micro.runtime.events", service.Client()),
+		Client: publisher,
 		// using the micro runtime
 		Runtime: manager,
 	})
 
+	// stream events to subscribers
+	events := handler.NewEvents()
+	epb.RegisterEventsHandler(service.Server(), events)
+	service.Server().Subscribe(service.Server().NewSubscriber(EventsTopic, events.Process))
+
+	// serve the log files of the services run on this node
+	pblog.RegisterLogHandler(service.Server(), &logHandler.Log{
+		Files: manager.logPaths,
+	})
+
+	// list the profiles and their capabilities
+	ppb.RegisterProfilesHandler(service.Server(), &handler.Profiles{
+		Active: ctx.String("profile"),
+	})
+
+	// post lifecycle events to webhooks
+	if len(ctx.String("notify_webhooks")) > 0 {
+		notifier, err := newNotifier(ctx.String("notify_webhooks"), ctx.String("notify_events"))
+		if err != nil {
+			log.Fatal(err)
+		}
+		// queued so each event is posted once by the runtime nodes together
+		service.Server().Subscribe(service.Server().NewSubscriber(EventsTopic, notifier, server.SubscriberQueue(Name+".notify")))
+	}
+
 	// start runtime service
 	if err := service.Run(); err != nil {
 		log.Logf("error running service: %v", err)
@@ -97,7 +159,7 @@ func Flags() []cli.Flag {
 		},
 		&cli.StringFlag{
 			Name:  "source",
-			Usage: "Set the source url of the service e.g /path/to/source",
+			Usage: "Set the source url of the service e.g /path/to/source, docker://org/image:tag or file:///path/to/binary",
 		},
 		&cli.BoolFlag{
 			Name:  "local",
@@ -107,10 +169,92 @@ func Flags() []cli.Flag {
 			Name:  "env",
 			Usage: "Set the environment variables e.g. foo=bar",
 		},
+		&cli.StringFlag{
+			Name:  "cpu",
+			Usage: "Set the cpu limit in cores e.g 0.5",
+		},
+		&cli.StringFlag{
+			Name:  "memory",
+			Usage: "Set the memory limit e.g 256M",
+		},
 		&cli.BoolFlag{
 			Name:  "runtime",
 			Usage: "Return the runtime services",
 		},
+		&cli.StringSliceFlag{
+			Name:  "dependencies",
+			Usage: "Set the services which must be registered and healthy before starting e.g go.micro.store",
+		},
+		&cli.DurationFlag{
+			Name:  "dependency_timeout",
+			Usage: "Set how long to wait for dependencies to be ready e.g 2m",
+		},
+		&cli.StringSliceFlag{
+			Name:  "requires",
+			Usage: "Set the platform dependencies which must be reachable before starting e.g store,config,broker",
+		},
+		&cli.StringSliceFlag{
+			Name:  "secret",
+			Usage: "Set the secrets to inject at start e.g db_password, db_password:env=DB_PASS, tls_key:file=tls.key written under $MICRO_SECRETS_DIR",
+		},
+		&cli.StringFlag{
+			Name:  "profile",
+			Usage: "Set the profile of defaults injected into the service e.g local, kubernetes, platform or one defined in ~/.micro/profiles.yaml or at micro.runtime.profiles in the config service",
+		},
+		&cli.StringFlag{
+			Name:  "probe",
+			Usage: "Set the health probe e.g rpc, rpc://go.micro.srv.greeter, tcp://localhost:8080, http://localhost:8080/health, none",
+		},
+		&cli.StringFlag{
+			Name:  "schedule",
+			Usage: "Set a cron schedule to run the service on e.g \"*/5 * * * *\", @daily or \"@every 1h\"",
+		},
+		&cli.BoolFlag{
+			Name:  "scheduled",
+			Usage: "Return the scheduled services with their last and next run",
+		},
+		&cli.BoolFlag{
+			Name:  "stats",
+			Usage: "Return the cpu time, memory and open files of the services run locally",
+		},
+		&cli.StringFlag{
+			Name:    "token",
+			Usage:   "Set the namespace token used to scope the services run, listed and killed to its namespace",
+			EnvVars: []string{"MICRO_NAMESPACE_TOKEN"},
+		},
+		&cli.BoolFlag{
+			Name:  "show-secrets",
+			Usage: "Show the values of secret keys in the output rather than masking them",
+		},
+		&cli.DurationFlag{
+			Name:  "grace",
+			Usage: "Set how long the killed service is given to drain and exit before it's killed e.g 30s",
+		},
+		&cli.StringFlag{
+			Name:  "canary",
+			Usage: "Set the share of traffic the deployed version receives e.g 10%",
+		},
+		&cli.StringFlag{
+			Name:  "strategy",
+			Usage: "Set the strategy used to update the service e.g recreate, blue-green",
+		},
+		&cli.DurationFlag{
+			Name:  "health_timeout",
+			Usage: "Set how long a blue-green update waits for the new version to be healthy e.g 2m",
+		},
+		&cli.DurationFlag{
+			Name:  "soak",
+			Usage: "Set how long the old version is kept after a blue-green update switches traffic e.g 5m",
+		},
+		&cli.IntFlag{
+			Name:  "revision",
+			Usage: "Set the revision to rollback to, defaults to the prior revision",
+		},
+		&cli.StringFlag{
+			Name:    "file",
+			Aliases: []string{"f"},
+			Usage:   "Set the manifest file describing the services to run e.g micro.yaml",
+		},
 	}
 }
 
@@ -130,11 +274,105 @@ func Commands(options ...micro.Option) []*cli.Command {
 					Usage:   "Set the runtime profile to use for services e.g local, kubernetes, platform",
 					EnvVars: []string{"MICRO_RUNTIME_PROFILE"},
 				},
+				&cli.StringFlag{
+					Name:    "state_dir",
+					Usage:   "Set the directory the services to run are persisted to when using the memory store, defaults to ~/.micro/runtime",
+					EnvVars: []string{"MICRO_RUNTIME_STATE_DIR"},
+				},
+				&cli.BoolFlag{
+					Name:    "artifact_cache",
+					Usage:   "Share the binaries built from versioned sources e.g github.com/my/service@v1.0.0 via the store",
+					EnvVars: []string{"MICRO_RUNTIME_ARTIFACT_CACHE"},
+				},
+				&cli.StringFlag{
+					Name:    "profiles",
+					Usage:   "Set the config file defining the profiles services can select, defaults to ~/.micro/profiles.yaml. Profiles at micro.runtime.profiles in the config service override it",
+					EnvVars: []string{"MICRO_RUNTIME_PROFILES"},
+				},
+				&cli.DurationFlag{
+					Name:    "dependency_timeout",
+					Usage:   "Set how long services wait for their dependencies to be ready e.g 2m",
+					EnvVars: []string{"MICRO_RUNTIME_DEPENDENCY_TIMEOUT"},
+				},
+				&cli.IntFlag{
+					Name:    "probe_failures",
+					Usage:   "Set the number of consecutive failed health probes before a service is restarted",
+					EnvVars: []string{"MICRO_RUNTIME_PROBE_FAILURES"},
+				},
+				&cli.StringFlag{
+					Name:    "notify_webhooks",
+					Usage:   "Comma separated list of webhooks posted lifecycle events e.g https://example.com/hook,slack=https://hooks.slack.com/services/...",
+					EnvVars: []string{"MICRO_RUNTIME_NOTIFY_WEBHOOKS"},
+				},
+				&cli.StringFlag{
+					Name:    "notify_events",
+					Usage:   "Comma separated list of events to notify e.g create,update,delete,crash_loop",
+					EnvVars: []string{"MICRO_RUNTIME_NOTIFY_EVENTS"},
+				},
+				&cli.DurationFlag{
+					Name:    "grace_period",
+					Usage:   "Set how long killed services are given to drain and exit before they're killed e.g 30s",
+					EnvVars: []string{"MICRO_RUNTIME_GRACE_PERIOD"},
+				},
+				&cli.StringFlag{
+					Name:    "namespace_limits",
+					Usage:   "Set the limits on services run in each namespace, * is the default e.g *=services:5;team-a=services:10,cpu:4,memory:8Gi",
+					EnvVars: []string{"MICRO_RUNTIME_NAMESPACE_LIMITS"},
+				},
 			},
 			Action: func(ctx *cli.Context) error {
 				Run(ctx, options...)
 				return nil
 			},
+			Subcommands: []*cli.Command{
+				{
+					Name:  "events",
+					Usage: "Stream the runtime lifecycle events e.g create, update, delete, crash",
+					Flags: []cli.Flag{
+						&cli.StringFlag{
+							Name:  "service",
+							Usage: "Only stream events for the service",
+						},
+						&cli.StringFlag{
+							Name:  "type",
+							Usage: "Only stream events of the type e.g crash",
+						},
+						&cli.StringFlag{
+							Name:    "token",
+							Usage:   "Set the namespace token used to only stream events for services in its namespace",
+							EnvVars: []string{"MICRO_NAMESPACE_TOKEN"},
+						},
+					},
+					Action: func(ctx *cli.Context) error {
+						streamEvents(ctx, options...)
+						return nil
+					},
+				},
+				{
+					Name:  "profiles",
+					Usage: "List the runtime profiles and their capabilities e.g scale, logs, exec, limits",
+					Flags: []cli.Flag{
+						&cli.BoolFlag{
+							Name:  "local",
+							Usage: "List the profiles compiled into this binary rather than asking the runtime",
+						},
+						&cli.StringFlag{
+							Name:    "profile",
+							Usage:   "Set the profile marked active when listing locally",
+							EnvVars: []string{"MICRO_RUNTIME_PROFILE"},
+						},
+						&cli.StringFlag{
+							Name:    "profiles",
+							Usage:   "Set the config file defining the profiles when listing locally, defaults to ~/.micro/profiles.yaml",
+							EnvVars: []string{"MICRO_RUNTIME_PROFILES"},
+						},
+					},
+					Action: func(ctx *cli.Context) error {
+						listProfiles(ctx, options...)
+						return nil
+					},
+				},
+			},
 		},
 		{
 			// In future we'll also have `micro run [x]` hence `micro run service` requiring "service"
@@ -146,10 +384,142 @@ func Commands(options ...micro.Option) []*cli.Command {
 				return nil
 			},
 		},
+		{
+			Name:  "deploy",
+			Usage: DeployUsage,
+			Flags: Flags(),
+			Action: func(ctx *cli.Context) error {
+				deployService(ctx, options...)
+				return nil
+			},
+		},
+		{
+			Name:  "update",
+			Usage: UpdateUsage,
+			Flags: Flags(),
+			Action: func(ctx *cli.Context) error {
+				updateService(ctx, options...)
+				return nil
+			},
+		},
+		{
+			Name:  "upgrade",
+			Usage: "Upgrade the platform services in dependency order rolling back a failed step",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "version",
+					Usage: "Set the release to upgrade to e.g v2.1.0",
+				},
+				&cli.StringFlag{
+					Name:  "source",
+					Usage: "Set the source to upgrade to, {version} is replaced by the version e.g micro/micro:{version}",
+				},
+				&cli.StringSliceFlag{
+					Name:  "services",
+					Usage: "Set the platform services to upgrade e.g registry,store, defaults to all",
+				},
+				&cli.DurationFlag{
+					Name:  "health_timeout",
+					Usage: "Set how long each service has to be healthy after upgrading e.g 2m",
+				},
+			},
+			Action: func(ctx *cli.Context) error {
+				upgradePlatform(ctx, options...)
+				return nil
+			},
+		},
+		{
+			Name:  "promote",
+			Usage: PromoteUsage,
+			Flags: Flags(),
+			Action: func(ctx *cli.Context) error {
+				promoteService(ctx, options...)
+				return nil
+			},
+		},
+		{
+			Name:  "history",
+			Usage: HistoryUsage,
+			Flags: Flags(),
+			Action: func(ctx *cli.Context) error {
+				historyService(ctx, options...)
+				return nil
+			},
+		},
+		{
+			Name:  "rollback",
+			Usage: RollbackUsage,
+			Flags: Flags(),
+			Action: func(ctx *cli.Context) error {
+				rollbackService(ctx, options...)
+				return nil
+			},
+		},
+		{
+			Name:  "autoscale",
+			Usage: "Manage the autoscaling of services e.g " + AutoscaleUsage,
+			Action: func(ctx *cli.Context) error {
+				listAutoscale(ctx, options...)
+				return nil
+			},
+			Flags: Flags(),
+			Subcommands: []*cli.Command{
+				{
+					Name:  "set",
+					Usage: AutoscaleUsage,
+					Flags: append(Flags(),
+						&cli.IntFlag{
+							Name:  "min",
+							Usage: "Set the minimum number of replicas",
+							Value: 1,
+						},
+						&cli.IntFlag{
+							Name:  "max",
+							Usage: "Set the maximum number of replicas",
+						},
+						&cli.Float64Flag{
+							Name:  "target-rps",
+							Usage: "Set the requests per second each replica should handle",
+						},
+						&cli.StringFlag{
+							Name:  "target-memory",
+							Usage: "Set the memory each replica should use e.g 256Mi",
+						},
+						&cli.DurationFlag{
+							Name:  "target-latency",
+							Usage: "Set the 99th percentile latency of the requests to each replica e.g 200ms",
+						},
+					),
+					Action: func(ctx *cli.Context) error {
+						setAutoscale(ctx, options...)
+						return nil
+					},
+				},
+				{
+					Name:  "unset",
+					Usage: "Remove the autoscale policy of a service e.g micro autoscale unset [service]",
+					Flags: Flags(),
+					Action: func(ctx *cli.Context) error {
+						unsetAutoscale(ctx, options...)
+						return nil
+					},
+				},
+			},
+		},
 		{
 			Name:  "kill",
 			Usage: KillUsage,
-			Flags: Flags(),
+			Flags: append(Flags(),
+				&cli.StringFlag{
+					Name:  "selector",
+					Usage: "Kill the services with metadata matching the selector e.g team=payments,env=dev",
+				},
+				&cli.IntFlag{
+					Name:  "concurrency",
+					Usage: "Set how many services are killed at once",
+					Value: bulk.Concurrency,
+				},
+			),
 			Action: func(ctx *cli.Context) error {
 				killService(ctx, options...)
 				return nil
@@ -0,0 +1,176 @@
+// Package secrets resolves the secrets injected into runtime services
+package secrets
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	// Backend is where secrets are read from e.g file, env
+	Backend = "file"
+	// Path is the directory the file backend reads secrets from, one file per secret
+	Path = "/etc/micro/secrets"
+	// Files is the directory secret files are written to, each service has its own
+	Files = filepath.Join(os.TempDir(), "micro", "secrets")
+	// FilesEnv is the env var set to the directory the secret files of the service are written to
+	FilesEnv = "MICRO_SECRETS_DIR"
+)
+
+func init() {
+	if b := os.Getenv("MICRO_SECRET_BACKEND"); len(b) > 0 {
+		Backend = b
+	}
+	if p := os.Getenv("MICRO_SECRET_PATH"); len(p) > 0 {
+		Path = p
+	}
+	if p := os.Getenv("MICRO_SECRET_FILES"); len(p) > 0 {
+		Files = p
+	}
+}
+
+// Secret is a secret to inject into a service
+type Secret struct {
+	// Name of the secret in the backend
+	Name string
+	// Env var to set, defaults to the upper case name
+	Env string
+	// File to write the secret to instead of setting an env var,
+	// relative to the secrets directory of the service
+	File string
+}
+
+// Parse a secret spec e.g db_password, db_password:env=DB_PASS or tls_key:file=tls/tls.key
+func Parse(spec string) (*Secret, error) {
+	parts := strings.SplitN(spec, ":", 2)
+
+	s := &Secret{Name: strings.TrimSpace(parts[0])}
+	if len(s.Name) == 0 || strings.ContainsAny(s.Name, `/\`) || strings.Contains(s.Name, "..") {
+		return nil, fmt.Errorf("invalid secret name %q", s.Name)
+	}
+
+	if len(parts) == 1 {
+		s.Env = envName(s.Name)
+		return s, nil
+	}
+
+	kv := strings.SplitN(parts[1], "=", 2)
+	if len(kv) != 2 || len(kv[1]) == 0 {
+		return nil, fmt.Errorf("invalid secret %q", spec)
+	}
+
+	switch kv[0] {
+	case "env":
+		s.Env = kv[1]
+	case "file":
+		// the file is confined to the secrets directory of the service
+		f := filepath.Clean(kv[1])
+		if filepath.IsAbs(kv[1]) || f == "." || f == ".." || strings.HasPrefix(f, ".."+string(filepath.Separator)) {
+			return nil, fmt.Errorf("invalid secret %q, the file must be relative to the secrets directory", spec)
+		}
+		s.File = f
+	default:
+		return nil, fmt.Errorf("invalid secret %q, expected env or file", spec)
+	}
+
+	return s, nil
+}
+
+// envName converts the name to an env var e.g db-password is DB_PASSWORD
+func envName(name string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(name))
+}
+
+// Read the value of the secret from the backend
+func Read(name string) ([]byte, error) {
+	switch Backend {
+	case "env":
+		v, ok := os.LookupEnv("MICRO_SECRET_" + envName(name))
+		if !ok {
+			return nil, fmt.Errorf("secret %s not found", name)
+		}
+		return []byte(v), nil
+	case "file":
+		b, err := ioutil.ReadFile(filepath.Join(Path, name))
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("secret %s not found", name)
+		}
+		return b, err
+	}
+
+	return nil, errors.New("unknown secret backend " + Backend)
+}
+
+// dir returns the directory the secret files of the service are written to
+func dir(service string) (string, error) {
+	if len(service) == 0 || strings.ContainsAny(service, `/\`) || strings.Contains(service, "..") {
+		return "", fmt.Errorf("invalid service name %q", service)
+	}
+	return filepath.Join(Files, service), nil
+}
+
+// Inject reads the secrets of the service returning the env vars to set and writing any
+// files to its secrets directory, the directory is set as FilesEnv if any are written
+func Inject(service string, specs []string) ([]string, error) {
+	var env []string
+	var files bool
+
+	for _, spec := range specs {
+		s, err := Parse(spec)
+		if err != nil {
+			return nil, err
+		}
+
+		v, err := Read(s.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(s.File) == 0 {
+			env = append(env, s.Env+"="+strings.TrimRight(string(v), "\n"))
+			continue
+		}
+
+		d, err := dir(service)
+		if err != nil {
+			return nil, err
+		}
+		path := filepath.Join(d, s.File)
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			return nil, err
+		}
+		if err := ioutil.WriteFile(path, v, 0600); err != nil {
+			return nil, err
+		}
+		files = true
+	}
+
+	if files {
+		d, _ := dir(service)
+		env = append(env, FilesEnv+"="+d)
+	}
+
+	return env, nil
+}
+
+// Remove deletes the secrets directory of the service with any files written to it
+func Remove(service string) {
+	if d, err := dir(service); err == nil {
+		os.RemoveAll(d)
+	}
+}
+
+// Split the secrets stored in service metadata
+func Split(md string) []string {
+	var specs []string
+	for _, spec := range strings.Split(md, ",") {
+		if spec = strings.TrimSpace(spec); len(spec) > 0 {
+			specs = append(specs, spec)
+		}
+	}
+	return specs
+}
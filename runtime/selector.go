@@ -0,0 +1,39 @@
+package runtime
+
+import (
+	"fmt"
+	"strings"
+)
+
+// parseSelector parses a selector of comma separated key=value pairs e.g team=payments,env=dev
+func parseSelector(s string) (map[string]string, error) {
+	selector := make(map[string]string)
+
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if len(pair) == 0 {
+			continue
+		}
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || len(parts[0]) == 0 {
+			return nil, fmt.Errorf("invalid selector %q, expected key=value", pair)
+		}
+		selector[parts[0]] = parts[1]
+	}
+
+	if len(selector) == 0 {
+		return nil, fmt.Errorf("empty selector")
+	}
+
+	return selector, nil
+}
+
+// selected returns true if the labels match every key of the selector
+func selected(labels, selector map[string]string) bool {
+	for k, v := range selector {
+		if l, ok := labels[k]; !ok || l != v {
+			return false
+		}
+	}
+	return true
+}
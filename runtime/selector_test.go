@@ -0,0 +1,34 @@
+package runtime
+
+import (
+	"testing"
+)
+
+func TestSelector(t *testing.T) {
+	selector, err := parseSelector("team=payments, env=dev")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testData := []struct {
+		labels map[string]string
+		expect bool
+	}{
+		{map[string]string{"team": "payments", "env": "dev", "name": "billing"}, true},
+		{map[string]string{"team": "payments", "env": "prod"}, false},
+		{map[string]string{"team": "payments"}, false},
+		{nil, false},
+	}
+
+	for _, d := range testData {
+		if got := selected(d.labels, selector); got != d.expect {
+			t.Fatalf("Expected %v for %v got %v", d.expect, d.labels, got)
+		}
+	}
+
+	for _, s := range []string{"", "team", "=payments", ","} {
+		if _, err := parseSelector(s); err == nil {
+			t.Fatalf("Expected an error parsing %q", s)
+		}
+	}
+}
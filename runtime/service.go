@@ -4,26 +4,34 @@ package runtime
 import (
 	"fmt"
 	"os"
+	osexec "os/exec"
 	"os/signal"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"syscall"
 	"text/tabwriter"
+	"time"
 
 	"github.com/micro/cli/v2"
 	"github.com/micro/go-micro/v2"
 	"github.com/micro/go-micro/v2/config/cmd"
 	"github.com/micro/go-micro/v2/runtime"
-	rs "github.com/micro/go-micro/v2/runtime/service"
+	"github.com/micro/micro/v2/internal/bulk"
+	"github.com/micro/micro/v2/internal/redact"
+	"github.com/micro/micro/v2/runtime/cgroup"
+	"github.com/micro/micro/v2/runtime/cron"
+	"github.com/micro/micro/v2/runtime/profile"
 	"github.com/micro/micro/v2/runtime/scheduler"
+	"github.com/micro/micro/v2/runtime/secrets"
 )
 
 const (
 	// RunUsage message for the run command
-	RunUsage = "Required usage: micro run github.com/my/service [--name service --version latest]"
+	RunUsage = "Required usage: micro run github.com/my/service [--name service --version latest] or micro run -f micro.yaml"
 	// KillUsage message for the kill command
-	KillUsage = "Require usage: micro kill [service] [version]"
+	KillUsage = "Require usage: micro kill [service] [version] or micro kill --selector team=payments"
 	// Getusage message for micro get command
 	GetUsage = "Require usage: micro ps [service] [version]"
 )
@@ -39,6 +47,35 @@ func defaultEnv() []string {
 	return env
 }
 
+// sourceCommit returns the commit of the source if it can be determined
+func sourceCommit(source string) string {
+	// a versioned source e.g github.com/my/service@v1.0.0
+	if parts := strings.Split(source, "@"); len(parts) == 2 {
+		return parts[1]
+	}
+
+	dir := source
+	if len(dir) == 0 {
+		dir, _ = os.Getwd()
+	}
+
+	out, err := osexec.Command("git", "-C", dir, "rev-parse", "--short", "HEAD").Output()
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(string(out))
+}
+
+// formatUptime returns the uptime since the unix start time
+func formatUptime(started string) string {
+	t, err := strconv.ParseInt(started, 10, 64)
+	if err != nil || t == 0 {
+		return "n/a"
+	}
+	return time.Since(time.Unix(t, 0)).Round(time.Second).String()
+}
+
 func runService(ctx *cli.Context, srvOpts ...micro.Option) {
 	// Init plugins
 	for _, p := range Plugins() {
@@ -52,6 +89,12 @@ func runService(ctx *cli.Context, srvOpts ...micro.Option) {
 	env := ctx.StringSlice("env")
 	local := ctx.Bool("local")
 
+	// run the services declared in a manifest
+	if file := ctx.String("file"); len(file) > 0 {
+		runManifest(ctx, file)
+		return
+	}
+
 	// we need some args to run
 	if ctx.Args().Len() == 0 {
 		fmt.Println(RunUsage)
@@ -69,7 +112,9 @@ func runService(ctx *cli.Context, srvOpts ...micro.Option) {
 
 	// must specify service name
 	if len(name) == 0 {
-		if len(source) > 0 {
+		if prebuilt(source) {
+			name = sourceName(source)
+		} else if len(source) > 0 {
 			name = filepath.Base(source)
 		} else {
 			// set name
@@ -84,6 +129,10 @@ func runService(ctx *cli.Context, srvOpts ...micro.Option) {
 	switch local {
 	case true:
 		r = *cmd.DefaultCmd.Options().Runtime
+		// prebuilt sources are run once the env is known
+		if prebuilt(source) {
+			break
+		}
 		// NOTE: When in local mode, we consider source to be
 		// the filesystem path to the source of the service
 		exec = []string{"go", "run", "."}
@@ -102,7 +151,7 @@ func runService(ctx *cli.Context, srvOpts ...micro.Option) {
 		}
 	default:
 		// new service runtime
-		r = rs.NewRuntime()
+		r = newRuntime(ctx)
 		// NOTE: we consider source in default mode
 		// to be the canonical Go module import path
 		// if source is empty, we bail as this can
@@ -111,7 +160,10 @@ func runService(ctx *cli.Context, srvOpts ...micro.Option) {
 			fmt.Println(RunUsage)
 			return
 		}
-		exec = []string{"go", "run", source}
+		// the runtime resolves the command of prebuilt sources
+		if !prebuilt(source) {
+			exec = []string{"go", "run", source}
+		}
 	}
 
 	// start the local runtime
@@ -127,6 +179,100 @@ func runService(ctx *cli.Context, srvOpts ...micro.Option) {
 		Metadata: make(map[string]string),
 	}
 
+	// record the commit we're running
+	if commit := sourceCommit(source); len(commit) > 0 {
+		service.Metadata["commit"] = commit
+	}
+
+	// set the dependencies to wait for
+	if deps := ctx.StringSlice("dependencies"); len(deps) > 0 {
+		service.Metadata["dependencies"] = strings.Join(deps, ",")
+	}
+
+	// set the platform dependencies checked before starting
+	if deps := ctx.StringSlice("requires"); len(deps) > 0 {
+		if err := validateRequires(deps); err != nil {
+			fmt.Println(err)
+			return
+		}
+		service.Metadata["requires"] = strings.Join(deps, ",")
+	}
+
+	// only the names of secrets are passed to the runtime
+	specs := ctx.StringSlice("secret")
+	for _, spec := range specs {
+		if _, err := secrets.Parse(spec); err != nil {
+			fmt.Println(err)
+			return
+		}
+	}
+	if len(specs) > 0 {
+		service.Metadata["secrets"] = strings.Join(specs, ",")
+	}
+
+	// set the health probe used by the runtime
+	if p := ctx.String("probe"); len(p) > 0 {
+		service.Metadata["probe"] = p
+	}
+
+	// select the defaults injected into the service
+	var profileEnv []string
+	if p := ctx.String("profile"); len(p) > 0 {
+		if local {
+			if err := profile.Load(profile.Path); err != nil {
+				fmt.Println(err)
+				return
+			}
+			vars, err := profile.Env(p)
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
+			profileEnv = vars
+		}
+		service.Metadata["profile"] = p
+	}
+
+	// run on a schedule rather than continuously
+	if sched := ctx.String("schedule"); len(sched) > 0 {
+		if local {
+			fmt.Println("Scheduled services are run by the runtime service, start it with micro runtime")
+			return
+		}
+		if _, err := cron.Parse(sched); err != nil {
+			fmt.Println(err)
+			return
+		}
+		service.Metadata["schedule"] = sched
+	}
+
+	// a canary receives a share of the traffic
+	if c := ctx.String("canary"); len(c) > 0 && !local {
+		weight, err := parseWeight(c)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		service.Metadata["weight"] = strconv.Itoa(weight)
+		service.Metadata["canary"] = "true"
+	}
+
+	// set the resource limits
+	if err := setResources(service.Metadata, ctx.String("cpu"), ctx.String("memory")); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	// enforce the limits for local services
+	if local && !prebuilt(source) {
+		command, err := cgroup.Command(key(service), resourceLimits(service.Metadata), exec)
+		if err != nil {
+			fmt.Printf("Could not apply resource limits: %v\n", err)
+			return
+		}
+		exec = command
+	}
+
 	// default environment
 	environment := defaultEnv()
 	// add environment variable passed in via cli
@@ -138,6 +284,38 @@ func runService(ctx *cli.Context, srvOpts ...micro.Option) {
 		}
 	}
 
+	// the profile overrides the environment as it does in the runtime
+	environment = append(environment, profileEnv...)
+
+	// local services read their secrets directly
+	if local && len(specs) > 0 {
+		vars, err := secrets.Inject(key(service), specs)
+		if err != nil {
+			fmt.Printf("Could not inject secrets: %v\n", err)
+			return
+		}
+		environment = append(environment, vars...)
+	}
+
+	// prebuilt sources bypass the build
+	if local && prebuilt(source) {
+		limits := resourceLimits(service.Metadata)
+		command, err := prebuiltCommand(name, version, source, environment, limits)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		exec = command
+		// images are limited by docker
+		if !docker(source) {
+			exec, err = cgroup.Command(key(service), limits, command)
+			if err != nil {
+				fmt.Printf("Could not apply resource limits: %v\n", err)
+				return
+			}
+		}
+	}
+
 	// runtime based on environment we run the service in
 	// TODO: how will this work with runtime service
 	opts := []runtime.CreateOption{
@@ -146,6 +324,24 @@ func runService(ctx *cli.Context, srvOpts ...micro.Option) {
 		runtime.WithEnv(environment),
 	}
 
+	// the runtime service waits for dependencies itself
+	if local {
+		timeout := DependencyTimeout
+		if t := ctx.Duration("dependency_timeout"); t > 0 {
+			timeout = t
+		}
+
+		if err := waitForDependencies(service.Metadata, timeout); err != nil {
+			fmt.Println(err)
+			return
+		}
+
+		if err := newPreflights().preflight(service.Metadata, append(os.Environ(), environment...)); err != nil {
+			fmt.Println(err)
+			return
+		}
+	}
+
 	// run the service
 	if err := r.Create(service, opts...); err != nil {
 		fmt.Println(err)
@@ -160,6 +356,13 @@ func runService(ctx *cli.Context, srvOpts ...micro.Option) {
 		// wait for shutdown
 		<-shutdown
 
+		// stop the container before the docker cli is killed
+		if docker(source) {
+			if err := stopContainer(name, version); err != nil {
+				fmt.Println(err)
+			}
+		}
+
 		// delete service from runtime
 		if err := r.Delete(service); err != nil {
 			fmt.Println(err)
@@ -168,8 +371,43 @@ func runService(ctx *cli.Context, srvOpts ...micro.Option) {
 
 		if err := r.Stop(); err != nil {
 			fmt.Println(err)
-			return
 		}
+
+		// remove the resource limits once the processes have exited
+		if err := cgroup.Delete(key(service)); err != nil {
+			fmt.Printf("Failed to remove the resource limits of %s: %v\n", name, err)
+		}
+		// and any secret files
+		secrets.Remove(key(service))
+	}
+}
+
+// runManifest reconciles the runtime against the manifest
+func runManifest(ctx *cli.Context, file string) {
+	if ctx.Bool("local") {
+		fmt.Println("Manifests are run by the runtime service, start it with micro runtime")
+		return
+	}
+
+	m, err := readManifest(file)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	// add environment variable passed in via cli
+	environment := defaultEnv()
+	for _, evar := range ctx.StringSlice("env") {
+		for _, e := range strings.Split(evar, ",") {
+			if len(e) > 0 {
+				environment = append(environment, strings.TrimSpace(e))
+			}
+		}
+	}
+
+	if err := reconcile(newRuntime(ctx), m, environment); err != nil {
+		fmt.Println(err)
+		return
 	}
 }
 
@@ -187,22 +425,32 @@ func killService(ctx *cli.Context, srvOpts ...micro.Option) {
 		}
 	}
 
-	if len(name) == 0 {
-		fmt.Println(KillUsage)
-		return
-	}
-
 	var r runtime.Runtime
 	switch local {
 	case true:
 		r = *cmd.DefaultCmd.Options().Runtime
 	default:
-		r = rs.NewRuntime()
+		r = newRuntime(ctx)
+	}
+
+	if ctx.IsSet("selector") {
+		killSelected(ctx, r)
+		return
+	}
+
+	if len(name) == 0 {
+		fmt.Println(KillUsage)
+		return
 	}
 
 	service := &runtime.Service{
-		Name:    name,
-		Version: version,
+		Name:     name,
+		Version:  version,
+		Metadata: make(map[string]string),
+	}
+
+	if ctx.IsSet("grace") {
+		service.Metadata["grace"] = ctx.Duration("grace").String()
 	}
 
 	if err := r.Delete(service); err != nil {
@@ -211,6 +459,60 @@ func killService(ctx *cli.Context, srvOpts ...micro.Option) {
 	}
 }
 
+// killSelected kills the services with metadata matching the selector concurrently
+func killSelected(ctx *cli.Context, r runtime.Runtime) {
+	selector, err := parseSelector(ctx.String("selector"))
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	services, err := r.List()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	matched := make(map[string]*runtime.Service)
+	var items []string
+
+	for _, s := range services {
+		labels := map[string]string{"name": s.Name, "version": s.Version}
+		for k, v := range s.Metadata {
+			labels[k] = v
+		}
+		if !selected(labels, selector) {
+			continue
+		}
+		item := s.Name + " " + s.Version
+		matched[item] = &runtime.Service{
+			Name:     s.Name,
+			Version:  s.Version,
+			Metadata: make(map[string]string),
+		}
+		items = append(items, item)
+	}
+
+	if len(items) == 0 {
+		fmt.Println("No services match the selector")
+		return
+	}
+	sort.Strings(items)
+
+	summary := bulk.Run(items, ctx.Int("concurrency"), os.Stderr, func(item string) error {
+		service := matched[item]
+		if ctx.IsSet("grace") {
+			service.Metadata["grace"] = ctx.Duration("grace").String()
+		}
+		return r.Delete(service)
+	})
+
+	summary.Print(os.Stdout)
+	if len(summary.Failed) > 0 {
+		os.Exit(1)
+	}
+}
+
 func getService(ctx *cli.Context, srvOpts ...micro.Option) {
 	// get the args
 	name := ctx.String("name")
@@ -223,7 +525,7 @@ func getService(ctx *cli.Context, srvOpts ...micro.Option) {
 	case true:
 		r = *cmd.DefaultCmd.Options().Runtime
 	default:
-		r = rs.NewRuntime()
+		r = newRuntime(ctx)
 	}
 
 	var list bool
@@ -297,21 +599,138 @@ func getService(ctx *cli.Context, srvOpts ...micro.Option) {
 
 	sort.Slice(services, func(i, j int) bool { return services[i].Name < services[j].Name })
 
+	if ctx.Bool("show-secrets") {
+		redact.Show = true
+	}
+
+	if ctx.Bool("scheduled") {
+		printScheduled(services)
+		return
+	}
+
+	if ctx.Bool("stats") {
+		printStats(services)
+		return
+	}
+
 	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
-	fmt.Fprintln(writer, "NAME\tVERSION\tSOURCE\tSTATUS\tBUILD\tMETADATA")
+	fmt.Fprintln(writer, "NAME\tVERSION\tSOURCE\tCOMMIT\tSTATUS\tUPTIME\tRESTARTS\tLAST ERROR\tBUILD\tMETADATA")
 	for _, service := range services {
 		status := parse(service.Metadata["status"])
-		if status == "error" {
-			status = service.Metadata["error"]
+
+		// only show uptime for running services
+		uptime := "n/a"
+		if status == "running" {
+			uptime = formatUptime(service.Metadata["started"])
 		}
 
-		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\n",
+		// prefer the current error over the last one seen
+		lastError := service.Metadata["error"]
+		if len(lastError) == 0 {
+			lastError = service.Metadata["last_error"]
+		}
+
+		restarts := service.Metadata["restarts"]
+		if len(restarts) == 0 {
+			restarts = "0"
+		}
+
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
 			service.Name,
 			parse(service.Version),
 			parse(service.Source),
+			parse(service.Metadata["commit"]),
 			status,
+			uptime,
+			restarts,
+			parse(redact.String(lastError)),
 			parse(service.Metadata["build"]),
-			fmt.Sprintf("owner=%s,group=%s", parse(service.Metadata["owner"]), parse(service.Metadata["group"])))
+			fmt.Sprintf("owner=%s,group=%s,namespace=%s", parse(service.Metadata["owner"]), parse(service.Metadata["group"]), parse(service.Metadata["namespace"])))
+	}
+	writer.Flush()
+}
+
+// formatRun returns the local time of the unix run time
+func formatRun(t string) string {
+	v, err := strconv.ParseInt(t, 10, 64)
+	if err != nil || v == 0 {
+		return "n/a"
+	}
+	return time.Unix(v, 0).Format("2006-01-02 15:04:05")
+}
+
+// formatBytes returns the size in the largest whole unit e.g 12.5MiB
+func formatBytes(v string) string {
+	n, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return "n/a"
+	}
+	units := []string{"B", "KiB", "MiB", "GiB"}
+	i := 0
+	for ; n >= 1024 && i < len(units)-1; i++ {
+		n /= 1024
+	}
+	return strconv.FormatFloat(n, 'f', 1, 64) + units[i]
+}
+
+// printStats prints the resource usage of the services
+func printStats(services []*runtime.Service) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
+	fmt.Fprintln(writer, "NAME\tVERSION\tSTATUS\tCPU TIME\tRSS\tFDS")
+	for _, service := range services {
+		status := service.Metadata["status"]
+		if len(status) == 0 {
+			status = "n/a"
+		}
+
+		cpu := "n/a"
+		if v, err := strconv.ParseFloat(service.Metadata["cpu_time"], 64); err == nil {
+			cpu = time.Duration(v * float64(time.Second)).Round(time.Millisecond * 10).String()
+		}
+
+		fds := service.Metadata["fds"]
+		if len(fds) == 0 {
+			fds = "n/a"
+		}
+
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			service.Name,
+			service.Version,
+			status,
+			cpu,
+			formatBytes(service.Metadata["rss"]),
+			fds)
+	}
+	writer.Flush()
+}
+
+// printScheduled prints the scheduled services with their runs
+func printScheduled(services []*runtime.Service) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
+	fmt.Fprintln(writer, "NAME\tVERSION\tSCHEDULE\tSTATUS\tLAST RUN\tNEXT RUN\tEXIT STATUS")
+	for _, service := range services {
+		if len(service.Metadata["schedule"]) == 0 {
+			continue
+		}
+
+		status := service.Metadata["status"]
+		if len(status) == 0 {
+			status = "n/a"
+		}
+
+		exit := service.Metadata["exit_status"]
+		if len(exit) == 0 {
+			exit = "n/a"
+		}
+
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			service.Name,
+			service.Version,
+			service.Metadata["schedule"],
+			status,
+			formatRun(service.Metadata["last_run"]),
+			formatRun(service.Metadata["next_run"]),
+			exit)
 	}
 	writer.Flush()
 }
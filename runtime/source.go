@@ -0,0 +1,136 @@
+package runtime
+
+import (
+	"fmt"
+	"net/url"
+	"os/exec"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/micro/go-micro/v2/util/log"
+	"github.com/micro/micro/v2/runtime/artifact"
+	"github.com/micro/micro/v2/runtime/cgroup"
+)
+
+const (
+	// dockerScheme runs a prebuilt image e.g docker://org/image:tag
+	dockerScheme = "docker://"
+	// fileScheme runs a prebuilt binary e.g file:///path/to/binary
+	fileScheme = "file://"
+)
+
+// prebuilt returns true if the source is run without being built
+func prebuilt(source string) bool {
+	return docker(source) || strings.HasPrefix(source, fileScheme)
+}
+
+// sourceName returns the service name for a prebuilt source
+// e.g docker://org/image:tag is image and file:///bin/greeter is greeter
+func sourceName(source string) string {
+	name := path.Base(strings.TrimPrefix(strings.TrimPrefix(source, dockerScheme), fileScheme))
+	// strip the tag or digest of an image
+	if i := strings.IndexAny(name, ":@"); i > 0 {
+		name = name[:i]
+	}
+	return name
+}
+
+// docker returns true if the source is an image run with docker
+func docker(source string) bool {
+	return strings.HasPrefix(source, dockerScheme)
+}
+
+// containerName is the name of the container the image of the service is run in
+func containerName(name, version string) string {
+	return strings.NewReplacer(":", "-", "#", "-", "/", "-").Replace("micro-" + name + "-" + version)
+}
+
+// prebuiltCommand returns the command to run a prebuilt source bypassing the build.
+// Images are run with docker passing through the env vars of the service by name
+// so their values aren't visible in the process list, the resource limits are
+// applied to the container as limiting the docker cli wouldn't limit the service.
+func prebuiltCommand(name, version, source string, env []string, limits cgroup.Limits) ([]string, error) {
+	switch {
+	case strings.HasPrefix(source, fileScheme):
+		u, err := url.Parse(source)
+		if err != nil || len(u.Path) == 0 || len(u.Host) > 0 {
+			return nil, fmt.Errorf("invalid source %s, expected file:///path/to/binary", source)
+		}
+		return []string{u.Path}, nil
+	case docker(source):
+		image := strings.TrimPrefix(source, dockerScheme)
+		if len(image) == 0 {
+			return nil, fmt.Errorf("invalid source %s, expected docker://org/image:tag", source)
+		}
+
+		command := []string{"docker", "run", "--rm", "--network", "host", "--name", containerName(name, version)}
+		if limits.CPU > 0 {
+			command = append(command, "--cpus", strconv.FormatFloat(limits.CPU, 'f', -1, 64))
+		}
+		if limits.Memory > 0 {
+			command = append(command, "--memory", strconv.FormatInt(limits.Memory, 10))
+		}
+		for _, kv := range env {
+			if k := strings.SplitN(kv, "=", 2)[0]; len(k) > 0 {
+				command = append(command, "-e", k)
+			}
+		}
+
+		return append(command, image), nil
+	}
+
+	return nil, fmt.Errorf("source %s is not prebuilt", source)
+}
+
+// stopContainer stops and removes the container the image of the service is run in,
+// killing the docker cli which started it would leave the container running
+func stopContainer(name, version string) error {
+	container := containerName(name, version)
+
+	if out, err := exec.Command("docker", "stop", container).CombinedOutput(); err != nil && !noContainer(out) {
+		return fmt.Errorf("failed to stop container %s: %s", container, strings.TrimSpace(string(out)))
+	}
+	// containers run with --rm are removed once stopped
+	if out, err := exec.Command("docker", "rm", "--force", container).CombinedOutput(); err != nil && !noContainer(out) {
+		return fmt.Errorf("failed to remove container %s: %s", container, strings.TrimSpace(string(out)))
+	}
+
+	return nil
+}
+
+// noContainer returns true if the docker output is for a container which doesn't exist
+func noContainer(out []byte) bool {
+	return strings.Contains(strings.ToLower(string(out)), "no such container")
+}
+
+// isGoRun returns true if the command builds and runs the source with go run
+func isGoRun(command []string, source string) bool {
+	return len(command) == 3 && command[0] == "go" && command[1] == "run" && command[2] == source
+}
+
+// cachedCommand returns the command to run the artifact cached for the source.
+// If it's not cached yet it's built in the background for the next node to
+// run it and the command is returned unchanged.
+func (m *manager) cachedCommand(source string, command []string) []string {
+	path, err := m.artifacts.Get(source)
+	if err == nil {
+		return []string{path}
+	}
+
+	if err != artifact.ErrNotCached {
+		log.Logf("Failed to get the artifact of %s: %v", source, err)
+		return command
+	}
+
+	go func() {
+		digest, err := m.artifacts.Build(source)
+		if err != nil {
+			log.Debugf("Failed to cache the artifact of %s: %v", source, err)
+			return
+		}
+		log.Logf("Cached the artifact of %s as %s", source, digest)
+	}()
+
+	return command
+}
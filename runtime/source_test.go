@@ -0,0 +1,58 @@
+package runtime
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/micro/micro/v2/runtime/cgroup"
+)
+
+func TestPrebuiltCommand(t *testing.T) {
+	testData := []struct {
+		source  string
+		limits  cgroup.Limits
+		name    string
+		command []string
+		err     bool
+	}{
+		{"file:///usr/local/bin/greeter", cgroup.Limits{}, "greeter", []string{"/usr/local/bin/greeter"}, false},
+		{"docker://org/greeter:v1", cgroup.Limits{}, "greeter", []string{
+			"docker", "run", "--rm", "--network", "host", "--name", "micro-greeter-v1-2",
+			"-e", "MICRO_REGISTRY", "org/greeter:v1",
+		}, false},
+		{"docker://org/greeter:v1", cgroup.Limits{CPU: 0.5, Memory: 128 << 20}, "greeter", []string{
+			"docker", "run", "--rm", "--network", "host", "--name", "micro-greeter-v1-2",
+			"--cpus", "0.5", "--memory", "134217728", "-e", "MICRO_REGISTRY", "org/greeter:v1",
+		}, false},
+		{"file://relative/greeter", cgroup.Limits{}, "", nil, true},
+		{"docker://", cgroup.Limits{}, "", nil, true},
+	}
+
+	for _, d := range testData {
+		if !prebuilt(d.source) {
+			t.Fatalf("Expected %s to be prebuilt", d.source)
+		}
+
+		command, err := prebuiltCommand(sourceName(d.source), "v1#2", d.source, []string{"MICRO_REGISTRY=mdns"}, d.limits)
+		if d.err {
+			if err == nil {
+				t.Fatalf("Expected error for %s", d.source)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if n := sourceName(d.source); n != d.name {
+			t.Fatalf("Expected name %s got %s", d.name, n)
+		}
+		if !reflect.DeepEqual(command, d.command) {
+			t.Fatalf("Expected %v got %v", d.command, command)
+		}
+	}
+
+	if prebuilt("github.com/micro/services/greeter") {
+		t.Fatal("Expected a go source not to be prebuilt")
+	}
+}
@@ -0,0 +1,201 @@
+// Package state is a store persisting the desired state of the runtime to
+// disk so the services are relaunched when the runtime restarts
+package state
+
+import (
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/micro/go-micro/v2/store"
+)
+
+var (
+	// Dir is where the state is persisted
+	Dir = filepath.Join(os.TempDir(), "micro", "runtime")
+)
+
+func init() {
+	if home, err := os.UserHomeDir(); err == nil {
+		Dir = filepath.Join(home, ".micro", "runtime")
+	}
+}
+
+type fileStore struct {
+	sync.RWMutex
+	opts store.Options
+	dir  string
+}
+
+// NewStore returns a store which writes each record to a file in the dir
+func NewStore(dir string) store.Store {
+	return &fileStore{dir: dir}
+}
+
+// expiresSuffix is appended to the path of a record to give the file of its expiry
+const expiresSuffix = ".expires"
+
+// path of the file for the key, the key is escaped so it's a single file
+func (f *fileStore) path(key string) string {
+	return filepath.Join(f.dir, url.PathEscape(key))
+}
+
+// get the record of the key deleting it if it's expired
+func (f *fileStore) get(key string) (*store.Record, error) {
+	b, err := ioutil.ReadFile(f.path(key))
+	if os.IsNotExist(err) {
+		return nil, store.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	r := &store.Record{
+		Key:   key,
+		Value: b,
+	}
+
+	if v, err := ioutil.ReadFile(f.path(key) + expiresSuffix); err == nil {
+		expires, err := strconv.ParseInt(string(v), 10, 64)
+		if err == nil {
+			r.Expiry = time.Until(time.Unix(0, expires))
+			if r.Expiry <= 0 {
+				os.Remove(f.path(key))
+				os.Remove(f.path(key) + expiresSuffix)
+				return nil, store.ErrNotFound
+			}
+		}
+	}
+
+	return r, nil
+}
+
+func (f *fileStore) Init(opts ...store.Option) error {
+	for _, o := range opts {
+		o(&f.opts)
+	}
+	return nil
+}
+
+func (f *fileStore) Options() store.Options {
+	return f.opts
+}
+
+func (f *fileStore) List() ([]*store.Record, error) {
+	f.RLock()
+	defer f.RUnlock()
+
+	files, err := ioutil.ReadDir(f.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	for _, file := range files {
+		// skip partially written records and expiries
+		if file.IsDir() || strings.HasSuffix(file.Name(), ".tmp") || strings.HasSuffix(file.Name(), expiresSuffix) {
+			continue
+		}
+		key, err := url.PathUnescape(file.Name())
+		if err != nil {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	records := make([]*store.Record, 0, len(keys))
+	for _, key := range keys {
+		r, err := f.get(key)
+		if err != nil {
+			continue
+		}
+		records = append(records, r)
+	}
+
+	return records, nil
+}
+
+func (f *fileStore) Read(key string, opts ...store.ReadOption) ([]*store.Record, error) {
+	var options store.ReadOptions
+	for _, o := range opts {
+		o(&options)
+	}
+
+	if options.Prefix {
+		all, err := f.List()
+		if err != nil {
+			return nil, err
+		}
+		var records []*store.Record
+		for _, r := range all {
+			if strings.HasPrefix(r.Key, key) {
+				records = append(records, r)
+			}
+		}
+		return records, nil
+	}
+
+	f.RLock()
+	defer f.RUnlock()
+
+	r, err := f.get(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*store.Record{r}, nil
+}
+
+func (f *fileStore) Write(r *store.Record) error {
+	f.Lock()
+	defer f.Unlock()
+
+	if err := os.MkdirAll(f.dir, 0700); err != nil {
+		return err
+	}
+
+	// the expiry is written first so the record is never read without it
+	expires := f.path(r.Key) + expiresSuffix
+	if r.Expiry > 0 {
+		v := strconv.FormatInt(time.Now().Add(r.Expiry).UnixNano(), 10)
+		if err := ioutil.WriteFile(expires, []byte(v), 0600); err != nil {
+			return err
+		}
+	} else if err := os.Remove(expires); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	// write then rename so a record is never partially read
+	tmp := f.path(r.Key) + ".tmp"
+	if err := ioutil.WriteFile(tmp, r.Value, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, f.path(r.Key))
+}
+
+func (f *fileStore) Delete(key string) error {
+	f.Lock()
+	defer f.Unlock()
+
+	if err := os.Remove(f.path(key)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := os.Remove(f.path(key) + expiresSuffix); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (f *fileStore) String() string {
+	return "file"
+}
@@ -0,0 +1,102 @@
+package state
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/micro/go-micro/v2/store"
+)
+
+func TestStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "state")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	s := NewStore(dir)
+
+	for _, k := range []string{"greeter:latest", "history/greeter", "auth:v1/2"} {
+		if err := s.Write(&store.Record{Key: k, Value: []byte(k)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// a restarted runtime reads the same state
+	s = NewStore(dir)
+
+	recs, err := s.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recs) != 3 || recs[0].Key != "auth:v1/2" {
+		t.Fatalf("Unexpected records %v", recs)
+	}
+
+	recs, err = s.Read("history/", store.ReadPrefix())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recs) != 1 || string(recs[0].Value) != "history/greeter" {
+		t.Fatalf("Unexpected records %v", recs)
+	}
+
+	if err := s.Delete("greeter:latest"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Read("greeter:latest"); err != store.ErrNotFound {
+		t.Fatalf("Expected %v got %v", store.ErrNotFound, err)
+	}
+}
+
+func TestExpiry(t *testing.T) {
+	dir, err := ioutil.TempDir("", "state")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	s := NewStore(dir)
+
+	if err := s.Write(&store.Record{Key: "pids/host", Value: []byte("{}"), Expiry: time.Millisecond * 50}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Write(&store.Record{Key: "greeter:latest", Value: []byte("greeter")}); err != nil {
+		t.Fatal(err)
+	}
+
+	recs, err := s.Read("pids/host")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if recs[0].Expiry <= 0 || recs[0].Expiry > time.Millisecond*50 {
+		t.Fatalf("Unexpected expiry %v", recs[0].Expiry)
+	}
+
+	time.Sleep(time.Millisecond * 100)
+
+	if _, err := s.Read("pids/host"); err != store.ErrNotFound {
+		t.Fatalf("Expected %v got %v", store.ErrNotFound, err)
+	}
+	recs, err = s.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recs) != 1 || recs[0].Key != "greeter:latest" {
+		t.Fatalf("Unexpected records %v", recs)
+	}
+
+	// the expiry is cleared when the record is rewritten without one
+	if err := s.Write(&store.Record{Key: "pids/host", Value: []byte("{}"), Expiry: time.Millisecond * 50}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Write(&store.Record{Key: "pids/host", Value: []byte("{}")}); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(time.Millisecond * 100)
+	if _, err := s.Read("pids/host"); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,301 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/micro/cli/v2"
+	"github.com/micro/go-micro/v2"
+	"github.com/micro/go-micro/v2/client"
+	"github.com/micro/go-micro/v2/config/cmd"
+	proto "github.com/micro/go-micro/v2/debug/service/proto"
+	"github.com/micro/go-micro/v2/runtime"
+)
+
+var (
+	// UpdateUsage message for the update command
+	UpdateUsage = "Required usage: micro update --version v2 [--strategy blue-green] github.com/my/service"
+	// HealthTimeout is how long a blue-green update waits for the new version to be healthy
+	HealthTimeout = time.Minute * 2
+	// SoakTime is how long the old version is kept once traffic is switched to the new one
+	SoakTime = time.Minute * 5
+)
+
+// versionReady returns nil if every registered node of the service version is healthy
+func versionReady(name, version string) error {
+	reg := *cmd.DefaultCmd.Options().Registry
+	c := *cmd.DefaultCmd.Options().Client
+
+	services, err := reg.ListServices()
+	if err != nil {
+		return err
+	}
+
+	var nodes int
+
+	for _, srv := range services {
+		if srv.Name != name && !strings.HasSuffix(srv.Name, "."+name) {
+			continue
+		}
+
+		records, err := reg.GetService(srv.Name)
+		if err != nil {
+			return err
+		}
+
+		req := c.NewRequest(srv.Name, "Debug.Health", &proto.HealthRequest{})
+
+		for _, record := range records {
+			if record.Version != version {
+				continue
+			}
+			for _, node := range record.Nodes {
+				rsp := &proto.HealthResponse{}
+				if err := c.Call(context.Background(), req, rsp, client.WithAddress(node.Address)); err != nil {
+					return err
+				}
+				if rsp.Status != "ok" {
+					return fmt.Errorf("node %s is %s", node.Id, rsp.Status)
+				}
+				nodes++
+			}
+		}
+	}
+
+	if nodes == 0 {
+		return errors.New("not registered")
+	}
+
+	return nil
+}
+
+// healthy checks the service with its probe, by default every node of the version must be healthy
+func healthy(s *runtime.Service) error {
+	switch p := s.Metadata["probe"]; {
+	case p == "none":
+		return nil
+	case len(p) == 0, p == "rpc":
+		return versionReady(s.Name, s.Version)
+	default:
+		_, err := probe(s, "")
+		return err
+	}
+}
+
+// waitHealthy waits until the service is running and healthy or the timeout passes
+func waitHealthy(r runtime.Runtime, s *runtime.Service, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+
+	for {
+		err := errors.New("not running")
+
+		services, rerr := r.Read(runtime.ReadService(s.Name), runtime.ReadVersion(s.Version))
+		switch {
+		case rerr != nil:
+			err = rerr
+		case len(services) == 0:
+			err = errors.New("not found")
+		case services[0].Metadata["status"] == "running":
+			err = healthy(services[0])
+		}
+
+		if err == nil {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return err
+		}
+
+		time.Sleep(time.Second)
+	}
+}
+
+// clearWeights removes the weights of the services so traffic is split evenly again
+func clearWeights(r runtime.Runtime, services []*runtime.Service) {
+	for _, s := range services {
+		if err := setWeight(r, s, 0, false); err != nil {
+			fmt.Printf("Failed to reset the weight of %s %s: %v\n", s.Name, s.Version, err)
+		}
+	}
+}
+
+// updateService updates a service using the deployment strategy
+func updateService(ctx *cli.Context, srvOpts ...micro.Option) {
+	switch s := ctx.String("strategy"); s {
+	case "", "recreate":
+		recreateService(ctx)
+	case "blue-green":
+		blueGreenService(ctx, srvOpts...)
+	default:
+		fmt.Printf("Unknown strategy %s, expected recreate or blue-green\n", s)
+	}
+}
+
+// recreateService updates the running version in place, restarting it
+func recreateService(ctx *cli.Context) {
+	name := ctx.String("name")
+	version := ctx.String("version")
+	source := ctx.String("source")
+
+	if v := ctx.Args().Get(0); len(v) > 0 && v != "service" {
+		source = v
+	}
+	if len(name) == 0 && len(source) > 0 {
+		name = filepath.Base(source)
+	}
+	if len(name) == 0 {
+		fmt.Println(UpdateUsage)
+		return
+	}
+
+	r := newRuntime(ctx)
+
+	services, err := r.Read(runtime.ReadService(name), runtime.ReadVersion(version))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	if len(services) == 0 {
+		fmt.Printf("No running version %s of %s to update, use micro run\n", version, name)
+		return
+	}
+
+	s := services[0]
+	srv := &runtime.Service{
+		Name:     s.Name,
+		Version:  s.Version,
+		Source:   s.Source,
+		Metadata: withoutKeys(s.Metadata, statusKeys...),
+	}
+	if len(source) > 0 {
+		srv.Source = source
+	}
+
+	if err := r.Update(srv); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Printf("Updated %s %s\n", srv.Name, srv.Version)
+}
+
+// blueGreenService runs the new version alongside the running ones, switches
+// all traffic to it once it's healthy and removes the old versions after the soak time
+func blueGreenService(ctx *cli.Context, srvOpts ...micro.Option) {
+	if ctx.Bool("local") {
+		fmt.Println("Blue-green updates are run by the runtime service, start it with micro runtime")
+		return
+	}
+
+	name := ctx.String("name")
+	version := ctx.String("version")
+	source := ctx.String("source")
+
+	if v := ctx.Args().Get(0); len(v) > 0 && v != "service" {
+		source = v
+	}
+	if len(name) == 0 && len(source) > 0 {
+		name = filepath.Base(source)
+	}
+	if len(name) == 0 || len(source) == 0 || len(version) == 0 || version == "latest" {
+		fmt.Println(UpdateUsage)
+		return
+	}
+
+	timeout := HealthTimeout
+	if t := ctx.Duration("health_timeout"); t > 0 {
+		timeout = t
+	}
+	soak := SoakTime
+	if ctx.IsSet("soak") {
+		soak = ctx.Duration("soak")
+	}
+
+	r := newRuntime(ctx)
+
+	services, err := r.Read(runtime.ReadService(name))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	var blue []*runtime.Service
+
+	for _, s := range services {
+		if s.Version == version {
+			fmt.Printf("Version %s of %s is already running, use micro promote to switch to it\n", version, name)
+			return
+		}
+		blue = append(blue, s)
+	}
+
+	if len(blue) == 0 {
+		fmt.Printf("No running version of %s to update, use micro run\n", name)
+		return
+	}
+
+	// keep all traffic on the running versions until the new one is healthy
+	if err := splitWeight(r, blue, 0); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	runService(ctx, srvOpts...)
+
+	services, err = r.Read(runtime.ReadService(name), runtime.ReadVersion(version))
+	if err != nil || len(services) == 0 {
+		clearWeights(r, blue)
+		return
+	}
+	green := services[0]
+
+	fmt.Printf("Waiting up to %v for %s %s to be healthy\n", timeout, name, version)
+
+	if err := waitHealthy(r, green, timeout); err != nil {
+		fmt.Printf("Version %s of %s is not healthy: %v, removing it\n", version, name, err)
+		if err := r.Delete(green); err != nil {
+			fmt.Println(err)
+		}
+		clearWeights(r, blue)
+		return
+	}
+
+	// switch the traffic, versions without a weight get none
+	if err := setWeight(r, green, 100, false); err != nil {
+		fmt.Println(err)
+		return
+	}
+	clearWeights(r, blue)
+
+	fmt.Printf("Switched traffic of %s to %s, removing the old versions in %v\n", name, version, soak)
+
+	shutdown := make(chan os.Signal, 1)
+	signal.Notify(shutdown, syscall.SIGTERM, syscall.SIGINT, syscall.SIGQUIT)
+	defer signal.Stop(shutdown)
+
+	select {
+	case <-time.After(soak):
+	case <-shutdown:
+		fmt.Println("Interrupted, the old versions are left running without traffic, remove them with micro kill")
+		return
+	}
+
+	for _, s := range blue {
+		if err := r.Delete(s); err != nil {
+			fmt.Printf("Failed to kill %s %s: %v\n", s.Name, s.Version, err)
+			return
+		}
+	}
+
+	// the new version is the only one left so no longer needs a weight
+	clearWeights(r, []*runtime.Service{green})
+
+	fmt.Printf("Updated %s to %s\n", name, version)
+}
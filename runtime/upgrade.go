@@ -0,0 +1,186 @@
+package runtime
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/micro/cli/v2"
+	"github.com/micro/go-micro/v2"
+	"github.com/micro/go-micro/v2/runtime"
+)
+
+var (
+	// UpgradeUsage message for the upgrade command
+	UpgradeUsage = "Required usage: micro upgrade --version v2.1.0 [--services registry,store]"
+	// PlatformServices are upgraded in order so the services others depend on go first
+	PlatformServices = []string{"registry", "store", "config", "runtime", "api", "web", "debug"}
+)
+
+// upgradeSource returns the source with its tag set to the version
+// e.g micro/micro:v2.0.0 becomes micro/micro:v2.1.0
+func upgradeSource(source, version string) string {
+	if i := strings.LastIndex(source, ":"); i > strings.LastIndex(source, "/") {
+		source = source[:i]
+	}
+	return source + ":" + version
+}
+
+// platformService returns the running platform service, its name may be prefixed by a namespace
+func platformService(services []*runtime.Service, name string) *runtime.Service {
+	for _, s := range services {
+		if s.Name == name || strings.HasSuffix(s.Name, "."+name) {
+			return s
+		}
+	}
+	return nil
+}
+
+// restartedSince returns true if the service was started after the time,
+// services which don't report when they started are assumed to have been
+func restartedSince(s *runtime.Service, t time.Time) bool {
+	started, err := strconv.ParseInt(s.Metadata["started"], 10, 64)
+	if err != nil || started == 0 {
+		return true
+	}
+	return started >= t.Unix()
+}
+
+// waitUpgraded waits until the platform service has restarted and is healthy
+func waitUpgraded(r runtime.Runtime, s *runtime.Service, name string, since time.Time, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+
+	for {
+		err := errors.New("not restarted")
+
+		// the runtime may itself be restarting so errors are retried
+		services, rerr := r.Read(runtime.ReadService(s.Name), runtime.ReadVersion(s.Version), runtime.ReadType("runtime"))
+		switch {
+		case rerr != nil:
+			err = rerr
+		case len(services) == 0:
+			err = errors.New("not found")
+		case services[0].Metadata["status"] == "error":
+			err = errors.New(services[0].Metadata["error"])
+		case services[0].Metadata["status"] == "running" && restartedSince(services[0], since):
+			err = ready("go.micro." + name)
+		}
+
+		if err == nil {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return err
+		}
+
+		time.Sleep(time.Second)
+	}
+}
+
+// upgradeStep updates the platform service to the source waiting for it to be healthy
+func upgradeStep(r runtime.Runtime, current *runtime.Service, name, source, release string, timeout time.Duration) error {
+	srv := &runtime.Service{
+		Name:     current.Name,
+		Version:  current.Version,
+		Source:   source,
+		Metadata: withoutKeys(current.Metadata, statusKeys...),
+	}
+	// the build changing restarts the service
+	srv.Metadata["build"] = strconv.FormatInt(time.Now().Unix(), 10)
+	if len(release) > 0 {
+		srv.Metadata["release"] = release
+	} else {
+		delete(srv.Metadata, "release")
+	}
+
+	since := time.Now()
+
+	if err := r.Update(srv); err != nil {
+		return err
+	}
+
+	return waitUpgraded(r, srv, name, since, timeout)
+}
+
+// upgradePlatform upgrades the platform services one at a time in dependency order,
+// a service which fails its health check is rolled back and the upgrade stopped
+func upgradePlatform(ctx *cli.Context, srvOpts ...micro.Option) {
+	version := ctx.String("version")
+	if len(version) == 0 || version == "latest" {
+		fmt.Println(UpgradeUsage)
+		return
+	}
+
+	timeout := HealthTimeout
+	if t := ctx.Duration("health_timeout"); t > 0 {
+		timeout = t
+	}
+
+	names := PlatformServices
+	if s := ctx.StringSlice("services"); len(s) > 0 {
+		selected := make(map[string]bool)
+		for _, v := range s {
+			for _, name := range strings.Split(v, ",") {
+				selected[strings.TrimSpace(name)] = true
+			}
+		}
+		// keep the dependency order
+		names = nil
+		for _, name := range PlatformServices {
+			if selected[name] {
+				names = append(names, name)
+				delete(selected, name)
+			}
+		}
+		for name := range selected {
+			fmt.Printf("Unknown platform service %s, expected one of %s\n", name, strings.Join(PlatformServices, ", "))
+			return
+		}
+	}
+
+	r := newRuntime(ctx)
+
+	services, err := r.Read(runtime.ReadType("runtime"))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	for i, name := range names {
+		current := platformService(services, name)
+		if current == nil {
+			fmt.Printf("[%d/%d] Skipping %s, it's not running\n", i+1, len(names), name)
+			continue
+		}
+
+		source := upgradeSource(current.Source, version)
+		if len(ctx.String("source")) > 0 {
+			source = strings.Replace(ctx.String("source"), "{version}", version, -1)
+		}
+		if source == current.Source {
+			fmt.Printf("[%d/%d] Skipping %s, it's already running %s\n", i+1, len(names), name, source)
+			continue
+		}
+
+		fmt.Printf("[%d/%d] Upgrading %s from %s to %s\n", i+1, len(names), name, current.Source, source)
+
+		if err := upgradeStep(r, current, name, source, version, timeout); err != nil {
+			fmt.Printf("[%d/%d] Upgrading %s failed: %v, rolling back to %s\n", i+1, len(names), name, err, current.Source)
+
+			if err := upgradeStep(r, current, name, current.Source, current.Metadata["release"], timeout); err != nil {
+				fmt.Printf("[%d/%d] Rolling back %s failed: %v\n", i+1, len(names), name, err)
+			} else {
+				fmt.Printf("[%d/%d] Rolled back %s\n", i+1, len(names), name)
+			}
+
+			fmt.Println("Upgrade stopped, the services upgraded before it are left on the new version")
+			return
+		}
+
+		fmt.Printf("[%d/%d] Upgraded %s\n", i+1, len(names), name)
+	}
+
+	fmt.Printf("Upgraded the platform to %s\n", version)
+}
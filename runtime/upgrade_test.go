@@ -0,0 +1,23 @@
+package runtime
+
+import (
+	"testing"
+)
+
+func TestUpgradeSource(t *testing.T) {
+	testData := []struct {
+		source string
+		expect string
+	}{
+		{"micro/micro:v2.0.0", "micro/micro:v2.1.0"},
+		{"micro/micro", "micro/micro:v2.1.0"},
+		{"localhost:5000/micro/micro", "localhost:5000/micro/micro:v2.1.0"},
+		{"localhost:5000/micro/micro:v2.0.0", "localhost:5000/micro/micro:v2.1.0"},
+	}
+
+	for _, d := range testData {
+		if v := upgradeSource(d.source, "v2.1.0"); v != d.expect {
+			t.Fatalf("Expected %s to upgrade to %s got %s", d.source, d.expect, v)
+		}
+	}
+}
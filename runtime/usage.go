@@ -0,0 +1,49 @@
+package runtime
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/micro/go-micro/v2/runtime"
+	"github.com/micro/micro/v2/runtime/process"
+)
+
+// sampleUsage reads the resource usage of the processes of the services run
+// locally from /proc. It's sampled on reconcile so reads are served from memory.
+func (m *manager) sampleUsage(procs map[string][]int) {
+	usage := make(map[string]*process.Stats, len(procs))
+	for instance, pids := range procs {
+		// skip the processes of other runtimes
+		k := strings.TrimPrefix(instance, m.id+"/")
+		if k == instance {
+			continue
+		}
+		stats, err := process.Usage(pids)
+		if err != nil {
+			continue
+		}
+		usage[k] = stats
+	}
+
+	m.usageMtx.Lock()
+	m.usageStats = usage
+	m.usageMtx.Unlock()
+}
+
+// usage returns the resource usage of the services sampled on the last reconcile
+func (m *manager) usage() map[string]*process.Stats {
+	m.usageMtx.RLock()
+	defer m.usageMtx.RUnlock()
+	return m.usageStats
+}
+
+// setUsage adds the resource usage of the service to the metadata read
+func setUsage(s *runtime.Service, usage map[string]*process.Stats) {
+	stats, ok := usage[key(s)]
+	if !ok {
+		return
+	}
+	s.Metadata["cpu_time"] = strconv.FormatFloat(stats.CPU.Seconds(), 'f', 2, 64)
+	s.Metadata["rss"] = strconv.FormatUint(stats.RSS, 10)
+	s.Metadata["fds"] = strconv.Itoa(stats.FDs)
+}
@@ -0,0 +1,68 @@
+package store
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/micro/go-micro/v2/store"
+	"github.com/micro/go-micro/v2/util/log"
+	"github.com/micro/micro/v2/store/handler"
+	"github.com/micro/micro/v2/store/snapshot"
+)
+
+var (
+	// SnapshotInterval is how often the stores are snapshotted to the snapshot target
+	SnapshotInterval = time.Hour
+)
+
+// snapshotStores saves a snapshot of the records of every store to the target
+func snapshotStores(h *handler.Store, target snapshot.Target) (int, error) {
+	b := bytes.NewBuffer(nil)
+	w := snapshot.NewWriter(b)
+
+	var n int
+
+	err := h.Each(func(namespace, prefix string, st store.Store) error {
+		records, err := st.List()
+		if err != nil {
+			return err
+		}
+		for _, r := range records {
+			if err := w.Write(namespace, prefix, r); err != nil {
+				return err
+			}
+			n++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if err := w.Close(); err != nil {
+		return 0, err
+	}
+
+	name := "store-" + time.Now().UTC().Format("20060102-150405") + ".jsonl.gz"
+	return n, target.Save(name, b.Bytes())
+}
+
+// scheduleSnapshots snapshots the stores every interval until exit is closed
+func scheduleSnapshots(h *handler.Store, target snapshot.Target, interval time.Duration, exit chan bool) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-exit:
+			return
+		case <-t.C:
+			n, err := snapshotStores(h, target)
+			if err != nil {
+				log.Logf("Failed to snapshot the store to %s: %v", target, err)
+				continue
+			}
+			log.Debugf("Snapshotted %d records to %s", n, target)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+// Package bench benchmarks the writes, reads and deletes of a store backend
+// so the backends can be compared before choosing one
+package bench
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/micro/go-micro/v2/store"
+	"github.com/micro/micro/v2/internal/bulk"
+)
+
+var (
+	// Keys is the default number of keys written and read
+	Keys = 1000
+	// ValueSize is the default size in bytes of the values written
+	ValueSize = 1024
+)
+
+// Options of a benchmark
+type Options struct {
+	// Keys written, read and deleted
+	Keys int
+	// ValueSize is the size in bytes of the values written
+	ValueSize int
+	// Concurrency is the number of operations run at once
+	Concurrency int
+	// Prefix of the keys written
+	Prefix string
+	// Keep the keys written rather than deleting them
+	Keep bool
+}
+
+// Result of benchmarking an operation
+type Result struct {
+	// Op benchmarked e.g write
+	Op string
+	// Ops run and how many failed
+	Ops    int
+	Errors int
+	// Err is the first error
+	Err error
+	// Bytes written or read
+	Bytes int64
+	// Duration of all the ops
+	Duration time.Duration
+	// Latencies of the ops sorted from fastest
+	Latencies []time.Duration
+}
+
+// Throughput returns the ops run per second
+func (r *Result) Throughput() float64 {
+	if r.Duration <= 0 {
+		return 0
+	}
+	return float64(r.Ops) / r.Duration.Seconds()
+}
+
+// Percentile returns the latency the percentage of the ops completed within e.g 99
+func (r *Result) Percentile(p float64) time.Duration {
+	if len(r.Latencies) == 0 {
+		return 0
+	}
+	i := int(math.Ceil(p/100*float64(len(r.Latencies)))) - 1
+	if i < 0 {
+		i = 0
+	}
+	if i >= len(r.Latencies) {
+		i = len(r.Latencies) - 1
+	}
+	return r.Latencies[i]
+}
+
+// Run writes, reads and deletes the keys returning the result of each op
+func Run(st store.Store, opts Options) ([]*Result, error) {
+	if opts.Keys <= 0 {
+		opts.Keys = Keys
+	}
+	if opts.ValueSize <= 0 {
+		opts.ValueSize = ValueSize
+	}
+
+	value := make([]byte, opts.ValueSize)
+	if _, err := rand.Read(value); err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, opts.Keys)
+	index := make(map[string]int, opts.Keys)
+	for i := range keys {
+		keys[i] = opts.Prefix + strconv.Itoa(i)
+		index[keys[i]] = i
+	}
+
+	// run the op for every key recording its latency
+	run := func(op string, size int, fn func(key string) error) *Result {
+		latencies := make([]time.Duration, len(keys))
+
+		start := time.Now()
+		summary := bulk.Run(keys, opts.Concurrency, nil, func(key string) error {
+			t := time.Now()
+			err := fn(key)
+			latencies[index[key]] = time.Since(t)
+			return err
+		})
+
+		r := &Result{
+			Op:        op,
+			Ops:       summary.Total,
+			Errors:    len(summary.Failed),
+			Bytes:     int64(summary.Succeeded) * int64(size),
+			Duration:  time.Since(start),
+			Latencies: latencies,
+		}
+		if len(summary.Failed) > 0 {
+			r.Err = summary.Failed[0].Err
+		}
+		sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+		return r
+	}
+
+	results := []*Result{
+		run("write", len(value), func(key string) error {
+			return st.Write(&store.Record{Key: key, Value: value})
+		}),
+		run("read", len(value), func(key string) error {
+			recs, err := st.Read(key)
+			if err != nil {
+				return err
+			}
+			if len(recs) == 0 || !bytes.Equal(recs[0].Value, value) {
+				return fmt.Errorf("read back a different value")
+			}
+			return nil
+		}),
+	}
+
+	if !opts.Keep {
+		results = append(results, run("delete", 0, st.Delete))
+	}
+
+	return results, nil
+}
+
+// Print writes the throughput and latency percentiles of the results as a table
+func Print(w io.Writer, results []*Result) {
+	writer := tabwriter.NewWriter(w, 0, 8, 1, '\t', tabwriter.AlignRight)
+	fmt.Fprintln(writer, "OP\tOPS\tERRORS\tOPS/S\tMB/S\tP50\tP90\tP99\tMAX")
+	for _, r := range results {
+		mbs := 0.0
+		if r.Duration > 0 {
+			mbs = float64(r.Bytes) / (1 << 20) / r.Duration.Seconds()
+		}
+		fmt.Fprintf(writer, "%s\t%d\t%d\t%.0f\t%.2f\t%v\t%v\t%v\t%v\n",
+			r.Op, r.Ops, r.Errors, r.Throughput(), mbs,
+			round(r.Percentile(50)), round(r.Percentile(90)), round(r.Percentile(99)), round(r.Percentile(100)))
+	}
+	writer.Flush()
+
+	for _, r := range results {
+		if r.Err != nil {
+			fmt.Fprintf(w, "%s failed %d times, first error: %v\n", r.Op, r.Errors, r.Err)
+		}
+	}
+}
+
+// round the latency so it's readable
+func round(d time.Duration) time.Duration {
+	switch {
+	case d > time.Second:
+		return d.Round(time.Millisecond)
+	case d > time.Millisecond:
+		return d.Round(10 * time.Microsecond)
+	}
+	return d.Round(time.Microsecond / 10)
+}
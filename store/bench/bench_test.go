@@ -0,0 +1,59 @@
+package bench
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/micro/go-micro/v2/store/memory"
+)
+
+func TestRun(t *testing.T) {
+	st := memory.NewStore()
+
+	results, err := Run(st, Options{Keys: 50, ValueSize: 128, Concurrency: 4, Prefix: "bench/"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected write, read and delete results got %d", len(results))
+	}
+	for _, r := range results {
+		if r.Ops != 50 || r.Errors != 0 {
+			t.Fatalf("expected 50 %s ops without errors got %d and %d: %v", r.Op, r.Ops, r.Errors, r.Err)
+		}
+	}
+	if results[0].Bytes != 50*128 {
+		t.Fatalf("expected %d bytes written got %d", 50*128, results[0].Bytes)
+	}
+
+	if recs, _ := st.List(); len(recs) != 0 {
+		t.Fatalf("expected the keys to be deleted got %d", len(recs))
+	}
+
+	var buf bytes.Buffer
+	Print(&buf, results)
+	if !strings.Contains(buf.String(), "P99") || strings.Count(buf.String(), "\n") != 4 {
+		t.Fatalf("unexpected output %s", buf.String())
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	r := &Result{}
+	for i := 1; i <= 100; i++ {
+		r.Latencies = append(r.Latencies, time.Duration(i)*time.Millisecond)
+	}
+
+	testData := map[float64]time.Duration{
+		50:  50 * time.Millisecond,
+		99:  99 * time.Millisecond,
+		100: 100 * time.Millisecond,
+		0:   time.Millisecond,
+	}
+	for p, d := range testData {
+		if v := r.Percentile(p); v != d {
+			t.Fatalf("expected p%v of %v got %v", p, d, v)
+		}
+	}
+}
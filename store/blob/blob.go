@@ -0,0 +1,164 @@
+// Package blob is a store backed by s3 compatible object storage for
+// artifacts and file-like values too large for the other backends
+package blob
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/micro/go-micro/v2/store"
+	"github.com/micro/micro/v2/internal/s3"
+)
+
+const (
+	// expiresKey is the object metadata holding the unix time the record expires
+	expiresKey = "expires"
+)
+
+type blobStore struct {
+	client  *s3.Client
+	options store.Options
+}
+
+// NewStore returns a store of the objects of the bucket of the url e.g
+// s3://bucket/prefix?region=eu-west-1, the namespace and prefix of the
+// store are prepended to the keys of the objects
+func NewStore(address string, opts ...store.Option) (store.Store, error) {
+	u, err := url.Parse(address)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := s3.New(u)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &blobStore{client: client}
+	for _, o := range opts {
+		o(&s.options)
+	}
+
+	return s, nil
+}
+
+func (s *blobStore) Init(opts ...store.Option) error {
+	for _, o := range opts {
+		o(&s.options)
+	}
+	return nil
+}
+
+func (s *blobStore) Options() store.Options {
+	return s.options
+}
+
+// object returns the object key of the record key
+func (s *blobStore) object(key string) string {
+	var parts []string
+	for _, p := range []string{s.options.Namespace, s.options.Prefix} {
+		if len(p) > 0 {
+			parts = append(parts, p)
+		}
+	}
+	return s.client.Key(strings.Join(append(parts, key), "/"))
+}
+
+// key returns the record key of the object key
+func (s *blobStore) key(object string) string {
+	return strings.TrimPrefix(object, s.object(""))
+}
+
+// get the record of the object deleting it if it's expired
+func (s *blobStore) get(object string) (*store.Record, error) {
+	b, md, err := s.client.Get(object)
+	if err == s3.ErrNotFound {
+		return nil, store.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	r := &store.Record{
+		Key:   s.key(object),
+		Value: b,
+	}
+
+	if v, ok := md[expiresKey]; ok {
+		expires, err := strconv.ParseInt(v, 10, 64)
+		if err == nil {
+			r.Expiry = time.Until(time.Unix(0, expires))
+			if r.Expiry <= 0 {
+				s.client.Delete(object)
+				return nil, store.ErrNotFound
+			}
+		}
+	}
+
+	return r, nil
+}
+
+// getAll returns the records of the objects skipping those deleted or expired
+func (s *blobStore) getAll(objects []string) ([]*store.Record, error) {
+	var records []*store.Record
+	for _, object := range objects {
+		r, err := s.get(object)
+		if err == store.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, r)
+	}
+	return records, nil
+}
+
+func (s *blobStore) List() ([]*store.Record, error) {
+	objects, err := s.client.List(s.object(""))
+	if err != nil {
+		return nil, err
+	}
+	return s.getAll(objects)
+}
+
+func (s *blobStore) Read(key string, opts ...store.ReadOption) ([]*store.Record, error) {
+	var options store.ReadOptions
+	for _, o := range opts {
+		o(&options)
+	}
+
+	if options.Prefix {
+		objects, err := s.client.List(s.object(key))
+		if err != nil {
+			return nil, err
+		}
+		return s.getAll(objects)
+	}
+
+	r, err := s.get(s.object(key))
+	if err != nil {
+		return nil, err
+	}
+	return []*store.Record{r}, nil
+}
+
+func (s *blobStore) Write(r *store.Record) error {
+	var md map[string]string
+	if r.Expiry > 0 {
+		md = map[string]string{
+			expiresKey: strconv.FormatInt(time.Now().Add(r.Expiry).UnixNano(), 10),
+		}
+	}
+	return s.client.PutMetadata(s.object(r.Key), "application/octet-stream", r.Value, md)
+}
+
+func (s *blobStore) Delete(key string) error {
+	return s.client.Delete(s.object(key))
+}
+
+func (s *blobStore) String() string {
+	return "s3"
+}
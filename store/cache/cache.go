@@ -0,0 +1,204 @@
+// Package cache is a read-through LRU cache of the records read from slow
+// store backends, bounded by the number of records, their size and a TTL
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/micro/go-micro/v2/store"
+)
+
+var (
+	// Size is the default maximum number of records cached
+	Size = 10000
+	// TTL is the default time a record is cached for
+	TTL = time.Minute
+)
+
+// Stats of the cache
+type Stats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	Records   uint64
+	Bytes     uint64
+}
+
+type entry struct {
+	id     string
+	store  string
+	record *store.Record
+	// when the entry is evicted, the earlier of the TTL and the record expiry
+	evict time.Time
+	// when the record expires, zero if it doesn't
+	expires time.Time
+	bytes   uint64
+}
+
+// Cache of the records of the stores
+type Cache struct {
+	size  int
+	bytes uint64
+	ttl   time.Duration
+
+	sync.Mutex
+	lru     *list.List
+	entries map[string]*list.Element
+	// generation is incremented by every invalidation so records read
+	// before a write completes aren't cached after it
+	generation uint64
+	stats      Stats
+}
+
+// New returns a cache of at most size records and bytes of keys and values
+// cached for the ttl, zero bytes is unbounded and zero size or ttl the default
+func New(size int, bytes uint64, ttl time.Duration) *Cache {
+	if size <= 0 {
+		size = Size
+	}
+	if ttl <= 0 {
+		ttl = TTL
+	}
+	return &Cache{
+		size:    size,
+		bytes:   bytes,
+		ttl:     ttl,
+		lru:     list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+func id(st, key string) string {
+	return st + "\x00" + key
+}
+
+// Read returns the cached record of the key of the store or reads it with fn
+// caching it, records not found aren't cached
+func (c *Cache) Read(st, key string, fn func() (*store.Record, error)) (*store.Record, error) {
+	c.Lock()
+	if el, ok := c.entries[id(st, key)]; ok {
+		e := el.Value.(*entry)
+		if time.Now().Before(e.evict) {
+			c.lru.MoveToFront(el)
+			c.stats.Hits++
+			r := &store.Record{Key: e.record.Key, Value: e.record.Value}
+			if !e.expires.IsZero() {
+				r.Expiry = time.Until(e.expires)
+			}
+			c.Unlock()
+			return r, nil
+		}
+		c.remove(el)
+	}
+	c.stats.Misses++
+	generation := c.generation
+	c.Unlock()
+
+	r, err := fn()
+	if err != nil {
+		return nil, err
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	// invalidated while it was read
+	if c.generation != generation {
+		return r, nil
+	}
+	c.set(st, r)
+
+	return r, nil
+}
+
+// set the record of the store, the lock must be held
+func (c *Cache) set(st string, r *store.Record) {
+	now := time.Now()
+	e := &entry{
+		id:     id(st, r.Key),
+		store:  st,
+		record: &store.Record{Key: r.Key, Value: r.Value},
+		evict:  now.Add(c.ttl),
+		bytes:  uint64(len(r.Key) + len(r.Value)),
+	}
+	if r.Expiry > 0 {
+		e.expires = now.Add(r.Expiry)
+		if e.expires.Before(e.evict) {
+			e.evict = e.expires
+		}
+	}
+
+	// too large to cache at all
+	if c.bytes > 0 && e.bytes > c.bytes {
+		return
+	}
+
+	if el, ok := c.entries[e.id]; ok {
+		c.remove(el)
+	}
+	c.entries[e.id] = c.lru.PushFront(e)
+	c.stats.Records++
+	c.stats.Bytes += e.bytes
+
+	for c.lru.Len() > c.size || (c.bytes > 0 && c.stats.Bytes > c.bytes) {
+		c.remove(c.lru.Back())
+		c.stats.Evictions++
+	}
+}
+
+// remove the entry, the lock must be held
+func (c *Cache) remove(el *list.Element) {
+	e := c.lru.Remove(el).(*entry)
+	delete(c.entries, e.id)
+	c.stats.Records--
+	c.stats.Bytes -= e.bytes
+}
+
+// Invalidate the cached record of the key of the store
+func (c *Cache) Invalidate(st, key string) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.generation++
+	if el, ok := c.entries[id(st, key)]; ok {
+		c.remove(el)
+	}
+}
+
+// Purge the cached records of the store
+func (c *Cache) Purge(st string) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.generation++
+	for _, el := range c.entries {
+		if el.Value.(*entry).store == st {
+			c.remove(el)
+		}
+	}
+}
+
+// Stores returns the stores with records cached
+func (c *Cache) Stores() []string {
+	c.Lock()
+	defer c.Unlock()
+
+	seen := make(map[string]bool)
+	var stores []string
+	for _, el := range c.entries {
+		if st := el.Value.(*entry).store; !seen[st] {
+			seen[st] = true
+			stores = append(stores, st)
+		}
+	}
+	return stores
+}
+
+// Stats returns the hits, misses and evictions since the cache was created
+// and the number and size of the records cached
+func (c *Cache) Stats() Stats {
+	c.Lock()
+	defer c.Unlock()
+	return c.stats
+}
@@ -0,0 +1,88 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/micro/go-micro/v2/store"
+)
+
+func TestCache(t *testing.T) {
+	c := New(2, 0, time.Minute)
+
+	reads := 0
+	read := func(key, value string) func() (*store.Record, error) {
+		return func() (*store.Record, error) {
+			reads++
+			return &store.Record{Key: key, Value: []byte(value)}, nil
+		}
+	}
+
+	c.Read("ns:", "foo", read("foo", "bar"))
+	r, _ := c.Read("ns:", "foo", read("foo", "baz"))
+	if string(r.Value) != "bar" || reads != 1 {
+		t.Fatalf("expected the cached value got %s after %d reads", r.Value, reads)
+	}
+
+	// written through the handler
+	c.Invalidate("ns:", "foo")
+	r, _ = c.Read("ns:", "foo", read("foo", "baz"))
+	if string(r.Value) != "baz" || reads != 2 {
+		t.Fatalf("expected the value to be read again got %s after %d reads", r.Value, reads)
+	}
+
+	// the least recently used is evicted
+	c.Read("ns:", "a", read("a", "1"))
+	c.Read("ns:", "b", read("b", "2"))
+	c.Read("ns:", "foo", read("foo", "qux"))
+	if reads != 5 {
+		t.Fatalf("expected foo to be evicted got %d reads", reads)
+	}
+
+	st := c.Stats()
+	if st.Hits != 1 || st.Misses != 5 || st.Evictions != 2 || st.Records != 2 {
+		t.Fatalf("unexpected stats %+v", st)
+	}
+
+	c.Purge("ns:")
+	if st := c.Stats(); st.Records != 0 || st.Bytes != 0 {
+		t.Fatalf("expected the store to be purged got %+v", st)
+	}
+}
+
+func TestCacheInvalidatedWhileRead(t *testing.T) {
+	c := New(0, 0, 0)
+
+	c.Read("ns:", "foo", func() (*store.Record, error) {
+		// written before the read completes
+		c.Invalidate("ns:", "foo")
+		return &store.Record{Key: "foo", Value: []byte("old")}, nil
+	})
+
+	if st := c.Stats(); st.Records != 0 {
+		t.Fatal("expected the record read before the write not to be cached")
+	}
+}
+
+func TestCacheExpiry(t *testing.T) {
+	c := New(0, 8, time.Minute)
+
+	c.Read("ns:", "foo", func() (*store.Record, error) {
+		return &store.Record{Key: "foo", Value: []byte("bar"), Expiry: time.Millisecond}, nil
+	})
+	time.Sleep(2 * time.Millisecond)
+
+	if _, err := c.Read("ns:", "foo", func() (*store.Record, error) {
+		return nil, store.ErrNotFound
+	}); err != store.ErrNotFound {
+		t.Fatalf("expected the expired record not to be returned got %v", err)
+	}
+
+	// larger than the cache
+	c.Read("ns:", "large", func() (*store.Record, error) {
+		return &store.Record{Key: "large", Value: []byte("too large")}, nil
+	})
+	if st := c.Stats(); st.Records != 0 {
+		t.Fatalf("expected the large record not to be cached got %+v", st)
+	}
+}
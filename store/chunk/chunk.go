@@ -0,0 +1,199 @@
+// Package chunk splits the values of records over the size limit of the
+// store backend into chunks written as separate records
+package chunk
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/micro/go-micro/v2/store"
+)
+
+var (
+	// Size is the default size in bytes of the chunks
+	Size = 1024 * 1024
+
+	// magic prefixing the manifest stored as the value of chunked records
+	magic = []byte("MSC1")
+
+	// separator between the key of a record and the index of its chunks
+	separator = ".__chunk."
+)
+
+// manifest stored in place of the value of a chunked record
+type manifest struct {
+	Chunks int `json:"chunks"`
+	Size   int `json:"size"`
+}
+
+type chunkStore struct {
+	store.Store
+
+	size int
+}
+
+// NewStore returns a store writing the values larger than the size in bytes
+// as chunks and a manifest, the chunks are reassembled on read
+func NewStore(st store.Store, size int) store.Store {
+	if size <= 0 {
+		size = Size
+	}
+	return &chunkStore{
+		Store: st,
+		size:  size,
+	}
+}
+
+// chunkKey returns the key of the nth chunk of the record
+func chunkKey(key string, n int) string {
+	return key + separator + strconv.Itoa(n)
+}
+
+// isChunk returns true if the key is the key of a chunk
+func isChunk(key string) bool {
+	return strings.Contains(key, separator)
+}
+
+// parse returns the manifest of a value, nil if it isn't chunked
+func parse(value []byte) *manifest {
+	if !bytes.HasPrefix(value, magic) {
+		return nil
+	}
+	var m manifest
+	if err := json.Unmarshal(value[len(magic):], &m); err != nil {
+		return nil
+	}
+	return &m
+}
+
+// current returns the manifest of the record stored, nil if it isn't chunked
+func (s *chunkStore) current(key string) *manifest {
+	recs, err := s.Store.Read(key)
+	if err != nil || len(recs) == 0 {
+		return nil
+	}
+	return parse(recs[0].Value)
+}
+
+// assemble returns copies of the records with the values of the chunked
+// records read from their chunks and the chunks themselves filtered out
+func (s *chunkStore) assemble(recs []*store.Record) ([]*store.Record, error) {
+	out := make([]*store.Record, 0, len(recs))
+
+	for _, r := range recs {
+		if isChunk(r.Key) {
+			continue
+		}
+
+		m := parse(r.Value)
+		if m == nil {
+			out = append(out, r)
+			continue
+		}
+
+		value := make([]byte, 0, m.Size)
+		for i := 0; i < m.Chunks; i++ {
+			c, err := s.Store.Read(chunkKey(r.Key, i))
+			if err != nil {
+				return nil, fmt.Errorf("%s: failed to read chunk %d: %v", r.Key, i, err)
+			}
+			value = append(value, c[0].Value...)
+		}
+		if len(value) != m.Size {
+			return nil, fmt.Errorf("%s: expected %d bytes got %d", r.Key, m.Size, len(value))
+		}
+
+		out = append(out, &store.Record{Key: r.Key, Value: value, Expiry: r.Expiry})
+	}
+
+	return out, nil
+}
+
+func (s *chunkStore) List() ([]*store.Record, error) {
+	recs, err := s.Store.List()
+	if err != nil {
+		return nil, err
+	}
+	return s.assemble(recs)
+}
+
+func (s *chunkStore) Read(key string, opts ...store.ReadOption) ([]*store.Record, error) {
+	recs, err := s.Store.Read(key, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return s.assemble(recs)
+}
+
+// Write the chunks before the manifest so it's never read without them,
+// the chunks left over from a larger value previously written are deleted
+func (s *chunkStore) Write(r *store.Record) error {
+	old := s.current(r.Key)
+
+	chunks := 0
+	if len(r.Value) > s.size {
+		for i := 0; i*s.size < len(r.Value); i++ {
+			end := (i + 1) * s.size
+			if end > len(r.Value) {
+				end = len(r.Value)
+			}
+			if err := s.Store.Write(&store.Record{
+				Key:    chunkKey(r.Key, i),
+				Value:  r.Value[i*s.size : end],
+				Expiry: r.Expiry,
+			}); err != nil {
+				return err
+			}
+			chunks++
+		}
+
+		b, _ := json.Marshal(&manifest{Chunks: chunks, Size: len(r.Value)})
+		if err := s.Store.Write(&store.Record{
+			Key:    r.Key,
+			Value:  append(append([]byte{}, magic...), b...),
+			Expiry: r.Expiry,
+		}); err != nil {
+			return err
+		}
+	} else if err := s.Store.Write(r); err != nil {
+		return err
+	}
+
+	if old != nil {
+		return s.deleteChunks(r.Key, chunks, old.Chunks)
+	}
+	return nil
+}
+
+func (s *chunkStore) Delete(key string) error {
+	old := s.current(key)
+	if err := s.Store.Delete(key); err != nil {
+		return err
+	}
+	if old != nil {
+		return s.deleteChunks(key, 0, old.Chunks)
+	}
+	return nil
+}
+
+// deleteChunks deletes the chunks of the record from index from to to
+func (s *chunkStore) deleteChunks(key string, from, to int) error {
+	for i := from; i < to; i++ {
+		if err := s.Store.Delete(chunkKey(key, i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close the backend if it holds connections
+func (s *chunkStore) Close() error {
+	if c, ok := s.Store.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
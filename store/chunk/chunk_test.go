@@ -0,0 +1,65 @@
+package chunk
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/micro/go-micro/v2/store"
+	"github.com/micro/go-micro/v2/store/memory"
+)
+
+func TestChunk(t *testing.T) {
+	backend := memory.NewStore()
+	st := NewStore(backend, 16)
+
+	large := bytes.Repeat([]byte("0123456789"), 10)
+
+	if err := st.Write(&store.Record{Key: "small", Value: []byte("small")}); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.Write(&store.Record{Key: "large", Value: large}); err != nil {
+		t.Fatal(err)
+	}
+
+	// 100 bytes in chunks of 16 is 7 chunks
+	raw, _ := backend.Read(chunkKey("large", 6))
+	if len(raw) != 1 || len(raw[0].Value) != 4 {
+		t.Fatalf("expected the last chunk to be written got %v", raw)
+	}
+
+	recs, err := st.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recs) != 2 {
+		t.Fatalf("expected the chunks to be filtered out got %d records", len(recs))
+	}
+
+	recs, err = st.Read("large")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(recs[0].Value, large) {
+		t.Fatalf("unexpected value %q", recs[0].Value)
+	}
+
+	// overwriting with a smaller value deletes the chunks left over
+	if err := st.Write(&store.Record{Key: "large", Value: large[:20]}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := backend.Read(chunkKey("large", 2)); err != store.ErrNotFound {
+		t.Fatalf("expected the chunks left over to be deleted got %v", err)
+	}
+	recs, _ = st.Read("large")
+	if !bytes.Equal(recs[0].Value, large[:20]) {
+		t.Fatalf("unexpected value %q", recs[0].Value)
+	}
+
+	if err := st.Delete("large"); err != nil {
+		t.Fatal(err)
+	}
+	recs, _ = backend.List()
+	if len(recs) != 1 {
+		t.Fatalf("expected the chunks to be deleted got %d records", len(recs))
+	}
+}
@@ -0,0 +1,755 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/micro/cli/v2"
+	"github.com/micro/go-micro/v2/client"
+	"github.com/micro/go-micro/v2/metadata"
+	"github.com/micro/go-micro/v2/store"
+	clic "github.com/micro/micro/v2/internal/command/cli"
+	"github.com/micro/micro/v2/store/bench"
+	"github.com/micro/micro/v2/store/export"
+	"github.com/micro/micro/v2/store/handler"
+	pb "github.com/micro/micro/v2/store/proto"
+	"github.com/micro/micro/v2/store/snapshot"
+)
+
+var (
+	// RestoreBatch is the number of records written at once by restore
+	RestoreBatch = 100
+)
+
+// storeFlags select the namespace and prefix of the store
+var storeFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:    "namespace",
+		Usage:   "Set the namespace of the store e.g team-a",
+		EnvVars: []string{"MICRO_STORE_CLIENT_NAMESPACE"},
+	},
+	&cli.StringFlag{
+		Name:    "prefix",
+		Usage:   "Set the key prefix of the store e.g users",
+		EnvVars: []string{"MICRO_STORE_CLIENT_PREFIX"},
+	},
+}
+
+// storeContext sets the metadata the store service selects the namespace and prefix by
+func storeContext(ctx *cli.Context) context.Context {
+	md := metadata.Metadata{}
+	if ns := ctx.String("namespace"); len(ns) > 0 {
+		md["Micro-Namespace"] = ns
+	}
+	if p := ctx.String("prefix"); len(p) > 0 {
+		md["Micro-Prefix"] = p
+	}
+	return metadata.NewContext(context.Background(), md)
+}
+
+func storeService() pb.StoreService {
+	return pb.NewStoreService(Name, client.DefaultClient)
+}
+
+// storeKey returns the key passed as the first arg exiting if missing
+func storeKey(ctx *cli.Context, usage string) string {
+	key := ctx.Args().First()
+	if len(key) == 0 {
+		fmt.Println("Required usage: " + usage)
+		os.Exit(1)
+	}
+	return key
+}
+
+// readRecord prints the value of the key
+func readRecord(ctx *cli.Context) {
+	key := storeKey(ctx, "micro store read [key]")
+
+	rsp, err := storeService().Read(storeContext(ctx), &pb.ReadRequest{Key: key})
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if len(rsp.Records) == 0 {
+		fmt.Printf("Key %s not found\n", key)
+		os.Exit(1)
+	}
+
+	r := rsp.Records[0]
+
+	if ctx.Bool("verbose") {
+		keys := make([]string, 0, len(r.Metadata))
+		for k := range r.Metadata {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fmt.Printf("%s: %s\n", k, r.Metadata[k])
+		}
+		if r.Expires > 0 {
+			fmt.Printf("expires: %s (in %ds)\n", time.Unix(r.Expires, 0).Format(time.RFC3339), r.Expiry)
+		}
+		fmt.Println()
+	}
+
+	os.Stdout.Write(r.Value)
+	fmt.Println()
+}
+
+// writeRecord writes the value passed as the second arg or read from the file
+func writeRecord(ctx *cli.Context) {
+	key := storeKey(ctx, "micro store write [key] [value] or micro store write [key] --file [path]")
+
+	var value []byte
+
+	switch file := ctx.String("file"); {
+	case file == "-":
+		b, err := ioutil.ReadAll(os.Stdin)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		value = b
+	case len(file) > 0:
+		b, err := ioutil.ReadFile(file)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		value = b
+	case ctx.Args().Len() > 1:
+		value = []byte(ctx.Args().Get(1))
+	default:
+		fmt.Println("Required usage: micro store write [key] [value] or micro store write [key] --file [path]")
+		os.Exit(1)
+	}
+
+	md := make(map[string]string)
+	for _, kv := range ctx.StringSlice("metadata") {
+		parts := strings.SplitN(kv, "=", 2)
+		if len(parts) != 2 || len(parts[0]) == 0 {
+			fmt.Printf("Invalid metadata %s, expected key=value\n", kv)
+			os.Exit(1)
+		}
+		md[parts[0]] = parts[1]
+	}
+
+	_, err := storeService().Write(storeContext(ctx), &pb.WriteRequest{
+		Record: &pb.Record{
+			Key:      key,
+			Value:    value,
+			Expiry:   int64(ctx.Duration("expiry").Seconds()),
+			Metadata: md,
+			Indexes:  ctx.StringSlice("index"),
+		},
+	})
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
+// deleteRecord deletes the key
+func deleteRecord(ctx *cli.Context) {
+	key := storeKey(ctx, "micro store delete [key]")
+
+	if _, err := storeService().Delete(storeContext(ctx), &pb.DeleteRequest{Key: key}); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
+// listRecords prints the keys of the store
+func listRecords(ctx *cli.Context) {
+	stream, err := storeService().List(storeContext(ctx), &pb.ListRequest{
+		Options: &pb.ListOptions{
+			Suffix: ctx.String("suffix"),
+			Limit:  ctx.Uint64("limit"),
+			Offset: ctx.Uint64("offset"),
+		},
+	})
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	defer stream.Close()
+
+	// keys are written in chunks and flushed as each batch is received
+	w := clic.NewWriter(os.Stdout)
+
+	for {
+		rsp, err := stream.Recv()
+		if err == io.EOF {
+			w.Flush()
+			return
+		}
+		if err != nil {
+			w.Flush()
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		for _, r := range rsp.Records {
+			fmt.Fprintln(w, r.Key)
+		}
+		w.Flush()
+	}
+}
+
+// queryRecords prints the keys of the records matching the filters
+func queryRecords(ctx *cli.Context) {
+	if ctx.Args().Len() == 0 {
+		fmt.Println("Required usage: micro store query [field=value]...")
+		os.Exit(1)
+	}
+
+	var filters []*pb.Filter
+	for _, arg := range ctx.Args().Slice() {
+		parts := strings.SplitN(arg, "=", 2)
+		if len(parts) != 2 || len(parts[0]) == 0 {
+			fmt.Printf("Invalid filter %s, expected field=value\n", arg)
+			os.Exit(1)
+		}
+		f := &pb.Filter{Field: parts[0], Value: parts[1]}
+		if strings.HasSuffix(f.Value, "*") {
+			f.Value = strings.TrimSuffix(f.Value, "*")
+			f.Prefix = true
+		}
+		filters = append(filters, f)
+	}
+
+	rsp, err := storeService().Query(storeContext(ctx), &pb.QueryRequest{
+		Filters: filters,
+		Limit:   ctx.Uint64("limit"),
+		Offset:  ctx.Uint64("offset"),
+	})
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	w := clic.NewWriter(os.Stdout)
+	for _, r := range rsp.Records {
+		fmt.Fprintln(w, r.Key)
+	}
+	w.Flush()
+}
+
+// snapshotRecords writes the records of the store to a gzipped snapshot
+func snapshotRecords(ctx *cli.Context) {
+	out := io.Writer(os.Stdout)
+	// progress is printed to stderr when the snapshot is written to stdout
+	status := os.Stderr
+
+	if path := ctx.String("output"); path != "-" {
+		f, err := os.Create(path)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		out = f
+		status = os.Stdout
+	}
+
+	stream, err := storeService().List(storeContext(ctx), &pb.ListRequest{})
+	if err != nil {
+		fmt.Fprintln(status, err)
+		os.Exit(1)
+	}
+	defer stream.Close()
+
+	w := snapshot.NewWriter(out)
+
+	var n int
+	for {
+		rsp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			fmt.Fprintln(status, err)
+			os.Exit(1)
+		}
+		for _, r := range rsp.Records {
+			// the metadata is kept in the value as it's stored
+			err := w.Write(ctx.String("namespace"), ctx.String("prefix"), &store.Record{
+				Key:    r.Key,
+				Value:  handler.EncodeValue(r.Value, r.Metadata),
+				Expiry: time.Duration(r.Expiry) * time.Second,
+			})
+			if err != nil {
+				fmt.Fprintln(status, err)
+				os.Exit(1)
+			}
+			n++
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		fmt.Fprintln(status, err)
+		os.Exit(1)
+	}
+
+	fmt.Fprintf(status, "Wrote %d records\n", n)
+}
+
+// restoreRecords writes the records of a snapshot to the store in batches, the
+// namespace and prefix of the records are used unless set by the flags
+func restoreRecords(ctx *cli.Context) {
+	in := io.Reader(os.Stdin)
+	if path := ctx.String("input"); path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		in = f
+	}
+
+	r, err := snapshot.NewReader(in)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	defer r.Close()
+
+	var restored, failed int
+	var batch []*pb.Record
+	var namespace, prefix string
+
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+
+		md := metadata.Metadata{}
+		if len(namespace) > 0 {
+			md["Micro-Namespace"] = namespace
+		}
+		if len(prefix) > 0 {
+			md["Micro-Prefix"] = prefix
+		}
+
+		rsp, err := storeService().BatchWrite(metadata.NewContext(context.Background(), md), &pb.BatchWriteRequest{Records: batch})
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		for _, st := range rsp.Statuses {
+			if len(st.Error) > 0 {
+				fmt.Printf("Failed to restore %s: %s\n", st.Key, st.Error)
+				failed++
+				continue
+			}
+			restored++
+		}
+		batch = nil
+	}
+
+	for {
+		rec, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		ns, px := rec.Namespace, rec.Prefix
+		if ctx.IsSet("namespace") {
+			ns = ctx.String("namespace")
+		}
+		if ctx.IsSet("prefix") {
+			px = ctx.String("prefix")
+		}
+
+		// batches are written to a single store
+		if ns != namespace || px != prefix || len(batch) == RestoreBatch {
+			flush()
+			namespace, prefix = ns, px
+		}
+
+		// round up so records expiring within a second still expire
+		record := rec.Record()
+		batch = append(batch, &pb.Record{
+			Key:    record.Key,
+			Value:  record.Value,
+			Expiry: int64(math.Ceil(record.Expiry.Seconds())),
+		})
+	}
+	flush()
+
+	fmt.Printf("Restored %d records, %d failed\n", restored, failed)
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// exportRecords writes the records of the store starting with the key prefix as json or csv
+func exportRecords(ctx *cli.Context) {
+	out := io.Writer(os.Stdout)
+	// progress is printed to stderr when the records are written to stdout
+	status := os.Stderr
+
+	path := ctx.String("output")
+	if path != "-" {
+		f, err := os.Create(path)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		out = f
+		status = os.Stdout
+	}
+
+	format := ctx.String("format")
+	if len(format) == 0 {
+		format = export.Format(path)
+	}
+
+	stream, err := storeService().List(storeContext(ctx), &pb.ListRequest{
+		Options: &pb.ListOptions{Prefix: ctx.String("key_prefix")},
+	})
+	if err != nil {
+		fmt.Fprintln(status, err)
+		os.Exit(1)
+	}
+	defer stream.Close()
+
+	var records []*export.Record
+	for {
+		rsp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			fmt.Fprintln(status, err)
+			os.Exit(1)
+		}
+		for _, r := range rsp.Records {
+			records = append(records, &export.Record{
+				Key:      r.Key,
+				Value:    r.Value,
+				Metadata: r.Metadata,
+				Expires:  r.Expires,
+			})
+		}
+	}
+
+	if err := export.Write(out, format, records); err != nil {
+		fmt.Fprintln(status, err)
+		os.Exit(1)
+	}
+
+	fmt.Fprintf(status, "Exported %d records\n", len(records))
+}
+
+// importRecords writes the records of a json or csv file to the store in batches
+func importRecords(ctx *cli.Context) {
+	path := storeKey(ctx, "micro store import [file.json|file.csv|-]")
+
+	in := io.Reader(os.Stdin)
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		in = f
+	}
+
+	format := ctx.String("format")
+	if len(format) == 0 {
+		format = export.Format(path)
+	}
+
+	mapping, err := export.ParseMapping(ctx.String("map"))
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	records, err := export.Read(in, format, mapping)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	var imported, failed int
+	for i := 0; i < len(records); i += RestoreBatch {
+		end := i + RestoreBatch
+		if end > len(records) {
+			end = len(records)
+		}
+
+		batch := make([]*pb.Record, 0, end-i)
+		for _, r := range records[i:end] {
+			batch = append(batch, &pb.Record{
+				Key:      r.Key,
+				Value:    r.Value,
+				Metadata: r.Metadata,
+				Expires:  r.Expires,
+			})
+		}
+
+		rsp, err := storeService().BatchWrite(storeContext(ctx), &pb.BatchWriteRequest{Records: batch})
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		for _, st := range rsp.Statuses {
+			if len(st.Error) > 0 {
+				fmt.Printf("Failed to import %s: %s\n", st.Key, st.Error)
+				failed++
+				continue
+			}
+			imported++
+		}
+	}
+
+	fmt.Printf("Imported %d records, %d failed\n", imported, failed)
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// benchStore benchmarks the store backend printing the throughput and latency of each op
+func benchStore(ctx *cli.Context) {
+	backend := ctx.String("backend")
+
+	st, err := newBackend(backend, split(ctx.String("nodes")), ctx.String("namespace"), ctx.String("prefix"))
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if c, ok := st.(io.Closer); ok {
+		defer c.Close()
+	}
+
+	fmt.Printf("Benchmarking %s with %d keys of %d bytes, %d at once\n\n",
+		backend, ctx.Int("keys"), ctx.Int("value_size"), ctx.Int("concurrency"))
+
+	results, err := bench.Run(st, bench.Options{
+		Keys:        ctx.Int("keys"),
+		ValueSize:   ctx.Int("value_size"),
+		Concurrency: ctx.Int("concurrency"),
+		// the keys of each run are unique so runs don't overwrite each other
+		Prefix: fmt.Sprintf("bench/%d/", time.Now().UnixNano()),
+		Keep:   ctx.Bool("keep"),
+	})
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	bench.Print(os.Stdout, results)
+}
+
+// rotateRecords rewrites the records of the store so their values are encrypted
+// with the current key, records changed while rotating are left as written
+func rotateRecords(ctx *cli.Context) {
+	c := storeContext(ctx)
+	st := storeService()
+
+	stream, err := st.List(c, &pb.ListRequest{})
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	defer stream.Close()
+
+	var keys []string
+	for {
+		rsp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		for _, r := range rsp.Records {
+			keys = append(keys, r.Key)
+		}
+	}
+
+	var rotated, changed, failed int
+
+	for _, key := range keys {
+		rsp, err := st.Read(c, &pb.ReadRequest{Key: key})
+		if err != nil {
+			fmt.Printf("Failed to read %s: %v\n", key, err)
+			failed++
+			continue
+		}
+		if len(rsp.Records) == 0 {
+			changed++
+			continue
+		}
+
+		// only rewrite the value read so concurrent writes aren't lost
+		r := rsp.Records[0]
+		txn, err := st.Txn(c, &pb.TxnRequest{
+			Conditions: []*pb.Condition{{Key: key, IfVersion: r.Version}},
+			Ops: []*pb.Op{{
+				Type:   "write",
+				Record: &pb.Record{Key: key, Value: r.Value, Expires: r.Expires, Metadata: r.Metadata},
+			}},
+		})
+		if err != nil {
+			fmt.Printf("Failed to rotate %s: %v\n", key, err)
+			failed++
+			continue
+		}
+		if !txn.Committed {
+			changed++
+			continue
+		}
+		rotated++
+	}
+
+	fmt.Printf("Rotated %d records, %d changed while rotating, %d failed\n", rotated, changed, failed)
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// watchRecords prints the changes to the keys starting with the prefix
+func watchRecords(ctx *cli.Context) {
+	stream, err := storeService().Watch(storeContext(ctx), &pb.WatchRequest{
+		Prefix: ctx.Args().First(),
+	})
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	defer stream.Close()
+
+	for {
+		ev, err := stream.Recv()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		fmt.Printf("%s %s %s\n", time.Unix(ev.Timestamp, 0).Format(time.RFC3339), ev.Type, ev.Record.GetKey())
+	}
+}
+
+// backends prints the status of the store backend nodes
+func backends(ctx *cli.Context) {
+	rsp, err := pb.NewStoreService(Name, client.DefaultClient).Backends(context.Background(), &pb.BackendsRequest{})
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
+	fmt.Fprintln(writer, "BACKEND\tNODE\tSTATUS\tACTIVE\tCHECKED\tERROR")
+	for _, b := range rsp.Backends {
+		status := "healthy"
+		if !b.Healthy {
+			status = "unhealthy"
+		}
+		checked := "-"
+		if b.Checked > 0 {
+			checked = time.Since(time.Unix(b.Checked, 0)).Truncate(time.Second).String() + " ago"
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%t\t%s\t%s\n", rsp.Backend, b.Node, status, b.Active, checked, b.Error)
+	}
+	writer.Flush()
+
+	fmt.Printf("\n%d namespace stores open, %d evicted\n", rsp.OpenStores, rsp.EvictedStores)
+
+	if c := rsp.Cache; c != nil {
+		ratio := 0.0
+		if c.Hits+c.Misses > 0 {
+			ratio = float64(c.Hits) / float64(c.Hits+c.Misses) * 100
+		}
+		fmt.Printf("cache %d hits, %d misses (%.1f%% hit ratio), %d evictions, %d records of %d bytes cached\n",
+			c.Hits, c.Misses, ratio, c.Evictions, c.Records, c.Bytes)
+	}
+}
+
+// printUsage prints the records and bytes stored in each namespace and prefix
+func printUsage(ctx *cli.Context) {
+	rsp, err := storeService().Usage(context.Background(), &pb.UsageRequest{Namespace: ctx.String("namespace")})
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	limit := func(used, quota int64) string {
+		if quota <= 0 {
+			return fmt.Sprintf("%d", used)
+		}
+		return fmt.Sprintf("%d/%d (%d%%)", used, quota, used*100/quota)
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
+	fmt.Fprintln(writer, "NAMESPACE\tPREFIX\tRECORDS\tBYTES")
+	for _, u := range rsp.Usage {
+		namespace := u.Namespace
+		if len(namespace) == 0 {
+			namespace = "-"
+		}
+		prefix := u.Prefix
+		if len(prefix) == 0 {
+			prefix = "-"
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", namespace, prefix, limit(u.Records, u.QuotaRecords), limit(u.Bytes, u.QuotaBytes))
+	}
+	writer.Flush()
+}
+
+// setMode sets the mode of the store if passed and prints it
+func setMode(ctx *cli.Context) {
+	req := &pb.ModeRequest{
+		Mode:     ctx.Args().First(),
+		Reason:   ctx.String("reason"),
+		Snapshot: ctx.Bool("snapshot"),
+		Timeout:  int64(ctx.Duration("timeout").Seconds()),
+	}
+	if req.Snapshot && req.Mode != handler.Maintenance {
+		fmt.Println("Snapshots are taken entering maintenance mode e.g micro store mode maintenance --snapshot")
+		os.Exit(1)
+	}
+
+	// wait for the drain and snapshot rather than the default request timeout
+	timeout := handler.DrainTimeout + time.Minute
+	if d := ctx.Duration("timeout"); d > 0 {
+		timeout = d + time.Minute
+	}
+
+	rsp, err := storeService().Mode(context.Background(), req, client.WithRequestTimeout(timeout))
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("mode: %s\n", rsp.Mode)
+	if len(rsp.Reason) > 0 {
+		fmt.Printf("reason: %s\n", rsp.Reason)
+	}
+	if rsp.Since > 0 {
+		fmt.Printf("since: %s\n", time.Unix(rsp.Since, 0).Format(time.RFC3339))
+	}
+	fmt.Printf("writes in flight: %d\n", rsp.Inflight)
+	if req.Snapshot {
+		fmt.Printf("snapshotted %d records\n", rsp.SnapshotRecords)
+	}
+}
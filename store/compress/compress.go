@@ -0,0 +1,162 @@
+// Package compress compresses the values of records over a size threshold
+// before they're written to the store backend
+package compress
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/micro/go-micro/v2/store"
+)
+
+const (
+	// Gzip compresses the values with gzip
+	Gzip = "gzip"
+)
+
+var (
+	// Threshold is the default size in bytes of the values compressed
+	Threshold = 1024
+
+	// magic prefixing the compressed values followed by the algorithm,
+	// values without it are stored raw
+	magic = []byte("MSZ1")
+
+	// algorithms by the id recorded with each value
+	algorithms = map[byte]string{
+		1: Gzip,
+	}
+)
+
+type compressStore struct {
+	store.Store
+
+	id        byte
+	threshold int
+}
+
+// NewStore returns a store compressing the values of at least the threshold
+// in bytes with the algorithm, compressed values are decompressed on read
+// whichever algorithm is set
+func NewStore(st store.Store, algorithm string, threshold int) (store.Store, error) {
+	s := &compressStore{
+		Store:     st,
+		threshold: threshold,
+	}
+	if s.threshold <= 0 {
+		s.threshold = Threshold
+	}
+
+	for id, name := range algorithms {
+		if name == algorithm {
+			s.id = id
+		}
+	}
+	if s.id == 0 {
+		return nil, fmt.Errorf("unknown compression %s", algorithm)
+	}
+
+	return s, nil
+}
+
+// Compressed returns the algorithm the value was compressed with,
+// blank if it's stored raw
+func Compressed(value []byte) string {
+	if !bytes.HasPrefix(value, magic) || len(value) < len(magic)+1 {
+		return ""
+	}
+	return algorithms[value[len(magic)]]
+}
+
+func (s *compressStore) compress(value []byte) ([]byte, error) {
+	if len(value) < s.threshold {
+		return value, nil
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 0, len(value)/2))
+	buf.Write(magic)
+	buf.WriteByte(s.id)
+
+	w := gzip.NewWriter(buf)
+	if _, err := w.Write(value); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	// store incompressible values raw
+	if buf.Len() >= len(value) {
+		return value, nil
+	}
+	return buf.Bytes(), nil
+}
+
+func (s *compressStore) decompress(key string, value []byte) ([]byte, error) {
+	switch Compressed(value) {
+	case "":
+		return value, nil
+	case Gzip:
+		r, err := gzip.NewReader(bytes.NewReader(value[len(magic)+1:]))
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to decompress: %v", key, err)
+		}
+		defer r.Close()
+
+		v, err := ioutil.ReadAll(r)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to decompress: %v", key, err)
+		}
+		return v, nil
+	default:
+		return nil, fmt.Errorf("%s: unknown compression %d", key, value[len(magic)])
+	}
+}
+
+// decompressAll returns copies of the records with the values decompressed
+func (s *compressStore) decompressAll(recs []*store.Record) ([]*store.Record, error) {
+	out := make([]*store.Record, 0, len(recs))
+	for _, r := range recs {
+		v, err := s.decompress(r.Key, r.Value)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, &store.Record{Key: r.Key, Value: v, Expiry: r.Expiry})
+	}
+	return out, nil
+}
+
+func (s *compressStore) List() ([]*store.Record, error) {
+	recs, err := s.Store.List()
+	if err != nil {
+		return nil, err
+	}
+	return s.decompressAll(recs)
+}
+
+func (s *compressStore) Read(key string, opts ...store.ReadOption) ([]*store.Record, error) {
+	recs, err := s.Store.Read(key, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return s.decompressAll(recs)
+}
+
+func (s *compressStore) Write(r *store.Record) error {
+	v, err := s.compress(r.Value)
+	if err != nil {
+		return err
+	}
+	return s.Store.Write(&store.Record{Key: r.Key, Value: v, Expiry: r.Expiry})
+}
+
+// Close the backend if it holds connections
+func (s *compressStore) Close() error {
+	if c, ok := s.Store.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
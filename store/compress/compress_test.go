@@ -0,0 +1,53 @@
+package compress
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/micro/go-micro/v2/store"
+	"github.com/micro/go-micro/v2/store/memory"
+)
+
+func TestCompress(t *testing.T) {
+	backend := memory.NewStore()
+
+	st, err := NewStore(backend, Gzip, 64)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	large := bytes.Repeat([]byte(`{"name":"micro"}`), 64)
+
+	// written before compression was enabled
+	backend.Write(&store.Record{Key: "raw", Value: large})
+
+	st.Write(&store.Record{Key: "small", Value: []byte("small")})
+	st.Write(&store.Record{Key: "large", Value: large})
+
+	raw, _ := backend.Read("small")
+	if Compressed(raw[0].Value) != "" {
+		t.Fatal("expected the value under the threshold to be stored raw")
+	}
+	raw, _ = backend.Read("large")
+	if Compressed(raw[0].Value) != Gzip || len(raw[0].Value) >= len(large) {
+		t.Fatalf("expected the large value to be compressed got %d bytes", len(raw[0].Value))
+	}
+
+	recs, err := st.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range recs {
+		expect := large
+		if r.Key == "small" {
+			expect = []byte("small")
+		}
+		if !bytes.Equal(r.Value, expect) {
+			t.Fatalf("%s: unexpected value %q", r.Key, r.Value)
+		}
+	}
+
+	if _, err := NewStore(backend, "lz4", 0); err == nil {
+		t.Fatal("expected an unknown compression to be rejected")
+	}
+}
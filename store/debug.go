@@ -0,0 +1,36 @@
+package store
+
+import (
+	"context"
+
+	debug "github.com/micro/go-micro/v2/debug/service/handler"
+	proto "github.com/micro/go-micro/v2/debug/service/proto"
+	"github.com/micro/micro/v2/store/handler"
+	pb "github.com/micro/micro/v2/store/proto"
+)
+
+// Debug is the debug handler of the store, Debug.Stats includes the stats of the store operations
+type Debug struct {
+	*debug.Debug
+	stats *handler.Stats
+}
+
+func (d *Debug) Stats(ctx context.Context, req *proto.StatsRequest, rsp *pb.StatsResponse) error {
+	base := new(proto.StatsResponse)
+	if err := d.Debug.Stats(ctx, req, base); err != nil {
+		return err
+	}
+
+	rsp.Timestamp = base.Timestamp
+	rsp.Started = base.Started
+	rsp.Uptime = base.Uptime
+	rsp.Memory = base.Memory
+	rsp.Threads = base.Threads
+	rsp.Gc = base.Gc
+	rsp.Requests = base.Requests
+	rsp.Errors = base.Errors
+	rsp.Operations = d.stats.List()
+	rsp.Cache = d.stats.CacheStats()
+
+	return nil
+}
@@ -0,0 +1,222 @@
+// Package encrypt encrypts the values of records before they're written to
+// the store backend and decrypts them when read
+package encrypt
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/micro/go-micro/v2/store"
+)
+
+var (
+	// magic prefixing the encrypted values, values without it were
+	// written before encryption was enabled and are returned as is
+	magic = []byte("MSE1")
+
+	// ErrUnknownKey is returned reading a value encrypted with a key that isn't configured
+	ErrUnknownKey = errors.New("value encrypted with an unknown key")
+)
+
+// Key used to encrypt the values
+type Key struct {
+	// ID recorded with each value encrypted by the key
+	ID string
+	// Secret is a 16, 24 or 32 byte AES key
+	Secret []byte
+}
+
+// ParseKeys parses a comma separated list of keys e.g v2:base64,v1:base64,
+// the first is used to encrypt and the rest to decrypt values not yet
+// rewritten after rotating the key. The list can be read from a file e.g
+// file:///etc/micro/store-keys mounted from a KMS backed secret.
+func ParseKeys(v string) ([]*Key, error) {
+	if strings.HasPrefix(v, "file://") {
+		b, err := ioutil.ReadFile(strings.TrimPrefix(v, "file://"))
+		if err != nil {
+			return nil, err
+		}
+		v = string(b)
+	}
+
+	var keys []*Key
+	seen := make(map[string]bool)
+
+	for _, entry := range strings.FieldsFunc(v, func(r rune) bool { return r == ',' || r == '\n' }) {
+		entry = strings.TrimSpace(entry)
+		if len(entry) == 0 {
+			continue
+		}
+
+		parts := strings.SplitN(entry, ":", 2)
+		if len(parts) != 2 || len(parts[0]) == 0 || len(parts[0]) > 255 {
+			return nil, fmt.Errorf("invalid key %s, expected id:base64", parts[0])
+		}
+		if seen[parts[0]] {
+			return nil, fmt.Errorf("duplicate key %s", parts[0])
+		}
+
+		secret, err := base64.StdEncoding.DecodeString(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid key %s: %v", parts[0], err)
+		}
+		switch len(secret) {
+		case 16, 24, 32:
+		default:
+			return nil, fmt.Errorf("invalid key %s, expected 16, 24 or 32 bytes got %d", parts[0], len(secret))
+		}
+
+		seen[parts[0]] = true
+		keys = append(keys, &Key{ID: parts[0], Secret: secret})
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("no keys")
+	}
+
+	return keys, nil
+}
+
+type encryptStore struct {
+	store.Store
+
+	// id of the key encrypting the values
+	current string
+	aeads   map[string]cipher.AEAD
+}
+
+// NewStore returns a store encrypting the values written to the backend
+// with the first key, the values encrypted with any of the keys are decrypted
+func NewStore(st store.Store, keys []*Key) (store.Store, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("no keys")
+	}
+
+	s := &encryptStore{
+		Store:   st,
+		current: keys[0].ID,
+		aeads:   make(map[string]cipher.AEAD, len(keys)),
+	}
+
+	for _, k := range keys {
+		block, err := aes.NewCipher(k.Secret)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key %s: %v", k.ID, err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key %s: %v", k.ID, err)
+		}
+		s.aeads[k.ID] = aead
+	}
+
+	return s, nil
+}
+
+// KeyID returns the id of the key the value was encrypted with,
+// blank if it isn't encrypted
+func KeyID(value []byte) string {
+	if !bytes.HasPrefix(value, magic) || len(value) < len(magic)+1 {
+		return ""
+	}
+	n := int(value[len(magic)])
+	if len(value) < len(magic)+1+n {
+		return ""
+	}
+	return string(value[len(magic)+1 : len(magic)+1+n])
+}
+
+// encrypt the value as magic, id length, id, nonce and the sealed value.
+// The key of the record is authenticated so values can't be swapped between keys.
+func (s *encryptStore) encrypt(key string, value []byte) ([]byte, error) {
+	aead := s.aeads[s.current]
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	b := make([]byte, 0, len(magic)+1+len(s.current)+len(nonce)+len(value)+aead.Overhead())
+	b = append(b, magic...)
+	b = append(b, byte(len(s.current)))
+	b = append(b, s.current...)
+	b = append(b, nonce...)
+
+	return aead.Seal(b, nonce, value, []byte(key)), nil
+}
+
+func (s *encryptStore) decrypt(key string, value []byte) ([]byte, error) {
+	id := KeyID(value)
+	if len(id) == 0 {
+		return value, nil
+	}
+
+	aead, ok := s.aeads[id]
+	if !ok {
+		return nil, fmt.Errorf("%s: %v %s", key, ErrUnknownKey, id)
+	}
+
+	b := value[len(magic)+1+len(id):]
+	if len(b) < aead.NonceSize() {
+		return nil, fmt.Errorf("%s: value too short to decrypt", key)
+	}
+
+	v, err := aead.Open(nil, b[:aead.NonceSize()], b[aead.NonceSize():], []byte(key))
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to decrypt: %v", key, err)
+	}
+	return v, nil
+}
+
+// decryptAll returns copies of the records with the values decrypted
+func (s *encryptStore) decryptAll(recs []*store.Record) ([]*store.Record, error) {
+	out := make([]*store.Record, 0, len(recs))
+	for _, r := range recs {
+		v, err := s.decrypt(r.Key, r.Value)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, &store.Record{Key: r.Key, Value: v, Expiry: r.Expiry})
+	}
+	return out, nil
+}
+
+func (s *encryptStore) List() ([]*store.Record, error) {
+	recs, err := s.Store.List()
+	if err != nil {
+		return nil, err
+	}
+	return s.decryptAll(recs)
+}
+
+func (s *encryptStore) Read(key string, opts ...store.ReadOption) ([]*store.Record, error) {
+	recs, err := s.Store.Read(key, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return s.decryptAll(recs)
+}
+
+func (s *encryptStore) Write(r *store.Record) error {
+	v, err := s.encrypt(r.Key, r.Value)
+	if err != nil {
+		return err
+	}
+	return s.Store.Write(&store.Record{Key: r.Key, Value: v, Expiry: r.Expiry})
+}
+
+// Close the backend if it holds connections
+func (s *encryptStore) Close() error {
+	if c, ok := s.Store.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
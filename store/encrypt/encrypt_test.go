@@ -0,0 +1,90 @@
+package encrypt
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+
+	"github.com/micro/go-micro/v2/store"
+	"github.com/micro/go-micro/v2/store/memory"
+)
+
+func key(id string, b byte) string {
+	return id + ":" + base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{b}, 32))
+}
+
+func TestEncrypt(t *testing.T) {
+	backend := memory.NewStore()
+
+	v1, err := ParseKeys(key("v1", 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	st, err := NewStore(backend, v1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// written before encryption was enabled
+	backend.Write(&store.Record{Key: "plain", Value: []byte("plain")})
+
+	if err := st.Write(&store.Record{Key: "foo", Value: []byte("bar")}); err != nil {
+		t.Fatal(err)
+	}
+
+	raw, _ := backend.Read("foo")
+	if bytes.Contains(raw[0].Value, []byte("bar")) || KeyID(raw[0].Value) != "v1" {
+		t.Fatalf("expected the value to be encrypted with v1 got %q", raw[0].Value)
+	}
+
+	recs, err := st.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]string{}
+	for _, r := range recs {
+		got[r.Key] = string(r.Value)
+	}
+	if got["foo"] != "bar" || got["plain"] != "plain" {
+		t.Fatalf("unexpected records %v", got)
+	}
+
+	// rotate to v2 keeping v1 to read the existing values
+	keys, err := ParseKeys(key("v2", 2) + "," + key("v1", 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rotated, err := NewStore(backend, keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if recs, err := rotated.Read("foo"); err != nil || string(recs[0].Value) != "bar" {
+		t.Fatalf("expected to read the value encrypted with v1: %v", err)
+	}
+
+	rotated.Write(&store.Record{Key: "foo", Value: []byte("baz")})
+	raw, _ = backend.Read("foo")
+	if KeyID(raw[0].Value) != "v2" {
+		t.Fatalf("expected the value to be encrypted with v2 got %s", KeyID(raw[0].Value))
+	}
+
+	// the value can't be read without its key
+	if _, err := st.Read("foo"); err == nil {
+		t.Fatal("expected the value encrypted with v2 to be unreadable with v1 only")
+	}
+
+	// nor when moved to another key
+	backend.Write(&store.Record{Key: "moved", Value: raw[0].Value})
+	if _, err := rotated.Read("moved"); err == nil {
+		t.Fatal("expected the value moved to another key to fail to decrypt")
+	}
+}
+
+func TestParseKeys(t *testing.T) {
+	for _, v := range []string{"", "v1", "v1:notbase64!", "v1:" + base64.StdEncoding.EncodeToString([]byte("short")), key("v1", 1) + "," + key("v1", 2)} {
+		if _, err := ParseKeys(v); err == nil {
+			t.Errorf("expected %q to be invalid", v)
+		}
+	}
+}
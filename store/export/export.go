@@ -0,0 +1,404 @@
+// Package export reads and writes the records of a store as json or csv so
+// they can be inspected and fixed by hand
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// JSON is an array of objects with the key, value, encoding, metadata and expires fields
+	JSON = "json"
+	// CSV has a header of the key, value, encoding and expires columns and a metadata.<name> column per metadata field
+	CSV = "csv"
+)
+
+const (
+	// encodings of the values, json values are embedded as is
+	encodingJSON   = "json"
+	encodingString = "string"
+	encodingBase64 = "base64"
+)
+
+// Record of a store
+type Record struct {
+	Key      string
+	Value    []byte
+	Metadata map[string]string
+	// Expires is the unix time the record expires, zero if it doesn't
+	Expires int64
+}
+
+// object is a record as written to json
+type object struct {
+	Key      string            `json:"key"`
+	Value    json.RawMessage   `json:"value"`
+	Encoding string            `json:"encoding,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+	Expires  string            `json:"expires,omitempty"`
+}
+
+// Format returns the format of the file by its extension, json by default
+func Format(path string) string {
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		return CSV
+	}
+	return JSON
+}
+
+// ParseMapping parses the fields of the records read mapped to the fields of a
+// record e.g id=key,data=value,owner=metadata.owner,ttl=expiry. Fields can be
+// mapped to key, value, encoding, metadata, metadata.<name>, expires as a unix
+// or RFC3339 time, expiry in seconds from now or - to be ignored.
+func ParseMapping(v string) (map[string]string, error) {
+	mapping := make(map[string]string)
+	for _, pair := range strings.Split(v, ",") {
+		pair = strings.TrimSpace(pair)
+		if len(pair) == 0 {
+			continue
+		}
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || len(parts[0]) == 0 {
+			return nil, fmt.Errorf("invalid mapping %s, expected field=field", pair)
+		}
+		switch dst := parts[1]; {
+		case dst == "key", dst == "value", dst == "encoding", dst == "metadata",
+			dst == "expires", dst == "expiry", dst == "-",
+			strings.HasPrefix(dst, "metadata.") && len(dst) > len("metadata."):
+		default:
+			return nil, fmt.Errorf("invalid mapping %s, can't map to %s", pair, dst)
+		}
+		mapping[parts[0]] = parts[1]
+	}
+	return mapping, nil
+}
+
+// encode returns the value as written and its encoding, json objects and
+// arrays are embedded as is if compact so they're read back unchanged
+func encode(value []byte) (string, string) {
+	if len(value) > 0 && (value[0] == '{' || value[0] == '[') && json.Valid(value) {
+		var buf bytes.Buffer
+		if json.Compact(&buf, value) == nil && bytes.Equal(buf.Bytes(), value) {
+			return string(value), encodingJSON
+		}
+	}
+	if utf8.Valid(value) {
+		return string(value), encodingString
+	}
+	return base64.StdEncoding.EncodeToString(value), encodingBase64
+}
+
+func expires(r *Record) string {
+	if r.Expires == 0 {
+		return ""
+	}
+	return time.Unix(r.Expires, 0).UTC().Format(time.RFC3339)
+}
+
+// Write the records in the format
+func Write(w io.Writer, format string, records []*Record) error {
+	switch format {
+	case JSON:
+		return writeJSON(w, records)
+	case CSV:
+		return writeCSV(w, records)
+	}
+	return fmt.Errorf("unknown format %s", format)
+}
+
+func writeJSON(w io.Writer, records []*Record) error {
+	objects := make([]*object, 0, len(records))
+	for _, r := range records {
+		value, encoding := encode(r.Value)
+
+		o := &object{
+			Key:      r.Key,
+			Encoding: encoding,
+			Metadata: r.Metadata,
+			Expires:  expires(r),
+		}
+		if encoding == encodingJSON {
+			o.Value = json.RawMessage(value)
+		} else {
+			o.Value, _ = json.Marshal(value)
+			// strings are the default
+			if encoding == encodingString {
+				o.Encoding = ""
+			}
+		}
+		objects = append(objects, o)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(objects)
+}
+
+func writeCSV(w io.Writer, records []*Record) error {
+	// a column for each of the metadata fields of any record
+	seen := make(map[string]bool)
+	var fields []string
+	for _, r := range records {
+		for k := range r.Metadata {
+			if !seen[k] {
+				seen[k] = true
+				fields = append(fields, k)
+			}
+		}
+	}
+	sort.Strings(fields)
+
+	cw := csv.NewWriter(w)
+
+	header := []string{"key", "value", "encoding", "expires"}
+	for _, f := range fields {
+		header = append(header, "metadata."+f)
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for _, r := range records {
+		value, encoding := encode(r.Value)
+		row := []string{r.Key, value, encoding, expires(r)}
+		for _, f := range fields {
+			row = append(row, r.Metadata[f])
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// Read the records in the format, the fields are mapped to the fields of
+// the records by the mapping and fields not mapped are read as named
+func Read(r io.Reader, format string, mapping map[string]string) ([]*Record, error) {
+	switch format {
+	case JSON:
+		return readJSON(r, mapping)
+	case CSV:
+		return readCSV(r, mapping)
+	}
+	return nil, fmt.Errorf("unknown format %s", format)
+}
+
+// builder of a record from its fields
+type builder struct {
+	record   *Record
+	encoding string
+}
+
+// set the field of the record the field read is mapped to
+func (b *builder) set(field string, value []byte, raw bool, mapping map[string]string) error {
+	dst := field
+	if m, ok := mapping[field]; ok {
+		dst = m
+	}
+
+	switch {
+	case dst == "key":
+		b.record.Key = string(value)
+	case dst == "value":
+		b.record.Value = value
+		// json values other than strings are embedded as is
+		if raw && len(b.encoding) == 0 {
+			b.encoding = encodingJSON
+		}
+	case dst == "encoding":
+		b.encoding = string(value)
+	case dst == "metadata":
+		var md map[string]string
+		if err := json.Unmarshal(value, &md); err != nil {
+			return fmt.Errorf("invalid metadata: %v", err)
+		}
+		for k, v := range md {
+			b.metadata()[k] = v
+		}
+	case strings.HasPrefix(dst, "metadata."):
+		b.metadata()[strings.TrimPrefix(dst, "metadata.")] = string(value)
+	case dst == "expires":
+		if len(value) == 0 {
+			return nil
+		}
+		if n, err := strconv.ParseInt(string(value), 10, 64); err == nil {
+			b.record.Expires = n
+			return nil
+		}
+		t, err := time.Parse(time.RFC3339, string(value))
+		if err != nil {
+			return fmt.Errorf("invalid expires %s, expected a unix or RFC3339 time", value)
+		}
+		b.record.Expires = t.Unix()
+	case dst == "expiry":
+		if len(value) == 0 {
+			return nil
+		}
+		n, err := strconv.ParseInt(string(value), 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid expiry %s, expected seconds", value)
+		}
+		if n > 0 {
+			b.record.Expires = time.Now().Unix() + n
+		}
+	}
+
+	return nil
+}
+
+func (b *builder) metadata() map[string]string {
+	if b.record.Metadata == nil {
+		b.record.Metadata = make(map[string]string)
+	}
+	return b.record.Metadata
+}
+
+// build returns the record decoding the value
+func (b *builder) build(n int) (*Record, error) {
+	if len(b.record.Key) == 0 {
+		return nil, fmt.Errorf("record %d has no key", n)
+	}
+
+	switch b.encoding {
+	case "", encodingString:
+	case encodingJSON:
+		var buf bytes.Buffer
+		if err := json.Compact(&buf, b.record.Value); err == nil {
+			b.record.Value = buf.Bytes()
+		}
+	case encodingBase64:
+		v, err := base64.StdEncoding.DecodeString(string(b.record.Value))
+		if err != nil {
+			return nil, fmt.Errorf("record %s: invalid base64 value: %v", b.record.Key, err)
+		}
+		b.record.Value = v
+	default:
+		return nil, fmt.Errorf("record %s: unknown encoding %s", b.record.Key, b.encoding)
+	}
+
+	return b.record, nil
+}
+
+// readJSON reads an array of objects or a stream of objects e.g json lines
+func readJSON(r io.Reader, mapping map[string]string) ([]*Record, error) {
+	var objects []map[string]json.RawMessage
+
+	br := bufio.NewReader(r)
+	dec := json.NewDecoder(br)
+
+	// skip the whitespace to check if it's an array
+	var first byte
+	for {
+		c, err := br.ReadByte()
+		if err == io.EOF {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if c != ' ' && c != '\t' && c != '\r' && c != '\n' {
+			br.UnreadByte()
+			first = c
+			break
+		}
+	}
+
+	if first == '[' {
+		if err := dec.Decode(&objects); err != nil {
+			return nil, err
+		}
+	} else {
+		for {
+			var o map[string]json.RawMessage
+			err := dec.Decode(&o)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			objects = append(objects, o)
+		}
+	}
+
+	records := make([]*Record, 0, len(objects))
+	for i, o := range objects {
+		b := &builder{record: new(Record)}
+		for field, raw := range o {
+			if bytes.Equal(raw, []byte("null")) {
+				continue
+			}
+
+			// strings are set as their value and anything else as json
+			value, isRaw := []byte(raw), true
+			var s string
+			if err := json.Unmarshal(raw, &s); err == nil {
+				value, isRaw = []byte(s), false
+			}
+
+			if err := b.set(field, value, isRaw, mapping); err != nil {
+				return nil, fmt.Errorf("record %d: %v", i+1, err)
+			}
+		}
+		rec, err := b.build(i + 1)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, rec)
+	}
+
+	return records, nil
+}
+
+func readCSV(r io.Reader, mapping map[string]string) ([]*Record, error) {
+	cr := csv.NewReader(r)
+
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var records []*Record
+	for n := 1; ; n++ {
+		row, err := cr.Read()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		b := &builder{record: new(Record)}
+		for i, field := range header {
+			// empty metadata columns are fields the record doesn't have
+			if len(row[i]) == 0 && field != "value" && field != "key" {
+				continue
+			}
+			if err := b.set(field, []byte(row[i]), false, mapping); err != nil {
+				return nil, fmt.Errorf("record %d: %v", n, err)
+			}
+		}
+		rec, err := b.build(n)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, rec)
+	}
+}
@@ -0,0 +1,69 @@
+package export
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRoundTrip(t *testing.T) {
+	records := []*Record{
+		{Key: "user/1", Value: []byte(`{"name":"john"}`), Metadata: map[string]string{"owner": "team-a"}},
+		{Key: "user/2", Value: []byte(`{ "name": "jane" }`), Expires: time.Now().Add(time.Hour).Unix()},
+		{Key: "text", Value: []byte("hello, world")},
+		{Key: "binary", Value: []byte{0xff, 0x00, 0xfe}},
+	}
+
+	for _, format := range []string{JSON, CSV} {
+		var buf bytes.Buffer
+		if err := Write(&buf, format, records); err != nil {
+			t.Fatal(err)
+		}
+
+		read, err := Read(&buf, format, nil)
+		if err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		if !reflect.DeepEqual(read, records) {
+			t.Fatalf("%s: expected the records to be read back unchanged got %+v", format, read)
+		}
+	}
+}
+
+func TestMapping(t *testing.T) {
+	mapping, err := ParseMapping("id=key,data=value,owner=metadata.owner,ttl=expiry,internal=-")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	in := `{"id": "user/1", "data": {"name": "john"}, "owner": "team-a", "ttl": 60, "internal": true}
+{"id": "user/2", "data": "plain"}`
+
+	read, err := Read(strings.NewReader(in), JSON, mapping)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(read) != 2 {
+		t.Fatalf("expected 2 records got %d", len(read))
+	}
+
+	r := read[0]
+	if r.Key != "user/1" || string(r.Value) != `{"name":"john"}` || r.Metadata["owner"] != "team-a" {
+		t.Fatalf("unexpected record %+v", r)
+	}
+	if d := r.Expires - time.Now().Unix(); d < 59 || d > 60 {
+		t.Fatalf("expected the record to expire in 60s got %ds", d)
+	}
+	if string(read[1].Value) != "plain" {
+		t.Fatalf("unexpected value %s", read[1].Value)
+	}
+
+	if _, err := ParseMapping("id=name"); err == nil {
+		t.Fatal("expected a mapping to an unknown field to be rejected")
+	}
+	if _, err := Read(strings.NewReader(`[{"data": "x"}]`), JSON, mapping); err == nil {
+		t.Fatal("expected a record without a key to be rejected")
+	}
+}
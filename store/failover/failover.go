@@ -0,0 +1,308 @@
+// Package failover spreads a store over multiple backend nodes, health
+// checking them and failing reads and writes over to a healthy node
+package failover
+
+import (
+	"sync"
+	"time"
+
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/store"
+	"github.com/micro/go-micro/v2/util/log"
+)
+
+var (
+	// Interval between health checks of the nodes
+	Interval = time.Second * 10
+	// Warmup is the number of consecutive health checks a failed
+	// node must pass before it's used again
+	Warmup = 3
+	// HealthKey is read to check the health of a node
+	HealthKey = "micro.store.health"
+)
+
+// Status of a node
+type Status struct {
+	Node    string
+	Healthy bool
+	Active  bool
+	Checked time.Time
+	Error   error
+}
+
+type node struct {
+	address string
+	// store used to check the health of the node
+	probe   store.Store
+	healthy bool
+	// consecutive health checks passed since failing
+	passed  int
+	checked time.Time
+	err     error
+}
+
+// Cluster tracks the health of the nodes and which one is active.
+// The stores created by it share the health of the nodes.
+type Cluster struct {
+	sync.RWMutex
+	nodes  []*node
+	active int
+
+	once sync.Once
+	exit chan bool
+}
+
+// NewCluster returns a cluster of the nodes, the probe creates the
+// store used to health check each node
+func NewCluster(nodes []string, probe func(node string) store.Store) *Cluster {
+	c := &Cluster{
+		exit: make(chan bool),
+	}
+
+	for _, n := range nodes {
+		c.nodes = append(c.nodes, &node{
+			address: n,
+			probe:   probe(n),
+			// assume the nodes are healthy until checked
+			healthy: true,
+		})
+	}
+
+	return c
+}
+
+// healthy returns nil if the error means the node responded. Client errors
+// e.g bad request or forbidden would fail on every node so they're not a
+// reason to fail over, only transport, timeout and server errors are.
+func healthy(err error) error {
+	if err == nil || err == store.ErrNotFound {
+		return nil
+	}
+	if e, ok := err.(*errors.Error); ok && e.Code >= 400 && e.Code < 500 && e.Code != 408 {
+		return nil
+	}
+	return err
+}
+
+// Check the health of each node once
+func (c *Cluster) Check() {
+	c.RLock()
+	nodes := c.nodes
+	c.RUnlock()
+
+	errs := make([]error, len(nodes))
+
+	var wg sync.WaitGroup
+	for i, n := range nodes {
+		wg.Add(1)
+		go func(i int, n *node) {
+			defer wg.Done()
+			_, err := n.probe.Read(HealthKey)
+			errs[i] = healthy(err)
+		}(i, n)
+	}
+	wg.Wait()
+
+	for i := range nodes {
+		if errs[i] != nil {
+			c.fail(i, errs[i])
+			continue
+		}
+		c.pass(i)
+	}
+}
+
+// pass records the node passing a health check
+func (c *Cluster) pass(i int) {
+	c.Lock()
+	defer c.Unlock()
+
+	n := c.nodes[i]
+	n.checked = time.Now()
+	n.err = nil
+
+	if n.healthy {
+		return
+	}
+
+	// warm up the node before using it again
+	if n.passed++; n.passed >= Warmup {
+		log.Logf("Store node %s is healthy", n.address)
+		n.healthy = true
+		n.passed = 0
+	}
+
+	// fail back to the node if none are active
+	if !c.nodes[c.active].healthy && n.healthy {
+		c.active = i
+	}
+}
+
+// fail records the node failing, failing over to the next healthy node
+func (c *Cluster) fail(i int, err error) {
+	c.Lock()
+	defer c.Unlock()
+
+	n := c.nodes[i]
+	n.checked = time.Now()
+	n.err = err
+	n.passed = 0
+
+	if n.healthy {
+		log.Logf("Store node %s is unhealthy: %v", n.address, err)
+	}
+	n.healthy = false
+
+	if i != c.active {
+		return
+	}
+
+	for j := 1; j < len(c.nodes); j++ {
+		next := (i + j) % len(c.nodes)
+		if c.nodes[next].healthy {
+			log.Logf("Failing over the store from %s to %s", n.address, c.nodes[next].address)
+			c.active = next
+			return
+		}
+	}
+}
+
+// Active returns the index of the node serving requests
+func (c *Cluster) Active() int {
+	c.RLock()
+	defer c.RUnlock()
+	return c.active
+}
+
+// Status returns the status of the nodes
+func (c *Cluster) Status() []*Status {
+	c.RLock()
+	defer c.RUnlock()
+
+	status := make([]*Status, 0, len(c.nodes))
+	for i, n := range c.nodes {
+		status = append(status, &Status{
+			Node:    n.address,
+			Healthy: n.healthy,
+			Active:  i == c.active,
+			Checked: n.checked,
+			Error:   n.err,
+		})
+	}
+	return status
+}
+
+// Start health checking the nodes
+func (c *Cluster) Start() {
+	go func() {
+		t := time.NewTicker(Interval)
+		defer t.Stop()
+
+		for {
+			select {
+			case <-c.exit:
+				return
+			case <-t.C:
+				c.Check()
+			}
+		}
+	}()
+}
+
+// Stop health checking the nodes
+func (c *Cluster) Stop() {
+	c.once.Do(func() {
+		close(c.exit)
+	})
+}
+
+// Store returns a store which uses the active node, fn
+// creates the store for each node of the cluster
+func (c *Cluster) Store(fn func(node string) store.Store) store.Store {
+	s := &failoverStore{cluster: c}
+	for _, n := range c.nodes {
+		s.stores = append(s.stores, fn(n.address))
+	}
+	return s
+}
+
+type failoverStore struct {
+	opts    store.Options
+	cluster *Cluster
+	stores  []store.Store
+}
+
+// do runs the operation against the active node failing over on errors
+func (s *failoverStore) do(fn func(store.Store) error) error {
+	var err error
+
+	for i := 0; i < len(s.stores); i++ {
+		active := s.cluster.Active()
+
+		err = fn(s.stores[active])
+		if healthy(err) == nil {
+			return err
+		}
+
+		s.cluster.fail(active, err)
+
+		// there's nothing to fail over to
+		if s.cluster.Active() == active {
+			return err
+		}
+	}
+
+	return err
+}
+
+func (s *failoverStore) Init(opts ...store.Option) error {
+	for _, o := range opts {
+		o(&s.opts)
+	}
+	for _, st := range s.stores {
+		if err := st.Init(opts...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *failoverStore) Options() store.Options {
+	return s.opts
+}
+
+func (s *failoverStore) List() ([]*store.Record, error) {
+	var records []*store.Record
+	err := s.do(func(st store.Store) error {
+		var err error
+		records, err = st.List()
+		return err
+	})
+	return records, err
+}
+
+func (s *failoverStore) Read(key string, opts ...store.ReadOption) ([]*store.Record, error) {
+	var records []*store.Record
+	err := s.do(func(st store.Store) error {
+		var err error
+		records, err = st.Read(key, opts...)
+		return err
+	})
+	return records, err
+}
+
+func (s *failoverStore) Write(r *store.Record) error {
+	return s.do(func(st store.Store) error {
+		return st.Write(r)
+	})
+}
+
+func (s *failoverStore) Delete(key string) error {
+	return s.do(func(st store.Store) error {
+		return st.Delete(key)
+	})
+}
+
+func (s *failoverStore) String() string {
+	return "failover"
+}
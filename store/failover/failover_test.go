@@ -0,0 +1,105 @@
+package failover
+
+import (
+	"errors"
+	"testing"
+
+	merrors "github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/store"
+	"github.com/micro/go-micro/v2/store/memory"
+)
+
+// downStore fails every operation while down
+type downStore struct {
+	store.Store
+	down *bool
+}
+
+func (d *downStore) Read(key string, opts ...store.ReadOption) ([]*store.Record, error) {
+	if *d.down {
+		return nil, errors.New("connection refused")
+	}
+	return d.Store.Read(key, opts...)
+}
+
+func (d *downStore) Write(r *store.Record) error {
+	if *d.down {
+		return errors.New("connection refused")
+	}
+	return d.Store.Write(r)
+}
+
+func TestFailover(t *testing.T) {
+	down := map[string]*bool{"a": new(bool), "b": new(bool)}
+	backends := map[string]store.Store{"a": memory.NewStore(), "b": memory.NewStore()}
+
+	fn := func(node string) store.Store {
+		return &downStore{Store: backends[node], down: down[node]}
+	}
+
+	c := NewCluster([]string{"a", "b"}, fn)
+	s := c.Store(fn)
+
+	if err := s.Write(&store.Record{Key: "foo", Value: []byte("bar")}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := backends["a"].Read("foo"); err != nil {
+		t.Fatal("Expected the write to go to the first node")
+	}
+
+	// fail over to b on error
+	*down["a"] = true
+	if err := s.Write(&store.Record{Key: "foo", Value: []byte("baz")}); err != nil {
+		t.Fatal(err)
+	}
+	if c.Active() != 1 {
+		t.Fatalf("Expected to fail over to b got %d", c.Active())
+	}
+	if _, err := backends["b"].Read("foo"); err != nil {
+		t.Fatal("Expected the write to go to the second node")
+	}
+
+	// a warms up before it's used again
+	*down["a"] = false
+	c.Check()
+	if st := c.Status(); st[0].Healthy || !st[1].Active {
+		t.Fatal("Expected a to be warming up")
+	}
+	for i := 1; i < Warmup; i++ {
+		c.Check()
+	}
+	if st := c.Status(); !st[0].Healthy || !st[1].Active {
+		t.Fatal("Expected a to be healthy and b to stay active")
+	}
+
+	// with both down the error is returned
+	*down["a"], *down["b"] = true, true
+	if err := s.Write(&store.Record{Key: "foo"}); err == nil {
+		t.Fatal("Expected an error with every node down")
+	}
+}
+
+// forbiddenStore rejects every write
+type forbiddenStore struct {
+	store.Store
+}
+
+func (f *forbiddenStore) Write(r *store.Record) error {
+	return merrors.Forbidden("go.micro.store", "write forbidden")
+}
+
+func TestNoFailoverOnClientError(t *testing.T) {
+	fn := func(node string) store.Store {
+		return &forbiddenStore{Store: memory.NewStore()}
+	}
+
+	c := NewCluster([]string{"a", "b"}, fn)
+	s := c.Store(fn)
+
+	if err := s.Write(&store.Record{Key: "foo"}); err == nil {
+		t.Fatal("Expected the write to be forbidden")
+	}
+	if c.Active() != 0 {
+		t.Fatalf("Expected to stay on a got %d", c.Active())
+	}
+}
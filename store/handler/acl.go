@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"context"
+	"strings"
+
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/micro/v2/internal/namespace"
+)
+
+// ACL restricts the namespaces of the store callers can access
+type ACL struct {
+	// Allow lists the namespaces that can be accessed, every namespace
+	// when empty. A trailing * matches by prefix e.g team-*
+	Allow []string
+	// Deny lists the namespaces that can't be accessed, it takes precedence
+	Deny []string
+	// Scoped restricts callers to the namespace of their namespace token
+	Scoped bool
+}
+
+// matches returns true if the namespace matches any of the patterns
+func matches(patterns []string, ns string) bool {
+	for _, p := range patterns {
+		if p == ns || (strings.HasSuffix(p, "*") && strings.HasPrefix(ns, strings.TrimSuffix(p, "*"))) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowed returns true if the namespace is in the allow list and not in the deny list
+func (a *ACL) allowed(ns string) bool {
+	if matches(a.Deny, ns) {
+		return false
+	}
+	return len(a.Allow) == 0 || matches(a.Allow, ns)
+}
+
+// Check returns an error if the caller may not access the namespace,
+// the default store has the blank namespace
+func (a *ACL) Check(ctx context.Context, ns string) error {
+	if !a.allowed(ns) {
+		return errors.Forbidden("go.micro.store", "namespace %s may not be accessed", ns)
+	}
+
+	if !a.Scoped {
+		return nil
+	}
+
+	scope, err := namespace.FromContext(ctx)
+	if err != nil {
+		return errors.Unauthorized("go.micro.store", err.Error())
+	}
+	// only unscoped callers access the default store
+	if !namespace.Allowed(scope, ns) {
+		return errors.Forbidden("go.micro.store", "namespace %s may not access namespace %s", scope, ns)
+	}
+
+	return nil
+}
+
+// check the caller may access the namespace of the request
+func (s *Store) check(ctx context.Context) error {
+	if s.ACL == nil {
+		return nil
+	}
+	ns, _ := name(ctx)
+	return s.ACL.Check(ctx, ns)
+}
@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"testing"
+)
+
+func TestACLAllowed(t *testing.T) {
+	acl := &ACL{
+		Allow: []string{"team-*", "shared"},
+		Deny:  []string{"team-internal"},
+	}
+
+	tests := map[string]bool{
+		"team-a":        true,
+		"team-b":        true,
+		"shared":        true,
+		"team-internal": false,
+		"other":         false,
+		"":              false,
+	}
+
+	for ns, expect := range tests {
+		if got := acl.allowed(ns); got != expect {
+			t.Errorf("%q: expected %v got %v", ns, expect, got)
+		}
+	}
+
+	// every namespace but those denied when nothing is allowed
+	acl = &ACL{Deny: []string{"team-*"}}
+	if !acl.allowed("") || !acl.allowed("other") || acl.allowed("team-a") {
+		t.Fatal("expected only the denied namespaces to be rejected")
+	}
+}
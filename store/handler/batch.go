@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/micro/go-micro/v2/errors"
+	pb "github.com/micro/micro/v2/store/proto"
+)
+
+var (
+	// MaxBatch is the maximum number of records of a batch
+	MaxBatch = 1000
+)
+
+// checkBatch returns an error if the batch is empty or too large
+func checkBatch(n int) error {
+	if n == 0 {
+		return errors.BadRequest("go.micro.store", "no records specified")
+	}
+	if n > MaxBatch {
+		return errors.BadRequest("go.micro.store", fmt.Sprintf("batch of %d records exceeds the maximum of %d", n, MaxBatch))
+	}
+	return nil
+}
+
+// status of applying the record of the batch
+func status(key string, err error) *pb.Status {
+	st := &pb.Status{Key: key}
+	if e, ok := err.(*errors.Error); ok {
+		st.Error = e.Detail
+	} else if err != nil {
+		st.Error = err.Error()
+	}
+	return st
+}
+
+// BatchRead reads many keys at once returning the status of each
+func (s *Store) BatchRead(ctx context.Context, req *pb.BatchReadRequest, rsp *pb.BatchReadResponse) error {
+	ctx = target(ctx, req.GetOptions().GetDatabase(), req.GetOptions().GetTable())
+
+	if err := checkBatch(len(req.Keys)); err != nil {
+		return err
+	}
+
+	st, err := s.get(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range req.Keys {
+		r, err := s.read(ctx, st, key)
+		rsp.Statuses = append(rsp.Statuses, status(key, err))
+		if err != nil {
+			continue
+		}
+		rsp.Records = append(rsp.Records, fromRecord(r))
+	}
+
+	return nil
+}
+
+// BatchWrite writes many records at once returning the status of each.
+// The go-micro stores write a record at a time so they're applied in order.
+func (s *Store) BatchWrite(ctx context.Context, req *pb.BatchWriteRequest, rsp *pb.BatchWriteResponse) error {
+	ctx = target(ctx, req.GetOptions().GetDatabase(), req.GetOptions().GetTable())
+
+	done, err := s.begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	if ok, err := s.forward(ctx, "Store.BatchWrite", req, rsp); ok {
+		return err
+	}
+
+	if err := checkBatch(len(req.Records)); err != nil {
+		return err
+	}
+
+	st, err := s.get(ctx)
+	if err != nil {
+		return err
+	}
+
+	var written int
+
+	unlock := s.lock(ctx)
+	defer unlock()
+
+	for _, r := range req.Records {
+		if r == nil {
+			rsp.Statuses = append(rsp.Statuses, status("", fmt.Errorf("no record specified")))
+			continue
+		}
+
+		record, err := toRecord(r)
+		if err == nil {
+			err = s.write(ctx, st, record)
+		}
+		if err == nil {
+			err = s.index(st, r, record)
+		}
+		if err == nil {
+			written++
+		}
+		rsp.Statuses = append(rsp.Statuses, status(r.Key, err))
+	}
+
+	if written > 0 {
+		s.replicate(ctx, "Store.BatchWrite", req, func() interface{} { return new(pb.BatchWriteResponse) })
+	}
+
+	return nil
+}
+
+// BatchDelete deletes many keys at once returning the status of each
+func (s *Store) BatchDelete(ctx context.Context, req *pb.BatchDeleteRequest, rsp *pb.BatchDeleteResponse) error {
+	ctx = target(ctx, req.GetOptions().GetDatabase(), req.GetOptions().GetTable())
+
+	done, err := s.begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	if ok, err := s.forward(ctx, "Store.BatchDelete", req, rsp); ok {
+		return err
+	}
+
+	if err := checkBatch(len(req.Keys)); err != nil {
+		return err
+	}
+
+	st, err := s.get(ctx)
+	if err != nil {
+		return err
+	}
+
+	var deleted int
+
+	unlock := s.lock(ctx)
+	defer unlock()
+
+	for _, key := range req.Keys {
+		err := s.delete(ctx, st, key)
+		if err == nil {
+			deleted++
+		}
+		rsp.Statuses = append(rsp.Statuses, status(key, err))
+	}
+
+	if deleted > 0 {
+		s.replicate(ctx, "Store.BatchDelete", req, func() interface{} { return new(pb.BatchDeleteResponse) })
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"io"
+	"time"
+
+	"github.com/micro/go-micro/v2/util/log"
+)
+
+var (
+	// MaxStores is the default maximum number of namespace and prefix stores
+	// kept open, the least recently used is evicted to open another
+	MaxStores = 1000
+	// IdleTimeout is the default time a store is kept open without being used
+	IdleTimeout = 10 * time.Minute
+	// EvictInterval is how often the idle stores are evicted
+	EvictInterval = time.Minute
+)
+
+func (s *Store) maxStores() int {
+	if s.MaxStores > 0 {
+		return s.MaxStores
+	}
+	return MaxStores
+}
+
+func (s *Store) idleTimeout() time.Duration {
+	if s.IdleTimeout > 0 {
+		return s.IdleTimeout
+	}
+	return IdleTimeout
+}
+
+// touch marks the store as used, it must be called with the lock held
+func (s *Store) touch(k string) {
+	if s.used == nil {
+		s.used = make(map[string]time.Time)
+	}
+	s.used[k] = time.Now()
+}
+
+// evict the store closing its backend connections if it has any,
+// it must be called with the lock held
+func (s *Store) evict(k string) {
+	st, ok := s.Stores[k]
+	if !ok {
+		return
+	}
+
+	delete(s.Stores, k)
+	delete(s.used, k)
+	s.forget(k)
+	s.evicted++
+
+	if c, ok := st.(io.Closer); ok {
+		go func(k string, c io.Closer) {
+			if err := c.Close(); err != nil {
+				log.Logf("Failed to close the evicted store %s: %v", k, err)
+			}
+		}(k, c)
+	}
+}
+
+// evictLRU evicts the least recently used store which isn't watched,
+// it must be called with the lock held
+func (s *Store) evictLRU() {
+	var lru string
+	var last time.Time
+
+	for k := range s.Stores {
+		if s.watchers().watching(k) {
+			continue
+		}
+		if used := s.used[k]; len(lru) == 0 || used.Before(last) {
+			lru, last = k, used
+		}
+	}
+
+	if len(lru) > 0 {
+		log.Debugf("Evicting the least recently used store %s", lru)
+		s.evict(lru)
+	}
+}
+
+// evictIdle evicts the stores which haven't been used within the idle timeout
+func (s *Store) evictIdle() {
+	s.Lock()
+	defer s.Unlock()
+
+	idle := s.idleTimeout()
+
+	for k := range s.Stores {
+		if s.watchers().watching(k) {
+			continue
+		}
+		if time.Since(s.used[k]) > idle {
+			log.Debugf("Evicting the idle store %s", k)
+			s.evict(k)
+		}
+	}
+}
+
+// GC evicts the idle stores every evict interval until exit is closed
+func (s *Store) GC(exit chan bool) {
+	t := time.NewTicker(EvictInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-exit:
+			return
+		case <-t.C:
+			s.evictIdle()
+		}
+	}
+}
+
+// open returns the number of stores open and evicted
+func (s *Store) open() (uint64, uint64) {
+	s.RLock()
+	defer s.RUnlock()
+	return uint64(len(s.Stores)), s.evicted
+}
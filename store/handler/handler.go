@@ -3,13 +3,17 @@ package handler
 import (
 	"context"
 	"io"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/micro/go-micro/v2/errors"
 	"github.com/micro/go-micro/v2/metadata"
 	"github.com/micro/go-micro/v2/store"
-	pb "github.com/micro/go-micro/v2/store/service/proto"
+	"github.com/micro/micro/v2/internal/standby"
+	"github.com/micro/micro/v2/store/cache"
+	"github.com/micro/micro/v2/store/failover"
+	pb "github.com/micro/micro/v2/store/proto"
 )
 
 type Store struct {
@@ -22,31 +26,139 @@ type Store struct {
 	// Store map
 	sync.RWMutex
 	Stores map[string]store.Store
+
+	// Backend is the name of the store backend e.g cockroach
+	Backend string
+	// Nodes of the backend
+	Nodes []string
+	// Cluster is set when failing over between the nodes
+	Cluster *failover.Cluster
+	// Standby is set when run as one of an active/standby pair
+	Standby *standby.Standby
+	// ACL restricts the namespaces callers can access, if set
+	ACL *ACL
+	// Cache of the records read from the backend, if set
+	Cache *cache.Cache
+	// Snapshot the stores once drained in maintenance mode, if set
+	Snapshot func() (int, error)
+
+	// MaxStores kept open, defaults to MaxStores
+	MaxStores int
+	// IdleTimeout of the stores, defaults to IdleTimeout
+	IdleTimeout time.Duration
+
+	// when the stores were last used
+	used    map[string]time.Time
+	evicted uint64
+
+	// Quotas of the stores of each namespace, * is the default
+	Quotas map[string]Quota
+
+	// locks serialising the writes to each store
+	locks sync.Map
+
+	// usage of the stores tracked
+	usageMu sync.Mutex
+	usage   map[string]*usage
+
+	// mode writes are applied or rejected in
+	mode mode
+
+	once  sync.Once
+	watch *watchers
 }
 
-func (s *Store) get(ctx context.Context) (store.Store, error) {
-	// lock (might be a race)
-	s.Lock()
-	defer s.Unlock()
+// watchers returns the watchers of the stores
+func (s *Store) watchers() *watchers {
+	s.once.Do(func() {
+		s.watch = newWatchers()
+	})
+	return s.watch
+}
 
+// name returns the namespace and prefix of the store the request is for
+func name(ctx context.Context) (string, string) {
 	md, ok := metadata.FromContext(ctx)
 	if !ok {
-		return s.Default, nil
+		return "", ""
 	}
+	return md["Micro-Namespace"], md["Micro-Prefix"]
+}
 
-	namespace := md["Micro-Namespace"]
-	prefix := md["Micro-Prefix"]
+// target returns the context selecting the store of the database and table
+// set by the request options, the metadata is used for those not set
+func target(ctx context.Context, database, table string) context.Context {
+	if len(database) == 0 && len(table) == 0 {
+		return ctx
+	}
+
+	md := make(metadata.Metadata)
+	if v, ok := metadata.FromContext(ctx); ok {
+		for k, val := range v {
+			md[k] = val
+		}
+	}
+	if len(database) > 0 {
+		md["Micro-Namespace"] = database
+	}
+	if len(table) > 0 {
+		md["Micro-Prefix"] = table
+	}
+
+	return metadata.NewContext(ctx, md)
+}
+
+// forward the write to the active instance returning true if it was, replicated
+// writes are applied locally and are returned as not forwarded
+func (s *Store) forward(ctx context.Context, endpoint string, req, rsp interface{}) (bool, error) {
+	if s.Standby == nil {
+		return false, nil
+	}
+	if ok, err := s.Standby.Replicated(ctx); ok {
+		return err != nil, err
+	}
+	return s.Standby.Forward(ctx, endpoint, req, rsp)
+}
+
+// replicate the write applied by the active instance to the standbys
+func (s *Store) replicate(ctx context.Context, endpoint string, req interface{}, rsp func() interface{}) {
+	if s.Standby == nil {
+		return
+	}
+	if ok, _ := s.Standby.Replicated(ctx); ok {
+		return
+	}
+	s.Standby.Replicate(ctx, endpoint, req, rsp)
+}
+
+func (s *Store) get(ctx context.Context) (store.Store, error) {
+	if err := s.check(ctx); err != nil {
+		return nil, err
+	}
+
+	// lock (might be a race)
+	s.Lock()
+	defer s.Unlock()
+
+	namespace, prefix := name(ctx)
 
 	if len(namespace) == 0 && len(prefix) == 0 {
 		return s.Default, nil
 	}
 
+	s.touch(namespace + ":" + prefix)
+
 	str, ok := s.Stores[namespace+":"+prefix]
 	// got it
 	if ok {
 		return str, nil
 	}
 
+	// make room for the new store
+	if len(s.Stores) >= s.maxStores() {
+		s.evictLRU()
+	}
+
 	// create a new store
 	// either namespace is not blank or prefix is not blank
 	st := s.New(namespace, prefix)
@@ -57,34 +169,200 @@ func (s *Store) get(ctx context.Context) (store.Store, error) {
 	return st, nil
 }
 
+// write the record to the store publishing the change to the watchers
+func (s *Store) write(ctx context.Context, st store.Store, record *store.Record) error {
+	namespace, prefix := name(ctx)
+	k := namespace + ":" + prefix
+	watched := s.watchers().watched(k, record.Key)
+	tracked := s.tracked(namespace, k)
+
+	// only read the existing record when the change is watched or the usage tracked
+	var old *store.Record
+	if watched || tracked {
+		if recs, err := st.Read(record.Key); err == nil && len(recs) > 0 {
+			old = recs[0]
+		}
+	}
+
+	var d usage
+	if tracked {
+		d = usage{records: 1, bytes: size(record)}
+		if old != nil {
+			d = usage{bytes: size(record) - size(old)}
+		}
+		if err := s.reserve(namespace, k, st, d); err != nil {
+			return err
+		}
+	}
+
+	err := st.Write(record)
+	s.invalidate(k, record.Key)
+	if err != nil {
+		if tracked {
+			s.release(k, d)
+		}
+		return err
+	}
+
+	if watched {
+		event := Create
+		if old != nil {
+			event = Update
+		}
+		s.watchers().publish(k, event, record)
+		s.watchers().expire(k, st, record)
+	}
+
+	return nil
+}
+
+// delete the key from the store publishing the change to the watchers
+func (s *Store) delete(ctx context.Context, st store.Store, key string) error {
+	namespace, prefix := name(ctx)
+	k := namespace + ":" + prefix
+	tracked := s.tracked(namespace, k)
+
+	var old *store.Record
+	if tracked {
+		if recs, err := st.Read(key); err == nil && len(recs) > 0 {
+			old = recs[0]
+		}
+	}
+
+	var d usage
+	if old != nil {
+		d = usage{records: -1, bytes: -size(old)}
+		if err := s.reserve(namespace, k, st, d); err != nil {
+			return err
+		}
+	}
+
+	err := st.Delete(key)
+	s.invalidate(k, key)
+	if err != nil {
+		s.release(k, d)
+		return err
+	}
+
+	s.watchers().cancel(k, key)
+	s.watchers().publish(k, Delete, &store.Record{Key: key})
+
+	return nil
+}
+
+// read the record of the key through the cache if it's set
+func (s *Store) read(ctx context.Context, st store.Store, key string) (*store.Record, error) {
+	fn := func() (*store.Record, error) {
+		recs, err := st.Read(key)
+		if err != nil {
+			return nil, err
+		}
+		if len(recs) == 0 {
+			return nil, store.ErrNotFound
+		}
+		return recs[0], nil
+	}
+
+	if s.Cache == nil {
+		return fn()
+	}
+
+	namespace, prefix := name(ctx)
+	return s.Cache.Read(namespace+":"+prefix, key, fn)
+}
+
+// invalidate the cached record of the key once it's written or deleted
+func (s *Store) invalidate(k, key string) {
+	if s.Cache != nil {
+		s.Cache.Invalidate(k, key)
+	}
+}
+
+// Each calls fn with the default store and the store of each namespace and prefix
+func (s *Store) Each(fn func(namespace, prefix string, st store.Store) error) error {
+	s.RLock()
+	stores := make(map[string]store.Store, len(s.Stores))
+	for k, st := range s.Stores {
+		stores[k] = st
+	}
+	s.RUnlock()
+
+	if err := fn("", "", s.Default); err != nil {
+		return err
+	}
+
+	for k, st := range stores {
+		parts := strings.SplitN(k, ":", 2)
+		if err := fn(parts[0], parts[1], st); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (s *Store) Read(ctx context.Context, req *pb.ReadRequest, rsp *pb.ReadResponse) error {
+	ctx = target(ctx, req.GetOptions().GetDatabase(), req.GetOptions().GetTable())
+
 	// get new store
 	st, err := s.get(ctx)
 	if err != nil {
 		return err
 	}
 
-	var opts []store.ReadOption
-	if req.Options != nil && req.Options.Prefix {
-		opts = append(opts, store.ReadPrefix())
+	opts := req.Options
+	if opts == nil {
+		opts = new(pb.ReadOptions)
 	}
 
-	vals, err := st.Read(req.Key, opts...)
+	var vals []*store.Record
+
+	switch {
+	case opts.Suffix:
+		// the store only reads by prefix so suffixes are matched here
+		vals, err = st.List()
+		if err != nil {
+			return errors.InternalServerError("go.micro.store", err.Error())
+		}
+		prefix := ""
+		if opts.Prefix {
+			prefix = req.Key
+		}
+		vals = match(visible(vals), prefix, req.Key)
+	case opts.Prefix:
+		vals, err = st.Read(req.Key, store.ReadPrefix())
+		vals = visible(vals)
+	default:
+		var r *store.Record
+		if r, err = s.read(ctx, st, req.Key); err == nil {
+			vals = []*store.Record{r}
+		}
+	}
 	if err != nil {
 		return errors.InternalServerError("go.micro.store", err.Error())
 	}
 
+	vals = paginate(vals, opts.Offset, opts.Limit)
+
 	for _, val := range vals {
-		rsp.Records = append(rsp.Records, &pb.Record{
-			Key:    val.Key,
-			Value:  val.Value,
-			Expiry: int64(val.Expiry.Seconds()),
-		})
+		rsp.Records = append(rsp.Records, fromRecord(val))
 	}
 	return nil
 }
 
 func (s *Store) Write(ctx context.Context, req *pb.WriteRequest, rsp *pb.WriteResponse) error {
+	ctx = target(ctx, req.GetOptions().GetDatabase(), req.GetOptions().GetTable())
+
+	done, err := s.begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	if ok, err := s.forward(ctx, "Store.Write", req, rsp); ok {
+		return err
+	}
+
 	// get new store
 	st, err := s.get(ctx)
 	if err != nil {
@@ -95,32 +373,59 @@ func (s *Store) Write(ctx context.Context, req *pb.WriteRequest, rsp *pb.WriteRe
 		return errors.BadRequest("go.micro.store", "no record specified")
 	}
 
-	record := &store.Record{
-		Key:    req.Record.Key,
-		Value:  req.Record.Value,
-		Expiry: time.Duration(req.Record.Expiry) * time.Second,
+	record, err := toRecord(req.Record)
+	if err != nil {
+		return errors.BadRequest("go.micro.store", err.Error())
 	}
 
-	if err := st.Write(record); err != nil {
-		return errors.InternalServerError("go.micro.store", err.Error())
+	unlock := s.lock(ctx)
+	err = s.write(ctx, st, record)
+	if err == nil {
+		err = s.index(st, req.Record, record)
+	}
+	unlock()
+	if err != nil {
+		return storeError(err)
 	}
 
+	s.replicate(ctx, "Store.Write", req, func() interface{} { return new(pb.WriteResponse) })
+
 	return nil
 }
 
 func (s *Store) Delete(ctx context.Context, req *pb.DeleteRequest, rsp *pb.DeleteResponse) error {
+	ctx = target(ctx, req.GetOptions().GetDatabase(), req.GetOptions().GetTable())
+
+	done, err := s.begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	if ok, err := s.forward(ctx, "Store.Delete", req, rsp); ok {
+		return err
+	}
+
 	// get new store
 	st, err := s.get(ctx)
 	if err != nil {
 		return err
 	}
-	if err := st.Delete(req.Key); err != nil {
-		return errors.InternalServerError("go.micro.store", err.Error())
+	unlock := s.lock(ctx)
+	err = s.delete(ctx, st, req.Key)
+	unlock()
+	if err != nil {
+		return storeError(err)
 	}
+
+	s.replicate(ctx, "Store.Delete", req, func() interface{} { return new(pb.DeleteResponse) })
+
 	return nil
 }
 
 func (s *Store) List(ctx context.Context, req *pb.ListRequest, stream pb.Store_ListStream) error {
+	ctx = target(ctx, req.GetOptions().GetDatabase(), req.GetOptions().GetTable())
+
 	// get new store
 	st, err := s.get(ctx)
 	if err != nil {
@@ -131,23 +436,118 @@ func (s *Store) List(ctx context.Context, req *pb.ListRequest, stream pb.Store_L
 	if err != nil {
 		return errors.InternalServerError("go.micro.store", err.Error())
 	}
-	rsp := new(pb.ListResponse)
 
-	// TODO: batch sync
-	for _, val := range vals {
-		rsp.Records = append(rsp.Records, &pb.Record{
-			Key:    val.Key,
-			Value:  val.Value,
-			Expiry: int64(val.Expiry.Seconds()),
-		})
+	opts := req.Options
+	if opts == nil {
+		opts = new(pb.ListOptions)
+	}
+	if !opts.Indexes {
+		vals = visible(vals)
+	}
+	vals = paginate(match(vals, opts.Prefix, opts.Suffix), opts.Offset, opts.Limit)
+
+	// send the records in batches rather than one large message
+	for _, batch := range batches(vals, ListBatch) {
+		rsp := new(pb.ListResponse)
+		for _, val := range batch {
+			rsp.Records = append(rsp.Records, fromRecord(val))
+		}
+
+		err := stream.Send(rsp)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.InternalServerError("go.micro.store", err.Error())
+		}
+	}
+
+	return nil
+}
+
+// Watch streams the changes to the keys of the store starting with the prefix
+func (s *Store) Watch(ctx context.Context, req *pb.WatchRequest, stream pb.Store_WatchStream) error {
+	ctx = target(ctx, req.GetDatabase(), req.GetTable())
+
+	if err := s.check(ctx); err != nil {
+		return err
+	}
+
+	namespace, prefix := name(ctx)
+
+	w := s.watchers().watch(namespace+":"+prefix, req.Prefix)
+	defer s.watchers().stop(w)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev, ok := <-w.events:
+			if !ok {
+				return errors.InternalServerError("go.micro.store", "watcher fell behind")
+			}
+
+			rsp := &pb.WatchEvent{
+				Type:      ev.Type,
+				Record:    fromRecord(ev.Record),
+				Timestamp: ev.Timestamp.Unix(),
+			}
+			// only the key of deleted and expired records is known
+			if ev.Type == Delete || ev.Type == Expire {
+				rsp.Record.Version = ""
+			}
+
+			err := stream.Send(rsp)
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return errors.InternalServerError("go.micro.store", err.Error())
+			}
+		}
 	}
+}
+
+// Backends returns the status of the backend nodes
+func (s *Store) Backends(ctx context.Context, req *pb.BackendsRequest, rsp *pb.BackendsResponse) error {
+	rsp.Backend = s.Backend
+	rsp.OpenStores, rsp.EvictedStores = s.open()
 
-	err = stream.Send(rsp)
-	if err == io.EOF {
+	if s.Cache != nil {
+		st := s.Cache.Stats()
+		rsp.Cache = &pb.CacheStats{
+			Hits:      st.Hits,
+			Misses:    st.Misses,
+			Evictions: st.Evictions,
+			Records:   st.Records,
+			Bytes:     st.Bytes,
+		}
+	}
+
+	// the nodes are used as one without health checks
+	if s.Cluster == nil {
+		rsp.Backends = append(rsp.Backends, &pb.Backend{
+			Node:    strings.Join(s.Nodes, ","),
+			Healthy: true,
+			Active:  true,
+		})
 		return nil
 	}
-	if err != nil {
-		return errors.InternalServerError("go.micro.store", err.Error())
+
+	for _, st := range s.Cluster.Status() {
+		b := &pb.Backend{
+			Node:    st.Node,
+			Healthy: st.Healthy,
+			Active:  st.Active,
+		}
+		if !st.Checked.IsZero() {
+			b.Checked = st.Checked.Unix()
+		}
+		if st.Error != nil {
+			b.Error = st.Error.Error()
+		}
+		rsp.Backends = append(rsp.Backends, b)
 	}
+
 	return nil
 }
@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/store"
+	pb "github.com/micro/micro/v2/store/proto"
+)
+
+const (
+	// IndexPrefix prefixes the keys of the index entries kept alongside the
+	// records, they're hidden from reads and lists
+	IndexPrefix = "\x1fidx\x1f"
+	// indexSep separates the field, value and key of an index entry
+	indexSep = "\x1f"
+)
+
+// indexKey returns the key of the index entry of the record for the field
+func indexKey(field, value, key string) string {
+	return IndexPrefix + field + indexSep + value + indexSep + key
+}
+
+// parseIndexKey returns the value and key of an index entry of the field
+func parseIndexKey(field, k string) (string, string, bool) {
+	k = strings.TrimPrefix(k, IndexPrefix+field+indexSep)
+	parts := strings.SplitN(k, indexSep, 2)
+	if len(parts) != 2 {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+// checkIndexes returns an error if the record can't be indexed by its fields
+func checkIndexes(r *pb.Record) error {
+	if strings.HasPrefix(r.Key, IndexPrefix) {
+		return fmt.Errorf("keys starting with %q are reserved", IndexPrefix)
+	}
+	for _, field := range r.Indexes {
+		if len(field) == 0 || strings.Contains(field, indexSep) {
+			return fmt.Errorf("invalid indexed field %q", field)
+		}
+		value, ok := r.Metadata[field]
+		if !ok {
+			return fmt.Errorf("record %s has no metadata field %s to index", r.Key, field)
+		}
+		if strings.Contains(value, indexSep) {
+			return fmt.Errorf("value of the indexed field %s contains %q", field, indexSep)
+		}
+	}
+	return nil
+}
+
+// visible returns the records without the index entries
+func visible(records []*store.Record) []*store.Record {
+	var recs []*store.Record
+	for _, r := range records {
+		if !strings.HasPrefix(r.Key, IndexPrefix) {
+			recs = append(recs, r)
+		}
+	}
+	return recs
+}
+
+// index writes the index entries of the record once it's written. Entries
+// aren't removed when the record changes, queries skip and delete those no
+// longer matching the record so writes don't have to read the old record.
+func (s *Store) index(st store.Store, r *pb.Record, record *store.Record) error {
+	for _, field := range r.Indexes {
+		err := st.Write(&store.Record{
+			Key:    indexKey(field, r.Metadata[field], r.Key),
+			Expiry: record.Expiry,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// filterMatches returns true if the metadata matches the filter
+func filterMatches(f *pb.Filter, md map[string]string) bool {
+	v, ok := md[f.Field]
+	if !ok {
+		return false
+	}
+	if f.Prefix {
+		return strings.HasPrefix(v, f.Value)
+	}
+	return v == f.Value
+}
+
+// Query returns the records matching the filters on their indexed metadata fields
+func (s *Store) Query(ctx context.Context, req *pb.QueryRequest, rsp *pb.QueryResponse) error {
+	ctx = target(ctx, req.GetOptions().GetDatabase(), req.GetOptions().GetTable())
+
+	if len(req.Filters) == 0 {
+		return errors.BadRequest("go.micro.store", "no filters specified")
+	}
+	for _, f := range req.Filters {
+		if f == nil || len(f.Field) == 0 {
+			return errors.BadRequest("go.micro.store", "filters must specify a field")
+		}
+	}
+
+	st, err := s.get(ctx)
+	if err != nil {
+		return err
+	}
+
+	// the entries of the first filter select the candidate records
+	f := req.Filters[0]
+	prefix := IndexPrefix + f.Field + indexSep + f.Value
+	if !f.Prefix {
+		prefix += indexSep
+	}
+
+	entries, err := st.Read(prefix, store.ReadPrefix())
+	if err != nil && err != store.ErrNotFound {
+		return errors.InternalServerError("go.micro.store", err.Error())
+	}
+
+	seen := make(map[string]bool)
+	var records []*store.Record
+
+	for _, e := range entries {
+		value, key, ok := parseIndexKey(f.Field, e.Key)
+		if !ok || seen[key] {
+			continue
+		}
+
+		r, err := s.read(ctx, st, key)
+		if err == store.ErrNotFound {
+			st.Delete(e.Key)
+			continue
+		}
+		if err != nil {
+			return errors.InternalServerError("go.micro.store", err.Error())
+		}
+
+		_, md := DecodeValue(r.Value)
+
+		// the record changed since the entry was written
+		if md[f.Field] != value {
+			st.Delete(e.Key)
+			continue
+		}
+
+		seen[key] = true
+
+		match := true
+		for _, f := range req.Filters {
+			if !filterMatches(f, md) {
+				match = false
+				break
+			}
+		}
+		if match {
+			records = append(records, r)
+		}
+	}
+
+	for _, r := range paginate(records, req.Offset, req.Limit) {
+		rsp.Records = append(rsp.Records, fromRecord(r))
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/micro/go-micro/v2/store"
+	pb "github.com/micro/micro/v2/store/proto"
+)
+
+func TestIndexKey(t *testing.T) {
+	k := indexKey("owner", "team-a", "user/1")
+
+	value, key, ok := parseIndexKey("owner", k)
+	if !ok || value != "team-a" || key != "user/1" {
+		t.Fatalf("expected team-a and user/1 got %q %q %v", value, key, ok)
+	}
+
+	recs := visible([]*store.Record{{Key: k}, {Key: "user/1"}})
+	if len(recs) != 1 || recs[0].Key != "user/1" {
+		t.Fatalf("expected the index entry to be hidden got %+v", recs)
+	}
+}
+
+func TestCheckIndexes(t *testing.T) {
+	testData := []struct {
+		record *pb.Record
+		valid  bool
+	}{
+		{&pb.Record{Key: "user/1", Metadata: map[string]string{"owner": "team-a"}, Indexes: []string{"owner"}}, true},
+		{&pb.Record{Key: "user/1", Indexes: []string{"owner"}}, false},
+		{&pb.Record{Key: "user/1", Metadata: map[string]string{"owner": "team" + indexSep}, Indexes: []string{"owner"}}, false},
+		{&pb.Record{Key: "user/1", Metadata: map[string]string{"": "x"}, Indexes: []string{""}}, false},
+		{&pb.Record{Key: IndexPrefix + "owner"}, false},
+	}
+
+	for _, d := range testData {
+		if err := checkIndexes(d.record); (err == nil) != d.valid {
+			t.Fatalf("expected %+v valid %v got %v", d.record, d.valid, err)
+		}
+	}
+}
+
+func TestMatches(t *testing.T) {
+	md := map[string]string{"owner": "team-a"}
+
+	if !filterMatches(&pb.Filter{Field: "owner", Value: "team-a"}, md) {
+		t.Fatal("expected an equal value to match")
+	}
+	if filterMatches(&pb.Filter{Field: "owner", Value: "team"}, md) {
+		t.Fatal("expected a partial value not to match")
+	}
+	if !filterMatches(&pb.Filter{Field: "owner", Value: "team", Prefix: true}, md) {
+		t.Fatal("expected the prefix to match")
+	}
+	if filterMatches(&pb.Filter{Field: "team", Value: "", Prefix: true}, md) {
+		t.Fatal("expected a missing field not to match")
+	}
+}
@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/micro/go-micro/v2/errors"
+	pb "github.com/micro/micro/v2/store/proto"
+)
+
+const (
+	// ReadWrite is the mode writes are applied in
+	ReadWrite = "read-write"
+	// ReadOnly is the mode writes are rejected in e.g while migrating or restoring
+	ReadOnly = "read-only"
+	// Maintenance is read only once the writes in flight are drained
+	Maintenance = "maintenance"
+)
+
+var (
+	// DrainTimeout is the default time waited for the writes in flight to finish
+	DrainTimeout = 30 * time.Second
+)
+
+// mode of the store, the zero value is read-write
+type mode struct {
+	sync.Mutex
+	name     string
+	reason   string
+	since    time.Time
+	inflight int64
+}
+
+// SetMode sets the mode of the store with the reason returned with the writes rejected
+func (s *Store) SetMode(name, reason string) error {
+	switch name {
+	case ReadWrite, ReadOnly, Maintenance:
+	default:
+		return errors.BadRequest("go.micro.store", "unknown mode %s, expected %s, %s or %s", name, ReadWrite, ReadOnly, Maintenance)
+	}
+
+	s.mode.Lock()
+	defer s.mode.Unlock()
+
+	if name == ReadWrite {
+		reason = ""
+	}
+	s.mode.name = name
+	s.mode.reason = reason
+	s.mode.since = time.Now()
+
+	return nil
+}
+
+// begin a write returning the func to call once it's applied, writes are rejected
+// unless the store is read-write or they're replicated from the active instance
+func (s *Store) begin(ctx context.Context) (func(), error) {
+	s.mode.Lock()
+	defer s.mode.Unlock()
+
+	if len(s.mode.name) > 0 && s.mode.name != ReadWrite {
+		replicated := false
+		if s.Standby != nil {
+			ok, err := s.Standby.Replicated(ctx)
+			replicated = ok && err == nil
+		}
+		if !replicated {
+			detail := "the store is " + s.mode.name + ", writes are rejected"
+			if len(s.mode.reason) > 0 {
+				detail += ": " + s.mode.reason
+			}
+			return nil, errors.New("go.micro.store", detail, 503)
+		}
+	}
+
+	s.mode.inflight++
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			s.mode.Lock()
+			s.mode.inflight--
+			s.mode.Unlock()
+		})
+	}, nil
+}
+
+// drain waits for the writes in flight to finish
+func (s *Store) drain(timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+
+	for {
+		s.mode.Lock()
+		n := s.mode.inflight
+		s.mode.Unlock()
+
+		if n == 0 {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return errors.Timeout("go.micro.store", "%d writes still in flight after %v", n, timeout)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// Mode sets the mode of the store if requested and returns it. Setting maintenance
+// mode drains the writes in flight and snapshots the stores if requested.
+func (s *Store) Mode(ctx context.Context, req *pb.ModeRequest, rsp *pb.ModeResponse) error {
+	if len(req.Mode) > 0 {
+		if err := s.SetMode(req.Mode, req.Reason); err != nil {
+			return err
+		}
+	}
+
+	if req.Mode == Maintenance {
+		timeout := DrainTimeout
+		if req.Timeout > 0 {
+			timeout = time.Duration(req.Timeout) * time.Second
+		}
+		if err := s.drain(timeout); err != nil {
+			return err
+		}
+
+		if req.Snapshot {
+			if s.Snapshot == nil {
+				return errors.BadRequest("go.micro.store", "no snapshot target, run the store with --snapshot")
+			}
+			n, err := s.Snapshot()
+			if err != nil {
+				return errors.InternalServerError("go.micro.store", "failed to snapshot: %v", err)
+			}
+			rsp.SnapshotRecords = int64(n)
+		}
+	}
+
+	s.mode.Lock()
+	defer s.mode.Unlock()
+
+	rsp.Mode = s.mode.name
+	if len(rsp.Mode) == 0 {
+		rsp.Mode = ReadWrite
+	}
+	rsp.Reason = s.mode.reason
+	if !s.mode.since.IsZero() {
+		rsp.Since = s.mode.since.Unix()
+	}
+	rsp.Inflight = s.mode.inflight
+
+	return nil
+}
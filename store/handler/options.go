@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/micro/go-micro/v2/store"
+)
+
+var (
+	// ListBatch is the number of records sent in each message of a list stream
+	ListBatch = 100
+)
+
+// match returns the records with keys matching the prefix and suffix
+func match(records []*store.Record, prefix, suffix string) []*store.Record {
+	var matched []*store.Record
+	for _, r := range records {
+		if strings.HasPrefix(r.Key, prefix) && strings.HasSuffix(r.Key, suffix) {
+			matched = append(matched, r)
+		}
+	}
+	return matched
+}
+
+// paginate sorts the records by key returning the page from the offset,
+// a limit of zero returns all the records after the offset
+func paginate(records []*store.Record, offset, limit uint64) []*store.Record {
+	sort.Slice(records, func(i, j int) bool { return records[i].Key < records[j].Key })
+
+	if offset >= uint64(len(records)) {
+		return nil
+	}
+	records = records[offset:]
+
+	if limit > 0 && limit < uint64(len(records)) {
+		records = records[:limit]
+	}
+	return records
+}
+
+// batches splits the records into batches of at most size records
+func batches(records []*store.Record, size int) [][]*store.Record {
+	var b [][]*store.Record
+	for len(records) > size {
+		b = append(b, records[:size])
+		records = records[size:]
+	}
+	if len(records) > 0 {
+		b = append(b, records)
+	}
+	return b
+}
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/micro/go-micro/v2/store"
+)
+
+func keys(records []*store.Record) []string {
+	var k []string
+	for _, r := range records {
+		k = append(k, r.Key)
+	}
+	return k
+}
+
+func TestPaginate(t *testing.T) {
+	var records []*store.Record
+	for _, k := range []string{"users/c", "users/a", "teams/a", "users/b", "users/d.json"} {
+		records = append(records, &store.Record{Key: k})
+	}
+
+	testData := []struct {
+		prefix, suffix string
+		offset, limit  uint64
+		expect         []string
+	}{
+		{"", "", 0, 0, []string{"teams/a", "users/a", "users/b", "users/c", "users/d.json"}},
+		{"users/", "", 1, 2, []string{"users/b", "users/c"}},
+		{"", "/a", 0, 0, []string{"teams/a", "users/a"}},
+		{"users/", ".json", 0, 1, []string{"users/d.json"}},
+		{"", "", 10, 0, nil},
+	}
+
+	for _, d := range testData {
+		got := keys(paginate(match(records, d.prefix, d.suffix), d.offset, d.limit))
+		if len(got) != len(d.expect) {
+			t.Fatalf("Expected %v got %v", d.expect, got)
+		}
+		for i := range got {
+			if got[i] != d.expect[i] {
+				t.Fatalf("Expected %v got %v", d.expect, got)
+			}
+		}
+	}
+}
+
+func TestBatches(t *testing.T) {
+	records := make([]*store.Record, 250)
+	b := batches(records, 100)
+	if len(b) != 3 || len(b[0]) != 100 || len(b[2]) != 50 {
+		t.Fatalf("Expected batches of 100, 100 and 50 got %d batches", len(b))
+	}
+	if len(batches(nil, 100)) != 0 {
+		t.Fatal("Expected no batches for no records")
+	}
+}
@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/micro/go-micro/v2/store"
+	pb "github.com/micro/micro/v2/store/proto"
+)
+
+var (
+	// metadataMagic prefixes the values stored with metadata as the
+	// backends only store the key, value and expiry of records
+	metadataMagic = []byte("MSM1")
+)
+
+// EncodeValue returns the value stored for a record with the metadata, the
+// metadata is prefixed as its length and json. Values without metadata are
+// stored as is.
+func EncodeValue(value []byte, md map[string]string) []byte {
+	if len(md) == 0 {
+		return value
+	}
+
+	b, _ := json.Marshal(md)
+	n := make([]byte, binary.MaxVarintLen64)
+	n = n[:binary.PutUvarint(n, uint64(len(b)))]
+
+	v := make([]byte, 0, len(metadataMagic)+len(n)+len(b)+len(value))
+	v = append(v, metadataMagic...)
+	v = append(v, n...)
+	v = append(v, b...)
+	return append(v, value...)
+}
+
+// DecodeValue returns the value and metadata of a stored value
+func DecodeValue(stored []byte) ([]byte, map[string]string) {
+	if !bytes.HasPrefix(stored, metadataMagic) {
+		return stored, nil
+	}
+
+	b := stored[len(metadataMagic):]
+	n, i := binary.Uvarint(b)
+	if i <= 0 || uint64(len(b)-i) < n {
+		return stored, nil
+	}
+
+	var md map[string]string
+	if err := json.Unmarshal(b[i:i+int(n)], &md); err != nil {
+		return stored, nil
+	}
+	return b[i+int(n):], md
+}
+
+// toRecord returns the record stored for the record of a request, the
+// absolute expiry is used over the duration if both are set
+func toRecord(r *pb.Record) (*store.Record, error) {
+	if err := checkIndexes(r); err != nil {
+		return nil, err
+	}
+
+	expiry := time.Duration(r.Expiry) * time.Second
+	if r.Expires > 0 {
+		expiry = time.Until(time.Unix(r.Expires, 0))
+		if expiry <= 0 {
+			return nil, fmt.Errorf("record %s has already expired", r.Key)
+		}
+	}
+
+	return &store.Record{
+		Key:    r.Key,
+		Value:  EncodeValue(r.Value, r.Metadata),
+		Expiry: expiry,
+	}, nil
+}
+
+// fromRecord returns the record of a response for a stored record. The
+// backends read the time remaining until a record expires so it's returned
+// rather than the duration it was written with.
+func fromRecord(r *store.Record) *pb.Record {
+	value, md := DecodeValue(r.Value)
+
+	rec := &pb.Record{
+		Key:      r.Key,
+		Value:    value,
+		Metadata: md,
+		Version:  version(r.Value),
+	}
+
+	if r.Expiry > 0 {
+		// round up so records about to expire aren't returned as never expiring
+		rec.Expiry = int64(math.Ceil(r.Expiry.Seconds()))
+		rec.Expires = time.Now().Unix() + rec.Expiry
+	}
+
+	return rec
+}
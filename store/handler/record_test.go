@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestEncodeValue(t *testing.T) {
+	// values without metadata are stored as is
+	if v := EncodeValue([]byte("bar"), nil); string(v) != "bar" {
+		t.Fatalf("expected the value to be unchanged got %q", v)
+	}
+
+	md := map[string]string{"owner": "team-a", "content-type": "application/json"}
+	stored := EncodeValue([]byte(`{"a":1}`), md)
+
+	value, got := DecodeValue(stored)
+	if !bytes.Equal(value, []byte(`{"a":1}`)) {
+		t.Fatalf("unexpected value %q", value)
+	}
+	if len(got) != 2 || got["owner"] != "team-a" || got["content-type"] != "application/json" {
+		t.Fatalf("unexpected metadata %v", got)
+	}
+
+	// values which only look encoded are returned as is
+	for _, v := range [][]byte{[]byte("MSM1"), []byte("MSM1\x05{}"), []byte("plain")} {
+		if value, md := DecodeValue(v); !bytes.Equal(value, v) || md != nil {
+			t.Errorf("expected %q to be returned as is", v)
+		}
+	}
+}
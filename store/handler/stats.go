@@ -0,0 +1,315 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/micro/go-micro/v2/server"
+	"github.com/micro/go-micro/v2/util/log"
+	"github.com/micro/micro/v2/store/cache"
+	pb "github.com/micro/micro/v2/store/proto"
+)
+
+var (
+	// LatencyBuckets are the upper bounds in seconds of the latency histograms
+	LatencyBuckets = []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5}
+	// SizeBuckets are the upper bounds in bytes of the value size histograms
+	SizeBuckets = []float64{64, 256, 1024, 4096, 16384, 65536, 262144, 1048576, 4194304}
+	// SlowKeys is the maximum number of keys logged for a slow operation
+	SlowKeys = 10
+)
+
+// operation is the stats of an endpoint of the store
+type operation struct {
+	requests uint64
+	errors   uint64
+	slow     uint64
+	// requests in each of the LatencyBuckets plus the overflow
+	latency    []uint64
+	latencySum float64
+	// values in each of the SizeBuckets plus the overflow
+	sizes   []uint64
+	values  uint64
+	sizeSum uint64
+}
+
+// Stats records the requests, latency and size of the values of each store operation
+type Stats struct {
+	// SlowThreshold is the latency above which the keys of an operation are logged
+	SlowThreshold time.Duration
+	// Cache reported with the stats, if set
+	Cache *cache.Cache
+
+	sync.Mutex
+	ops map[string]*operation
+}
+
+// NewStats returns the stats of the store operations logging those slower than the threshold
+func NewStats(slow time.Duration, c *cache.Cache) *Stats {
+	return &Stats{
+		SlowThreshold: slow,
+		Cache:         c,
+		ops:           make(map[string]*operation),
+	}
+}
+
+// get the operation creating it if needed, the lock must be held
+func (s *Stats) get(name string) *operation {
+	op, ok := s.ops[name]
+	if !ok {
+		op = &operation{
+			latency: make([]uint64, len(LatencyBuckets)+1),
+			sizes:   make([]uint64, len(SizeBuckets)+1),
+		}
+		s.ops[name] = op
+	}
+	return op
+}
+
+// observe the latency, error and value sizes of a request
+func (s *Stats) observe(name string, d time.Duration, sizes []int, err error) bool {
+	s.Lock()
+	defer s.Unlock()
+
+	op := s.get(name)
+	op.requests++
+	op.latencySum += d.Seconds()
+	op.latency[sort.SearchFloat64s(LatencyBuckets, d.Seconds())]++
+	if err != nil {
+		op.errors++
+	}
+
+	for _, n := range sizes {
+		op.values++
+		op.sizeSum += uint64(n)
+		op.sizes[sort.SearchFloat64s(SizeBuckets, float64(n))]++
+	}
+
+	slow := s.SlowThreshold > 0 && d >= s.SlowThreshold
+	if slow {
+		op.slow++
+	}
+	return slow
+}
+
+// Wrapper returns the handler wrapper recording the stats of the store endpoints,
+// watches are long lived so they aren't recorded
+func (s *Stats) Wrapper() server.HandlerWrapper {
+	return func(fn server.HandlerFunc) server.HandlerFunc {
+		return func(ctx context.Context, req server.Request, rsp interface{}) error {
+			if !strings.HasPrefix(req.Endpoint(), "Store.") || req.Endpoint() == "Store.Watch" {
+				return fn(ctx, req, rsp)
+			}
+
+			start := time.Now()
+			err := fn(ctx, req, rsp)
+			d := time.Since(start)
+
+			if s.observe(req.Endpoint(), d, sizes(req.Body(), rsp), err) {
+				namespace, prefix := name(ctx)
+				log.Logf("Slow %s of %s:%s took %v: %s", req.Endpoint(), namespace, prefix, d, strings.Join(requestKeys(req.Body()), ", "))
+			}
+
+			return err
+		}
+	}
+}
+
+// sizes returns the sizes of the values written by the request or read by the response
+func sizes(req, rsp interface{}) []int {
+	var records []*pb.Record
+
+	switch r := req.(type) {
+	case *pb.WriteRequest:
+		records = append(records, r.Record)
+	case *pb.BatchWriteRequest:
+		records = r.Records
+	case *pb.TxnRequest:
+		for _, op := range r.Ops {
+			records = append(records, op.Record)
+		}
+	}
+
+	switch r := rsp.(type) {
+	case *pb.ReadResponse:
+		records = append(records, r.Records...)
+	case *pb.BatchReadResponse:
+		records = append(records, r.Records...)
+	}
+
+	var n []int
+	for _, r := range records {
+		if r != nil {
+			n = append(n, len(r.Value))
+		}
+	}
+	return n
+}
+
+// requestKeys returns the keys of the request, at most SlowKeys are returned
+func requestKeys(req interface{}) []string {
+	var k []string
+
+	switch r := req.(type) {
+	case *pb.ReadRequest:
+		k = []string{r.Key}
+	case *pb.WriteRequest:
+		if r.Record != nil {
+			k = []string{r.Record.Key}
+		}
+	case *pb.DeleteRequest:
+		k = []string{r.Key}
+	case *pb.BatchReadRequest:
+		k = r.Keys
+	case *pb.BatchWriteRequest:
+		for _, rec := range r.Records {
+			k = append(k, rec.Key)
+		}
+	case *pb.BatchDeleteRequest:
+		k = r.Keys
+	case *pb.TxnRequest:
+		for _, op := range r.Ops {
+			if op.Record != nil {
+				k = append(k, op.Record.Key)
+			}
+		}
+	case *pb.ListRequest:
+		if r.Options != nil {
+			k = []string{r.Options.Prefix + "*"}
+		}
+	}
+
+	if len(k) > SlowKeys {
+		k = append(k[:SlowKeys:SlowKeys], fmt.Sprintf("and %d more", len(k)-SlowKeys))
+	}
+	return k
+}
+
+// cumulative returns the cumulative histogram of the counts of the buckets
+func cumulative(bounds []float64, counts []uint64) []*pb.Bucket {
+	buckets := make([]*pb.Bucket, 0, len(bounds))
+	var count uint64
+	for i, le := range bounds {
+		count += counts[i]
+		buckets = append(buckets, &pb.Bucket{Le: le, Count: count})
+	}
+	return buckets
+}
+
+// List returns the stats of the operations sorted by name
+func (s *Stats) List() []*pb.Operation {
+	s.Lock()
+	defer s.Unlock()
+
+	names := make([]string, 0, len(s.ops))
+	for name := range s.ops {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	ops := make([]*pb.Operation, 0, len(names))
+	for _, name := range names {
+		op := s.ops[name]
+		ops = append(ops, &pb.Operation{
+			Name:       name,
+			Requests:   op.requests,
+			Errors:     op.errors,
+			Latency:    cumulative(LatencyBuckets, op.latency),
+			LatencySum: op.latencySum,
+			Sizes:      cumulative(SizeBuckets, op.sizes),
+			Values:     op.values,
+			SizeSum:    op.sizeSum,
+			Slow:       op.slow,
+		})
+	}
+
+	return ops
+}
+
+// CacheStats returns the stats of the cache, nil if it isn't set
+func (s *Stats) CacheStats() *pb.CacheStats {
+	if s.Cache == nil {
+		return nil
+	}
+	st := s.Cache.Stats()
+	return &pb.CacheStats{
+		Hits:      st.Hits,
+		Misses:    st.Misses,
+		Evictions: st.Evictions,
+		Records:   st.Records,
+		Bytes:     st.Bytes,
+	}
+}
+
+// write the stats in the prometheus text format
+func (s *Stats) write(w io.Writer) {
+	ops := s.List()
+
+	fmt.Fprintln(w, "# HELP micro_store_request_duration_seconds Latency of the store operations.")
+	fmt.Fprintln(w, "# TYPE micro_store_request_duration_seconds histogram")
+	for _, op := range ops {
+		for _, b := range op.Latency {
+			fmt.Fprintf(w, "micro_store_request_duration_seconds_bucket{operation=%q,le=\"%g\"} %d\n", op.Name, b.Le, b.Count)
+		}
+		fmt.Fprintf(w, "micro_store_request_duration_seconds_bucket{operation=%q,le=\"+Inf\"} %d\n", op.Name, op.Requests)
+		fmt.Fprintf(w, "micro_store_request_duration_seconds_sum{operation=%q} %g\n", op.Name, op.LatencySum)
+		fmt.Fprintf(w, "micro_store_request_duration_seconds_count{operation=%q} %d\n", op.Name, op.Requests)
+	}
+
+	fmt.Fprintln(w, "# HELP micro_store_value_size_bytes Size of the values read or written by the store operations.")
+	fmt.Fprintln(w, "# TYPE micro_store_value_size_bytes histogram")
+	for _, op := range ops {
+		if op.Values == 0 {
+			continue
+		}
+		for _, b := range op.Sizes {
+			fmt.Fprintf(w, "micro_store_value_size_bytes_bucket{operation=%q,le=\"%g\"} %d\n", op.Name, b.Le, b.Count)
+		}
+		fmt.Fprintf(w, "micro_store_value_size_bytes_bucket{operation=%q,le=\"+Inf\"} %d\n", op.Name, op.Values)
+		fmt.Fprintf(w, "micro_store_value_size_bytes_sum{operation=%q} %d\n", op.Name, op.SizeSum)
+		fmt.Fprintf(w, "micro_store_value_size_bytes_count{operation=%q} %d\n", op.Name, op.Values)
+	}
+
+	fmt.Fprintln(w, "# HELP micro_store_errors_total Errors returned by the store operations.")
+	fmt.Fprintln(w, "# TYPE micro_store_errors_total counter")
+	for _, op := range ops {
+		fmt.Fprintf(w, "micro_store_errors_total{operation=%q} %d\n", op.Name, op.Errors)
+	}
+
+	fmt.Fprintln(w, "# HELP micro_store_slow_requests_total Store operations slower than the slow operation threshold.")
+	fmt.Fprintln(w, "# TYPE micro_store_slow_requests_total counter")
+	for _, op := range ops {
+		fmt.Fprintf(w, "micro_store_slow_requests_total{operation=%q} %d\n", op.Name, op.Slow)
+	}
+
+	c := s.CacheStats()
+	if c == nil {
+		return
+	}
+
+	fmt.Fprintln(w, "# HELP micro_store_cache_requests_total Reads of the cache by result.")
+	fmt.Fprintln(w, "# TYPE micro_store_cache_requests_total counter")
+	fmt.Fprintf(w, "micro_store_cache_requests_total{result=\"hit\"} %d\n", c.Hits)
+	fmt.Fprintf(w, "micro_store_cache_requests_total{result=\"miss\"} %d\n", c.Misses)
+	fmt.Fprintln(w, "# HELP micro_store_cache_evictions_total Records evicted from the cache to make room for others.")
+	fmt.Fprintln(w, "# TYPE micro_store_cache_evictions_total counter")
+	fmt.Fprintf(w, "micro_store_cache_evictions_total %d\n", c.Evictions)
+	fmt.Fprintln(w, "# HELP micro_store_cache_records Records cached.")
+	fmt.Fprintln(w, "# TYPE micro_store_cache_records gauge")
+	fmt.Fprintf(w, "micro_store_cache_records %d\n", c.Records)
+	fmt.Fprintln(w, "# HELP micro_store_cache_bytes Size of the records cached.")
+	fmt.Fprintln(w, "# TYPE micro_store_cache_bytes gauge")
+	fmt.Fprintf(w, "micro_store_cache_bytes %d\n", c.Bytes)
+}
+
+// ServeHTTP serves the prometheus metrics
+func (s *Stats) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	s.write(w)
+}
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/micro/micro/v2/store/proto"
+)
+
+func TestStats(t *testing.T) {
+	s := NewStats(100*time.Millisecond, nil)
+
+	write := &pb.WriteRequest{Record: &pb.Record{Key: "foo", Value: make([]byte, 100)}}
+	if s.observe("Store.Write", 10*time.Millisecond, sizes(write, nil), nil) {
+		t.Fatal("expected the write not to be slow")
+	}
+
+	read := &pb.ReadResponse{Records: []*pb.Record{{Key: "foo", Value: make([]byte, 2000)}}}
+	if !s.observe("Store.Read", 200*time.Millisecond, sizes(&pb.ReadRequest{Key: "foo"}, read), errors.New("timeout")) {
+		t.Fatal("expected the read to be slow")
+	}
+
+	ops := s.List()
+	if len(ops) != 2 || ops[0].Name != "Store.Read" || ops[1].Name != "Store.Write" {
+		t.Fatalf("unexpected operations %v", ops)
+	}
+
+	rd := ops[0]
+	if rd.Requests != 1 || rd.Errors != 1 || rd.Slow != 1 || rd.Values != 1 || rd.SizeSum != 2000 {
+		t.Fatalf("unexpected read stats %+v", rd)
+	}
+	// cumulative so the buckets from 4096 bytes count the 2000 byte value
+	for _, b := range rd.Sizes {
+		expect := uint64(0)
+		if b.Le >= 4096 {
+			expect = 1
+		}
+		if b.Count != expect {
+			t.Fatalf("expected %d values of at most %g bytes got %d", expect, b.Le, b.Count)
+		}
+	}
+
+	var buf bytes.Buffer
+	s.write(&buf)
+	if !strings.Contains(buf.String(), `micro_store_request_duration_seconds_count{operation="Store.Write"} 1`) {
+		t.Fatalf("expected the write to be counted got\n%s", buf.String())
+	}
+}
+
+func TestRequestKeys(t *testing.T) {
+	var ks []string
+	for i := 0; i < SlowKeys+5; i++ {
+		ks = append(ks, "key")
+	}
+
+	k := requestKeys(&pb.BatchDeleteRequest{Keys: ks})
+	if len(k) != SlowKeys+1 || k[SlowKeys] != "and 5 more" {
+		t.Fatalf("expected the keys to be truncated got %v", k)
+	}
+	// the keys of the request are left as is
+	if len(ks) != SlowKeys+5 || ks[SlowKeys] != "key" {
+		t.Fatalf("expected the request not to be modified got %v", ks)
+	}
+
+	if k := requestKeys(&pb.ListRequest{Options: &pb.ListOptions{Prefix: "user/"}}); k[0] != "user/*" {
+		t.Fatalf("unexpected keys %v", k)
+	}
+}
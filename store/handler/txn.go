@@ -0,0 +1,190 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/store"
+	pb "github.com/micro/micro/v2/store/proto"
+)
+
+const (
+	// Write op of a transaction
+	Write = "write"
+)
+
+// version of the value compared by transactions
+func version(value []byte) string {
+	h := sha256.Sum256(value)
+	return hex.EncodeToString(h[:8])
+}
+
+// lock the store the request is for, writes through the service
+// are serialised so transactions are applied atomically
+func (s *Store) lock(ctx context.Context) func() {
+	namespace, prefix := name(ctx)
+	return s.lockKey(namespace + ":" + prefix)
+}
+
+// lockKey locks the store by its namespace and prefix
+func (s *Store) lockKey(k string) func() {
+	v, _ := s.locks.LoadOrStore(k, new(sync.Mutex))
+	mtx := v.(*sync.Mutex)
+	mtx.Lock()
+	return mtx.Unlock
+}
+
+// read the record of the key returning nil if it doesn't exist
+func read(st store.Store, key string) (*store.Record, error) {
+	recs, err := st.Read(key)
+	if err == store.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range recs {
+		if r.Key == key {
+			return r, nil
+		}
+	}
+	return nil, nil
+}
+
+// holds returns true if the condition holds for the record
+func holds(c *pb.Condition, r *store.Record) bool {
+	if c.IfMissing {
+		return r == nil
+	}
+	if r == nil {
+		return false
+	}
+	if value, _ := DecodeValue(r.Value); len(c.IfValue) > 0 && !bytes.Equal(c.IfValue, value) {
+		return false
+	}
+	if len(c.IfVersion) > 0 && c.IfVersion != version(r.Value) {
+		return false
+	}
+	return true
+}
+
+// Txn applies the ops if every condition holds. The ops are applied atomically
+// with respect to the other writes made through the service, those applied
+// are rolled back if one fails. Backends shared with other instances can't be
+// written atomically so require the store to run as an active/standby pair,
+// otherwise a 501 is returned.
+func (s *Store) Txn(ctx context.Context, req *pb.TxnRequest, rsp *pb.TxnResponse) error {
+	ctx = target(ctx, req.GetOptions().GetDatabase(), req.GetOptions().GetTable())
+
+	done, err := s.begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	if ok, err := s.forward(ctx, "Store.Txn", req, rsp); ok {
+		return err
+	}
+
+	if s.Backend != "memory" && s.Standby == nil {
+		return errors.New("go.micro.store", "transactions on the "+s.Backend+" backend require the store to run with --standby", 501)
+	}
+
+	if len(req.Ops) == 0 {
+		return errors.BadRequest("go.micro.store", "no ops specified")
+	}
+	for _, op := range req.Ops {
+		if op.Record == nil || (op.Type != Write && op.Type != Delete) {
+			return errors.BadRequest("go.micro.store", "ops must write or delete a record")
+		}
+		if _, err := toRecord(op.Record); op.Type == Write && err != nil {
+			return errors.BadRequest("go.micro.store", err.Error())
+		}
+	}
+
+	st, err := s.get(ctx)
+	if err != nil {
+		return err
+	}
+
+	unlock := s.lock(ctx)
+	defer unlock()
+
+	for _, c := range req.Conditions {
+		r, err := read(st, c.Key)
+		if err != nil {
+			return errors.InternalServerError("go.micro.store", err.Error())
+		}
+		if !holds(c, r) {
+			rsp.Failed = append(rsp.Failed, c.Key)
+		}
+	}
+	if len(rsp.Failed) > 0 {
+		return nil
+	}
+
+	// the records before the ops are applied to roll back to
+	type prior struct {
+		key    string
+		record *store.Record
+	}
+	var applied []prior
+
+	rollback := func() {
+		for i := len(applied) - 1; i >= 0; i-- {
+			p := applied[i]
+			if p.record != nil {
+				s.write(ctx, st, p.record)
+			} else {
+				s.delete(ctx, st, p.key)
+			}
+		}
+	}
+
+	rsp.Versions = make(map[string]string)
+
+	for _, op := range req.Ops {
+		key := op.Record.Key
+
+		r, err := read(st, key)
+		if err != nil {
+			rollback()
+			return errors.InternalServerError("go.micro.store", err.Error())
+		}
+
+		switch op.Type {
+		case Write:
+			var record *store.Record
+			if record, err = toRecord(op.Record); err == nil {
+				err = s.write(ctx, st, record)
+				rsp.Versions[key] = version(record.Value)
+			}
+			if err == nil {
+				err = s.index(st, op.Record, record)
+			}
+		case Delete:
+			if r == nil {
+				continue
+			}
+			err = s.delete(ctx, st, key)
+			delete(rsp.Versions, key)
+		}
+		if err != nil {
+			rollback()
+			return storeError(err)
+		}
+
+		applied = append(applied, prior{key: key, record: r})
+	}
+
+	rsp.Committed = true
+
+	// replicas apply the ops unconditionally
+	s.replicate(ctx, "Store.Txn", &pb.TxnRequest{Ops: req.Ops}, func() interface{} { return new(pb.TxnResponse) })
+
+	return nil
+}
@@ -0,0 +1,251 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/store"
+	pb "github.com/micro/micro/v2/store/proto"
+)
+
+var (
+	// byte suffixes, longest first so Mi matches before M
+	byteUnits = []struct {
+		suffix string
+		size   int64
+	}{
+		{"Ki", 1 << 10},
+		{"Mi", 1 << 20},
+		{"Gi", 1 << 30},
+		{"Ti", 1 << 40},
+		{"K", 1 << 10},
+		{"M", 1 << 20},
+		{"G", 1 << 30},
+		{"T", 1 << 40},
+	}
+)
+
+// Quota limits what's stored in each store of a namespace, zero is unlimited
+type Quota struct {
+	// Records stored
+	Records int64
+	// Bytes of the keys and values stored
+	Bytes int64
+}
+
+// usage of a store
+type usage struct {
+	records int64
+	bytes   int64
+}
+
+// parseBytes parses a size e.g 256M, 1Gi or 1048576
+func parseBytes(v string) (int64, error) {
+	v = strings.TrimSpace(v)
+	for _, unit := range byteUnits {
+		if !strings.HasSuffix(v, unit.suffix) {
+			continue
+		}
+		n, err := strconv.ParseFloat(strings.TrimSuffix(v, unit.suffix), 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid size %s", v)
+		}
+		return int64(n * float64(unit.size)), nil
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size %s", v)
+	}
+	return n, nil
+}
+
+// ParseQuotas parses the quotas of the namespaces, * sets the default
+// e.g *=records:10000,bytes:100Mi;team-a=bytes:1Gi
+func ParseQuotas(v string) (map[string]Quota, error) {
+	quotas := make(map[string]Quota)
+
+	for _, entry := range strings.Split(v, ";") {
+		entry = strings.TrimSpace(entry)
+		if len(entry) == 0 {
+			continue
+		}
+
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 || len(strings.TrimSpace(parts[0])) == 0 {
+			return nil, fmt.Errorf("invalid quota %s", entry)
+		}
+
+		var q Quota
+
+		for _, limit := range strings.Split(parts[1], ",") {
+			kv := strings.SplitN(strings.TrimSpace(limit), ":", 2)
+			if len(kv) != 2 {
+				return nil, fmt.Errorf("invalid quota %s", entry)
+			}
+
+			var err error
+
+			switch kv[0] {
+			case "records":
+				q.Records, err = strconv.ParseInt(kv[1], 10, 64)
+			case "bytes":
+				q.Bytes, err = parseBytes(kv[1])
+			default:
+				err = fmt.Errorf("unknown limit %s", kv[0])
+			}
+			if err != nil {
+				return nil, fmt.Errorf("invalid quota %s: %v", entry, err)
+			}
+		}
+
+		quotas[strings.TrimSpace(parts[0])] = q
+	}
+
+	return quotas, nil
+}
+
+// size of the record counted against the quota
+func size(r *store.Record) int64 {
+	return int64(len(r.Key) + len(r.Value))
+}
+
+// count the records and bytes of the store
+func count(st store.Store) (*usage, error) {
+	recs, err := st.List()
+	if err != nil {
+		return nil, err
+	}
+
+	u := new(usage)
+	for _, r := range recs {
+		u.records++
+		u.bytes += size(r)
+	}
+	return u, nil
+}
+
+// quota returns the quota of the namespace falling back to the default
+func (s *Store) quota(namespace string) (Quota, bool) {
+	if q, ok := s.Quotas[namespace]; ok {
+		return q, true
+	}
+	q, ok := s.Quotas["*"]
+	return q, ok
+}
+
+// tracked returns true if the usage of the store is kept up to date
+// on writes, either as it's been reported or is under a quota
+func (s *Store) tracked(namespace, k string) bool {
+	if _, ok := s.quota(namespace); ok {
+		return true
+	}
+
+	s.usageMu.Lock()
+	defer s.usageMu.Unlock()
+	_, ok := s.usage[k]
+	return ok
+}
+
+// reserve adds the change to the usage of the store counting it first if
+// it's not known. Changes growing the usage beyond the quota are rejected.
+func (s *Store) reserve(namespace, k string, st store.Store, d usage) error {
+	s.usageMu.Lock()
+	defer s.usageMu.Unlock()
+
+	u, ok := s.usage[k]
+	if !ok {
+		var err error
+		if u, err = count(st); err != nil {
+			return errors.InternalServerError("go.micro.store", err.Error())
+		}
+		if s.usage == nil {
+			s.usage = make(map[string]*usage)
+		}
+		s.usage[k] = u
+	}
+
+	if q, ok := s.quota(namespace); ok {
+		if d.records > 0 && q.Records > 0 && u.records+d.records > q.Records {
+			return errors.New("go.micro.store", fmt.Sprintf("namespace %s is over its quota of %d records", namespace, q.Records), 507)
+		}
+		if d.bytes > 0 && q.Bytes > 0 && u.bytes+d.bytes > q.Bytes {
+			return errors.New("go.micro.store", fmt.Sprintf("namespace %s is over its quota of %d bytes", namespace, q.Bytes), 507)
+		}
+	}
+
+	u.records += d.records
+	u.bytes += d.bytes
+
+	return nil
+}
+
+// storeError passes on the errors with a status such as a quota being
+// exceeded, any other error is internal
+func storeError(err error) error {
+	if e, ok := err.(*errors.Error); ok {
+		return e
+	}
+	return errors.InternalServerError("go.micro.store", err.Error())
+}
+
+// release reverts a change reserved but not applied
+func (s *Store) release(k string, d usage) {
+	s.usageMu.Lock()
+	defer s.usageMu.Unlock()
+
+	if u, ok := s.usage[k]; ok {
+		u.records -= d.records
+		u.bytes -= d.bytes
+	}
+}
+
+// forget the usage of the evicted store, it's counted again when reopened
+func (s *Store) forget(k string) {
+	s.usageMu.Lock()
+	delete(s.usage, k)
+	s.usageMu.Unlock()
+}
+
+// Usage reports the records and bytes stored in each open store. The stores
+// are counted again as records expire without the usage being updated.
+func (s *Store) Usage(ctx context.Context, req *pb.UsageRequest, rsp *pb.UsageResponse) error {
+	return s.Each(func(namespace, prefix string, st store.Store) error {
+		if len(req.Namespace) > 0 && namespace != req.Namespace {
+			return nil
+		}
+		// only report the namespaces the caller can access
+		if s.ACL != nil && s.ACL.Check(ctx, namespace) != nil {
+			return nil
+		}
+
+		k := namespace + ":" + prefix
+		unlock := s.lockKey(k)
+		defer unlock()
+
+		u, err := count(st)
+		if err != nil {
+			return errors.InternalServerError("go.micro.store", err.Error())
+		}
+
+		s.usageMu.Lock()
+		if s.usage == nil {
+			s.usage = make(map[string]*usage)
+		}
+		s.usage[k] = u
+		s.usageMu.Unlock()
+
+		q, _ := s.quota(namespace)
+		rsp.Usage = append(rsp.Usage, &pb.Usage{
+			Namespace:    namespace,
+			Prefix:       prefix,
+			Records:      u.records,
+			Bytes:        u.bytes,
+			QuotaRecords: q.Records,
+			QuotaBytes:   q.Bytes,
+		})
+		return nil
+	})
+}
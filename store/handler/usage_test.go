@@ -0,0 +1,25 @@
+package handler
+
+import (
+	"testing"
+)
+
+func TestParseQuotas(t *testing.T) {
+	quotas, err := ParseQuotas("*=records:10000,bytes:100Mi; team-a=bytes:1Gi")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if q := quotas["*"]; q.Records != 10000 || q.Bytes != 100<<20 {
+		t.Fatalf("unexpected default quota %+v", q)
+	}
+	if q := quotas["team-a"]; q.Records != 0 || q.Bytes != 1<<30 {
+		t.Fatalf("unexpected team-a quota %+v", q)
+	}
+
+	for _, v := range []string{"team-a", "=records:1", "team-a=files:1", "team-a=bytes:lots"} {
+		if _, err := ParseQuotas(v); err == nil {
+			t.Errorf("expected %q to be invalid", v)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/micro/go-micro/v2/store"
+)
+
+const (
+	// Create is the event of a key being written for the first time
+	Create = "create"
+	// Update is the event of an existing key being written
+	Update = "update"
+	// Delete is the event of a key being deleted
+	Delete = "delete"
+	// Expire is the event of a key expiring
+	Expire = "expire"
+)
+
+var (
+	// WatchBuffer is the number of events buffered for a watcher,
+	// watchers which fall further behind are stopped
+	WatchBuffer = 64
+)
+
+// Event is a change to a record of a store
+type Event struct {
+	Type      string
+	Record    *store.Record
+	Timestamp time.Time
+}
+
+type watcher struct {
+	id     string
+	store  string
+	prefix string
+	events chan *Event
+}
+
+// watchers of the changes to the stores
+type watchers struct {
+	sync.RWMutex
+	watchers map[string]*watcher
+	// expiry timers of the watched keys
+	timers map[string]*time.Timer
+}
+
+func newWatchers() *watchers {
+	return &watchers{
+		watchers: make(map[string]*watcher),
+		timers:   make(map[string]*time.Timer),
+	}
+}
+
+// watch the keys of the store starting with the prefix, the
+// events channel is closed if the watcher falls behind
+func (w *watchers) watch(st, prefix string) *watcher {
+	wt := &watcher{
+		id:     uuid.New().String(),
+		store:  st,
+		prefix: prefix,
+		events: make(chan *Event, WatchBuffer),
+	}
+
+	w.Lock()
+	w.watchers[wt.id] = wt
+	w.Unlock()
+
+	return wt
+}
+
+// stop the watcher
+func (w *watchers) stop(wt *watcher) {
+	w.Lock()
+	defer w.Unlock()
+
+	if _, ok := w.watchers[wt.id]; ok {
+		delete(w.watchers, wt.id)
+		close(wt.events)
+	}
+}
+
+// watched returns true if the key of the store is being watched
+func (w *watchers) watched(st, key string) bool {
+	w.RLock()
+	defer w.RUnlock()
+
+	for _, wt := range w.watchers {
+		if wt.store == st && strings.HasPrefix(key, wt.prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// watching returns true if any key of the store is being watched
+func (w *watchers) watching(st string) bool {
+	w.RLock()
+	defer w.RUnlock()
+
+	for _, wt := range w.watchers {
+		if wt.store == st {
+			return true
+		}
+	}
+	return false
+}
+
+// publish the event to the watchers of the key
+func (w *watchers) publish(st, typ string, record *store.Record) {
+	ev := &Event{
+		Type:      typ,
+		Record:    record,
+		Timestamp: time.Now(),
+	}
+
+	w.Lock()
+	defer w.Unlock()
+
+	for id, wt := range w.watchers {
+		if wt.store != st || !strings.HasPrefix(record.Key, wt.prefix) {
+			continue
+		}
+		select {
+		case wt.events <- ev:
+		default:
+			// the watcher fell behind
+			delete(w.watchers, id)
+			close(wt.events)
+		}
+	}
+}
+
+// expire publishes an expire event once the record has expired from the store,
+// it replaces the timer of an earlier write of the key
+func (w *watchers) expire(st string, s store.Store, record *store.Record) {
+	k := st + "/" + record.Key
+
+	w.Lock()
+	defer w.Unlock()
+
+	if t, ok := w.timers[k]; ok {
+		t.Stop()
+		delete(w.timers, k)
+	}
+
+	if record.Expiry <= 0 {
+		return
+	}
+
+	var t *time.Timer
+	t = time.AfterFunc(record.Expiry, func() {
+		w.Lock()
+		// replaced by a later write
+		if w.timers[k] != t {
+			w.Unlock()
+			return
+		}
+		delete(w.timers, k)
+		w.Unlock()
+
+		if _, err := s.Read(record.Key); err == store.ErrNotFound {
+			w.publish(st, Expire, &store.Record{Key: record.Key})
+		}
+	})
+	w.timers[k] = t
+}
+
+// cancel the expiry timer of the deleted key
+func (w *watchers) cancel(st, key string) {
+	k := st + "/" + key
+
+	w.Lock()
+	defer w.Unlock()
+
+	if t, ok := w.timers[k]; ok {
+		t.Stop()
+		delete(w.timers, k)
+	}
+}
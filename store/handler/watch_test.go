@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/micro/go-micro/v2/store"
+	"github.com/micro/go-micro/v2/store/memory"
+)
+
+func TestWatchers(t *testing.T) {
+	w := newWatchers()
+
+	users := w.watch("ns:", "users/")
+	defer w.stop(users)
+
+	if !w.watched("ns:", "users/1") || w.watched("ns:", "teams/1") || w.watched("other:", "users/1") {
+		t.Fatal("Expected only the users of the ns store to be watched")
+	}
+
+	w.publish("ns:", Create, &store.Record{Key: "teams/1"})
+	w.publish("other:", Create, &store.Record{Key: "users/1"})
+	w.publish("ns:", Update, &store.Record{Key: "users/1", Value: []byte("bar")})
+
+	select {
+	case ev := <-users.events:
+		if ev.Type != Update || ev.Record.Key != "users/1" {
+			t.Fatalf("Expected an update of users/1 got %s of %s", ev.Type, ev.Record.Key)
+		}
+	default:
+		t.Fatal("Expected an event")
+	}
+
+	if len(users.events) > 0 {
+		t.Fatal("Expected no events for other keys or stores")
+	}
+}
+
+func TestWatcherBehind(t *testing.T) {
+	w := newWatchers()
+	wt := w.watch("", "")
+
+	for i := 0; i <= WatchBuffer; i++ {
+		w.publish("", Create, &store.Record{Key: "key"})
+	}
+
+	n := 0
+	for range wt.events {
+		n++
+	}
+	if n != WatchBuffer {
+		t.Fatalf("Expected %d buffered events got %d", WatchBuffer, n)
+	}
+	if w.watched("", "key") {
+		t.Fatal("Expected the watcher to be stopped")
+	}
+
+	// stopping again is a noop
+	w.stop(wt)
+}
+
+func TestWatchExpire(t *testing.T) {
+	w := newWatchers()
+	wt := w.watch("", "")
+	defer w.stop(wt)
+
+	st := memory.NewStore()
+	record := &store.Record{Key: "session", Expiry: 10 * time.Millisecond}
+
+	// the timer of a cancelled key doesn't fire
+	w.expire("", st, record)
+	w.cancel("", record.Key)
+
+	// the record still exists when the timer fires, it's written without
+	// an expiry so the memory store doesn't expire it first
+	st.Write(&store.Record{Key: record.Key})
+	w.expire("", st, record)
+	time.Sleep(30 * time.Millisecond)
+	if len(wt.events) > 0 {
+		t.Fatal("Expected no expire event for an existing record")
+	}
+
+	// the record has expired from the store
+	st.Delete(record.Key)
+	w.expire("", st, record)
+
+	select {
+	case ev := <-wt.events:
+		if ev.Type != Expire || ev.Record.Key != "session" {
+			t.Fatalf("Expected session to expire got %s of %s", ev.Type, ev.Record.Key)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected an expire event")
+	}
+}
@@ -0,0 +1,159 @@
+package store
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/micro/go-micro/v2/client"
+	"github.com/micro/go-micro/v2/metadata"
+	"github.com/micro/go-micro/v2/registry"
+	"github.com/micro/go-micro/v2/util/log"
+	"github.com/micro/micro/v2/store/cache"
+	pb "github.com/micro/micro/v2/store/proto"
+)
+
+var (
+	// InvalidateInterval is how often the other store nodes are looked up
+	// to watch the stores with records cached
+	InvalidateInterval = time.Second * 10
+)
+
+// invalidator watches the stores with records cached on the other nodes of
+// the store service, invalidating the records written through them
+type invalidator struct {
+	id       string
+	cache    *cache.Cache
+	registry registry.Registry
+	client   client.Client
+
+	sync.Mutex
+	// watches by node id and store
+	watches map[string]context.CancelFunc
+
+	exit chan bool
+}
+
+func newInvalidator(id string, c *cache.Cache, r registry.Registry, cl client.Client) *invalidator {
+	return &invalidator{
+		id:       id,
+		cache:    c,
+		registry: r,
+		client:   cl,
+		watches:  make(map[string]context.CancelFunc),
+		exit:     make(chan bool),
+	}
+}
+
+// peers returns the other nodes of the store service
+func (i *invalidator) peers() []*registry.Node {
+	services, err := i.registry.GetService(Name)
+	if err != nil {
+		log.Debugf("Failed to get the store nodes: %v", err)
+		return nil
+	}
+
+	var nodes []*registry.Node
+	for _, srv := range services {
+		for _, node := range srv.Nodes {
+			if node.Id != i.id {
+				nodes = append(nodes, node)
+			}
+		}
+	}
+	return nodes
+}
+
+// update starts watching the stores cached on the nodes not yet watched
+func (i *invalidator) update() {
+	stores := i.cache.Stores()
+	if len(stores) == 0 {
+		return
+	}
+
+	for _, node := range i.peers() {
+		for _, st := range stores {
+			k := node.Id + "/" + st
+
+			i.Lock()
+			_, ok := i.watches[k]
+			if !ok {
+				ctx, cancel := context.WithCancel(context.Background())
+				i.watches[k] = cancel
+				go i.watch(ctx, k, node, st)
+			}
+			i.Unlock()
+		}
+	}
+}
+
+// watch the store on the node invalidating the records changed until the stream fails
+func (i *invalidator) watch(ctx context.Context, k string, node *registry.Node, st string) {
+	defer func() {
+		i.Lock()
+		delete(i.watches, k)
+		i.Unlock()
+		// changes may have been missed while not watching
+		i.cache.Purge(st)
+	}()
+
+	parts := strings.SplitN(st, ":", 2)
+	md := make(metadata.Metadata)
+	if len(parts[0]) > 0 {
+		md["Micro-Namespace"] = parts[0]
+	}
+	if len(parts) > 1 && len(parts[1]) > 0 {
+		md["Micro-Prefix"] = parts[1]
+	}
+
+	stream, err := pb.NewStoreService(Name, i.client).Watch(
+		metadata.NewContext(ctx, md),
+		&pb.WatchRequest{},
+		client.WithAddress(node.Address),
+	)
+	if err != nil {
+		log.Debugf("Failed to watch the store %s on %s: %v", st, node.Id, err)
+		return
+	}
+	defer stream.Close()
+
+	for {
+		ev, err := stream.Recv()
+		if err != nil {
+			log.Debugf("Stopped watching the store %s on %s: %v", st, node.Id, err)
+			return
+		}
+		if ev.Record != nil {
+			i.cache.Invalidate(st, ev.Record.Key)
+		}
+	}
+}
+
+// Start watching the other nodes
+func (i *invalidator) Start() {
+	go func() {
+		t := time.NewTicker(InvalidateInterval)
+		defer t.Stop()
+
+		for {
+			select {
+			case <-t.C:
+				i.update()
+			case <-i.exit:
+				return
+			}
+		}
+	}()
+}
+
+// Stop watching the other nodes
+func (i *invalidator) Stop() {
+	close(i.exit)
+
+	i.Lock()
+	defer i.Unlock()
+	for _, cancel := range i.watches {
+		cancel()
+	}
+}
@@ -0,0 +1,390 @@
+package redis
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// Timeout of dialing and each command
+	Timeout = 5 * time.Second
+	// MaxIdle is the number of idle connections kept to each node
+	MaxIdle = 8
+
+	// errNil is the reply of a key which doesn't exist
+	errNil = errors.New("redis: nil")
+)
+
+// replyError is an error replied by redis e.g MOVED 3999 127.0.0.1:6381
+type replyError string
+
+func (e replyError) Error() string {
+	return string(e)
+}
+
+// conn is a connection to a node, commands are written and their replies read in turn
+type conn struct {
+	net.Conn
+	r *bufio.Reader
+	w *bufio.Writer
+}
+
+// client of a single redis or the nodes of a redis cluster
+type client struct {
+	password string
+	db       int
+	cluster  bool
+
+	sync.Mutex
+	// addrs of the nodes, the first is tried first
+	addrs []string
+	// idle connections by address
+	idle map[string][]*conn
+	// slots maps the hash slots to the address of the node serving them
+	slots map[uint16]string
+}
+
+func newClient(addrs []string, password string, db int, cluster bool) *client {
+	return &client{
+		password: password,
+		db:       db,
+		cluster:  cluster,
+		addrs:    addrs,
+		idle:     make(map[string][]*conn),
+		slots:    make(map[uint16]string),
+	}
+}
+
+func (c *client) dial(addr string) (*conn, error) {
+	nc, err := net.DialTimeout("tcp", addr, Timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	cn := &conn{Conn: nc, r: bufio.NewReader(nc), w: bufio.NewWriter(nc)}
+
+	if len(c.password) > 0 {
+		if _, err := cn.do("AUTH", c.password); err != nil {
+			cn.Close()
+			return nil, err
+		}
+	}
+	// clusters only have database 0
+	if c.db > 0 && !c.cluster {
+		if _, err := cn.do("SELECT", c.db); err != nil {
+			cn.Close()
+			return nil, err
+		}
+	}
+
+	return cn, nil
+}
+
+// get an idle connection to the node or dial it
+func (c *client) get(addr string) (*conn, error) {
+	c.Lock()
+	if idle := c.idle[addr]; len(idle) > 0 {
+		cn := idle[len(idle)-1]
+		c.idle[addr] = idle[:len(idle)-1]
+		c.Unlock()
+		return cn, nil
+	}
+	c.Unlock()
+	return c.dial(addr)
+}
+
+// put the connection back unless it failed or enough are idle
+func (c *client) put(addr string, cn *conn, err error) {
+	if _, ok := err.(replyError); err != nil && !ok && err != errNil {
+		cn.Close()
+		return
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	if len(c.idle[addr]) >= MaxIdle {
+		cn.Close()
+		return
+	}
+	c.idle[addr] = append(c.idle[addr], cn)
+}
+
+// addr returns the address of the node serving the key
+func (c *client) addr(key string) string {
+	c.Lock()
+	defer c.Unlock()
+	if addr, ok := c.slots[slot(key)]; ok {
+		return addr
+	}
+	return c.addrs[0]
+}
+
+// on runs the command on the node
+func (c *client) on(addr string, args ...interface{}) (interface{}, error) {
+	cn, err := c.get(addr)
+	if err != nil {
+		return nil, err
+	}
+	v, err := cn.do(args...)
+	c.put(addr, cn, err)
+	return v, err
+}
+
+// do the command of the key, in a cluster the command is sent to the node
+// serving the key and redirected if the slot has moved
+func (c *client) do(key string, args ...interface{}) (interface{}, error) {
+	addr := c.addr(key)
+
+	for i := 0; i < 5; i++ {
+		v, err := c.on(addr, args...)
+		e, ok := err.(replyError)
+		if !ok || !c.cluster {
+			return v, err
+		}
+
+		// MOVED 3999 127.0.0.1:6381 or ASK 3999 127.0.0.1:6381
+		parts := strings.Fields(string(e))
+		if len(parts) != 3 {
+			return v, err
+		}
+
+		switch parts[0] {
+		case "MOVED":
+			c.Lock()
+			c.slots[slot(key)] = parts[2]
+			c.Unlock()
+			addr = parts[2]
+		case "ASK":
+			cn, err := c.get(parts[2])
+			if err != nil {
+				return nil, err
+			}
+			if _, err := cn.do("ASKING"); err != nil {
+				c.put(parts[2], cn, err)
+				return nil, err
+			}
+			v, err := cn.do(args...)
+			c.put(parts[2], cn, err)
+			return v, err
+		default:
+			return v, err
+		}
+	}
+
+	return nil, fmt.Errorf("redis: too many redirects for %s", key)
+}
+
+// masters returns the addresses of the nodes to scan, the master nodes
+// of a cluster or the node of a single redis
+func (c *client) masters() ([]string, error) {
+	if !c.cluster {
+		return c.addrs[:1], nil
+	}
+
+	var v interface{}
+	var err error
+	for _, addr := range c.addrs {
+		if v, err = c.on(addr, "CLUSTER", "NODES"); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	b, _ := v.([]byte)
+	return parseNodes(string(b)), nil
+}
+
+// parseNodes returns the addresses of the healthy masters of the reply of
+// CLUSTER NODES e.g <id> 127.0.0.1:30001@31001 myself,master - 0 0 1 connected 0-5460
+func parseNodes(nodes string) []string {
+	var addrs []string
+	for _, line := range strings.Split(nodes, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			continue
+		}
+		flags := strings.Split(fields[2], ",")
+		master, failed := false, false
+		for _, f := range flags {
+			switch f {
+			case "master":
+				master = true
+			case "fail", "fail?", "noaddr":
+				failed = true
+			}
+		}
+		if !master || failed {
+			continue
+		}
+		addrs = append(addrs, strings.SplitN(fields[1], "@", 2)[0])
+	}
+	return addrs
+}
+
+// scan returns the keys matching the pattern on every master
+func (c *client) scan(match string) ([]string, error) {
+	masters, err := c.masters()
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	for _, addr := range masters {
+		cursor := "0"
+		for {
+			v, err := c.on(addr, "SCAN", cursor, "MATCH", match, "COUNT", 1000)
+			if err != nil {
+				return nil, err
+			}
+			reply, ok := v.([]interface{})
+			if !ok || len(reply) != 2 {
+				return nil, fmt.Errorf("redis: unexpected scan reply %v", v)
+			}
+			next, _ := reply[0].([]byte)
+			found, _ := reply[1].([]interface{})
+			for _, k := range found {
+				if b, ok := k.([]byte); ok {
+					keys = append(keys, string(b))
+				}
+			}
+			cursor = string(next)
+			if cursor == "0" {
+				break
+			}
+		}
+	}
+
+	return keys, nil
+}
+
+func (c *client) close() error {
+	c.Lock()
+	defer c.Unlock()
+	for addr, idle := range c.idle {
+		for _, cn := range idle {
+			cn.Close()
+		}
+		delete(c.idle, addr)
+	}
+	return nil
+}
+
+// do writes the command as an array of bulk strings and reads the reply
+func (cn *conn) do(args ...interface{}) (interface{}, error) {
+	cn.SetDeadline(time.Now().Add(Timeout))
+
+	fmt.Fprintf(cn.w, "*%d\r\n", len(args))
+	for _, arg := range args {
+		var b []byte
+		switch v := arg.(type) {
+		case []byte:
+			b = v
+		case string:
+			b = []byte(v)
+		case int:
+			b = []byte(strconv.Itoa(v))
+		case int64:
+			b = []byte(strconv.FormatInt(v, 10))
+		default:
+			b = []byte(fmt.Sprint(v))
+		}
+		fmt.Fprintf(cn.w, "$%d\r\n", len(b))
+		cn.w.Write(b)
+		cn.w.WriteString("\r\n")
+	}
+	if err := cn.w.Flush(); err != nil {
+		return nil, err
+	}
+
+	return readReply(cn.r)
+}
+
+// readReply reads a reply of the redis protocol, simple and bulk strings
+// are returned as bytes, integers as int64 and arrays as []interface{}
+func readReply(r *bufio.Reader) (interface{}, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 || !strings.HasSuffix(line, "\r\n") {
+		return nil, fmt.Errorf("redis: invalid reply %q", line)
+	}
+	line = line[:len(line)-2]
+
+	switch line[0] {
+	case '+':
+		return []byte(line[1:]), nil
+	case '-':
+		return nil, replyError(line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 {
+			return nil, errNil
+		}
+		b := make([]byte, n+2)
+		if _, err := io.ReadFull(r, b); err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	case '*':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 {
+			return nil, errNil
+		}
+		vals := make([]interface{}, 0, n)
+		for i := 0; i < n; i++ {
+			v, err := readReply(r)
+			if err != nil && err != errNil {
+				return nil, err
+			}
+			vals = append(vals, v)
+		}
+		return vals, nil
+	}
+
+	return nil, fmt.Errorf("redis: invalid reply %q", line)
+}
+
+// slot returns the cluster hash slot of the key, only the hash tag
+// between braces is hashed if the key has one e.g {user}.name
+func slot(key string) uint16 {
+	if s := strings.IndexByte(key, '{'); s >= 0 {
+		if e := strings.IndexByte(key[s+1:], '}'); e > 0 {
+			key = key[s+1 : s+1+e]
+		}
+	}
+	return crc16(key) % 16384
+}
+
+// crc16 is the CRC16-CCITT (XMODEM) checksum used by redis cluster
+func crc16(s string) uint16 {
+	var crc uint16
+	for i := 0; i < len(s); i++ {
+		crc ^= uint16(s[i]) << 8
+		for j := 0; j < 8; j++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
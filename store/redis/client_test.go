@@ -0,0 +1,59 @@
+package redis
+
+import (
+	"bufio"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSlot(t *testing.T) {
+	testData := []struct {
+		key  string
+		slot uint16
+	}{
+		{"123456789", 12739},
+		{"foo", 12182},
+		{"{user1000}.following", slot("user1000")},
+		{"{}.following", slot("{}.following")},
+	}
+
+	for _, d := range testData {
+		if s := slot(d.key); s != d.slot {
+			t.Fatalf("%s: expected slot %d got %d", d.key, d.slot, s)
+		}
+	}
+}
+
+func TestReadReply(t *testing.T) {
+	r := bufio.NewReader(strings.NewReader("*2\r\n$2\r\n17\r\n*2\r\n$3\r\nfoo\r\n$-1\r\n-MOVED 3999 127.0.0.1:6381\r\n:-2\r\n"))
+
+	v, err := readReply(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := []interface{}{[]byte("17"), []interface{}{[]byte("foo"), nil}}
+	if !reflect.DeepEqual(v, expect) {
+		t.Fatalf("expected %v got %v", expect, v)
+	}
+
+	if _, err := readReply(r); err != replyError("MOVED 3999 127.0.0.1:6381") {
+		t.Fatalf("expected a reply error got %v", err)
+	}
+
+	if v, _ := readReply(r); v != int64(-2) {
+		t.Fatalf("expected -2 got %v", v)
+	}
+}
+
+func TestParseNodes(t *testing.T) {
+	nodes := `07c3 127.0.0.1:30004@31004 slave e7d1 0 1426238317239 4 connected
+67ed 127.0.0.1:30002@31002 master - 0 1426238316232 2 connected 5461-10922
+2928 127.0.0.1:30003@31003 master,fail - 0 1426238318243 3 connected 10923-16383
+e7d1 127.0.0.1:30001@31001 myself,master - 0 0 1 connected 0-5460
+`
+	addrs := parseNodes(nodes)
+	if !reflect.DeepEqual(addrs, []string{"127.0.0.1:30002", "127.0.0.1:30001"}) {
+		t.Fatalf("unexpected masters %v", addrs)
+	}
+}
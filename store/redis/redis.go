@@ -0,0 +1,214 @@
+// Package redis is a store backed by redis or a redis cluster for users
+// already running redis rather than cockroach
+package redis
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/micro/go-micro/v2/store"
+)
+
+type redisStore struct {
+	client  *client
+	options store.Options
+}
+
+// NewStore returns a store of the nodes e.g redis://:password@localhost:6379/0,
+// more than one node or ?cluster=true is treated as the nodes of a cluster
+func NewStore(opts ...store.Option) (store.Store, error) {
+	var options store.Options
+	for _, o := range opts {
+		o(&options)
+	}
+
+	if len(options.Nodes) == 0 {
+		options.Nodes = []string{"localhost:6379"}
+	}
+
+	var addrs []string
+	var password string
+	var db int
+	cluster := len(options.Nodes) > 1
+
+	for _, node := range options.Nodes {
+		if !strings.Contains(node, "://") {
+			addrs = append(addrs, node)
+			continue
+		}
+
+		u, err := url.Parse(node)
+		if err != nil {
+			return nil, err
+		}
+		if u.Scheme != "redis" {
+			return nil, errors.New("redis nodes are set as redis://host:port")
+		}
+		if p, ok := u.User.Password(); ok {
+			password = p
+		}
+		if v := strings.Trim(u.Path, "/"); len(v) > 0 {
+			if db, err = strconv.Atoi(v); err != nil {
+				return nil, errors.New("redis database must be a number")
+			}
+		}
+		if v, _ := strconv.ParseBool(u.Query().Get("cluster")); v {
+			cluster = true
+		}
+		addrs = append(addrs, u.Host)
+	}
+
+	for i, addr := range addrs {
+		if !strings.Contains(addr, ":") {
+			addrs[i] = addr + ":6379"
+		}
+	}
+
+	return &redisStore{
+		client:  newClient(addrs, password, db, cluster),
+		options: options,
+	}, nil
+}
+
+func (s *redisStore) Init(opts ...store.Option) error {
+	for _, o := range opts {
+		o(&s.options)
+	}
+	return nil
+}
+
+func (s *redisStore) Options() store.Options {
+	return s.options
+}
+
+// redisKey returns the redis key of the record key
+func (s *redisStore) redisKey(key string) string {
+	var parts []string
+	for _, p := range []string{s.options.Namespace, s.options.Prefix} {
+		if len(p) > 0 {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(append(parts, key), "/")
+}
+
+// get the record and the time remaining until it expires
+func (s *redisStore) get(key string) (*store.Record, error) {
+	rk := s.redisKey(key)
+
+	v, err := s.client.do(rk, "GET", rk)
+	if err == errNil {
+		return nil, store.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	r := &store.Record{Key: key}
+	r.Value, _ = v.([]byte)
+
+	ttl, err := s.client.do(rk, "PTTL", rk)
+	if err != nil {
+		return nil, err
+	}
+	switch ms, _ := ttl.(int64); {
+	case ms == -2:
+		// expired since it was read
+		return nil, store.ErrNotFound
+	case ms > 0:
+		r.Expiry = time.Duration(ms) * time.Millisecond
+	}
+
+	return r, nil
+}
+
+// scan returns the records with keys starting with the prefix
+func (s *redisStore) scan(prefix string) ([]*store.Record, error) {
+	base := s.redisKey("")
+
+	keys, err := s.client.scan(escape(s.redisKey(prefix)) + "*")
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*store.Record, 0, len(keys))
+	for _, k := range keys {
+		r, err := s.get(strings.TrimPrefix(k, base))
+		if err == store.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, r)
+	}
+	return records, nil
+}
+
+func (s *redisStore) List() ([]*store.Record, error) {
+	return s.scan("")
+}
+
+func (s *redisStore) Read(key string, opts ...store.ReadOption) ([]*store.Record, error) {
+	var options store.ReadOptions
+	for _, o := range opts {
+		o(&options)
+	}
+
+	if options.Prefix {
+		return s.scan(key)
+	}
+
+	r, err := s.get(key)
+	if err != nil {
+		return nil, err
+	}
+	return []*store.Record{r}, nil
+}
+
+func (s *redisStore) Write(r *store.Record) error {
+	rk := s.redisKey(r.Key)
+
+	args := []interface{}{"SET", rk, r.Value}
+	if r.Expiry > 0 {
+		ms := int64(r.Expiry / time.Millisecond)
+		if ms == 0 {
+			ms = 1
+		}
+		args = append(args, "PX", ms)
+	}
+
+	_, err := s.client.do(rk, args...)
+	return err
+}
+
+func (s *redisStore) Delete(key string) error {
+	rk := s.redisKey(key)
+	_, err := s.client.do(rk, "DEL", rk)
+	return err
+}
+
+// Close the idle connections
+func (s *redisStore) Close() error {
+	return s.client.close()
+}
+
+func (s *redisStore) String() string {
+	return "redis"
+}
+
+// escape the glob characters of the key matched by SCAN
+func escape(key string) string {
+	var b strings.Builder
+	for _, c := range key {
+		switch c {
+		case '*', '?', '[', ']', '\\':
+			b.WriteByte('\\')
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
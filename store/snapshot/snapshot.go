@@ -0,0 +1,105 @@
+// Package snapshot encodes the records of stores as gzipped json lines
+package snapshot
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/micro/go-micro/v2/store"
+)
+
+// Record of a snapshot
+type Record struct {
+	Namespace string `json:"namespace,omitempty"`
+	Prefix    string `json:"prefix,omitempty"`
+	Key       string `json:"key"`
+	Value     []byte `json:"value"`
+	// Expires is the unix time the record expires, zero if it doesn't
+	Expires int64 `json:"expires,omitempty"`
+}
+
+// Writer writes the records of a snapshot
+type Writer struct {
+	gz  *gzip.Writer
+	enc *json.Encoder
+}
+
+// NewWriter returns a writer of a snapshot to w, it must be closed to flush the snapshot
+func NewWriter(w io.Writer) *Writer {
+	gz := gzip.NewWriter(w)
+	return &Writer{
+		gz:  gz,
+		enc: json.NewEncoder(gz),
+	}
+}
+
+// Write the record of the store with the namespace and prefix
+func (w *Writer) Write(namespace, prefix string, r *store.Record) error {
+	rec := &Record{
+		Namespace: namespace,
+		Prefix:    prefix,
+		Key:       r.Key,
+		Value:     r.Value,
+	}
+	if r.Expiry > 0 {
+		rec.Expires = time.Now().Add(r.Expiry).Unix()
+	}
+	return w.enc.Encode(rec)
+}
+
+// Close flushes the snapshot
+func (w *Writer) Close() error {
+	return w.gz.Close()
+}
+
+// Reader reads the records of a snapshot
+type Reader struct {
+	gz  *gzip.Reader
+	dec *json.Decoder
+}
+
+// NewReader returns a reader of the snapshot read from r
+func NewReader(r io.Reader) (*Reader, error) {
+	gz, err := gzip.NewReader(bufio.NewReader(r))
+	if err != nil {
+		return nil, err
+	}
+	return &Reader{
+		gz:  gz,
+		dec: json.NewDecoder(gz),
+	}, nil
+}
+
+// Next returns the next record which hasn't expired, io.EOF is returned after the last
+func (r *Reader) Next() (*Record, error) {
+	for {
+		rec := new(Record)
+		if err := r.dec.Decode(rec); err != nil {
+			return nil, err
+		}
+		if rec.Expires > 0 && rec.Expires <= time.Now().Unix() {
+			continue
+		}
+		return rec, nil
+	}
+}
+
+// Record returns the record to write to a store
+func (r *Record) Record() *store.Record {
+	rec := &store.Record{
+		Key:   r.Key,
+		Value: r.Value,
+	}
+	if r.Expires > 0 {
+		rec.Expiry = time.Until(time.Unix(r.Expires, 0))
+	}
+	return rec
+}
+
+// Close the reader
+func (r *Reader) Close() error {
+	return r.gz.Close()
+}
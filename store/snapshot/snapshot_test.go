@@ -0,0 +1,111 @@
+package snapshot
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/micro/go-micro/v2/store"
+)
+
+func TestSnapshot(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+
+	w := NewWriter(b)
+	records := []*store.Record{
+		{Key: "users/1", Value: []byte(`{"name": "john"}`)},
+		{Key: "sessions/1", Value: []byte("token"), Expiry: time.Hour},
+	}
+	for _, r := range records {
+		if err := w.Write("team-a", "users", r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewReader(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	for _, expect := range records {
+		rec, err := r.Next()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rec.Namespace != "team-a" || rec.Prefix != "users" {
+			t.Fatalf("Expected the namespace and prefix to be kept got %s %s", rec.Namespace, rec.Prefix)
+		}
+		got := rec.Record()
+		if got.Key != expect.Key || string(got.Value) != string(expect.Value) {
+			t.Fatalf("Expected %s=%s got %s=%s", expect.Key, expect.Value, got.Key, got.Value)
+		}
+		if expect.Expiry > 0 && (got.Expiry <= 0 || got.Expiry > expect.Expiry) {
+			t.Fatalf("Expected an expiry of up to %v got %v", expect.Expiry, got.Expiry)
+		}
+		if expect.Expiry == 0 && got.Expiry != 0 {
+			t.Fatalf("Expected no expiry got %v", got.Expiry)
+		}
+	}
+
+	if _, err := r.Next(); err != io.EOF {
+		t.Fatalf("Expected EOF got %v", err)
+	}
+}
+
+func TestSnapshotExpired(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+
+	w := NewWriter(b)
+	w.enc.Encode(&Record{Key: "expired", Expires: time.Now().Add(-time.Minute).Unix()})
+	w.enc.Encode(&Record{Key: "kept"})
+	w.Close()
+
+	r, err := NewReader(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec, err := r.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec.Key != "kept" {
+		t.Fatalf("Expected the expired record to be skipped got %s", rec.Key)
+	}
+}
+
+func TestFileTarget(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	target, err := NewTarget("file://" + filepath.Join(dir, "store"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := target.Save("snapshot.jsonl.gz", []byte("data")); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, "store", "snapshot.jsonl.gz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "data" {
+		t.Fatalf("Expected the snapshot to be saved got %q", b)
+	}
+
+	if _, err := NewTarget("ftp://host/path"); err == nil {
+		t.Fatal("Expected an error for an unknown target")
+	}
+}
@@ -0,0 +1,76 @@
+package snapshot
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/micro/micro/v2/internal/s3"
+)
+
+// Target is where scheduled snapshots are saved
+type Target interface {
+	// Save the snapshot with the name
+	Save(name string, b []byte) error
+	String() string
+}
+
+// NewTarget returns the target of the url e.g file:///var/backups/store or s3://bucket/prefix
+func NewTarget(addr string) (Target, error) {
+	u, err := url.Parse(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	switch u.Scheme {
+	case "", "file":
+		dir := u.Path
+		if len(u.Host) > 0 {
+			// relative paths e.g file://backups
+			dir = filepath.Join(u.Host, u.Path)
+		}
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return nil, err
+		}
+		return &fileTarget{dir: dir}, nil
+	case "s3":
+		client, err := s3.New(u)
+		if err != nil {
+			return nil, err
+		}
+		return &s3Target{client: client}, nil
+	}
+
+	return nil, fmt.Errorf("unknown snapshot target %s", u.Scheme)
+}
+
+type fileTarget struct {
+	dir string
+}
+
+// Save writes the snapshot to a temporary file before renaming it so it's never partially written
+func (f *fileTarget) Save(name string, b []byte) error {
+	path := filepath.Join(f.dir, name)
+	if err := ioutil.WriteFile(path+".tmp", b, 0600); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+func (f *fileTarget) String() string {
+	return "file://" + f.dir
+}
+
+type s3Target struct {
+	client *s3.Client
+}
+
+func (s *s3Target) Save(name string, b []byte) error {
+	return s.client.Put(s.client.Key(name), "application/gzip", b)
+}
+
+func (s *s3Target) String() string {
+	return "s3://" + s.client.Bucket
+}
@@ -0,0 +1,44 @@
+package store
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/micro/go-micro/v2/client"
+	"github.com/micro/go-micro/v2/store"
+	"github.com/micro/micro/v2/store/handler"
+	pb "github.com/micro/micro/v2/store/proto"
+)
+
+// syncFrom copies the records of the default store from the active instance at the address
+func syncFrom(address string, st store.Store) error {
+	stream, err := pb.NewStoreService(Name, client.DefaultClient).List(context.Background(), &pb.ListRequest{
+		Options: &pb.ListOptions{Indexes: true},
+	}, client.WithAddress(address))
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	for {
+		rsp, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		for _, r := range rsp.Records {
+			err := st.Write(&store.Record{
+				Key:    r.Key,
+				Value:  handler.EncodeValue(r.Value, r.Metadata),
+				Expiry: time.Duration(r.Expiry) * time.Second,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
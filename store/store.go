@@ -1,15 +1,30 @@
 package store
 
 import (
+	"net/http"
 	"strings"
 	"time"
 
 	"github.com/micro/cli/v2"
 	"github.com/micro/go-micro/v2"
+	debug "github.com/micro/go-micro/v2/debug/service/handler"
+	"github.com/micro/go-micro/v2/server"
 	"github.com/micro/go-micro/v2/store"
-	pb "github.com/micro/go-micro/v2/store/service/proto"
 	"github.com/micro/go-micro/v2/util/log"
+	"github.com/micro/micro/v2/internal/bulk"
+	"github.com/micro/micro/v2/internal/namespace"
+	"github.com/micro/micro/v2/internal/standby"
+	"github.com/micro/micro/v2/store/bench"
+	"github.com/micro/micro/v2/store/blob"
+	"github.com/micro/micro/v2/store/cache"
+	"github.com/micro/micro/v2/store/chunk"
+	"github.com/micro/micro/v2/store/compress"
+	"github.com/micro/micro/v2/store/encrypt"
+	"github.com/micro/micro/v2/store/failover"
 	"github.com/micro/micro/v2/store/handler"
+	pb "github.com/micro/micro/v2/store/proto"
+	"github.com/micro/micro/v2/store/redis"
+	"github.com/micro/micro/v2/store/snapshot"
 
 	"github.com/micro/go-micro/v2/store/cockroach"
 	"github.com/micro/go-micro/v2/store/memory"
@@ -55,13 +70,28 @@ func run(ctx *cli.Context, srvOpts ...micro.Option) {
 		Namespace = ctx.String("namespace")
 	}
 
-	// Initialise service
-	service := micro.NewService(
+	srvOpts = append(srvOpts,
 		micro.Name(Name),
 		micro.RegisterTTL(time.Duration(ctx.Int("register_ttl"))*time.Second),
 		micro.RegisterInterval(time.Duration(ctx.Int("register_interval"))*time.Second),
 	)
 
+	// take part in electing the active instance
+	if ctx.Bool("standby") {
+		// the writes replicated between the instances are signed with the key
+		if len(ctx.String("standby_key")) == 0 {
+			log.Fatal("--standby_key is required to run as an active/standby pair")
+		}
+		srvOpts = append(srvOpts, micro.Metadata(standby.Metadata()))
+	}
+
+	// record the stats of each store operation
+	stats := handler.NewStats(ctx.Duration("store_slow_op_threshold"), nil)
+	srvOpts = append(srvOpts, micro.WrapHandler(stats.Wrapper()))
+
+	// Initialise service
+	service := micro.NewService(srvOpts...)
+
 	opts := []store.Option{store.Nodes(Nodes...)}
 	if len(Namespace) > 0 {
 		opts = append(opts, store.Namespace(Namespace))
@@ -72,7 +102,32 @@ func run(ctx *cli.Context, srvOpts ...micro.Option) {
 
 	// the store handler
 	storeHandler := &handler.Store{
-		Stores: make(map[string]store.Store),
+		Stores:      make(map[string]store.Store),
+		Backend:     Backend,
+		Nodes:       Nodes,
+		MaxStores:   ctx.Int("max_stores"),
+		IdleTimeout: ctx.Duration("store_idle_timeout"),
+	}
+
+	// limit what's stored in each namespace
+	if q := ctx.String("quotas"); len(q) > 0 {
+		quotas, err := handler.ParseQuotas(q)
+		if err != nil {
+			log.Fatal(err)
+		}
+		storeHandler.Quotas = quotas
+	}
+
+	// restrict the namespaces callers can access
+	if ctx.Bool("acl") || len(ctx.String("namespace_allow")) > 0 || len(ctx.String("namespace_deny")) > 0 {
+		storeHandler.ACL = &handler.ACL{
+			Allow:  split(ctx.String("namespace_allow")),
+			Deny:   split(ctx.String("namespace_deny")),
+			Scoped: ctx.Bool("acl"),
+		}
+		if ctx.Bool("acl") && len(namespace.Key) == 0 {
+			log.Logf("MICRO_NAMESPACE_KEY is not set, callers are not scoped to a namespace")
+		}
 	}
 
 	switch Backend {
@@ -88,6 +143,29 @@ func run(ctx *cli.Context, srvOpts ...micro.Option) {
 			)
 		}
 	case "cockroach":
+		// fail over between the nodes rather than treating them as one
+		if ctx.Bool("failover") && len(Nodes) > 1 {
+			cluster := failover.NewCluster(Nodes, func(node string) store.Store {
+				return cockroach.NewStore(store.Nodes(node))
+			})
+			cluster.Start()
+			defer cluster.Stop()
+
+			storeHandler.Cluster = cluster
+			storeHandler.Default = cluster.Store(func(node string) store.Store {
+				return cockroach.NewStore(append(opts, store.Nodes(node))...)
+			})
+			storeHandler.New = func(namespace string, prefix string) store.Store {
+				return cluster.Store(func(node string) store.Store {
+					return cockroach.NewStore(
+						store.Nodes(node),
+						store.Namespace(namespace),
+						store.Prefix(prefix),
+					)
+				})
+			}
+			break
+		}
 		// set the default store
 		storeHandler.Default = cockroach.NewStore(opts...)
 		// set the new store initialiser
@@ -98,18 +176,199 @@ func run(ctx *cli.Context, srvOpts ...micro.Option) {
 				store.Prefix(prefix),
 			)
 		}
+	case "s3":
+		// the bucket is set as a url e.g s3://bucket/prefix?region=eu-west-1
+		newStore := func(opts ...store.Option) store.Store {
+			st, err := blob.NewStore(Nodes[0], opts...)
+			if err != nil {
+				log.Fatalf("Invalid s3 store %s: %v", Nodes[0], err)
+			}
+			return st
+		}
+		storeHandler.Default = newStore(opts...)
+		storeHandler.New = func(namespace string, prefix string) store.Store {
+			return newStore(
+				store.Namespace(namespace),
+				store.Prefix(prefix),
+			)
+		}
+	case "redis":
+		// the nodes are set as redis://:password@host:port/db, more than one is a cluster
+		newStore := func(opts ...store.Option) store.Store {
+			st, err := redis.NewStore(opts...)
+			if err != nil {
+				log.Fatalf("Invalid redis store: %v", err)
+			}
+			return st
+		}
+		storeHandler.Default = newStore(opts...)
+		storeHandler.New = func(namespace string, prefix string) store.Store {
+			return newStore(
+				store.Nodes(Nodes...),
+				store.Namespace(namespace),
+				store.Prefix(prefix),
+			)
+		}
 	default:
 		log.Fatalf("%s is not an implemented store", Backend)
 	}
 
+	// split the values over the size limit of the backend into chunks,
+	// cockroach rejects rows much larger than its range size
+	chunkSize := ctx.Int("store_chunk_size")
+	if chunkSize == 0 && Backend == "cockroach" {
+		chunkSize = chunk.Size
+	}
+	if chunkSize > 0 {
+		newStore := storeHandler.New
+		storeHandler.Default = chunk.NewStore(storeHandler.Default, chunkSize)
+		storeHandler.New = func(namespace string, prefix string) store.Store {
+			return chunk.NewStore(newStore(namespace, prefix), chunkSize)
+		}
+	}
+
+	// encrypt the values before they're written to the backend
+	if v := ctx.String("store_encryption_key"); len(v) > 0 {
+		keys, err := encrypt.ParseKeys(v)
+		if err != nil {
+			log.Fatalf("Invalid store encryption key: %v", err)
+		}
+
+		newStore := storeHandler.New
+		wrap := func(st store.Store) store.Store {
+			est, err := encrypt.NewStore(st, keys)
+			if err != nil {
+				log.Fatalf("Invalid store encryption key: %v", err)
+			}
+			return est
+		}
+
+		storeHandler.Default = wrap(storeHandler.Default)
+		storeHandler.New = func(namespace string, prefix string) store.Store {
+			return wrap(newStore(namespace, prefix))
+		}
+
+		log.Logf("Encrypting values with key %s", keys[0].ID)
+	}
+
+	// compress the large values, before they're encrypted
+	if algorithm := ctx.String("store_compression"); len(algorithm) > 0 {
+		threshold := ctx.Int("store_compression_threshold")
+
+		newStore := storeHandler.New
+		wrap := func(st store.Store) store.Store {
+			cst, err := compress.NewStore(st, algorithm, threshold)
+			if err != nil {
+				log.Fatal(err)
+			}
+			return cst
+		}
+
+		storeHandler.Default = wrap(storeHandler.Default)
+		storeHandler.New = func(namespace string, prefix string) store.Store {
+			return wrap(newStore(namespace, prefix))
+		}
+	}
+
+	// cache the records read from slow backends
+	if ctx.Bool("store_cache") {
+		storeHandler.Cache = cache.New(
+			ctx.Int("store_cache_size"),
+			uint64(ctx.Int("store_cache_bytes")),
+			ctx.Duration("store_cache_ttl"),
+		)
+
+		// invalidate the records written through the other store nodes
+		opts := service.Server().Options()
+		inv := newInvalidator(opts.Name+"-"+opts.Id, storeHandler.Cache, service.Options().Registry, service.Client())
+		inv.Start()
+		defer inv.Stop()
+	}
+
+	if ctx.Bool("standby") {
+		opts := service.Server().Options()
+		sb := standby.New(Name, opts.Name+"-"+opts.Id, ctx.String("standby_key"), service.Options().Registry, service.Client())
+		sb.Start()
+		defer sb.Stop()
+
+		// catch up with the writes made before we started
+		if node := sb.ActiveNode(); node != nil {
+			if err := syncFrom(node.Address, storeHandler.Default); err != nil {
+				log.Logf("Failed to sync from the active instance %s: %v", node.Id, err)
+			}
+		}
+
+		storeHandler.Standby = sb
+	}
+
 	pb.RegisterStoreHandler(service.Server(), storeHandler)
 
+	// registered before the service runs so it's used over the default debug handler
+	stats.Cache = storeHandler.Cache
+	service.Server().Handle(
+		service.Server().NewHandler(
+			&Debug{Debug: debug.NewHandler(), stats: stats},
+			server.InternalHandler(true),
+		),
+	)
+
+	if addr := ctx.String("metrics_address"); len(addr) > 0 {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", stats)
+		go func() {
+			log.Logf("Store serving metrics on %s/metrics", addr)
+			if err := http.ListenAndServe(addr, mux); err != nil {
+				log.Logf("Store error serving metrics: %v", err)
+			}
+		}()
+	}
+
+	exit := make(chan bool)
+	defer close(exit)
+
+	// evict the idle namespace stores
+	go storeHandler.GC(exit)
+
+	if addr := ctx.String("snapshot"); len(addr) > 0 {
+		target, err := snapshot.NewTarget(addr)
+		if err != nil {
+			log.Fatalf("Failed to create the snapshot target: %v", err)
+		}
+
+		if d := ctx.Duration("snapshot_interval"); d > 0 {
+			SnapshotInterval = d
+		}
+
+		go scheduleSnapshots(storeHandler, target, SnapshotInterval, exit)
+
+		// snapshot on demand once drained in maintenance mode
+		storeHandler.Snapshot = func() (int, error) {
+			return snapshotStores(storeHandler, target)
+		}
+	}
+
+	// reject the writes until set read-write e.g while a restore is verified
+	if ctx.Bool("read_only") {
+		storeHandler.SetMode(handler.ReadOnly, "started with --read_only")
+	}
+
 	// start the service
 	if err := service.Run(); err != nil {
 		log.Fatal(err)
 	}
 }
 
+// split the comma separated list dropping blank entries
+func split(list string) []string {
+	var parts []string
+	for _, p := range strings.Split(list, ",") {
+		if p = strings.TrimSpace(p); len(p) > 0 {
+			parts = append(parts, p)
+		}
+	}
+	return parts
+}
+
 // Commands is the cli interface for the store service
 func Commands(options ...micro.Option) []*cli.Command {
 	command := &cli.Command{
@@ -123,7 +382,7 @@ func Commands(options ...micro.Option) []*cli.Command {
 			},
 			&cli.StringFlag{
 				Name:    "backend",
-				Usage:   "Set the backend for the micro store",
+				Usage:   "Set the backend for the micro store; memory, cockroach, redis with --nodes redis://host:6379 or s3 with --nodes s3://bucket/prefix",
 				EnvVars: []string{"MICRO_STORE_BACKEND"},
 				Value:   "memory",
 			},
@@ -142,11 +401,432 @@ func Commands(options ...micro.Option) []*cli.Command {
 				Usage:   "Key prefix to pass to the store backend",
 				EnvVars: []string{"MICRO_STORE_PREFIX"},
 			},
+			&cli.BoolFlag{
+				Name:    "standby",
+				Usage:   "Run as one of an active/standby pair, writes are forwarded to the active instance and replicated back",
+				EnvVars: []string{"MICRO_STORE_STANDBY"},
+			},
+			&cli.StringFlag{
+				Name:    "standby_key",
+				Usage:   "Key shared by the active/standby pair to sign the writes replicated between them",
+				EnvVars: []string{"MICRO_STORE_STANDBY_KEY"},
+			},
+			&cli.IntFlag{
+				Name:    "max_stores",
+				Usage:   "Set the maximum namespace stores kept open, the least recently used is evicted",
+				EnvVars: []string{"MICRO_STORE_MAX_STORES"},
+			},
+			&cli.DurationFlag{
+				Name:    "store_idle_timeout",
+				Usage:   "Set how long a namespace store is kept open without being used e.g 10m",
+				EnvVars: []string{"MICRO_STORE_IDLE_TIMEOUT"},
+			},
+			&cli.StringFlag{
+				Name:    "store_encryption_key",
+				Usage:   "Encrypt the values at rest with the first of a comma separated list of keys e.g v2:base64,v1:base64 or file:///etc/micro/store-keys, the rest decrypt values written before rotating",
+				EnvVars: []string{"MICRO_STORE_ENCRYPTION_KEY"},
+			},
+			&cli.StringFlag{
+				Name:    "store_compression",
+				Usage:   "Compress the values over the threshold before they're written to the backend e.g gzip",
+				EnvVars: []string{"MICRO_STORE_COMPRESSION"},
+			},
+			&cli.IntFlag{
+				Name:    "store_compression_threshold",
+				Usage:   "Set the size in bytes of the values compressed, defaults to 1024",
+				EnvVars: []string{"MICRO_STORE_COMPRESSION_THRESHOLD"},
+			},
+			&cli.IntFlag{
+				Name:    "store_chunk_size",
+				Usage:   "Split the values larger than the size in bytes into chunks, defaults to 1048576 for cockroach",
+				EnvVars: []string{"MICRO_STORE_CHUNK_SIZE"},
+			},
+			&cli.BoolFlag{
+				Name:    "store_cache",
+				Usage:   "Cache the records read from the backend, invalidated by writes through any store node",
+				EnvVars: []string{"MICRO_STORE_CACHE"},
+			},
+			&cli.IntFlag{
+				Name:    "store_cache_size",
+				Usage:   "Set the maximum number of records cached, defaults to 10000",
+				EnvVars: []string{"MICRO_STORE_CACHE_SIZE"},
+			},
+			&cli.IntFlag{
+				Name:    "store_cache_bytes",
+				Usage:   "Set the maximum size in bytes of the records cached, unbounded by default",
+				EnvVars: []string{"MICRO_STORE_CACHE_BYTES"},
+			},
+			&cli.DurationFlag{
+				Name:    "store_cache_ttl",
+				Usage:   "Set how long a record is cached for, defaults to 1m",
+				EnvVars: []string{"MICRO_STORE_CACHE_TTL"},
+			},
+			&cli.DurationFlag{
+				Name:    "store_slow_op_threshold",
+				Usage:   "Log the keys of the store operations slower than the threshold e.g 500ms",
+				EnvVars: []string{"MICRO_STORE_SLOW_OP_THRESHOLD"},
+			},
+			&cli.StringFlag{
+				Name:    "metrics_address",
+				Usage:   "Set the address to serve the store stats in the prometheus format on e.g 0.0.0.0:9100",
+				EnvVars: []string{"MICRO_STORE_METRICS_ADDRESS"},
+			},
+			&cli.StringFlag{
+				Name:    "quotas",
+				Usage:   "Set the quotas of the stores of each namespace, * is the default e.g *=records:10000,bytes:100Mi;team-a=bytes:1Gi",
+				EnvVars: []string{"MICRO_STORE_QUOTAS"},
+			},
+			&cli.BoolFlag{
+				Name:    "acl",
+				Usage:   "Restrict callers to the namespace of their namespace token, requires MICRO_NAMESPACE_KEY",
+				EnvVars: []string{"MICRO_STORE_ACL"},
+			},
+			&cli.StringFlag{
+				Name:    "namespace_allow",
+				Usage:   "Comma separated list of the namespaces that can be accessed, a trailing * matches by prefix e.g team-*,shared",
+				EnvVars: []string{"MICRO_STORE_NAMESPACE_ALLOW"},
+			},
+			&cli.StringFlag{
+				Name:    "namespace_deny",
+				Usage:   "Comma separated list of the namespaces that can't be accessed e.g internal,team-b",
+				EnvVars: []string{"MICRO_STORE_NAMESPACE_DENY"},
+			},
+			&cli.StringFlag{
+				Name:    "snapshot",
+				Usage:   "Snapshot the records to a target every snapshot interval e.g file:///var/backups/store or s3://bucket/prefix",
+				EnvVars: []string{"MICRO_STORE_SNAPSHOT"},
+			},
+			&cli.DurationFlag{
+				Name:    "snapshot_interval",
+				Usage:   "Set how often the records are snapshotted e.g 1h",
+				EnvVars: []string{"MICRO_STORE_SNAPSHOT_INTERVAL"},
+			},
+			&cli.BoolFlag{
+				Name:    "read_only",
+				Usage:   "Start in read-only mode rejecting writes until set read-write with micro store mode",
+				EnvVars: []string{"MICRO_STORE_READ_ONLY"},
+			},
+			&cli.BoolFlag{
+				Name:    "failover",
+				Usage:   "Health check the nodes and fail over reads and writes to a healthy node (cockroach only)",
+				EnvVars: []string{"MICRO_STORE_FAILOVER"},
+			},
 		},
 		Action: func(ctx *cli.Context) error {
 			run(ctx, options...)
 			return nil
 		},
+		Subcommands: []*cli.Command{
+			{
+				Name:      "read",
+				Usage:     "Read the value of a key",
+				ArgsUsage: "[key]",
+				Flags: append([]cli.Flag{
+					&cli.BoolFlag{
+						Name:    "verbose",
+						Aliases: []string{"v"},
+						Usage:   "Print the metadata and expiry of the record before the value",
+					},
+				}, storeFlags...),
+				Action: func(ctx *cli.Context) error {
+					readRecord(ctx)
+					return nil
+				},
+			},
+			{
+				Name:      "write",
+				Usage:     "Write the value of a key",
+				ArgsUsage: "[key] [value]",
+				Flags: append([]cli.Flag{
+					&cli.StringFlag{
+						Name:    "file",
+						Aliases: []string{"f"},
+						Usage:   "Read the value from the file, - for stdin",
+					},
+					&cli.DurationFlag{
+						Name:  "expiry",
+						Usage: "Set how long until the key expires e.g 1h",
+					},
+					&cli.StringSliceFlag{
+						Name:  "metadata",
+						Usage: "Set the metadata of the record e.g --metadata owner=team-a",
+					},
+					&cli.StringSliceFlag{
+						Name:  "index",
+						Usage: "Index the metadata field so the record can be queried by it e.g --index owner",
+					},
+				}, storeFlags...),
+				Action: func(ctx *cli.Context) error {
+					writeRecord(ctx)
+					return nil
+				},
+			},
+			{
+				Name:      "delete",
+				Usage:     "Delete a key",
+				ArgsUsage: "[key]",
+				Flags:     storeFlags,
+				Action: func(ctx *cli.Context) error {
+					deleteRecord(ctx)
+					return nil
+				},
+			},
+			{
+				Name:  "list",
+				Usage: "List the keys, --prefix lists the keys of the store with the prefix",
+				Flags: append([]cli.Flag{
+					&cli.StringFlag{
+						Name:  "suffix",
+						Usage: "Only list the keys ending with the suffix e.g .json",
+					},
+					&cli.Uint64Flag{
+						Name:  "limit",
+						Usage: "Maximum number of keys listed",
+					},
+					&cli.Uint64Flag{
+						Name:  "offset",
+						Usage: "Number of keys skipped",
+					},
+				}, storeFlags...),
+				Action: func(ctx *cli.Context) error {
+					listRecords(ctx)
+					return nil
+				},
+			},
+			{
+				Name:      "query",
+				Usage:     "List the keys of the records matching the indexed metadata fields, a trailing * matches by prefix e.g micro store query owner=team-*",
+				ArgsUsage: "[field=value]...",
+				Flags: append([]cli.Flag{
+					&cli.Uint64Flag{
+						Name:  "limit",
+						Usage: "Maximum number of keys listed",
+					},
+					&cli.Uint64Flag{
+						Name:  "offset",
+						Usage: "Number of keys skipped",
+					},
+				}, storeFlags...),
+				Action: func(ctx *cli.Context) error {
+					queryRecords(ctx)
+					return nil
+				},
+			},
+			{
+				Name:  "snapshot",
+				Usage: "Write the records to a gzipped snapshot e.g micro store snapshot --output dump.gz",
+				Flags: append([]cli.Flag{
+					&cli.StringFlag{
+						Name:    "output",
+						Aliases: []string{"o"},
+						Usage:   "Set the file the snapshot is written to, - for stdout",
+						Value:   "-",
+					},
+				}, storeFlags...),
+				Action: func(ctx *cli.Context) error {
+					snapshotRecords(ctx)
+					return nil
+				},
+			},
+			{
+				Name:  "restore",
+				Usage: "Write the records of a snapshot to the store e.g micro store restore --input dump.gz",
+				Flags: append([]cli.Flag{
+					&cli.StringFlag{
+						Name:    "input",
+						Aliases: []string{"i"},
+						Usage:   "Set the file the snapshot is read from, - for stdin",
+						Value:   "-",
+					},
+				}, storeFlags...),
+				Action: func(ctx *cli.Context) error {
+					restoreRecords(ctx)
+					return nil
+				},
+			},
+			{
+				Name:  "export",
+				Usage: "Write the records as json or csv e.g micro store export --key_prefix user/ --output users.json",
+				Flags: append([]cli.Flag{
+					&cli.StringFlag{
+						Name:    "output",
+						Aliases: []string{"o"},
+						Usage:   "Set the file the records are written to, - for stdout",
+						Value:   "-",
+					},
+					&cli.StringFlag{
+						Name:  "format",
+						Usage: "Set the format; json or csv, defaults to the extension of the file or json",
+					},
+					&cli.StringFlag{
+						Name:  "key_prefix",
+						Usage: "Only export the records with keys starting with the prefix",
+					},
+				}, storeFlags...),
+				Action: func(ctx *cli.Context) error {
+					exportRecords(ctx)
+					return nil
+				},
+			},
+			{
+				Name:  "import",
+				Usage: "Write the records of a json or csv file e.g micro store import users.json --map id=key,data=value",
+				Flags: append([]cli.Flag{
+					&cli.StringFlag{
+						Name:  "format",
+						Usage: "Set the format; json or csv, defaults to the extension of the file or json",
+					},
+					&cli.StringFlag{
+						Name:  "map",
+						Usage: "Map the fields of the file to key, value, encoding, metadata, metadata.<name>, expires, expiry or - e.g id=key,owner=metadata.owner",
+					},
+				}, storeFlags...),
+				Action: func(ctx *cli.Context) error {
+					importRecords(ctx)
+					return nil
+				},
+			},
+			{
+				Name:  "rotate",
+				Usage: "Rewrite the records so they're encrypted with the current key after rotating --store_encryption_key",
+				Flags: storeFlags,
+				Action: func(ctx *cli.Context) error {
+					rotateRecords(ctx)
+					return nil
+				},
+			},
+			{
+				Name:  "sync",
+				Usage: "Copy the records between store backends e.g micro store sync --from cockroach --to file --to_nodes dump.gz",
+				Flags: append([]cli.Flag{
+					&cli.StringFlag{
+						Name:     "from",
+						Usage:    "Set the backend synced from; cockroach, memory, redis, s3, service or file",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "to",
+						Usage:    "Set the backend synced to; cockroach, memory, redis, s3, service or file",
+						Required: true,
+					},
+					&cli.StringSliceFlag{
+						Name:  "from_nodes",
+						Usage: "Set the nodes of the backend synced from, the name of the store service or the path of the file",
+					},
+					&cli.StringSliceFlag{
+						Name:  "to_nodes",
+						Usage: "Set the nodes of the backend synced to, the name of the store service or the path of the file",
+					},
+					&cli.BoolFlag{
+						Name:  "continuous",
+						Usage: "Keep replicating the changes watched on the store service after the records are copied",
+					},
+					&cli.IntFlag{
+						Name:  "concurrency",
+						Usage: "Set how many records are written at once",
+						Value: bulk.Concurrency,
+					},
+				}, storeFlags...),
+				Action: func(ctx *cli.Context) error {
+					syncStores(ctx)
+					return nil
+				},
+			},
+			{
+				Name:  "bench",
+				Usage: "Benchmark the writes, reads and deletes of a store backend e.g micro store bench --backend cockroach --keys 10000",
+				Flags: append([]cli.Flag{
+					&cli.StringFlag{
+						Name:    "backend",
+						Usage:   "Set the backend benchmarked; memory, cockroach, redis or s3",
+						EnvVars: []string{"MICRO_STORE_BACKEND"},
+						Value:   "memory",
+					},
+					&cli.StringFlag{
+						Name:    "nodes",
+						Usage:   "Comma separated list of Nodes to pass to the store backend",
+						EnvVars: []string{"MICRO_STORE_NODES"},
+					},
+					&cli.IntFlag{
+						Name:  "keys",
+						Usage: "Set the number of keys written, read and deleted",
+						Value: bench.Keys,
+					},
+					&cli.IntFlag{
+						Name:  "value_size",
+						Usage: "Set the size in bytes of the values written",
+						Value: bench.ValueSize,
+					},
+					&cli.IntFlag{
+						Name:  "concurrency",
+						Usage: "Set how many operations are run at once",
+						Value: bulk.Concurrency,
+					},
+					&cli.BoolFlag{
+						Name:  "keep",
+						Usage: "Keep the keys written rather than deleting them",
+					},
+				}, storeFlags...),
+				Action: func(ctx *cli.Context) error {
+					benchStore(ctx)
+					return nil
+				},
+			},
+			{
+				Name:  "watch",
+				Usage: "Watch the changes to the keys starting with a prefix e.g micro store watch users/",
+				Flags: storeFlags,
+				Action: func(ctx *cli.Context) error {
+					watchRecords(ctx)
+					return nil
+				},
+			},
+			{
+				Name:  "backends",
+				Usage: "List the nodes of the store backend and which is active",
+				Action: func(ctx *cli.Context) error {
+					backends(ctx)
+					return nil
+				},
+			},
+			{
+				Name:  "usage",
+				Usage: "List the records and bytes stored in each namespace and prefix against their quotas",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "namespace",
+						Usage:   "Only list the usage of the namespace e.g team-a",
+						EnvVars: []string{"MICRO_STORE_CLIENT_NAMESPACE"},
+					},
+				},
+				Action: func(ctx *cli.Context) error {
+					printUsage(ctx)
+					return nil
+				},
+			},
+			{
+				Name:  "mode",
+				Usage: "Get or set the mode of the store; read-write, read-only or maintenance e.g micro store mode maintenance --snapshot",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "reason",
+						Usage: "Set the reason returned with the writes rejected e.g migrating to cockroach",
+					},
+					&cli.BoolFlag{
+						Name:  "snapshot",
+						Usage: "Snapshot the stores to the snapshot target once the writes in flight are drained",
+					},
+					&cli.DurationFlag{
+						Name:  "timeout",
+						Usage: "Set how long to wait for the writes in flight to finish, defaults to 30s",
+					},
+				},
+				Action: func(ctx *cli.Context) error {
+					setMode(ctx)
+					return nil
+				},
+			},
+		},
 	}
 
 	for _, p := range Plugins() {
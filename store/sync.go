@@ -0,0 +1,351 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/micro/cli/v2"
+	"github.com/micro/go-micro/v2/client"
+	"github.com/micro/go-micro/v2/metadata"
+	"github.com/micro/go-micro/v2/store"
+	"github.com/micro/go-micro/v2/store/cockroach"
+	"github.com/micro/go-micro/v2/store/memory"
+	"github.com/micro/micro/v2/internal/bulk"
+	"github.com/micro/micro/v2/store/blob"
+	"github.com/micro/micro/v2/store/handler"
+	pb "github.com/micro/micro/v2/store/proto"
+	"github.com/micro/micro/v2/store/redis"
+	"github.com/micro/micro/v2/store/snapshot"
+)
+
+var (
+	// SyncRetry is how long continuous replication waits before resyncing after an error
+	SyncRetry = 5 * time.Second
+)
+
+// endpoint is a store synced from or to
+type endpoint interface {
+	List() ([]*store.Record, error)
+	Write(*store.Record) error
+	Delete(key string) error
+	Close() error
+}
+
+// newEndpoint returns the endpoint of the backend, nodes are passed to the backend,
+// the name of the store service for service or the path of the snapshot for file
+func newEndpoint(backend string, nodes []string, namespace, prefix string, source bool) (endpoint, error) {
+	switch backend {
+	case "cockroach", "memory", "redis", "s3":
+		st, err := newBackend(backend, nodes, namespace, prefix)
+		if err != nil {
+			return nil, err
+		}
+		return &backendEndpoint{st}, nil
+	case "service":
+		name := Name
+		if len(nodes) > 0 {
+			name = nodes[0]
+		}
+		return newServiceEndpoint(name, namespace, prefix), nil
+	case "file":
+		if len(nodes) == 0 {
+			return nil, fmt.Errorf("the path of the snapshot file is required")
+		}
+		return newFileEndpoint(nodes[0], namespace, prefix, source)
+	}
+
+	return nil, fmt.Errorf("%s is not an implemented store", backend)
+}
+
+// newBackend returns the store backend with the nodes
+func newBackend(backend string, nodes []string, namespace, prefix string) (store.Store, error) {
+	opts := []store.Option{store.Namespace(namespace), store.Prefix(prefix)}
+	if len(nodes) > 0 {
+		opts = append(opts, store.Nodes(nodes...))
+	}
+
+	switch backend {
+	case "cockroach":
+		return cockroach.NewStore(opts...), nil
+	case "memory":
+		return memory.NewStore(opts...), nil
+	case "redis":
+		return redis.NewStore(opts...)
+	case "s3":
+		if len(nodes) == 0 {
+			return nil, fmt.Errorf("the url of the bucket is required")
+		}
+		return blob.NewStore(nodes[0], store.Namespace(namespace), store.Prefix(prefix))
+	}
+
+	return nil, fmt.Errorf("%s is not an implemented store", backend)
+}
+
+// backendEndpoint syncs a store backend directly
+type backendEndpoint struct {
+	store.Store
+}
+
+func (b *backendEndpoint) Close() error {
+	return nil
+}
+
+// serviceEndpoint syncs through the store service
+type serviceEndpoint struct {
+	service pb.StoreService
+	md      metadata.Metadata
+}
+
+func newServiceEndpoint(name, namespace, prefix string) *serviceEndpoint {
+	md := metadata.Metadata{}
+	if len(namespace) > 0 {
+		md["Micro-Namespace"] = namespace
+	}
+	if len(prefix) > 0 {
+		md["Micro-Prefix"] = prefix
+	}
+
+	return &serviceEndpoint{
+		service: pb.NewStoreService(name, client.DefaultClient),
+		md:      md,
+	}
+}
+
+func (s *serviceEndpoint) context() context.Context {
+	return metadata.NewContext(context.Background(), s.md)
+}
+
+func (s *serviceEndpoint) List() ([]*store.Record, error) {
+	stream, err := s.service.List(s.context(), &pb.ListRequest{})
+	if err != nil {
+		return nil, err
+	}
+	defer stream.Close()
+
+	var records []*store.Record
+	for {
+		rsp, err := stream.Recv()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range rsp.Records {
+			records = append(records, &store.Record{
+				Key:    r.Key,
+				Value:  handler.EncodeValue(r.Value, r.Metadata),
+				Expiry: time.Duration(r.Expiry) * time.Second,
+			})
+		}
+	}
+}
+
+func (s *serviceEndpoint) Write(r *store.Record) error {
+	_, err := s.service.Write(s.context(), &pb.WriteRequest{Record: &pb.Record{
+		Key:    r.Key,
+		Value:  r.Value,
+		Expiry: int64(r.Expiry.Seconds()),
+	}})
+	return err
+}
+
+func (s *serviceEndpoint) Delete(key string) error {
+	_, err := s.service.Delete(s.context(), &pb.DeleteRequest{Key: key})
+	return err
+}
+
+func (s *serviceEndpoint) Close() error {
+	return nil
+}
+
+// fileEndpoint syncs from or to a snapshot
+type fileEndpoint struct {
+	file      *os.File
+	namespace string
+	prefix    string
+
+	sync.Mutex
+	w *snapshot.Writer
+}
+
+func newFileEndpoint(path, namespace, prefix string, source bool) (*fileEndpoint, error) {
+	f := &fileEndpoint{namespace: namespace, prefix: prefix}
+
+	var err error
+	if source {
+		f.file, err = os.Open(path)
+	} else {
+		f.file, err = os.Create(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !source {
+		f.w = snapshot.NewWriter(f.file)
+	}
+
+	return f, nil
+}
+
+func (f *fileEndpoint) List() ([]*store.Record, error) {
+	r, err := snapshot.NewReader(f.file)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	var records []*store.Record
+	for {
+		rec, err := r.Next()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, rec.Record())
+	}
+}
+
+func (f *fileEndpoint) Write(r *store.Record) error {
+	f.Lock()
+	defer f.Unlock()
+	return f.w.Write(f.namespace, f.prefix, r)
+}
+
+func (f *fileEndpoint) Delete(key string) error {
+	return fmt.Errorf("records can't be deleted from a snapshot")
+}
+
+func (f *fileEndpoint) Close() error {
+	if f.w != nil {
+		if err := f.w.Close(); err != nil {
+			f.file.Close()
+			return err
+		}
+	}
+	return f.file.Close()
+}
+
+// copyRecords writes the records of the source to the destination
+func copyRecords(from, to endpoint, concurrency int) (*bulk.Summary, error) {
+	records, err := from.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	byKey := make(map[string]*store.Record, len(records))
+	for _, r := range records {
+		keys = append(keys, r.Key)
+		byKey[r.Key] = r
+	}
+	sort.Strings(keys)
+
+	return bulk.Run(keys, concurrency, os.Stderr, func(key string) error {
+		return to.Write(byKey[key])
+	}), nil
+}
+
+// replicate applies the changes watched on the store service to the destination until the watch fails
+func replicate(from *serviceEndpoint, to endpoint, concurrency int) error {
+	stream, err := from.service.Watch(from.context(), &pb.WatchRequest{})
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	// changes made while copying are applied after
+	summary, err := copyRecords(from, to, concurrency)
+	if err != nil {
+		return err
+	}
+	summary.Print(os.Stdout)
+	fmt.Println("Replicating changes")
+
+	for {
+		ev, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		key := ev.Record.GetKey()
+
+		switch ev.Type {
+		case handler.Create, handler.Update:
+			err = to.Write(&store.Record{
+				Key:    key,
+				Value:  handler.EncodeValue(ev.Record.GetValue(), ev.Record.GetMetadata()),
+				Expiry: time.Duration(ev.Record.GetExpiry()) * time.Second,
+			})
+		case handler.Delete, handler.Expire:
+			err = to.Delete(key)
+			if err == store.ErrNotFound {
+				err = nil
+			}
+		}
+		if err != nil {
+			fmt.Printf("Failed to replicate the %s of %s: %v\n", ev.Type, key, err)
+		}
+	}
+}
+
+// syncStores copies the records from one store backend to another, with --continuous
+// the changes to the store service are replicated until interrupted
+func syncStores(ctx *cli.Context) {
+	namespace := ctx.String("namespace")
+	prefix := ctx.String("prefix")
+
+	from, err := newEndpoint(ctx.String("from"), ctx.StringSlice("from_nodes"), namespace, prefix, true)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	defer from.Close()
+
+	to, err := newEndpoint(ctx.String("to"), ctx.StringSlice("to_nodes"), namespace, prefix, false)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if !ctx.Bool("continuous") {
+		summary, err := copyRecords(from, to, ctx.Int("concurrency"))
+		if err == nil {
+			err = to.Close()
+		}
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		summary.Print(os.Stdout)
+		if len(summary.Failed) > 0 {
+			os.Exit(1)
+		}
+		return
+	}
+	defer to.Close()
+
+	src, ok := from.(*serviceEndpoint)
+	if !ok {
+		fmt.Println("Continuous replication requires syncing from the store service, use --from service")
+		os.Exit(1)
+	}
+	if _, ok := to.(*fileEndpoint); ok {
+		fmt.Println("Continuous replication to a snapshot file is not supported")
+		os.Exit(1)
+	}
+
+	for {
+		err := replicate(src, to, ctx.Int("concurrency"))
+		fmt.Printf("Replication stopped: %v, resyncing in %v\n", err, SyncRetry)
+		time.Sleep(SyncRetry)
+	}
+}
@@ -5,8 +5,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/micro/cli/v2"
+	"github.com/micro/micro/v2/internal/namespace"
 	"github.com/micro/micro/v2/internal/token"
 )
 
@@ -105,8 +107,47 @@ func list(ctx *cli.Context) error {
 	return nil
 }
 
+func generateNamespace(ctx *cli.Context) error {
+	ns := ctx.String("namespace")
+	if len(ns) == 0 {
+		// TODO return err
+		fmt.Println("Namespace is blank (specify --namespace)")
+		os.Exit(1)
+	}
+
+	t, err := namespace.Generate(ns, ctx.String("account"), ctx.Duration("ttl"))
+	if err != nil {
+		// TODO return err
+		fmt.Println("Token generation failed:", err)
+		os.Exit(1)
+	}
+	fmt.Println("Your namespace token (set as MICRO_NAMESPACE_TOKEN env var):")
+	fmt.Println(t)
+	return nil
+}
+
 func tokenCommands() []*cli.Command {
 	return []*cli.Command{
+		{
+			Name:   "namespace",
+			Usage:  "Generate a token scoped to a namespace (specify --namespace), requires MICRO_NAMESPACE_KEY",
+			Action: generateNamespace,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "namespace",
+					Usage: "Namespace the token is scoped to e.g foo, or * for all namespaces",
+				},
+				&cli.StringFlag{
+					Name:  "account",
+					Usage: "Account the token identifies e.g to approve config changes",
+				},
+				&cli.DurationFlag{
+					Name:  "ttl",
+					Usage: "Time until the token expires",
+					Value: time.Hour * 24 * 7,
+				},
+			},
+		},
 		{
 			Name:   "list",
 			Usage:  "List tokens",
@@ -0,0 +1,89 @@
+package verify
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+)
+
+const (
+	// Pass is the status of a check that succeeded
+	Pass = "PASS"
+	// Fail is the status of a check that returned an error
+	Fail = "FAIL"
+	// Skip is the status of a check not run as a check it requires failed
+	Skip = "SKIP"
+)
+
+// check is a step of the verification
+type check struct {
+	name string
+	// names of the checks that must pass for it to run
+	requires []string
+	fn       func() error
+}
+
+// result of running a check
+type result struct {
+	name   string
+	status string
+	took   time.Duration
+	err    error
+}
+
+// run the checks in order skipping those whose requirements didn't pass
+func run(checks []*check) []*result {
+	passed := make(map[string]bool)
+	results := make([]*result, 0, len(checks))
+
+	for _, c := range checks {
+		res := &result{name: c.name, status: Pass}
+
+		for _, r := range c.requires {
+			if !passed[r] {
+				res.status = Skip
+				res.err = fmt.Errorf("requires %s", r)
+				break
+			}
+		}
+
+		if res.status != Skip {
+			start := time.Now()
+			if err := c.fn(); err != nil {
+				res.status = Fail
+				res.err = err
+			}
+			res.took = time.Since(start)
+		}
+
+		passed[c.name] = res.status == Pass
+		results = append(results, res)
+	}
+
+	return results
+}
+
+// failed returns true if any check didn't pass
+func failed(results []*result) bool {
+	for _, r := range results {
+		if r.status != Pass {
+			return true
+		}
+	}
+	return false
+}
+
+// printResults prints the pass/fail matrix of the results
+func printResults(w io.Writer, results []*result) {
+	writer := tabwriter.NewWriter(w, 0, 8, 1, '\t', tabwriter.AlignRight)
+	fmt.Fprintln(writer, "CHECK\tRESULT\tTIME\tERROR")
+	for _, r := range results {
+		var err string
+		if r.err != nil {
+			err = r.err.Error()
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", r.name, r.status, r.took.Round(time.Millisecond), err)
+	}
+	writer.Flush()
+}
@@ -0,0 +1,47 @@
+package verify
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	var ran []string
+	fn := func(name string, err error) func() error {
+		return func() error {
+			ran = append(ran, name)
+			return err
+		}
+	}
+
+	results := run([]*check{
+		{name: "deploy", fn: fn("deploy", nil)},
+		{name: "register", requires: []string{"deploy"}, fn: fn("register", errors.New("timeout"))},
+		{name: "call", requires: []string{"register"}, fn: fn("call", nil)},
+		{name: "store", fn: fn("store", nil)},
+		{name: "teardown", requires: []string{"deploy"}, fn: fn("teardown", nil)},
+	})
+
+	if got := strings.Join(ran, ","); got != "deploy,register,store,teardown" {
+		t.Fatalf("expected the call to be skipped got %s", got)
+	}
+
+	expect := []string{Pass, Fail, Skip, Pass, Pass}
+	for i, r := range results {
+		if r.status != expect[i] {
+			t.Errorf("%s: expected %s got %s", r.name, expect[i], r.status)
+		}
+	}
+
+	if !failed(results) {
+		t.Fatal("expected the results to have failed")
+	}
+
+	var b bytes.Buffer
+	printResults(&b, results)
+	if !strings.Contains(b.String(), "requires register") {
+		t.Fatalf("expected the skipped check to be explained got %s", b.String())
+	}
+}
@@ -0,0 +1,45 @@
+package verify
+
+import (
+	"context"
+
+	"github.com/micro/cli/v2"
+	"github.com/micro/go-micro/v2"
+	"github.com/micro/go-micro/v2/util/log"
+)
+
+// Verify is the handler of the sample service
+type Verify struct{}
+
+// Request is echoed by the sample service
+type Request struct {
+	Message string `json:"message"`
+}
+
+// Response echoes the message of the request
+type Response struct {
+	Message string `json:"message"`
+}
+
+// Call echoes the message
+func (v *Verify) Call(ctx context.Context, req *Request, rsp *Response) error {
+	rsp.Message = req.Message
+	return nil
+}
+
+// runService runs the sample service deployed by micro verify
+func runService(ctx *cli.Context, srvOpts ...micro.Option) {
+	log.Name("verify")
+
+	name := ServiceName
+	if n := ctx.String("server_name"); len(n) > 0 {
+		name = n
+	}
+
+	service := micro.NewService(append(srvOpts, micro.Name(name))...)
+	service.Server().Handle(service.Server().NewHandler(new(Verify)))
+
+	if err := service.Run(); err != nil {
+		log.Fatal(err)
+	}
+}
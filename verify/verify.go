@@ -0,0 +1,330 @@
+// Package verify is an end-to-end smoke test of a micro installation
+package verify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/micro/cli/v2"
+	"github.com/micro/go-micro/v2"
+	"github.com/micro/go-micro/v2/broker"
+	"github.com/micro/go-micro/v2/client"
+	"github.com/micro/go-micro/v2/config/cmd"
+	mp "github.com/micro/go-micro/v2/config/source/service/proto"
+	"github.com/micro/go-micro/v2/runtime"
+	rs "github.com/micro/go-micro/v2/runtime/service"
+	pb "github.com/micro/micro/v2/store/proto"
+)
+
+var (
+	// ServiceName of the sample service, it's in the api namespace to be
+	// callable through the api gateway
+	ServiceName = "go.micro.api.verify"
+	// APIAddress of the api gateway the sample service is called through
+	APIAddress = "http://localhost:8080"
+	// ProxyAddress of the proxy the sample service is called through
+	ProxyAddress = "localhost:8081"
+	// Timeout is how long the sample service is given to register
+	Timeout = time.Minute
+
+	// StoreName of the store service
+	StoreName = "go.micro.store"
+	// ConfigName of the config service
+	ConfigName = "go.micro.config"
+)
+
+// verifier runs the checks against the installation
+type verifier struct {
+	id      string
+	name    string
+	command []string
+	api     string
+	proxy   string
+	timeout time.Duration
+	runtime runtime.Runtime
+}
+
+func (v *verifier) service() *runtime.Service {
+	return &runtime.Service{
+		Name:    v.name,
+		Version: v.id,
+	}
+}
+
+// deploy the sample service through the runtime
+func (v *verifier) deploy() error {
+	var env []string
+	for _, evar := range os.Environ() {
+		if strings.HasPrefix(evar, "MICRO_") {
+			env = append(env, evar)
+		}
+	}
+	return v.runtime.Create(v.service(), runtime.WithCommand(v.command...), runtime.WithEnv(env))
+}
+
+// register waits for the sample service to be registered
+func (v *verifier) register() error {
+	reg := *cmd.DefaultOptions().Registry
+	deadline := time.Now().Add(v.timeout)
+
+	for {
+		services, err := reg.GetService(v.name)
+		if err == nil {
+			for _, s := range services {
+				if s.Version == v.id && len(s.Nodes) > 0 {
+					return nil
+				}
+			}
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%s not registered after %v", v.name, v.timeout)
+		}
+		time.Sleep(time.Second)
+	}
+}
+
+// call the sample service checking the message is echoed
+func (v *verifier) call(opts ...client.CallOption) error {
+	req := client.NewRequest(v.name, "Verify.Call", &Request{Message: v.id}, client.WithContentType("application/json"))
+	rsp := new(Response)
+	if err := client.Call(context.Background(), req, rsp, opts...); err != nil {
+		return err
+	}
+	if rsp.Message != v.id {
+		return fmt.Errorf("expected message %s got %s", v.id, rsp.Message)
+	}
+	return nil
+}
+
+// api calls the sample service through the api gateway
+func (v *verifier) callAPI() error {
+	path := v.name[strings.LastIndex(v.name, ".")+1:]
+	url := strings.TrimSuffix(v.api, "/") + "/" + path + "/call"
+
+	b, _ := json.Marshal(&Request{Message: v.id})
+	hc := &http.Client{Timeout: v.timeout}
+	resp, err := hc.Post(url, "application/json", bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned %s: %s", url, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	rsp := new(Response)
+	if err := json.Unmarshal(body, rsp); err != nil {
+		return err
+	}
+	if rsp.Message != v.id {
+		return fmt.Errorf("expected message %s got %s", v.id, rsp.Message)
+	}
+	return nil
+}
+
+// broker publishes a message and waits to consume it
+func (v *verifier) broker() error {
+	b := *cmd.DefaultOptions().Broker
+	if err := b.Connect(); err != nil {
+		return err
+	}
+
+	topic := "go.micro.verify." + v.id
+	recv := make(chan []byte, 1)
+
+	sub, err := b.Subscribe(topic, func(p broker.Event) error {
+		select {
+		case recv <- p.Message().Body:
+		default:
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	defer sub.Unsubscribe()
+
+	if err := b.Publish(topic, &broker.Message{Body: []byte(v.id)}); err != nil {
+		return err
+	}
+
+	select {
+	case body := <-recv:
+		if string(body) != v.id {
+			return fmt.Errorf("expected message %s got %s", v.id, body)
+		}
+		return nil
+	case <-time.After(v.timeout):
+		return fmt.Errorf("message not consumed after %v", v.timeout)
+	}
+}
+
+// store writes, reads and deletes a key
+func (v *verifier) store() error {
+	st := pb.NewStoreService(StoreName, client.DefaultClient)
+	ctx := context.Background()
+	key := "verify/" + v.id
+
+	if _, err := st.Write(ctx, &pb.WriteRequest{Record: &pb.Record{Key: key, Value: []byte(v.id)}}); err != nil {
+		return err
+	}
+	defer st.Delete(ctx, &pb.DeleteRequest{Key: key})
+
+	rsp, err := st.Read(ctx, &pb.ReadRequest{Key: key})
+	if err != nil {
+		return err
+	}
+	if len(rsp.Records) == 0 || string(rsp.Records[0].Value) != v.id {
+		return fmt.Errorf("expected value %s for key %s", v.id, key)
+	}
+	return nil
+}
+
+// config writes and reads a value in a namespace of its own
+func (v *verifier) config() error {
+	cfg := mp.NewConfigService(ConfigName, client.DefaultClient)
+	ctx := context.Background()
+	namespace := "verify-" + v.id
+	value, _ := json.Marshal(v.id)
+
+	_, err := cfg.Create(ctx, &mp.CreateRequest{Change: &mp.Change{
+		Key:       namespace,
+		ChangeSet: &mp.ChangeSet{Data: []byte(`{}`), Format: "json", Source: "verify"},
+	}})
+	if err != nil {
+		return err
+	}
+	defer cfg.Delete(ctx, &mp.DeleteRequest{Change: &mp.Change{Key: namespace}})
+
+	_, err = cfg.Update(ctx, &mp.UpdateRequest{Change: &mp.Change{
+		Key:       namespace,
+		Path:      "value",
+		ChangeSet: &mp.ChangeSet{Data: value, Format: "json", Source: "verify"},
+	}})
+	if err != nil {
+		return err
+	}
+
+	rsp, err := cfg.Read(ctx, &mp.ReadRequest{Key: namespace, Path: "value"})
+	if err != nil {
+		return err
+	}
+	if got := rsp.GetChange().GetChangeSet().GetData(); !bytes.Equal(bytes.TrimSpace(got), value) {
+		return fmt.Errorf("expected value %s got %s", value, got)
+	}
+	return nil
+}
+
+// teardown deletes the sample service
+func (v *verifier) teardown() error {
+	return v.runtime.Delete(v.service())
+}
+
+// Run the checks against the installation printing the pass/fail matrix
+func Run(ctx *cli.Context) {
+	command := strings.Fields(ctx.String("command"))
+	if len(command) == 0 {
+		exe, err := os.Executable()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		command = []string{exe, "verify", "service"}
+	}
+
+	v := &verifier{
+		id:      uuid.New().String(),
+		name:    ServiceName,
+		command: command,
+		api:     APIAddress,
+		proxy:   ProxyAddress,
+		timeout: Timeout,
+		runtime: rs.NewRuntime(),
+	}
+	if a := ctx.String("api_address"); len(a) > 0 {
+		v.api = a
+	}
+	if a := ctx.String("proxy_address"); len(a) > 0 {
+		v.proxy = a
+	}
+	if t := ctx.Duration("timeout"); t > 0 {
+		v.timeout = t
+	}
+
+	fmt.Printf("Verifying the installation with %s version %s\n", v.name, v.id)
+
+	results := run([]*check{
+		{name: "deploy", fn: v.deploy},
+		{name: "register", requires: []string{"deploy"}, fn: v.register},
+		{name: "call", requires: []string{"register"}, fn: func() error { return v.call() }},
+		{name: "proxy", requires: []string{"register"}, fn: func() error { return v.call(client.WithAddress(v.proxy)) }},
+		{name: "api", requires: []string{"register"}, fn: v.callAPI},
+		{name: "broker", fn: v.broker},
+		{name: "store", fn: v.store},
+		{name: "config", fn: v.config},
+		{name: "teardown", requires: []string{"deploy"}, fn: v.teardown},
+	})
+
+	printResults(os.Stdout, results)
+
+	if failed(results) {
+		os.Exit(1)
+	}
+}
+
+func Commands(options ...micro.Option) []*cli.Command {
+	command := &cli.Command{
+		Name:  "verify",
+		Usage: "Verify an installation by deploying a sample service and checking the runtime, proxy, api, broker, store and config",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "command",
+				Usage: "Set the command the runtime runs the sample service with, defaults to this binary e.g /usr/local/bin/micro verify service",
+			},
+			&cli.StringFlag{
+				Name:    "api_address",
+				Usage:   "Set the address of the api gateway e.g http://localhost:8080",
+				EnvVars: []string{"MICRO_VERIFY_API_ADDRESS"},
+			},
+			&cli.StringFlag{
+				Name:    "proxy_address",
+				Usage:   "Set the address of the proxy e.g localhost:8081",
+				EnvVars: []string{"MICRO_VERIFY_PROXY_ADDRESS"},
+			},
+			&cli.DurationFlag{
+				Name:  "timeout",
+				Usage: "Set how long the sample service is given to register and messages to be consumed e.g 2m",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			Run(ctx)
+			return nil
+		},
+		Subcommands: []*cli.Command{
+			{
+				Name:   "service",
+				Usage:  "Run the sample service deployed by micro verify",
+				Hidden: true,
+				Action: func(ctx *cli.Context) error {
+					runService(ctx, options...)
+					return nil
+				},
+			},
+		},
+	}
+
+	return []*cli.Command{command}
+}